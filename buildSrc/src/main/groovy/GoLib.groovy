@@ -3,6 +3,7 @@ import org.gradle.api.DefaultTask
 import org.gradle.api.file.FileCollection
 import org.gradle.api.tasks.Input
 import org.gradle.api.tasks.InputFiles
+import org.gradle.api.tasks.Optional
 import org.gradle.api.tasks.OutputFile
 import org.gradle.api.tasks.Internal
 import org.gradle.api.tasks.TaskAction
@@ -19,6 +20,10 @@ class GoLib extends DefaultTask {
     @InputFiles
     FileCollection sources
 
+    @Input
+    @Optional
+    String ldflags
+
     @Internal
     File outputDir
 
@@ -58,6 +63,10 @@ class GoLib extends DefaultTask {
         this.mainFile = mainFile
     }
 
+    void ldflags(String ldflags) {
+        this.ldflags = ldflags
+    }
+
     @TaskAction
     void exec() {
 
@@ -65,8 +74,11 @@ class GoLib extends DefaultTask {
             'go', 'build',
             '-o', outputLib,
             '-buildmode=c-shared',
-            "${mainFile}"
         ]
+        if (ldflags != null) {
+            cmd += ["-ldflags=${ldflags}"]
+        }
+        cmd += ["${mainFile}"]
 
         ExecResult execResult = project.exec {
             commandLine cmd
@@ -50,6 +50,7 @@ var (
 	EventWithDataSoliditySignature     = pdm("EventWithData.soliditySignature", "A Solidity style description of the event and parameters, including parameter names and whether they are indexed")
 	EventWithDataAddress               = pdm("EventWithData.address", "The address of the smart contract that emitted this event")
 	EventWithDataData                  = pdm("EventWithData.data", "JSON formatted data from the event")
+	EventWithDataRemoved               = pdm("EventWithData.removed", "Set when the event was previously delivered unconfirmed from the head of the chain, and the block containing it has since been replaced by a re-org")
 )
 
 // pldapi/keymgr.go
@@ -60,8 +61,23 @@ var (
 	KeyMappingIdentifier               = pdm("KeyMapping.identifier", "The full identifier used to look up this key")
 	KeyMappingWallet                   = pdm("KeyMapping.wallet", "The name of the wallet containing this key")
 	KeyMappingKeyHandle                = pdm("KeyMapping.keyHandle", "The handle within the wallet containing the key")
+	KeyMappingRotation                 = pdm("KeyMapping.rotation", "The number of times the key for this identifier has been rotated")
+	KeyMappingDisabled                 = pdm("KeyMapping.disabled", "If set, the time the key was disabled for new signing operations")
 	KeyMappingWithPathPath             = pdm("KeyMappingWithPath.path", "The full path including the leaf that is the identifier")
 	KeyMappingAndVerifierVerifier      = pdm("KeyMappingAndVerifier.verifier", "The verifier associated with this key mapping")
+	KeyMappingAndVerifierRetired       = pdm("KeyMappingAndVerifier.retired", "If set, the time the key this verifier belongs to was retired by a rotation")
+	KeyRotationVerifiers               = pdm("KeyRotation.verifiers", "The verifiers of the new key")
+	KeyRotationRetiredVerifiers        = pdm("KeyRotation.retiredVerifiers", "The verifiers of the retired key, which remain available for reverse lookup")
+	KeySigningAuditEntrySequence       = pdm("KeySigningAuditEntry.sequence", "Local sequence number of the audit entry")
+	KeySigningAuditEntryCreated        = pdm("KeySigningAuditEntry.created", "Time the signing operation was performed")
+	KeySigningAuditEntryIdentifier     = pdm("KeySigningAuditEntry.identifier", "The identifier of the key used for signing")
+	KeySigningAuditEntryWallet         = pdm("KeySigningAuditEntry.wallet", "The name of the wallet containing the key")
+	KeySigningAuditEntryKeyHandle      = pdm("KeySigningAuditEntry.keyHandle", "The handle within the wallet of the key used for signing")
+	KeySigningAuditEntryAlgorithm      = pdm("KeySigningAuditEntry.algorithm", "The signing algorithm")
+	KeySigningAuditEntryVerifier       = pdm("KeySigningAuditEntry.verifier", "The verifier of the key used for signing")
+	KeySigningAuditEntryPayloadType    = pdm("KeySigningAuditEntry.payloadType", "The type of payload that was signed")
+	KeySigningAuditEntryPayloadHash    = pdm("KeySigningAuditEntry.payloadHash", "SHA-256 hash of the payload that was signed")
+	KeySigningAuditEntryError          = pdm("KeySigningAuditEntry.error", "The error, if the signing operation was rejected or failed")
 	KeyVerifierWithKeyRefKeyIdentifier = pdm("KeyVerifierWithKeyRef.keyIdentifier", "The identifier of the key associated with this verifier")
 	KeyVerifierVerifier                = pdm("KeyVerifier.verifier", "The verifier value")
 	KeyVerifierType                    = pdm("KeyVerifier.type", "The type of verifier")
@@ -121,10 +137,14 @@ var (
 	TransactionTo                                           = pdm("Transaction.to", "Target contract address, or null for a deploy")
 	TransactionData                                         = pdm("Transaction.data", "Pre-encoded array with/without function selector, array, or object input")
 	TransactionInputDependsOn                               = pdm("TransactionInput.dependsOn", "Transactions that must be mined on the blockchain successfully before this transaction submits")
+	TransactionInputNotBefore                               = pdm("TransactionInput.notBefore", "The transaction is held until this time before it is processed")
+	TransactionInputExpiresAt                               = pdm("TransactionInput.expiresAt", "If the transaction has not been mined by this time, it is finalized with a failure receipt")
 	TransactionInputABI                                     = pdm("TransactionInput.abi", "Application Binary Interface (ABI) definition - required if abiReference not supplied")
 	TransactionInputBytecode                                = pdm("TransactionInput.bytecode", "Bytecode prepended to encoded data inputs for deploy transactions")
 	TransactionCallDataFormat                               = pdm("TransactionCall.dataFormat", "How call data should be serialized into JSON once decoded using the ABI function definition")
 	TransactionFullDependsOn                                = pdm("TransactionFull.dependsOn", "Transactions registered as dependencies when the transaction was created")
+	TransactionFullNotBefore                                = pdm("TransactionFull.notBefore", "The time the transaction was held until before processing")
+	TransactionFullExpiresAt                                = pdm("TransactionFull.expiresAt", "The deadline for the transaction to be mined, after which it is finalized with a failure receipt")
 	TransactionFullReceipt                                  = pdm("TransactionFull.receipt", "Transaction receipt data - available if the transaction has reached a final state")
 	TransactionFullPublic                                   = pdm("TransactionFull.public", "List of public transactions associated with this transaction")
 	TransactionFullHistory                                  = pdm("TransactionFull.history", "List of values that have previously been provided for this transaction")
@@ -144,6 +164,11 @@ var (
 	TransactionReceiptFullStates                            = pdm("TransactionReceiptFull.states", "The state receipt for the transaction (private transactions only)")
 	TransactionReceiptFullDomainReceipt                     = pdm("TransactionReceiptFull.domainReceipt", "The domain receipt for the transaction (private transaction only)")
 	TransactionReceiptFullDomainReceiptError                = pdm("TransactionReceiptFull.domainReceiptError", "Contains the error if it was not possible to obtain the domain receipt for a private transaction")
+	TransactionExportRecordSequence                         = pdm("TransactionExportRecord.sequence", "The sequence of the receipt, which is the cursor for resuming an export")
+	TransactionExportRecordReceipt                          = pdm("TransactionExportRecord.receipt", "The receipt for the transaction")
+	TransactionExportRecordTransaction                      = pdm("TransactionExportRecord.transaction", "The transaction, if it was submitted to this node")
+	TransactionExportRecordPublic                           = pdm("TransactionExportRecord.public", "Public transactions associated with the transaction, including their submissions")
+	TransactionExportRecordStates                           = pdm("TransactionExportRecord.states", "The states produced and consumed by a private transaction")
 	TransactionActivityRecordTime                           = pdm("TransactionActivityRecord.time", "Time the record occurred")
 	TransactionActivityRecordMessage                        = pdm("TransactionActivityRecord.message", "Activity message")
 	TransactionDependenciesDependsOn                        = pdm("TransactionDependencies.dependsOn", "Transactions that this transaction depends on")
@@ -166,8 +191,11 @@ var (
 	TransactionReceiptFiltersSequenceAbove                  = pdm("TransactionReceiptFilters.sequenceAbove", "Only deliver receipts above a certain sequence (rather than from the beginning of indexing of the chain)")
 	TransactionReceiptFiltersType                           = pdm("TransactionReceiptFilters.type", "Only deliver receipts for one transaction type (public/private)")
 	TransactionReceiptFiltersDomain                         = pdm("TransactionReceiptFilters.domain", "Only deliver receipts for an individual domain (only valid with type=private)")
+	TransactionReceiptFiltersQuery                          = pdm("TransactionReceiptFilters.query", "Only deliver receipts matching this query. Fields: sequence, domain, success, transactionHash, blockNumber, source, contractAddress, failureMessage, and for transactions submitted to this node: type, from, to, function, abiReference, idempotencyKey")
 	TransactionReceiptOptionsDomainReceipts                 = pdm("TransactionReceiptOptions.domainReceipts", "When true, a full domain receipt will be generated for each event with complete state data")
 	TransactionReceiptOptionsIncompleteStateReceiptBehavior = pdm("TransactionReceiptOptions.incompleteStateReceiptBehavior", "When set to 'block_contract', if a transaction with incomplete state data is detected then delivery of all receipts on that individual smart contract address will pause until the missing state arrives. Receipts for other contract addresses continue to be delivered")
+	TransactionReceiptOptionsWebhook                        = pdm("TransactionReceiptOptions.webhook", "When set, each batch of receipts is delivered as an HTTP POST to the configured webhook. The webhook shares batches with any other receivers on the listener")
+	TransactionReceiptOptionsMaxRedeliveries                = pdm("TransactionReceiptOptions.maxRedeliveries", "The maximum number of times delivery of a batch is retried before it is moved to the dead-letter store, and the listener moves on to the next batch. When not set, delivery is retried indefinitely")
 	BlockchainEventListenerName                             = pdm("BlockchainEventListener.name", "Unique name for the blockchain event listener")
 	BlockchainEventListenerCreated                          = pdm("BlockchainEventListener.created", "Time the listener was created")
 	BlockchainEventListenerStarted                          = pdm("BlockchainEventListener.started", "If the listener is started - can be set to false to disable delivery server-side")
@@ -176,8 +204,34 @@ var (
 	BlockchainEventListenerOptionsBatchSize                 = pdm("BlockchainEventListenerOptions.batchSize", "The maximum number of events to deliver in each batch")
 	BlockchainEventListenerOptionsBatchTimeout              = pdm("BlockchainEventListenerOptions.batchTimeout", "The maximum time to wait for a batch to fill before delivering")
 	BlockchainEventListenerOptionsFromBlock                 = pdm("BlockchainEventListenerOptions.fromBlock", "The block number from which to start listenening for events, or 'latest' to start from the latest block")
+	BlockchainEventListenerOptionsWebhook                   = pdm("BlockchainEventListenerOptions.webhook", "When set, each batch of events is delivered as an HTTP POST to the configured webhook. The webhook shares batches with any other receivers on the listener")
+	BlockchainEventListenerOptionsMaxRedeliveries           = pdm("BlockchainEventListenerOptions.maxRedeliveries", "The maximum number of times delivery of a batch is retried before it is moved to the dead-letter store, and the listener moves on to the next batch. When not set, delivery is retried indefinitely")
+	BlockchainEventListenerOptionsUnconfirmed               = pdm("BlockchainEventListenerOptions.unconfirmed", "When true, events are delivered as soon as their block is seen at the head of the chain, rather than waiting for the configured number of confirmations. If a block is then replaced by a re-org, each event previously delivered from it is delivered again with 'removed' set. Events are not delivered a second time when their block is confirmed")
+	BlockchainEventListenerOptionsDecodeFailurePolicy       = pdm("BlockchainEventListenerOptions.decodeFailurePolicy", "What to do with an event that matches the address and topic filters of a source, and the signature of an event in its ABI, but cannot be decoded against any source. 'skip' (the default) does not deliver it, 'deliver' delivers it without data, and 'halt' stops the listener before the event until its checkpoint is reset")
+	ListenerWebhookURL                                      = pdm("ListenerWebhook.url", "The http or https URL to POST each batch to. The checkpoint of the listener only moves forwards when the webhook returns a 2xx response, and failed deliveries are retried with backoff")
+	ListenerWebhookHeaders                                  = pdm("ListenerWebhook.headers", "Additional HTTP headers to set on each request")
+	ListenerWebhookHMACSecret                               = pdm("ListenerWebhook.hmacSecret", "When set, each request contains an X-Paladin-Signature header set to sha256= followed by the hex encoded HMAC-SHA256 of the request body, using this secret as the key. Redacted when the listener is returned by the API")
+	ListenerWebhookRequestTimeout                           = pdm("ListenerWebhook.requestTimeout", "The timeout for each HTTP request")
+	ListenerWebhookTLS                                      = pdm("ListenerWebhook.tls", "TLS configuration for https webhook URLs")
+	ListenerWebhookTLSCA                                    = pdm("ListenerWebhookTLS.ca", "PEM encoded CA certificates to verify the server certificate. The system CAs are used when not set")
+	ListenerWebhookTLSCert                                  = pdm("ListenerWebhookTLS.cert", "PEM encoded client certificate for mutual TLS")
+	ListenerWebhookTLSKey                                   = pdm("ListenerWebhookTLS.key", "PEM encoded private key for the client certificate. Redacted when the listener is returned by the API")
+	ListenerWebhookTLSInsecureSkipHostVerify                = pdm("ListenerWebhookTLS.insecureSkipHostVerify", "Skip verification of the server certificate - for use in test environments only")
+	ListenerDeadLetterID                                    = pdm("ListenerDeadLetter.id", "Unique identifier for the dead-lettered batch")
+	ListenerDeadLetterListener                              = pdm("ListenerDeadLetter.listener", "The name of the listener")
+	ListenerDeadLetterCreated                               = pdm("ListenerDeadLetter.created", "Time the batch was moved to the dead-letter store")
+	ListenerDeadLetterBatchID                               = pdm("ListenerDeadLetter.batchId", "The ID of the batch when delivery was last attempted")
+	ListenerDeadLetterAttempts                              = pdm("ListenerDeadLetter.attempts", "The total number of delivery attempts, including any replays")
+	ListenerDeadLetterLastError                             = pdm("ListenerDeadLetter.lastError", "The error from the last delivery attempt")
+	ListenerDeadLetterReplay                                = pdm("ListenerDeadLetter.replay", "True when a replay has been requested, and the listener has not yet delivered the batch")
+	ListenerDeadLetterBatch                                 = pdm("ListenerDeadLetter.batch", "The receipts, events or messages of the batch, as they are delivered by the listener")
 	BlockchainEventListenerSourceABI                        = pdm("BlockchainEventListenerSource.abi", "The ABI containing events to listen for")
 	BlockchainEventListenerSourceAddress                    = pdm("BlockchainEventListenerSource.address", "The address to listen for events from")
+	BlockchainEventListenerSourceTopics                     = pdm("BlockchainEventListenerSource.topics", "Filters on the indexed parameters of the events, by position after the event signature. An event matches if its topic in each position is one of the values for that position, and an empty list matches any value. Values shorter than 32 bytes, such as addresses, are left padded with zeros")
+	BlockchainEventListenerSourceFactory                    = pdm("BlockchainEventListenerSource.factory", "Listen for events from any contract deployed by a factory contract, instead of a single address")
+	BlockchainEventListenerFactoryAddress                   = pdm("BlockchainEventListenerFactory.address", "The address of the factory contract")
+	BlockchainEventListenerFactoryEvent                     = pdm("BlockchainEventListenerFactory.event", "The ABI of the event the factory emits for each contract it deploys")
+	BlockchainEventListenerFactoryAddressParam              = pdm("BlockchainEventListenerFactory.addressParam", "The name of the address parameter in the factory event that contains the address of the deployed contract")
 	BlockchainEventListenerStatusCatchup                    = pdm("BlockchainEventListenerStatus.catchup", "Whether the event listener is catching up to the latest block")
 	BlockcgainEventListenerStatusCheckpoint                 = pdm("BlockchainEventListenerStatus.checkpoint", "The checkpoint for the event listener")
 	BlockchainEventListenerCheckpointBlockNumber            = pdm("BlockchainEventListenerCheckpoint.blockNumber", "The last block fully processed by the event listener")
@@ -264,6 +318,13 @@ var (
 	RegistryPropertyEntryID               = pdm("RegistryProperty.entryId", "The ID of the entry this property is associated with")
 	RegistryPropertyName                  = pdm("RegistryProperty.name", "The name of the property")
 	RegistryPropertyValue                 = pdm("RegistryProperty.value", "The value of the property")
+	RegistryPropertyChangeSequence        = pdm("RegistryPropertyChange.sequence", "Local sequence number of the property change")
+	RegistryPropertyChangeCreated         = pdm("RegistryPropertyChange.created", "Time the property change was indexed by this node")
+	RegistryPropertyChangeRegistry        = pdm("RegistryPropertyChange.registry", "The registry that maintains this record")
+	RegistryPropertyChangeEntryID         = pdm("RegistryPropertyChange.entryId", "The ID of the entry this property is associated with")
+	RegistryPropertyChangeName            = pdm("RegistryPropertyChange.name", "The name of the property. Revocation and reinstatement of the entry itself are recorded under the name '.active'")
+	RegistryPropertyChangeValue           = pdm("RegistryPropertyChange.value", "The value of the property after the change")
+	RegistryPropertyChangeActive          = pdm("RegistryPropertyChange.active", "Whether the property was active after the change")
 	OnChainLocationBlockNumber            = pdm("OnChainLocation.blockNumber", "For Ethereum blockchain backed registries, this is the block number where the registry entry/property was set")
 	OnChainLocationTransactionIndex       = pdm("OnChainLocation.transactionIndex", "The transaction index within the block")
 	OnChainLocationLogIndex               = pdm("OnChainLocation.logIndex", "The log index within the transaction of the event")
@@ -278,6 +339,7 @@ var (
 	PeerInfoOutbound          = pdm("PeerInfo.outbound", "Transport specific information about an established outbound connection to the peer. Omitted if the peer does not have an established outbound connection")
 	PeerInfoOutboundTransport = pdm("PeerInfo.outboundTransport", "The name of the transport selected for outbound connection to the peer. Omitted if no attempt to send data has occurred for this peer")
 	PeerInfoOutboundError     = pdm("PeerInfo.outboundError", "Contains an error if attempting to send data, and the transport connection failed")
+	PeerInfoReliable          = pdm("PeerInfo.reliable", "Summary of the outbound reliable messages to this peer that have not yet been acknowledged")
 
 	PeerStatsSentMsgs            = pdm("PeerStats.sentMsgs", "Count of messages sent since activation of this peer")
 	PeerStatsReceivedMsgs        = pdm("PeerStats.receivedMsgs", "Count of messages received since activation of this peer")
@@ -288,6 +350,12 @@ var (
 	PeerStatsReliableHighestSent = pdm("PeerStats.reliableHighestSent", "Outbound reliable messages are assigned a sequence. This is the highest sequence sent to the peer since activation")
 	PeerStatsReliableAckBase     = pdm("PeerStats.reliableAckBase", "Outbound reliable messages are assigned a sequence. This is the lowest sequence that has not received an acknowledgement from the peer")
 
+	PeerReliableInfoBacklog          = pdm("PeerReliableInfo.backlog", "Count of reliable messages to this peer that have not received an ack or nack")
+	PeerReliableInfoOldestUnacked    = pdm("PeerReliableInfo.oldestUnacked", "Creation time of the oldest reliable message to this peer that has not received an ack or nack. Omitted if the backlog is empty")
+	PeerReliableInfoOldestUnackedAge = pdm("PeerReliableInfo.oldestUnackedAge", "Age of the oldest unacknowledged reliable message to this peer, as a duration string. Omitted if the backlog is empty")
+	PeerReliableInfoLastError        = pdm("PeerReliableInfo.lastError", "The last error returned by the transport when sending to this peer since activation")
+	PeerReliableInfoLastErrorTime    = pdm("PeerReliableInfo.lastErrorTime", "Timestamp of the last error returned by the transport when sending to this peer")
+
 	ReliableMessageSequence    = pdm("ReliableMessage.sequence", "Sequence number for the position of this message in the local database")
 	ReliableMessageID          = pdm("ReliableMessage.id", "UUID for this message. A separate message, with a separate ID, is allocated for each participant that will receive the message")
 	ReliableMessageCreated     = pdm("ReliableMessage.created", "The time this message was created")
@@ -329,17 +397,25 @@ var (
 	PrivacyGroupGenesisTransaction = pdm("PrivacyGroup.genesisTransaction", "The ID of the genesis transaction for the privacy group, correlated with the receipt")
 	PrivacyGroupGenesisSchema      = pdm("PrivacyGroup.genesisSchema", "The ID of the schema for the genesis state")
 	PrivacyGroupGenesisSalt        = pdm("PrivacyGroup.genesisSalt", "The salt used in the genesis state to ensure uniqueness of the resulting state ID")
+	PrivacyGroupMembersRevision    = pdm("PrivacyGroup.membersRevision", "Incremented each time the member list of the group is changed after genesis")
+	PrivacyGroupMembersTransaction = pdm("PrivacyGroup.membersTransaction", "The ID of the transaction submitted to the domain for the latest change to the member list, if the domain required one")
+
+	PrivacyGroupMembersInputDomain             = pdm("PrivacyGroupMembersInput.domain", "The domain of the privacy group")
+	PrivacyGroupMembersInputGroup              = pdm("PrivacyGroupMembersInput.group", "The privacy group ID")
+	PrivacyGroupMembersInputMembers            = pdm("PrivacyGroupMembersInput.members", "The fully qualified identity locators 'some.identity@node.name' to add to, or remove from, the member list")
+	PrivacyGroupMembersInputTransactionOptions = pdm("PrivacyGroupMembersInput.transactionOptions", "Options that will be propagated to the transaction submitted if the domain needs to reconfigure the privacy group for the new member list")
 
-	PrivacyGroupMessageListenerName      = pdm("PrivacyGroupMessageListener.name", "Unique name for the message listener")
-	PrivacyGroupMessageListenerCreated   = pdm("PrivacyGroupMessageListener.created", "Time the listener was created")
-	PrivacyGroupMessageListenerStarted   = pdm("PrivacyGroupMessageListener.started", "If the listener is started - can be set to false to disable delivery server-side")
-	PrivacyGroupMessageListenerFilters   = pdm("PrivacyGroupMessageListener.filters", "Filters to apply to messages")
-	PrivacyGroupMessageListenerOptions   = pdm("PrivacyGroupMessageListener.options", "Options for the receipt listener")
-	MessageListenerFiltersSequenceAbove  = pdm("MessageListenerFilters.sequenceAbove", "Only deliver message above a certain sequence (rather than from the earliest message)")
-	MessageListenerFiltersDomain         = pdm("MessageListenerFilters.domain", "Only deliver messages for an individual domain")
-	MessageListenerFiltersGroup          = pdm("MessageListenerFilters.group", "Only deliver messages for an individual group ID")
-	MessageListenerFiltersTopicp         = pdm("MessageListenerFilters.topic", "Regular expression filter to apply to the topic of each message to determine whether to deliver it to the listener")
-	MessageListenerOptionsDomainReceipts = pdm("MessageListenerOptions.excludeLocal", "When true, messages sent by the local node will not be delivered to the listener")
+	PrivacyGroupMessageListenerName       = pdm("PrivacyGroupMessageListener.name", "Unique name for the message listener")
+	PrivacyGroupMessageListenerCreated    = pdm("PrivacyGroupMessageListener.created", "Time the listener was created")
+	PrivacyGroupMessageListenerStarted    = pdm("PrivacyGroupMessageListener.started", "If the listener is started - can be set to false to disable delivery server-side")
+	PrivacyGroupMessageListenerFilters    = pdm("PrivacyGroupMessageListener.filters", "Filters to apply to messages")
+	PrivacyGroupMessageListenerOptions    = pdm("PrivacyGroupMessageListener.options", "Options for the receipt listener")
+	MessageListenerFiltersSequenceAbove   = pdm("MessageListenerFilters.sequenceAbove", "Only deliver message above a certain sequence (rather than from the earliest message)")
+	MessageListenerFiltersDomain          = pdm("MessageListenerFilters.domain", "Only deliver messages for an individual domain")
+	MessageListenerFiltersGroup           = pdm("MessageListenerFilters.group", "Only deliver messages for an individual group ID")
+	MessageListenerFiltersTopicp          = pdm("MessageListenerFilters.topic", "Regular expression filter to apply to the topic of each message to determine whether to deliver it to the listener")
+	MessageListenerOptionsDomainReceipts  = pdm("MessageListenerOptions.excludeLocal", "When true, messages sent by the local node will not be delivered to the listener")
+	MessageListenerOptionsMaxRedeliveries = pdm("MessageListenerOptions.maxRedeliveries", "The maximum number of times delivery of a batch is retried before it is moved to the dead-letter store, and the listener moves on to the next batch. When not set, delivery is retried indefinitely")
 
 	PrivacyGroupMessageID                 = pdm("PrivacyGroupMessage.id", "Unique UUID for each message - will be the same on all nodes that receive the message")
 	PrivacyGroupMessageLocalSequence      = pdm("PrivacyGroupMessage.localSequence", "Local sequence number for the message, with the local database of the local node. Will not be the same on all nodes that receive the message")
@@ -351,4 +427,15 @@ var (
 	PrivacyGroupMessageLocalGroup         = pdm("PrivacyGroupMessage.group", "Group ID of the privacy group. All members in the group will receive a copy of the message (no guarantee of order)")
 	PrivacyGroupMessageTopic              = pdm("PrivacyGroupMessage.topic", "A topic for the message, which by convention should be a dot or slash separated string instructing the receiver how the message should be processed")
 	PrivacyGroupMessageData               = pdm("PrivacyGroupMessage.data", "Application defined JSON payload for the message. Can be any JSON type including as an object, array, hex string, other string, or number")
+	PrivacyGroupMessageRedacted           = pdm("PrivacyGroupMessage.redacted", "Time the data of the message was redacted on the local node. The envelope of the message is retained for audit")
+
+	PrivacyGroupMessagePolicyDomain      = pdm("PrivacyGroupMessagePolicy.domain", "Domain of the privacy group")
+	PrivacyGroupMessagePolicyGroup       = pdm("PrivacyGroupMessagePolicy.group", "Group ID of the privacy group")
+	PrivacyGroupMessagePolicyUpdated     = pdm("PrivacyGroupMessagePolicy.updated", "Time the policy was last updated")
+	PrivacyGroupMessagePolicyRetention   = pdm("PrivacyGroupMessagePolicy.retention", "Retention policy for messages in the group on the local node. Messages outside of the retention policy are periodically deleted")
+	PrivacyGroupMessagePolicyTopicACLs   = pdm("PrivacyGroupMessagePolicy.topicACLs", "Per-topic lists of the nodes that may send and receive messages on each topic. Topics without an entry are open to all members")
+	PrivacyGroupMessageRetentionMaxAge   = pdm("PrivacyGroupMessageRetention.maxAge", "Messages received longer ago than this duration are deleted")
+	PrivacyGroupMessageRetentionMaxCount = pdm("PrivacyGroupMessageRetention.maxCount", "Only this number of the most recently received messages are retained")
+	PrivacyGroupTopicACLTopic            = pdm("PrivacyGroupTopicACL.topic", "The exact topic the access control list applies to")
+	PrivacyGroupTopicACLNodes            = pdm("PrivacyGroupTopicACL.nodes", "The nodes that may send and receive on the topic, each of which must host a member of the group. Messages are exchanged between nodes, so the list applies to all identities on each node")
 )
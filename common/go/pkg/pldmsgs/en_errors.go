@@ -116,6 +116,16 @@ var (
 	MsgJSONRPCInvalidParam        = pde("PD020704", "method %s parameter %d invalid: %s")
 	MsgJSONRPCResultSerialization = pde("PD020705", "method %s result serialization failed: %s")
 	MsgJSONRPCAysncNonWSConn      = pde("PD020706", "method %s only available on WebSocket connections")
+	MsgJSONRPCUnauthenticated     = pde("PD020707", "Authentication required", 401)
+	MsgJSONRPCUnauthorized        = pde("PD020708", "Identity '%s' is not authorized to call method %s", 403)
+	MsgJSONRPCAuthConfigInvalid   = pde("PD020709", "Invalid JSON/RPC authentication configuration: %s")
+	MsgJSONRPCJWKSLoadFailed      = pde("PD020710", "Failed to load JWKS file '%s'")
+	MsgJSONRPCJWTInvalid          = pde("PD020711", "Invalid JWT: %s", 401)
+	MsgJSONRPCLimitsConfigInvalid = pde("PD020712", "Invalid JSON/RPC limits configuration: %s")
+	MsgJSONRPCRateLimited         = pde("PD020713", "Rate limit '%s' exceeded calling method %s", 429)
+	MsgJSONRPCBatchTooLarge       = pde("PD020714", "Batch of %d requests exceeds the maximum batch size of %d")
+	MsgJSONRPCRequestTooLarge     = pde("PD020715", "Request exceeds the maximum size of %d bytes", 413)
+	MsgJSONRPCMaxSubscriptions    = pde("PD020716", "Client already has the maximum of %d subscriptions across its connections")
 
 	// Signing module PD0208XX
 	MsgSigningModuleBadPathError                = pde("PD020800", "Path '%s' does not exist, or it is not a directory")
@@ -146,6 +156,16 @@ var (
 	MsgSigningEmptyPayload                      = pde("PD020825", "No payload supplied for signing")
 	MsgSigningInvalidDomainAlgorithmNoPrefix    = pde("PD020826", "Invalid domain algorithm (no 'domain:' prefix): %s")
 	MsgSigningNoDomainRegisteredWithModule      = pde("PD020827", "Domain '%s' has not been registered in this signing module")
+	MsgSigningUnsupportedMasterKeyType          = pde("PD020828", "Unsupported master key type: '%s'")
+	MsgSigningMasterKeySourceInvalid            = pde("PD020829", "Master key source must specify exactly one of a file or an environment variable")
+	MsgSigningMasterKeyLoadFailed               = pde("PD020830", "Failed to load master key material from '%s'")
+	MsgSigningMasterKeyNotAvailable             = pde("PD020831", "Master key '%s' is not available")
+	MsgSigningMasterKeyUnwrapFailed             = pde("PD020832", "Failed to unwrap secret with master key '%s'")
+	MsgSigningModuleBadWrappedPassFile          = pde("PD020833", "Wrapped password file '%s' is invalid")
+	MsgSigningModuleMasterKeyRequired           = pde("PD020834", "Password file '%s' is wrapped with a master key, but no master key is configured")
+	MsgSigningUnsupportedEdDSACurve             = pde("PD020835", "Unsupported EdDSA curve: '%s'")
+	MsgSigningKeyMaterialTooShort               = pde("PD020836", "Key material for algorithm '%s' must be at least %d bytes")
+	MsgSigningKeyMaterialInvalidForCurve        = pde("PD020837", "Key material for algorithm '%s' is not a valid private key for the curve")
 
 	// Reference markdown PD0209XX
 	MsgReferenceMarkdownMissing = pde("PD020900", "Reference markdown file missing: '%s'")
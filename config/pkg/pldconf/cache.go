@@ -17,5 +17,6 @@
 package pldconf
 
 type CacheConfig struct {
-	Capacity *int `json:"capacity"`
+	Capacity *int    `json:"capacity"`
+	TTL      *string `json:"ttl"` // entries expire after this duration - unset means no expiry
 }
@@ -19,6 +19,7 @@ import "github.com/kaleido-io/paladin/config/pkg/confutil"
 type GroupManagerConfig struct {
 	Cache            CacheConfig      `json:"cache"`
 	MessageListeners MessageListeners `json:"messageListeners"`
+	MessageRetention MessageRetention `json:"messageRetention"`
 }
 
 type MessageListeners struct {
@@ -26,6 +27,10 @@ type MessageListeners struct {
 	ReadPageSize *int        `json:"readPageSize"`
 }
 
+type MessageRetention struct {
+	PruneInterval *string `json:"pruneInterval"`
+}
+
 var GroupManagerDefaults = &GroupManagerConfig{
 	Cache: CacheConfig{
 		Capacity: confutil.P(50),
@@ -34,4 +39,7 @@ var GroupManagerDefaults = &GroupManagerConfig{
 		Retry:        GenericRetryDefaults.RetryConfig,
 		ReadPageSize: confutil.P(100),
 	},
+	MessageRetention: MessageRetention{
+		PruneInterval: confutil.P("1m"),
+	},
 }
@@ -24,8 +24,9 @@ type KeyManagerConfig struct {
 }
 
 type KeyManagerManagerConfig struct {
-	IdentifierCache CacheConfig `json:"identifierCache"`
-	VerifierCache   CacheConfig `json:"verifierCache"`
+	IdentifierCache    CacheConfig       `json:"identifierCache"`
+	VerifierCache      CacheConfig       `json:"verifierCache"`
+	SigningAuditWriter FlushWriterConfig `json:"signingAuditWriter"`
 }
 
 type SigningModuleConfig struct {
@@ -39,12 +40,28 @@ type SigningModuleInitConfig struct {
 }
 
 type WalletConfig struct {
-	Name                    string        `json:"name"`
-	KeySelector             string        `json:"keySelector"`             // Regex pattern conforming to https://golang.org/s/re2syntax
-	KeySelectorMustNotMatch bool          `json:"keySelectorMustNotMatch"` // To allow for specifying a non-matching regex i.e. all keys that aren't this pattern
-	Signer                  *SignerConfig `json:"signer"`                  // embedded only
-	SignerPluginName        string        `json:"signerPluginName"`
-	SignerType              string        `json:"signerType"`
+	Name                    string               `json:"name"`
+	KeySelector             string               `json:"keySelector"`             // Regex pattern conforming to https://golang.org/s/re2syntax
+	KeySelectorMustNotMatch bool                 `json:"keySelectorMustNotMatch"` // To allow for specifying a non-matching regex i.e. all keys that aren't this pattern
+	Signer                  *SignerConfig        `json:"signer"`                  // embedded only
+	SignerPluginName        string               `json:"signerPluginName"`
+	SignerType              string               `json:"signerType"`
+	SigningPolicy           *SigningPolicyConfig `json:"signingPolicy"` // optional restrictions on what keys in this wallet can sign
+}
+
+// Each list is an allow-list, where an empty list means no restriction is applied
+type SigningPolicyConfig struct {
+	AllowedPayloadTypes []string               `json:"allowedPayloadTypes"`
+	AllowedAlgorithms   []string               `json:"allowedAlgorithms"`
+	AllowedContracts    []string               `json:"allowedContracts"` // public transaction targets
+	AllowDeploy         *bool                  `json:"allowDeploy"`      // only checked when allowedContracts is set
+	MaxValue            *string                `json:"maxValue"`         // in wei, for public transactions
+	RateLimit           SigningRateLimitConfig `json:"rateLimit"`
+}
+
+type SigningRateLimitConfig struct {
+	MaxSignatures *int    `json:"maxSignatures"` // across all keys in the wallet, per period
+	Period        *string `json:"period"`
 }
 
 const (
@@ -58,6 +75,13 @@ var WalletDefaults = &WalletConfig{
 	SignerType:              WalletSignerTypeEmbedded, // uses the embedded signing module running in the Paladin process
 }
 
+var SigningPolicyDefaults = &SigningPolicyConfig{
+	AllowDeploy: confutil.P(false),
+	RateLimit: SigningRateLimitConfig{
+		Period: confutil.P("1s"),
+	},
+}
+
 var KeyManagerDefaults = &KeyManagerConfig{
 	KeyManagerManagerConfig: KeyManagerManagerConfig{
 		IdentifierCache: CacheConfig{
@@ -66,5 +90,10 @@ var KeyManagerDefaults = &KeyManagerConfig{
 		VerifierCache: CacheConfig{
 			Capacity: confutil.P(1000),
 		},
+		SigningAuditWriter: FlushWriterConfig{
+			WorkerCount:  confutil.P(1),
+			BatchTimeout: confutil.P("25ms"),
+			BatchMaxSize: confutil.P(100),
+		},
 	},
 }
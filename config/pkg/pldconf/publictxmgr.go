@@ -71,16 +71,22 @@ var PublicTxManagerDefaults = &PublicTxManagerConfig{
 		},
 	},
 	GasPrice: GasPriceConfig{
-		IncreaseMax:        nil,
-		IncreasePercentage: confutil.P(0),
-		FixedGasPrice:      nil,
+		IncreaseMax:                   nil,
+		IncreasePercentage:            confutil.P(0),
+		PriorityFeeIncreaseMax:        nil,
+		PriorityFeeIncreasePercentage: confutil.P(0),
+		FixedGasPrice:                 nil,
+		EthFeeHistory: EthFeeHistoryConfig{
+			Enabled:               confutil.P(false),
+			BlockCount:            confutil.P(20),
+			PriorityFeePercentile: confutil.P(50.0),
+			BaseFeeMultiplier:     confutil.P(2.0),
+			MaxPriorityFeePerGas:  nil,
+			MaxFeePerGas:          nil,
+		},
 		Cache: CacheConfig{
 			Capacity: confutil.P(100),
-			// TODO: Enable a KB based cache with TTL in Paladin
-			//       Until then the gas price cache will not expire (which is problematic)
-			// Enabled: confutil.P(true),
-			// Size:    confutil.P("1kb"),
-			// TTL:     confutil.P("1s"),
+			TTL:      confutil.P("10s"),
 		},
 	},
 	BalanceManager: BalanceManagerConfig{
@@ -91,6 +97,11 @@ var PublicTxManagerDefaults = &PublicTxManagerConfig{
 			// Size:     confutil.P("5m"),
 			// TTL:      confutil.P("30s"),
 		},
+		AutoFueling: AutoFuelingConfig{
+			MinThreshold: confutil.P("0"),
+			MinInterval:  confutil.P("30s"),
+			MaxInFlight:  confutil.P(10),
+		},
 	},
 	GasLimit: GasLimitConfig{
 		GasEstimateFactor: confutil.P(1.5),
@@ -115,15 +126,41 @@ type PublicTxManagerActivityRecordsConfig struct {
 }
 
 type BalanceManagerConfig struct {
-	Cache CacheConfig `json:"cache"`
+	Cache       CacheConfig       `json:"cache"`
+	AutoFueling AutoFuelingConfig `json:"autoFueling"`
+}
+
+// AutoFuelingConfig enables a funding account to top up signing addresses that are short of
+// the balance they need to submit their transactions. Fueling is disabled unless a source is set.
+type AutoFuelingConfig struct {
+	Source        *string `json:"source"`        // key identifier of the funding account
+	TargetBalance *string `json:"targetBalance"` // balance (in wei) a signing address is topped up to
+	MinThreshold  *string `json:"minThreshold"`  // top up when the balance available after in-flight costs drops below this
+	MaxThreshold  *string `json:"maxThreshold"`  // upper limit on the value of any single fueling transaction
+	MinInterval   *string `json:"minInterval"`   // minimum time between fueling transactions to the same address
+	MaxInFlight   *int    `json:"maxInFlight"`   // maximum number of unconfirmed fueling transactions across all addresses
 }
 
 type GasPriceConfig struct {
-	IncreaseMax        *string            `json:"increaseMax"`
-	IncreasePercentage *int               `json:"increasePercentage"`
-	FixedGasPrice      any                `json:"fixedGasPrice"` // number or object
-	GasOracleAPI       GasOracleAPIConfig `json:"gasOracleAPI"`
-	Cache              CacheConfig        `json:"cache"`
+	IncreaseMax                   *string             `json:"increaseMax"`
+	IncreasePercentage            *int                `json:"increasePercentage"`
+	PriorityFeeIncreaseMax        *string             `json:"priorityFeeIncreaseMax"`        // cap for maxPriorityFeePerGas when bumping a stuck EIP-1559 transaction
+	PriorityFeeIncreasePercentage *int                `json:"priorityFeeIncreasePercentage"` // bump for maxPriorityFeePerGas when resubmitting a stuck EIP-1559 transaction
+	FixedGasPrice                 any                 `json:"fixedGasPrice"`                 // number or object
+	GasOracleAPI                  GasOracleAPIConfig  `json:"gasOracleAPI"`
+	EthFeeHistory                 EthFeeHistoryConfig `json:"ethFeeHistory"`
+	Cache                         CacheConfig         `json:"cache"`
+}
+
+// EthFeeHistoryConfig configures the EIP-1559 pricing strategy, which derives maxFeePerGas and
+// maxPriorityFeePerGas from the eth_feeHistory of the most recent blocks
+type EthFeeHistoryConfig struct {
+	Enabled               *bool    `json:"enabled"`
+	BlockCount            *int     `json:"blockCount"`            // number of recent blocks to sample
+	PriorityFeePercentile *float64 `json:"priorityFeePercentile"` // reward percentile (0-100) sampled from each block
+	BaseFeeMultiplier     *float64 `json:"baseFeeMultiplier"`     // headroom applied to the next block base fee when calculating maxFeePerGas
+	MaxPriorityFeePerGas  *string  `json:"maxPriorityFeePerGas"`  // cap on the calculated maxPriorityFeePerGas
+	MaxFeePerGas          *string  `json:"maxFeePerGas"`          // cap on the calculated maxFeePerGas
 }
 
 type GasLimitConfig struct {
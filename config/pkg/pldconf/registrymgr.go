@@ -38,6 +38,7 @@ type RegistryInitConfig struct {
 type RegistryConfig struct {
 	Init       RegistryInitConfig       `json:"init"`
 	Transports RegistryTransportsConfig `json:"transports"`
+	Publish    RegistryPublishConfig    `json:"publish"`
 	Plugin     PluginConfig             `json:"plugin"`
 	Config     map[string]any           `json:"config"`
 }
@@ -86,3 +87,36 @@ var RegistryTransportsDefaults = &RegistryTransportsConfig{
 	Enabled:        confutil.P(true),
 	PropertyRegexp: "^transport.(.*)$",
 }
+
+type RegistryPublishConfig struct {
+
+	// If true, then on startup the local node registers its own entry in this
+	// registry (if not already registered), and publishes the details of each
+	// local transport as a property of that entry.
+	// The registry plugin must support writes.
+	Enabled *bool `json:"enabled"`
+
+	// The signing key that owns the node's entry, and sets its properties.
+	// Required to publish, including via the reg_publishLocalTransports RPC.
+	From string `json:"from"`
+
+	// The signing key that owns the parent entry (the root entry of the registry
+	// for a top level node) used to register the node's entry, if it does not
+	// already exist. Defaults to the from key.
+	RegisterFrom string `json:"registerFrom"`
+
+	// How often the local transport details are checked, and re-published
+	// if they have changed (for example due to TLS certificate rotation)
+	Interval *string `json:"interval"`
+
+	// The prefix added to the transport name to build each property name,
+	// which must be matched by transports.propertyRegexp for other nodes
+	// to find the transport.
+	PropertyPrefix *string `json:"propertyPrefix"`
+}
+
+var RegistryPublishDefaults = &RegistryPublishConfig{
+	Enabled:        confutil.P(false),
+	Interval:       confutil.P("1m"),
+	PropertyPrefix: confutil.P("transport."),
+}
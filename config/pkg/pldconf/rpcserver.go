@@ -42,6 +42,70 @@ type RPCServerConfigWS struct {
 }
 
 type RPCServerConfig struct {
-	HTTP RPCServerConfigHTTP `json:"http,omitempty"`
-	WS   RPCServerConfigWS   `json:"ws,omitempty"`
+	HTTP   RPCServerConfigHTTP `json:"http,omitempty"`
+	WS     RPCServerConfigWS   `json:"ws,omitempty"`
+	Auth   RPCAuthConfig       `json:"auth,omitempty"`
+	Limits RPCLimitsConfig     `json:"limits,omitempty"`
+}
+
+// All limits are disabled unless configured
+type RPCLimitsConfig struct {
+	MaxRequestSize            *string              `json:"maxRequestSize,omitempty"`            // maximum size of an HTTP request body, or a WebSocket message
+	MaxBatchSize              *int                 `json:"maxBatchSize,omitempty"`              // maximum number of requests in a JSON/RPC batch
+	MaxSubscriptionsPerClient *int                 `json:"maxSubscriptionsPerClient,omitempty"` // maximum concurrent WebSocket subscriptions of a client, across all its connections
+	RateLimits                []RPCRateLimitConfig `json:"rateLimits,omitempty"`
+}
+
+// A token bucket rate limit, applied separately to each client (the authenticated identity, or the remote IP if
+// authentication is not enabled). Where a method matches multiple rate limits, a request must be allowed by all of them.
+type RPCRateLimitConfig struct {
+	Name              string   `json:"name"`
+	Methods           []string `json:"methods"`                     // a trailing "*" matches any suffix
+	RequestsPerSecond *float64 `json:"requestsPerSecond,omitempty"` // the rate the bucket refills
+	Burst             *int     `json:"burst,omitempty"`             // the size of the bucket
+}
+
+var RPCRateLimitDefaults = RPCRateLimitConfig{
+	RequestsPerSecond: confutil.P(10.0),
+	Burst:             confutil.P(20),
+}
+
+// Authentication is enabled if any of the static tokens, JWT or mTLS options are configured,
+// after which every request (and every WebSocket upgrade) must be from an authenticated identity.
+type RPCAuthConfig struct {
+	StaticTokens []RPCAuthStaticTokenConfig `json:"staticTokens,omitempty"`
+	JWT          RPCAuthJWTConfig           `json:"jwt,omitempty"`
+	MTLS         RPCAuthMTLSConfig          `json:"mtls,omitempty"`
+	MethodGroups map[string][]string        `json:"methodGroups,omitempty"` // named lists of methods (or HTTP path prefixes), where a trailing "*" matches any suffix
+	Roles        map[string][]string        `json:"roles,omitempty"`        // the method groups each role can call - if empty, any authenticated identity can call any method
+}
+
+type RPCAuthStaticTokenConfig struct {
+	Identity  string   `json:"identity"`
+	Token     string   `json:"token,omitempty"`
+	TokenFile string   `json:"tokenFile,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+}
+
+type RPCAuthJWTConfig struct {
+	JWKSFile      string  `json:"jwksFile,omitempty"` // JWT authentication is enabled if set
+	Issuer        string  `json:"issuer,omitempty"`
+	Audience      string  `json:"audience,omitempty"`
+	IdentityClaim *string `json:"identityClaim,omitempty"`
+	RolesClaim    *string `json:"rolesClaim,omitempty"` // a string, or an array of strings
+	ClockSkew     *string `json:"clockSkew,omitempty"`
+}
+
+// Requires TLS with clientAuth enabled on the HTTP/WebSocket server, so the client certificate is verified
+type RPCAuthMTLSConfig struct {
+	Enabled bool                `json:"enabled,omitempty"`
+	Roles   map[string][]string `json:"roles,omitempty"` // roles for each client certificate subject, such as "CN=app1,O=acme"
+}
+
+var RPCAuthDefaults = RPCAuthConfig{
+	JWT: RPCAuthJWTConfig{
+		IdentityClaim: confutil.P("sub"),
+		RolesClaim:    confutil.P("roles"),
+		ClockSkew:     confutil.P("30s"),
+	},
 }
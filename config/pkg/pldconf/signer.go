@@ -99,10 +99,32 @@ type StaticKeyStoreConfig struct {
 }
 
 type FileSystemKeyStoreConfig struct {
-	Path     *string     `json:"path"`
-	Cache    CacheConfig `json:"cache"`
-	FileMode *string     `json:"fileMode"`
-	DirMode  *string     `json:"dirMode"`
+	Path      *string         `json:"path"`
+	Cache     CacheConfig     `json:"cache"`
+	FileMode  *string         `json:"fileMode"`
+	DirMode   *string         `json:"dirMode"`
+	MasterKey MasterKeyConfig `json:"masterKey"`
+}
+
+const (
+	MasterKeyTypeFile = "file" // master key material loaded from a file, such as a mounted Kubernetes secret
+	MasterKeyTypeEnv  = "env"  // master key material loaded from an environment variable
+)
+
+// The location of a piece of master key material
+type MasterKeySourceConfig struct {
+	File       string `json:"file,omitempty"`
+	Env        string `json:"env,omitempty"`
+	Passphrase bool   `json:"passphrase,omitempty"` // the material is a passphrase rather than random bytes, so is stretched with Argon2id
+}
+
+// When a master key is configured, the random password for each key file is wrapped (encrypted) with the
+// master key before it is written to disk, rather than being stored in plaintext next to the key file.
+type MasterKeyConfig struct {
+	Type                  string                  `json:"type"` // empty for plaintext passwords
+	MasterKeySourceConfig                         // the current master key, used for all new wrapping
+	PreviousKeys          []MasterKeySourceConfig `json:"previousKeys"`     // during rotation, passwords wrapped with these keys are re-wrapped with the current key on startup
+	MigratePlaintext      bool                    `json:"migratePlaintext"` // wrap any existing plaintext password files on startup, and remove the plaintext
 }
 
 var FileSystemDefaults = &FileSystemKeyStoreConfig{
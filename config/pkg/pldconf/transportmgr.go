@@ -25,9 +25,24 @@ type TransportManagerConfig struct {
 	ReliableScanRetry     RetryConfig                 `json:"reliableScanRetry"`
 	ReliableMessageResend *string                     `json:"reliableMessageResend"`
 	ReliableMessageWriter FlushWriterConfig           `json:"reliableMessageWriter"`
+	PayloadEncryption     TransportEncryptionConfig   `json:"payloadEncryption"`
 	Transports            map[string]*TransportConfig `json:"transports"`
 }
 
+// End-to-end encryption and signing of message payloads between nodes, independent of the TLS
+// provided by each transport, so that relays and TLS terminating proxies cannot read private data.
+type TransportEncryptionConfig struct {
+	// Enables encryption of payloads to nodes that publish keys, and publishing of our own keys
+	Enabled *bool `json:"enabled"`
+	// If true, payloads are never sent to, or accepted from, nodes without encryption
+	Required *bool `json:"required"`
+	// Files containing the P-256 node keys in PEM format. The first is the current key, and any others
+	// are retained after a rollover to decrypt messages sent before other nodes saw the new key
+	KeyFiles []string `json:"keyFiles"`
+	// The name of the registry property that the public keys are published to, and read from
+	PropertyName *string `json:"propertyName"`
+}
+
 type TransportInitConfig struct {
 	Retry RetryConfig `json:"retry"`
 }
@@ -52,6 +67,11 @@ var TransportManagerDefaults = &TransportManagerConfig{
 		BatchTimeout: confutil.P("250ms"),
 		BatchMaxSize: confutil.P(50),
 	},
+	PayloadEncryption: TransportEncryptionConfig{
+		Enabled:      confutil.P(false),
+		Required:     confutil.P(false),
+		PropertyName: confutil.P("node.keys"),
+	},
 }
 
 type TransportConfig struct {
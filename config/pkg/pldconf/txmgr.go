@@ -23,6 +23,7 @@ type TxManagerConfig struct {
 	ABI              ABIConfig          `json:"abi"`
 	Transactions     TransactionsConfig `json:"transactions"`
 	ReceiptListeners ReceiptListeners   `json:"receiptListeners"`
+	Export           ExportConfig       `json:"export"`
 }
 
 type ABIConfig struct {
@@ -30,7 +31,8 @@ type ABIConfig struct {
 }
 
 type TransactionsConfig struct {
-	Cache CacheConfig `json:"cache"`
+	Cache               CacheConfig `json:"cache"`
+	ExpiryCheckInterval *string     `json:"expiryCheckInterval"` // how often to look for transactions that have passed their expiresAt deadline
 }
 
 type ReceiptListeners struct {
@@ -39,6 +41,12 @@ type ReceiptListeners struct {
 	StateGapCheckInterval *string     `json:"stateGapCheckInterval"`
 }
 
+type ExportConfig struct {
+	PageSize         *int    `json:"pageSize"`         // number of receipts read from the DB for each page of an export
+	PageWriteTimeout *string `json:"pageWriteTimeout"` // the write deadline is extended by this amount for each page streamed to the client
+	SettleTime       *string `json:"settleTime"`       // receipts indexed more recently than this are excluded, as transactions with lower sequences might not have committed yet
+}
+
 var TxManagerDefaults = &TxManagerConfig{
 	ABI: ABIConfig{
 		Cache: CacheConfig{
@@ -49,10 +57,16 @@ var TxManagerDefaults = &TxManagerConfig{
 		Cache: CacheConfig{
 			Capacity: confutil.P(100),
 		},
+		ExpiryCheckInterval: confutil.P("5s"),
 	},
 	ReceiptListeners: ReceiptListeners{
 		Retry:                 GenericRetryDefaults.RetryConfig,
 		ReadPageSize:          confutil.P(100),
 		StateGapCheckInterval: confutil.P("1s"),
 	},
+	Export: ExportConfig{
+		PageSize:         confutil.P(1000),
+		PageWriteTimeout: confutil.P("1m"),
+		SettleTime:       confutil.P("10s"),
+	},
 }
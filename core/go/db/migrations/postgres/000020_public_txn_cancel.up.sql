@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "public_txns" ADD "cancelled" BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE "public_txns" DROP COLUMN "expires_at";
+ALTER TABLE "public_txns" DROP COLUMN "not_before";
+
+DROP INDEX transactions_expires_at;
+ALTER TABLE "transactions" DROP COLUMN "expires_at";
+ALTER TABLE "transactions" DROP COLUMN "not_before";
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE "transactions" ADD "not_before" BIGINT;
+ALTER TABLE "transactions" ADD "expires_at" BIGINT;
+CREATE INDEX transactions_expires_at ON transactions("expires_at");
+
+ALTER TABLE "public_txns" ADD "not_before" BIGINT;
+ALTER TABLE "public_txns" ADD "expires_at" BIGINT;
+
+COMMIT;
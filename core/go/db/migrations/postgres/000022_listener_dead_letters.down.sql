@@ -0,0 +1,3 @@
+BEGIN;
+DROP TABLE listener_dead_letters;
+COMMIT;
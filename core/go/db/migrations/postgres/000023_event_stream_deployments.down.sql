@@ -0,0 +1,3 @@
+BEGIN;
+DROP TABLE event_stream_deployments;
+COMMIT;
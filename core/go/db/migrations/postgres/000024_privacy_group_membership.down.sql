@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE "privacy_groups" DROP COLUMN "members_tx";
+ALTER TABLE "privacy_groups" DROP COLUMN "members_revision";
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE "privacy_groups" ADD "members_revision" INT NOT NULL DEFAULT 0;
+ALTER TABLE "privacy_groups" ADD "members_tx" UUID;
+
+COMMIT;
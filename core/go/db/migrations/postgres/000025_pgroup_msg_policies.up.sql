@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE pgroup_msg_policies (
+    "domain"         TEXT       NOT NULL,
+    "group"          TEXT       NOT NULL,
+    "updated"        BIGINT     NOT NULL,
+    "max_age"        TEXT       ,
+    "max_count"      INT        ,
+    "topic_acls"     TEXT       NOT NULL,
+    PRIMARY KEY ("domain", "group"),
+    FOREIGN KEY ("domain", "group") REFERENCES privacy_groups ("domain", "id") ON DELETE CASCADE
+);
+
+ALTER TABLE pgroup_msgs ADD "redacted" BIGINT;
+CREATE INDEX pgroup_msgs_group_received ON pgroup_msgs("domain","group","received");
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE key_signing_audit;
+DROP TABLE key_verifier_history;
+ALTER TABLE key_mappings DROP COLUMN "rotation";
+ALTER TABLE key_mappings DROP COLUMN "disabled";
+
+COMMIT;
@@ -0,0 +1,36 @@
+BEGIN;
+
+ALTER TABLE key_mappings ADD "disabled" BIGINT;
+ALTER TABLE key_mappings ADD "rotation" BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE key_verifier_history (
+    "identifier"         VARCHAR         NOT NULL,
+    "rotation"           BIGINT          NOT NULL,
+    "retired"            BIGINT          NOT NULL,
+    "wallet"             VARCHAR         NOT NULL,
+    "key_handle"         VARCHAR         NOT NULL,
+    "algorithm"          VARCHAR         NOT NULL,
+    "type"               VARCHAR         NOT NULL,
+    "verifier"           VARCHAR         NOT NULL,
+    PRIMARY KEY ("verifier", "algorithm", "type"),
+    FOREIGN KEY ("identifier") REFERENCES key_mappings ("identifier") ON DELETE CASCADE
+);
+
+CREATE INDEX key_verifier_history_identifier ON key_verifier_history ("identifier");
+
+CREATE TABLE key_signing_audit (
+    "seq"                BIGINT          GENERATED ALWAYS AS IDENTITY,
+    "created"            BIGINT          NOT NULL,
+    "identifier"         VARCHAR         NOT NULL,
+    "wallet"             VARCHAR         NOT NULL,
+    "key_handle"         VARCHAR         NOT NULL,
+    "algorithm"          VARCHAR         NOT NULL,
+    "verifier"           VARCHAR         NOT NULL,
+    "payload_type"       VARCHAR         NOT NULL,
+    "payload_hash"       VARCHAR         NOT NULL,
+    "error"              VARCHAR
+);
+
+CREATE INDEX key_signing_audit_identifier ON key_signing_audit ("identifier");
+
+COMMIT;
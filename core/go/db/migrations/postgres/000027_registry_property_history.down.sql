@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE reg_prop_history;
+
+COMMIT;
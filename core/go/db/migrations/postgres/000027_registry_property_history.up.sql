@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE reg_prop_history (
+    "seq"                BIGINT          GENERATED ALWAYS AS IDENTITY,
+    "registry"           VARCHAR         NOT NULL,
+    "entry_id"           VARCHAR         NOT NULL,
+    "name"               VARCHAR         NOT NULL,
+    "value"              VARCHAR         NOT NULL,
+    "active"             BOOLEAN         NOT NULL,
+    "created"            BIGINT          NOT NULL,
+    "tx_hash"            VARCHAR,
+    "block_number"       BIGINT,
+    "tx_index"           INT,
+    "log_index"          INT,
+    PRIMARY KEY ("seq"),
+    FOREIGN KEY ("registry", "entry_id") REFERENCES reg_entries ("registry", "id") ON DELETE CASCADE
+);
+
+CREATE INDEX reg_prop_history_entry ON reg_prop_history ("registry", "entry_id", "name");
+CREATE INDEX reg_prop_history_block ON reg_prop_history ("registry", "block_number");
+
+COMMIT;
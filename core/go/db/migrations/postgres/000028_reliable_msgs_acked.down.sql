@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX reliable_msgs_unacked;
+ALTER TABLE reliable_msgs DROP COLUMN "acked";
+
+COMMIT;
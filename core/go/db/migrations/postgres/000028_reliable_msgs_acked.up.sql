@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Maintained alongside the ack, so the backlog of unacknowledged messages can be summarized
+-- from a partial index rather than joining the whole history of acks
+ALTER TABLE reliable_msgs ADD "acked" BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE reliable_msgs SET "acked" = TRUE WHERE "id" IN (SELECT "id" FROM reliable_msg_acks);
+CREATE INDEX reliable_msgs_unacked ON reliable_msgs ("node", "created") WHERE "acked" IS FALSE;
+
+COMMIT;
@@ -0,0 +1 @@
+ALTER TABLE "public_txns" DROP COLUMN "cancelled";
\ No newline at end of file
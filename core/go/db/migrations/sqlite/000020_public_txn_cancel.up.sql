@@ -0,0 +1 @@
+ALTER TABLE "public_txns" ADD "cancelled" BOOLEAN NOT NULL DEFAULT FALSE;
\ No newline at end of file
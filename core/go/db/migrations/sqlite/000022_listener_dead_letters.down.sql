@@ -0,0 +1 @@
+DROP TABLE listener_dead_letters;
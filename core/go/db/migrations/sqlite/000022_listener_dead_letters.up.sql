@@ -0,0 +1,16 @@
+-- Shared by all listener types. There is no foreign key to the listener, as the
+-- listener definitions are in different tables - so the managers delete these explicitly.
+CREATE TABLE listener_dead_letters (
+    "id"             UUID       NOT NULL,
+    "listener_type"  TEXT       NOT NULL,
+    "listener"       TEXT       NOT NULL,
+    "created"        BIGINT     NOT NULL,
+    "batch_id"       TEXT       NOT NULL,
+    "attempts"       INT        NOT NULL,
+    "last_error"     TEXT       NOT NULL,
+    "replay"         BOOLEAN    NOT NULL,
+    "batch"          TEXT       NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX listener_dead_letters_listener ON listener_dead_letters("listener_type", "listener", "created");
+
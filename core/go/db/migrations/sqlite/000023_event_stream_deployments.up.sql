@@ -0,0 +1,9 @@
+-- The contracts deployed by the factories configured on the sources of an event stream
+CREATE TABLE event_stream_deployments (
+    "stream"          UUID    NOT NULL,
+    "factory"         TEXT    NOT NULL,
+    "address"         TEXT    NOT NULL,
+    "block_number"    BIGINT  NOT NULL,
+    PRIMARY KEY ("stream", "factory", "address"),
+    FOREIGN KEY ("stream") REFERENCES event_streams ("id") ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE "privacy_groups" DROP COLUMN "members_tx";
+ALTER TABLE "privacy_groups" DROP COLUMN "members_revision";
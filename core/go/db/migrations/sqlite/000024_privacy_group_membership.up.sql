@@ -0,0 +1,2 @@
+ALTER TABLE "privacy_groups" ADD "members_revision" INT NOT NULL DEFAULT 0;
+ALTER TABLE "privacy_groups" ADD "members_tx" UUID;
@@ -0,0 +1,3 @@
+DROP INDEX pgroup_msgs_group_received;
+ALTER TABLE pgroup_msgs DROP COLUMN "redacted";
+DROP TABLE pgroup_msg_policies;
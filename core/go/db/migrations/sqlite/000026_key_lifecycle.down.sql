@@ -0,0 +1,4 @@
+DROP TABLE key_signing_audit;
+DROP TABLE key_verifier_history;
+ALTER TABLE key_mappings DROP COLUMN "rotation";
+ALTER TABLE key_mappings DROP COLUMN "disabled";
@@ -0,0 +1,32 @@
+ALTER TABLE key_mappings ADD "disabled" BIGINT;
+ALTER TABLE key_mappings ADD "rotation" BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE key_verifier_history (
+    "identifier"         TEXT            NOT NULL,
+    "rotation"           BIGINT          NOT NULL,
+    "retired"            BIGINT          NOT NULL,
+    "wallet"             TEXT            NOT NULL,
+    "key_handle"         TEXT            NOT NULL,
+    "algorithm"          TEXT            NOT NULL,
+    "type"               TEXT            NOT NULL,
+    "verifier"           TEXT            NOT NULL,
+    PRIMARY KEY ("verifier", "algorithm", "type"),
+    FOREIGN KEY ("identifier") REFERENCES key_mappings ("identifier") ON DELETE CASCADE
+);
+
+CREATE INDEX key_verifier_history_identifier ON key_verifier_history ("identifier");
+
+CREATE TABLE key_signing_audit (
+    "seq"                INTEGER         PRIMARY KEY AUTOINCREMENT,
+    "created"            BIGINT          NOT NULL,
+    "identifier"         TEXT            NOT NULL,
+    "wallet"             TEXT            NOT NULL,
+    "key_handle"         TEXT            NOT NULL,
+    "algorithm"          TEXT            NOT NULL,
+    "verifier"           TEXT            NOT NULL,
+    "payload_type"       TEXT            NOT NULL,
+    "payload_hash"       TEXT            NOT NULL,
+    "error"              TEXT
+);
+
+CREATE INDEX key_signing_audit_identifier ON key_signing_audit ("identifier");
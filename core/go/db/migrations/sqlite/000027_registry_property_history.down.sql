@@ -0,0 +1 @@
+DROP TABLE reg_prop_history;
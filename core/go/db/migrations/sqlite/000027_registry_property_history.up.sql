@@ -0,0 +1,17 @@
+CREATE TABLE reg_prop_history (
+    "seq"                INTEGER         PRIMARY KEY AUTOINCREMENT,
+    "registry"           TEXT            NOT NULL,
+    "entry_id"           TEXT            NOT NULL,
+    "name"               TEXT            NOT NULL,
+    "value"              TEXT            NOT NULL,
+    "active"             BOOLEAN         NOT NULL,
+    "created"            BIGINT          NOT NULL,
+    "tx_hash"            TEXT,
+    "block_number"       BIGINT,
+    "tx_index"           INT,
+    "log_index"          INT,
+    FOREIGN KEY ("registry", "entry_id") REFERENCES reg_entries ("registry", "id") ON DELETE CASCADE
+);
+
+CREATE INDEX reg_prop_history_entry ON reg_prop_history ("registry", "entry_id", "name");
+CREATE INDEX reg_prop_history_block ON reg_prop_history ("registry", "block_number");
@@ -0,0 +1,2 @@
+DROP INDEX reliable_msgs_unacked;
+ALTER TABLE reliable_msgs DROP COLUMN "acked";
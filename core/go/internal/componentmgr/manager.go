@@ -38,9 +38,11 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/statemgr"
 	"github.com/kaleido-io/paladin/core/internal/transportmgr"
 	"github.com/kaleido-io/paladin/core/internal/txmgr"
+	"github.com/kaleido-io/paladin/core/internal/version"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 	"github.com/kaleido-io/paladin/core/pkg/ethclient"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/httpserver"
 	"github.com/kaleido-io/paladin/toolkit/pkg/metricsserver"
@@ -160,13 +162,17 @@ func (cm *componentManager) Init() (err error) {
 		cm.blockIndexer, err = blockindexer.NewBlockIndexer(cm.bgCtx, &cm.conf.BlockIndexer, &cm.conf.Blockchain.WS, cm.persistence)
 		err = cm.wrapIfErr(err, msgs.MsgComponentBlockIndexerInitError)
 	}
+	if err == nil {
+		cm.metricsManager = metrics.NewMetricsManager(cm.bgCtx)
+		err = cm.wrapIfErr(err, msgs.MsgComponentMetricsManagerInitError)
+	}
 	if err == nil {
 		cm.rpcServer, err = rpcserver.NewRPCServer(cm.bgCtx, &cm.conf.RPCServer)
 		err = cm.wrapIfErr(err, msgs.MsgComponentRPCServerInitError)
 	}
 	if err == nil {
-		cm.metricsManager = metrics.NewMetricsManager(cm.bgCtx)
-		err = cm.wrapIfErr(err, msgs.MsgComponentMetricsManagerInitError)
+		cm.rpcServer.RegisterMetrics(cm.metricsManager.Registry())
+		cm.rpcServer.SetOpenRPCInfo(rpcserver.OpenRPCInfo{Title: "Paladin JSON/RPC API", Version: version.Version}, rpcMethodNames())
 	}
 	if err == nil {
 		if confutil.Bool(cm.conf.MetricsServer.Enabled, *pldconf.MetricsServerDefaults.Enabled) {
@@ -494,12 +500,38 @@ func (cm *componentManager) registerRPCModules() {
 		for _, rpcMod := range initResult.RPCModules {
 			cm.rpcServer.Register(rpcMod)
 		}
+		for pathPrefix, handler := range initResult.HTTPHandlers {
+			cm.rpcServer.RegisterHTTPHandler(pathPrefix, handler)
+		}
 	}
 	// We handle block indexer separately (doesn't fit the internal ManagerLifecycle model
 	// as it's currently a standalone re-usable component)
 	cm.rpcServer.Register(cm.BlockIndexer().RPCModule())
 }
 
+// Parameter names cannot be introspected via reflection, so for the OpenRPC document we use the
+// same names as the client SDK (and the reference documentation)
+func rpcMethodNames() rpcserver.RPCMethodNamer {
+	c := pldclient.New()
+	modules := []pldclient.RPCModule{
+		c.PTX(),
+		c.KeyManager(),
+		c.Registry(),
+		c.Transport(),
+		c.StateStore(),
+		c.BlockIndex(),
+		c.PrivacyGroups(),
+	}
+	return func(method string) ([]string, string, bool) {
+		for _, module := range modules {
+			if info := module.MethodInfo(method); info != nil {
+				return info.Inputs, info.Output, true
+			}
+		}
+		return nil, "", false
+	}
+}
+
 func (cm *componentManager) Stop() {
 	log.L(cm.bgCtx).Info("Stopping")
 	// stop all the stoppable things we started
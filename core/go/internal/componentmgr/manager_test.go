@@ -225,6 +225,7 @@ func TestStartOK(t *testing.T) {
 	mockRPCServer := rpcservermocks.NewRPCServer(t)
 	mockRPCServer.On("Start").Return(nil)
 	mockRPCServer.On("Register", mock.AnythingOfType("*rpcserver.RPCModule")).Return()
+	mockRPCServer.On("RegisterHTTPHandler", "/ut", mock.Anything).Return()
 	mockRPCServer.On("Stop").Return()
 	mockRPCServer.On("HTTPAddr").Return(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8545})
 	mockRPCServer.On("WSAddr").Return(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8546})
@@ -241,6 +242,9 @@ func TestStartOK(t *testing.T) {
 			RPCModules: []*rpcserver.RPCModule{
 				rpcserver.NewRPCModule("ut"),
 			},
+			HTTPHandlers: map[string]http.HandlerFunc{
+				"/ut": func(w http.ResponseWriter, r *http.Request) {},
+			},
 		},
 	}
 	cm.blockIndexer = mockBlockIndexer
@@ -297,3 +301,20 @@ func TestErrorWrapping(t *testing.T) {
 	assert.Regexp(t, "PD010008.*pop", cm.wrapIfErr(errors.New("pop"), msgs.MsgComponentBlockIndexerInitError))
 
 }
+
+func TestRPCMethodNames(t *testing.T) {
+	methodNames := rpcMethodNames()
+
+	params, result, ok := methodNames("ptx_sendTransaction")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"transaction"}, params)
+	assert.Equal(t, "transactionId", result)
+
+	params, result, ok = methodNames("bidx_getBlockByNumber")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"blockNumber"}, params)
+	assert.Equal(t, "block", result)
+
+	_, _, ok = methodNames("debug_getTransactionStatus")
+	assert.False(t, ok)
+}
@@ -16,6 +16,8 @@
 package components
 
 import (
+	"net/http"
+
 	"github.com/kaleido-io/paladin/core/internal/metrics"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 	"github.com/kaleido-io/paladin/core/pkg/ethclient"
@@ -75,6 +77,7 @@ type AdditionalManager interface {
 type ManagerInitResult struct {
 	PreCommitHandler blockindexer.PreCommitHandler
 	RPCModules       []*rpcserver.RPCModule
+	HTTPHandlers     map[string]http.HandlerFunc // plain HTTP handlers served alongside JSON/RPC, keyed by path prefix
 }
 
 type AllComponents interface {
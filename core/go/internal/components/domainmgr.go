@@ -54,6 +54,7 @@ type Domain interface {
 	RegistryAddress() *pldtypes.EthAddress
 	Configuration() *prototk.DomainConfig
 	CustomHashFunction() bool
+	StateSchemas() []Schema
 
 	// Specific to domains that support privacy groups (domain should return error if it does not).
 	// Validates the input properties, and turns it into the full genesis configuration for a group
@@ -88,6 +89,8 @@ type DomainSmartContract interface {
 	ExecCall(dCtx DomainContext, readTX persistence.DBTX, tx *ResolvedTransaction, verifiers []*prototk.ResolvedVerifier) (*abi.ComponentValue, error)
 
 	WrapPrivacyGroupEVMTX(context.Context, *pldapi.PrivacyGroup, *pldapi.PrivacyGroupEVMTX) (*pldapi.TransactionInput, error)
+	// Returns a nil transaction if the domain does not need to reconfigure the contract on-chain for the new members
+	UpdatePrivacyGroupMembers(ctx context.Context, pg *pldapi.PrivacyGroup, previousMembers []string) (*pldapi.TransactionInput, error)
 }
 
 type DomainPrivacyGroupConfig struct {
@@ -41,6 +41,13 @@ type PrivacyGroupDistribution struct {
 	GenesisState       StateDistributionWithData `json:"genesisState"`
 }
 
+type PrivacyGroupMembersDistribution struct {
+	Group              pldtypes.HexBytes         `json:"group"`
+	Revision           int                       `json:"revision"`
+	MembersTransaction *uuid.UUID                `json:"membersTransaction,omitempty"`
+	MembersState       StateDistributionWithData `json:"membersState"`
+}
+
 type PrivacyGroupMessageReceiver interface {
 	DeliverMessageBatch(ctx context.Context, batchID uint64, msgs []*pldapi.PrivacyGroupMessage) error
 }
@@ -54,6 +61,9 @@ type GroupManager interface {
 
 	CreateGroup(ctx context.Context, dbTX persistence.DBTX, spec *pldapi.PrivacyGroupInput) (group *pldapi.PrivacyGroup, err error)
 	StoreReceivedGroup(context.Context, persistence.DBTX, string, uuid.UUID, *pldapi.State) (error, error)
+	AddMembers(ctx context.Context, dbTX persistence.DBTX, spec *pldapi.PrivacyGroupMembersInput) (group *pldapi.PrivacyGroup, err error)
+	RemoveMembers(ctx context.Context, dbTX persistence.DBTX, spec *pldapi.PrivacyGroupMembersInput) (group *pldapi.PrivacyGroup, err error)
+	StoreReceivedGroupMembers(ctx context.Context, dbTX persistence.DBTX, domainName, senderNode string, pgmd *PrivacyGroupMembersDistribution, state *pldapi.State) (rejectionErr, err error)
 	GetGroupByID(ctx context.Context, dbTX persistence.DBTX, domainName string, groupID pldtypes.HexBytes) (*pldapi.PrivacyGroup, error)
 	QueryGroups(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.PrivacyGroup, error)
 
@@ -61,6 +71,9 @@ type GroupManager interface {
 	ReceiveMessages(ctx context.Context, dbTX persistence.DBTX, msgs []*pldapi.PrivacyGroupMessage) (results map[uuid.UUID]error, err error)
 	QueryMessages(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.PrivacyGroupMessage, error)
 	GetMessageByID(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID, failNotFound bool) (*pldapi.PrivacyGroupMessage, error)
+	RedactMessage(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID) (*pldapi.PrivacyGroupMessage, error)
+	SetMessagePolicy(ctx context.Context, dbTX persistence.DBTX, policy *pldapi.PrivacyGroupMessagePolicy) (*pldapi.PrivacyGroupMessagePolicy, error)
+	GetMessagePolicy(ctx context.Context, dbTX persistence.DBTX, domainName string, groupID pldtypes.HexBytes) (*pldapi.PrivacyGroupMessagePolicy, error)
 
 	CreateMessageListener(ctx context.Context, spec *pldapi.PrivacyGroupMessageListener) error
 	AddMessageReceiver(ctx context.Context, name string, r PrivacyGroupMessageReceiver) (PrivacyGroupMessageReceiverCloser, error)
@@ -17,6 +17,7 @@ package components
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
@@ -70,5 +71,32 @@ type KeyManager interface {
 
 	ReverseKeyLookup(ctx context.Context, dbTX persistence.DBTX, algorithm, verifierType, verifier string) (mapping *pldapi.KeyMappingAndVerifier, err error)
 
-	Sign(ctx context.Context, mapping *pldapi.KeyMappingAndVerifier, payloadType string, payload []byte) ([]byte, error)
+	// Every signing attempt is recorded in the signing audit log before returning. When called within a DB transaction
+	// the entry is written in that transaction, otherwise the call waits for the entry to be committed.
+	Sign(ctx context.Context, dbTX persistence.DBTX, mapping *pldapi.KeyMappingAndVerifier, payloadType string, payload []byte) ([]byte, error)
+
+	// Checks the target and value of a public transaction against the signing policy of the wallet that owns the
+	// signing key, before it is accepted for submission and again before it is signed (signing only sees the hash of the transaction)
+	CheckPublicTransactionPolicy(ctx context.Context, dbTX persistence.DBTX, from pldtypes.EthAddress, to *pldtypes.EthAddress, value *pldtypes.HexUint256) error
+}
+
+// Returned when a request is rejected by the signing policy of a wallet, rather than failing in the signer.
+// Retrying will not help, so callers should fail the transaction rather than retry.
+// Requests rejected by the rate limit of a policy are not policy errors, as they succeed on retry.
+type SigningPolicyError struct {
+	Wallet string
+	Cause  error
+}
+
+func (e *SigningPolicyError) Error() string {
+	return e.Cause.Error()
+}
+
+func (e *SigningPolicyError) Unwrap() error {
+	return e.Cause
+}
+
+func IsSigningPolicyError(err error) bool {
+	var spe *SigningPolicyError
+	return errors.As(err, &spe)
 }
@@ -92,10 +92,12 @@ type PrivateTxManager interface {
 	Subscribe(ctx context.Context, subscriber PrivateTxEventSubscriber)
 
 	NotifyFailedPublicTx(ctx context.Context, dbTX persistence.DBTX, confirms []*PublicTxMatch) error
+	// Writes the failure receipts for dispatched transactions that expired before their public transaction was assigned a nonce
+	NotifyExpiredTransactions(ctx context.Context, dbTX persistence.DBTX, receipts []*ReceiptInput) error
 
 	PrivateTransactionConfirmed(ctx context.Context, receipt *TxCompletion)
 
 	BuildStateDistributions(ctx context.Context, tx *PrivateTransaction) (*StateDistributionSet, error)
-	BuildNullifier(ctx context.Context, kr KeyResolver, s *StateDistributionWithData) (*NullifierUpsert, error)
+	BuildNullifier(ctx context.Context, dbTX persistence.DBTX, s *StateDistributionWithData) (*NullifierUpsert, error)
 	BuildNullifiers(ctx context.Context, distributions []*StateDistributionWithData) (nullifiers []*NullifierUpsert, err error)
 }
@@ -40,7 +40,9 @@ var PublicTxFilterFields filters.FieldSet = filters.FieldMap{
 
 type PublicTxSubmission struct {
 	Bindings             []*PaladinTXReference
-	pldapi.PublicTxInput // the request to create the transaction
+	pldapi.PublicTxInput                     // the request to create the transaction
+	NotBefore            *pldtypes.Timestamp // no nonce is assigned until this time
+	ExpiresAt            *pldtypes.Timestamp // no nonce is assigned after this time
 }
 
 type PaladinTXReference struct {
@@ -48,9 +50,16 @@ type PaladinTXReference struct {
 	TransactionType pldtypes.Enum[pldapi.TransactionType]
 }
 
+// The named lock held while assigning nonces to public transactions from a signing address, which
+// is also taken to check a transaction from that address has no nonce before failing it on expiry
+func PublicTxNonceLock(from pldtypes.EthAddress) string {
+	return "public_txn_nonces_" + from.String()
+}
+
 type PublicTxMatch struct {
 	PaladinTXReference
 	*blockindexer.IndexedTransactionNotify
+	Cancelled bool // the confirmed transaction was the zero value replacement submitted to cancel the original
 }
 
 type PublicTxManager interface {
@@ -60,6 +69,8 @@ type PublicTxManager interface {
 	QueryPublicTxForTransactions(ctx context.Context, dbTX persistence.DBTX, boundToTxns []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error)
 	QueryPublicTxWithBindings(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.PublicTxWithBinding, error)
 	GetPublicTransactionForHash(ctx context.Context, dbTX persistence.DBTX, hash pldtypes.Bytes32) (*pldapi.PublicTxWithBinding, error)
+	// Counts the transactions from a signing address that are not complete, including suspended transactions and those not yet assigned a nonce
+	CountPendingTransactions(ctx context.Context, dbTX persistence.DBTX, from pldtypes.EthAddress) (int64, error)
 
 	// Perform (potentially expensive) transaction level validation, such as gas estimation. Call before starting a DB transaction
 	ValidateTransaction(ctx context.Context, dbTX persistence.DBTX, transaction *PublicTxSubmission) error
@@ -72,4 +83,10 @@ type PublicTxManager interface {
 	NotifyConfirmPersisted(ctx context.Context, confirms []*PublicTxMatch)
 
 	UpdateTransaction(ctx context.Context, id uuid.UUID, pubTXID uint64, from *pldtypes.EthAddress, tx *pldapi.TransactionInput, publicTxData []byte, txmgrDBUpdate func(dbTX persistence.DBTX) error) error
+
+	// User actions on an incomplete transaction, identified by its signing address and assigned nonce.
+	// Cancel replaces the transaction with a zero value transfer to self at the same nonce, with a bumped fee.
+	SuspendTransaction(ctx context.Context, from pldtypes.EthAddress, nonce uint64) error
+	ResumeTransaction(ctx context.Context, from pldtypes.EthAddress, nonce uint64) error
+	CancelTransaction(ctx context.Context, from pldtypes.EthAddress, nonce uint64) error
 }
@@ -17,10 +17,13 @@ package components
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -48,11 +51,25 @@ type RegistryManager interface {
 	ConfiguredRegistries() map[string]*pldconf.PluginConfig
 	RegistryRegistered(name string, id uuid.UUID, toRegistry RegistryManagerToRegistry) (fromRegistry plugintk.RegistryCallbacks, err error)
 	GetNodeTransports(ctx context.Context, node string) ([]*RegistryNodeTransportEntry, error)
+	GetNodeProperties(ctx context.Context, node string) (map[string]string, error)
 	GetRegistry(ctx context.Context, name string) (Registry, error)
 }
 
+// Distinguishes a node that the registries do not know, or have revoked, from a failure to perform the lookup
+func IsNodeUnresolvable(err error) bool {
+	var pde i18n.PDError
+	if errors.As(err, &pde) {
+		switch pde.MessageKey() {
+		case msgs.MsgRegistryNodeEntiresNotFound, msgs.MsgRegistryNodeRevoked:
+			return true
+		}
+	}
+	return false
+}
+
 type Registry interface {
 	QueryEntries(ctx context.Context, dbTX persistence.DBTX, fActive pldapi.ActiveFilter, jq *query.QueryJSON) ([]*pldapi.RegistryEntry, error)
 	QueryEntriesWithProps(ctx context.Context, dbTX persistence.DBTX, fActive pldapi.ActiveFilter, jq *query.QueryJSON) ([]*pldapi.RegistryEntryWithProperties, error)
 	GetEntryProperties(ctx context.Context, dbTX persistence.DBTX, fActive pldapi.ActiveFilter, entityIDs ...pldtypes.HexBytes) ([]*pldapi.RegistryProperty, error)
+	QueryPropertyHistory(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.RegistryPropertyChange, error)
 }
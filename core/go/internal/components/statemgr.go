@@ -67,6 +67,7 @@ type StateManager interface {
 
 	// Get all states created, read or spent by a confirmed transaction
 	GetTransactionStates(ctx context.Context, dbTX persistence.DBTX, txID uuid.UUID) (*pldapi.TransactionStates, error)
+	GetTransactionStatesBatch(ctx context.Context, dbTX persistence.DBTX, txIDs []uuid.UUID) (map[uuid.UUID]*pldapi.TransactionStates, error)
 }
 
 type StateQueryOptions struct {
@@ -88,6 +88,11 @@ type PrivateTransaction struct {
 	// This enum describes the point in the private transaction flow where processing of the transaction should stop
 	Intent prototk.TransactionSpecification_Intent `json:"intent"`
 
+	// Scheduling constraints from the original submission - the transaction is not assembled before NotBefore,
+	// and is reverted if it has not been dispatched by ExpiresAt
+	NotBefore *pldtypes.Timestamp `json:"notBefore,omitempty"`
+	ExpiresAt *pldtypes.Timestamp `json:"expiresAt,omitempty"`
+
 	// ASSEMBLY PHASE: Items that get added to the transaction as it goes on its journey through
 	// assembly, signing and endorsement (possibly going back through the journey many times)
 	PreAssembly  *TransactionPreAssembly  `json:"pre_assembly"`  // the bit of the assembly phase state that can be retained across re-assembly
@@ -87,6 +87,16 @@ type TransportManager interface {
 	ConfiguredTransports() map[string]*pldconf.PluginConfig
 	TransportRegistered(name string, id uuid.UUID, toTransport TransportManagerToTransport) (fromTransport plugintk.TransportCallbacks, err error)
 	LocalNodeName() string
+	LocalTransportNames() []string
+	GetLocalTransportDetails(ctx context.Context, transportName string) (string, error)
+
+	// Returns the name and value of the registry property that publishes the public keys other nodes use to
+	// encrypt payloads to this node, or an empty name if end-to-end payload encryption is not enabled.
+	LocalNodeKeysProperty() (name, value string)
+
+	// Asynchronously re-resolves the transport details of every connected peer, closing the connection to
+	// any peer that can no longer be resolved. Called by the registry manager when entries are revoked.
+	RevalidatePeers()
 
 	// Send a message - performs a cache-optimized registry lookup of the transport to use for the node,
 	// then synchronously calls the transport to *accept* the message for sending.
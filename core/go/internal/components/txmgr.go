@@ -55,6 +55,8 @@ type TxCompletion struct {
 type ResolvedTransaction struct {
 	Transaction *pldapi.Transaction `json:"transaction"`
 	DependsOn   []uuid.UUID         `json:"dependsOn"`
+	NotBefore   *pldtypes.Timestamp `json:"notBefore,omitempty"`
+	ExpiresAt   *pldtypes.Timestamp `json:"expiresAt,omitempty"`
 	Function    *ResolvedFunction   `json:"function"`
 }
 
@@ -0,0 +1,275 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package deadletters provides the dead letter handling shared by the listeners of the TX manager
+// and the group manager. When a listener has a maxRedeliveries limit, a batch that fails delivery
+// more times than the limit is parked in the dead letter table so the listener can move its
+// checkpoint forwards. Dead letters are redelivered on request (with the same limit) and deleted
+// once delivered.
+package deadletters
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
+	"gorm.io/gorm"
+)
+
+// The types of listener that share the dead letter table
+const (
+	TypeReceipt         = "receipt"
+	TypeBlockchainEvent = "blockchain_event"
+	TypeMessage         = "message"
+)
+
+type PersistedDeadLetter struct {
+	ID           uuid.UUID          `gorm:"column:id;primaryKey"`
+	ListenerType string             `gorm:"column:listener_type"`
+	Listener     string             `gorm:"column:listener"`
+	Created      pldtypes.Timestamp `gorm:"column:created"`
+	BatchID      string             `gorm:"column:batch_id"`
+	Attempts     int                `gorm:"column:attempts"`
+	LastError    string             `gorm:"column:last_error"`
+	Replay       bool               `gorm:"column:replay"`
+	Batch        pldtypes.RawJSON   `gorm:"column:batch"`
+}
+
+func (PersistedDeadLetter) TableName() string {
+	return "listener_dead_letters"
+}
+
+var deadLetterFilters = filters.FieldMap{
+	"id":       filters.UUIDField("id"),
+	"created":  filters.TimestampField("created"),
+	"batchId":  filters.StringField("batch_id"),
+	"attempts": filters.Int64Field("attempts"),
+	"replay":   filters.BooleanField("replay"),
+}
+
+// Store reads and writes the dead letters for one type of listener
+type Store struct {
+	p            persistence.Persistence
+	listenerType string
+	notFound     i18n.ErrorMessageKey
+}
+
+// The notFound message is used to report a dead letter that does not exist, with the ID
+// and listener name as inserts
+func NewStore(p persistence.Persistence, listenerType string, notFound i18n.ErrorMessageKey) *Store {
+	return &Store{
+		p:            p,
+		listenerType: listenerType,
+		notFound:     notFound,
+	}
+}
+
+// The invalid message is used to report a negative limit, with the limit and listener name as inserts
+func ValidateMaxRedeliveries(ctx context.Context, invalid i18n.ErrorMessageKey, name string, maxRedeliveries *int) error {
+	if maxRedeliveries != nil && *maxRedeliveries < 0 {
+		return i18n.NewError(ctx, invalid, *maxRedeliveries, name)
+	}
+	return nil
+}
+
+// With no limit set, delivery is retried indefinitely
+func RedeliveriesExhausted(maxRedeliveries *int, attempt int) bool {
+	return maxRedeliveries != nil && attempt > *maxRedeliveries
+}
+
+// Retries delivery until it succeeds, or the redelivery limit is reached. In the latter case the
+// number of attempts and the last delivery error are returned. An error is only returned if the
+// context is cancelled before the outcome is known.
+func DeliverWithRedeliveryLimit(ctx context.Context, r *retry.Retry, maxRedeliveries *int, deliver func() error) (attempts int, deliveryErr error, err error) {
+	err = r.Do(ctx, func(attempt int) (retryable bool, err error) {
+		attempts = attempt
+		deliveryErr = deliver()
+		return !RedeliveriesExhausted(maxRedeliveries, attempt), deliveryErr
+	})
+	if err == nil {
+		return attempts, nil, nil
+	}
+	if ctx.Err() != nil {
+		return attempts, nil, err
+	}
+	return attempts, deliveryErr, nil
+}
+
+// Writes the batch to the dead letter table, retrying until it is stored or the context is cancelled
+func (s *Store) Write(ctx context.Context, r *retry.Retry, listener, batchID string, attempts int, deliveryErr error, batch any) error {
+	dl := &PersistedDeadLetter{
+		ID:           uuid.New(),
+		ListenerType: s.listenerType,
+		Listener:     listener,
+		Created:      pldtypes.TimestampNow(),
+		BatchID:      batchID,
+		Attempts:     attempts,
+		LastError:    deliveryErr.Error(),
+		Batch:        pldtypes.JSONString(batch),
+	}
+	log.L(ctx).Warnf("Batch %s for %s listener '%s' failed after %d attempts and will be dead-lettered as %s: %s", batchID, s.listenerType, listener, attempts, dl.ID, dl.LastError)
+	return r.Do(ctx, func(attempt int) (retryable bool, err error) {
+		return true, s.p.DB().
+			WithContext(ctx).
+			Create(dl).
+			Error
+	})
+}
+
+func (s *Store) Query(ctx context.Context, dbTX persistence.DBTX, listener string, jq *query.QueryJSON) ([]*pldapi.ListenerDeadLetter, error) {
+	qw := &filters.QueryWrapper[PersistedDeadLetter, pldapi.ListenerDeadLetter]{
+		P:           s.p,
+		Table:       "listener_dead_letters",
+		DefaultSort: "-created",
+		Filters:     deadLetterFilters,
+		Query:       jq,
+		Finalize: func(q *gorm.DB) *gorm.DB {
+			return q.Where("listener_type = ?", s.listenerType).Where("listener = ?", listener)
+		},
+		MapResult: func(pdl *PersistedDeadLetter) (*pldapi.ListenerDeadLetter, error) {
+			return &pldapi.ListenerDeadLetter{
+				ID:        pdl.ID,
+				Listener:  pdl.Listener,
+				Created:   pdl.Created,
+				BatchID:   pdl.BatchID,
+				Attempts:  pdl.Attempts,
+				LastError: pdl.LastError,
+				Replay:    pdl.Replay,
+				Batch:     pdl.Batch,
+			}, nil
+		},
+	}
+	return qw.Run(ctx, dbTX)
+}
+
+// The caller is responsible for notifying the listener, so it calls Replay
+func (s *Store) MarkForReplay(ctx context.Context, listener string, id uuid.UUID) error {
+	res := s.p.DB().
+		WithContext(ctx).
+		Model(&PersistedDeadLetter{}).
+		Where("id = ?", id).
+		Where("listener_type = ?", s.listenerType).
+		Where("listener = ?", listener).
+		Update("replay", true)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return i18n.NewError(ctx, s.notFound, id, listener)
+	}
+	return nil
+}
+
+func (s *Store) Discard(ctx context.Context, listener string, id uuid.UUID) error {
+	res := s.p.DB().
+		WithContext(ctx).
+		Where("id = ?", id).
+		Where("listener_type = ?", s.listenerType).
+		Where("listener = ?", listener).
+		Delete(&PersistedDeadLetter{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return i18n.NewError(ctx, s.notFound, id, listener)
+	}
+	return nil
+}
+
+func (s *Store) DeleteAll(ctx context.Context, listener string) error {
+	return s.p.DB().
+		WithContext(ctx).
+		Where("listener_type = ?", s.listenerType).
+		Where("listener = ?", listener).
+		Delete(&PersistedDeadLetter{}).
+		Error
+}
+
+// Redelivers each of the dead letters that have been marked for replay, in the order they were
+// created. Those that are delivered are deleted, and those that fail again are left in the table
+// with the replay flag cleared. An error is only returned if the context is cancelled.
+//
+// The redeliver function is called with the parsed batch, and returns the ID of the new batch
+// along with the function that delivers it.
+func Replay[T any](ctx context.Context, s *Store, r *retry.Retry, listener string, pageSize int, maxRedeliveries *int,
+	redeliver func(batch T) (batchID string, deliver func() error),
+) error {
+	for {
+		var page []*PersistedDeadLetter
+		err := r.Do(ctx, func(attempt int) (retryable bool, err error) {
+			return true, s.p.DB().
+				WithContext(ctx).
+				Where("listener_type = ?", s.listenerType).
+				Where("listener = ?", listener).
+				Where("replay = ?", true).
+				Order("created").
+				Limit(pageSize).
+				Find(&page).
+				Error
+		})
+		if err != nil || len(page) == 0 {
+			return err
+		}
+
+		for _, dl := range page {
+			var attempts int
+			var deliveryErr error
+			var batch T
+			batchID := dl.BatchID
+			if parseErr := json.Unmarshal(dl.Batch, &batch); parseErr != nil {
+				deliveryErr = parseErr
+			} else {
+				var deliver func() error
+				batchID, deliver = redeliver(batch)
+				log.L(ctx).Infof("Replaying dead letter %s as batch %s", dl.ID, batchID)
+				if attempts, deliveryErr, err = DeliverWithRedeliveryLimit(ctx, r, maxRedeliveries, deliver); err != nil {
+					return err
+				}
+			}
+			if err := s.completeReplay(ctx, r, dl, batchID, attempts, deliveryErr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Store) completeReplay(ctx context.Context, r *retry.Retry, dl *PersistedDeadLetter, batchID string, attempts int, deliveryErr error) error {
+	return r.Do(ctx, func(attempt int) (retryable bool, err error) {
+		q := s.p.DB().
+			WithContext(ctx).
+			Where("id = ?", dl.ID)
+		if deliveryErr == nil {
+			return true, q.Delete(&PersistedDeadLetter{}).Error
+		}
+		log.L(ctx).Warnf("Replay of dead letter %s failed: %s", dl.ID, deliveryErr)
+		return true, q.
+			Model(&PersistedDeadLetter{}).
+			Updates(map[string]any{
+				"batch_id":   batchID,
+				"attempts":   dl.Attempts + attempts,
+				"last_error": deliveryErr.Error(),
+				"replay":     false,
+			}).
+			Error
+	})
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package deadletters
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBatch struct {
+	Values []string `json:"values"`
+}
+
+func newTestStore(t *testing.T) (context.Context, *Store, *retry.Retry, func()) {
+	ctx := context.Background()
+	p, done, err := persistence.NewUnitTestPersistence(ctx, "deadletters")
+	require.NoError(t, err)
+	r := retry.NewRetryIndefinite(&pldconf.RetryConfig{InitialDelay: confutil.P("1ms")})
+	return ctx, NewStore(p, TypeReceipt, msgs.MsgTxMgrDeadLetterNotFound), r, done
+}
+
+func TestValidateMaxRedeliveries(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, ValidateMaxRedeliveries(ctx, msgs.MsgTxMgrInvalidMaxRedeliveries, "listener1", nil))
+	assert.NoError(t, ValidateMaxRedeliveries(ctx, msgs.MsgTxMgrInvalidMaxRedeliveries, "listener1", confutil.P(0)))
+	err := ValidateMaxRedeliveries(ctx, msgs.MsgTxMgrInvalidMaxRedeliveries, "listener1", confutil.P(-1))
+	assert.Regexp(t, "PD012266.*listener1", err)
+}
+
+func TestDeliverWithRedeliveryLimit(t *testing.T) {
+	ctx := context.Background()
+	r := retry.NewRetryIndefinite(&pldconf.RetryConfig{InitialDelay: confutil.P("1ms")})
+
+	calls := 0
+	attempts, deliveryErr, err := DeliverWithRedeliveryLimit(ctx, r, confutil.P(2), func() error {
+		calls++
+		if calls < 2 {
+			return fmt.Errorf("pop")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.NoError(t, deliveryErr)
+	assert.Equal(t, 2, attempts)
+
+	attempts, deliveryErr, err = DeliverWithRedeliveryLimit(ctx, r, confutil.P(2), func() error {
+		return fmt.Errorf("pop")
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, "pop", deliveryErr)
+	assert.Equal(t, 3, attempts)
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, deliveryErr, err = DeliverWithRedeliveryLimit(cancelledCtx, r, nil, func() error {
+		return fmt.Errorf("pop")
+	})
+	assert.Error(t, err)
+	assert.NoError(t, deliveryErr)
+}
+
+func TestDeadLetterLifecycle(t *testing.T) {
+	ctx, s, r, done := newTestStore(t)
+	defer done()
+
+	// Another listener type with the same listener name is kept separate
+	other := NewStore(s.p, TypeMessage, msgs.MsgPGroupsDeadLetterNotFound)
+	err := other.Write(ctx, r, "listener1", "1", 1, fmt.Errorf("pop"), &testBatch{})
+	require.NoError(t, err)
+
+	for i := range 3 {
+		err := s.Write(ctx, r, "listener1", fmt.Sprintf("%d", i), 2, fmt.Errorf("pop%d", i), &testBatch{Values: []string{fmt.Sprintf("v%d", i)}})
+		require.NoError(t, err)
+	}
+
+	dls, err := s.Query(ctx, s.p.NOTX(), "listener1", query.NewQueryBuilder().Limit(10).Sort("created").Query())
+	require.NoError(t, err)
+	require.Len(t, dls, 3)
+	assert.Equal(t, "listener1", dls[0].Listener)
+	assert.Equal(t, "0", dls[0].BatchID)
+	assert.Equal(t, 2, dls[0].Attempts)
+	assert.Equal(t, "pop0", dls[0].LastError)
+	assert.JSONEq(t, `{"values":["v0"]}`, dls[0].Batch.String())
+
+	err = s.MarkForReplay(ctx, "listener1", uuid.New())
+	assert.Regexp(t, "PD012265", err)
+	err = s.Discard(ctx, "listener1", uuid.New())
+	assert.Regexp(t, "PD012265", err)
+
+	// Replay the first two - one succeeds and one fails again
+	require.NoError(t, s.MarkForReplay(ctx, "listener1", dls[0].ID))
+	require.NoError(t, s.MarkForReplay(ctx, "listener1", dls[1].ID))
+	var replayed []string
+	err = Replay(ctx, s, r, "listener1", 1, confutil.P(1), func(batch *testBatch) (string, func() error) {
+		replayed = append(replayed, batch.Values...)
+		return fmt.Sprintf("replay%d", len(replayed)), func() error {
+			if batch.Values[0] == "v1" {
+				return fmt.Errorf("pop again")
+			}
+			return nil
+		}
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v0", "v1"}, replayed)
+
+	dls, err = s.Query(ctx, s.p.NOTX(), "listener1", query.NewQueryBuilder().Limit(10).Sort("created").Query())
+	require.NoError(t, err)
+	require.Len(t, dls, 2)
+	assert.Equal(t, "replay2", dls[0].BatchID)
+	assert.Equal(t, 4, dls[0].Attempts)
+	assert.Equal(t, "pop again", dls[0].LastError)
+	assert.False(t, dls[0].Replay)
+
+	require.NoError(t, s.Discard(ctx, "listener1", dls[1].ID))
+	require.NoError(t, s.DeleteAll(ctx, "listener1"))
+	dls, err = s.Query(ctx, s.p.NOTX(), "listener1", query.NewQueryBuilder().Limit(10).Query())
+	require.NoError(t, err)
+	assert.Empty(t, dls)
+
+	dls, err = other.Query(ctx, s.p.NOTX(), "listener1", query.NewQueryBuilder().Limit(10).Query())
+	require.NoError(t, err)
+	assert.Len(t, dls, 1)
+}
+
+func TestReplayBadBatch(t *testing.T) {
+	ctx, s, r, done := newTestStore(t)
+	defer done()
+
+	err := s.p.DB().Create(&PersistedDeadLetter{
+		ID:           uuid.New(),
+		ListenerType: TypeReceipt,
+		Listener:     "listener1",
+		Created:      pldtypes.TimestampNow(),
+		BatchID:      "1",
+		Attempts:     1,
+		LastError:    "pop",
+		Replay:       true,
+		Batch:        pldtypes.RawJSON(`["not an object"]`),
+	}).Error
+	require.NoError(t, err)
+
+	err = Replay(ctx, s, r, "listener1", 10, nil, func(batch *testBatch) (string, func() error) {
+		panic("should not be called")
+	})
+	require.NoError(t, err)
+
+	dls, err := s.Query(ctx, s.p.NOTX(), "listener1", query.NewQueryBuilder().Limit(1).Query())
+	require.NoError(t, err)
+	require.Len(t, dls, 1)
+	assert.False(t, dls[0].Replay)
+	assert.Equal(t, "1", dls[0].BatchID)
+	assert.Regexp(t, "cannot unmarshal", dls[0].LastError)
+}
+
+func TestReplayCancelled(t *testing.T) {
+	ctx, s, r, done := newTestStore(t)
+	defer done()
+
+	err := s.Write(ctx, r, "listener1", "1", 1, fmt.Errorf("pop"), &testBatch{Values: []string{"v1"}})
+	require.NoError(t, err)
+	err = s.p.DB().Model(&PersistedDeadLetter{}).Where("listener = ?", "listener1").Update("replay", true).Error
+	require.NoError(t, err)
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	err = Replay(cancelledCtx, s, r, "listener1", 10, nil, func(batch *testBatch) (string, func() error) {
+		return "2", func() error {
+			cancel()
+			return fmt.Errorf("pop")
+		}
+	})
+	assert.Error(t, err)
+}
+
+func TestDeadLetterDBErrors(t *testing.T) {
+	ctx := context.Background()
+	mp, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	s := NewStore(mp.P, TypeReceipt, msgs.MsgTxMgrDeadLetterNotFound)
+
+	mp.Mock.ExpectExec("UPDATE.*listener_dead_letters").WillReturnError(fmt.Errorf("pop"))
+	mp.Mock.ExpectExec("DELETE.*listener_dead_letters").WillReturnError(fmt.Errorf("pop"))
+
+	err = s.MarkForReplay(ctx, "listener1", uuid.New())
+	assert.Regexp(t, "pop", err)
+
+	err = s.Discard(ctx, "listener1", uuid.New())
+	assert.Regexp(t, "pop", err)
+
+	require.NoError(t, mp.Mock.ExpectationsWereMet())
+}
@@ -63,6 +63,7 @@ type domain struct {
 	initialized        atomic.Bool
 	initRetry          *retry.Retry
 	config             *prototk.DomainConfig
+	schemas            []components.Schema
 	schemasBySignature map[string]components.Schema
 	schemasByID        map[string]components.Schema
 	eventStream        *blockindexer.EventStream
@@ -132,6 +133,7 @@ func (d *domain) processDomainConfig(dbTX persistence.DBTX, confRes *prototk.Con
 	}
 
 	// Build the schema IDs to send back in the init
+	d.schemas = schemas
 	schemasProto := make([]*prototk.StateSchema, len(schemas))
 	for i, s := range schemas {
 		schemaID := s.ID()
@@ -451,7 +453,7 @@ func (d *domain) inlineEthSign(ctx context.Context, payload []byte, keyIdentifie
 
 	var signatureRSV []byte
 	if err == nil {
-		signatureRSV, err = d.dm.keyManager.Sign(ctx, resolvedKey, signpayloads.OPAQUE_TO_RSV, pldtypes.HexBytes(sigPayloadHash.Sum(nil)))
+		signatureRSV, err = d.dm.keyManager.Sign(ctx, d.dm.persistence.NOTX(), resolvedKey, signpayloads.OPAQUE_TO_RSV, pldtypes.HexBytes(sigPayloadHash.Sum(nil)))
 	}
 
 	if err == nil {
@@ -718,6 +720,10 @@ func (d *domain) toEndorsableListBase(states []*pldapi.StateBase) []*prototk.End
 	return endorsableList
 }
 
+func (d *domain) StateSchemas() []components.Schema {
+	return d.schemas
+}
+
 func (d *domain) CustomHashFunction() bool {
 	// note config assured to be non-nil by GetDomainByName() not returning a domain until init complete
 	return d.config.CustomHashFunction
@@ -865,25 +871,31 @@ func (d *domain) InitPrivacyGroup(ctx context.Context, id pldtypes.HexBytes, gen
 		return nil, err
 	}
 
+	return d.mapPreparedTransaction(ctx, res.Transaction)
+
+}
+
+func (d *domain) mapPreparedTransaction(ctx context.Context, ptx *prototk.PreparedTransaction) (*pldapi.TransactionInput, error) {
 	signer := ""
-	if res.Transaction.RequiredSigner != nil {
-		signer = *res.Transaction.RequiredSigner
+	if ptx.RequiredSigner != nil {
+		signer = *ptx.RequiredSigner
 	}
 	txType := pldapi.TransactionTypePrivate.Enum()
-	if res.Transaction.Type == prototk.PreparedTransaction_PUBLIC {
+	if ptx.Type == prototk.PreparedTransaction_PUBLIC {
 		txType = pldapi.TransactionTypePublic.Enum()
 	}
 
 	var functionABI abi.Entry
-	if err := json.Unmarshal(([]byte)(res.Transaction.FunctionAbiJson), &functionABI); err != nil {
+	if err := json.Unmarshal(([]byte)(ptx.FunctionAbiJson), &functionABI); err != nil {
 		return nil, i18n.WrapError(ctx, err, msgs.MsgDomainPrivateAbiJsonInvalid)
 	}
 	var optionalContractAddr *pldtypes.EthAddress
-	if res.Transaction.ContractAddress != nil {
-		optionalContractAddr, err = pldtypes.ParseEthAddress(*res.Transaction.ContractAddress)
+	if ptx.ContractAddress != nil {
+		addr, err := pldtypes.ParseEthAddress(*ptx.ContractAddress)
 		if err != nil {
 			return nil, err
 		}
+		optionalContractAddr = addr
 	}
 
 	return &pldapi.TransactionInput{
@@ -891,10 +903,9 @@ func (d *domain) InitPrivacyGroup(ctx context.Context, id pldtypes.HexBytes, gen
 			From:   signer,
 			To:     optionalContractAddr,
 			Type:   txType,
-			Data:   pldtypes.RawJSON(res.Transaction.ParamsJson),
+			Data:   pldtypes.RawJSON(ptx.ParamsJson),
 			Domain: d.name,
 		},
 		ABI: abi.ABI{&functionABI},
 	}, nil
-
 }
@@ -294,6 +294,8 @@ func TestDomainInitStates(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, td.d, byAddr)
 	assert.True(t, td.d.Initialized())
+	require.Len(t, td.d.StateSchemas(), 1)
+	assert.Equal(t, td.d.schemasByID[td.d.StateSchemas()[0].ID().String()], td.d.StateSchemas()[0])
 
 }
 
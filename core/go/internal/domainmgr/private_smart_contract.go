@@ -26,6 +26,7 @@ import (
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/internal/plugins"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
@@ -803,3 +804,33 @@ func (dc *domainContract) WrapPrivacyGroupEVMTX(ctx context.Context, pg *pldapi.
 	return ptx, nil
 
 }
+
+func (dc *domainContract) UpdatePrivacyGroupMembers(ctx context.Context, pg *pldapi.PrivacyGroup, previousMembers []string) (*pldapi.TransactionInput, error) {
+
+	// The privacy group we pass in has the new list of members
+	res, err := dc.api.UpdatePrivacyGroupMembers(ctx, &prototk.UpdatePrivacyGroupMembersRequest{
+		PrivacyGroup:    mapPrivacyGroupToProto(pg.ID, pg.GenesisStateData()),
+		PreviousMembers: previousMembers,
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    dc.info.Address.String(),
+			ContractConfigJson: dc.config.ContractConfigJson,
+		},
+	})
+	if plugins.IsNotSupported(err) {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgPGroupsMembersChangeNotSupported, dc.d.name)
+	}
+	if err != nil || res.Transaction == nil {
+		return nil, err
+	}
+
+	tx, err := dc.d.mapPreparedTransaction(ctx, res.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	if tx.To == nil {
+		pscAddr := dc.Address()
+		tx.To = &pscAddr
+	}
+	return tx, nil
+
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/keymanager"
+	"github.com/kaleido-io/paladin/core/internal/plugins"
 	"github.com/kaleido-io/paladin/core/mocks/componentsmocks"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -1772,3 +1773,100 @@ func TestWrapPGBadData(t *testing.T) {
 	_, err := goodWrapPGTxCall(psc, pldtypes.RandBytes32())
 	require.Regexp(t, "PD011612", err)
 }
+
+func TestUpdatePGMembersOk(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
+	defer done()
+	assert.Nil(t, td.d.initError.Load())
+
+	psc := goodPSC(t, td)
+
+	td.tp.Functions.UpdatePrivacyGroupMembers = func(ctx context.Context, req *prototk.UpdatePrivacyGroupMembersRequest) (*prototk.UpdatePrivacyGroupMembersResponse, error) {
+		require.Equal(t, []string{"me@node1", "you@node2"}, req.PrivacyGroup.Members)
+		require.Equal(t, []string{"me@node1"}, req.PreviousMembers)
+		require.Equal(t, psc.Address().String(), req.ContractInfo.ContractAddress)
+		return &prototk.UpdatePrivacyGroupMembersResponse{
+			Transaction: &prototk.PreparedTransaction{
+				Type:            prototk.PreparedTransaction_PUBLIC,
+				RequiredSigner:  confutil.P("pgroup.signer"),
+				FunctionAbiJson: `{"type":"function","name":"setMembers","inputs":[{"name":"members","type":"string[]"}]}`,
+				ParamsJson:      `{"members":["me@node1","you@node2"]}`,
+			},
+		}, nil
+	}
+
+	tx, err := psc.UpdatePrivacyGroupMembers(td.ctx, &pldapi.PrivacyGroup{
+		ID:      pldtypes.RandBytes(32),
+		Name:    "pg1",
+		Members: []string{"me@node1", "you@node2"},
+	}, []string{"me@node1"})
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.TransactionTypePublic, tx.Type.V())
+	assert.Equal(t, "pgroup.signer", tx.From)
+	assert.Equal(t, psc.Address(), *tx.To)
+	assert.Equal(t, "setMembers", tx.ABI[0].Name)
+	assert.JSONEq(t, `{"members":["me@node1","you@node2"]}`, tx.Data.String())
+}
+
+func TestUpdatePGMembersNoTx(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
+	defer done()
+	assert.Nil(t, td.d.initError.Load())
+
+	psc := goodPSC(t, td)
+
+	td.tp.Functions.UpdatePrivacyGroupMembers = func(ctx context.Context, req *prototk.UpdatePrivacyGroupMembersRequest) (*prototk.UpdatePrivacyGroupMembersResponse, error) {
+		return &prototk.UpdatePrivacyGroupMembersResponse{}, nil
+	}
+
+	tx, err := psc.UpdatePrivacyGroupMembers(td.ctx, &pldapi.PrivacyGroup{ID: pldtypes.RandBytes(32)}, []string{"me@node1"})
+	require.NoError(t, err)
+	assert.Nil(t, tx)
+}
+
+func TestUpdatePGMembersFail(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
+	defer done()
+	assert.Nil(t, td.d.initError.Load())
+
+	psc := goodPSC(t, td)
+
+	td.tp.Functions.UpdatePrivacyGroupMembers = func(ctx context.Context, req *prototk.UpdatePrivacyGroupMembersRequest) (*prototk.UpdatePrivacyGroupMembersResponse, error) {
+		return nil, fmt.Errorf("pop")
+	}
+
+	_, err := psc.UpdatePrivacyGroupMembers(td.ctx, &pldapi.PrivacyGroup{ID: pldtypes.RandBytes(32)}, []string{"me@node1"})
+	require.Regexp(t, "pop", err)
+}
+
+func TestUpdatePGMembersNotSupported(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
+	defer done()
+	assert.Nil(t, td.d.initError.Load())
+
+	psc := goodPSC(t, td)
+
+	td.tp.Functions.UpdatePrivacyGroupMembers = func(ctx context.Context, req *prototk.UpdatePrivacyGroupMembersRequest) (*prototk.UpdatePrivacyGroupMembersResponse, error) {
+		return nil, plugins.NewPluginError(prototk.Header_NOT_SUPPORTED, fmt.Errorf("pop"))
+	}
+
+	_, err := psc.UpdatePrivacyGroupMembers(td.ctx, &pldapi.PrivacyGroup{ID: pldtypes.RandBytes(32)}, []string{"me@node1"})
+	require.Regexp(t, "PD012537.*test1.*pop", err)
+}
+
+func TestUpdatePGMembersBadData(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
+	defer done()
+	assert.Nil(t, td.d.initError.Load())
+
+	psc := goodPSC(t, td)
+
+	td.tp.Functions.UpdatePrivacyGroupMembers = func(ctx context.Context, req *prototk.UpdatePrivacyGroupMembersRequest) (*prototk.UpdatePrivacyGroupMembersResponse, error) {
+		return &prototk.UpdatePrivacyGroupMembersResponse{
+			Transaction: &prototk.PreparedTransaction{},
+		}, nil
+	}
+
+	_, err := psc.UpdatePrivacyGroupMembers(td.ctx, &pldapi.PrivacyGroup{ID: pldtypes.RandBytes(32)}, []string{"me@node1"})
+	require.Regexp(t, "PD011607", err)
+}
@@ -34,6 +34,8 @@ func (gm *groupManager) RPCModule() *rpcserver.RPCModule {
 func (gm *groupManager) initRPC() {
 	gm.rpcModule = rpcserver.NewRPCModule("pgroup").
 		Add("pgroup_createGroup", gm.rpcCreateGroup()).
+		Add("pgroup_addMembers", gm.rpcAddMembers()).
+		Add("pgroup_removeMembers", gm.rpcRemoveMembers()).
 		Add("pgroup_getGroupById", gm.rpcGetGroupByID()).
 		Add("pgroup_getGroupByAddress", gm.rpcGetGroupByAddress()).
 		Add("pgroup_queryGroups", gm.rpcQueryGroups()).
@@ -46,9 +48,16 @@ func (gm *groupManager) initRPC() {
 		Add("pgroup_startMessageListener", gm.rpcStartMessageListener()).
 		Add("pgroup_stopMessageListener", gm.rpcStopMessageListener()).
 		Add("pgroup_deleteMessageListener", gm.rpcDeleteMessageListener()).
+		Add("pgroup_queryMessageListenerDeadLetters", gm.rpcQueryMessageListenerDeadLetters()).
+		Add("pgroup_replayMessageListenerDeadLetter", gm.rpcReplayMessageListenerDeadLetter()).
+		Add("pgroup_discardMessageListenerDeadLetter", gm.rpcDiscardMessageListenerDeadLetter()).
+		Add("pgroup_resetMessageListenerCheckpoint", gm.rpcResetMessageListenerCheckpoint()).
 		Add("pgroup_sendMessage", gm.rpcSendMessage()).
 		Add("pgroup_getMessageById", gm.rpcGetMessageByID()).
 		Add("pgroup_queryMessages", gm.rpcQueryMessages()).
+		Add("pgroup_redactMessage", gm.rpcRedactMessage()).
+		Add("pgroup_setMessagePolicy", gm.rpcSetMessagePolicy()).
+		Add("pgroup_getMessagePolicy", gm.rpcGetMessagePolicy()).
 		AddAsync(gm.rpcEventStreams)
 }
 
@@ -62,6 +71,26 @@ func (gm *groupManager) rpcCreateGroup() rpcserver.RPCHandler {
 	})
 }
 
+func (gm *groupManager) rpcAddMembers() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, spec pldapi.PrivacyGroupMembersInput) (group *pldapi.PrivacyGroup, err error) {
+		err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			group, err = gm.AddMembers(ctx, dbTX, &spec)
+			return err
+		})
+		return group, err
+	})
+}
+
+func (gm *groupManager) rpcRemoveMembers() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, spec pldapi.PrivacyGroupMembersInput) (group *pldapi.PrivacyGroup, err error) {
+		err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			group, err = gm.RemoveMembers(ctx, dbTX, &spec)
+			return err
+		})
+		return group, err
+	})
+}
+
 func (gm *groupManager) rpcGetGroupByID() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod2(func(ctx context.Context, domainName string, id pldtypes.HexBytes) (*pldapi.PrivacyGroup, error) {
 		return gm.GetGroupByID(ctx, gm.p.NOTX(), domainName, id)
@@ -125,6 +154,32 @@ func (gm *groupManager) rpcQueryMessages() rpcserver.RPCHandler {
 	})
 }
 
+func (gm *groupManager) rpcRedactMessage() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, id uuid.UUID) (msg *pldapi.PrivacyGroupMessage, err error) {
+		err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			msg, err = gm.RedactMessage(ctx, dbTX, id)
+			return err
+		})
+		return msg, err
+	})
+}
+
+func (gm *groupManager) rpcSetMessagePolicy() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, policy *pldapi.PrivacyGroupMessagePolicy) (result *pldapi.PrivacyGroupMessagePolicy, err error) {
+		err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			result, err = gm.SetMessagePolicy(ctx, dbTX, policy)
+			return err
+		})
+		return result, err
+	})
+}
+
+func (gm *groupManager) rpcGetMessagePolicy() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context, domainName string, id pldtypes.HexBytes) (*pldapi.PrivacyGroupMessagePolicy, error) {
+		return gm.GetMessagePolicy(ctx, gm.p.NOTX(), domainName, id)
+	})
+}
+
 func (gm *groupManager) rpcCreateMessageListener() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		listener *pldapi.PrivacyGroupMessageListener,
@@ -173,3 +228,39 @@ func (gm *groupManager) rpcDeleteMessageListener() rpcserver.RPCHandler {
 		return true, gm.DeleteMessageListener(ctx, name)
 	})
 }
+
+func (gm *groupManager) rpcQueryMessageListenerDeadLetters() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		query query.QueryJSON,
+	) ([]*pldapi.ListenerDeadLetter, error) {
+		return gm.QueryMessageListenerDeadLetters(ctx, gm.p.NOTX(), name, &query)
+	})
+}
+
+func (gm *groupManager) rpcReplayMessageListenerDeadLetter() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		id uuid.UUID,
+	) (bool, error) {
+		return true, gm.ReplayMessageListenerDeadLetter(ctx, name, id)
+	})
+}
+
+func (gm *groupManager) rpcDiscardMessageListenerDeadLetter() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		id uuid.UUID,
+	) (bool, error) {
+		return true, gm.DiscardMessageListenerDeadLetter(ctx, name, id)
+	})
+}
+
+func (gm *groupManager) rpcResetMessageListenerCheckpoint() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		sequence uint64,
+	) (bool, error) {
+		return true, gm.ResetMessageListenerCheckpoint(ctx, name, sequence)
+	})
+}
@@ -24,9 +24,11 @@ import (
 	"github.com/go-resty/resty/v2"
 	"github.com/google/uuid"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/mocks/componentsmocks"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
@@ -283,6 +285,24 @@ func TestPrivacyGroupRPCLifecycleRealDB(t *testing.T) {
 	require.Len(t, msgByCID, 1)
 	require.Equal(t, msgID, msgByCID[0].ID)
 
+	// Set and get a message policy
+	policy, err := pgroupRPC.SetMessagePolicy(ctx, &pldapi.PrivacyGroupMessagePolicy{
+		Domain:    "domain1",
+		Group:     groupID,
+		Retention: pldapi.PrivacyGroupMessageRetention{MaxCount: confutil.P(100)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 100, *policy.Retention.MaxCount)
+	policy, err = pgroupRPC.GetMessagePolicy(ctx, "domain1", groupID)
+	require.NoError(t, err)
+	require.Equal(t, 100, *policy.Retention.MaxCount)
+
+	// Redact the message
+	redacted, err := pgroupRPC.RedactMessage(ctx, msgID)
+	require.NoError(t, err)
+	require.NotNil(t, redacted.Redacted)
+	require.Equal(t, "null", redacted.Data.String())
+
 }
 
 func TestRCPMessageListenersCRUDRealDB(t *testing.T) {
@@ -384,3 +404,98 @@ func TestRCPMessageListenersCRUDRealDB(t *testing.T) {
 	require.NotNil(t, gm.messageListeners["listener1"].done)
 
 }
+
+func TestRPCUpdateMembersBadInput(t *testing.T) {
+	ctx, gm, _, done := newTestGroupManager(t, true, &pldconf.GroupManagerConfig{})
+	defer done()
+
+	client := newTestRPCServer(t, ctx, gm)
+	pgroupRPC := pldclient.Wrap(client).PrivacyGroups()
+
+	_, err := pgroupRPC.AddMembers(ctx, &pldapi.PrivacyGroupMembersInput{Domain: "domain1"})
+	assert.Regexp(t, "PD012504", err)
+
+	_, err = pgroupRPC.RemoveMembers(ctx, &pldapi.PrivacyGroupMembersInput{Group: pldtypes.RandBytes(32)})
+	assert.Regexp(t, "PD012505", err)
+}
+
+func TestRPCAddRemoveMembersRealDB(t *testing.T) {
+	contractAddr := pldtypes.RandAddress()
+	ctx, gm, mc, done := newTestGroupManager(t, true, &pldconf.GroupManagerConfig{}, func(mc *mockComponents, conf *pldconf.GroupManagerConfig) {
+		mc.registryManager.On("GetNodeTransports", mock.Anything, mock.Anything).
+			Return([]*components.RegistryNodeTransportEntry{ /* contents not checked */ }, nil)
+	})
+	defer done()
+
+	client := newTestRPCServer(t, ctx, gm)
+	pgroupRPC := pldclient.Wrap(client).PrivacyGroups()
+
+	groupIDs := createTestGroups(t, ctx, mc, gm, &pldapi.PrivacyGroupInput{
+		Domain:  "domain1",
+		Name:    "pg1",
+		Members: []string{"me@node1", "you@node2"},
+	})
+	groupID := groupIDs[0]
+
+	pg, err := gm.GetGroupByID(ctx, gm.p.NOTX(), "domain1", groupID)
+	require.NoError(t, err)
+	err = gm.p.DB().Exec(`INSERT INTO transaction_receipts ("transaction", domain, indexed, success, contract_address) VALUES ( ?, ?, ?, ?, ? )`,
+		pg.GenesisTransaction, "domain1", pldtypes.TimestampNow(), true, contractAddr,
+	).Error
+	require.NoError(t, err)
+
+	genesisSchema := componentsmocks.NewSchema(t)
+	genesisSchema.On("ID").Return(pg.GenesisSchema)
+	mc.domain.On("StateSchemas").Return([]components.Schema{genesisSchema})
+
+	psc := componentsmocks.NewDomainSmartContract(t)
+	psc.On("Address").Return(*contractAddr)
+	psc.On("Domain").Return(mc.domain)
+	mc.domainManager.On("GetSmartContractByAddress", mock.Anything, mock.Anything, *contractAddr).Return(psc, nil)
+	mc.transportManager.On("SendReliable", mock.Anything, mock.Anything, mock.MatchedBy(func(rm []*pldapi.ReliableMessage) bool {
+		return rm[0].MessageType.V() == pldapi.RMTPrivacyGroupMembers
+	})).Return(nil)
+
+	// Add a member, with the domain submitting a transaction to reconfigure the contract
+	psc.On("UpdatePrivacyGroupMembers", mock.Anything, mock.Anything, mock.Anything).Return(&pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			Type: pldapi.TransactionTypePrivate.Enum(),
+		},
+	}, nil).Once()
+	updated, err := pgroupRPC.AddMembers(ctx, &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"them@node3"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"me@node1", "you@node2", "them@node3"}, updated.Members)
+	require.Equal(t, 1, updated.MembersRevision)
+	require.NotNil(t, updated.MembersTransaction)
+
+	// Remove it again, with no transaction required by the domain
+	psc.On("UpdatePrivacyGroupMembers", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	updated, err = pgroupRPC.RemoveMembers(ctx, &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"them@node3"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"me@node1", "you@node2"}, updated.Members)
+	require.Equal(t, 2, updated.MembersRevision)
+	require.Nil(t, updated.MembersTransaction)
+
+	// A domain that cannot reconfigure its contracts rejects the change, and nothing is updated
+	psc.On("UpdatePrivacyGroupMembers", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, i18n.NewError(ctx, msgs.MsgPGroupsMembersChangeNotSupported, "domain1")).Once()
+	_, err = pgroupRPC.AddMembers(ctx, &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"them@node3"},
+	})
+	assert.Regexp(t, "PD012537.*domain1", err)
+
+	pg, err = pgroupRPC.GetGroupById(ctx, "domain1", groupID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"me@node1", "you@node2"}, pg.Members)
+	require.Equal(t, 2, pg.MembersRevision)
+}
@@ -20,11 +20,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/deadletters"
 	"github.com/kaleido-io/paladin/core/internal/filters"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
@@ -57,20 +59,25 @@ type groupManager struct {
 	rpcModule *rpcserver.RPCModule
 	conf      *pldconf.GroupManagerConfig
 
-	deployedPGCache  cache.Cache[string, *pldapi.PrivacyGroup]
-	stateManager     components.StateManager
-	txManager        components.TXManager
-	domainManager    components.DomainManager
-	transportManager components.TransportManager
-	registryManager  components.RegistryManager
-	p                persistence.Persistence
-	rpcEventStreams  *rpcEventStreams
-
+	deployedPGCache    cache.Cache[string, *pldapi.PrivacyGroup]
+	messagePolicyCache cache.Cache[string, *messagePolicy]
+	stateManager       components.StateManager
+	txManager          components.TXManager
+	domainManager      components.DomainManager
+	transportManager   components.TransportManager
+	registryManager    components.RegistryManager
+	p                  persistence.Persistence
+	rpcEventStreams    *rpcEventStreams
+
+	catchupStatesPageSize        int
 	messagesRetry                *retry.Retry
 	messagesReadPageSize         int
 	messageListenersLoadPageSize int
 	messageListenerLock          sync.Mutex
 	messageListeners             map[string]*messageListener
+	messageDeadLetters           *deadletters.Store
+	messagePruneInterval         time.Duration
+	messagePrunerDone            chan struct{}
 }
 
 type referencedReceipt struct {
@@ -92,6 +99,8 @@ type persistedGroup struct {
 	GenesisSalt   pldtypes.Bytes32   `gorm:"column:genesis_salt"`
 	Properties    pldtypes.RawJSON   `gorm:"column:properties"`
 	Configuration pldtypes.RawJSON   `gorm:"column:configuration"`
+	MembersRev    int                `gorm:"column:members_revision"`
+	MembersTX     *uuid.UUID         `gorm:"column:members_tx"`
 	Receipt       *referencedReceipt `gorm:"foreignKey:genesis_tx;references:transaction"`
 }
 
@@ -112,11 +121,13 @@ func (pgm persistedGroupMember) TableName() string {
 
 func NewGroupManager(bgCtx context.Context, conf *pldconf.GroupManagerConfig) components.GroupManager {
 	gm := &groupManager{
-		conf:             conf,
-		deployedPGCache:  cache.NewCache[string, *pldapi.PrivacyGroup](&conf.Cache, &pldconf.GroupManagerDefaults.Cache),
-		messageListeners: make(map[string]*messageListener),
+		conf:               conf,
+		deployedPGCache:    cache.NewCache[string, *pldapi.PrivacyGroup](&conf.Cache, &pldconf.GroupManagerDefaults.Cache),
+		messagePolicyCache: cache.NewCache[string, *messagePolicy](&conf.Cache, &pldconf.GroupManagerDefaults.Cache),
+		messageListeners:   make(map[string]*messageListener),
 	}
 	gm.messagesInit()
+	gm.catchupStatesPageSize = 100 /* not currently tunable */
 	gm.rpcEventStreams = newRPCEventStreams(gm)
 	gm.bgCtx, gm.cancelCtx = context.WithCancel(bgCtx)
 	return gm
@@ -134,6 +145,7 @@ func (gm *groupManager) PostInit(c components.AllComponents) error {
 	gm.txManager = c.TxManager()
 	gm.domainManager = c.DomainManager()
 	gm.p = c.Persistence()
+	gm.messageDeadLetters = deadletters.NewStore(gm.p, deadletters.TypeMessage, msgs.MsgPGroupsDeadLetterNotFound)
 	gm.transportManager = c.TransportManager()
 	gm.registryManager = c.RegistryManager()
 	return gm.loadMessageListeners()
@@ -141,6 +153,8 @@ func (gm *groupManager) PostInit(c components.AllComponents) error {
 
 func (gm *groupManager) Start() error {
 	gm.startMessageListeners()
+	gm.messagePrunerDone = make(chan struct{})
+	go gm.messagePruner()
 	return nil
 }
 
@@ -148,6 +162,9 @@ func (gm *groupManager) Stop() {
 	gm.rpcEventStreams.stop()
 	gm.stopMessageListeners()
 	gm.cancelCtx()
+	if gm.messagePrunerDone != nil {
+		<-gm.messagePrunerDone
+	}
 }
 
 func (gm *groupManager) validateMembers(ctx context.Context, members []string, checkConnectivity bool) (remoteMembers map[string][]string, err error) {
@@ -409,6 +426,8 @@ func (dbPG *persistedGroup) mapToAPI() *pldapi.PrivacyGroup {
 		GenesisSalt:        dbPG.GenesisSalt,
 		GenesisSchema:      dbPG.GenesisSchema,
 		GenesisTransaction: dbPG.GenesisTX,
+		MembersRevision:    dbPG.MembersRev,
+		MembersTransaction: dbPG.MembersTX,
 	}
 	if dbPG.Receipt != nil {
 		pg.ContractAddress = dbPG.Receipt.ContractAddress
@@ -432,7 +451,8 @@ func (gm *groupManager) GetGroupByID(ctx context.Context, dbTX persistence.DBTX,
 
 	pg = groups[0]
 
-	// ONLY cache if there is a contract address set (that one-time bind is immutable, but until it happens we need to do the DB JOIN)
+	// ONLY cache if there is a contract address set (that one-time bind is immutable, but until it happens we need to do the DB JOIN).
+	// Changes to the members evict the entry from the cache.
 	if pg.ContractAddress != nil {
 		gm.deployedPGCache.Set(groupIDStr, pg)
 	}
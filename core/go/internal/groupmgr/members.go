@@ -0,0 +1,398 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package groupmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"gorm.io/gorm"
+)
+
+// The member list of a privacy group can be changed after genesis, once the contract for the group has been
+// deployed. The ID of the group does not change - it is always the ID of the original genesis state.
+//
+// Each change:
+//   - Asks the domain for the transaction (if any) to reconfigure the contract for the new members
+//   - Writes an updated copy of the genesis state, with the new member list, against the contract address
+//   - Increments the members revision of the group
+//   - Distributes the updated genesis state to every remote member, before and after the change
+//   - Sends new members the original genesis state, followed by all the available states of the contract
+//     so they can catch up and participate in transactions
+//   - Removes any members that are no longer in the group from the topic ACLs of the message policy
+//
+// Changes are only accepted from a node that hosts a current member of the group.
+
+func (gm *groupManager) AddMembers(ctx context.Context, dbTX persistence.DBTX, spec *pldapi.PrivacyGroupMembersInput) (*pldapi.PrivacyGroup, error) {
+	return gm.updateMembers(ctx, dbTX, spec, func(pg *pldapi.PrivacyGroup) ([]string, error) {
+		newMembers := slices.Clone(pg.Members)
+		for _, m := range spec.Members {
+			if slices.Contains(newMembers, m) {
+				return nil, i18n.NewError(ctx, msgs.MsgPGroupsAlreadyMember, m, pg.ID)
+			}
+			newMembers = append(newMembers, m)
+		}
+		return newMembers, nil
+	})
+}
+
+func (gm *groupManager) RemoveMembers(ctx context.Context, dbTX persistence.DBTX, spec *pldapi.PrivacyGroupMembersInput) (*pldapi.PrivacyGroup, error) {
+	return gm.updateMembers(ctx, dbTX, spec, func(pg *pldapi.PrivacyGroup) ([]string, error) {
+		for _, m := range spec.Members {
+			if !slices.Contains(pg.Members, m) {
+				return nil, i18n.NewError(ctx, msgs.MsgPGroupsNotMember, m, pg.ID)
+			}
+		}
+		newMembers := make([]string, 0, len(pg.Members))
+		for _, m := range pg.Members {
+			if !slices.Contains(spec.Members, m) {
+				newMembers = append(newMembers, m)
+			}
+		}
+		return newMembers, nil
+	})
+}
+
+func (gm *groupManager) updateMembers(ctx context.Context, dbTX persistence.DBTX, spec *pldapi.PrivacyGroupMembersInput, buildMembers func(pg *pldapi.PrivacyGroup) ([]string, error)) (*pldapi.PrivacyGroup, error) {
+
+	if spec.Domain == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsNoDomain)
+	}
+
+	if len(spec.Group) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsNoGroupID)
+	}
+
+	if len(spec.Members) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsNoMembersToChange)
+	}
+
+	// We bypass the cache, as we need the latest revision of the members from the DB
+	groups, err := gm.QueryGroups(ctx, dbTX, query.NewQueryBuilder().Equal("domain", spec.Domain).Equal("id", spec.Group).Limit(1).Query())
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsGroupNotFound, spec.Group)
+	}
+	pg := groups[0]
+	if pg.ContractAddress == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsNotReady, spec.Group, pg.GenesisTransaction)
+	}
+
+	newMembers, err := buildMembers(pg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := gm.validateMembers(ctx, newMembers, true /* check connectivity */); err != nil {
+		return nil, err
+	}
+
+	psc, err := gm.domainManager.GetSmartContractByAddress(ctx, dbTX, *pg.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *pg
+	updated.Members = newMembers
+	updated.MembersRevision = pg.MembersRevision + 1
+	updated.MembersTransaction = nil
+
+	// The domain decides if a transaction is required to reconfigure the contract for the new members
+	tx, err := psc.UpdatePrivacyGroupMembers(ctx, &updated, pg.Members)
+	if err != nil {
+		return nil, err
+	}
+	if tx != nil {
+		if spec.TransactionOptions != nil {
+			tx.IdempotencyKey = spec.TransactionOptions.IdempotencyKey
+			tx.PublicTxOptions = spec.TransactionOptions.PublicTxOptions
+		}
+		if tx.From == "" {
+			tx.From = fmt.Sprintf("domains.%s.pgroupmembers.%s", spec.Domain, pg.ID)
+		}
+		txIDs, err := gm.txManager.SendTransactions(ctx, dbTX, tx)
+		if err != nil {
+			return nil, err
+		}
+		updated.MembersTransaction = &txIDs[0]
+	}
+
+	// The updated genesis is stored against the contract address, as it is only valid once the contract exists
+	states, err := gm.stateManager.WriteReceivedStates(ctx, dbTX, pg.Domain, []*components.StateUpsertOutsideContext{
+		{
+			SchemaID:        pg.GenesisSchema,
+			ContractAddress: pg.ContractAddress,
+			Data:            pldtypes.JSONString(updated.GenesisStateData()),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	written, err := gm.writeMembers(ctx, dbTX, &updated, true)
+	if err != nil {
+		return nil, err
+	}
+	if !written {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsMembersConcurrentUpdate, pg.ID, pg.MembersRevision)
+	}
+
+	if err := gm.distributeMembers(ctx, dbTX, psc, pg, &updated, states[0]); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// Replaces the member list of the group, as long as the revision is newer than the one we have.
+// When exactRevision is set, the revision must be the direct successor of the one we have.
+func (gm *groupManager) writeMembers(ctx context.Context, dbTX persistence.DBTX, pg *pldapi.PrivacyGroup, exactRevision bool) (bool, error) {
+	q := dbTX.DB().WithContext(ctx).
+		Model(&persistedGroup{}).
+		Where("domain = ?", pg.Domain).
+		Where("id = ?", pg.ID)
+	if exactRevision {
+		q = q.Where("members_revision = ?", pg.MembersRevision-1)
+	} else {
+		q = q.Where("members_revision < ?", pg.MembersRevision)
+	}
+	res := q.Updates(map[string]any{
+		"members_revision": pg.MembersRevision,
+		"members_tx":       pg.MembersTransaction,
+	})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+
+	err := dbTX.DB().WithContext(ctx).
+		Where(`"domain" = ?`, pg.Domain).
+		Where(`"group" = ?`, pg.ID).
+		Delete(&persistedGroupMember{}).
+		Error
+	if err == nil {
+		pgms := make([]*persistedGroupMember, len(pg.Members))
+		for i, identity := range pg.Members {
+			pgms[i] = &persistedGroupMember{
+				Domain:   pg.Domain,
+				Group:    pg.ID,
+				Index:    i,
+				Identity: identity,
+			}
+		}
+		err = dbTX.DB().WithContext(ctx).Create(pgms).Error
+	}
+	if err == nil {
+		err = gm.pruneTopicACLs(ctx, dbTX, pg)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	dbTX.AddPostCommit(func(ctx context.Context) {
+		gm.deployedPGCache.Delete(fmt.Sprintf("%s:%s", pg.Domain, pg.ID.String()))
+	})
+	return true, nil
+}
+
+func (gm *groupManager) distributeMembers(ctx context.Context, dbTX persistence.DBTX, psc components.DomainSmartContract, pg, updated *pldapi.PrivacyGroup, membersState *pldapi.State) error {
+	localNode := gm.transportManager.LocalNodeName()
+
+	// Members that have been removed are notified too, so they know they are no longer in the group
+	identities := slices.Clone(pg.Members)
+	for _, m := range updated.Members {
+		if !slices.Contains(identities, m) {
+			identities = append(identities, m)
+		}
+	}
+
+	var msgs []*pldapi.ReliableMessage
+	var newMembers []string
+	for _, identity := range identities {
+		node, _ := pldtypes.PrivateIdentityLocator(identity).Node(ctx, false)
+		if node == localNode {
+			continue
+		}
+		if !slices.Contains(pg.Members, identity) {
+			// New members need the original genesis of the group, before the updated one
+			newMembers = append(newMembers, identity)
+			msgs = append(msgs, &pldapi.ReliableMessage{
+				Node:        node,
+				MessageType: pldapi.RMTPrivacyGroup.Enum(),
+				Metadata: pldtypes.JSONString(&components.PrivacyGroupDistribution{
+					GenesisTransaction: pg.GenesisTransaction,
+					GenesisState: components.StateDistributionWithData{
+						StateDistribution: components.StateDistribution{
+							IdentityLocator: identity,
+							Domain:          pg.Domain,
+							StateID:         pg.ID.String(),
+							SchemaID:        pg.GenesisSchema.String(),
+						},
+					},
+				}),
+			})
+		}
+		msgs = append(msgs, &pldapi.ReliableMessage{
+			Node:        node,
+			MessageType: pldapi.RMTPrivacyGroupMembers.Enum(),
+			Metadata: pldtypes.JSONString(&components.PrivacyGroupMembersDistribution{
+				Group:              pg.ID,
+				Revision:           updated.MembersRevision,
+				MembersTransaction: updated.MembersTransaction,
+				MembersState: components.StateDistributionWithData{
+					StateDistribution: components.StateDistribution{
+						IdentityLocator: identity,
+						Domain:          pg.Domain,
+						ContractAddress: pg.ContractAddress.String(),
+						StateID:         membersState.ID.String(),
+						SchemaID:        membersState.Schema.String(),
+					},
+				},
+			}),
+		})
+	}
+
+	if len(newMembers) > 0 {
+		catchupMsgs, err := gm.buildCatchupDistributions(ctx, dbTX, psc, newMembers)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, catchupMsgs...)
+	}
+
+	if len(msgs) > 0 {
+		return gm.transportManager.SendReliable(ctx, dbTX, msgs...)
+	}
+	return nil
+}
+
+// New members are entitled to all the states of the contract that are currently available (confirmed and unspent),
+// so that they can participate in new transactions in the group.
+func (gm *groupManager) buildCatchupDistributions(ctx context.Context, dbTX persistence.DBTX, psc components.DomainSmartContract, identities []string) ([]*pldapi.ReliableMessage, error) {
+	contractAddr := psc.Address()
+	domainName := psc.Domain().Name()
+	var msgs []*pldapi.ReliableMessage
+	for _, schema := range psc.Domain().StateSchemas() {
+		schemaID := schema.ID()
+		var lastID pldtypes.HexBytes
+		for {
+			qb := query.NewQueryBuilder().Sort(".id").Limit(gm.catchupStatesPageSize)
+			if lastID != nil {
+				qb = qb.GreaterThan(".id", lastID)
+			}
+			states, err := gm.stateManager.FindStates(ctx, dbTX, domainName, schemaID, qb.Query(), &components.StateQueryOptions{
+				StatusQualifier: pldapi.StateStatusAvailable,
+				QueryModifier: func(dbTX persistence.DBTX, q *gorm.DB) *gorm.DB {
+					return q.Where("states.contract_address = ?", contractAddr)
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, s := range states {
+				for _, identity := range identities {
+					node, _ := pldtypes.PrivateIdentityLocator(identity).Node(ctx, false)
+					msgs = append(msgs, &pldapi.ReliableMessage{
+						Node:        node,
+						MessageType: pldapi.RMTState.Enum(),
+						Metadata: pldtypes.JSONString(&components.StateDistribution{
+							IdentityLocator: identity,
+							Domain:          domainName,
+							ContractAddress: contractAddr.String(),
+							StateID:         s.ID.String(),
+							SchemaID:        schemaID.String(),
+						}),
+					})
+				}
+			}
+			if len(states) < gm.catchupStatesPageSize {
+				break
+			}
+			lastID = states[len(states)-1].ID
+		}
+	}
+	log.L(ctx).Infof("Sending %d states to %d new members of privacy group contract %s", len(msgs), len(identities), contractAddr)
+	return msgs, nil
+}
+
+func (gm *groupManager) StoreReceivedGroupMembers(ctx context.Context, dbTX persistence.DBTX, domainName, senderNode string, pgmd *components.PrivacyGroupMembersDistribution, state *pldapi.State) (rejectionErr, err error) {
+
+	var pgGenesis pldapi.PrivacyGroupGenesisState
+	if err := json.Unmarshal(state.Data, &pgGenesis); err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgPGroupsReceivedGenesisInvalid), nil
+	}
+
+	// The group must already exist - the original genesis is always sent before the first member update
+	groups, err := gm.QueryGroups(ctx, dbTX, query.NewQueryBuilder().Equal("domain", domainName).Equal("id", pgmd.Group).Limit(1).Query())
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return i18n.NewError(ctx, msgs.MsgPGroupsGroupNotFound, pgmd.Group), nil
+	}
+	pg := groups[0]
+
+	// Only a node that hosts one of the current members can change the member list
+	senderIsMember := false
+	for _, m := range pg.Members {
+		if node, _ := pldtypes.PrivateIdentityLocator(m).Node(ctx, false); node == senderNode {
+			senderIsMember = true
+			break
+		}
+	}
+	if !senderIsMember {
+		return i18n.NewError(ctx, msgs.MsgPGroupsMembersSenderNotMember, senderNode, pgmd.Group), nil
+	}
+
+	// Only the members can change
+	if pgGenesis.GenesisSalt != pg.GenesisSalt ||
+		pgGenesis.Name != pg.Name ||
+		!maps.Equal(pgGenesis.Properties.Map(), pg.Properties) ||
+		!maps.Equal(pgGenesis.Configuration.Map(), pg.Configuration) {
+		return i18n.NewError(ctx, msgs.MsgPGroupsMembersGenesisMismatch, pgmd.Group), nil
+	}
+	if _, err := gm.validateMembers(ctx, pgGenesis.Members, false); err != nil {
+		return err, nil
+	}
+
+	updated := *pg
+	updated.Members = pgGenesis.Members
+	updated.MembersRevision = pgmd.Revision
+	updated.MembersTransaction = pgmd.MembersTransaction
+	written, err := gm.writeMembers(ctx, dbTX, &updated, false)
+	if err != nil {
+		return nil, err
+	}
+	if !written {
+		log.L(ctx).Infof("Ignoring members revision %d for privacy group %s, as we have revision %d", pgmd.Revision, pg.ID, pg.MembersRevision)
+	}
+	return nil, nil
+
+}
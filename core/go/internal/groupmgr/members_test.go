@@ -0,0 +1,699 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package groupmgr
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentsmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddRemoveMembersRealDB(t *testing.T) {
+
+	contractAddr := pldtypes.RandAddress()
+	ctx, gm, mc, done := newTestGroupManager(t, true, &pldconf.GroupManagerConfig{}, func(mc *mockComponents, conf *pldconf.GroupManagerConfig) {
+		mc.registryManager.On("GetNodeTransports", mock.Anything, mock.Anything).
+			Return([]*components.RegistryNodeTransportEntry{ /* contents not checked */ }, nil)
+	})
+	defer done()
+
+	groupIDs := createTestGroups(t, ctx, mc, gm, &pldapi.PrivacyGroupInput{
+		Domain:  "domain1",
+		Name:    "pg1",
+		Members: []string{"me@node1", "you@node2"},
+	})
+	groupID := groupIDs[0]
+
+	// Attempt before the contract is deployed
+	_, err := gm.AddMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"them@node3"},
+	})
+	assert.Regexp(t, "PD012503", err)
+
+	pg, err := gm.GetGroupByID(ctx, gm.p.NOTX(), "domain1", groupID)
+	require.NoError(t, err)
+	err = gm.p.DB().Exec(`INSERT INTO transaction_receipts ("transaction", domain, indexed, success, contract_address) VALUES ( ?, ?, ?, ?, ? )`,
+		pg.GenesisTransaction, "domain1", pldtypes.TimestampNow(), true, contractAddr,
+	).Error
+	require.NoError(t, err)
+
+	// Prime the cache, to check it is evicted on update
+	pg, err = gm.GetGroupByID(ctx, gm.p.NOTX(), "domain1", groupID)
+	require.NoError(t, err)
+	require.Equal(t, 0, pg.MembersRevision)
+
+	genesisSchema := componentsmocks.NewSchema(t)
+	genesisSchema.On("ID").Return(pg.GenesisSchema)
+	mc.domain.On("StateSchemas").Return([]components.Schema{genesisSchema})
+
+	psc := componentsmocks.NewDomainSmartContract(t)
+	psc.On("Address").Return(*contractAddr)
+	psc.On("Domain").Return(mc.domain)
+	mc.domainManager.On("GetSmartContractByAddress", mock.Anything, mock.Anything, *contractAddr).Return(psc, nil)
+
+	mupm := psc.On("UpdatePrivacyGroupMembers", mock.Anything, mock.Anything, mock.Anything).Once()
+	mupm.Run(func(args mock.Arguments) {
+		updated := args[1].(*pldapi.PrivacyGroup)
+		require.Equal(t, []string{"me@node1", "you@node2", "them@node3"}, updated.Members)
+		require.Equal(t, 1, updated.MembersRevision)
+		require.Equal(t, []string{"me@node1", "you@node2"}, args[2].([]string))
+		mupm.Return(&pldapi.TransactionInput{
+			TransactionBase: pldapi.TransactionBase{
+				Type: pldapi.TransactionTypePrivate.Enum(),
+			},
+		}, nil)
+	})
+
+	var sent []*pldapi.ReliableMessage
+	mc.transportManager.On("SendReliable", mock.Anything, mock.Anything, mock.MatchedBy(func(rm []*pldapi.ReliableMessage) bool {
+		return rm[0].MessageType.V() == pldapi.RMTPrivacyGroupMembers
+	})).Return(nil).Run(func(args mock.Arguments) {
+		sent = args[2].([]*pldapi.ReliableMessage)
+	})
+
+	var updated *pldapi.PrivacyGroup
+	err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		updated, err = gm.AddMembers(ctx, dbTX, &pldapi.PrivacyGroupMembersInput{
+			Domain:  "domain1",
+			Group:   groupID,
+			Members: []string{"them@node3"},
+			TransactionOptions: &pldapi.PrivacyGroupTXOptions{
+				IdempotencyKey: "members_1",
+			},
+		})
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, updated.MembersRevision)
+	require.NotNil(t, updated.MembersTransaction)
+
+	// Existing remote member gets the update, new member gets the genesis then the update
+	require.Len(t, sent, 3)
+	assert.Equal(t, pldapi.RMTPrivacyGroupMembers, sent[0].MessageType.V())
+	assert.Equal(t, "node2", sent[0].Node)
+	assert.Equal(t, pldapi.RMTPrivacyGroup, sent[1].MessageType.V())
+	assert.Equal(t, "node3", sent[1].Node)
+	assert.Equal(t, pldapi.RMTPrivacyGroupMembers, sent[2].MessageType.V())
+	assert.Equal(t, "node3", sent[2].Node)
+	var pgmd components.PrivacyGroupMembersDistribution
+	err = json.Unmarshal(sent[2].Metadata, &pgmd)
+	require.NoError(t, err)
+	assert.Equal(t, groupID, pgmd.Group)
+	assert.Equal(t, 1, pgmd.Revision)
+	assert.Equal(t, contractAddr.String(), pgmd.MembersState.ContractAddress)
+	assert.Equal(t, "them@node3", pgmd.MembersState.IdentityLocator)
+
+	pg, err = gm.GetGroupByID(ctx, gm.p.NOTX(), "domain1", groupID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"me@node1", "you@node2", "them@node3"}, pg.Members)
+	require.Equal(t, 1, pg.MembersRevision)
+	require.Equal(t, updated.MembersTransaction, pg.MembersTransaction)
+
+	// Cannot add an existing member
+	_, err = gm.AddMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"you@node2"},
+	})
+	assert.Regexp(t, "PD012527", err)
+
+	// Cannot remove a non-member
+	_, err = gm.RemoveMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"other@node4"},
+	})
+	assert.Regexp(t, "PD012528", err)
+
+	// Restrict some topics to nodes, including the node of the member we are about to remove
+	_, err = setTestMessagePolicy(ctx, gm, &pldapi.PrivacyGroupMessagePolicy{
+		Domain: "domain1",
+		Group:  groupID,
+		TopicACLs: []*pldapi.PrivacyGroupTopicACL{
+			{Topic: "topic1", Nodes: []string{"node1", "node2"}},
+			{Topic: "topic2", Nodes: []string{"node2"}},
+			{Topic: "topic3", Nodes: []string{"node3"}},
+		},
+	})
+	require.NoError(t, err)
+	mp, err := gm.getMessagePolicy(ctx, gm.p.NOTX(), "domain1", groupID)
+	require.NoError(t, err)
+	require.True(t, mp.nodePermitted("topic1", "node2"))
+
+	// Remove a member, with no transaction required by the domain
+	psc.On("UpdatePrivacyGroupMembers", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		updated, err = gm.RemoveMembers(ctx, dbTX, &pldapi.PrivacyGroupMembersInput{
+			Domain:  "domain1",
+			Group:   groupID,
+			Members: []string{"you@node2"},
+		})
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, updated.MembersRevision)
+	require.Nil(t, updated.MembersTransaction)
+	require.Equal(t, []string{"me@node1", "them@node3"}, updated.Members)
+
+	// The removed member is still notified
+	require.Len(t, sent, 2)
+	assert.Equal(t, "node2", sent[0].Node)
+	assert.Equal(t, "node3", sent[1].Node)
+
+	pg, err = gm.GetGroupByID(ctx, gm.p.NOTX(), "domain1", groupID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"me@node1", "them@node3"}, pg.Members)
+
+	// The node of the removed member is pruned from the topic ACLs, and topics left with no nodes stay restricted
+	policy, err := gm.GetMessagePolicy(ctx, gm.p.NOTX(), "domain1", groupID)
+	require.NoError(t, err)
+	require.Len(t, policy.TopicACLs, 3)
+	assert.Equal(t, []string{"node1"}, policy.TopicACLs[0].Nodes)
+	assert.Empty(t, policy.TopicACLs[1].Nodes)
+	assert.Equal(t, []string{"node3"}, policy.TopicACLs[2].Nodes)
+	mp, err = gm.getMessagePolicy(ctx, gm.p.NOTX(), "domain1", groupID)
+	require.NoError(t, err)
+	assert.False(t, mp.nodePermitted("topic1", "node2"))
+	assert.False(t, mp.nodePermitted("topic2", "node2"))
+	assert.True(t, mp.nodePermitted("topic3", "node3"))
+
+}
+
+func TestStoreReceivedGroupMembersRealDB(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, true, &pldconf.GroupManagerConfig{}, func(mc *mockComponents, conf *pldconf.GroupManagerConfig) {
+		mc.registryManager.On("GetNodeTransports", mock.Anything, mock.Anything).
+			Return([]*components.RegistryNodeTransportEntry{ /* contents not checked */ }, nil)
+	})
+	defer done()
+
+	groupIDs := createTestGroups(t, ctx, mc, gm, &pldapi.PrivacyGroupInput{
+		Domain:     "domain1",
+		Name:       "pg1",
+		Members:    []string{"me@node1", "you@node2"},
+		Properties: map[string]string{"prop1": "value1"},
+	})
+	groupID := groupIDs[0]
+	pg, err := gm.GetGroupByID(ctx, gm.p.NOTX(), "domain1", groupID)
+	require.NoError(t, err)
+
+	newGenesis := *pg
+	newGenesis.Members = []string{"me@node1", "you@node2", "them@node3"}
+	newState := &pldapi.State{StateBase: pldapi.StateBase{
+		ID:   pldtypes.RandBytes(32),
+		Data: pldtypes.JSONString(newGenesis.GenesisStateData()),
+	}}
+
+	storeMembers := func(pgmd *components.PrivacyGroupMembersDistribution, state *pldapi.State) (rejectErr error) {
+		err := gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+			rejectErr, err = gm.StoreReceivedGroupMembers(ctx, dbTX, "domain1", "node2", pgmd, state)
+			return err
+		})
+		require.NoError(t, err)
+		return rejectErr
+	}
+
+	txID := uuid.New()
+	rejectErr := storeMembers(&components.PrivacyGroupMembersDistribution{
+		Group:              groupID,
+		Revision:           2,
+		MembersTransaction: &txID,
+	}, newState)
+	require.NoError(t, rejectErr)
+
+	pg, err = gm.GetGroupByID(ctx, gm.p.NOTX(), "domain1", groupID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"me@node1", "you@node2", "them@node3"}, pg.Members)
+	require.Equal(t, 2, pg.MembersRevision)
+	require.Equal(t, txID, *pg.MembersTransaction)
+
+	// An older revision arriving late is ignored
+	oldGenesis := *pg
+	oldGenesis.Members = []string{"me@node1"}
+	rejectErr = storeMembers(&components.PrivacyGroupMembersDistribution{
+		Group:    groupID,
+		Revision: 1,
+	}, &pldapi.State{StateBase: pldapi.StateBase{
+		ID:   pldtypes.RandBytes(32),
+		Data: pldtypes.JSONString(oldGenesis.GenesisStateData()),
+	}})
+	require.NoError(t, rejectErr)
+
+	pg, err = gm.GetGroupByID(ctx, gm.p.NOTX(), "domain1", groupID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"me@node1", "you@node2", "them@node3"}, pg.Members)
+	require.Equal(t, 2, pg.MembersRevision)
+
+	// Changes to anything other than the members are rejected
+	badGenesis := *pg
+	badGenesis.Properties = map[string]string{"prop1": "changed"}
+	rejectErr = storeMembers(&components.PrivacyGroupMembersDistribution{
+		Group:    groupID,
+		Revision: 3,
+	}, &pldapi.State{StateBase: pldapi.StateBase{
+		ID:   pldtypes.RandBytes(32),
+		Data: pldtypes.JSONString(badGenesis.GenesisStateData()),
+	}})
+	assert.Regexp(t, "PD012530", rejectErr)
+
+	// Invalid members are rejected
+	badGenesis = *pg
+	badGenesis.Members = []string{"unqualified"}
+	rejectErr = storeMembers(&components.PrivacyGroupMembersDistribution{
+		Group:    groupID,
+		Revision: 3,
+	}, &pldapi.State{StateBase: pldapi.StateBase{
+		ID:   pldtypes.RandBytes(32),
+		Data: pldtypes.JSONString(badGenesis.GenesisStateData()),
+	}})
+	assert.Error(t, rejectErr)
+
+	// Unknown groups are rejected
+	rejectErr = storeMembers(&components.PrivacyGroupMembersDistribution{
+		Group:    pldtypes.RandBytes(32),
+		Revision: 1,
+	}, newState)
+	assert.Regexp(t, "PD012502", rejectErr)
+
+	// Updates from a node that does not host a current member are rejected
+	err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		rejectErr, err = gm.StoreReceivedGroupMembers(ctx, dbTX, "domain1", "node4", &components.PrivacyGroupMembersDistribution{
+			Group:    groupID,
+			Revision: 3,
+		}, newState)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, "PD012536", rejectErr)
+
+	// Invalid data is rejected
+	rejectErr = storeMembers(&components.PrivacyGroupMembersDistribution{
+		Group:    groupID,
+		Revision: 3,
+	}, &pldapi.State{StateBase: pldapi.StateBase{
+		ID:   pldtypes.RandBytes(32),
+		Data: pldtypes.RawJSON(`!!! wrong`),
+	}})
+	assert.Regexp(t, "PD012523", rejectErr)
+
+}
+
+func TestUpdateMembersBadInput(t *testing.T) {
+
+	ctx, gm, _, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	_, err := gm.AddMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{})
+	assert.Regexp(t, "PD012505", err)
+
+	_, err = gm.AddMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{Domain: "domain1"})
+	assert.Regexp(t, "PD012504", err)
+
+	_, err = gm.RemoveMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{Domain: "domain1", Group: pldtypes.RandBytes(32)})
+	assert.Regexp(t, "PD012526", err)
+
+}
+
+func TestUpdateMembersQueryFail(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	mc.db.Mock.ExpectQuery("SELECT.*privacy_groups").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := gm.AddMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   pldtypes.RandBytes(32),
+		Members: []string{"them@node3"},
+	})
+	assert.Regexp(t, "pop", err)
+
+}
+
+func TestUpdateMembersGroupNotFound(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	mc.db.Mock.ExpectQuery("SELECT.*privacy_groups").WillReturnRows(sqlmock.NewRows([]string{}))
+
+	_, err := gm.AddMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   pldtypes.RandBytes(32),
+		Members: []string{"them@node3"},
+	})
+	assert.Regexp(t, "PD012502", err)
+
+}
+
+func TestUpdateMembersInvalidMembers(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	groupID := pldtypes.RandBytes(32)
+	mockDBPrivacyGroup(mc, pldtypes.RandBytes32(), groupID, pldtypes.RandAddress(), "me@node1")
+
+	_, err := gm.AddMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"unqualified"},
+	})
+	assert.Regexp(t, "PD020017", err)
+
+}
+
+func mockReadyToUpdateMembers(t *testing.T, mc *mockComponents, groupID pldtypes.HexBytes) *componentsmocks.DomainSmartContract {
+	contractAddr := pldtypes.RandAddress()
+	mockDBPrivacyGroup(mc, pldtypes.RandBytes32(), groupID, contractAddr, "me@node1", "you@node2")
+	mc.registryManager.On("GetNodeTransports", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	psc := componentsmocks.NewDomainSmartContract(t)
+	mc.domainManager.On("GetSmartContractByAddress", mock.Anything, mock.Anything, *contractAddr).Return(psc, nil)
+	return psc
+}
+
+func TestUpdateMembersGetContractFail(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	groupID := pldtypes.RandBytes(32)
+	contractAddr := pldtypes.RandAddress()
+	mockDBPrivacyGroup(mc, pldtypes.RandBytes32(), groupID, contractAddr, "me@node1")
+	mc.domainManager.On("GetSmartContractByAddress", mock.Anything, mock.Anything, *contractAddr).Return(nil, fmt.Errorf("pop"))
+
+	_, err := gm.AddMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"you@node1"},
+	})
+	assert.Regexp(t, "pop", err)
+
+}
+
+func TestUpdateMembersDomainFail(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	groupID := pldtypes.RandBytes(32)
+	psc := mockReadyToUpdateMembers(t, mc, groupID)
+	psc.On("UpdatePrivacyGroupMembers", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := gm.RemoveMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"you@node2"},
+	})
+	assert.Regexp(t, "pop", err)
+
+}
+
+func TestUpdateMembersSendTransactionFail(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	groupID := pldtypes.RandBytes(32)
+	psc := mockReadyToUpdateMembers(t, mc, groupID)
+	psc.On("UpdatePrivacyGroupMembers", mock.Anything, mock.Anything, mock.Anything).Return(&pldapi.TransactionInput{}, nil)
+	mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop")).
+		Run(func(args mock.Arguments) {
+			tx := args[2].([]*pldapi.TransactionInput)[0]
+			assert.Regexp(t, `domains\.domain1\.pgroupmembers\.0x[0-9a-f]{64}`, tx.From)
+		})
+
+	_, err := gm.AddMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"them@node3"},
+	})
+	assert.Regexp(t, "pop", err)
+
+}
+
+func TestUpdateMembersWriteStateFail(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	groupID := pldtypes.RandBytes(32)
+	psc := mockReadyToUpdateMembers(t, mc, groupID)
+	psc.On("UpdatePrivacyGroupMembers", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	mc.stateManager.On("WriteReceivedStates", mock.Anything, mock.Anything, "domain1", mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := gm.AddMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"them@node3"},
+	})
+	assert.Regexp(t, "pop", err)
+
+}
+
+func mockReadyToWriteMembers(t *testing.T, mc *mockComponents, groupID pldtypes.HexBytes) *componentsmocks.DomainSmartContract {
+	psc := mockReadyToUpdateMembers(t, mc, groupID)
+	psc.On("UpdatePrivacyGroupMembers", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	mc.stateManager.On("WriteReceivedStates", mock.Anything, mock.Anything, "domain1", mock.Anything).
+		Return([]*pldapi.State{{StateBase: pldapi.StateBase{ID: pldtypes.RandBytes(32)}}}, nil)
+	return psc
+}
+
+func TestUpdateMembersConcurrentUpdate(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	groupID := pldtypes.RandBytes(32)
+	mockReadyToWriteMembers(t, mc, groupID)
+	mc.db.Mock.ExpectExec("UPDATE.*privacy_groups").WillReturnResult(driver.RowsAffected(0))
+
+	_, err := gm.AddMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"them@node3"},
+	})
+	assert.Regexp(t, "PD012529", err)
+
+}
+
+func TestUpdateMembersUpdateGroupFail(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	groupID := pldtypes.RandBytes(32)
+	mockReadyToWriteMembers(t, mc, groupID)
+	mc.db.Mock.ExpectExec("UPDATE.*privacy_groups").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := gm.AddMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"them@node3"},
+	})
+	assert.Regexp(t, "pop", err)
+
+}
+
+func TestUpdateMembersDeleteMembersFail(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	groupID := pldtypes.RandBytes(32)
+	mockReadyToWriteMembers(t, mc, groupID)
+	mc.db.Mock.ExpectExec("UPDATE.*privacy_groups").WillReturnResult(driver.RowsAffected(1))
+	mc.db.Mock.ExpectExec("DELETE.*privacy_group_members").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := gm.AddMembers(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMembersInput{
+		Domain:  "domain1",
+		Group:   groupID,
+		Members: []string{"them@node3"},
+	})
+	assert.Regexp(t, "pop", err)
+
+}
+
+func TestUpdateMembersCatchupQueryFail(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	groupID := pldtypes.RandBytes(32)
+	mc.db.Mock.ExpectBegin()
+	psc := mockReadyToWriteMembers(t, mc, groupID)
+	mc.db.Mock.ExpectExec("UPDATE.*privacy_groups").WillReturnResult(driver.RowsAffected(1))
+	mc.db.Mock.ExpectExec("DELETE.*privacy_group_members").WillReturnResult(driver.RowsAffected(2))
+	mc.db.Mock.ExpectExec("INSERT.*privacy_group_members").WillReturnResult(driver.RowsAffected(3))
+	mc.db.Mock.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnRows(sqlmock.NewRows([]string{}))
+
+	ms := componentsmocks.NewSchema(t)
+	ms.On("ID").Return(pldtypes.RandBytes32())
+	mc.domain.On("StateSchemas").Return([]components.Schema{ms})
+	psc.On("Address").Return(*pldtypes.RandAddress())
+	psc.On("Domain").Return(mc.domain)
+	mc.stateManager.On("FindStates", mock.Anything, mock.Anything, "domain1", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("pop"))
+	mc.db.Mock.ExpectRollback()
+
+	err := gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := gm.AddMembers(ctx, dbTX, &pldapi.PrivacyGroupMembersInput{
+			Domain:  "domain1",
+			Group:   groupID,
+			Members: []string{"them@node3"},
+		})
+		return err
+	})
+	assert.Regexp(t, "pop", err)
+
+}
+
+func TestBuildCatchupDistributionsPaging(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+	gm.catchupStatesPageSize = 2
+
+	schemaID := pldtypes.RandBytes32()
+	ms := componentsmocks.NewSchema(t)
+	ms.On("ID").Return(schemaID)
+	mc.domain.On("StateSchemas").Return([]components.Schema{ms})
+	contractAddr := pldtypes.RandAddress()
+	psc := componentsmocks.NewDomainSmartContract(t)
+	psc.On("Address").Return(*contractAddr)
+	psc.On("Domain").Return(mc.domain)
+
+	page1 := []*pldapi.State{
+		{StateBase: pldapi.StateBase{ID: pldtypes.RandBytes(32)}},
+		{StateBase: pldapi.StateBase{ID: pldtypes.RandBytes(32)}},
+	}
+	page2 := []*pldapi.State{
+		{StateBase: pldapi.StateBase{ID: pldtypes.RandBytes(32)}},
+	}
+	mc.stateManager.On("FindStates", mock.Anything, mock.Anything, "domain1", schemaID, mock.Anything, mock.Anything).
+		Return(page1, nil).Once()
+	mc.stateManager.On("FindStates", mock.Anything, mock.Anything, "domain1", schemaID, mock.Anything, mock.Anything).
+		Return(page2, nil).Once()
+
+	msgs, err := gm.buildCatchupDistributions(ctx, gm.p.NOTX(), psc, []string{"them@node3", "others@node4"})
+	require.NoError(t, err)
+	require.Len(t, msgs, 6)
+	var sd components.StateDistribution
+	err = json.Unmarshal(msgs[5].Metadata, &sd)
+	require.NoError(t, err)
+	assert.Equal(t, "node4", msgs[5].Node)
+	assert.Equal(t, pldapi.RMTState, msgs[5].MessageType.V())
+	assert.Equal(t, page2[0].ID.String(), sd.StateID)
+	assert.Equal(t, contractAddr.String(), sd.ContractAddress)
+	assert.Equal(t, "others@node4", sd.IdentityLocator)
+
+}
+
+func TestStoreReceivedGroupMembersQueryFail(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	mc.db.Mock.ExpectQuery("SELECT.*privacy_groups").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := gm.StoreReceivedGroupMembers(ctx, gm.p.NOTX(), "domain1", "node2", &components.PrivacyGroupMembersDistribution{
+		Group: pldtypes.RandBytes(32),
+	}, newValidPGState())
+	assert.Regexp(t, "pop", err)
+
+}
+
+func TestStoreReceivedGroupMembersWriteFail(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	state := newValidPGState()
+	var genesis pldapi.PrivacyGroupGenesisState
+	err := json.Unmarshal(state.Data, &genesis)
+	require.NoError(t, err)
+
+	mc.db.Mock.ExpectQuery("SELECT.*privacy_groups").WillReturnRows(sqlmock.NewRows([]string{
+		"domain", "id", "name", "genesis_salt", "properties", "configuration",
+	}).AddRow(
+		"domain1", state.ID, genesis.Name, genesis.GenesisSalt.String(),
+		pldtypes.JSONString(genesis.Properties.Map()).String(), pldtypes.JSONString(genesis.Configuration.Map()).String(),
+	))
+	mc.db.Mock.ExpectQuery("SELECT.*privacy_group_members").WillReturnRows(sqlmock.NewRows([]string{
+		"domain", "group", "idx", "identity",
+	}).AddRow("domain1", state.ID, 0, "you@node2"))
+	mc.db.Mock.ExpectExec("UPDATE.*privacy_groups").WillReturnError(fmt.Errorf("pop"))
+
+	rejectErr, err := gm.StoreReceivedGroupMembers(ctx, gm.p.NOTX(), "domain1", "node2", &components.PrivacyGroupMembersDistribution{
+		Group:    state.ID,
+		Revision: 1,
+	}, state)
+	require.NoError(t, rejectErr)
+	assert.Regexp(t, "pop", err)
+
+}
+
+func TestPruneTopicACLsQueryFail(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	mc.db.Mock.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnError(fmt.Errorf("pop"))
+
+	err := gm.pruneTopicACLs(ctx, gm.p.NOTX(), &pldapi.PrivacyGroup{
+		Domain:  "domain1",
+		ID:      pldtypes.RandBytes(32),
+		Members: []string{"me@node1"},
+	})
+	assert.Regexp(t, "pop", err)
+
+}
+
+func TestPruneTopicACLsUpdateFail(t *testing.T) {
+
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	groupID := pldtypes.RandBytes(32)
+	mc.db.Mock.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnRows(sqlmock.NewRows([]string{
+		"domain", "group", "topic_acls",
+	}).AddRow("domain1", groupID, `[{"topic":"topic1","nodes":["node1","node2"]}]`))
+	mc.db.Mock.ExpectExec("UPDATE.*pgroup_msg_policies").WillReturnError(fmt.Errorf("pop"))
+
+	err := gm.pruneTopicACLs(ctx, gm.p.NOTX(), &pldapi.PrivacyGroup{
+		Domain:  "domain1",
+		ID:      groupID,
+		Members: []string{"me@node1"},
+	})
+	assert.Regexp(t, "pop", err)
+
+}
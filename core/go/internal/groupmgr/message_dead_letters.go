@@ -0,0 +1,134 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package groupmgr
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/deadletters"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"gorm.io/gorm/clause"
+)
+
+func (gm *groupManager) getLoadedMessageListener(ctx context.Context, name string) (*messageListener, error) {
+	gm.messageListenerLock.Lock()
+	defer gm.messageListenerLock.Unlock()
+
+	l := gm.messageListeners[name]
+	if l == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsMessageListenerNotLoaded, name)
+	}
+	return l, nil
+}
+
+func (gm *groupManager) QueryMessageListenerDeadLetters(ctx context.Context, dbTX persistence.DBTX, name string, jq *query.QueryJSON) ([]*pldapi.ListenerDeadLetter, error) {
+	if _, err := gm.getLoadedMessageListener(ctx, name); err != nil {
+		return nil, err
+	}
+	return gm.messageDeadLetters.Query(ctx, dbTX, name, jq)
+}
+
+func (gm *groupManager) ReplayMessageListenerDeadLetter(ctx context.Context, name string, id uuid.UUID) error {
+	l, err := gm.getLoadedMessageListener(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err := gm.messageDeadLetters.MarkForReplay(ctx, name, id); err != nil {
+		return err
+	}
+	l.notifyReplays()
+	return nil
+}
+
+func (gm *groupManager) DiscardMessageListenerDeadLetter(ctx context.Context, name string, id uuid.UUID) error {
+	if _, err := gm.getLoadedMessageListener(ctx, name); err != nil {
+		return err
+	}
+	return gm.messageDeadLetters.Discard(ctx, name, id)
+}
+
+// Moves the checkpoint of the listener to the supplied sequence, so that messages after that
+// sequence are delivered (again). The listener is stopped while the checkpoint is updated.
+func (gm *groupManager) ResetMessageListenerCheckpoint(ctx context.Context, name string, sequence uint64) error {
+	gm.messageListenerLock.Lock()
+	defer gm.messageListenerLock.Unlock()
+
+	l := gm.messageListeners[name]
+	if l == nil {
+		return i18n.NewError(ctx, msgs.MsgPGroupsMessageListenerNotLoaded, name)
+	}
+
+	log.L(ctx).Infof("Resetting message listener '%s' checkpoint to sequence %d", name, sequence)
+	wasRunning := l.done != nil
+	l.stop()
+
+	err := gm.p.DB().
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "listener"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"sequence",
+				"time",
+			}),
+		}).
+		Create(&persistedMessageCheckpoint{
+			Listener: name,
+			Sequence: sequence,
+			Time:     pldtypes.TimestampNow(),
+		}).
+		Error
+	if err == nil {
+		l.checkpoint = &sequence
+	}
+	if wasRunning {
+		l.start()
+	}
+	return err
+}
+
+func (l *messageListener) deliverWithRedeliveryLimit(b *messageDeliveryBatch) (attempts int, deliveryErr error, err error) {
+	return deadletters.DeliverWithRedeliveryLimit(l.ctx, l.gm.messagesRetry, l.spec.Options.MaxRedeliveries, func() error {
+		return l.deliverBatch(b)
+	})
+}
+
+func (l *messageListener) writeDeadLetter(b *messageDeliveryBatch, attempts int, deliveryErr error) error {
+	return l.gm.messageDeadLetters.Write(l.ctx, l.gm.messagesRetry, l.spec.Name, strconv.FormatUint(b.ID, 10), attempts, deliveryErr, b.Messages)
+}
+
+func (l *messageListener) replayDeadLetters() error {
+	return deadletters.Replay(l.ctx, l.gm.messageDeadLetters, l.gm.messagesRetry, l.spec.Name, l.gm.messageListenersLoadPageSize, l.spec.Options.MaxRedeliveries,
+		func(pgMsgs []*pldapi.PrivacyGroupMessage) (string, func() error) {
+			batch := &messageDeliveryBatch{
+				ID:       l.nextBatchID,
+				Messages: pgMsgs,
+			}
+			l.nextBatchID++
+			return strconv.FormatUint(batch.ID, 10), func() error {
+				return l.deliverBatch(batch)
+			}
+		})
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package groupmgr
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/deadletters"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testFailingMessageReceiver struct {
+	fail   atomic.Bool
+	pgMsgs chan *pldapi.PrivacyGroupMessage
+}
+
+func (tmr *testFailingMessageReceiver) DeliverMessageBatch(ctx context.Context, batchID uint64, pgMsgs []*pldapi.PrivacyGroupMessage) error {
+	if tmr.fail.Load() {
+		return fmt.Errorf("pop")
+	}
+	for _, r := range pgMsgs {
+		tmr.pgMsgs <- r
+	}
+	return nil
+}
+
+func TestMessageListenerDeadLetters(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, true, &pldconf.GroupManagerConfig{
+		MessageListeners: pldconf.MessageListeners{
+			Retry: pldconf.RetryConfig{InitialDelay: confutil.P("1ms")},
+		},
+	})
+	defer done()
+
+	mc.registryManager.On("GetNodeTransports", mock.Anything, "node2").
+		Return([]*components.RegistryNodeTransportEntry{ /* contents not checked */ }, nil)
+	mc.transportManager.On("SendReliable", mock.Anything, mock.Anything, mock.MatchedBy(func(rm []*pldapi.ReliableMessage) bool {
+		return rm[0].MessageType.V() == pldapi.RMTPrivacyGroupMessage
+	})).Return(nil)
+
+	groupIDs := createTestGroups(t, ctx, mc, gm, &pldapi.PrivacyGroupInput{
+		Domain:  "domain1",
+		Members: []string{"me@node1", "you@node2"},
+	})
+
+	err := gm.CreateMessageListener(ctx, &pldapi.PrivacyGroupMessageListener{
+		Name: "listener1",
+		Options: pldapi.PrivacyGroupMessageListenerOptions{
+			MaxRedeliveries: confutil.P(1),
+		},
+	})
+	require.NoError(t, err)
+
+	tmr := &testFailingMessageReceiver{pgMsgs: make(chan *pldapi.PrivacyGroupMessage, 1)}
+	tmr.fail.Store(true)
+	r, err := gm.AddMessageReceiver(ctx, "listener1", tmr)
+	require.NoError(t, err)
+	defer r.Close()
+
+	sendMessage := func() uuid.UUID {
+		var msgID *uuid.UUID
+		err := gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+			msgID, err = gm.SendMessage(ctx, dbTX, &pldapi.PrivacyGroupMessageInput{
+				Domain: "domain1",
+				Group:  groupIDs[0],
+				Topic:  "my/topic",
+				Data:   pldtypes.JSONString("some data"),
+			})
+			return err
+		})
+		require.NoError(t, err)
+		return *msgID
+	}
+	waitDeadLetters := func(count int) []*pldapi.ListenerDeadLetter {
+		for {
+			dls, err := gm.QueryMessageListenerDeadLetters(ctx, gm.p.NOTX(), "listener1", query.NewQueryBuilder().Limit(100).Query())
+			require.NoError(t, err)
+			if len(dls) == count {
+				return dls
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// The message fails delivery twice, and is parked
+	msgID := sendMessage()
+	dls := waitDeadLetters(1)
+	assert.Equal(t, "listener1", dls[0].Listener)
+	assert.Equal(t, 2, dls[0].Attempts)
+	assert.Equal(t, "pop", dls[0].LastError)
+	assert.False(t, dls[0].Replay)
+	assert.Contains(t, dls[0].Batch.String(), msgID.String())
+
+	// Replay it successfully
+	err = gm.ReplayMessageListenerDeadLetter(ctx, "listener1", uuid.New())
+	assert.Regexp(t, "PD012524", err)
+	tmr.fail.Store(false)
+	err = gm.ReplayMessageListenerDeadLetter(ctx, "listener1", dls[0].ID)
+	require.NoError(t, err)
+	rm := <-tmr.pgMsgs
+	assert.Equal(t, msgID, rm.ID)
+	waitDeadLetters(0)
+
+	// Reset the checkpoint, and we get it again
+	err = gm.ResetMessageListenerCheckpoint(ctx, "listener1", 0)
+	require.NoError(t, err)
+	rm = <-tmr.pgMsgs
+	assert.Equal(t, msgID, rm.ID)
+
+	// Park another one, and discard it
+	tmr.fail.Store(true)
+	sendMessage()
+	dls = waitDeadLetters(1)
+	err = gm.DiscardMessageListenerDeadLetter(ctx, "listener1", dls[0].ID)
+	require.NoError(t, err)
+	err = gm.DiscardMessageListenerDeadLetter(ctx, "listener1", dls[0].ID)
+	assert.Regexp(t, "PD012524", err)
+
+	// A failed replay is left in the table
+	sendMessage()
+	dls = waitDeadLetters(1)
+	err = gm.ReplayMessageListenerDeadLetter(ctx, "listener1", dls[0].ID)
+	require.NoError(t, err)
+	for dls[0].Attempts < 4 {
+		dls = waitDeadLetters(1)
+	}
+	assert.False(t, dls[0].Replay)
+
+	// Deleting the listener removes its dead letters
+	err = gm.DeleteMessageListener(ctx, "listener1")
+	require.NoError(t, err)
+	var count int64
+	err = gm.p.DB().Model(&deadletters.PersistedDeadLetter{}).Count(&count).Error
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestMessageListenerDeadLetterNotLoaded(t *testing.T) {
+	ctx, gm, _, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	_, err := gm.QueryMessageListenerDeadLetters(ctx, gm.p.NOTX(), "unknown", query.NewQueryBuilder().Limit(1).Query())
+	assert.Regexp(t, "PD012508", err)
+
+	err = gm.ReplayMessageListenerDeadLetter(ctx, "unknown", uuid.New())
+	assert.Regexp(t, "PD012508", err)
+
+	err = gm.DiscardMessageListenerDeadLetter(ctx, "unknown", uuid.New())
+	assert.Regexp(t, "PD012508", err)
+
+	err = gm.ResetMessageListenerCheckpoint(ctx, "unknown", 0)
+	assert.Regexp(t, "PD012508", err)
+
+	err = gm.CreateMessageListener(ctx, &pldapi.PrivacyGroupMessageListener{
+		Name: "listener1",
+		Options: pldapi.PrivacyGroupMessageListenerOptions{
+			MaxRedeliveries: confutil.P(-1),
+		},
+	})
+	assert.Regexp(t, "PD012525", err)
+}
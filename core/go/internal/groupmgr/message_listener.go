@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"regexp"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
@@ -27,6 +28,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/deadletters"
 	"github.com/kaleido-io/paladin/core/internal/filters"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
@@ -77,6 +79,7 @@ type messageListener struct {
 	checkpoint *uint64
 
 	newMessages chan bool
+	newReplays  chan bool
 
 	nextBatchID  uint64
 	newReceivers chan bool
@@ -101,6 +104,7 @@ func (gm *groupManager) messagesInit() {
 	gm.messagesReadPageSize = confutil.IntMin(gm.conf.MessageListeners.ReadPageSize, 1, *pldconf.GroupManagerDefaults.MessageListeners.ReadPageSize)
 	gm.messageListeners = make(map[string]*messageListener)
 	gm.messageListenersLoadPageSize = 100 /* not currently tunable */
+	gm.messagePruneInterval = confutil.DurationMin(gm.conf.MessageRetention.PruneInterval, 100*time.Millisecond, *pldconf.GroupManagerDefaults.MessageRetention.PruneInterval)
 }
 
 func (pm *persistedMessage) mapToAPI() *pldapi.PrivacyGroupMessage {
@@ -109,6 +113,7 @@ func (pm *persistedMessage) mapToAPI() *pldapi.PrivacyGroupMessage {
 		Node:          pm.Node,
 		Sent:          pm.Sent,
 		Received:      pm.Received,
+		Redacted:      pm.Redacted,
 		ID:            pm.ID,
 		PrivacyGroupMessageInput: pldapi.PrivacyGroupMessageInput{
 			Domain:        pm.Domain,
@@ -240,6 +245,9 @@ func (gm *groupManager) DeleteMessageListener(ctx context.Context, name string)
 		Where("name = ?", name).
 		Delete(&persistedMessageListener{}).
 		Error
+	if err == nil {
+		err = gm.messageDeadLetters.DeleteAll(ctx, name)
+	}
 	if err != nil {
 		return err
 	}
@@ -360,6 +368,10 @@ func (gm *groupManager) validateListenerSpec(ctx context.Context, spec *pldapi.P
 		}
 	}
 
+	if err := deadletters.ValidateMaxRedeliveries(ctx, msgs.MsgPGroupsInvalidMaxRedeliveries, spec.Name, spec.Options.MaxRedeliveries); err != nil {
+		return nil, err
+	}
+
 	return topicMatch, nil
 }
 
@@ -442,6 +454,7 @@ func (gm *groupManager) loadListener(ctx context.Context, pl *persistedMessageLi
 		gm:           gm,
 		newReceivers: make(chan bool, 1),
 		newMessages:  make(chan bool, 1),
+		newReplays:   make(chan bool, 1),
 	}
 
 	l.topicMatch, l.spec, err = gm.mapListener(ctx, pl)
@@ -485,6 +498,13 @@ func (l *messageListener) notifyNewMessages() {
 	}
 }
 
+func (l *messageListener) notifyReplays() {
+	select {
+	case l.newReplays <- true:
+	default:
+	}
+}
+
 func (l *messageListener) addReceiver(r components.PrivacyGroupMessageReceiver) *registeredMessageReceiver {
 	l.receiverLock.Lock()
 	defer l.receiverLock.Unlock()
@@ -558,13 +578,27 @@ func (l *messageListener) readPage() ([]*persistedMessage, error) {
 	return messages, err
 }
 
-func (l *messageListener) processPersistedMessage(b *messageDeliveryBatch, pm *persistedMessage) {
+func (l *messageListener) processPersistedMessage(b *messageDeliveryBatch, pm *persistedMessage) error {
 	if !l.checkMatch(pm) {
-		return
+		return nil
+	}
+	// Messages are only delivered if both the sender and this node are permitted on the topic
+	var policy *messagePolicy
+	err := l.gm.messagesRetry.Do(l.ctx, func(attempt int) (retryable bool, err error) {
+		policy, err = l.gm.getMessagePolicy(l.ctx, l.gm.p.NOTX(), pm.Domain, pm.Group)
+		return true, err
+	})
+	if err != nil {
+		return err
+	}
+	if !policy.nodePermitted(pm.Topic, pm.Node) || !policy.nodePermitted(pm.Topic, l.gm.transportManager.LocalNodeName()) {
+		log.L(l.ctx).Warnf("Skipping message %d/%s from node '%s' on topic '%s' due to topic ACL", pm.LocalSeq, pm.ID, pm.Node, pm.Topic)
+		return nil
 	}
 	// Otherwise we can process the message
 	log.L(l.ctx).Infof("Added message %d/%s (domain='%s') to batch %d", pm.LocalSeq, pm.ID, pm.Domain, b.ID)
 	b.Messages = append(b.Messages, pm.mapToAPI())
+	return nil
 }
 
 func (l *messageListener) nextReceiver(b *messageDeliveryBatch) (r components.PrivacyGroupMessageReceiver, err error) {
@@ -635,15 +669,18 @@ func (l *messageListener) processPage(page []*persistedMessage) (*messageDeliver
 	batch.ID = l.nextBatchID
 	l.nextBatchID++
 	for _, r := range page {
-		l.processPersistedMessage(&batch, r)
+		if err := l.processPersistedMessage(&batch, r); err != nil {
+			return nil, err
+		}
 	}
 
 	// If our batch contains some work, we need to wait for someone to process that work
 	// (note we're not holding any resource open at this point - no DB TX or anything).
 	if len(batch.Messages) > 0 {
-		err := l.gm.messagesRetry.Do(l.ctx, func(attempt int) (retryable bool, err error) {
-			return true, l.deliverBatch(&batch)
-		})
+		attempts, deliveryErr, err := l.deliverWithRedeliveryLimit(&batch)
+		if err == nil && deliveryErr != nil {
+			err = l.writeDeadLetter(&batch, attempts, deliveryErr)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -664,8 +701,18 @@ func (l *messageListener) runListener() {
 		return
 	}
 
+	replays := true
 	for {
 
+		if replays {
+			// Redeliver any dead letters that have been marked for replay
+			if err := l.replayDeadLetters(); err != nil {
+				log.L(l.ctx).Warnf("listener stopping (replaying dead letters): %s", err) // cancelled context
+				return
+			}
+			replays = false
+		}
+
 		// Read the next page of messages
 		page, err := l.readPage()
 		if err != nil {
@@ -695,6 +742,8 @@ func (l *messageListener) runListener() {
 		if len(page) < l.gm.messagesReadPageSize {
 			select {
 			case <-l.newMessages:
+			case <-l.newReplays:
+				replays = true
 			case <-l.ctx.Done():
 				log.L(l.ctx).Warnf("listener stopping (waiting for new messages/states)") // cancelled context
 				return
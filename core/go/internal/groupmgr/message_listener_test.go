@@ -518,6 +518,7 @@ func mockMessages(count int, mc *mockComponents) {
 		)
 	}
 	mc.db.Mock.ExpectQuery("SELECT.*pgroup_msgs").WillReturnRows(rows)
+	mc.db.Mock.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnRows(sqlmock.NewRows([]string{}))
 }
 
 func TestClosedRetryingBatchDeliver(t *testing.T) {
@@ -527,6 +528,7 @@ func TestClosedRetryingBatchDeliver(t *testing.T) {
 	mdb := mc.db.Mock
 	mdb.ExpectExec("INSERT.*message_listeners").WillReturnResult(driver.ResultNoRows)
 	mdb.ExpectQuery("SELECT.*message_listener_checkpoints").WillReturnRows(sqlmock.NewRows([]string{}))
+	mdb.ExpectQuery("SELECT.*listener_dead_letters").WillReturnRows(sqlmock.NewRows([]string{}))
 	mockMessages(1, mc)
 
 	err := gm.CreateMessageListener(ctx, &pldapi.PrivacyGroupMessageListener{
@@ -554,6 +556,7 @@ func TestClosedRetryingWritingCheckpoint(t *testing.T) {
 	mdb := mc.db.Mock
 	mdb.ExpectExec("INSERT.*message_listeners").WillReturnResult(driver.ResultNoRows)
 	mdb.ExpectQuery("SELECT.*message_listener_checkpoints").WillReturnRows(sqlmock.NewRows([]string{}))
+	mdb.ExpectQuery("SELECT.*listener_dead_letters").WillReturnRows(sqlmock.NewRows([]string{}))
 	mdb.ExpectExec("INSERT.*message_listener_checkpoints").WillReturnError(fmt.Errorf("pop"))
 	mockMessages(1, mc)
 
@@ -582,6 +585,7 @@ func TestClosedRetryingQueryMessages(t *testing.T) {
 	mdb := mc.db.Mock
 	mdb.ExpectExec("INSERT.*message_listeners").WillReturnResult(driver.ResultNoRows)
 	mdb.ExpectQuery("SELECT.*message_listener_checkpoints").WillReturnRows(sqlmock.NewRows([]string{}))
+	mdb.ExpectQuery("SELECT.*listener_dead_letters").WillReturnRows(sqlmock.NewRows([]string{}))
 	mdb.ExpectQuery("SELECT.*transaction_receipts").WillReturnError(fmt.Errorf("pop"))
 
 	err := gm.CreateMessageListener(ctx, &pldapi.PrivacyGroupMessageListener{
@@ -0,0 +1,393 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package groupmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/deadletters"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"gorm.io/gorm/clause"
+)
+
+// Message policies are local to each node. Each node enforces the topic ACLs it has been configured with
+// on the messages it sends, the messages it accepts from other nodes, and the messages it delivers to
+// its listeners. Retention is applied by a background pruner - listeners that have not yet processed
+// a message by the time it falls outside of the retention policy will not receive it.
+type persistedMessagePolicy struct {
+	Domain    string             `gorm:"column:domain;primaryKey"`
+	Group     pldtypes.HexBytes  `gorm:"column:group;primaryKey"`
+	Updated   pldtypes.Timestamp `gorm:"column:updated"`
+	MaxAge    *string            `gorm:"column:max_age"`
+	MaxCount  *int               `gorm:"column:max_count"`
+	TopicACLs pldtypes.RawJSON   `gorm:"column:topic_acls"`
+}
+
+func (persistedMessagePolicy) TableName() string {
+	return "pgroup_msg_policies"
+}
+
+// The in-memory form of the topic ACLs, with the set of permitted nodes for each topic.
+// ACLs are per node, as messages are exchanged between nodes and delivered to the listeners of a node,
+// rather than to individual identities.
+type messagePolicy struct {
+	topicNodes map[string]map[string]bool
+}
+
+func (mp *messagePolicy) nodePermitted(topic, node string) bool {
+	nodes, hasACL := mp.topicNodes[topic]
+	return !hasACL || nodes[node]
+}
+
+func (pp *persistedMessagePolicy) mapToAPI() *pldapi.PrivacyGroupMessagePolicy {
+	policy := &pldapi.PrivacyGroupMessagePolicy{
+		Domain:  pp.Domain,
+		Group:   pp.Group,
+		Updated: pp.Updated,
+		Retention: pldapi.PrivacyGroupMessageRetention{
+			MaxAge:   pp.MaxAge,
+			MaxCount: pp.MaxCount,
+		},
+	}
+	_ = json.Unmarshal(pp.TopicACLs, &policy.TopicACLs)
+	return policy
+}
+
+func (gm *groupManager) validateMessagePolicy(ctx context.Context, pg *pldapi.PrivacyGroup, policy *pldapi.PrivacyGroupMessagePolicy) error {
+	if policy.Retention.MaxAge != nil {
+		maxAge, err := time.ParseDuration(*policy.Retention.MaxAge)
+		if err != nil || maxAge <= 0 {
+			return i18n.NewError(ctx, msgs.MsgPGroupsRetentionMaxAgeInvalid, *policy.Retention.MaxAge)
+		}
+	}
+	if policy.Retention.MaxCount != nil && *policy.Retention.MaxCount <= 0 {
+		return i18n.NewError(ctx, msgs.MsgPGroupsRetentionMaxCountInvalid, *policy.Retention.MaxCount)
+	}
+	memberNodes := groupMemberNodes(ctx, pg)
+	topics := make(map[string]bool)
+	for _, acl := range policy.TopicACLs {
+		if acl == nil || acl.Topic == "" || topics[acl.Topic] || len(acl.Nodes) == 0 {
+			return i18n.NewError(ctx, msgs.MsgPGroupsTopicACLInvalid, pldtypes.JSONString(acl))
+		}
+		topics[acl.Topic] = true
+		for _, node := range acl.Nodes {
+			if !memberNodes[node] {
+				return i18n.NewError(ctx, msgs.MsgPGroupsTopicACLNodeNotInGroup, node, acl.Topic, pg.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// The nodes hosting at least one member of the group
+func groupMemberNodes(ctx context.Context, pg *pldapi.PrivacyGroup) map[string]bool {
+	nodes := make(map[string]bool)
+	for _, m := range pg.Members {
+		// Members are validated as fully qualified when the group is created
+		node, _ := pldtypes.PrivateIdentityLocator(m).Node(ctx, false)
+		nodes[node] = true
+	}
+	return nodes
+}
+
+func (gm *groupManager) SetMessagePolicy(ctx context.Context, dbTX persistence.DBTX, policy *pldapi.PrivacyGroupMessagePolicy) (*pldapi.PrivacyGroupMessagePolicy, error) {
+	if policy.Domain == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsNoDomain)
+	}
+	if len(policy.Group) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsNoGroupID)
+	}
+
+	pg, err := gm.GetGroupByID(ctx, dbTX, policy.Domain, policy.Group)
+	if err != nil {
+		return nil, err
+	}
+	if pg == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsGroupNotFound, policy.Group)
+	}
+	if err := gm.validateMessagePolicy(ctx, pg, policy); err != nil {
+		return nil, err
+	}
+
+	topicACLs := policy.TopicACLs
+	if topicACLs == nil {
+		topicACLs = []*pldapi.PrivacyGroupTopicACL{}
+	}
+	pp := &persistedMessagePolicy{
+		Domain:    policy.Domain,
+		Group:     policy.Group,
+		Updated:   pldtypes.TimestampNow(),
+		MaxAge:    policy.Retention.MaxAge,
+		MaxCount:  policy.Retention.MaxCount,
+		TopicACLs: pldtypes.JSONString(topicACLs),
+	}
+	err = dbTX.DB().WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "domain"},
+				{Name: "group"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"updated",
+				"max_age",
+				"max_count",
+				"topic_acls",
+			}),
+		}).
+		Create(pp).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	dbTX.AddPostCommit(func(ctx context.Context) {
+		gm.messagePolicyCache.Delete(fmt.Sprintf("%s:%s", policy.Domain, policy.Group))
+	})
+	return pp.mapToAPI(), nil
+}
+
+// Nodes left without any member of the group, when members are removed, are removed from the topic ACLs.
+// A topic that is left with no nodes in its ACL stays restricted, so that no remaining node gains access
+// to it until the policy is updated.
+func (gm *groupManager) pruneTopicACLs(ctx context.Context, dbTX persistence.DBTX, pg *pldapi.PrivacyGroup) error {
+	policy, err := gm.GetMessagePolicy(ctx, dbTX, pg.Domain, pg.ID)
+	if err != nil || policy == nil {
+		return err
+	}
+	memberNodes := groupMemberNodes(ctx, pg)
+	pruned := false
+	for _, acl := range policy.TopicACLs {
+		nodes := slices.DeleteFunc(slices.Clone(acl.Nodes), func(node string) bool {
+			return !memberNodes[node]
+		})
+		if len(nodes) != len(acl.Nodes) {
+			log.L(ctx).Infof("Removing %d nodes from the ACL for topic '%s' in privacy group %s", len(acl.Nodes)-len(nodes), acl.Topic, pg.ID)
+			acl.Nodes = nodes
+			pruned = true
+		}
+	}
+	if !pruned {
+		return nil
+	}
+	err = dbTX.DB().WithContext(ctx).
+		Model(&persistedMessagePolicy{}).
+		Where("domain = ?", pg.Domain).
+		Where(`"group" = ?`, pg.ID).
+		Updates(map[string]any{
+			"updated":    pldtypes.TimestampNow(),
+			"topic_acls": pldtypes.JSONString(policy.TopicACLs),
+		}).
+		Error
+	if err != nil {
+		return err
+	}
+	dbTX.AddPostCommit(func(ctx context.Context) {
+		gm.messagePolicyCache.Delete(fmt.Sprintf("%s:%s", pg.Domain, pg.ID))
+	})
+	return nil
+}
+
+func (gm *groupManager) GetMessagePolicy(ctx context.Context, dbTX persistence.DBTX, domainName string, groupID pldtypes.HexBytes) (*pldapi.PrivacyGroupMessagePolicy, error) {
+	var policies []*persistedMessagePolicy
+	err := dbTX.DB().WithContext(ctx).
+		Where("domain = ?", domainName).
+		Where(`"group" = ?`, groupID).
+		Limit(1).
+		Find(&policies).
+		Error
+	if err != nil || len(policies) == 0 {
+		return nil, err
+	}
+	return policies[0].mapToAPI(), nil
+}
+
+// Cached lookup of the topic ACLs for a group - a group without a policy has an empty policy cached
+func (gm *groupManager) getMessagePolicy(ctx context.Context, dbTX persistence.DBTX, domainName string, groupID pldtypes.HexBytes) (*messagePolicy, error) {
+	cacheKey := fmt.Sprintf("%s:%s", domainName, groupID)
+	mp, found := gm.messagePolicyCache.Get(cacheKey)
+	if found {
+		return mp, nil
+	}
+
+	policy, err := gm.GetMessagePolicy(ctx, dbTX, domainName, groupID)
+	if err != nil {
+		return nil, err
+	}
+	mp = &messagePolicy{topicNodes: make(map[string]map[string]bool)}
+	if policy != nil {
+		for _, acl := range policy.TopicACLs {
+			nodes := make(map[string]bool)
+			for _, node := range acl.Nodes {
+				nodes[node] = true
+			}
+			mp.topicNodes[acl.Topic] = nodes
+		}
+	}
+	gm.messagePolicyCache.Set(cacheKey, mp)
+	return mp, nil
+}
+
+func (gm *groupManager) RedactMessage(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID) (*pldapi.PrivacyGroupMessage, error) {
+	// Redacting a message that is already redacted is a no-op
+	now := pldtypes.TimestampNow()
+	res := dbTX.DB().WithContext(ctx).
+		Model(&persistedMessage{}).
+		Where("id = ?", id).
+		Where("redacted IS NULL").
+		Updates(map[string]any{
+			"data":     pldtypes.RawJSON(`null`),
+			"redacted": now,
+		})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected > 0 {
+		log.L(ctx).Infof("Redacted message %s", id)
+		if err := gm.redactDeadLetters(ctx, dbTX, id); err != nil {
+			return nil, err
+		}
+	}
+	return gm.GetMessageByID(ctx, dbTX, id, true)
+}
+
+// Copies of the message might have been stored in dead-letter batches, so we redact those too
+func (gm *groupManager) redactDeadLetters(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID) error {
+	var deadLetters []*deadletters.PersistedDeadLetter
+	err := dbTX.DB().WithContext(ctx).
+		Where("listener_type = ?", deadletters.TypeMessage).
+		Where("batch LIKE ?", "%"+id.String()+"%").
+		Find(&deadLetters).
+		Error
+	if err != nil {
+		return err
+	}
+	for _, dl := range deadLetters {
+		var batch []*pldapi.PrivacyGroupMessage
+		if err := json.Unmarshal(dl.Batch, &batch); err != nil {
+			log.L(ctx).Warnf("Unable to parse dead letter %s to redact message %s: %s", dl.ID, id, err)
+			continue
+		}
+		for _, m := range batch {
+			if m.ID == id {
+				now := pldtypes.TimestampNow()
+				m.Data = nil
+				m.Redacted = &now
+			}
+		}
+		err := dbTX.DB().WithContext(ctx).
+			Model(&deadletters.PersistedDeadLetter{}).
+			Where("id = ?", dl.ID).
+			Update("batch", pldtypes.JSONString(batch)).
+			Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (gm *groupManager) messagePruner() {
+	defer close(gm.messagePrunerDone)
+
+	for {
+		select {
+		case <-gm.bgCtx.Done():
+			log.L(gm.bgCtx).Debugf("message pruner exiting")
+			return
+		case <-time.After(gm.messagePruneInterval):
+		}
+
+		if err := gm.pruneMessages(gm.bgCtx); err != nil {
+			log.L(gm.bgCtx).Errorf("message pruning failed: %s", err)
+		}
+	}
+}
+
+func (gm *groupManager) pruneMessages(ctx context.Context) error {
+	var policies []*persistedMessagePolicy
+	err := gm.p.DB().WithContext(ctx).
+		Where("max_age IS NOT NULL OR max_count IS NOT NULL").
+		Find(&policies).
+		Error
+	if err != nil {
+		return err
+	}
+	for _, pp := range policies {
+		if err := gm.pruneGroupMessages(ctx, pp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (gm *groupManager) pruneGroupMessages(ctx context.Context, pp *persistedMessagePolicy) error {
+	var pruned int64
+	db := gm.p.DB()
+	if pp.MaxAge != nil {
+		maxAge, _ := time.ParseDuration(*pp.MaxAge) // validated on write
+		cutoff := pldtypes.TimestampNow() - pldtypes.Timestamp(maxAge)
+		res := db.WithContext(ctx).
+			Where("domain = ?", pp.Domain).
+			Where(`"group" = ?`, pp.Group).
+			Where("received < ?", cutoff).
+			Delete(&persistedMessage{})
+		if res.Error != nil {
+			return res.Error
+		}
+		pruned += res.RowsAffected
+	}
+	if pp.MaxCount != nil {
+		// Find the newest message that falls outside of the retained count
+		var boundary []*persistedMessage
+		err := db.WithContext(ctx).
+			Select("local_seq").
+			Where("domain = ?", pp.Domain).
+			Where(`"group" = ?`, pp.Group).
+			Order("local_seq DESC").
+			Offset(*pp.MaxCount).
+			Limit(1).
+			Find(&boundary).
+			Error
+		if err != nil {
+			return err
+		}
+		if len(boundary) > 0 {
+			res := db.WithContext(ctx).
+				Where("domain = ?", pp.Domain).
+				Where(`"group" = ?`, pp.Group).
+				Where("local_seq <= ?", boundary[0].LocalSeq).
+				Delete(&persistedMessage{})
+			if res.Error != nil {
+				return res.Error
+			}
+			pruned += res.RowsAffected
+		}
+	}
+	if pruned > 0 {
+		log.L(ctx).Infof("Pruned %d messages from privacy group %s:%s", pruned, pp.Domain, pp.Group)
+	}
+	return nil
+}
@@ -0,0 +1,670 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package groupmgr
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/deadletters"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setTestMessagePolicy(ctx context.Context, gm *groupManager, policy *pldapi.PrivacyGroupMessagePolicy) (result *pldapi.PrivacyGroupMessagePolicy, err error) {
+	err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		result, err = gm.SetMessagePolicy(ctx, dbTX, policy)
+		return err
+	})
+	return result, err
+}
+
+func sendTestMessage(t *testing.T, ctx context.Context, gm *groupManager, groupID pldtypes.HexBytes, topic string) (*uuid.UUID, error) {
+	var msgID *uuid.UUID
+	err := gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		msgID, err = gm.SendMessage(ctx, dbTX, &pldapi.PrivacyGroupMessageInput{
+			Domain: "domain1",
+			Group:  groupID,
+			Topic:  topic,
+			Data:   pldtypes.JSONString("some data"),
+		})
+		return err
+	})
+	return msgID, err
+}
+
+func TestMessagePolicyTopicACLs(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, true, &pldconf.GroupManagerConfig{})
+	defer done()
+
+	mc.registryManager.On("GetNodeTransports", mock.Anything, mock.Anything).
+		Return([]*components.RegistryNodeTransportEntry{ /* contents not checked */ }, nil)
+
+	var distributedTo []string
+	mc.transportManager.On("SendReliable", mock.Anything, mock.Anything, mock.MatchedBy(func(rm []*pldapi.ReliableMessage) bool {
+		return rm[0].MessageType.V() == pldapi.RMTPrivacyGroupMessage
+	})).Run(func(args mock.Arguments) {
+		for _, rm := range args[2].([]*pldapi.ReliableMessage) {
+			distributedTo = append(distributedTo, rm.Node)
+		}
+	}).Return(nil)
+
+	groupIDs := createTestGroups(t, ctx, mc, gm,
+		&pldapi.PrivacyGroupInput{
+			Domain:  "domain1",
+			Members: []string{"me@node1", "you@node2", "them@node3"},
+		},
+	)
+	require.Len(t, groupIDs, 1)
+
+	// No policy to start with
+	policy, err := gm.GetMessagePolicy(ctx, gm.p.NOTX(), "domain1", groupIDs[0])
+	require.NoError(t, err)
+	require.Nil(t, policy)
+
+	policy, err = setTestMessagePolicy(ctx, gm, &pldapi.PrivacyGroupMessagePolicy{
+		Domain: "domain1",
+		Group:  groupIDs[0],
+		TopicACLs: []*pldapi.PrivacyGroupTopicACL{
+			{Topic: "restricted", Nodes: []string{"node1", "node2"}},
+			{Topic: "others", Nodes: []string{"node2", "node3"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, policy.TopicACLs, 2)
+
+	policy, err = gm.GetMessagePolicy(ctx, gm.p.NOTX(), "domain1", groupIDs[0])
+	require.NoError(t, err)
+	require.Equal(t, "restricted", policy.TopicACLs[0].Topic)
+	require.Nil(t, policy.Retention.MaxAge)
+
+	// Open topics go to everyone
+	_, err = sendTestMessage(t, ctx, gm, groupIDs[0], "open")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"node2", "node3"}, distributedTo)
+
+	// Restricted topics only go to the permitted nodes
+	distributedTo = nil
+	_, err = sendTestMessage(t, ctx, gm, groupIDs[0], "restricted")
+	require.NoError(t, err)
+	require.Equal(t, []string{"node2"}, distributedTo)
+
+	// We cannot send on a topic we are not permitted on
+	_, err = sendTestMessage(t, ctx, gm, groupIDs[0], "others")
+	require.Regexp(t, "PD012531.*node1.*others", err)
+
+	// Nor can we receive messages from a node not permitted on the topic, or on a topic we are not permitted on
+	msgFromNode3, msgOnOthers, msgOK := uuid.New(), uuid.New(), uuid.New()
+	receivedMsg := func(id uuid.UUID, node, topic string) *pldapi.PrivacyGroupMessage {
+		return &pldapi.PrivacyGroupMessage{
+			Sent: pldtypes.TimestampNow(),
+			Node: node,
+			ID:   id,
+			PrivacyGroupMessageInput: pldapi.PrivacyGroupMessageInput{
+				Domain: "domain1",
+				Group:  groupIDs[0],
+				Topic:  topic,
+				Data:   pldtypes.JSONString("some data"),
+			},
+		}
+	}
+	var results map[uuid.UUID]error
+	err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		results, err = gm.ReceiveMessages(ctx, dbTX, []*pldapi.PrivacyGroupMessage{
+			receivedMsg(msgFromNode3, "node3", "restricted"),
+			receivedMsg(msgOnOthers, "node2", "others"),
+			receivedMsg(msgOK, "node2", "restricted"),
+		})
+		return err
+	})
+	require.NoError(t, err)
+	require.Regexp(t, "PD012531.*node3.*restricted", results[msgFromNode3])
+	require.Regexp(t, "PD012531.*node1.*others", results[msgOnOthers])
+	require.NoError(t, results[msgOK])
+
+	// Clearing the ACLs opens up the topic again
+	_, err = setTestMessagePolicy(ctx, gm, &pldapi.PrivacyGroupMessagePolicy{
+		Domain: "domain1",
+		Group:  groupIDs[0],
+	})
+	require.NoError(t, err)
+	_, err = sendTestMessage(t, ctx, gm, groupIDs[0], "others")
+	require.NoError(t, err)
+}
+
+func TestMessagePolicyListenerSkipsNotPermitted(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, true, &pldconf.GroupManagerConfig{})
+	defer done()
+
+	mc.registryManager.On("GetNodeTransports", mock.Anything, mock.Anything).
+		Return([]*components.RegistryNodeTransportEntry{ /* contents not checked */ }, nil)
+	mc.transportManager.On("SendReliable", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	groupIDs := createTestGroups(t, ctx, mc, gm,
+		&pldapi.PrivacyGroupInput{
+			Domain:  "domain1",
+			Members: []string{"me@node1", "you@node2"},
+		},
+	)
+
+	// Messages sent before the policy was applied are stored locally
+	_, err := sendTestMessage(t, ctx, gm, groupIDs[0], "secret")
+	require.NoError(t, err)
+	okMsgID, err := sendTestMessage(t, ctx, gm, groupIDs[0], "open")
+	require.NoError(t, err)
+
+	_, err = setTestMessagePolicy(ctx, gm, &pldapi.PrivacyGroupMessagePolicy{
+		Domain: "domain1",
+		Group:  groupIDs[0],
+		TopicACLs: []*pldapi.PrivacyGroupTopicACL{
+			{Topic: "secret", Nodes: []string{"node2"}},
+		},
+	})
+	require.NoError(t, err)
+
+	err = gm.CreateMessageListener(ctx, &pldapi.PrivacyGroupMessageListener{
+		Name: "listener1",
+	})
+	require.NoError(t, err)
+
+	tmr := newTestMessageReceiver(nil)
+	r, err := gm.AddMessageReceiver(ctx, "listener1", tmr)
+	require.NoError(t, err)
+	defer r.Close()
+
+	// Only the open message is delivered
+	rm := <-tmr.pgMsgs
+	require.Equal(t, okMsgID.String(), rm.ID.String())
+}
+
+func TestMessagePolicyListenerPolicyLoadFail(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	mdb := mc.db.Mock
+	mdb.ExpectExec("INSERT.*message_listeners").WillReturnResult(driver.ResultNoRows)
+	mdb.ExpectQuery("SELECT.*message_listener_checkpoints").WillReturnRows(sqlmock.NewRows([]string{}))
+	mdb.ExpectQuery("SELECT.*listener_dead_letters").WillReturnRows(sqlmock.NewRows([]string{}))
+	mdb.ExpectQuery("SELECT.*pgroup_msgs").WillReturnRows(sqlmock.NewRows([]string{"local_seq", "id"}).AddRow(1000, uuid.New()))
+	mdb.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnError(fmt.Errorf("pop"))
+
+	err := gm.CreateMessageListener(ctx, &pldapi.PrivacyGroupMessageListener{
+		Name:    "listener1",
+		Started: confutil.P(false),
+	})
+	require.NoError(t, err)
+
+	gm.messagesRetry.UTSetMaxAttempts(1)
+	l := gm.messageListeners["listener1"]
+	l.initStart()
+	l.runListener()
+	require.NoError(t, mdb.ExpectationsWereMet())
+}
+
+func TestSetMessagePolicyValidation(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, true, &pldconf.GroupManagerConfig{})
+	defer done()
+
+	mc.registryManager.On("GetNodeTransports", mock.Anything, "node2").
+		Return([]*components.RegistryNodeTransportEntry{ /* contents not checked */ }, nil)
+
+	groupIDs := createTestGroups(t, ctx, mc, gm,
+		&pldapi.PrivacyGroupInput{
+			Domain:  "domain1",
+			Members: []string{"me@node1", "you@node2"},
+		},
+	)
+
+	for _, tc := range []struct {
+		policy *pldapi.PrivacyGroupMessagePolicy
+		err    string
+	}{
+		{
+			policy: &pldapi.PrivacyGroupMessagePolicy{Group: groupIDs[0]},
+			err:    "PD012505",
+		},
+		{
+			policy: &pldapi.PrivacyGroupMessagePolicy{Domain: "domain1"},
+			err:    "PD012504",
+		},
+		{
+			policy: &pldapi.PrivacyGroupMessagePolicy{Domain: "domain1", Group: pldtypes.RandBytes(32)},
+			err:    "PD012502",
+		},
+		{
+			policy: &pldapi.PrivacyGroupMessagePolicy{Domain: "domain1", Group: groupIDs[0],
+				Retention: pldapi.PrivacyGroupMessageRetention{MaxAge: confutil.P("wrong")}},
+			err: "PD012534",
+		},
+		{
+			policy: &pldapi.PrivacyGroupMessagePolicy{Domain: "domain1", Group: groupIDs[0],
+				Retention: pldapi.PrivacyGroupMessageRetention{MaxAge: confutil.P("-1s")}},
+			err: "PD012534",
+		},
+		{
+			policy: &pldapi.PrivacyGroupMessagePolicy{Domain: "domain1", Group: groupIDs[0],
+				Retention: pldapi.PrivacyGroupMessageRetention{MaxCount: confutil.P(0)}},
+			err: "PD012535",
+		},
+		{
+			policy: &pldapi.PrivacyGroupMessagePolicy{Domain: "domain1", Group: groupIDs[0],
+				TopicACLs: []*pldapi.PrivacyGroupTopicACL{{Nodes: []string{"node1"}}}},
+			err: "PD012533",
+		},
+		{
+			policy: &pldapi.PrivacyGroupMessagePolicy{Domain: "domain1", Group: groupIDs[0],
+				TopicACLs: []*pldapi.PrivacyGroupTopicACL{{Topic: "topic1"}}},
+			err: "PD012533",
+		},
+		{
+			policy: &pldapi.PrivacyGroupMessagePolicy{Domain: "domain1", Group: groupIDs[0],
+				TopicACLs: []*pldapi.PrivacyGroupTopicACL{
+					{Topic: "topic1", Nodes: []string{"node1"}},
+					{Topic: "topic1", Nodes: []string{"node2"}},
+				}},
+			err: "PD012533",
+		},
+		{
+			policy: &pldapi.PrivacyGroupMessagePolicy{Domain: "domain1", Group: groupIDs[0],
+				TopicACLs: []*pldapi.PrivacyGroupTopicACL{{Topic: "topic1", Nodes: []string{"node3"}}}},
+			err: "PD012532.*node3",
+		},
+	} {
+		_, err := setTestMessagePolicy(ctx, gm, tc.policy)
+		require.Regexp(t, tc.err, err)
+	}
+
+	// Update an existing policy
+	policy, err := setTestMessagePolicy(ctx, gm, &pldapi.PrivacyGroupMessagePolicy{
+		Domain:    "domain1",
+		Group:     groupIDs[0],
+		Retention: pldapi.PrivacyGroupMessageRetention{MaxAge: confutil.P("1h")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "1h", *policy.Retention.MaxAge)
+	policy, err = setTestMessagePolicy(ctx, gm, &pldapi.PrivacyGroupMessagePolicy{
+		Domain:    "domain1",
+		Group:     groupIDs[0],
+		Retention: pldapi.PrivacyGroupMessageRetention{MaxCount: confutil.P(10)},
+	})
+	require.NoError(t, err)
+	require.Nil(t, policy.Retention.MaxAge)
+
+	policy, err = gm.GetMessagePolicy(ctx, gm.p.NOTX(), "domain1", groupIDs[0])
+	require.NoError(t, err)
+	require.Nil(t, policy.Retention.MaxAge)
+	require.Equal(t, 10, *policy.Retention.MaxCount)
+	require.Empty(t, policy.TopicACLs)
+}
+
+func TestSetMessagePolicyGetGroupFail(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	mc.db.Mock.ExpectQuery("SELECT.*privacy_groups").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := gm.SetMessagePolicy(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMessagePolicy{
+		Domain: "domain1",
+		Group:  pldtypes.RandBytes(32),
+	})
+	require.Regexp(t, "pop", err)
+}
+
+func TestSetMessagePolicyInsertFail(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	groupID := pldtypes.RandBytes(32)
+	mockDBPrivacyGroup(mc, pldtypes.RandBytes32(), groupID, nil, "me@node1")
+	mc.db.Mock.ExpectExec("INSERT.*pgroup_msg_policies").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := gm.SetMessagePolicy(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMessagePolicy{
+		Domain: "domain1",
+		Group:  groupID,
+	})
+	require.Regexp(t, "pop", err)
+}
+
+func TestSendMessagePolicyLoadFail(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	groupID := pldtypes.RandBytes(32)
+	mockDBPrivacyGroup(mc, pldtypes.RandBytes32(), groupID, nil, "me@node1")
+	mc.db.Mock.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := gm.SendMessage(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMessageInput{
+		Domain: "domain1",
+		Data:   pldtypes.JSONString("some data"),
+		Group:  groupID,
+		Topic:  "topic1",
+	})
+	require.Regexp(t, "pop", err)
+}
+
+func TestReceiveMessagesPolicyLoadFail(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	groupID := pldtypes.RandBytes(32)
+	mockDBPrivacyGroup(mc, pldtypes.RandBytes32(), groupID, nil, "me@node1", "you@node2")
+	mc.db.Mock.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := gm.ReceiveMessages(ctx, gm.p.NOTX(), []*pldapi.PrivacyGroupMessage{
+		{
+			Sent: pldtypes.TimestampNow(),
+			Node: "node2",
+			ID:   uuid.New(),
+			PrivacyGroupMessageInput: pldapi.PrivacyGroupMessageInput{
+				Domain: "domain1",
+				Data:   pldtypes.JSONString("some data"),
+				Group:  groupID,
+				Topic:  "topic1",
+			},
+		},
+	})
+	require.Regexp(t, "pop", err)
+}
+
+func TestRedactMessage(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, true, &pldconf.GroupManagerConfig{})
+	defer done()
+
+	mc.registryManager.On("GetNodeTransports", mock.Anything, "node2").
+		Return([]*components.RegistryNodeTransportEntry{ /* contents not checked */ }, nil)
+	mc.transportManager.On("SendReliable", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	groupIDs := createTestGroups(t, ctx, mc, gm,
+		&pldapi.PrivacyGroupInput{
+			Domain:  "domain1",
+			Members: []string{"me@node1", "you@node2"},
+		},
+	)
+
+	msgID, err := sendTestMessage(t, ctx, gm, groupIDs[0], "topic1")
+	require.NoError(t, err)
+	otherMsgID, err := sendTestMessage(t, ctx, gm, groupIDs[0], "topic1")
+	require.NoError(t, err)
+	msg, err := gm.GetMessageByID(ctx, gm.p.NOTX(), *msgID, true)
+	require.NoError(t, err)
+	otherMsg, err := gm.GetMessageByID(ctx, gm.p.NOTX(), *otherMsgID, true)
+	require.NoError(t, err)
+
+	// Put a copy of the message into a dead letter
+	err = gm.CreateMessageListener(ctx, &pldapi.PrivacyGroupMessageListener{
+		Name:    "listener1",
+		Started: confutil.P(false),
+	})
+	require.NoError(t, err)
+	err = gm.p.DB().Create(&deadletters.PersistedDeadLetter{
+		ID:           uuid.New(),
+		ListenerType: deadletters.TypeMessage,
+		Listener:     "listener1",
+		Created:      pldtypes.TimestampNow(),
+		BatchID:      "1",
+		Attempts:     1,
+		LastError:    "pop",
+		Batch:        pldtypes.JSONString([]*pldapi.PrivacyGroupMessage{msg, otherMsg}),
+	}).Error
+	require.NoError(t, err)
+	// And a dead letter that cannot be parsed, which is skipped
+	err = gm.p.DB().Create(&deadletters.PersistedDeadLetter{
+		ID:           uuid.New(),
+		ListenerType: deadletters.TypeMessage,
+		Listener:     "listener1",
+		Created:      pldtypes.TimestampNow(),
+		BatchID:      "2",
+		Batch:        pldtypes.RawJSON(fmt.Sprintf(`{"id":"%s"}`, msgID)),
+	}).Error
+	require.NoError(t, err)
+
+	var redacted *pldapi.PrivacyGroupMessage
+	err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		redacted, err = gm.RedactMessage(ctx, dbTX, *msgID)
+		return err
+	})
+	require.NoError(t, err)
+	require.NotNil(t, redacted.Redacted)
+	require.Equal(t, "null", redacted.Data.String())
+	require.Equal(t, msg.Topic, redacted.Topic)
+	require.Equal(t, msg.Sent, redacted.Sent)
+
+	// The message can be found by its redaction
+	found, err := gm.QueryMessages(ctx, gm.p.NOTX(), query.NewQueryBuilder().NotNull("redacted").Limit(10).Query())
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, *msgID, found[0].ID)
+
+	// The dead letter copy is redacted, but not the other message in the batch
+	dls, err := gm.QueryMessageListenerDeadLetters(ctx, gm.p.NOTX(), "listener1", query.NewQueryBuilder().Equal("batchId", "1").Limit(10).Query())
+	require.NoError(t, err)
+	require.Len(t, dls, 1)
+	var dlBatch []*pldapi.PrivacyGroupMessage
+	require.NoError(t, json.Unmarshal(dls[0].Batch, &dlBatch))
+	require.NotNil(t, dlBatch[0].Redacted)
+	require.Nil(t, dlBatch[0].Data)
+	require.Nil(t, dlBatch[1].Redacted)
+	require.Equal(t, otherMsg.Data, dlBatch[1].Data)
+
+	// Redacting again is a no-op
+	err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		msg, err = gm.RedactMessage(ctx, dbTX, *msgID)
+		return err
+	})
+	require.NoError(t, err)
+	require.Equal(t, *redacted.Redacted, *msg.Redacted)
+
+	// Redacting an unknown message fails
+	err = gm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		_, err = gm.RedactMessage(ctx, dbTX, uuid.New())
+		return err
+	})
+	require.Regexp(t, "PD012513", err)
+}
+
+func TestRedactMessageUpdateFail(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	mc.db.Mock.ExpectExec("UPDATE.*pgroup_msgs").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := gm.RedactMessage(ctx, gm.p.NOTX(), uuid.New())
+	require.Regexp(t, "pop", err)
+}
+
+func TestRedactMessageDeadLetterQueryFail(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	mc.db.Mock.ExpectExec("UPDATE.*pgroup_msgs").WillReturnResult(driver.RowsAffected(1))
+	mc.db.Mock.ExpectQuery("SELECT.*listener_dead_letters").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := gm.RedactMessage(ctx, gm.p.NOTX(), uuid.New())
+	require.Regexp(t, "pop", err)
+}
+
+func TestRedactMessageDeadLetterUpdateFail(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+	defer done()
+
+	msgID := uuid.New()
+	mc.db.Mock.ExpectExec("UPDATE.*pgroup_msgs").WillReturnResult(driver.RowsAffected(1))
+	mc.db.Mock.ExpectQuery("SELECT.*listener_dead_letters").WillReturnRows(sqlmock.NewRows([]string{"id", "batch"}).
+		AddRow(uuid.New().String(), fmt.Sprintf(`[{"id":"%s"}]`, msgID)))
+	mc.db.Mock.ExpectExec("UPDATE.*listener_dead_letters").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := gm.RedactMessage(ctx, gm.p.NOTX(), msgID)
+	require.Regexp(t, "pop", err)
+}
+
+func TestMessagePruning(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, true, &pldconf.GroupManagerConfig{})
+	defer done()
+
+	mc.registryManager.On("GetNodeTransports", mock.Anything, "node2").
+		Return([]*components.RegistryNodeTransportEntry{ /* contents not checked */ }, nil)
+	mc.transportManager.On("SendReliable", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	groupIDs := createTestGroups(t, ctx, mc, gm,
+		&pldapi.PrivacyGroupInput{
+			Domain:  "domain1",
+			Members: []string{"me@node1", "you@node2"},
+		},
+		&pldapi.PrivacyGroupInput{
+			Domain:  "domain1",
+			Members: []string{"me@node1", "you@node2"},
+		},
+		&pldapi.PrivacyGroupInput{
+			Domain:  "domain1",
+			Members: []string{"me@node1", "you@node2"},
+		},
+	)
+
+	msgIDs := make([][]uuid.UUID, len(groupIDs))
+	for i, groupID := range groupIDs {
+		for j := 0; j < 5; j++ {
+			msgID, err := sendTestMessage(t, ctx, gm, groupID, "topic1")
+			require.NoError(t, err)
+			msgIDs[i] = append(msgIDs[i], *msgID)
+		}
+	}
+
+	// Age the first two messages in the first group
+	for _, msgID := range msgIDs[0][0:2] {
+		err := gm.p.DB().Model(&persistedMessage{}).
+			Where("id = ?", msgID).
+			Update("received", pldtypes.Timestamp(time.Now().Add(-2*time.Hour).UnixNano())).
+			Error
+		require.NoError(t, err)
+	}
+
+	_, err := setTestMessagePolicy(ctx, gm, &pldapi.PrivacyGroupMessagePolicy{
+		Domain:    "domain1",
+		Group:     groupIDs[0],
+		Retention: pldapi.PrivacyGroupMessageRetention{MaxAge: confutil.P("1h")},
+	})
+	require.NoError(t, err)
+	_, err = setTestMessagePolicy(ctx, gm, &pldapi.PrivacyGroupMessagePolicy{
+		Domain:    "domain1",
+		Group:     groupIDs[1],
+		Retention: pldapi.PrivacyGroupMessageRetention{MaxCount: confutil.P(3)},
+	})
+	require.NoError(t, err)
+	// Third group has a policy, but no retention
+	_, err = setTestMessagePolicy(ctx, gm, &pldapi.PrivacyGroupMessagePolicy{
+		Domain: "domain1",
+		Group:  groupIDs[2],
+	})
+	require.NoError(t, err)
+
+	err = gm.pruneMessages(ctx)
+	require.NoError(t, err)
+
+	remaining := func(groupID pldtypes.HexBytes) []uuid.UUID {
+		msgs, err := gm.QueryMessages(ctx, gm.p.NOTX(), query.NewQueryBuilder().Equal("group", groupID).Sort("localSequence").Limit(100).Query())
+		require.NoError(t, err)
+		ids := make([]uuid.UUID, len(msgs))
+		for i, m := range msgs {
+			ids[i] = m.ID
+		}
+		return ids
+	}
+	require.Equal(t, msgIDs[0][2:], remaining(groupIDs[0]))
+	require.Equal(t, msgIDs[1][2:], remaining(groupIDs[1]))
+	require.Equal(t, msgIDs[2], remaining(groupIDs[2]))
+
+	// Pruning again does nothing
+	err = gm.pruneMessages(ctx)
+	require.NoError(t, err)
+	require.Equal(t, msgIDs[1][2:], remaining(groupIDs[1]))
+}
+
+func TestMessagePrunerLoop(t *testing.T) {
+	ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{
+		MessageRetention: pldconf.MessageRetention{
+			PruneInterval: confutil.P("1ms"),
+		},
+	}, mockEmptyMessageListeners)
+	defer done()
+
+	// Stop the default pruner, so we can run it in the foreground
+	gm.cancelCtx()
+	<-gm.messagePrunerDone
+	require.Equal(t, 100*time.Millisecond, gm.messagePruneInterval)
+
+	gm.bgCtx, gm.cancelCtx = context.WithCancel(ctx)
+	gm.messagePrunerDone = make(chan struct{})
+	gm.messagePruneInterval = 1 * time.Millisecond
+	mc.db.Mock.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnError(fmt.Errorf("pop"))
+	mc.db.Mock.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnRows(sqlmock.NewRows([]string{}))
+	go func() {
+		for mc.db.Mock.ExpectationsWereMet() != nil {
+			time.Sleep(1 * time.Millisecond)
+		}
+		gm.cancelCtx()
+	}()
+	gm.messagePruner()
+}
+
+func TestPruneMessagesFailures(t *testing.T) {
+	maxAge := &persistedMessagePolicy{Domain: "domain1", Group: pldtypes.RandBytes(32), MaxAge: confutil.P("1h")}
+	maxCount := &persistedMessagePolicy{Domain: "domain1", Group: pldtypes.RandBytes(32), MaxCount: confutil.P(1)}
+	for _, tc := range []struct {
+		policy *persistedMessagePolicy
+		setup  func(mdb sqlmock.Sqlmock)
+	}{
+		{
+			policy: maxAge,
+			setup: func(mdb sqlmock.Sqlmock) {
+				mdb.ExpectExec("DELETE.*pgroup_msgs").WillReturnError(fmt.Errorf("pop"))
+			},
+		},
+		{
+			policy: maxCount,
+			setup: func(mdb sqlmock.Sqlmock) {
+				mdb.ExpectQuery("SELECT.*pgroup_msgs").WillReturnError(fmt.Errorf("pop"))
+			},
+		},
+		{
+			policy: maxCount,
+			setup: func(mdb sqlmock.Sqlmock) {
+				mdb.ExpectQuery("SELECT.*pgroup_msgs").WillReturnRows(sqlmock.NewRows([]string{"local_seq"}).AddRow(1000))
+				mdb.ExpectExec("DELETE.*pgroup_msgs").WillReturnError(fmt.Errorf("pop"))
+			},
+		},
+	} {
+		ctx, gm, mc, done := newTestGroupManager(t, false, &pldconf.GroupManagerConfig{}, mockEmptyMessageListeners)
+		mc.db.Mock.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnRows(sqlmock.NewRows([]string{"domain", "group", "max_age", "max_count"}).
+			AddRow(tc.policy.Domain, tc.policy.Group.String(), tc.policy.MaxAge, tc.policy.MaxCount))
+		tc.setup(mc.db.Mock)
+		err := gm.pruneMessages(ctx)
+		require.Regexp(t, "pop", err)
+		done()
+	}
+}
@@ -32,16 +32,17 @@ import (
 )
 
 type persistedMessage struct {
-	LocalSeq uint64             `gorm:"column:local_seq;autoIncrement;primaryKey"`
-	Domain   string             `gorm:"column:domain"`
-	Group    pldtypes.HexBytes  `gorm:"column:group"`
-	Node     string             `gorm:"column:node"`
-	Sent     pldtypes.Timestamp `gorm:"column:sent"`
-	Received pldtypes.Timestamp `gorm:"column:received"`
-	ID       uuid.UUID          `gorm:"column:id"`
-	CID      *uuid.UUID         `gorm:"column:cid"`
-	Topic    string             `gorm:"column:topic"`
-	Data     pldtypes.RawJSON   `gorm:"column:data"`
+	LocalSeq uint64              `gorm:"column:local_seq;autoIncrement;primaryKey"`
+	Domain   string              `gorm:"column:domain"`
+	Group    pldtypes.HexBytes   `gorm:"column:group"`
+	Node     string              `gorm:"column:node"`
+	Sent     pldtypes.Timestamp  `gorm:"column:sent"`
+	Received pldtypes.Timestamp  `gorm:"column:received"`
+	ID       uuid.UUID           `gorm:"column:id"`
+	CID      *uuid.UUID          `gorm:"column:cid"`
+	Topic    string              `gorm:"column:topic"`
+	Data     pldtypes.RawJSON    `gorm:"column:data"`
+	Redacted *pldtypes.Timestamp `gorm:"column:redacted"`
 }
 
 func (persistedMessage) TableName() string {
@@ -57,6 +58,7 @@ var messageFilters = filters.FieldMap{
 	"id":            filters.UUIDField("id"),
 	"correlationId": filters.UUIDField("cid"),
 	"topic":         filters.StringField("topic"),
+	"redacted":      filters.TimestampField("redacted"),
 }
 
 // Validation before attempting DB insertion
@@ -86,6 +88,15 @@ func (gm *groupManager) SendMessage(ctx context.Context, dbTX persistence.DBTX,
 		return nil, i18n.NewError(ctx, msgs.MsgPGroupsGroupNotFound, msg.Group)
 	}
 
+	localNode := gm.transportManager.LocalNodeName()
+	policy, err := gm.getMessagePolicy(ctx, dbTX, msg.Domain, msg.Group)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.nodePermitted(msg.Topic, localNode) {
+		return nil, i18n.NewError(ctx, msgs.MsgPGroupsTopicNotPermitted, localNode, msg.Topic, msg.Group)
+	}
+
 	// Build and insert the message
 	now := pldtypes.TimestampNow()
 	msgID := uuid.New()
@@ -94,7 +105,7 @@ func (gm *groupManager) SendMessage(ctx context.Context, dbTX persistence.DBTX,
 		Group:    msg.Group,
 		Sent:     now,
 		Received: now,
-		Node:     gm.transportManager.LocalNodeName(),
+		Node:     localNode,
 		ID:       msgID,
 		CID:      msg.CorrelationID,
 		Topic:    msg.Topic,
@@ -116,6 +127,10 @@ func (gm *groupManager) SendMessage(ctx context.Context, dbTX persistence.DBTX,
 	// We also need to create a reliable message to send the state to all the remote members
 	msgs := make([]*pldapi.ReliableMessage, 0, len(remoteMembers))
 	for node := range remoteMembers {
+		// Nodes that are not on the ACL for the topic do not get a copy
+		if !policy.nodePermitted(msg.Topic, node) {
+			continue
+		}
 		// Each node gets a single copy (not one per identity)
 		msgs = append(msgs, &pldapi.ReliableMessage{
 			Node:        node,
@@ -146,7 +161,8 @@ func (gm *groupManager) ReceiveMessages(ctx context.Context, dbTX persistence.DB
 	results = make(map[uuid.UUID]error)
 	now := pldtypes.TimestampNow()
 	pMsgs := make([]*persistedMessage, 0, len(messages))
-	validatedGroups := make(map[string]*pldapi.PrivacyGroup)
+	validatedGroups := make(map[string]*messagePolicy)
+	localNode := gm.transportManager.LocalNodeName()
 	for _, msg := range messages {
 		pm := &persistedMessage{
 			Domain:   msg.Domain,
@@ -165,7 +181,8 @@ func (gm *groupManager) ReceiveMessages(ctx context.Context, dbTX persistence.DB
 			continue
 		}
 		mapKey := pm.Domain + "/" + pm.Group.String()
-		if validatedGroups[mapKey] == nil {
+		policy := validatedGroups[mapKey]
+		if policy == nil {
 			group, err := gm.GetGroupByID(ctx, dbTX, pm.Domain, pm.Group)
 			if err != nil {
 				return nil, err
@@ -175,7 +192,20 @@ func (gm *groupManager) ReceiveMessages(ctx context.Context, dbTX persistence.DB
 				results[pm.ID] = i18n.NewError(ctx, msgs.MsgPGroupsGroupNotFound, pm.Group)
 				continue
 			}
-			validatedGroups[mapKey] = group
+			if policy, err = gm.getMessagePolicy(ctx, dbTX, pm.Domain, pm.Group); err != nil {
+				return nil, err
+			}
+			validatedGroups[mapKey] = policy
+		}
+		// Both the sender and this node must be permitted on the topic
+		for _, node := range []string{pm.Node, localNode} {
+			if results[pm.ID] == nil && !policy.nodePermitted(pm.Topic, node) {
+				log.L(ctx).Errorf("Rejecting received message %s from node '%s' on topic '%s' due to topic ACL", pm.ID, pm.Node, pm.Topic)
+				results[pm.ID] = i18n.NewError(ctx, msgs.MsgPGroupsTopicNotPermitted, node, pm.Topic, pm.Group)
+			}
+		}
+		if results[pm.ID] != nil {
+			continue
 		}
 		results[pm.ID] = nil // success
 		pMsgs = append(pMsgs, pm)
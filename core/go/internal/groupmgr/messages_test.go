@@ -120,6 +120,7 @@ func TestSendMessageWriteFail(t *testing.T) {
 	schemaID := pldtypes.RandBytes32()
 	groupID := pldtypes.RandBytes(32)
 	mockDBPrivacyGroup(mc, schemaID, groupID, nil)
+	mc.db.Mock.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnRows(sqlmock.NewRows([]string{}))
 
 	mc.db.Mock.ExpectExec("INSERT.*pgroup_msgs").WillReturnError(fmt.Errorf("pop"))
 
@@ -139,6 +140,7 @@ func TestSendMessageBadMembers(t *testing.T) {
 	schemaID := pldtypes.RandBytes32()
 	groupID := pldtypes.RandBytes(32)
 	mockDBPrivacyGroup(mc, schemaID, groupID, nil, "!!!! badness")
+	mc.db.Mock.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnRows(sqlmock.NewRows([]string{}))
 
 	mc.db.Mock.ExpectQuery("INSERT.*pgroup_msgs").WillReturnRows(sqlmock.NewRows([]string{}))
 
@@ -164,6 +166,7 @@ func TestSendMessageSendMessageFail(t *testing.T) {
 	schemaID := pldtypes.RandBytes32()
 	groupID := pldtypes.RandBytes(32)
 	mockDBPrivacyGroup(mc, schemaID, groupID, nil, "me@node1", "me@node2")
+	mc.db.Mock.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnRows(sqlmock.NewRows([]string{}))
 
 	mc.db.Mock.ExpectQuery("INSERT.*pgroup_msgs").WillReturnRows(sqlmock.NewRows([]string{}))
 	mc.db.Mock.ExpectRollback()
@@ -247,6 +250,7 @@ func TestReceiveMessagesFailInsert(t *testing.T) {
 	schemaID := pldtypes.RandBytes32()
 	groupID := pldtypes.RandBytes(32)
 	mockDBPrivacyGroup(mc, schemaID, groupID, nil, "me@node1", "me@node2")
+	mc.db.Mock.ExpectQuery("SELECT.*pgroup_msg_policies").WillReturnRows(sqlmock.NewRows([]string{}))
 	mc.db.Mock.ExpectQuery("INSERT.*pgroup_msgs").WillReturnError(fmt.Errorf("pop"))
 	mc.db.Mock.ExpectRollback()
 
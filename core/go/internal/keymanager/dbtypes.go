@@ -15,7 +15,11 @@
 
 package keymanager
 
-import "github.com/kaleido-io/paladin/core/internal/filters"
+import (
+	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
 
 type DBKeyPath struct {
 	Parent string `gorm:"column:parent;primaryKey"`
@@ -28,15 +32,27 @@ func (t DBKeyPath) TableName() string {
 }
 
 type DBKeyMapping struct {
-	Identifier string `gorm:"column:identifier;primaryKey"`
-	Wallet     string `gorm:"column:wallet"`
-	KeyHandle  string `gorm:"column:key_handle"`
+	Identifier string              `gorm:"column:identifier;primaryKey"`
+	Wallet     string              `gorm:"column:wallet"`
+	KeyHandle  string              `gorm:"column:key_handle"`
+	Rotation   int64               `gorm:"column:rotation"`
+	Disabled   *pldtypes.Timestamp `gorm:"column:disabled"`
 }
 
 func (t DBKeyMapping) TableName() string {
 	return "key_mappings"
 }
 
+func (t *DBKeyMapping) mapping() *pldapi.KeyMapping {
+	return &pldapi.KeyMapping{
+		Identifier: t.Identifier,
+		Wallet:     t.Wallet,
+		KeyHandle:  t.KeyHandle,
+		Rotation:   t.Rotation,
+		Disabled:   t.Disabled,
+	}
+}
+
 type DBKeyVerifier struct {
 	Identifier string `gorm:"column:identifier;primaryKey"`
 	Algorithm  string `gorm:"column:algorithm;primaryKey"`
@@ -48,6 +64,42 @@ func (t DBKeyVerifier) TableName() string {
 	return "key_verifiers"
 }
 
+type DBKeyVerifierHistory struct {
+	Identifier string             `gorm:"column:identifier"`
+	Rotation   int64              `gorm:"column:rotation"`
+	Retired    pldtypes.Timestamp `gorm:"column:retired"`
+	Wallet     string             `gorm:"column:wallet"`
+	KeyHandle  string             `gorm:"column:key_handle"`
+	Algorithm  string             `gorm:"column:algorithm;primaryKey"`
+	Type       string             `gorm:"column:type;primaryKey"`
+	Verifier   string             `gorm:"column:verifier;primaryKey"`
+}
+
+func (t DBKeyVerifierHistory) TableName() string {
+	return "key_verifier_history"
+}
+
+type DBKeySigningAuditEntry struct {
+	Sequence    uint64             `gorm:"column:seq;autoIncrement;primaryKey"`
+	Created     pldtypes.Timestamp `gorm:"column:created"`
+	Identifier  string             `gorm:"column:identifier"`
+	Wallet      string             `gorm:"column:wallet"`
+	KeyHandle   string             `gorm:"column:key_handle"`
+	Algorithm   string             `gorm:"column:algorithm"`
+	Verifier    string             `gorm:"column:verifier"`
+	PayloadType string             `gorm:"column:payload_type"`
+	PayloadHash pldtypes.Bytes32   `gorm:"column:payload_hash"`
+	Error       *string            `gorm:"column:error"`
+}
+
+func (t DBKeySigningAuditEntry) TableName() string {
+	return "key_signing_audit"
+}
+
+func (t *DBKeySigningAuditEntry) WriteKey() string {
+	return t.Identifier
+}
+
 var KeyEntryFilters filters.FieldSet = filters.FieldMap{
 	"isKey":       filters.BooleanField("key_mappings.identifier IS NOT NULL"),
 	"hasChildren": filters.BooleanField("k.p IS NOT NULL"),
@@ -56,4 +108,18 @@ var KeyEntryFilters filters.FieldSet = filters.FieldMap{
 	"path":        filters.StringField("path"),
 	"wallet":      filters.StringField("wallet"),
 	"keyHandle":   filters.StringField("key_handle"),
+	"rotation":    filters.Int64Field("rotation"),
+	"disabled":    filters.TimestampField("disabled"),
+}
+
+var signingAuditFilters = filters.FieldMap{
+	"sequence":    filters.Int64Field("seq"),
+	"created":     filters.TimestampField("created"),
+	"identifier":  filters.StringField("identifier"),
+	"wallet":      filters.StringField("wallet"),
+	"keyHandle":   filters.StringField("key_handle"),
+	"algorithm":   filters.StringField("algorithm"),
+	"verifier":    filters.StringField("verifier"),
+	"payloadType": filters.StringField("payload_type"),
+	"payloadHash": filters.Bytes32Field("payload_hash"),
 }
@@ -0,0 +1,333 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package keymanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+// Rotated keys are derived from a path allocated as a child of the identifier, with a segment
+// name containing a character that is not valid in an identifier. So the rotated key is assured
+// not to clash with any other key in the wallet, in either HD wallet or key store based signing modules.
+func rotationIdentifier(identifier string, rotation int64) string {
+	return fmt.Sprintf("%s.~r%d", identifier, rotation)
+}
+
+const rotationPathPattern = "%.~r%"
+
+func (km *keyManager) DisableKey(ctx context.Context, identifier string) error {
+	return km.setKeyDisabled(ctx, identifier, confutil.P(pldtypes.TimestampNow()))
+}
+
+func (km *keyManager) EnableKey(ctx context.Context, identifier string) error {
+	return km.setKeyDisabled(ctx, identifier, nil)
+}
+
+// A disabled key cannot be used for new signing operations, but remains available for resolution
+// and reverse lookup of its verifiers
+func (km *keyManager) setKeyDisabled(ctx context.Context, identifier string, disabled *pldtypes.Timestamp) error {
+	return km.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		db := dbTX.DB()
+		result := db.WithContext(ctx).
+			Model(&DBKeyMapping{}).
+			Where(`"identifier" = ?`, identifier).
+			Update("disabled", disabled)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return i18n.NewError(ctx, msgs.MsgKeyManagerExistingIdentifierNotFound, identifier)
+		}
+		var state DBKeyMapping
+		if err := db.WithContext(ctx).Where(`"identifier" = ?`, identifier).Take(&state).Error; err != nil {
+			return err
+		}
+		log.L(ctx).Infof("Key %s disabled=%t", identifier, disabled != nil)
+		dbTX.AddPostCommit(func(ctx context.Context) {
+			km.keyStateChanged(&state)
+		})
+		return nil
+	})
+}
+
+func (km *keyManager) RotateKey(ctx context.Context, identifier string) (rotation *pldapi.KeyRotation, err error) {
+	err = km.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		rotation, err = km.KeyResolverForDBTX(dbTX).(*keyResolver).rotateKey(ctx, identifier)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// Rotation derives a new key for the identifier, with all of the same verifier types as the
+// existing key. The verifiers of the previous key are moved to a history table, so they remain
+// available for reverse lookup (for verification of historical signatures), but the key handle
+// of the previous key cannot be used for new signing operations.
+// A key cannot be rotated while there are pending public transactions from its address.
+func (kr *keyResolver) rotateKey(ctx context.Context, identifier string) (*pldapi.KeyRotation, error) {
+	kr.l.Lock()
+	defer kr.l.Unlock()
+
+	// We take the allocation lock up-front, so the read of the current rotation is
+	// consistent with the allocation of the new path for the rotated key
+	if !kr.allocationLockTaken {
+		if err := kr.km.takeAllocationLock(ctx, kr); err != nil {
+			return nil, err // context cancelled while waiting
+		}
+		kr.allocationLockTaken = true
+	}
+
+	db := kr.dbTX.DB()
+	var mappings []*DBKeyMapping
+	err := db.WithContext(ctx).
+		Where(`"identifier" = ?`, identifier).
+		Limit(1).
+		Find(&mappings).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if len(mappings) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgKeyManagerExistingIdentifierNotFound, identifier)
+	}
+	prev := mappings[0]
+
+	w, err := kr.km.getWalletByName(ctx, prev.Wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	var prevVerifiers []*DBKeyVerifier
+	err = db.WithContext(ctx).
+		Where(`"identifier" = ?`, identifier).
+		Order(`"algorithm", "type"`).
+		Find(&prevVerifiers).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if len(prevVerifiers) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgKeyManagerNoVerifiersToRotate, identifier)
+	}
+
+	// Pending public transactions from the key could not be signed once it is retired, so they
+	// would block the nonces of the address indefinitely
+	for _, v := range prevVerifiers {
+		if v.Algorithm != algorithms.ECDSA_SECP256K1 || v.Type != verifiers.ETH_ADDRESS {
+			continue
+		}
+		addr, err := pldtypes.ParseEthAddress(v.Verifier)
+		if err != nil {
+			return nil, err
+		}
+		pending, err := kr.km.components.PublicTxManager().CountPendingTransactions(ctx, kr.dbTX, *addr)
+		if err != nil {
+			return nil, err
+		}
+		if pending > 0 {
+			return nil, i18n.NewError(ctx, msgs.MsgKeyManagerRotatePendingPublicTxns, identifier, pending, addr)
+		}
+	}
+
+	identifierPath, err := kr.getOrCreateIdentifierPath(ctx, identifier, false)
+	if err != nil {
+		return nil, err
+	}
+	newRotation := prev.Rotation + 1
+	rotationPath, err := kr.getOrCreateIdentifierPath(ctx, rotationIdentifier(identifier, newRotation), true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the new key, with all the verifier types of the previous key
+	required := make([]*prototk.PublicKeyIdentifierType, len(prevVerifiers))
+	retiredVerifiers := make([]*pldapi.KeyVerifier, len(prevVerifiers))
+	for i, v := range prevVerifiers {
+		required[i] = &prototk.PublicKeyIdentifierType{Algorithm: v.Algorithm, VerifierType: v.Type}
+		retiredVerifiers[i] = &pldapi.KeyVerifier{Algorithm: v.Algorithm, Type: v.Type, Verifier: v.Verifier}
+	}
+	mapping := &pldapi.KeyMappingWithPath{
+		KeyMapping: &pldapi.KeyMapping{
+			Identifier: identifier,
+			Wallet:     prev.Wallet,
+			Rotation:   newRotation,
+			Disabled:   prev.Disabled,
+		},
+		Path: identifierPath.pathSegments(),
+	}
+	newVerifiers, err := w.resolveKeyAndVerifiers(ctx, mapping, rotationPath.pathSegments(), required)
+	if err != nil {
+		return nil, err
+	}
+
+	// Move the verifiers of the previous key into the history table, and replace them with the new ones
+	retired := pldtypes.TimestampNow()
+	history := make([]*DBKeyVerifierHistory, len(prevVerifiers))
+	for i, v := range prevVerifiers {
+		history[i] = &DBKeyVerifierHistory{
+			Identifier: identifier,
+			Rotation:   prev.Rotation,
+			Retired:    retired,
+			Wallet:     prev.Wallet,
+			KeyHandle:  prev.KeyHandle,
+			Algorithm:  v.Algorithm,
+			Type:       v.Type,
+			Verifier:   v.Verifier,
+		}
+	}
+	dbVerifiers := make([]*DBKeyVerifier, len(newVerifiers))
+	for i, v := range newVerifiers {
+		dbVerifiers[i] = &DBKeyVerifier{
+			Identifier: identifier,
+			Algorithm:  v.Algorithm,
+			Type:       v.Type,
+			Verifier:   v.Verifier,
+		}
+	}
+	err = db.WithContext(ctx).Create(history).Error
+	if err == nil {
+		err = db.WithContext(ctx).
+			Where(`"identifier" = ?`, identifier).
+			Delete(&DBKeyVerifier{}).
+			Error
+	}
+	if err == nil {
+		err = db.WithContext(ctx).
+			Model(&DBKeyMapping{}).
+			Where(`"identifier" = ?`, identifier).
+			Updates(map[string]any{
+				"key_handle": mapping.KeyHandle,
+				"rotation":   newRotation,
+			}).
+			Error
+	}
+	if err == nil {
+		err = db.WithContext(ctx).Create(dbVerifiers).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.L(ctx).Infof("Rotated key: identifier=%s rotation=%d keyHandle=%s previousKeyHandle=%s",
+		identifier, newRotation, mapping.KeyHandle, prev.KeyHandle)
+	kr.dbTX.AddPostCommit(func(ctx context.Context) {
+		kr.km.keyStateChanged(&DBKeyMapping{
+			Identifier: identifier,
+			Wallet:     prev.Wallet,
+			KeyHandle:  mapping.KeyHandle,
+			Rotation:   newRotation,
+			Disabled:   prev.Disabled,
+		}, prevVerifiers...)
+	})
+	return &pldapi.KeyRotation{
+		KeyMappingWithPath: mapping,
+		Verifiers:          newVerifiers,
+		RetiredVerifiers:   retiredVerifiers,
+	}, nil
+}
+
+// Resolution of the key will load the new state from the DB, as we invalidate the caches here.
+// We also record the new state, to check on signing requests with mappings resolved before the change.
+func (km *keyManager) keyStateChanged(state *DBKeyMapping, verifiers ...*DBKeyVerifier) {
+	km.identifierCache.Delete(state.Identifier)
+	km.keyStatesLock.Lock()
+	km.keyStates[state.Identifier] = state
+	km.keyStatesLock.Unlock()
+	for _, v := range verifiers {
+		km.verifierByIdentityCache.Delete(verifierForwardCacheKey(state.Identifier, v.Algorithm, v.Type))
+	}
+	// Reverse lookups include the state of the mapping, but are not indexed by identifier
+	km.verifierReverseCache.Clear()
+}
+
+// Note no DB access is performed here, as signing might be happening inside a DB transaction that
+// resolved the key. The mapping reflects the state of the key when it was resolved, and any lifecycle
+// change since then in this runtime is recorded in the key states.
+func (km *keyManager) checkKeyUsable(ctx context.Context, mapping *pldapi.KeyMappingAndVerifier) error {
+	disabled := mapping.Disabled != nil
+	retired := mapping.Retired != nil
+	km.keyStatesLock.Lock()
+	state := km.keyStates[mapping.Identifier]
+	km.keyStatesLock.Unlock()
+	if state != nil {
+		disabled = state.Disabled != nil
+		retired = retired || state.KeyHandle != mapping.KeyHandle
+	}
+	if disabled {
+		return i18n.NewError(ctx, msgs.MsgKeyManagerKeyDisabled, mapping.Identifier)
+	}
+	if retired {
+		return i18n.NewError(ctx, msgs.MsgKeyManagerKeyRotated, mapping.KeyHandle, mapping.Identifier)
+	}
+	return nil
+}
+
+func (km *keyManager) reverseKeyLookupRetired(ctx context.Context, dbTX persistence.DBTX, algorithm, verifierType, verifier string) (*pldapi.KeyMappingAndVerifier, error) {
+	var retired []*DBKeyVerifierHistory
+	err := dbTX.DB().WithContext(ctx).
+		Where(`"algorithm" = ?`, algorithm).
+		Where(`"type" = ?`, verifierType).
+		Where(`"verifier" = ?`, verifier).
+		Limit(1).
+		Find(&retired).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if len(retired) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgKeyManagerVerifierLookupNotFound)
+	}
+	r := retired[0]
+
+	kr := km.newKeyResolver(dbTX, false /* allowing use with NOTX() */).(*keyResolver)
+	dbPath, err := kr.getOrCreateIdentifierPath(ctx, r.Identifier, false)
+	if err != nil {
+		return nil, err
+	}
+	mapping := &pldapi.KeyMappingAndVerifier{
+		KeyMappingWithPath: &pldapi.KeyMappingWithPath{
+			KeyMapping: &pldapi.KeyMapping{
+				Identifier: r.Identifier,
+				Wallet:     r.Wallet,
+				KeyHandle:  r.KeyHandle,
+				Rotation:   r.Rotation,
+			},
+			Path: dbPath.pathSegments(),
+		},
+		Verifier: &pldapi.KeyVerifier{
+			Algorithm: r.Algorithm,
+			Type:      r.Type,
+			Verifier:  r.Verifier,
+		},
+		Retired: &r.Retired,
+	}
+	km.verifierReverseCache.Set(verifierReverseCacheKey(algorithm, verifierType, verifier), mapping)
+	return mapping, nil
+}
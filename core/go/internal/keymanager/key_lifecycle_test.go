@@ -0,0 +1,399 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package keymanager
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyLifecycleDisableRotateAudit(t *testing.T) {
+	ctx, km, mc, done := newTestDBKeyManagerWithWallets(t, hdWalletConfig("hdwallet1", ""))
+	defer done()
+
+	rpc, rpcDone := newTestRPCServer(t, ctx, km)
+	defer rpcDone()
+
+	var key1 *pldapi.KeyMappingAndVerifier
+	err := rpc.CallRPC(ctx, &key1, "keymgr_resolveKey", "lc.key1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/60'/1'/0", key1.KeyHandle)
+
+	_, signErr := km.Sign(ctx, km.p.NOTX(), key1, signpayloads.OPAQUE_TO_RSV, []byte("payload1"))
+	require.NoError(t, signErr)
+
+	// Disable blocks signing with the mapping we resolved earlier, and with a fresh resolution
+	var ok bool
+	err = rpc.CallRPC(ctx, &ok, "keymgr_disableKey", "lc.key1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	_, signErr = km.Sign(ctx, km.p.NOTX(), key1, signpayloads.OPAQUE_TO_RSV, []byte("payload2"))
+	assert.Regexp(t, "PD010518", signErr)
+
+	var disabledKey *pldapi.KeyMappingAndVerifier
+	err = rpc.CallRPC(ctx, &disabledKey, "keymgr_resolveKey", "lc.key1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+	assert.NotNil(t, disabledKey.Disabled)
+	assert.Equal(t, key1.Verifier, disabledKey.Verifier)
+	km.keyStates = make(map[string]*DBKeyMapping)
+	_, signErr = km.Sign(ctx, km.p.NOTX(), disabledKey, signpayloads.OPAQUE_TO_RSV, []byte("payload2"))
+	assert.Regexp(t, "PD010518", signErr)
+
+	// Reverse lookup still works while disabled
+	var reverseLookedUp *pldapi.KeyMappingAndVerifier
+	err = rpc.CallRPC(ctx, &reverseLookedUp, "keymgr_reverseKeyLookup", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, key1.Verifier.Verifier)
+	require.NoError(t, err)
+	assert.Equal(t, "lc.key1", reverseLookedUp.Identifier)
+
+	err = rpc.CallRPC(ctx, &ok, "keymgr_enableKey", "lc.key1")
+	require.NoError(t, err)
+	_, signErr = km.Sign(ctx, km.p.NOTX(), disabledKey, signpayloads.OPAQUE_TO_RSV, []byte("payload3"))
+	require.NoError(t, signErr)
+
+	// Cannot rotate while there are public transactions pending from the key
+	key1Addr := pldtypes.MustEthAddress(key1.Verifier.Verifier)
+	mc.publicTxMgr.On("CountPendingTransactions", mock.Anything, mock.Anything, *key1Addr).Return(int64(2), nil).Once()
+	var rotation *pldapi.KeyRotation
+	err = rpc.CallRPC(ctx, &rotation, "keymgr_rotateKey", "lc.key1")
+	assert.Regexp(t, "PD010528.*2 public transactions", err)
+
+	// Rotate to a new key, derived from a new path under the identifier
+	mc.publicTxMgr.On("CountPendingTransactions", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil)
+	err = rpc.CallRPC(ctx, &rotation, "keymgr_rotateKey", "lc.key1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rotation.Rotation)
+	assert.Equal(t, "m/44'/60'/1'/0/0", rotation.KeyHandle)
+	assert.Equal(t, []*pldapi.KeyVerifier{key1.Verifier}, rotation.RetiredVerifiers)
+	require.Len(t, rotation.Verifiers, 1)
+	assert.NotEqual(t, key1.Verifier.Verifier, rotation.Verifiers[0].Verifier)
+
+	// The old mapping cannot be used to sign
+	_, signErr = km.Sign(ctx, km.p.NOTX(), key1, signpayloads.OPAQUE_TO_RSV, []byte("payload4"))
+	assert.Regexp(t, "PD010519", signErr)
+
+	// Resolution gives the new key, including for new verifier types
+	var key1r1 *pldapi.KeyMappingAndVerifier
+	err = rpc.CallRPC(ctx, &key1r1, "keymgr_resolveKey", "lc.key1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+	assert.Equal(t, rotation.Verifiers[0], key1r1.Verifier)
+	assert.Equal(t, key1.Path, key1r1.Path)
+	_, signErr = km.Sign(ctx, km.p.NOTX(), key1r1, signpayloads.OPAQUE_TO_RSV, []byte("payload5"))
+	require.NoError(t, signErr)
+	var key1r1PubKey *pldapi.KeyMappingAndVerifier
+	err = rpc.CallRPC(ctx, &key1r1PubKey, "keymgr_resolveKey", "lc.key1", algorithms.ECDSA_SECP256K1, verifiers.HEX_ECDSA_PUBKEY_UNCOMPRESSED)
+	require.NoError(t, err)
+	assert.Equal(t, rotation.KeyHandle, key1r1PubKey.KeyHandle)
+
+	// The old verifier remains reverse resolvable, but flagged as retired
+	var retiredKey *pldapi.KeyMappingAndVerifier
+	err = rpc.CallRPC(ctx, &retiredKey, "keymgr_reverseKeyLookup", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, key1.Verifier.Verifier)
+	require.NoError(t, err)
+	assert.Equal(t, key1.KeyHandle, retiredKey.KeyHandle)
+	assert.Equal(t, key1.Path, retiredKey.Path)
+	assert.NotNil(t, retiredKey.Retired)
+	km.keyStates = make(map[string]*DBKeyMapping)
+	_, signErr = km.Sign(ctx, km.p.NOTX(), retiredKey, signpayloads.OPAQUE_TO_RSV, []byte("payload6"))
+	assert.Regexp(t, "PD010519", signErr)
+
+	// A second rotation includes both the verifier types
+	err = rpc.CallRPC(ctx, &rotation, "keymgr_rotateKey", "lc.key1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), rotation.Rotation)
+	assert.Equal(t, "m/44'/60'/1'/0/1", rotation.KeyHandle)
+	assert.Len(t, rotation.Verifiers, 2)
+	assert.Len(t, rotation.RetiredVerifiers, 2)
+	retiredKey = nil
+	err = rpc.CallRPC(ctx, &retiredKey, "keymgr_reverseKeyLookup", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, key1.Verifier.Verifier)
+	require.NoError(t, err)
+	assert.Equal(t, key1.KeyHandle, retiredKey.KeyHandle)
+	assert.Equal(t, int64(0), retiredKey.Rotation)
+
+	// The rotation paths are not visible in the key list
+	var queryEntries []*pldapi.KeyQueryEntry
+	err = rpc.CallRPC(ctx, &queryEntries, "keymgr_queryKeys", query.NewQueryBuilder().Equal("parent", "lc").Limit(10).Query())
+	require.NoError(t, err)
+	require.Len(t, queryEntries, 1)
+	assert.True(t, queryEntries[0].IsKey)
+	assert.False(t, queryEntries[0].HasChildren)
+	assert.Equal(t, int64(2), queryEntries[0].Rotation)
+
+	// Every signing attempt is in the audit log
+	var auditEntries []*pldapi.KeySigningAuditEntry
+	require.Eventually(t, func() bool {
+		err = rpc.CallRPC(ctx, &auditEntries, "keymgr_querySigningAudit", query.NewQueryBuilder().Equal("identifier", "lc.key1").Limit(10).Query())
+		require.NoError(t, err)
+		return len(auditEntries) == 7
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, key1r1.KeyHandle, auditEntries[1].KeyHandle)
+	assert.Empty(t, auditEntries[1].Error)
+	assert.Regexp(t, "PD010519", auditEntries[0].Error)
+	assert.Equal(t, signpayloads.OPAQUE_TO_RSV, auditEntries[0].PayloadType)
+	assert.Regexp(t, "PD010518", auditEntries[4].Error)
+	assert.Equal(t, key1.Verifier.Verifier, auditEntries[6].Verifier)
+}
+
+func TestKeyLifecycleNotFound(t *testing.T) {
+	ctx, km, _, done := newTestDBKeyManagerWithWallets(t, hdWalletConfig("hdwallet1", ""))
+	defer done()
+
+	err := km.DisableKey(ctx, "unknown")
+	assert.Regexp(t, "PD010513", err)
+
+	_, err = km.RotateKey(ctx, "unknown")
+	assert.Regexp(t, "PD010513", err)
+
+	_, err = km.QuerySigningAudit(ctx, km.p.NOTX(), query.NewQueryBuilder().Query())
+	assert.Regexp(t, "PD010721", err)
+}
+
+func TestRotateKeyErrors(t *testing.T) {
+	ctx, km, mc, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{hdWalletConfig("hdwallet1", "")},
+	}, nil)
+	defer done()
+
+	mappingRows := func(wallet string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"identifier", "wallet", "key_handle", "rotation"}).
+			AddRow("key1", wallet, "m/44'/60'/1'/0", 0)
+	}
+	addr := pldtypes.RandAddress()
+	verifierRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"identifier", "algorithm", "type", "verifier"}).
+			AddRow("key1", algorithms.ECDSA_SECP256K1, verifiers.HEX_ECDSA_PUBKEY_UNCOMPRESSED, "0x1234").
+			AddRow("key1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, addr.String())
+	}
+
+	mc.db.ExpectBegin()
+	mc.db.ExpectQuery("SELECT.*key_mappings").WillReturnError(fmt.Errorf("pop"))
+	mc.db.ExpectRollback()
+	_, err := km.RotateKey(ctx, "key1")
+	assert.Regexp(t, "pop", err)
+
+	mc.db.ExpectBegin()
+	mc.db.ExpectQuery("SELECT.*key_mappings").WillReturnRows(mappingRows("unknown"))
+	mc.db.ExpectRollback()
+	_, err = km.RotateKey(ctx, "key1")
+	assert.Regexp(t, "PD010503", err)
+
+	mc.db.ExpectBegin()
+	mc.db.ExpectQuery("SELECT.*key_mappings").WillReturnRows(mappingRows("hdwallet1"))
+	mc.db.ExpectQuery("SELECT.*key_verifiers").WillReturnError(fmt.Errorf("pop"))
+	mc.db.ExpectRollback()
+	_, err = km.RotateKey(ctx, "key1")
+	assert.Regexp(t, "pop", err)
+
+	mc.db.ExpectBegin()
+	mc.db.ExpectQuery("SELECT.*key_mappings").WillReturnRows(mappingRows("hdwallet1"))
+	mc.db.ExpectQuery("SELECT.*key_verifiers").WillReturnRows(sqlmock.NewRows([]string{}))
+	mc.db.ExpectRollback()
+	_, err = km.RotateKey(ctx, "key1")
+	assert.Regexp(t, "PD010520", err)
+
+	mc.db.ExpectBegin()
+	mc.db.ExpectQuery("SELECT.*key_mappings").WillReturnRows(mappingRows("hdwallet1"))
+	mc.db.ExpectQuery("SELECT.*key_verifiers").WillReturnRows(sqlmock.NewRows([]string{"identifier", "algorithm", "type", "verifier"}).
+		AddRow("key1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, "wrong"))
+	mc.db.ExpectRollback()
+	_, err = km.RotateKey(ctx, "key1")
+	assert.Regexp(t, "bad address", err)
+
+	mc.publicTxMgr.On("CountPendingTransactions", mock.Anything, mock.Anything, *addr).Return(int64(0), fmt.Errorf("pop")).Once()
+	mc.db.ExpectBegin()
+	mc.db.ExpectQuery("SELECT.*key_mappings").WillReturnRows(mappingRows("hdwallet1"))
+	mc.db.ExpectQuery("SELECT.*key_verifiers").WillReturnRows(verifierRows())
+	mc.db.ExpectRollback()
+	_, err = km.RotateKey(ctx, "key1")
+	assert.Regexp(t, "pop", err)
+
+	mc.publicTxMgr.On("CountPendingTransactions", mock.Anything, mock.Anything, *addr).Return(int64(0), nil)
+
+	mc.db.ExpectBegin()
+	mc.db.ExpectQuery("SELECT.*key_mappings").WillReturnRows(mappingRows("hdwallet1"))
+	mc.db.ExpectQuery("SELECT.*key_verifiers").WillReturnRows(verifierRows())
+	mc.db.ExpectQuery("SELECT.*key_paths").WillReturnError(fmt.Errorf("pop"))
+	mc.db.ExpectRollback()
+	_, err = km.RotateKey(ctx, "key1")
+	assert.Regexp(t, "pop", err)
+
+	mc.db.ExpectBegin()
+	mc.db.ExpectQuery("SELECT.*key_mappings").WillReturnRows(mappingRows("hdwallet1"))
+	mc.db.ExpectQuery("SELECT.*key_verifiers").WillReturnRows(verifierRows())
+	mc.db.ExpectQuery("SELECT.*key_paths").WillReturnRows(sqlmock.NewRows([]string{"path", "index"}).AddRow("", 0))
+	mc.db.ExpectQuery("SELECT.*key_paths").WillReturnRows(sqlmock.NewRows([]string{"path", "index"}).AddRow("key1", 0))
+	mc.db.ExpectQuery("SELECT.*key_paths").WillReturnError(fmt.Errorf("pop"))
+	mc.db.ExpectRollback()
+	_, err = km.RotateKey(ctx, "key1")
+	assert.Regexp(t, "pop", err)
+
+	mc.db.ExpectBegin()
+	mc.db.ExpectQuery("SELECT.*key_mappings").WillReturnRows(mappingRows("hdwallet1"))
+	mc.db.ExpectQuery("SELECT.*key_verifiers").WillReturnRows(verifierRows())
+	mc.db.ExpectQuery("SELECT.*key_paths").WillReturnRows(sqlmock.NewRows([]string{"path", "index"}).AddRow("", 0))
+	mc.db.ExpectQuery("SELECT.*key_paths").WillReturnRows(sqlmock.NewRows([]string{"path", "index"}).AddRow("key1", 0))
+	mc.db.ExpectQuery("SELECT.*key_paths").WillReturnRows(sqlmock.NewRows([]string{"path", "index"}).AddRow("key1.~r1", 0))
+	mc.db.ExpectExec("INSERT.*key_verifier_history").WillReturnError(fmt.Errorf("pop"))
+	mc.db.ExpectRollback()
+	_, err = km.RotateKey(ctx, "key1")
+	assert.Regexp(t, "pop", err)
+}
+
+func TestRotateKeyCancelledWaitingForLock(t *testing.T) {
+	ctx, km, _, done := newTestDBKeyManagerWithWallets(t, hdWalletConfig("hdwallet1", ""))
+	defer done()
+
+	km.allocLockHolder = &keyResolver{id: "other", done: make(chan struct{})}
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err := km.RotateKey(cancelledCtx, "key1")
+	assert.Regexp(t, "PD010301", err)
+}
+
+func TestDisableKeyDBError(t *testing.T) {
+	ctx, km, mc, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{hdWalletConfig("hdwallet1", "")},
+	}, nil)
+	defer done()
+
+	mc.db.ExpectBegin()
+	mc.db.ExpectExec("UPDATE.*key_mappings").WillReturnError(fmt.Errorf("pop"))
+	mc.db.ExpectRollback()
+	err := km.DisableKey(ctx, "key1")
+	assert.Regexp(t, "pop", err)
+
+	mc.db.ExpectBegin()
+	mc.db.ExpectExec("UPDATE.*key_mappings").WillReturnResult(sqlmock.NewResult(0, 1))
+	mc.db.ExpectQuery("SELECT.*key_mappings").WillReturnError(fmt.Errorf("pop"))
+	mc.db.ExpectRollback()
+	err = km.EnableKey(ctx, "key1")
+	assert.Regexp(t, "pop", err)
+}
+
+func TestReverseKeyLookupRetiredErrors(t *testing.T) {
+	ctx, km, mc, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{hdWalletConfig("hdwallet1", "")},
+	}, nil)
+	defer done()
+
+	mc.db.ExpectQuery("SELECT.*key_verifiers").WillReturnRows(sqlmock.NewRows([]string{}))
+	mc.db.ExpectQuery("SELECT.*key_verifier_history").WillReturnError(fmt.Errorf("pop"))
+	_, err := km.ReverseKeyLookup(ctx, km.p.NOTX(), algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, "0x1234")
+	assert.Regexp(t, "pop", err)
+
+	mc.db.ExpectQuery("SELECT.*key_verifiers").WillReturnRows(sqlmock.NewRows([]string{}))
+	mc.db.ExpectQuery("SELECT.*key_verifier_history").WillReturnRows(
+		sqlmock.NewRows([]string{"identifier", "algorithm", "type", "verifier"}).
+			AddRow("key1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, "0x1234"))
+	mc.db.ExpectQuery("SELECT.*key_paths").WillReturnError(fmt.Errorf("pop"))
+	_, err = km.ReverseKeyLookup(ctx, km.p.NOTX(), algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, "0x1234")
+	assert.Regexp(t, "pop", err)
+}
+
+func testAuditKeyMapping() *pldapi.KeyMappingAndVerifier {
+	return &pldapi.KeyMappingAndVerifier{
+		KeyMappingWithPath: &pldapi.KeyMappingWithPath{KeyMapping: &pldapi.KeyMapping{
+			Identifier: "key1",
+			Wallet:     "hdwallet1",
+			KeyHandle:  "not a derivation path",
+		}},
+		Verifier: &pldapi.KeyVerifier{Algorithm: algorithms.ECDSA_SECP256K1, Type: verifiers.ETH_ADDRESS, Verifier: "0x1234"},
+	}
+}
+
+func TestSigningAuditWriteFail(t *testing.T) {
+	ctx, km, mc, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{hdWalletConfig("hdwallet1", "")},
+	}, nil)
+	defer done()
+
+	mc.db.ExpectBegin()
+	mc.db.ExpectQuery("INSERT.*key_signing_audit").WillReturnError(fmt.Errorf("pop"))
+	mc.db.ExpectRollback()
+
+	// The audit failure is returned, in preference to any error from the signing itself
+	signature, err := km.Sign(ctx, km.p.NOTX(), testAuditKeyMapping(), signpayloads.OPAQUE_TO_RSV, []byte("payload"))
+	assert.Regexp(t, "PD010529.*key1.*pop", err)
+	assert.Nil(t, signature)
+}
+
+func TestSigningAuditWaitCancelled(t *testing.T) {
+	ctx, km, _, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{hdWalletConfig("hdwallet1", "")},
+	}, nil)
+	defer done()
+
+	cancelledCtx, cancelCtx := context.WithCancel(ctx)
+	cancelCtx()
+	_, err := km.Sign(cancelledCtx, km.p.NOTX(), testAuditKeyMapping(), signpayloads.OPAQUE_TO_RSV, []byte("payload"))
+	assert.Regexp(t, "PD010529.*PD010301", err)
+}
+
+func TestSigningAuditInDBTXFail(t *testing.T) {
+	ctx, km, mc, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{hdWalletConfig("hdwallet1", "")},
+	}, nil)
+	defer done()
+
+	mc.db.ExpectBegin()
+	mc.db.ExpectQuery("INSERT.*key_signing_audit").WillReturnError(fmt.Errorf("pop"))
+	mc.db.ExpectRollback()
+
+	err := km.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := km.Sign(ctx, dbTX, testAuditKeyMapping(), signpayloads.OPAQUE_TO_RSV, []byte("payload"))
+		return err
+	})
+	assert.Regexp(t, "PD010529.*pop", err)
+}
+
+func TestResolveRotatedKeyPathFail(t *testing.T) {
+	ctx, km, mc, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{hdWalletConfig("hdwallet1", "")},
+	}, nil)
+	defer done()
+
+	mc.db.ExpectBegin()
+	mockQueryRootExisting(mc)
+	mc.db.ExpectQuery("SELECT.*").WillReturnRows(sqlmock.NewRows([]string{
+		"segment", "index", "path", "next_index", "parent",
+	}).AddRow(
+		"key1", 0, "key1", 1, "",
+	))
+	mc.db.ExpectQuery("SELECT.*key_mappings").WillReturnRows(
+		sqlmock.NewRows([]string{"identifier", "wallet", "key_handle", "rotation"}).
+			AddRow("key1", "hdwallet1", "m/44'/60'/0'/0", 1))
+	mc.db.ExpectQuery("SELECT.*key_verifiers").WillReturnRows(sqlmock.NewRows([]string{}))
+	mc.db.ExpectQuery("SELECT.*key_paths").WillReturnError(fmt.Errorf("pop"))
+
+	err := km.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := km.KeyResolverForDBTX(dbTX).ResolveKey(ctx, "key1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+		return err
+	})
+	require.Regexp(t, "pop", err)
+}
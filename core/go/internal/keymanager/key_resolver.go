@@ -284,12 +284,8 @@ func (kr *keyResolver) resolveKey(ctx context.Context, identifier, algorithm, ve
 		// Now we know if we're creating a new DB, or we have an existing one
 		if len(mappings) > 0 {
 			mapping = &pldapi.KeyMappingWithPath{
-				KeyMapping: &pldapi.KeyMapping{
-					Identifier: mappings[0].Identifier,
-					Wallet:     mappings[0].Wallet,
-					KeyHandle:  mappings[0].KeyHandle,
-				},
-				Path: dbPath.pathSegments(),
+				KeyMapping: mappings[0].mapping(),
+				Path:       dbPath.pathSegments(),
 			}
 		} else {
 			if requireExistingMapping {
@@ -366,8 +362,16 @@ func (kr *keyResolver) resolveMapping(ctx context.Context, mapping *pldapi.KeyMa
 	}
 
 	// Ok - we are ready to talk to the wallet signing module to resolve the
-	// key handle and verifier.
-	result, err := w.resolveKeyAndVerifier(ctx, mapping, algorithm, verifierType)
+	// key handle and verifier - which for a rotated key is at the path allocated for that rotation.
+	signingPath := mapping.Path
+	if mapping.Rotation > 0 {
+		rotationPath, err := kr.getOrCreateIdentifierPath(ctx, rotationIdentifier(identifier, mapping.Rotation), false)
+		if err != nil {
+			return nil, err
+		}
+		signingPath = rotationPath.pathSegments()
+	}
+	result, err := w.resolveKeyAndVerifier(ctx, mapping, signingPath, algorithm, verifierType)
 	if err != nil {
 		return nil, err
 	}
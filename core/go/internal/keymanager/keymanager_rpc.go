@@ -35,7 +35,11 @@ func (km *keyManager) initRPC() {
 		Add("keymgr_resolveKey", km.rpcResolveKey()).
 		Add("keymgr_resolveEthAddress", km.rpcResolveEthAddress()).
 		Add("keymgr_reverseKeyLookup", km.rpcReverseKeyLookup()).
-		Add("keymgr_queryKeys", km.rpcQueryKeys())
+		Add("keymgr_queryKeys", km.rpcQueryKeys()).
+		Add("keymgr_disableKey", km.rpcDisableKey()).
+		Add("keymgr_enableKey", km.rpcEnableKey()).
+		Add("keymgr_rotateKey", km.rpcRotateKey()).
+		Add("keymgr_querySigningAudit", km.rpcQuerySigningAudit())
 
 }
 
@@ -81,3 +85,35 @@ func (km *keyManager) rpcQueryKeys() rpcserver.RPCHandler {
 		return km.QueryKeys(ctx, km.p.DB(), &jq)
 	})
 }
+
+func (km *keyManager) rpcDisableKey() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		identifier string,
+	) (bool, error) {
+		return true, km.DisableKey(ctx, identifier)
+	})
+}
+
+func (km *keyManager) rpcEnableKey() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		identifier string,
+	) (bool, error) {
+		return true, km.EnableKey(ctx, identifier)
+	})
+}
+
+func (km *keyManager) rpcRotateKey() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		identifier string,
+	) (*pldapi.KeyRotation, error) {
+		return km.RotateKey(ctx, identifier)
+	})
+}
+
+func (km *keyManager) rpcQuerySigningAudit() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		jq query.QueryJSON,
+	) ([]*pldapi.KeySigningAuditEntry, error) {
+		return km.QuerySigningAudit(ctx, km.p.NOTX(), &jq)
+	})
+}
@@ -17,7 +17,12 @@ package keymanager
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/go-resty/resty/v2"
@@ -29,6 +34,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
 	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -141,6 +147,69 @@ func TestRPCLocalDetails(t *testing.T) {
 
 }
 
+func TestRPCResolveKeyCurvesAndVerifierTypes(t *testing.T) {
+	// Known answers from RFC 6979 A.2.5 (P-256) and RFC 8032 7.1 test 1 (Ed25519)
+	staticKeys := staticKeyConfig("static", "")
+	staticKeys.Signer.KeyStore.Static.Keys["p256.key"] = pldconf.StaticKeyEntryConfig{
+		Encoding: "hex",
+		Inline:   "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721",
+	}
+	staticKeys.Signer.KeyStore.Static.Keys["ed25519.key"] = pldconf.StaticKeyEntryConfig{
+		Encoding: "hex",
+		Inline:   "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+	}
+	ctx, km, _, done := newTestDBKeyManagerWithWallets(t, staticKeys)
+	defer done()
+
+	rpc, rpcDone := newTestRPCServer(t, ctx, km)
+	defer rpcDone()
+
+	p256X := "60fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6"
+	p256Y := "7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299"
+	ed25519Pub := "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a"
+	for _, tc := range []struct {
+		identifier   string
+		algorithm    string
+		verifierType string
+		expected     string
+	}{
+		{"p256.key", algorithms.ECDSA_SECP256R1, verifiers.HEX_ECDSA_PUBKEY_UNCOMPRESSED, p256X + p256Y},
+		{"p256.key", algorithms.ECDSA_SECP256R1, verifiers.HEX_ECDSA_PUBKEY_UNCOMPRESSED_0X, "0x" + p256X + p256Y},
+		{"p256.key", algorithms.ECDSA_SECP256R1, verifiers.JWK, `{"crv":"P-256","kty":"EC","x":"YP7UuiVanTHJYet0xjVtaMBJuJI7Yfps5mliLmDyn7Y","y":"eQP-EAi4vJmkGunpVii8ZPLxsgwtfp9Rd6PClNRGIpk"}`},
+		{"ed25519.key", algorithms.EDDSA_ED25519, verifiers.HEX_ED25519_PUBKEY, ed25519Pub},
+		{"ed25519.key", algorithms.EDDSA_ED25519, verifiers.DID_KEY, "did:key:z6MktwupdmLXVVqTzCw4i46r4uGyosGXRnR3XjN4Zq7oMMsw"},
+	} {
+		// The same resolution the identity resolver performs for ptx_resolveVerifier on a local lookup
+		resolved, err := km.ResolveKeyNewDatabaseTX(ctx, tc.identifier, tc.algorithm, tc.verifierType)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, resolved.Verifier.Verifier, "%s %s", tc.algorithm, tc.verifierType)
+
+		var viaRPC *pldapi.KeyMappingAndVerifier
+		err = rpc.CallRPC(ctx, &viaRPC, "keymgr_resolveKey", tc.identifier, tc.algorithm, tc.verifierType)
+		require.NoError(t, err)
+		assert.Equal(t, resolved, viaRPC)
+	}
+
+	// Signatures from the resolved keys verify against the published verifiers
+	digest := sha256.Sum256([]byte("some data"))
+	p256Mapping, err := km.ResolveKeyNewDatabaseTX(ctx, "p256.key", algorithms.ECDSA_SECP256R1, verifiers.HEX_ECDSA_PUBKEY_UNCOMPRESSED)
+	require.NoError(t, err)
+	sig, err := km.Sign(ctx, km.p.NOTX(), p256Mapping, signpayloads.OPAQUE_TO_RS, digest[:])
+	require.NoError(t, err)
+	pubKey := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(pldtypes.MustParseHexBytes(p256X)),
+		Y:     new(big.Int).SetBytes(pldtypes.MustParseHexBytes(p256Y)),
+	}
+	assert.True(t, ecdsa.Verify(pubKey, digest[:], new(big.Int).SetBytes(sig[0:32]), new(big.Int).SetBytes(sig[32:64])))
+
+	ed25519Mapping, err := km.ResolveKeyNewDatabaseTX(ctx, "ed25519.key", algorithms.EDDSA_ED25519, verifiers.HEX_ED25519_PUBKEY)
+	require.NoError(t, err)
+	sig, err = km.Sign(ctx, km.p.NOTX(), ed25519Mapping, signpayloads.OPAQUE_TO_ED25519, []byte("some data"))
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(ed25519.PublicKey(pldtypes.MustParseHexBytes(ed25519Pub)), []byte("some data"), sig))
+}
+
 func newTestRPCServer(t *testing.T, ctx context.Context, km *keyManager) (rpcclient.Client, func()) {
 
 	s, err := rpcserver.NewRPCServer(ctx, &pldconf.RPCServerConfig{
@@ -24,6 +24,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/internal/flushwriter"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
@@ -45,16 +46,23 @@ type keyManager struct {
 	bgCtx context.Context
 
 	conf                    *pldconf.KeyManagerConfig
+	components              components.AllComponents
 	rpcModule               *rpcserver.RPCModule
 	identifierCache         cache.Cache[string, *pldapi.KeyMappingWithPath]
 	verifierByIdentityCache cache.Cache[string, *pldapi.KeyVerifier]
 	verifierReverseCache    cache.Cache[string, *pldapi.KeyMappingAndVerifier]
+	signingAuditWriter      flushwriter.Writer[*DBKeySigningAuditEntry, *noResult]
 	walletsOrdered          []*wallet
 	walletsByName           map[string]*wallet
+	publicTxPolicies        bool
 
 	allocLock       sync.Mutex
 	allocLockHolder *keyResolver
 
+	// Not a cache, as entries must not be evicted - but only keys that have been through a lifecycle change are recorded
+	keyStatesLock sync.Mutex
+	keyStates     map[string]*DBKeyMapping
+
 	// plugin signing modules
 	mux                  sync.Mutex
 	signingModulesByID   map[uuid.UUID]*signingModule
@@ -72,6 +80,7 @@ func NewKeyManager(bgCtx context.Context, conf *pldconf.KeyManagerConfig) compon
 		signingModulesByName:    make(map[string]*signingModule),
 		verifierByIdentityCache: cache.NewCache[string, *pldapi.KeyVerifier](&conf.VerifierCache, &pldconf.KeyManagerDefaults.VerifierCache),
 		verifierReverseCache:    cache.NewCache[string, *pldapi.KeyMappingAndVerifier](&conf.VerifierCache, &pldconf.KeyManagerDefaults.VerifierCache),
+		keyStates:               make(map[string]*DBKeyMapping),
 		walletsByName:           make(map[string]*wallet),
 	}
 }
@@ -84,7 +93,10 @@ func (km *keyManager) PreInit(pic components.PreInitComponents) (*components.Man
 }
 
 func (km *keyManager) PostInit(c components.AllComponents) error {
+	km.components = c
 	km.p = c.Persistence()
+	km.signingAuditWriter = flushwriter.NewWriter(km.bgCtx, km.writeSigningAuditBatch, km.p,
+		&km.conf.SigningAuditWriter, &pldconf.KeyManagerDefaults.SigningAuditWriter)
 	return nil
 }
 
@@ -100,12 +112,17 @@ func (km *keyManager) Start() error {
 		}
 		km.walletsByName[w.name] = w
 		km.walletsOrdered = append(km.walletsOrdered, w)
+		km.publicTxPolicies = km.publicTxPolicies || w.signingPolicy.hasPublicTransactionPolicy()
 	}
 
+	km.signingAuditWriter.Start()
 	return nil
 }
 
 func (km *keyManager) Stop() {
+	if km.signingAuditWriter != nil {
+		km.signingAuditWriter.Shutdown()
+	}
 }
 
 func (km *keyManager) cleanupSigningModule(sm *signingModule) {
@@ -160,12 +177,22 @@ func (km *keyManager) GetSigningModule(ctx context.Context, name string) (signer
 	return sm, nil
 }
 
-func (km *keyManager) Sign(ctx context.Context, mapping *pldapi.KeyMappingAndVerifier, payloadType string, payload []byte) ([]byte, error) {
+func (km *keyManager) Sign(ctx context.Context, dbTX persistence.DBTX, mapping *pldapi.KeyMappingAndVerifier, payloadType string, payload []byte) (signature []byte, err error) {
 	w, err := km.getWalletByName(ctx, mapping.Wallet)
 	if err != nil {
 		return nil, err
 	}
-	return w.sign(ctx, mapping, payloadType, payload)
+	// Disabled and rotated keys are rejected, as are requests that violate the signing policy of the
+	// wallet, and recorded in the audit log along with every other attempt
+	err = km.checkKeyUsable(ctx, mapping)
+	if err == nil {
+		signature, err = w.sign(ctx, mapping, payloadType, payload)
+	}
+	// The signature is only returned once the attempt is recorded in the audit log
+	if auditErr := km.auditSign(ctx, dbTX, mapping, payloadType, payload, err); auditErr != nil {
+		return nil, auditErr
+	}
+	return signature, err
 }
 
 func (km *keyManager) lockAllocationOrGetOwner(kr *keyResolver) *keyResolver {
@@ -286,7 +313,8 @@ func (km *keyManager) ReverseKeyLookup(ctx context.Context, dbTX persistence.DBT
 		return nil, err
 	}
 	if len(dbVerifiers) == 0 {
-		return nil, i18n.NewError(ctx, msgs.MsgKeyManagerVerifierLookupNotFound)
+		// The verifier might belong to a key that has been retired by a rotation
+		return km.reverseKeyLookupRetired(ctx, dbTX, algorithm, verifierType, verifier)
 	}
 
 	// Now we need to look up the associated mapping and rebuild it
@@ -312,12 +340,16 @@ func (km *keyManager) QueryKeys(ctx context.Context, dbTX *gorm.DB, jq *query.Qu
 		`key_paths."index" AS "index",` +
 		`key_paths.path AS "path",` +
 		`key_mappings.wallet AS "wallet",` +
-		`key_mappings.key_handle AS "key_handle"`,
+		`key_mappings.key_handle AS "key_handle",` +
+		`key_mappings.rotation AS "rotation",` +
+		`key_mappings.disabled AS "disabled"`,
 	)
 
+	// Paths allocated for key rotations are excluded, as they are not identifiers
 	q.Joins("LEFT OUTER JOIN key_mappings ON key_paths.path = key_mappings.identifier")
-	q.Joins(`LEFT OUTER JOIN (SELECT parent AS "p" from key_paths AS p) AS k ON key_paths.path = k.p`)
+	q.Joins(`LEFT OUTER JOIN (SELECT parent AS "p" from key_paths AS p WHERE p.path NOT LIKE ?) AS k ON key_paths.path = k.p`, rotationPathPattern)
 	q.Where("key_paths.path != ''")
+	q.Where("key_paths.path NOT LIKE ?", rotationPathPattern)
 
 	err = q.Find(&keyList).Error
 	if err != nil {
@@ -45,8 +45,9 @@ import (
 )
 
 type mockComponents struct {
-	c  *componentsmocks.AllComponents
-	db sqlmock.Sqlmock
+	c           *componentsmocks.AllComponents
+	publicTxMgr *componentsmocks.PublicTxManager
+	db          sqlmock.Sqlmock
 }
 
 func newTestSigner(t *testing.T) (context.Context, signer.SigningModule) {
@@ -78,8 +79,12 @@ func newTestKeyManager(t *testing.T, realDB bool, conf *pldconf.KeyManagerConfig
 	oldLevel := logrus.GetLevel()
 	logrus.SetLevel(logrus.TraceLevel)
 
-	mc := &mockComponents{c: componentsmocks.NewAllComponents(t)}
+	mc := &mockComponents{
+		c:           componentsmocks.NewAllComponents(t),
+		publicTxMgr: componentsmocks.NewPublicTxManager(t),
+	}
 	componentsmocks := mc.c
+	componentsmocks.On("PublicTxManager").Return(mc.publicTxMgr).Maybe()
 
 	var p persistence.Persistence
 	var pDone func()
@@ -346,7 +351,7 @@ func TestE2ESigningHDWalletRealDB(t *testing.T) {
 
 			// sign and recover something
 			payload := []byte("some data")
-			signature, err := km.Sign(ctx, resolved1, signpayloads.OPAQUE_TO_RSV, payload)
+			signature, err := km.Sign(ctx, dbTX, resolved1, signpayloads.OPAQUE_TO_RSV, payload)
 			require.NoError(t, err)
 			sig, err := secp256k1.DecodeCompactRSV(ctx, signature)
 			require.NoError(t, err)
@@ -546,7 +551,7 @@ func TestE2ESigningModulePluginRealDB(t *testing.T) {
 
 			// sign and recover something
 			payload := []byte("some data")
-			signature, err := km.Sign(ctx, resolved1, signpayloads.OPAQUE_TO_RSV, payload)
+			signature, err := km.Sign(ctx, dbTX, resolved1, signpayloads.OPAQUE_TO_RSV, payload)
 			require.NoError(t, err)
 			sig, err := secp256k1.DecodeCompactRSV(ctx, signature)
 			require.NoError(t, err)
@@ -813,7 +818,7 @@ func TestSignUnknownWallet(t *testing.T) {
 	ctx, km, _, done := newTestDBKeyManagerWithWallets(t)
 	defer done()
 
-	_, err := km.Sign(ctx, &pldapi.KeyMappingAndVerifier{KeyMappingWithPath: &pldapi.KeyMappingWithPath{KeyMapping: &pldapi.KeyMapping{
+	_, err := km.Sign(ctx, km.p.NOTX(), &pldapi.KeyMappingAndVerifier{KeyMappingWithPath: &pldapi.KeyMappingWithPath{KeyMapping: &pldapi.KeyMapping{
 		Wallet: "unknown",
 	}}}, signpayloads.OPAQUE_TO_RSV, []byte{})
 	assert.Regexp(t, "PD010503", err)
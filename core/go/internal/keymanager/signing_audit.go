@@ -0,0 +1,97 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package keymanager
+
+import (
+	"context"
+	"crypto/sha256"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/internal/flushwriter"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
+
+type noResult struct{}
+
+// The signing audit log is append-only, and a signature is never returned without an audit entry.
+// Within a DB transaction the entry is written in that transaction, so it commits (or rolls back) along with
+// whatever the signature was for. Otherwise entries are written in batches across all the signing going on
+// in the node, and the caller waits for its entry to be committed.
+func (km *keyManager) auditSign(ctx context.Context, dbTX persistence.DBTX, mapping *pldapi.KeyMappingAndVerifier, payloadType string, payload []byte, err error) error {
+	entry := &DBKeySigningAuditEntry{
+		Created:     pldtypes.TimestampNow(),
+		Identifier:  mapping.Identifier,
+		Wallet:      mapping.Wallet,
+		KeyHandle:   mapping.KeyHandle,
+		Algorithm:   mapping.Verifier.Algorithm,
+		Verifier:    mapping.Verifier.Verifier,
+		PayloadType: payloadType,
+		PayloadHash: sha256.Sum256(payload),
+	}
+	if err != nil {
+		errString := err.Error()
+		entry.Error = &errString
+	}
+	if dbTX.FullTransaction() {
+		err = dbTX.DB().WithContext(ctx).Create(entry).Error
+	} else {
+		_, err = km.signingAuditWriter.Queue(ctx, entry).WaitFlushed(ctx)
+	}
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgKeyManagerSigningAuditFailed, mapping.Identifier)
+	}
+	return nil
+}
+
+func (km *keyManager) writeSigningAuditBatch(ctx context.Context, dbTX persistence.DBTX, entries []*DBKeySigningAuditEntry) ([]flushwriter.Result[*noResult], error) {
+	err := dbTX.DB().WithContext(ctx).Create(entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return make([]flushwriter.Result[*noResult], len(entries)), nil
+}
+
+func (km *keyManager) QuerySigningAudit(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.KeySigningAuditEntry, error) {
+	qw := &filters.QueryWrapper[DBKeySigningAuditEntry, pldapi.KeySigningAuditEntry]{
+		P:           km.p,
+		DefaultSort: "-sequence",
+		Filters:     signingAuditFilters,
+		Query:       jq,
+		MapResult: func(e *DBKeySigningAuditEntry) (*pldapi.KeySigningAuditEntry, error) {
+			entry := &pldapi.KeySigningAuditEntry{
+				Sequence:    e.Sequence,
+				Created:     e.Created,
+				Identifier:  e.Identifier,
+				Wallet:      e.Wallet,
+				KeyHandle:   e.KeyHandle,
+				Algorithm:   e.Algorithm,
+				Verifier:    e.Verifier,
+				PayloadType: e.PayloadType,
+				PayloadHash: e.PayloadHash,
+			}
+			if e.Error != nil {
+				entry.Error = *e.Error
+			}
+			return entry, nil
+		},
+	}
+	return qw.Run(ctx, dbTX)
+}
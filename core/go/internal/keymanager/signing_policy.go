@@ -0,0 +1,177 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package keymanager
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+type signingPolicy struct {
+	payloadTypes map[string]bool
+	algorithms   map[string]bool
+	contracts    map[pldtypes.EthAddress]bool
+	allowDeploy  bool
+	maxValue     *pldtypes.HexUint256
+	rateLimit    *rateLimiter
+}
+
+// A simple fixed window limiter, shared by all keys in the wallet
+type rateLimiter struct {
+	lock          sync.Mutex
+	maxSignatures int
+	period        time.Duration
+	windowStart   time.Time
+	count         int
+}
+
+func newSigningPolicy(ctx context.Context, walletName string, conf *pldconf.SigningPolicyConfig) (*signingPolicy, error) {
+	if conf == nil {
+		return nil, nil
+	}
+	sp := &signingPolicy{
+		payloadTypes: make(map[string]bool),
+		algorithms:   make(map[string]bool),
+		contracts:    make(map[pldtypes.EthAddress]bool),
+		allowDeploy:  confutil.Bool(conf.AllowDeploy, *pldconf.SigningPolicyDefaults.AllowDeploy),
+	}
+	for _, payloadType := range conf.AllowedPayloadTypes {
+		sp.payloadTypes[payloadType] = true
+	}
+	for _, algorithm := range conf.AllowedAlgorithms {
+		sp.algorithms[algorithm] = true
+	}
+	for _, contract := range conf.AllowedContracts {
+		addr, err := pldtypes.ParseEthAddress(contract)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgKeyManagerInvalidSigningPolicy, walletName)
+		}
+		sp.contracts[*addr] = true
+	}
+	if conf.MaxValue != nil {
+		maxValue, err := pldtypes.ParseHexUint256(ctx, *conf.MaxValue)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgKeyManagerInvalidSigningPolicy, walletName)
+		}
+		sp.maxValue = maxValue
+	}
+	maxSignatures := confutil.Int(conf.RateLimit.MaxSignatures, 0)
+	if maxSignatures > 0 {
+		sp.rateLimit = &rateLimiter{
+			maxSignatures: maxSignatures,
+			period:        confutil.DurationMin(conf.RateLimit.Period, 1*time.Millisecond, *pldconf.SigningPolicyDefaults.RateLimit.Period),
+		}
+	}
+	return sp, nil
+}
+
+func (w *wallet) policyError(ctx context.Context, msg i18n.ErrorMessageKey, inserts ...any) error {
+	return &components.SigningPolicyError{
+		Wallet: w.name,
+		Cause:  i18n.NewError(ctx, msg, append([]any{w.name}, inserts...)...),
+	}
+}
+
+func (w *wallet) checkSigningPolicy(ctx context.Context, algorithm, payloadType string) error {
+	sp := w.signingPolicy
+	if sp == nil {
+		return nil
+	}
+	if len(sp.payloadTypes) > 0 && !sp.payloadTypes[payloadType] {
+		return w.policyError(ctx, msgs.MsgKeyManagerPolicyPayloadType, payloadType)
+	}
+	if len(sp.algorithms) > 0 && !sp.algorithms[algorithm] {
+		return w.policyError(ctx, msgs.MsgKeyManagerPolicyAlgorithm, algorithm)
+	}
+	// The rate limit is checked last, so requests rejected for other reasons do not count towards it.
+	// Exceeding it is not a policy violation, as the same request will be allowed in a later window,
+	// so callers retry it as they would any other signing failure.
+	if sp.rateLimit != nil && !sp.rateLimit.allow() {
+		return i18n.NewError(ctx, msgs.MsgKeyManagerPolicyRateLimit, w.name, sp.rateLimit.maxSignatures, sp.rateLimit.period)
+	}
+	return nil
+}
+
+func (w *wallet) checkPublicTransactionPolicy(ctx context.Context, to *pldtypes.EthAddress, value *pldtypes.HexUint256) error {
+	sp := w.signingPolicy
+	if sp == nil {
+		return nil
+	}
+	if len(sp.contracts) > 0 {
+		if to == nil && !sp.allowDeploy {
+			return w.policyError(ctx, msgs.MsgKeyManagerPolicyDeploy)
+		}
+		if to != nil && !sp.contracts[*to] {
+			return w.policyError(ctx, msgs.MsgKeyManagerPolicyContract, to)
+		}
+	}
+	if sp.maxValue != nil && value != nil && value.Int().Cmp(sp.maxValue.Int()) > 0 {
+		return w.policyError(ctx, msgs.MsgKeyManagerPolicyMaxValue, value.Int(), sp.maxValue.Int())
+	}
+	return nil
+}
+
+func (sp *signingPolicy) hasPublicTransactionPolicy() bool {
+	return sp != nil && (len(sp.contracts) > 0 || sp.maxValue != nil)
+}
+
+func (rl *rateLimiter) allow() bool {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	now := time.Now()
+	if now.Sub(rl.windowStart) >= rl.period {
+		rl.windowStart = now
+		rl.count = 0
+	}
+	if rl.count >= rl.maxSignatures {
+		return false
+	}
+	rl.count++
+	return true
+}
+
+func (km *keyManager) CheckPublicTransactionPolicy(ctx context.Context, dbTX persistence.DBTX, from pldtypes.EthAddress, to *pldtypes.EthAddress, value *pldtypes.HexUint256) error {
+	// Avoid the reverse lookup entirely if no wallet has a policy that applies
+	if !km.publicTxPolicies {
+		return nil
+	}
+	mapping, err := km.ReverseKeyLookup(ctx, dbTX, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, from.String())
+	if err != nil {
+		return err
+	}
+	w, err := km.getWalletByName(ctx, mapping.Wallet)
+	if err != nil {
+		return err
+	}
+	err = w.checkPublicTransactionPolicy(ctx, to, value)
+	if err != nil {
+		log.L(ctx).Errorf("Public transaction from %s rejected: %s", from, err)
+	}
+	return err
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package keymanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningPolicySign(t *testing.T) {
+	restricted := hdWalletConfig("restricted", "^restricted\\.")
+	restricted.SigningPolicy = &pldconf.SigningPolicyConfig{
+		AllowedPayloadTypes: []string{signpayloads.OPAQUE_TO_RSV},
+		AllowedAlgorithms:   []string{algorithms.ECDSA_SECP256K1},
+		RateLimit: pldconf.SigningRateLimitConfig{
+			MaxSignatures: confutil.P(2),
+			Period:        confutil.P("1h"),
+		},
+	}
+	wrongAlgo := hdWalletConfig("wrongalgo", "^wrongalgo\\.")
+	wrongAlgo.SigningPolicy = &pldconf.SigningPolicyConfig{
+		AllowedAlgorithms: []string{"domain:other:snark"},
+	}
+	ctx, km, _, done := newTestDBKeyManagerWithWallets(t, restricted, wrongAlgo)
+	defer done()
+
+	key1, err := km.ResolveKeyNewDatabaseTX(ctx, "restricted.key1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+
+	_, err = km.Sign(ctx, km.p.NOTX(), key1, signpayloads.OPAQUE_TO_RS, []byte("payload"))
+	assert.Regexp(t, "PD010522", err)
+	assert.True(t, components.IsSigningPolicyError(err))
+
+	// Rejected requests do not count towards the rate limit
+	for i := 0; i < 2; i++ {
+		_, err = km.Sign(ctx, km.p.NOTX(), key1, signpayloads.OPAQUE_TO_RSV, []byte("payload"))
+		require.NoError(t, err)
+	}
+	_, err = km.Sign(ctx, km.p.NOTX(), key1, signpayloads.OPAQUE_TO_RSV, []byte("payload"))
+	assert.Regexp(t, "PD010524.*2 signatures every 1h0m0s", err)
+	assert.False(t, components.IsSigningPolicyError(err))
+
+	// The limit resets once the window has passed
+	km.walletsByName["restricted"].signingPolicy.rateLimit.windowStart = time.Now().Add(-1 * time.Hour)
+	_, err = km.Sign(ctx, km.p.NOTX(), key1, signpayloads.OPAQUE_TO_RSV, []byte("payload"))
+	require.NoError(t, err)
+
+	key2, err := km.ResolveKeyNewDatabaseTX(ctx, "wrongalgo.key2", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+	_, err = km.Sign(ctx, km.p.NOTX(), key2, signpayloads.OPAQUE_TO_RSV, []byte("payload"))
+	assert.Regexp(t, "PD010523", err)
+	assert.True(t, components.IsSigningPolicyError(err))
+}
+
+func TestSigningPolicyPublicTransactions(t *testing.T) {
+	allowedContract := pldtypes.RandAddress()
+	restricted := hdWalletConfig("restricted", "^restricted\\.")
+	restricted.SigningPolicy = &pldconf.SigningPolicyConfig{
+		AllowedContracts: []string{allowedContract.String()},
+		MaxValue:         confutil.P("1000"),
+	}
+	deployer := hdWalletConfig("deployer", "^deployer\\.")
+	deployer.SigningPolicy = &pldconf.SigningPolicyConfig{
+		AllowedContracts: []string{allowedContract.String()},
+		AllowDeploy:      confutil.P(true),
+	}
+	ctx, km, _, done := newTestDBKeyManagerWithWallets(t, restricted, deployer, hdWalletConfig("open", ""))
+	defer done()
+	assert.True(t, km.publicTxPolicies)
+
+	addrs, err := km.ResolveEthAddressBatchNewDatabaseTX(ctx, []string{"restricted.key1", "deployer.key2", "open.key3"})
+	require.NoError(t, err)
+	restrictedAddr, deployerAddr, openAddr := *addrs[0], *addrs[1], *addrs[2]
+
+	dbTX := km.p.NOTX()
+	err = km.CheckPublicTransactionPolicy(ctx, dbTX, restrictedAddr, allowedContract, pldtypes.Int64ToInt256(1000))
+	require.NoError(t, err)
+
+	err = km.CheckPublicTransactionPolicy(ctx, dbTX, restrictedAddr, allowedContract, pldtypes.Int64ToInt256(1001))
+	assert.Regexp(t, "PD010527.*1001.*1000", err)
+	assert.True(t, components.IsSigningPolicyError(err))
+
+	err = km.CheckPublicTransactionPolicy(ctx, dbTX, restrictedAddr, pldtypes.RandAddress(), nil)
+	assert.Regexp(t, "PD010525", err)
+	assert.True(t, components.IsSigningPolicyError(err))
+
+	err = km.CheckPublicTransactionPolicy(ctx, dbTX, restrictedAddr, nil, nil)
+	assert.Regexp(t, "PD010526", err)
+
+	err = km.CheckPublicTransactionPolicy(ctx, dbTX, deployerAddr, nil, pldtypes.Int64ToInt256(1001))
+	require.NoError(t, err)
+
+	err = km.CheckPublicTransactionPolicy(ctx, dbTX, openAddr, pldtypes.RandAddress(), pldtypes.Int64ToInt256(1001))
+	require.NoError(t, err)
+
+	// Unknown signing addresses cannot be checked (and would not be signable)
+	err = km.CheckPublicTransactionPolicy(ctx, dbTX, *pldtypes.RandAddress(), allowedContract, nil)
+	assert.Regexp(t, "PD010511", err)
+}
+
+func TestSigningPolicyPublicTransactionsSkippedWithoutPolicies(t *testing.T) {
+	ctx, km, _, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{
+		Wallets: []*pldconf.WalletConfig{hdWalletConfig("hdwallet1", "")},
+	}, nil)
+	defer done()
+
+	// No reverse lookup is performed (the mock DB has no expectations)
+	err := km.CheckPublicTransactionPolicy(ctx, km.p.NOTX(), *pldtypes.RandAddress(), nil, nil)
+	require.NoError(t, err)
+}
+
+func TestSigningPolicyConfigErrors(t *testing.T) {
+	ctx, km, _, done := newTestKeyManager(t, false, &pldconf.KeyManagerConfig{}, nil)
+	defer done()
+
+	w := hdWalletConfig("wallet1", "")
+	w.SigningPolicy = &pldconf.SigningPolicyConfig{
+		AllowedContracts: []string{"not an address"},
+	}
+	_, err := km.newWallet(ctx, w)
+	assert.Regexp(t, "PD010521", err)
+
+	w.SigningPolicy = &pldconf.SigningPolicyConfig{
+		MaxValue: confutil.P("lots"),
+	}
+	_, err = km.newWallet(ctx, w)
+	assert.Regexp(t, "PD010521", err)
+}
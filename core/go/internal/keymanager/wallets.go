@@ -35,6 +35,7 @@ type wallet struct {
 	name          string
 	keySelector   keySelector
 	signingModule signer.SigningModule
+	signingPolicy *signingPolicy
 }
 
 type keySelector struct {
@@ -59,6 +60,11 @@ func (km *keyManager) newWallet(ctx context.Context, walletConf *pldconf.WalletC
 		return nil, i18n.WrapError(ctx, err, msgs.MsgKeyManagerInvalidKeySelector, w.name)
 	}
 
+	w.signingPolicy, err = newSigningPolicy(ctx, w.name, walletConf.SigningPolicy)
+	if err != nil {
+		return nil, err
+	}
+
 	signerType := confutil.StringNotEmpty(&walletConf.SignerType, pldconf.WalletDefaults.SignerType)
 	if signerType == pldconf.WalletSignerTypeEmbedded {
 		w.signingModule, err = signer.NewSigningModule(ctx, (*signerapi.ConfigNoExt)(walletConf.Signer))
@@ -113,22 +119,35 @@ func (km *keyManager) getWalletList() []*pldapi.WalletInfo {
 	return walletNames
 }
 
-func (w *wallet) resolveKeyAndVerifier(ctx context.Context, mapping *pldapi.KeyMappingWithPath, algorithm, verifierType string) (*pldapi.KeyMappingAndVerifier, error) {
+func (w *wallet) resolveKeyAndVerifier(ctx context.Context, mapping *pldapi.KeyMappingWithPath, signingPath []*pldapi.KeyPathSegment, algorithm, verifierType string) (*pldapi.KeyMappingAndVerifier, error) {
+	verifiers, err := w.resolveKeyAndVerifiers(ctx, mapping, signingPath, []*prototk.PublicKeyIdentifierType{
+		{Algorithm: algorithm, VerifierType: verifierType},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pldapi.KeyMappingAndVerifier{
+		KeyMappingWithPath: mapping,
+		Verifier:           verifiers[0],
+	}, nil
+}
+
+// The signing path is the same as the path of the mapping, unless the key has been rotated, in which
+// case it is the path allocated for the rotation (see rotationIdentifier)
+func (w *wallet) resolveKeyAndVerifiers(ctx context.Context, mapping *pldapi.KeyMappingWithPath, signingPath []*pldapi.KeyPathSegment, required []*prototk.PublicKeyIdentifierType) ([]*pldapi.KeyVerifier, error) {
 	req := &prototk.ResolveKeyRequest{
-		Attributes: map[string]string{},
-		RequiredIdentifiers: []*prototk.PublicKeyIdentifierType{
-			{Algorithm: algorithm, VerifierType: verifierType},
-		},
-		Path: []*prototk.ResolveKeyPathSegment{},
+		Attributes:          map[string]string{},
+		RequiredIdentifiers: required,
+		Path:                []*prototk.ResolveKeyPathSegment{},
 	}
 
-	for i := 0; i < (len(mapping.Path) - 1); i++ {
+	for i := 0; i < (len(signingPath) - 1); i++ {
 		req.Path = append(req.Path, &prototk.ResolveKeyPathSegment{
-			Name:  mapping.Path[i].Name,
-			Index: uint64(mapping.Path[i].Index),
+			Name:  signingPath[i].Name,
+			Index: uint64(signingPath[i].Index),
 		})
 	}
-	leaf := mapping.Path[len(mapping.Path)-1]
+	leaf := signingPath[len(signingPath)-1]
 	req.Name = leaf.Name
 	req.Index = uint64(leaf.Index)
 	res, err := w.signingModule.Resolve(ctx, req)
@@ -138,31 +157,37 @@ func (w *wallet) resolveKeyAndVerifier(ctx context.Context, mapping *pldapi.KeyM
 
 	// Check the mapping input didn't have a different key handle, if the incoming mapping already had one on there
 	if mapping.KeyHandle != "" && res.KeyHandle != mapping.KeyHandle {
-		return nil, i18n.NewError(ctx, msgs.MsgKeyManagerKeyHandleNonDeterminism, w.name, res.KeyHandle, verifierType, mapping.KeyHandle)
+		return nil, i18n.NewError(ctx, msgs.MsgKeyManagerKeyHandleNonDeterminism, w.name, res.KeyHandle, required[0].VerifierType, mapping.KeyHandle)
 	}
 
-	if len(res.Identifiers) != 1 ||
-		res.Identifiers[0].Algorithm != algorithm ||
-		res.Identifiers[0].VerifierType != verifierType {
-		log.L(ctx).Errorf("Invalid response from wallet '%s' expected[algorithm=%s,verifierType=%s] received: %+v", w.name, algorithm, verifierType, res)
+	valid := len(res.Identifiers) == len(required)
+	verifiers := make([]*pldapi.KeyVerifier, len(required))
+	for i := 0; valid && i < len(required); i++ {
+		valid = res.Identifiers[i].Algorithm == required[i].Algorithm &&
+			res.Identifiers[i].VerifierType == required[i].VerifierType
+		verifiers[i] = &pldapi.KeyVerifier{
+			Algorithm: res.Identifiers[i].Algorithm,
+			Type:      res.Identifiers[i].VerifierType,
+			Verifier:  res.Identifiers[i].Verifier,
+		}
+	}
+	if !valid {
+		log.L(ctx).Errorf("Invalid response from wallet '%s' expected %+v received: %+v", w.name, required, res)
 		return nil, i18n.NewError(ctx, msgs.MsgKeyManagerInvalidResolveResponse, w.name)
 	}
 
 	mapping.KeyHandle = res.KeyHandle
-	return &pldapi.KeyMappingAndVerifier{
-		KeyMappingWithPath: mapping,
-		Verifier: &pldapi.KeyVerifier{
-			Algorithm: res.Identifiers[0].Algorithm,
-			Type:      res.Identifiers[0].VerifierType,
-			Verifier:  res.Identifiers[0].Verifier,
-		},
-	}, nil
+	return verifiers, nil
 
 }
 
 func (w *wallet) sign(ctx context.Context, mapping *pldapi.KeyMappingAndVerifier, payloadType string, payload []byte) ([]byte, error) {
 	log.L(ctx).Infof("Wallet '%s' signing %d bytes with keyIdentifier=%s keyHandle=%s algorithm=%s payloadType=%s", w.name, len(payload), mapping.Identifier, mapping.KeyHandle, mapping.Verifier.Algorithm, payloadType)
 
+	if err := w.checkSigningPolicy(ctx, mapping.Verifier.Algorithm, payloadType); err != nil {
+		return nil, err
+	}
+
 	res, err := w.signingModule.Sign(ctx, &prototk.SignWithKeyRequest{
 		KeyHandle:   mapping.KeyHandle,
 		Algorithm:   mapping.Verifier.Algorithm,
@@ -89,7 +89,7 @@ func TestResolveKeyAndVerifierErr(t *testing.T) {
 			Identifier: "key",
 		},
 		Path: []*pldapi.KeyPathSegment{{Name: "a"}},
-	}, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	}, []*pldapi.KeyPathSegment{{Name: "a"}}, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
 	assert.Regexp(t, "PD010504.*another", err)
 
 }
@@ -115,7 +115,7 @@ func TestResolveBadSignerResponse(t *testing.T) {
 			Identifier: "key",
 		},
 		Path: []*pldapi.KeyPathSegment{{Name: "a"}},
-	}, "test:algo", "requested-type")
+	}, []*pldapi.KeyPathSegment{{Name: "a"}}, "test:algo", "requested-type")
 	assert.Regexp(t, "PD010505", err)
 }
 
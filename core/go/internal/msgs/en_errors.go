@@ -157,6 +157,18 @@ var (
 	MsgKeyManagerSigningModuleNotFound      = pde("PD010515", "Signing module '%s' not found")
 	MsgKeyManagerPluginSignerEmptyName      = pde("PD010516", "Wallet '%s' signing module plugin name cannot be empty")
 	MsgKeyManagerPluginSignerFailInit       = pde("PD010517", "Initialization of plugin signer for wallet '%s' failed")
+	MsgKeyManagerKeyDisabled                = pde("PD010518", "Key '%s' is disabled for signing")
+	MsgKeyManagerKeyRotated                 = pde("PD010519", "Key handle '%s' for identifier '%s' has been retired by rotation")
+	MsgKeyManagerNoVerifiersToRotate        = pde("PD010520", "Identifier '%s' has no verifiers to rotate")
+	MsgKeyManagerInvalidSigningPolicy       = pde("PD010521", "Signing policy for wallet '%s' invalid")
+	MsgKeyManagerPolicyPayloadType          = pde("PD010522", "Signing policy of wallet '%s' does not allow payload type '%s'")
+	MsgKeyManagerPolicyAlgorithm            = pde("PD010523", "Signing policy of wallet '%s' does not allow algorithm '%s'")
+	MsgKeyManagerPolicyRateLimit            = pde("PD010524", "Signing policy of wallet '%s' limits signing to %d signatures every %s")
+	MsgKeyManagerPolicyContract             = pde("PD010525", "Signing policy of wallet '%s' does not allow transactions to contract '%s'")
+	MsgKeyManagerPolicyDeploy               = pde("PD010526", "Signing policy of wallet '%s' does not allow contract deployment")
+	MsgKeyManagerPolicyMaxValue             = pde("PD010527", "Signing policy of wallet '%s' does not allow value %s exceeding the maximum of %s")
+	MsgKeyManagerRotatePendingPublicTxns    = pde("PD010528", "Key '%s' cannot be rotated while %d public transactions from %s are pending")
+	MsgKeyManagerSigningAuditFailed         = pde("PD010529", "Failed to record signing with key '%s' in the signing audit log")
 
 	// Comms bus PD0106XX
 	MsgDestinationNotFound     = pde("PD010600", "Destination not found: %s")
@@ -209,6 +221,12 @@ var (
 	MsgBlockIndexerConfirmedBlockNotFound   = pde("PD011310", "Block %s (%d) not found on retrieval after detection and requested number of confirmations")
 	MsgBlockIndexerLimitRequired            = pde("PD011311", "limit is required on all queries")
 	MsgBlockIndexerEventStreamNotFound      = pde("PD011312", "Event stream not found: %s")
+	MsgBlockIndexerHeadBlockNotFound        = pde("PD011313", "Receipts for block %s (%d) at the head of the chain not available")
+	MsgBlockIndexerESTopicFilterInvalid     = pde("PD011314", "Topic filter value %s at position %d of event stream source %d is longer than 32 bytes")
+	MsgBlockIndexerESTopicFilterTooLong     = pde("PD011315", "Event stream source %d has %d topic filter positions (max=3)")
+	MsgBlockIndexerESFactoryWithAddress     = pde("PD011316", "Event stream source %d cannot have both an address and a factory")
+	MsgBlockIndexerESFactoryEventInvalid    = pde("PD011317", "Factory event of event stream source %d must be an event with an address parameter '%s'")
+	MsgBlockIndexerESDecodeFailure          = pde("PD011318", "Event %d/%d/%d (tx=%s,address=%s) matches the signature of an event in the ABI but could not be decoded")
 
 	// EthClient module PD0115XX
 	MsgEthClientInvalidInput            = pde("PD011500", "Unable to convert to ABI function input (func=%s)")
@@ -340,32 +358,41 @@ var (
 	MsgPrivateTxMgrAssembleTxnNotFound           = pde("PD011838", "Transaction %s not found in local node")
 
 	// Public Transaction Manager PD0119XX
-	MsgSubmitFailedWrongHashReturned   = pde("PD011905", "Submission of transaction with calculatedHash '%s' returned hash '%s'")
-	MsgSubmissionResponseMissingTxHash = pde("PD011906", "Missing transaction hash from the submission response for transaction with ID: %s")
-	MsgPublicTxMgrAlreadyInit          = pde("PD011907", "Public transaction manager already initialized")
-	MsgInvalidGasClientConfig          = pde("PD011908", "Invalid gas client config: %s")
-	MsgInvalidGasPriceIncreaseMax      = pde("PD011909", "Invalid max gas price increase price string %s")
-	MsgMissingTransactionID            = pde("PD011910", "Transaction ID must be provided")
-	MsgPublicTransactionNotFound       = pde("PD011911", "Public transaction not found with id %s")
-	MsgGasPriceError                   = pde("PD011917", `The gasPrice '%s' could not be parsed. Must be a numeric string, or an object with 'gasPrice' field, or 'maxFeePerGas'/'maxPriorityFeePerGas' fields (EIP-1559), error: %s`)
-	MsgPersistError                    = pde("PD011918", "Unexpected internal error, cannot persist stage.")
-	MsgInvalidStageOutput              = pde("PD011919", "Stage output object is missing %s: %+v")
-	MsgInvalidGasLimit                 = pde("PD011920", "Invalid gas limit, must be a positive number")
-	MsgStatusUpdateForbidden           = pde("PD011921", "Cannot update status of a completed transaction")
-	MsgTransactionNotFound             = pde("PD011924", "Transaction '%s' not found")
-	MsgTransactionEngineRequestTimeout = pde("PD011926", "The transaction handler did not acknowledge the request after %.2fs")
-	MsgErrorMissingSignerID            = pde("PD011928", "Signer Identifier must be provided")
-	MsgInvalidTransactionType          = pde("PD011929", "Transaction type invalid")
-	MsgMissingConfirmedTransaction     = pde("PD011930", "Transaction %s with nonce smaller than the recorded confirmed nonce does not have an indexed transaction.")
-	MsgPublicTxHistoryInfo             = pde("PD011931", "PubTx[INFO] from=%s nonce=%s subStatus=%s action=%s info=%s")
-	MsgPublicTxHistoryError            = pde("PD011932", "PubTx[ERROR] from=%s nonce=%s subStatus=%s action=%s error=%s")
-	MsgPublicBatchCompleted            = pde("PD011933", "Batch already completed")
-	MsgInvalidStateMissingTXHash       = pde("PD011935", "Invalid state - missing transaction hash from previous sign stage")
-	MsgInvalidTXMissingFromAddr        = pde("PD011936", "From address missing for transaction")
-	MsgTransactionAlreadyComplete      = pde("PD011937", "Transaction cannot be updated as it is already complete")
-	MsgUpdateGasPriceLower             = pde("PD011938", "Gas price cannot be lowered for transaction (current=%s requested=%s)")
-	MsgUpdateMaxFeePerGasLower         = pde("PD011939", "Max fee per gas cannot be lowered for transaction (current=%s requested=%s)")
-	MsgUpdateNoFixedPricing            = pde("PD011940", "Cannot unset gas price for transaction with fixed gas pricing")
+	MsgSubmitFailedWrongHashReturned    = pde("PD011905", "Submission of transaction with calculatedHash '%s' returned hash '%s'")
+	MsgSubmissionResponseMissingTxHash  = pde("PD011906", "Missing transaction hash from the submission response for transaction with ID: %s")
+	MsgPublicTxMgrAlreadyInit           = pde("PD011907", "Public transaction manager already initialized")
+	MsgInvalidGasClientConfig           = pde("PD011908", "Invalid gas client config: %s")
+	MsgInvalidGasPriceIncreaseMax       = pde("PD011909", "Invalid max gas price increase price string %s")
+	MsgMissingTransactionID             = pde("PD011910", "Transaction ID must be provided")
+	MsgPublicTransactionNotFound        = pde("PD011911", "Public transaction not found with id %s")
+	MsgGasPriceError                    = pde("PD011917", `The gasPrice '%s' could not be parsed. Must be a numeric string, or an object with 'gasPrice' field, or 'maxFeePerGas'/'maxPriorityFeePerGas' fields (EIP-1559), error: %s`)
+	MsgPersistError                     = pde("PD011918", "Unexpected internal error, cannot persist stage.")
+	MsgInvalidStageOutput               = pde("PD011919", "Stage output object is missing %s: %+v")
+	MsgInvalidGasLimit                  = pde("PD011920", "Invalid gas limit, must be a positive number")
+	MsgStatusUpdateForbidden            = pde("PD011921", "Cannot update status of a completed transaction")
+	MsgTransactionNotFound              = pde("PD011924", "Transaction '%s' not found")
+	MsgTransactionEngineRequestTimeout  = pde("PD011926", "The transaction handler did not acknowledge the request after %.2fs")
+	MsgErrorMissingSignerID             = pde("PD011928", "Signer Identifier must be provided")
+	MsgInvalidTransactionType           = pde("PD011929", "Transaction type invalid")
+	MsgMissingConfirmedTransaction      = pde("PD011930", "Transaction %s with nonce smaller than the recorded confirmed nonce does not have an indexed transaction.")
+	MsgPublicTxHistoryInfo              = pde("PD011931", "PubTx[INFO] from=%s nonce=%s subStatus=%s action=%s info=%s")
+	MsgPublicTxHistoryError             = pde("PD011932", "PubTx[ERROR] from=%s nonce=%s subStatus=%s action=%s error=%s")
+	MsgPublicBatchCompleted             = pde("PD011933", "Batch already completed")
+	MsgInvalidStateMissingTXHash        = pde("PD011935", "Invalid state - missing transaction hash from previous sign stage")
+	MsgInvalidTXMissingFromAddr         = pde("PD011936", "From address missing for transaction")
+	MsgTransactionAlreadyComplete       = pde("PD011937", "Transaction cannot be updated as it is already complete")
+	MsgUpdateGasPriceLower              = pde("PD011938", "Gas price cannot be lowered for transaction (current=%s requested=%s)")
+	MsgUpdateMaxFeePerGasLower          = pde("PD011939", "Max fee per gas cannot be lowered for transaction (current=%s requested=%s)")
+	MsgUpdateNoFixedPricing             = pde("PD011940", "Cannot unset gas price for transaction with fixed gas pricing")
+	MsgFeeHistoryMissingBaseFee         = pde("PD011941", "eth_feeHistory response did not include a base fee for the next block")
+	MsgPublicTxNotFoundForNonce         = pde("PD011942", "Public transaction not found from=%s nonce=%d")
+	MsgPublicTxAlreadyComplete          = pde("PD011943", "Public transaction from=%s nonce=%d is already complete")
+	MsgPublicTxHistorySuspended         = pde("PD011944", "PubTx[INFO] from=%s nonce=%d suspended")
+	MsgPublicTxHistoryResumed           = pde("PD011945", "PubTx[INFO] from=%s nonce=%d resumed")
+	MsgPublicTxHistoryCancelRequested   = pde("PD011946", "PubTx[INFO] from=%s nonce=%d cancel requested, replacing with zero value transfer to self gasPricing=%s")
+	MsgPublicTxHistoryCancelled         = pde("PD011947", "PubTx[INFO] from=%s nonce=%d cancelled by transaction %s in block %d")
+	MsgPublicTxCancelled                = pde("PD011948", "Public transaction from=%s nonce=%d was cancelled and replaced by transaction %s")
+	MsgFuelingSourceInsufficientBalance = pde("PD011949", "Fueling source %s balance %s is insufficient to transfer %s to %s")
 
 	// TransportManager module PD0120XX
 	MsgTransportInvalidMessage                 = pde("PD012000", "Invalid message")
@@ -390,6 +417,20 @@ var (
 	MsgTransportStateSchemaNotAvailableLocally = pde("PD012020", "State schema not available locally: domain=%s,id=%s")
 	MsgTransportMessageNotAvailableLocally     = pde("PD012021", "Message not available locally: id=%s")
 	MsgTransportPrivacyGroupStateStorageFailed = pde("PD012022", "Storage of privacy group state failed: id=%s")
+	MsgTransportEncryptionKeyRequired          = pde("PD012023", "At least one key file must be configured when payload encryption is enabled")
+	MsgTransportEncryptionKeyInvalid           = pde("PD012024", "Payload encryption key file '%s' does not contain a valid P-256 private key")
+	MsgTransportPeerKeysInvalid                = pde("PD012025", "Invalid payload encryption keys published by node '%s'")
+	MsgTransportPeerKeysRequired               = pde("PD012026", "Node '%s' does not publish payload encryption keys, and encryption is required")
+	MsgTransportPeerKeyNotPublished            = pde("PD012027", "Key '%s' is not published by node '%s'")
+	MsgTransportLocalKeyNotFound               = pde("PD012028", "Message from node '%s' is encrypted to key '%s' which is not a key of this node")
+	MsgTransportSealRequired                   = pde("PD012029", "Message from node '%s' is not encrypted, and encryption is required")
+	MsgTransportSealNotEnabled                 = pde("PD012030", "Message from node '%s' is encrypted, and payload encryption is not enabled on this node")
+	MsgTransportSealInvalid                    = pde("PD012031", "Message from node '%s' failed signature verification or decryption")
+	MsgTransportReliableMsgNotFound            = pde("PD012032", "Reliable message '%s' not found")
+	MsgTransportReliableMsgAlreadyAcked        = pde("PD012033", "Reliable message '%s' has already been finalized by an ack or nack at %s")
+	MsgTransportAbandonReasonRequired          = pde("PD012034", "A reason must be supplied to abandon reliable message '%s'")
+	MsgTransportReliableMsgAbandoned           = pde("PD012035", "Abandoned by an administrator: %s")
+	MsgTransportSealMissing                    = pde("PD012036", "Message from node '%s' is not encrypted, but the node publishes payload encryption keys")
 
 	// RegistryManager module PD0121XX
 	MsgRegistryNodeEntiresNotFound     = pde("PD012100", "No entries found for node '%s'")
@@ -402,6 +443,12 @@ var (
 	MsgRegistryQueryLimitRequired      = pde("PD012107", "Limit is required on all queries")
 	MsgRegistryTransportPropertyRegexp = pde("PD012108", "transports.propertyRegexp for registry '%s' is invalid")
 	MsgRegistryDollarPrefixReserved    = pde("PD012109", "Name '%s' is invalid. Dollar ('$') prefix is allowed only for reserved properties, and then is required (pluginReserved=%t)")
+	MsgRegistryWritesNotSupported      = pde("PD012110", "Registry '%s' does not support publishing entries")
+	MsgRegistryPublishFromMissing      = pde("PD012111", "publish.from must be configured to publish to registry '%s'")
+	MsgRegistryPublishParentMissing    = pde("PD012112", "Parent entry '%s' for node '%s' is not registered in registry '%s'")
+	MsgRegistryNotInitialized          = pde("PD012113", "Registry '%s' has not completed initialization")
+	MsgRegistryInvalidPreparedTx       = pde("PD012114", "Registry '%s' returned an invalid transaction at index %d")
+	MsgRegistryNodeRevoked             = pde("PD012115", "Node '%s' has been revoked in registry '%s' (entry '%s')")
 
 	// TxMgr module PD0122XX
 	MsgTxMgrInvalidABI                            = pde("PD012201", "ABI is invalid")
@@ -454,6 +501,19 @@ var (
 	MsgTxMgrBlockchainEventListenerNoSources      = pde("PD012251", "Blockchain event listener '%s' has no sources configured")
 	MsgTxMgrBlockchainEventListenerNoABIs         = pde("PD012252", "Blockchain event listener '%s' has a source with no ABI configured")
 	MsgTxMgrVerifierNotEthAddress                 = pde("PD012253", "Verifier '%s' is not an Ethereum address")
+	MsgTxMgrExpiryNotAfterNotBefore               = pde("PD012254", "Transaction expiresAt %s must be after notBefore %s")
+	MsgTxMgrTransactionExpired                    = pde("PD012255", "Transaction expired at %s before it was mined")
+	MsgTxMgrScheduleNotSupportedPrivateDeploy     = pde("PD012256", "notBefore and expiresAt are not supported for private contract deployments")
+	MsgTxMgrExportInvalidFormat                   = pde("PD012257", "Invalid export format '%s'")
+	MsgTxMgrExportInvalidParam                    = pde("PD012258", "Invalid value for export parameter '%s': %s")
+	MsgTxMgrExportInvalidInclude                  = pde("PD012259", "Invalid export include option '%s'")
+	MsgTxMgrBadReceiptListenerQuery               = pde("PD012260", "Transaction receipt listener '%s' query filter is invalid")
+	MsgTxMgrWebhookInvalid                        = pde("PD012261", "Invalid webhook configuration for listener '%s'")
+	MsgTxMgrWebhookInvalidTimeout                 = pde("PD012262", "Invalid webhook request timeout '%s': %s")
+	MsgTxMgrWebhookDeliveryFailed                 = pde("PD012263", "Webhook delivery of batch %s failed")
+	MsgTxMgrWebhookDeliveryRejected               = pde("PD012264", "Webhook delivery of batch %s rejected with status %d: %s")
+	MsgTxMgrDeadLetterNotFound                    = pde("PD012265", "Dead letter %s not found for listener '%s'")
+	MsgTxMgrInvalidMaxRedeliveries                = pde("PD012266", "Invalid maxRedeliveries %d for listener '%s' - must not be negative")
 
 	// FlushWriter module PD0123XX
 	MsgFlushWriterQuiescing      = pde("PD012300", "Writer shutting down")
@@ -488,4 +548,18 @@ var (
 	MsgPGroupsJSONRPCSubscriptionNack       = pde("PD012521", "JSON/RPC subscription '%s' returned nack for message batch")
 	MsgPGroupsGenesisSaltUnset              = pde("PD012522", "Genesis salt must be set")
 	MsgPGroupsReceivedGenesisInvalid        = pde("PD012523", "Received genesis state is invalid")
+	MsgPGroupsDeadLetterNotFound            = pde("PD012524", "Dead letter %s not found for message listener '%s'")
+	MsgPGroupsInvalidMaxRedeliveries        = pde("PD012525", "Invalid maxRedeliveries %d for message listener '%s' - must not be negative")
+	MsgPGroupsNoMembersToChange             = pde("PD012526", "At least one member must be supplied to add to, or remove from, the privacy group")
+	MsgPGroupsAlreadyMember                 = pde("PD012527", "'%s' is already a member of privacy group '%s'")
+	MsgPGroupsNotMember                     = pde("PD012528", "'%s' is not a member of privacy group '%s'")
+	MsgPGroupsMembersConcurrentUpdate       = pde("PD012529", "The member list of privacy group '%s' was updated concurrently (revision %d)")
+	MsgPGroupsMembersGenesisMismatch        = pde("PD012530", "Received member list for privacy group '%s' does not match the genesis of the group")
+	MsgPGroupsTopicNotPermitted             = pde("PD012531", "Node '%s' is not permitted to send or receive messages on topic '%s' in privacy group '%s'")
+	MsgPGroupsTopicACLNodeNotInGroup        = pde("PD012532", "Topic ACL node '%s' for topic '%s' does not host a member of privacy group '%s'")
+	MsgPGroupsTopicACLInvalid               = pde("PD012533", "Topic ACL entries must have a unique topic, and at least one node: %s")
+	MsgPGroupsRetentionMaxAgeInvalid        = pde("PD012534", "Invalid message retention maxAge '%s'")
+	MsgPGroupsRetentionMaxCountInvalid      = pde("PD012535", "Invalid message retention maxCount %d - must be greater than zero")
+	MsgPGroupsMembersSenderNotMember        = pde("PD012536", "Node '%s' does not host a member of privacy group '%s', so cannot change its member list")
+	MsgPGroupsMembersChangeNotSupported     = pde("PD012537", "Membership change not supported by domain '%s'")
 )
@@ -414,3 +414,18 @@ func (br *domainBridge) WrapPrivacyGroupEVMTX(ctx context.Context, req *prototk.
 	)
 	return
 }
+
+func (br *domainBridge) UpdatePrivacyGroupMembers(ctx context.Context, req *prototk.UpdatePrivacyGroupMembersRequest) (res *prototk.UpdatePrivacyGroupMembersResponse, err error) {
+	err = br.toPlugin.RequestReply(ctx,
+		func(dm plugintk.PluginMessage[prototk.DomainMessage]) {
+			dm.Message().RequestToDomain = &prototk.DomainMessage_UpdatePrivacyGroupMembers{UpdatePrivacyGroupMembers: req}
+		},
+		func(dm plugintk.PluginMessage[prototk.DomainMessage]) bool {
+			if r, ok := dm.Message().ResponseFromDomain.(*prototk.DomainMessage_UpdatePrivacyGroupMembersRes); ok {
+				res = r.UpdatePrivacyGroupMembersRes
+			}
+			return res != nil
+		},
+	)
+	return
+}
@@ -266,6 +266,14 @@ func TestDomainRequestsOK(t *testing.T) {
 				},
 			}, nil
 		},
+		UpdatePrivacyGroupMembers: func(ctx context.Context, upgmr *prototk.UpdatePrivacyGroupMembersRequest) (*prototk.UpdatePrivacyGroupMembersResponse, error) {
+			assert.Equal(t, []string{"me@node1"}, upgmr.PreviousMembers)
+			return &prototk.UpdatePrivacyGroupMembersResponse{
+				Transaction: &prototk.PreparedTransaction{
+					ParamsJson: `{"new":"members"}`,
+				},
+			}, nil
+		},
 	}
 
 	tdm := &testDomainManager{
@@ -471,6 +479,12 @@ func TestDomainRequestsOK(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, `{"wrapped":"params"}`, wpgtr.Transaction.ParamsJson)
 
+	upgmr, err := domainAPI.UpdatePrivacyGroupMembers(ctx, &prototk.UpdatePrivacyGroupMembersRequest{
+		PreviousMembers: []string{"me@node1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"new":"members"}`, upgmr.Transaction.ParamsJson)
+
 	callbacks := <-waitForCallbacks
 
 	fas, err := callbacks.FindAvailableStates(ctx, &prototk.FindAvailableStatesRequest{
@@ -61,6 +61,15 @@ func (e *PluginError) Error() string {
 	return e.Cause.Error()
 }
 
+func (e *PluginError) Unwrap() error {
+	return e.Cause
+}
+
+func IsNotSupported(err error) bool {
+	var pluginError *PluginError
+	return errors.As(err, &pluginError) && pluginError.ErrorType == prototk.Header_NOT_SUPPORTED
+}
+
 func NewPluginError(errorType prototk.Header_ErrorType, cause error) *PluginError {
 	return &PluginError{
 		ErrorType: errorType,
@@ -332,7 +341,12 @@ func (ph *pluginHandler[M]) RequestReply(ctx context.Context, reqFn func(plugint
 			errMessage = plugintk.PluginMessageToJSON(res)
 		}
 		l.Errorf("[%s] <== ERROR [%s]: %s", reqID, inflight.Age(), errMessage)
-		return i18n.NewError(ctx, msgs.MsgPluginError, pi.pluginType, pi.name, errMessage)
+		err := i18n.NewError(ctx, msgs.MsgPluginError, pi.pluginType, pi.name, errMessage)
+		if res.Header().ErrorType != prototk.Header_UNKNOWN {
+			// Keep the classification of the error from the plugin, so the caller can act on it
+			return NewPluginError(res.Header().ErrorType, err)
+		}
+		return err
 	}
 
 	responseOk := resFn(res)
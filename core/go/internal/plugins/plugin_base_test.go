@@ -293,6 +293,7 @@ func TestDomainRequestsErrorWithMessage(t *testing.T) {
 								CorrelationId: &req.Header.MessageId,
 								MessageType:   prototk.Header_ERROR_RESPONSE,
 								ErrorMessage:  confutil.P("some error"),
+								ErrorType:     prototk.Header_NOT_SUPPORTED,
 							},
 						},
 					}
@@ -314,6 +315,7 @@ func TestDomainRequestsErrorWithMessage(t *testing.T) {
 
 	_, err := domainAPI.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{})
 	assert.Regexp(t, "PD011206.*some error", err)
+	assert.True(t, IsNotSupported(err))
 
 }
 
@@ -355,6 +357,7 @@ func TestDomainRequestsErrorNoMessage(t *testing.T) {
 
 	_, err := domainAPI.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{})
 	assert.Regexp(t, "PD011206.*ERROR_RESPONSE", err)
+	assert.False(t, IsNotSupported(err))
 
 }
 
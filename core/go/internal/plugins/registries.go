@@ -105,3 +105,18 @@ func (br *RegistryBridge) HandleRegistryEvents(ctx context.Context, req *prototk
 	)
 	return
 }
+
+func (br *RegistryBridge) PrepareRegistryTransactions(ctx context.Context, req *prototk.PrepareRegistryTransactionsRequest) (res *prototk.PrepareRegistryTransactionsResponse, err error) {
+	err = br.toPlugin.RequestReply(ctx,
+		func(dm plugintk.PluginMessage[prototk.RegistryMessage]) {
+			dm.Message().RequestToRegistry = &prototk.RegistryMessage_PrepareRegistryTransactions{PrepareRegistryTransactions: req}
+		},
+		func(dm plugintk.PluginMessage[prototk.RegistryMessage]) bool {
+			if r, ok := dm.Message().ResponseFromRegistry.(*prototk.RegistryMessage_PrepareRegistryTransactionsRes); ok {
+				res = r.PrepareRegistryTransactionsRes
+			}
+			return res != nil
+		},
+	)
+	return
+}
@@ -119,6 +119,12 @@ func TestRegistryRequestsOK(t *testing.T) {
 				Entries: []*prototk.RegistryEntry{{Name: "node1"}},
 			}, nil
 		},
+		PrepareRegistryTransactions: func(ctx context.Context, prtr *prototk.PrepareRegistryTransactionsRequest) (*prototk.PrepareRegistryTransactionsResponse, error) {
+			assert.Equal(t, "node1", prtr.Name)
+			return &prototk.PrepareRegistryTransactionsResponse{
+				Transactions: []*prototk.PreparedRegistryTransaction{{RegisterEntry: true}},
+			}, nil
+		},
 	}
 
 	trm := &testRegistryManager{
@@ -155,6 +161,12 @@ func TestRegistryRequestsOK(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "node1", rebr.Entries[0].Name)
 
+	prtr, err := registryAPI.PrepareRegistryTransactions(ctx, &prototk.PrepareRegistryTransactionsRequest{
+		Name: "node1",
+	})
+	require.NoError(t, err)
+	assert.True(t, prtr.Transactions[0].RegisterEntry)
+
 	// This is the point the registry manager would call us to say the registry is initialized
 	// (once it's happy it's updated its internal state)
 	registryAPI.Initialized()
@@ -167,10 +167,18 @@ func (s *Sequencer) assembleAndSign(ctx context.Context, transactionID uuid.UUID
 						return nil, i18n.WrapError(ctx, err, msgs.MsgPrivateTxManagerResolveError, unqualifiedLookup, attRequest.Algorithm)
 					}
 
-					signaturePayload, err := keyMgr.Sign(ctx, resolvedKey, attRequest.PayloadType, attRequest.Payload)
+					signaturePayload, err := keyMgr.Sign(ctx, s.components.Persistence().NOTX(), resolvedKey, attRequest.PayloadType, attRequest.Payload)
 					if err != nil {
 						log.L(ctx).Errorf("failed to sign for party %s (verifier=%s,algorithm=%s): %s", unqualifiedLookup, resolvedKey.Verifier.Verifier, attRequest.Algorithm, err)
-						return nil, i18n.WrapError(ctx, err, msgs.MsgPrivateTxManagerSignError, unqualifiedLookup, resolvedKey.Verifier.Verifier, attRequest.Algorithm)
+						signErr := i18n.WrapError(ctx, err, msgs.MsgPrivateTxManagerSignError, unqualifiedLookup, resolvedKey.Verifier.Verifier, attRequest.Algorithm)
+						if components.IsSigningPolicyError(err) {
+							// Re-assembly will not help, so the assembly is reverted with the policy violation as the reason
+							revertReason := signErr.Error()
+							transaction.PostAssembly.AssemblyResult = prototk.AssembleTransactionResponse_REVERT
+							transaction.PostAssembly.RevertReason = &revertReason
+							return transaction.PostAssembly, nil
+						}
+						return nil, signErr
 					}
 					log.L(ctx).Debugf("payload: %x signed %x by %s (%s)", attRequest.Payload, signaturePayload, unqualifiedLookup, resolvedKey.Verifier.Verifier)
 
@@ -102,7 +102,7 @@ func (e *endorsementGatherer) GatherEndorsement(ctx context.Context, transaction
 		return nil, confutil.P(revertReason), nil
 	case prototk.EndorseTransactionResponse_SIGN:
 		// Build the signature
-		signaturePayload, err := e.keyMgr.Sign(ctx, resolvedSigner, endorsementRequest.PayloadType, endorseRes.Payload)
+		signaturePayload, err := e.keyMgr.Sign(ctx, e.p.NOTX(), resolvedSigner, endorsementRequest.PayloadType, endorseRes.Payload)
 		if err != nil {
 			errorMessage := fmt.Sprintf("failed to endorse for party %s (verifier=%s,algorithm=%s): %s", partyName, resolvedSigner.Verifier.Verifier, endorsementRequest.Algorithm, err)
 			log.L(ctx).Error(errorMessage)
@@ -26,7 +26,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 )
 
-func (p *privateTxManager) BuildNullifier(ctx context.Context, kr components.KeyResolver, s *components.StateDistributionWithData) (*components.NullifierUpsert, error) {
+func (p *privateTxManager) BuildNullifier(ctx context.Context, dbTX persistence.DBTX, s *components.StateDistributionWithData) (*components.NullifierUpsert, error) {
 	// We need to call the signing engine with the local identity to build the nullifier
 	log.L(ctx).Infof("Generating nullifier for state %s on node %s (algorithm=%s,verifierType=%s,payloadType=%s)",
 		s.StateID, p.nodeName, *s.NullifierAlgorithm, *s.NullifierVerifierType, *s.NullifierPayloadType)
@@ -39,9 +39,9 @@ func (p *privateTxManager) BuildNullifier(ctx context.Context, kr components.Key
 
 	// Call the signing engine to build the nullifier
 	var nulliferBytes []byte
-	mapping, err := kr.ResolveKey(ctx, identifier, *s.NullifierAlgorithm, *s.NullifierVerifierType)
+	mapping, err := p.components.KeyManager().KeyResolverForDBTX(dbTX).ResolveKey(ctx, identifier, *s.NullifierAlgorithm, *s.NullifierVerifierType)
 	if err == nil {
-		nulliferBytes, err = p.components.KeyManager().Sign(ctx, mapping, *s.NullifierPayloadType, s.StateData.Bytes())
+		nulliferBytes, err = p.components.KeyManager().Sign(ctx, dbTX, mapping, *s.NullifierPayloadType, s.StateData.Bytes())
 	}
 	if err != nil || len(nulliferBytes) == 0 {
 		return nil, i18n.WrapError(ctx, err, msgs.MsgStateDistributorNullifierFail, s.StateID)
@@ -62,7 +62,7 @@ func (p *privateTxManager) BuildNullifiers(ctx context.Context, stateDistributio
 				continue
 			}
 
-			nullifier, err := p.BuildNullifier(ctx, p.components.KeyManager().KeyResolverForDBTX(dbTX), s)
+			nullifier, err := p.BuildNullifier(ctx, dbTX, s)
 			if err != nil {
 				return err
 			}
@@ -223,10 +223,12 @@ func (p *privateTxManager) HandleNewTx(ctx context.Context, dbTX persistence.DBT
 		return i18n.NewError(ctx, msgs.MsgPrivateTxMgrFunctionNotProvided)
 	}
 	return p.handleNewTx(ctx, dbTX, &components.PrivateTransaction{
-		ID:      *tx.ID,
-		Domain:  tx.Domain,
-		Address: *tx.To,
-		Intent:  intent,
+		ID:        *tx.ID,
+		Domain:    tx.Domain,
+		Address:   *tx.To,
+		Intent:    intent,
+		NotBefore: txi.NotBefore,
+		ExpiresAt: txi.ExpiresAt,
 	}, &txi.ResolvedTransaction)
 }
 
@@ -960,19 +962,59 @@ func (p *privateTxManager) NotifyFailedPublicTx(ctx context.Context, dbTX persis
 	// For now, we directly raise a failure receipt for them back with the main transaction manager
 	privateFailureReceipts := make([]*components.ReceiptInput, len(failures))
 	for i, tx := range failures {
-		privateFailureReceipts[i] = &components.ReceiptInput{
+		receipt := &components.ReceiptInput{
 			ReceiptType:   components.RT_FailedOnChainWithRevertData,
 			TransactionID: tx.TransactionID,
 			OnChain: pldtypes.OnChainLocation{
 				Type:             pldtypes.OnChainTransaction,
 				TransactionHash:  tx.Hash,
 				BlockNumber:      tx.BlockNumber,
-				TransactionIndex: tx.BlockNumber,
+				TransactionIndex: tx.TransactionIndex,
 			},
 			RevertData: tx.RevertReason,
 		}
+		if tx.Cancelled {
+			// the transfer to self that replaced the transaction succeeded, but the transaction itself did not
+			receipt.ReceiptType = components.RT_FailedWithMessage
+			receipt.FailureMessage = i18n.NewError(ctx, msgs.MsgPublicTxCancelled, tx.From, tx.Nonce, tx.Hash).Error()
+			receipt.RevertData = nil
+		}
+		privateFailureReceipts[i] = receipt
+	}
+	return p.finalizeDispatchedFailures(ctx, dbTX, privateFailureReceipts)
+}
+
+func (p *privateTxManager) NotifyExpiredTransactions(ctx context.Context, dbTX persistence.DBTX, receipts []*components.ReceiptInput) error {
+	return p.finalizeDispatchedFailures(ctx, dbTX, receipts)
+}
+
+func (p *privateTxManager) finalizeDispatchedFailures(ctx context.Context, dbTX persistence.DBTX, receipts []*components.ReceiptInput) error {
+	dbTX.AddPostCommit(func(ctx context.Context) {
+		for _, receipt := range receipts {
+			p.releaseFailedTransaction(ctx, receipt.TransactionID)
+		}
+	})
+	return p.components.TxManager().FinalizeTransactions(ctx, dbTX, receipts)
+}
+
+// Once the failure receipt is committed the transaction will not be retried, so we remove it from the
+// sequencer that dispatched it, releasing the states it holds in the coordinator domain context.
+func (p *privateTxManager) releaseFailedTransaction(ctx context.Context, txID uuid.UUID) {
+	var seq *Sequencer
+	p.sequencersLock.RLock()
+	for _, s := range p.sequencers {
+		if s.getTransactionProcessor(txID.String()) != nil {
+			seq = s
+			break
+		}
+	}
+	p.sequencersLock.RUnlock()
+	if seq == nil {
+		log.L(ctx).Infof("Failed transaction %s is not in flight in any sequencer", txID)
+		return
 	}
-	return p.components.TxManager().FinalizeTransactions(ctx, dbTX, privateFailureReceipts)
+	seq.coordinatorDomainContext.ResetTransactions(txID)
+	seq.publisher.PublishTransactionRevertedEvent(ctx, txID.String())
 }
 
 // We get called post-commit by the indexer in the domain when transaction confirmations have been recorded,
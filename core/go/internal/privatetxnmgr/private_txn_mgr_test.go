@@ -316,7 +316,7 @@ func TestPrivateTxManagerSimpleTransaction(t *testing.T) {
 		},
 	}, nil)
 
-	mocks.keyManager.On("Sign", mock.Anything, notaryKeyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
+	mocks.keyManager.On("Sign", mock.Anything, mock.Anything, notaryKeyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
 		Return([]byte("notary-signature-bytes"), nil)
 
 	mocks.domainSmartContract.On("PrepareTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(
@@ -381,10 +381,82 @@ func TestPrivateTxManagerSimpleTransaction(t *testing.T) {
 
 	<-dcFlushed
 
+	// The base ledger transaction is cancelled, so the private transaction fails and releases its states
+	statesReleased := make(chan struct{})
+	mocks.domainContext.On("ResetTransactions", []uuid.UUID{*testTransactionID}).Return().Run(func(args mock.Arguments) {
+		close(statesReleased)
+	})
+	mocks.txManager.On("FinalizeTransactions", mock.Anything, mock.Anything, mock.MatchedBy(func(receipts []*components.ReceiptInput) bool {
+		return len(receipts) == 1 &&
+			receipts[0].TransactionID == *testTransactionID &&
+			receipts[0].ReceiptType == components.RT_FailedWithMessage
+	})).Return(nil)
+	mocks.txManager.On("GetTransactionByIDFull", mock.Anything, *testTransactionID).Return(&pldapi.TransactionFull{
+		Receipt: &pldapi.TransactionReceiptData{FailureMessage: "cancelled"},
+	}, nil)
+	err = privateTxManager.P().Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return privateTxManager.NotifyFailedPublicTx(ctx, dbTX, []*components.PublicTxMatch{
+			{
+				PaladinTXReference: components.PaladinTXReference{
+					TransactionID:   *testTransactionID,
+					TransactionType: pldapi.TransactionTypePrivate.Enum(),
+				},
+				IndexedTransactionNotify: &blockindexer.IndexedTransactionNotify{
+					IndexedTransaction: pldapi.IndexedTransaction{
+						Hash: pldtypes.RandBytes32(),
+						From: signingAddr,
+						To:   signingAddr,
+					},
+				},
+				Cancelled: true,
+			},
+		})
+	})
+	require.NoError(t, err)
+	<-statesReleased
+	status = pollForStatus(ctx, t, "failed", privateTxManager, domainAddressString, testTransactionID.String(), testTimeout)
+	assert.Equal(t, "failed", status)
+
 	privateTxManager.Stop()
 
 }
 
+func TestNotifyFailedPublicTxNotInFlight(t *testing.T) {
+	ctx := context.Background()
+	privateTxManager, mocks := NewPrivateTransactionMgrForPackageTesting(t, "node1")
+
+	txID := uuid.New()
+	revertData := pldtypes.HexBytes(pldtypes.RandBytes(8))
+	mocks.txManager.On("FinalizeTransactions", mock.Anything, mock.Anything, mock.MatchedBy(func(receipts []*components.ReceiptInput) bool {
+		return len(receipts) == 1 &&
+			receipts[0].TransactionID == txID &&
+			receipts[0].ReceiptType == components.RT_FailedOnChainWithRevertData &&
+			receipts[0].RevertData.Equals(revertData) &&
+			receipts[0].OnChain.TransactionIndex == 10
+	})).Return(nil)
+
+	err := privateTxManager.P().Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return privateTxManager.NotifyFailedPublicTx(ctx, dbTX, []*components.PublicTxMatch{
+			{
+				PaladinTXReference: components.PaladinTXReference{
+					TransactionID:   txID,
+					TransactionType: pldapi.TransactionTypePrivate.Enum(),
+				},
+				IndexedTransactionNotify: &blockindexer.IndexedTransactionNotify{
+					IndexedTransaction: pldapi.IndexedTransaction{
+						Hash:             pldtypes.RandBytes32(),
+						BlockNumber:      12345,
+						TransactionIndex: 10,
+						Result:           pldapi.TXResult_FAILURE.Enum(),
+					},
+					RevertReason: revertData,
+				},
+			},
+		})
+	})
+	require.NoError(t, err)
+}
+
 func TestPrivateTxManagerSimplePreparedTransaction(t *testing.T) {
 	//Prepare a transaction that gets assembled with an attestation plan for a local endorser to sign the transaction
 	// submit mode external means the transaction does not get dispatched to the public tx manager
@@ -497,7 +569,7 @@ func TestPrivateTxManagerSimplePreparedTransaction(t *testing.T) {
 		},
 	}, nil)
 
-	mocks.keyManager.On("Sign", mock.Anything, notaryKeyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
+	mocks.keyManager.On("Sign", mock.Anything, mock.Anything, notaryKeyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
 		Return([]byte("notary-signature-bytes"), nil)
 
 	mocks.domainSmartContract.On("PrepareTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(
@@ -697,7 +769,7 @@ func TestPrivateTxManagerMultipleSignature(t *testing.T) {
 		},
 	}, nil)
 
-	mocks.keyManager.On("Sign", mock.Anything, notaryKeyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
+	mocks.keyManager.On("Sign", mock.Anything, mock.Anything, notaryKeyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
 		Return([]byte("notary-signature-bytes"), nil)
 
 	aliceKeyMapping := &pldapi.KeyMappingAndVerifier{
@@ -708,7 +780,7 @@ func TestPrivateTxManagerMultipleSignature(t *testing.T) {
 		Verifier: &pldapi.KeyVerifier{Verifier: alice.verifier},
 	}
 
-	mocks.keyManager.On("Sign", mock.Anything, aliceKeyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
+	mocks.keyManager.On("Sign", mock.Anything, mock.Anything, aliceKeyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
 		Return([]byte("notary-signature-bytes"), nil)
 
 	bobKeyMapping := &pldapi.KeyMappingAndVerifier{
@@ -719,7 +791,7 @@ func TestPrivateTxManagerMultipleSignature(t *testing.T) {
 		Verifier: &pldapi.KeyVerifier{Verifier: bob.verifier},
 	}
 
-	mocks.keyManager.On("Sign", mock.Anything, bobKeyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
+	mocks.keyManager.On("Sign", mock.Anything, mock.Anything, bobKeyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
 		Return([]byte("notary-signature-bytes"), nil)
 
 	mocks.domainSmartContract.On("PrepareTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(
@@ -3031,7 +3103,7 @@ func newPartyForTesting(ctx context.Context, name, node string, mocks *dependenc
 	mocks.keyManager.On("ResolveKeyNewDatabaseTX", mock.Anything, party.identity, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).Return(keyMapping, nil).Maybe()
 
 	party.mockSign = func(payload []byte, signature []byte) {
-		mocks.keyManager.On("Sign", mock.Anything, keyMapping, signpayloads.OPAQUE_TO_RSV, payload).
+		mocks.keyManager.On("Sign", mock.Anything, mock.Anything, keyMapping, signpayloads.OPAQUE_TO_RSV, payload).
 			Return(signature, nil)
 	}
 
@@ -3096,3 +3168,20 @@ func pollForStatus(ctx context.Context, t *testing.T, expectedStatus string, pri
 		}
 	}
 }
+
+func TestNotifyExpiredTransactions(t *testing.T) {
+	ctx := context.Background()
+	privateTxManager, mocks := NewPrivateTransactionMgrForPackageTesting(t, "node1")
+
+	receipts := []*components.ReceiptInput{{
+		ReceiptType:    components.RT_FailedWithMessage,
+		TransactionID:  uuid.New(),
+		FailureMessage: "expired",
+	}}
+	mocks.txManager.On("FinalizeTransactions", mock.Anything, mock.Anything, receipts).Return(nil)
+
+	err := privateTxManager.P().Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return privateTxManager.NotifyExpiredTransactions(ctx, dbTX, receipts)
+	})
+	require.NoError(t, err)
+}
@@ -55,6 +55,7 @@ type Publisher interface {
 	PublishTransactionFinalizedEvent(ctx context.Context, transactionId string)
 	PublishTransactionFinalizeError(ctx context.Context, transactionId string, revertReason string, err error)
 	PublishTransactionConfirmedEvent(ctx context.Context, transactionId string)
+	PublishTransactionRevertedEvent(ctx context.Context, transactionId string)
 	PublishNudgeEvent(ctx context.Context, transactionId string)
 }
 
@@ -172,6 +172,16 @@ func (p *publisher) PublishTransactionConfirmedEvent(ctx context.Context, transa
 	p.privateTxManager.HandleNewEvent(ctx, event)
 }
 
+func (p *publisher) PublishTransactionRevertedEvent(ctx context.Context, transactionId string) {
+	event := &ptmgrtypes.TransactionRevertedEvent{
+		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{
+			ContractAddress: p.contractAddress,
+			TransactionID:   transactionId,
+		},
+	}
+	p.privateTxManager.HandleNewEvent(ctx, event)
+}
+
 func (p *publisher) PublishNudgeEvent(ctx context.Context, transactionId string) {
 	event := &ptmgrtypes.TransactionNudgeEvent{
 		PrivateTransactionEventBase: ptmgrtypes.PrivateTransactionEventBase{
@@ -142,6 +142,7 @@ func (s *Sequencer) DispatchTransactions(ctx context.Context, dispatchableTransa
 						To:              &s.contractAddress,
 						PublicTxOptions: pt.PreparedPublicTransaction.PublicTxOptions,
 					},
+					ExpiresAt: pt.ExpiresAt,
 				}
 
 				// TODO: This aligning with submission in public Tx manage
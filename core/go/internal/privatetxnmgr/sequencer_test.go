@@ -31,7 +31,10 @@ import (
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -203,3 +206,53 @@ func TestSequencerPollingLoopCancelContext(t *testing.T) {
 
 	cancel()
 }
+
+func TestAssembleAndSignPolicyViolationReverts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	testOc, dependencyMocks, ocDone := newSequencerForTesting(t, ctx, nil)
+	defer ocDone()
+	defer cancel()
+
+	newTxID := uuid.New()
+	dependencyMocks.txManager.On("GetResolvedTransactionByID", mock.Anything, newTxID).Return(&components.ResolvedTransaction{
+		Transaction: &pldapi.Transaction{
+			ID: &newTxID,
+			TransactionBase: pldapi.TransactionBase{
+				Domain: "domain1",
+				To:     &testOc.contractAddress,
+			},
+		},
+	}, nil)
+	dependencyMocks.domain.On("Name").Return("domain1")
+	dependencyMocks.domainSmartContract.On("AssembleTransaction", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		tx := args[2].(*components.PrivateTransaction)
+		tx.PostAssembly = &components.TransactionPostAssembly{
+			AssemblyResult: prototk.AssembleTransactionResponse_OK,
+			AttestationPlan: []*prototk.AttestationRequest{
+				{
+					Name:            "sign",
+					AttestationType: prototk.AttestationType_SIGN,
+					Algorithm:       algorithms.ECDSA_SECP256K1,
+					VerifierType:    verifiers.ETH_ADDRESS,
+					PayloadType:     signpayloads.OPAQUE_TO_RSV,
+					Payload:         []byte("payload"),
+					Parties:         []string{"alice@" + testOc.nodeName},
+				},
+			},
+		}
+	})
+	keyMapping := &pldapi.KeyMappingAndVerifier{
+		KeyMappingWithPath: &pldapi.KeyMappingWithPath{KeyMapping: &pldapi.KeyMapping{Identifier: "alice", Wallet: "wallet1"}},
+		Verifier:           &pldapi.KeyVerifier{Verifier: pldtypes.RandAddress().String()},
+	}
+	dependencyMocks.keyManager.On("ResolveKeyNewDatabaseTX", mock.Anything, "alice", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).Return(keyMapping, nil)
+	dependencyMocks.keyManager.On("Sign", mock.Anything, mock.Anything, keyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).Return(nil, &components.SigningPolicyError{
+		Wallet: "wallet1",
+		Cause:  errors.New("PD010522: not allowed"),
+	})
+
+	postAssembly, err := testOc.assembleAndSign(ctx, newTxID, &components.TransactionPreAssembly{}, testOc.coordinatorDomainContext)
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, postAssembly.AssemblyResult)
+	assert.Regexp(t, "PD010522", *postAssembly.RevertReason)
+}
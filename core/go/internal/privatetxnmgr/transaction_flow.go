@@ -28,6 +28,7 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/privatetxnmgr/syncpoints"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 )
 
@@ -109,6 +110,8 @@ type transactionFlow struct {
 	delegateRequestBlockHeight  int64
 	delegated                   bool
 	delegateRequestTimer        *time.Timer
+	scheduleTimer               *time.Timer
+	scheduleTimerDeadline       pldtypes.Timestamp
 	assemblePending             bool
 	complete                    bool
 	requestedVerifierResolution bool                                      //TODO add precision here so that we can track individual requests and implement retry as per endorsement
@@ -23,6 +23,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -83,6 +84,10 @@ func (tf *transactionFlow) Action(ctx context.Context) {
 		return
 	}
 
+	if !tf.checkSchedule(ctx) {
+		return
+	}
+
 	if tf.transaction.PreAssembly == nil || tf.transaction.PreAssembly.TransactionSpecification == nil {
 		tf.logActionDebug(ctx, "PreAssembly is nil")
 		panic("PreAssembly is nil.")
@@ -239,6 +244,42 @@ func (tf *transactionFlow) setTransactionSigner(ctx context.Context) (reDelegate
 	return false, nil
 }
 
+// checkSchedule reverts the transaction if it has expired before being dispatched, or holds it if it
+// has not yet reached its notBefore time. A timer nudges the transaction when the next of these passes.
+func (tf *transactionFlow) checkSchedule(ctx context.Context) (doContinue bool) {
+	tx := tf.transaction
+	if tx.NotBefore == nil && tx.ExpiresAt == nil {
+		return true
+	}
+	now := tf.clock.Now()
+	if tx.ExpiresAt != nil && !now.Before(tx.ExpiresAt.Time()) {
+		tf.revertTransaction(ctx, i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgTxMgrTransactionExpired), tx.ExpiresAt))
+		return false
+	}
+
+	held := tx.NotBefore != nil && now.Before(tx.NotBefore.Time())
+	nextDeadline := tx.ExpiresAt
+	if held {
+		nextDeadline = tx.NotBefore
+	}
+	if nextDeadline != nil && (tf.scheduleTimer == nil || tf.scheduleTimerDeadline != *nextDeadline) {
+		if tf.scheduleTimer != nil {
+			tf.scheduleTimer.Stop()
+		}
+		tf.scheduleTimerDeadline = *nextDeadline
+		tf.scheduleTimer = time.AfterFunc(nextDeadline.Time().Sub(now), func() {
+			tf.publisher.PublishNudgeEvent(ctx, tf.transaction.ID.String())
+		})
+	}
+
+	if held {
+		tf.status = "scheduled"
+		tf.logActionInfof(ctx, "Transaction held until %s", tx.NotBefore)
+		return false
+	}
+	return true
+}
+
 func (tf *transactionFlow) revertTransaction(ctx context.Context, revertReason string) {
 	log.L(ctx).Errorf("Reverting transaction %s: %s", tf.transaction.ID.String(), revertReason)
 	//trigger a finalize and update the transaction state so that finalize can be retried if it fails
@@ -487,10 +528,14 @@ func (tf *transactionFlow) requestSignature(ctx context.Context, attRequest *pro
 		return
 	}
 	// TODO this could be calling out to a remote signer, should we be doing these in parallel?
-	signaturePayload, err := keyMgr.Sign(ctx, resolvedKey, attRequest.PayloadType, attRequest.Payload)
+	signaturePayload, err := keyMgr.Sign(ctx, tf.components.Persistence().NOTX(), resolvedKey, attRequest.PayloadType, attRequest.Payload)
 	if err != nil {
 		log.L(ctx).Errorf("failed to sign for party %s (verifier=%s,algorithm=%s): %s", partyName, resolvedKey.Verifier.Verifier, attRequest.Algorithm, err)
 		tf.latestError = i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgPrivateTxManagerSignError), partyName, resolvedKey.Verifier.Verifier, attRequest.Algorithm, err.Error())
+		if components.IsSigningPolicyError(err) {
+			// The signing policy will not change on retry, so the transaction is failed with the violation in the receipt
+			tf.revertTransaction(ctx, tf.latestError)
+		}
 		return
 	}
 	log.L(ctx).Debugf("payload: %x signed %x by %s (%s)", attRequest.Payload, signaturePayload, partyName, resolvedKey.Verifier.Verifier)
@@ -198,10 +198,13 @@ func (tf *transactionFlow) applyTransactionConfirmedEvent(ctx context.Context, e
 	tf.finalizeRequired = true
 }
 
+// The base ledger transaction failed (or was cancelled) and the failure receipt has already been written,
+// so there is nothing more to do for this transaction
 func (tf *transactionFlow) applyTransactionRevertedEvent(ctx context.Context, _ *ptmgrtypes.TransactionRevertedEvent) {
 	log.L(ctx).Debugf("transactionFlow:applyTransactionRevertedEvent transactionID:%s", tf.transaction.ID.String())
 	tf.latestEvent = "TransactionRevertedEvent"
 	tf.status = "reverted"
+	tf.complete = true
 }
 
 func (tf *transactionFlow) applyTransactionDelegationAcknowledgedEvent(ctx context.Context, event *ptmgrtypes.TransactionDelegationAcknowledgedEvent) {
@@ -251,6 +254,9 @@ func (tf *transactionFlow) applyTransactionFinalizedEvent(ctx context.Context, _
 	log.L(ctx).Debugf("transactionFlow:applyTransactionFinalizedEvent transactionID:%s", tf.transaction.ID.String())
 	tf.latestEvent = "TransactionFinalizedEvent"
 	tf.complete = true
+	if tf.scheduleTimer != nil {
+		tf.scheduleTimer.Stop()
+	}
 	log.L(ctx).Debug("HandleTransactionFinalizedEvent")
 }
 
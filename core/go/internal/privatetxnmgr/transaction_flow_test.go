@@ -17,6 +17,8 @@ package privatetxnmgr
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,6 +29,8 @@ import (
 	"github.com/kaleido-io/paladin/core/mocks/componentsmocks"
 	"github.com/kaleido-io/paladin/core/mocks/ptmgrtypesmocks"
 	"github.com/kaleido-io/paladin/core/mocks/syncpointsmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
@@ -1847,3 +1851,97 @@ type fakeClock struct {
 func (f *fakeClock) Now() time.Time {
 	return time.Now().Add(f.timePassed)
 }
+
+func TestCheckScheduleHeldUntilNotBefore(t *testing.T) {
+	ctx := context.Background()
+	newTxID := uuid.New()
+	notBefore := pldtypes.Timestamp(time.Now().Add(50 * time.Millisecond).UnixNano())
+	tp, mocks := newTransactionFlowForTesting(t, ctx, &components.PrivateTransaction{
+		ID:        newTxID,
+		NotBefore: &notBefore,
+	}, "node1")
+
+	nudged := make(chan struct{})
+	mocks.publisher.On("PublishNudgeEvent", mock.Anything, newTxID.String()).Run(func(args mock.Arguments) {
+		close(nudged)
+	}).Once()
+
+	assert.False(t, tp.checkSchedule(ctx))
+	assert.Equal(t, "scheduled", tp.status)
+	assert.Equal(t, notBefore, tp.scheduleTimerDeadline)
+
+	// checking again does not re-arm the timer
+	timer := tp.scheduleTimer
+	assert.False(t, tp.checkSchedule(ctx))
+	assert.Same(t, timer, tp.scheduleTimer)
+
+	<-nudged
+	assert.True(t, tp.checkSchedule(ctx))
+}
+
+func TestCheckScheduleExpired(t *testing.T) {
+	ctx := context.Background()
+	newTxID := uuid.New()
+	expiresAt := pldtypes.Timestamp(time.Now().Add(1 * time.Hour).UnixNano())
+	tp, mocks := newTransactionFlowForTesting(t, ctx, &components.PrivateTransaction{
+		ID:        newTxID,
+		ExpiresAt: &expiresAt,
+	}, "node1")
+
+	// before expiry we continue, with a timer armed for the expiry
+	assert.True(t, tp.checkSchedule(ctx))
+	assert.Equal(t, expiresAt, tp.scheduleTimerDeadline)
+
+	mocks.syncPoints.On("QueueTransactionFinalize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, newTxID, mock.MatchedBy(func(revertReason string) bool {
+		return strings.Contains(revertReason, "PD012255")
+	}), mock.Anything, mock.Anything).Return().Once()
+
+	tp.clock = &fakeClock{timePassed: 2 * time.Hour}
+	assert.False(t, tp.checkSchedule(ctx))
+	assert.True(t, tp.finalizePending)
+
+	tp.applyTransactionFinalizedEvent(ctx, &ptmgrtypes.TransactionFinalizedEvent{})
+	assert.True(t, tp.complete)
+}
+
+func TestRequestSignaturePolicyViolationReverts(t *testing.T) {
+	ctx := context.Background()
+	newTxID := uuid.New()
+	tp, mocks := newTransactionFlowForTesting(t, ctx, &components.PrivateTransaction{
+		ID: newTxID,
+	}, "node1")
+
+	attRequest := &prototk.AttestationRequest{
+		Name:            "sign",
+		AttestationType: prototk.AttestationType_SIGN,
+		Algorithm:       algorithms.ECDSA_SECP256K1,
+		VerifierType:    verifiers.ETH_ADDRESS,
+		PayloadType:     signpayloads.OPAQUE_TO_RSV,
+		Payload:         []byte("payload"),
+	}
+	keyMapping := &pldapi.KeyMappingAndVerifier{
+		KeyMappingWithPath: &pldapi.KeyMappingWithPath{KeyMapping: &pldapi.KeyMapping{Identifier: "alice", Wallet: "wallet1"}},
+		Verifier:           &pldapi.KeyVerifier{Verifier: pldtypes.RandAddress().String()},
+	}
+	mocks.keyManager.On("ResolveKeyNewDatabaseTX", mock.Anything, "alice", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).Return(keyMapping, nil)
+	mp, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	mocks.allComponents.On("Persistence").Return(mp.P)
+
+	// A failure of the signer is retried
+	mocks.keyManager.On("Sign", mock.Anything, mock.Anything, keyMapping, signpayloads.OPAQUE_TO_RSV, attRequest.Payload).Return(nil, fmt.Errorf("pop")).Once()
+	tp.requestSignature(ctx, attRequest, "alice@node1")
+	assert.Regexp(t, "pop", tp.latestError)
+	assert.False(t, tp.finalizePending)
+
+	// A violation of the signing policy fails the transaction
+	mocks.keyManager.On("Sign", mock.Anything, mock.Anything, keyMapping, signpayloads.OPAQUE_TO_RSV, attRequest.Payload).Return(nil, &components.SigningPolicyError{
+		Wallet: "wallet1",
+		Cause:  fmt.Errorf("PD010522: not allowed"),
+	}).Once()
+	mocks.syncPoints.On("QueueTransactionFinalize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, newTxID, mock.MatchedBy(func(revertReason string) bool {
+		return strings.Contains(revertReason, "PD010522")
+	}), mock.Anything, mock.Anything).Return().Once()
+	tp.requestSignature(ctx, attRequest, "alice@node1")
+	assert.True(t, tp.finalizePending)
+}
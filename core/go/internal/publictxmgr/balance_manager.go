@@ -19,16 +19,23 @@ import (
 	"context"
 	"math/big"
 	"sync"
+	"time"
 
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
 
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
 )
 
 // Balance manager is a component that provides the following services
 // - retrieve the balance of a given address either from the node or from the cache
+// - top up signing addresses from a funding account, when auto fueling is configured
 
 type BalanceManagerWithInMemoryTracking struct {
 	// transaction handler is retrieve balances from the chain
@@ -41,6 +48,33 @@ type BalanceManagerWithInMemoryTracking struct {
 	// the balance of the signing address from the chain
 	addressBalanceChangedMap    map[pldtypes.EthAddress]bool
 	addressBalanceChangedMapMux sync.Mutex
+
+	// auto fueling settings - the source key is resolved to an address on first use
+	fuelingSource        string
+	fuelingSourceAddress *pldtypes.EthAddress
+	fuelingTargetBalance *big.Int
+	fuelingMinThreshold  *big.Int
+	fuelingMaxThreshold  *big.Int
+	fuelingMinInterval   time.Duration
+	fuelingMaxInFlight   int
+
+	// fueling transactions are tracked in memory against the address they are fueling,
+	// so transactions for that address wait for the fuel to arrive. Each address is fueled under
+	// its own lock, and the fueling mutex only protects these maps.
+	trackedFuelingTxs map[pldtypes.EthAddress]*fuelingTransaction
+	lastFueled        map[pldtypes.EthAddress]time.Time
+	fuelingLocks      map[pldtypes.EthAddress]*fuelingLock
+	fuelingMux        sync.Mutex
+}
+
+type fuelingTransaction struct {
+	pubTxnID uint64 // zero while the transaction is being submitted
+	value    *big.Int
+}
+
+type fuelingLock struct {
+	sync.Mutex
+	refs int
 }
 
 func (af *BalanceManagerWithInMemoryTracking) NotifyAddressBalanceChanged(ctx context.Context, address pldtypes.EthAddress) {
@@ -85,10 +119,224 @@ func (af *BalanceManagerWithInMemoryTracking) GetAddressBalance(ctx context.Cont
 	}, nil
 }
 
+func (af *BalanceManagerWithInMemoryTracking) IsAutoFuelingEnabled(ctx context.Context) bool {
+	return af.fuelingSource != ""
+}
+
+func (af *BalanceManagerWithInMemoryTracking) getFuelingSourceAddress(ctx context.Context) (*pldtypes.EthAddress, error) {
+	af.fuelingMux.Lock()
+	sourceAddress := af.fuelingSourceAddress
+	af.fuelingMux.Unlock()
+	if sourceAddress == nil {
+		var err error
+		sourceAddress, err = af.pubTxMgr.keymgr.ResolveEthAddressNewDatabaseTX(ctx, af.fuelingSource)
+		if err != nil {
+			return nil, err
+		}
+		af.fuelingMux.Lock()
+		af.fuelingSourceAddress = sourceAddress
+		af.fuelingMux.Unlock()
+	}
+	return sourceAddress, nil
+}
+
+// Fueling of one address is serialized, without blocking the fueling of other addresses
+func (af *BalanceManagerWithInMemoryTracking) lockFuelingAddress(address pldtypes.EthAddress) (unlock func()) {
+	af.fuelingMux.Lock()
+	fl := af.fuelingLocks[address]
+	if fl == nil {
+		fl = &fuelingLock{}
+		af.fuelingLocks[address] = fl
+	}
+	fl.refs++
+	af.fuelingMux.Unlock()
+
+	fl.Lock()
+	return func() {
+		fl.Unlock()
+		af.fuelingMux.Lock()
+		defer af.fuelingMux.Unlock()
+		fl.refs--
+		if fl.refs == 0 {
+			delete(af.fuelingLocks, address)
+		}
+	}
+}
+
+func (af *BalanceManagerWithInMemoryTracking) getTrackedFuelingTx(address pldtypes.EthAddress) *fuelingTransaction {
+	af.fuelingMux.Lock()
+	defer af.fuelingMux.Unlock()
+	return af.trackedFuelingTxs[address]
+}
+
+func (af *BalanceManagerWithInMemoryTracking) fueledRecently(ctx context.Context, address pldtypes.EthAddress) bool {
+	af.fuelingMux.Lock()
+	defer af.fuelingMux.Unlock()
+	if lastFueled, ok := af.lastFueled[address]; ok && time.Since(lastFueled) < af.fuelingMinInterval {
+		log.L(ctx).Debugf("Fueling of %s rate limited (last fueled %s)", address, lastFueled)
+		return true
+	}
+	return false
+}
+
+func (af *BalanceManagerWithInMemoryTracking) setTrackedFuelingTx(address pldtypes.EthAddress, tracked *fuelingTransaction) {
+	af.fuelingMux.Lock()
+	defer af.fuelingMux.Unlock()
+	if tracked == nil {
+		delete(af.trackedFuelingTxs, address)
+	} else {
+		af.trackedFuelingTxs[address] = tracked
+	}
+}
+
+// Takes one of the limited number of in-flight fueling slots for the address, before the transaction is submitted
+func (af *BalanceManagerWithInMemoryTracking) reserveFuelingTx(ctx context.Context, address pldtypes.EthAddress, value *big.Int) *fuelingTransaction {
+	af.fuelingMux.Lock()
+	defer af.fuelingMux.Unlock()
+	if len(af.trackedFuelingTxs) >= af.fuelingMaxInFlight {
+		log.L(ctx).Debugf("Fueling of %s deferred as %d fueling transactions are in flight", address, len(af.trackedFuelingTxs))
+		return nil
+	}
+	tracked := &fuelingTransaction{value: value}
+	af.trackedFuelingTxs[address] = tracked
+	return tracked
+}
+
+// The in-memory tracking is lost on restart, so before submitting a new fueling transaction we check
+// for one submitted before the restart that has not yet completed
+func (af *BalanceManagerWithInMemoryTracking) getPendingFuelingTx(ctx context.Context, sourceAddress, address pldtypes.EthAddress) (*fuelingTransaction, error) {
+	var ptxs []*DBPublicTxn
+	err := af.pubTxMgr.p.DB().
+		WithContext(ctx).
+		Table("public_txns").
+		Joins("Completed").
+		Where(`"Completed"."tx_hash" IS NULL`).
+		Where(`"public_txns"."from" = ?`, sourceAddress).
+		Where(`"public_txns"."to" = ?`, address).
+		Order(`"public_txns"."pub_txn_id" DESC`).
+		Limit(1).
+		Find(&ptxs).
+		Error
+	if err != nil || len(ptxs) == 0 {
+		return nil, err
+	}
+	return &fuelingTransaction{
+		pubTxnID: ptxs[0].PublicTxnID,
+		value:    ptxs[0].Value.Int(),
+	}, nil
+}
+
+func (af *BalanceManagerWithInMemoryTracking) TopUpAccount(ctx context.Context, addAccount *AddressAccount) (fuelingPending bool, err error) {
+	sourceAddress, err := af.getFuelingSourceAddress(ctx)
+	if err != nil {
+		return false, err
+	}
+	if addAccount.Address == *sourceAddress {
+		// the funding account cannot fuel itself
+		return false, nil
+	}
+
+	unlock := af.lockFuelingAddress(addAccount.Address)
+	defer unlock()
+
+	if tracked := af.getTrackedFuelingTx(addAccount.Address); tracked != nil {
+		completed, err := af.pubTxMgr.CheckTransactionCompleted(ctx, tracked.pubTxnID)
+		if err != nil || !completed {
+			return true, err
+		}
+		// the balance we were passed pre-dates the fuel arriving, so we wait until it has been refreshed
+		// before deciding whether another top up is needed
+		log.L(ctx).Infof("Fueling transaction %d of %s to %s completed", tracked.pubTxnID, tracked.value, addAccount.Address)
+		af.setTrackedFuelingTx(addAccount.Address, nil)
+		af.NotifyAddressBalanceChanged(ctx, addAccount.Address)
+		return false, nil
+	}
+
+	availableToSpend := addAccount.GetAvailableToSpend(ctx)
+	if availableToSpend.Cmp(af.fuelingMinThreshold) >= 0 || af.fueledRecently(ctx, addAccount.Address) {
+		return false, nil
+	}
+
+	pending, err := af.getPendingFuelingTx(ctx, *sourceAddress, addAccount.Address)
+	if err != nil {
+		return false, err
+	}
+	if pending != nil {
+		log.L(ctx).Infof("Tracking pending fueling transaction %d of %s from %s to %s", pending.pubTxnID, pending.value, sourceAddress, addAccount.Address)
+		af.setTrackedFuelingTx(addAccount.Address, pending)
+		return true, nil
+	}
+
+	value := new(big.Int).Sub(af.fuelingTargetBalance, availableToSpend)
+	if value.Sign() <= 0 {
+		return false, nil
+	}
+	if af.fuelingMaxThreshold != nil && value.Cmp(af.fuelingMaxThreshold) > 0 {
+		value.Set(af.fuelingMaxThreshold)
+	}
+
+	tracked := af.reserveFuelingTx(ctx, addAccount.Address, value)
+	if tracked == nil {
+		return false, nil
+	}
+	ptx, err := af.submitFuelingTx(ctx, *sourceAddress, addAccount.Address, value)
+	if err != nil {
+		af.setTrackedFuelingTx(addAccount.Address, nil)
+		return false, err
+	}
+	log.L(ctx).Infof("Submitted fueling transaction %d of %s from %s to %s (available=%s)", *ptx.LocalID, value, sourceAddress, addAccount.Address, availableToSpend)
+	af.fuelingMux.Lock()
+	tracked.pubTxnID = *ptx.LocalID
+	af.lastFueled[addAccount.Address] = time.Now()
+	af.fuelingMux.Unlock()
+	// the source account's balance is about to change
+	af.NotifyAddressBalanceChanged(ctx, *sourceAddress)
+	return true, nil
+}
+
+func (af *BalanceManagerWithInMemoryTracking) submitFuelingTx(ctx context.Context, sourceAddress, address pldtypes.EthAddress, value *big.Int) (*pldapi.PublicTx, error) {
+	sourceAccount, err := af.GetAddressBalance(ctx, sourceAddress)
+	if err != nil {
+		return nil, err
+	}
+	if sourceAccount.Balance.Cmp(value) < 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgFuelingSourceInsufficientBalance, sourceAddress, sourceAccount.Balance, value, address)
+	}
+	return af.pubTxMgr.SingleTransactionSubmit(ctx, &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: &sourceAddress,
+			To:   &address,
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas:   confutil.P(pldtypes.HexUint64(valueTransferGasLimit)),
+				Value: (*pldtypes.HexUint256)(value),
+			},
+		},
+	})
+}
+
 func NewBalanceManagerWithInMemoryTracking(ctx context.Context, conf *pldconf.PublicTxManagerConfig, publicTxMgr *pubTxManager) BalanceManager {
-	return &BalanceManagerWithInMemoryTracking{
+	fuelingConf := &conf.BalanceManager.AutoFueling
+	fuelingDefaults := &pldconf.PublicTxManagerDefaults.BalanceManager.AutoFueling
+	bm := &BalanceManagerWithInMemoryTracking{
 		pubTxMgr:                 publicTxMgr,
 		balanceCache:             cache.NewCache[pldtypes.EthAddress, *big.Int](&conf.BalanceManager.Cache, &pldconf.PublicTxManagerDefaults.BalanceManager.Cache),
 		addressBalanceChangedMap: make(map[pldtypes.EthAddress]bool),
+		fuelingSource:            confutil.StringOrEmpty(fuelingConf.Source, ""),
+		fuelingTargetBalance:     confutil.BigIntOrNil(fuelingConf.TargetBalance),
+		fuelingMinThreshold:      confutil.BigIntOrNil(fuelingConf.MinThreshold),
+		fuelingMaxThreshold:      confutil.BigIntOrNil(fuelingConf.MaxThreshold),
+		fuelingMinInterval:       confutil.DurationMin(fuelingConf.MinInterval, 0, *fuelingDefaults.MinInterval),
+		fuelingMaxInFlight:       confutil.IntMin(fuelingConf.MaxInFlight, 1, *fuelingDefaults.MaxInFlight),
+		trackedFuelingTxs:        make(map[pldtypes.EthAddress]*fuelingTransaction),
+		lastFueled:               make(map[pldtypes.EthAddress]time.Time),
+		fuelingLocks:             make(map[pldtypes.EthAddress]*fuelingLock),
+	}
+	if bm.fuelingMinThreshold == nil {
+		bm.fuelingMinThreshold = confutil.BigIntOrNil(fuelingDefaults.MinThreshold)
+	}
+	if bm.fuelingSource != "" && bm.fuelingTargetBalance == nil {
+		log.L(ctx).Warnf("Auto fueling from '%s' disabled as no valid target balance is configured", bm.fuelingSource)
+		bm.fuelingSource = ""
 	}
+	return bm
 }
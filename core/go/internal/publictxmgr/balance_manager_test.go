@@ -20,7 +20,12 @@ import (
 	"errors"
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/componentsmocks"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -161,3 +166,295 @@ func TestAddressAccountSpend(t *testing.T) {
 	assert.Equal(t, spent5.String(), addressAccount.MaxCost.String())
 	assert.Equal(t, 5, addressAccount.SpentTransactionCount)
 }
+
+func newTestFuelingBalanceManager(t *testing.T, realDB bool, extraSetup ...func(conf *pldconf.AutoFuelingConfig)) (context.Context, *BalanceManagerWithInMemoryTracking, *pubTxManager, *mocksAndTestControl, func()) {
+	ctx, ble, m, done := newTestPublicTxManager(t, realDB, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+		conf.BalanceManager.AutoFueling = pldconf.AutoFuelingConfig{
+			Source:        confutil.P("fuel.source"),
+			TargetBalance: confutil.P("1000"),
+			MinThreshold:  confutil.P("500"),
+			MaxThreshold:  confutil.P("800"),
+		}
+		for _, setup := range extraSetup {
+			setup(&conf.BalanceManager.AutoFueling)
+		}
+	})
+	return ctx, ble.balanceManager.(*BalanceManagerWithInMemoryTracking), ble, m, done
+}
+
+func newTestFuelingAccount(balance, spent int64) *AddressAccount {
+	return &AddressAccount{
+		Address: *pldtypes.RandAddress(),
+		Balance: big.NewInt(balance),
+		Spent:   big.NewInt(spent),
+		MinCost: big.NewInt(0),
+		MaxCost: big.NewInt(0),
+	}
+}
+
+func TestAutoFuelingDisabled(t *testing.T) {
+	ctx, bm, _, _, done := newTestBalanceManager(t)
+	defer done()
+	assert.False(t, bm.IsAutoFuelingEnabled(ctx))
+
+	_, bm, _, _, done = newTestFuelingBalanceManager(t, false, func(conf *pldconf.AutoFuelingConfig) {
+		conf.TargetBalance = nil
+	})
+	defer done()
+	assert.False(t, bm.IsAutoFuelingEnabled(ctx))
+}
+
+func TestAutoFuelingTopUpLifecycleRealDB(t *testing.T) {
+	ctx, bm, ptm, m, done := newTestFuelingBalanceManager(t, true)
+	defer done()
+	assert.True(t, bm.IsAutoFuelingEnabled(ctx))
+
+	sourceAddr, err := m.keyManager.ResolveEthAddressNewDatabaseTX(ctx, "fuel.source")
+	require.NoError(t, err)
+	m.ethClient.On("GetBalance", mock.Anything, *sourceAddr, "latest").Return(pldtypes.Uint64ToUint256(1000000), nil)
+
+	// above the threshold, nothing to do
+	account := newTestFuelingAccount(600, 50)
+	fuelingPending, err := bm.TopUpAccount(ctx, account)
+	require.NoError(t, err)
+	assert.False(t, fuelingPending)
+	assert.Empty(t, bm.trackedFuelingTxs)
+
+	// the source account never fuels itself
+	fuelingPending, err = bm.TopUpAccount(ctx, &AddressAccount{Address: *sourceAddr, Balance: big.NewInt(0), Spent: big.NewInt(0)})
+	require.NoError(t, err)
+	assert.False(t, fuelingPending)
+
+	// below the threshold we top up to the target, capped at the max threshold
+	account = newTestFuelingAccount(100, 200)
+	fuelingPending, err = bm.TopUpAccount(ctx, account)
+	require.NoError(t, err)
+	assert.True(t, fuelingPending)
+	tracked := bm.trackedFuelingTxs[account.Address]
+	require.NotNil(t, tracked)
+	assert.Equal(t, int64(800), tracked.value.Int64())
+
+	var ptxs []*DBPublicTxn
+	err = ptm.p.DB().Where(`"pub_txn_id" = ?`, tracked.pubTxnID).Find(&ptxs).Error
+	require.NoError(t, err)
+	require.Len(t, ptxs, 1)
+	assert.Equal(t, *sourceAddr, ptxs[0].From)
+	assert.Equal(t, account.Address, *ptxs[0].To)
+	assert.Equal(t, uint64(800), ptxs[0].Value.Int().Uint64())
+	assert.Equal(t, uint64(valueTransferGasLimit), ptxs[0].Gas)
+
+	// still pending until the fueling transaction completes
+	fuelingPending, err = bm.TopUpAccount(ctx, account)
+	require.NoError(t, err)
+	assert.True(t, fuelingPending)
+
+	err = ptm.p.DB().Create(&DBPublicTxnCompletion{
+		PublicTxnID:     tracked.pubTxnID,
+		TransactionHash: pldtypes.RandBytes32(),
+		Success:         true,
+	}).Error
+	require.NoError(t, err)
+
+	fuelingPending, err = bm.TopUpAccount(ctx, account)
+	require.NoError(t, err)
+	assert.False(t, fuelingPending)
+	assert.Empty(t, bm.trackedFuelingTxs)
+	assert.True(t, bm.addressBalanceChangedMap[account.Address])
+
+	// rate limited by the min interval, even though the balance we pass is still short
+	fuelingPending, err = bm.TopUpAccount(ctx, account)
+	require.NoError(t, err)
+	assert.False(t, fuelingPending)
+	assert.Empty(t, bm.trackedFuelingTxs)
+}
+
+func TestAutoFuelingMaxInFlight(t *testing.T) {
+	ctx, bm, _, m, done := newTestFuelingBalanceManager(t, false, func(conf *pldconf.AutoFuelingConfig) {
+		conf.MaxInFlight = confutil.P(1)
+	})
+	defer done()
+
+	bm.fuelingSourceAddress = pldtypes.RandAddress()
+	bm.trackedFuelingTxs[*pldtypes.RandAddress()] = &fuelingTransaction{pubTxnID: 12345, value: big.NewInt(10)}
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{}))
+
+	fuelingPending, err := bm.TopUpAccount(ctx, newTestFuelingAccount(0, 0))
+	require.NoError(t, err)
+	assert.False(t, fuelingPending)
+	assert.Len(t, bm.trackedFuelingTxs, 1)
+}
+
+func TestAutoFuelingAtTarget(t *testing.T) {
+	ctx, bm, _, m, done := newTestFuelingBalanceManager(t, false, func(conf *pldconf.AutoFuelingConfig) {
+		conf.MinThreshold = confutil.P("2000")
+	})
+	defer done()
+
+	bm.fuelingSourceAddress = pldtypes.RandAddress()
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{}))
+
+	// below threshold, but the target is lower than the threshold
+	fuelingPending, err := bm.TopUpAccount(ctx, newTestFuelingAccount(1500, 0))
+	require.NoError(t, err)
+	assert.False(t, fuelingPending)
+}
+
+func TestAutoFuelingResolveSourceFail(t *testing.T) {
+	ctx, bm, _, m, done := newTestFuelingBalanceManager(t, false)
+	defer done()
+
+	m.keyManager.(*componentsmocks.KeyManager).On("ResolveEthAddressNewDatabaseTX", mock.Anything, "fuel.source").
+		Return(nil, errors.New("pop"))
+
+	_, err := bm.TopUpAccount(ctx, newTestFuelingAccount(0, 0))
+	assert.Regexp(t, "pop", err)
+}
+
+func TestAutoFuelingCheckCompletedFail(t *testing.T) {
+	ctx, bm, _, m, done := newTestFuelingBalanceManager(t, false)
+	defer done()
+
+	account := newTestFuelingAccount(0, 0)
+	bm.fuelingSourceAddress = pldtypes.RandAddress()
+	bm.trackedFuelingTxs[account.Address] = &fuelingTransaction{pubTxnID: 12345, value: big.NewInt(10)}
+
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnError(errors.New("pop"))
+
+	_, err := bm.TopUpAccount(ctx, account)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestAutoFuelingSourceBalanceFail(t *testing.T) {
+	ctx, bm, _, m, done := newTestFuelingBalanceManager(t, false)
+	defer done()
+
+	bm.fuelingSourceAddress = pldtypes.RandAddress()
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{}))
+	m.ethClient.On("GetBalance", mock.Anything, *bm.fuelingSourceAddress, "latest").Return(nil, errors.New("pop"))
+
+	_, err := bm.TopUpAccount(ctx, newTestFuelingAccount(0, 0))
+	assert.Regexp(t, "pop", err)
+}
+
+func TestAutoFuelingSourceInsufficientBalance(t *testing.T) {
+	ctx, bm, _, m, done := newTestFuelingBalanceManager(t, false)
+	defer done()
+
+	bm.fuelingSourceAddress = pldtypes.RandAddress()
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{}))
+	m.ethClient.On("GetBalance", mock.Anything, *bm.fuelingSourceAddress, "latest").Return(pldtypes.Uint64ToUint256(799), nil)
+
+	_, err := bm.TopUpAccount(ctx, newTestFuelingAccount(0, 0))
+	assert.Regexp(t, "PD011949", err)
+	assert.Empty(t, bm.trackedFuelingTxs)
+}
+
+func TestAutoFuelingSubmitFail(t *testing.T) {
+	ctx, bm, _, m, done := newTestFuelingBalanceManager(t, false)
+	defer done()
+
+	bm.fuelingSourceAddress = pldtypes.RandAddress()
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{}))
+	m.ethClient.On("GetBalance", mock.Anything, *bm.fuelingSourceAddress, "latest").Return(pldtypes.Uint64ToUint256(1000), nil)
+	m.db.ExpectBegin()
+	m.db.ExpectQuery("INSERT.*public_txns").WillReturnError(errors.New("pop"))
+
+	account := newTestFuelingAccount(0, 0)
+	_, err := bm.TopUpAccount(ctx, account)
+	assert.Regexp(t, "pop", err)
+	assert.Empty(t, bm.trackedFuelingTxs)
+	_, rateLimited := bm.lastFueled[account.Address]
+	assert.False(t, rateLimited)
+}
+
+func TestAutoFuelingPendingQueryFail(t *testing.T) {
+	ctx, bm, _, m, done := newTestFuelingBalanceManager(t, false)
+	defer done()
+
+	bm.fuelingSourceAddress = pldtypes.RandAddress()
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnError(errors.New("pop"))
+
+	_, err := bm.TopUpAccount(ctx, newTestFuelingAccount(0, 0))
+	assert.Regexp(t, "pop", err)
+	assert.Empty(t, bm.trackedFuelingTxs)
+}
+
+func TestAutoFuelingPendingAfterRestartRealDB(t *testing.T) {
+	ctx, bm, ptm, m, done := newTestFuelingBalanceManager(t, true)
+	defer done()
+
+	sourceAddr, err := m.keyManager.ResolveEthAddressNewDatabaseTX(ctx, "fuel.source")
+	require.NoError(t, err)
+	m.ethClient.On("GetBalance", mock.Anything, *sourceAddr, "latest").Return(pldtypes.Uint64ToUint256(1000000), nil)
+
+	account := newTestFuelingAccount(100, 200)
+	fuelingPending, err := bm.TopUpAccount(ctx, account)
+	require.NoError(t, err)
+	assert.True(t, fuelingPending)
+	submitted := bm.trackedFuelingTxs[account.Address]
+	require.NotNil(t, submitted)
+
+	// After a restart the in-memory tracking is lost, but the pending fueling transaction is
+	// found in the DB rather than submitting another one
+	bm = NewBalanceManagerWithInMemoryTracking(ctx, ptm.conf, ptm).(*BalanceManagerWithInMemoryTracking)
+	fuelingPending, err = bm.TopUpAccount(ctx, account)
+	require.NoError(t, err)
+	assert.True(t, fuelingPending)
+	tracked := bm.trackedFuelingTxs[account.Address]
+	require.NotNil(t, tracked)
+	assert.Equal(t, submitted.pubTxnID, tracked.pubTxnID)
+	assert.Equal(t, int64(800), tracked.value.Int64())
+
+	var count int64
+	err = ptm.p.DB().Model(&DBPublicTxn{}).Where(`"to" = ?`, account.Address).Count(&count).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// Completed fueling transactions are not pending
+	err = ptm.p.DB().Create(&DBPublicTxnCompletion{
+		PublicTxnID:     tracked.pubTxnID,
+		TransactionHash: pldtypes.RandBytes32(),
+		Success:         true,
+	}).Error
+	require.NoError(t, err)
+	pending, err := bm.getPendingFuelingTx(ctx, *sourceAddr, account.Address)
+	require.NoError(t, err)
+	assert.Nil(t, pending)
+}
+
+func TestAutoFuelingLockPerAddress(t *testing.T) {
+	_, bm, _, _, done := newTestFuelingBalanceManager(t, false)
+	defer done()
+
+	address1 := *pldtypes.RandAddress()
+	address2 := *pldtypes.RandAddress()
+
+	unlock1 := bm.lockFuelingAddress(address1)
+
+	// A different address is not blocked
+	unlock2 := bm.lockFuelingAddress(address2)
+	unlock2()
+
+	// The same address waits for the lock to be released
+	locked := make(chan struct{})
+	go func() {
+		unlock := bm.lockFuelingAddress(address1)
+		close(locked)
+		unlock()
+	}()
+	select {
+	case <-locked:
+		assert.Fail(t, "locked twice")
+	case <-time.After(10 * time.Millisecond):
+	}
+	unlock1()
+	<-locked
+
+	// Locks are cleaned up once nobody holds or is waiting for them
+	require.Eventually(t, func() bool {
+		bm.fuelingMux.Lock()
+		defer bm.fuelingMux.Unlock()
+		return len(bm.fuelingLocks) == 0
+	}, 5*time.Second, 1*time.Millisecond)
+}
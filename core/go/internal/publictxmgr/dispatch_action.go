@@ -44,8 +44,8 @@ func (ptm *pubTxManager) persistSuspendedFlag(ctx context.Context, from pldtypes
 // TODO: this code needs to stop using from and nonce as the way of identifying a transaction. It didn't get edited
 // with the move to delayed nonce assignment, where pubTXID became the primary key for a public transaction instead
 // of from and nonce as a composite primary key. This isn't a problem for dispatching a confirm action because a
-// confirmed transaction must have a nonce, but it isn't guaranteed to work for suspend and resume. Those actions
-// have been copied across but aren't wired up above this level so they aren't obviously broken yet.
+// confirmed transaction must have a nonce. The user actions for suspend and resume look up the transaction by
+// from and nonce before dispatching, so they can only be applied once a nonce has been assigned.
 func (ptm *pubTxManager) dispatchAction(ctx context.Context, from pldtypes.EthAddress, nonce uint64, action AsyncRequestType) error {
 	ptm.inFlightOrchestratorMux.Lock()
 	defer ptm.inFlightOrchestratorMux.Unlock()
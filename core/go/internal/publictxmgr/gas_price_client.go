@@ -19,11 +19,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 
@@ -48,14 +50,27 @@ type GasPriceClient interface {
 //   - Fixed gas price
 //   - Cached gas price
 //   - Gas Oracle
+//   - EIP-1559 fee history (if enabled)
 //   - Node gas_Price
 type HybridGasPriceClient struct {
 	hasZeroGasPrice bool
 	fixedGasPrice   pldtypes.RawJSON
+	feeHistory      *feeHistoryStrategy
 	ethClient       ethclient.EthClient
 	gasPriceCache   cache.Cache[string, pldtypes.RawJSON]
 }
 
+// The fee history strategy derives EIP-1559 pricing from the recent blocks:
+//   - maxPriorityFeePerGas is the average of the configured reward percentile over the non-empty sampled blocks
+//   - maxFeePerGas is the base fee of the next block multiplied by the configured factor, plus the priority fee
+type feeHistoryStrategy struct {
+	blockCount            int
+	priorityFeePercentile float64
+	baseFeeMultiplier     float64
+	maxPriorityFeePerGas  *big.Int
+	maxFeePerGas          *big.Int
+}
+
 func (hGpc *HybridGasPriceClient) HasZeroGasPrice(ctx context.Context) bool {
 	return hGpc.hasZeroGasPrice
 }
@@ -105,15 +120,26 @@ func (hGpc *HybridGasPriceClient) getGasPriceJSON(ctx context.Context) (gasPrice
 		return cachedGasPrice, nil
 	}
 
-	// then try to use the node eth call
-	log.L(ctx).Debugf("Retrieving gas price from node eth call")
-	gasPriceHexInt, err := hGpc.ethClient.GasPrice(ctx)
-	if err != nil {
-		// no fallback is available, return the error
-		log.L(ctx).Errorf("Failed to retrieve gas price from the node")
-		return nil, err
+	if hGpc.feeHistory != nil {
+		// EIP-1559 pricing derived from the fee history
+		log.L(ctx).Debugf("Retrieving gas price from node fee history")
+		gpo, err := hGpc.feeHistory.getGasPriceObject(ctx, hGpc.ethClient)
+		if err != nil {
+			log.L(ctx).Errorf("Failed to retrieve fee history from the node")
+			return nil, err
+		}
+		gasPriceJSON = pldtypes.JSONString(gpo)
 	} else {
-		gasPriceJSON = pldtypes.RawJSON(fmt.Sprintf(`"%s"`, gasPriceHexInt))
+		// then try to use the node eth call
+		log.L(ctx).Debugf("Retrieving gas price from node eth call")
+		gasPriceHexInt, err := hGpc.ethClient.GasPrice(ctx)
+		if err != nil {
+			// no fallback is available, return the error
+			log.L(ctx).Errorf("Failed to retrieve gas price from the node")
+			return nil, err
+		} else {
+			gasPriceJSON = pldtypes.RawJSON(fmt.Sprintf(`"%s"`, gasPriceHexInt))
+		}
 	}
 
 	hGpc.gasPriceCache.Set("gasPrice", gasPriceJSON)
@@ -152,10 +178,73 @@ func NewGasPriceClient(ctx context.Context, conf *pldconf.PublicTxManagerConfig)
 	if b != nil && string(b) != `null` {
 		gasPriceClient.fixedGasPrice = pldtypes.RawJSON(b)
 	}
+	if confutil.Bool(conf.GasPrice.EthFeeHistory.Enabled, *pldconf.PublicTxManagerDefaults.GasPrice.EthFeeHistory.Enabled) {
+		gasPriceClient.feeHistory = newFeeHistoryStrategy(&conf.GasPrice.EthFeeHistory)
+		log.L(ctx).Infof("Gas price using fee history strategy: blockCount=%d percentile=%.2f baseFeeMultiplier=%.2f",
+			gasPriceClient.feeHistory.blockCount, gasPriceClient.feeHistory.priorityFeePercentile, gasPriceClient.feeHistory.baseFeeMultiplier)
+	}
 	gasPriceClient.gasPriceCache = gasPriceCache
 	return gasPriceClient
 }
 
+func newFeeHistoryStrategy(conf *pldconf.EthFeeHistoryConfig) *feeHistoryStrategy {
+	defs := &pldconf.PublicTxManagerDefaults.GasPrice.EthFeeHistory
+	return &feeHistoryStrategy{
+		blockCount:            confutil.IntMin(conf.BlockCount, 1, *defs.BlockCount),
+		priorityFeePercentile: math.Min(confutil.Float64Min(conf.PriorityFeePercentile, 0, *defs.PriorityFeePercentile), 100),
+		baseFeeMultiplier:     confutil.Float64Min(conf.BaseFeeMultiplier, 1, *defs.BaseFeeMultiplier),
+		maxPriorityFeePerGas:  confutil.BigIntOrNil(conf.MaxPriorityFeePerGas),
+		maxFeePerGas:          confutil.BigIntOrNil(conf.MaxFeePerGas),
+	}
+}
+
+func (fh *feeHistoryStrategy) getGasPriceObject(ctx context.Context, ethClient ethclient.EthClient) (*pldapi.PublicTxGasPricing, error) {
+	feeHistory, err := ethClient.FeeHistory(ctx, fh.blockCount, "latest", []float64{fh.priorityFeePercentile})
+	if err != nil {
+		return nil, err
+	}
+	if len(feeHistory.BaseFeePerGas) == 0 || feeHistory.BaseFeePerGas[len(feeHistory.BaseFeePerGas)-1] == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgFeeHistoryMissingBaseFee)
+	}
+	nextBaseFee := feeHistory.BaseFeePerGas[len(feeHistory.BaseFeePerGas)-1].Int()
+
+	// Empty blocks report a zero reward, so they are excluded from the average
+	totalReward := big.NewInt(0)
+	sampledBlocks := 0
+	for i, blockRewards := range feeHistory.Reward {
+		if i < len(feeHistory.GasUsedRatio) && feeHistory.GasUsedRatio[i] == 0 {
+			continue
+		}
+		if len(blockRewards) > 0 && blockRewards[0] != nil {
+			totalReward.Add(totalReward, blockRewards[0].Int())
+			sampledBlocks++
+		}
+	}
+	priorityFee := big.NewInt(0)
+	if sampledBlocks > 0 {
+		priorityFee.Div(totalReward, big.NewInt(int64(sampledBlocks)))
+	}
+	if fh.maxPriorityFeePerGas != nil && priorityFee.Cmp(fh.maxPriorityFeePerGas) > 0 {
+		priorityFee.Set(fh.maxPriorityFeePerGas)
+	}
+
+	maxFee, _ := new(big.Float).Mul(new(big.Float).SetInt(nextBaseFee), big.NewFloat(fh.baseFeeMultiplier)).Int(nil)
+	maxFee.Add(maxFee, priorityFee)
+	if fh.maxFeePerGas != nil && maxFee.Cmp(fh.maxFeePerGas) > 0 {
+		maxFee.Set(fh.maxFeePerGas)
+	}
+	// the priority fee can never exceed the max fee
+	if priorityFee.Cmp(maxFee) > 0 {
+		priorityFee.Set(maxFee)
+	}
+	log.L(ctx).Debugf("Fee history from block %d: nextBaseFee=%s priorityFee=%s maxFee=%s (sampled=%d)", feeHistory.OldestBlock, nextBaseFee, priorityFee, maxFee, sampledBlocks)
+
+	return &pldapi.PublicTxGasPricing{
+		MaxFeePerGas:         (*pldtypes.HexUint256)(maxFee),
+		MaxPriorityFeePerGas: (*pldtypes.HexUint256)(priorityFee),
+	}, nil
+}
+
 func (hGpc *HybridGasPriceClient) ParseGasPriceJSON(ctx context.Context, input pldtypes.RawJSON) (gpo *pldapi.PublicTxGasPricing, err error) {
 	gpo = &pldapi.PublicTxGasPricing{}
 	if input == nil {
@@ -20,8 +20,10 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 
 	"github.com/kaleido-io/paladin/core/mocks/ethclientmocks"
@@ -174,3 +176,112 @@ func TestGasPriceClient(t *testing.T) {
 	assert.Regexp(t, "doesn't work", err)
 	assert.Nil(t, gpo)
 }
+
+func TestGasPriceClientFeeHistory(t *testing.T) {
+	ctx := context.Background()
+
+	gasPriceClient := NewGasPriceClient(ctx, &pldconf.PublicTxManagerConfig{
+		GasPrice: pldconf.GasPriceConfig{
+			EthFeeHistory: pldconf.EthFeeHistoryConfig{
+				Enabled:               confutil.P(true),
+				BlockCount:            confutil.P(3),
+				PriorityFeePercentile: confutil.P(75.0),
+			},
+		},
+	})
+	hgc := gasPriceClient.(*HybridGasPriceClient)
+	require.NotNil(t, hgc.feeHistory)
+
+	mEC := ethclientmocks.NewEthClient(t)
+	hgc.Init(ctx, mEC)
+
+	mEC.On("FeeHistory", ctx, 3, "latest", []float64{75}).Return(&ethclient.FeeHistoryResult{
+		BaseFeePerGas: []*pldtypes.HexUint256{
+			pldtypes.Uint64ToUint256(100), pldtypes.Uint64ToUint256(110), pldtypes.Uint64ToUint256(120), pldtypes.Uint64ToUint256(130),
+		},
+		GasUsedRatio: []float64{0.5, 0, 0.9},
+		Reward: [][]*pldtypes.HexUint256{
+			{pldtypes.Uint64ToUint256(10)}, {pldtypes.Uint64ToUint256(0)}, {pldtypes.Uint64ToUint256(20)},
+		},
+	}, nil).Once()
+
+	// the empty block is excluded from the average priority fee, and the
+	// next block base fee is doubled by default
+	gpo, err := hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, gpo.GasPrice)
+	assert.Equal(t, big.NewInt(15), gpo.MaxPriorityFeePerGas.Int())
+	assert.Equal(t, big.NewInt(275), gpo.MaxFeePerGas.Int())
+
+	// should be cached
+	gpo, err = hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(275), gpo.MaxFeePerGas.Int())
+
+	// return error when the node does not support fee history
+	hgc.DeleteCache(ctx)
+	mEC.On("FeeHistory", ctx, 3, "latest", []float64{75}).Return(nil, fmt.Errorf("not supported")).Once()
+	_, err = hgc.GetGasPriceObject(ctx)
+	assert.Regexp(t, "not supported", err)
+
+	// return error when the node does not return a base fee
+	mEC.On("FeeHistory", ctx, 3, "latest", []float64{75}).Return(&ethclient.FeeHistoryResult{}, nil).Once()
+	_, err = hgc.GetGasPriceObject(ctx)
+	assert.Regexp(t, "PD011941", err)
+}
+
+func TestGasPriceClientFeeHistoryCaps(t *testing.T) {
+	ctx := context.Background()
+
+	gasPriceClient := NewGasPriceClient(ctx, &pldconf.PublicTxManagerConfig{
+		GasPrice: pldconf.GasPriceConfig{
+			EthFeeHistory: pldconf.EthFeeHistoryConfig{
+				Enabled:              confutil.P(true),
+				BaseFeeMultiplier:    confutil.P(1.5),
+				MaxPriorityFeePerGas: confutil.P("50"),
+				MaxFeePerGas:         confutil.P("0x28"), // 40
+			},
+		},
+	})
+	hgc := gasPriceClient.(*HybridGasPriceClient)
+	mEC := ethclientmocks.NewEthClient(t)
+	hgc.Init(ctx, mEC)
+
+	mEC.On("FeeHistory", ctx, 20, "latest", []float64{50}).Return(&ethclient.FeeHistoryResult{
+		BaseFeePerGas: []*pldtypes.HexUint256{pldtypes.Uint64ToUint256(10), pldtypes.Uint64ToUint256(20)},
+		GasUsedRatio:  []float64{1},
+		Reward:        [][]*pldtypes.HexUint256{{pldtypes.Uint64ToUint256(100)}},
+	}, nil).Once()
+
+	// priority fee capped at 50, max fee capped at 40, and then the priority fee cannot exceed the max fee
+	gpo, err := hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(40), gpo.MaxFeePerGas.Int())
+	assert.Equal(t, big.NewInt(40), gpo.MaxPriorityFeePerGas.Int())
+}
+
+func TestGasPriceCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+
+	gasPriceClient := NewGasPriceClient(ctx, &pldconf.PublicTxManagerConfig{
+		GasPrice: pldconf.GasPriceConfig{
+			Cache: pldconf.CacheConfig{
+				TTL: confutil.P("1ms"),
+			},
+		},
+	})
+	hgc := gasPriceClient.(*HybridGasPriceClient)
+	mEC := ethclientmocks.NewEthClient(t)
+	hgc.Init(ctx, mEC)
+
+	mEC.On("GasPrice", ctx).Return(pldtypes.Uint64ToUint256(1000), nil).Once()
+	gpo, err := hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), gpo.GasPrice.Int())
+
+	time.Sleep(5 * time.Millisecond)
+	mEC.On("GasPrice", ctx).Return(pldtypes.Uint64ToUint256(2000), nil).Once()
+	gpo, err = hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(2000), gpo.GasPrice.Int())
+}
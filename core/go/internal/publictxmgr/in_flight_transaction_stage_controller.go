@@ -552,38 +552,51 @@ func (it *inFlightTransactionStageController) calculateNewGasPrice(ctx context.C
 
 	if newGpo.GasPrice != nil && existingGpo.GasPrice != nil && existingGpo.GasPrice.Int().Cmp(newGpo.GasPrice.Int()) == 1 {
 		// existing gas price already above the new gas price, increase using percentage
-		newPercentage := big.NewInt(100)
-		newPercentage = newPercentage.Add(newPercentage, big.NewInt(int64(it.gasPriceIncreasePercent)))
-		newGasPrice := new(big.Int).Mul(existingGpo.GasPrice.Int(), newPercentage)
-		newGasPrice = newGasPrice.Div(newGasPrice, big.NewInt(100))
-		if it.gasPriceIncreaseMax != nil && newGasPrice.Cmp(it.gasPriceIncreaseMax) == 1 {
-			newGasPrice.Set(it.gasPriceIncreaseMax)
-		}
 		newGpo = &pldapi.PublicTxGasPricing{
-			GasPrice:             (*pldtypes.HexUint256)(newGasPrice),
+			GasPrice:             (*pldtypes.HexUint256)(increaseByPercentage(existingGpo.GasPrice.Int(), it.gasPriceIncreasePercent, it.gasPriceIncreaseMax)),
 			MaxFeePerGas:         existingGpo.MaxFeePerGas,         // copy over unchanged (although expected to be unset)
 			MaxPriorityFeePerGas: existingGpo.MaxPriorityFeePerGas, //   "
 		}
-	} else if newGpo.MaxFeePerGas != nil && existingGpo.MaxFeePerGas != nil && existingGpo.MaxFeePerGas.Int().Cmp(newGpo.MaxFeePerGas.Int()) == 1 {
-		// existing MaxFeePerGas already above the new MaxFeePerGas, increase using percentage
-		newPercentage := big.NewInt(100)
-
-		newPercentage = newPercentage.Add(newPercentage, big.NewInt(int64(it.gasPriceIncreasePercent)))
-		newMaxFeePerGas := new(big.Int).Mul(existingGpo.MaxFeePerGas.Int(), newPercentage)
-		newMaxFeePerGas = newMaxFeePerGas.Div(newMaxFeePerGas, big.NewInt(100))
-		if it.gasPriceIncreaseMax != nil && newMaxFeePerGas.Cmp(it.gasPriceIncreaseMax) == 1 {
-			newMaxFeePerGas.Set(it.gasPriceIncreaseMax)
+	} else if newGpo.MaxFeePerGas != nil && existingGpo.MaxFeePerGas != nil {
+		resultGpo := &pldapi.PublicTxGasPricing{
+			GasPrice:             newGpo.GasPrice,
+			MaxFeePerGas:         newGpo.MaxFeePerGas,
+			MaxPriorityFeePerGas: newGpo.MaxPriorityFeePerGas,
 		}
-		newGpo = &pldapi.PublicTxGasPricing{
-			GasPrice:             existingGpo.GasPrice, // copy over unchanged (although expected to be unset)
-			MaxFeePerGas:         (*pldtypes.HexUint256)(newMaxFeePerGas),
-			MaxPriorityFeePerGas: existingGpo.MaxPriorityFeePerGas,
+		if existingGpo.MaxFeePerGas.Int().Cmp(newGpo.MaxFeePerGas.Int()) == 1 {
+			// existing MaxFeePerGas already above the new MaxFeePerGas, increase using percentage
+			resultGpo = &pldapi.PublicTxGasPricing{
+				GasPrice:             existingGpo.GasPrice, // copy over unchanged (although expected to be unset)
+				MaxFeePerGas:         (*pldtypes.HexUint256)(increaseByPercentage(existingGpo.MaxFeePerGas.Int(), it.gasPriceIncreasePercent, it.gasPriceIncreaseMax)),
+				MaxPriorityFeePerGas: existingGpo.MaxPriorityFeePerGas,
+			}
+		}
+		if it.priorityFeeIncreasePercent > 0 && existingGpo.MaxPriorityFeePerGas != nil {
+			// nodes only accept a replacement EIP-1559 transaction if the priority fee is bumped too
+			bumpedPriorityFee := increaseByPercentage(existingGpo.MaxPriorityFeePerGas.Int(), it.priorityFeeIncreasePercent, it.priorityFeeIncreaseMax)
+			if resultGpo.MaxPriorityFeePerGas == nil || bumpedPriorityFee.Cmp(resultGpo.MaxPriorityFeePerGas.Int()) == 1 {
+				resultGpo.MaxPriorityFeePerGas = (*pldtypes.HexUint256)(bumpedPriorityFee)
+			}
 		}
+		if resultGpo.MaxPriorityFeePerGas != nil && resultGpo.MaxPriorityFeePerGas.Int().Cmp(resultGpo.MaxFeePerGas.Int()) == 1 {
+			// the priority fee can never exceed the max fee
+			resultGpo.MaxPriorityFeePerGas = resultGpo.MaxFeePerGas
+		}
+		newGpo = resultGpo
 	}
 
 	return newGpo
 }
 
+func increaseByPercentage(value *big.Int, percentage int, max *big.Int) *big.Int {
+	newValue := new(big.Int).Mul(value, big.NewInt(int64(100+percentage)))
+	newValue = newValue.Div(newValue, big.NewInt(100))
+	if max != nil && newValue.Cmp(max) == 1 {
+		newValue.Set(max)
+	}
+	return newValue
+}
+
 func calculateGasRequiredForTransaction(ctx context.Context, gpo *pldapi.PublicTxGasPricing, gasLimit uint64) (gasRequired *big.Int, err error) {
 	if gpo.GasPrice != nil {
 		log.L(ctx).Debugf("gas calculation using GasPrice (%+v)", gpo.GasPrice)
@@ -25,6 +25,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockStatusUpdater struct {
@@ -402,6 +403,80 @@ func TestProduceLatestInFlightStageContextRetrieveGasIncrementsEIP1559HigherExis
 
 }
 
+func TestProduceLatestInFlightStageContextRetrieveGasIncrementsEIP1559PriorityFee(t *testing.T) {
+	ctx, o, _, done := newTestOrchestrator(t)
+	defer done()
+	it, mTS := newInflightTransaction(o, 1)
+	it.testOnlyNoActionMode = true
+	it.testOnlyNoEventMode = true
+	it.gasPriceClient = NewTestFixedPriceGasPriceClientEIP1559(t)
+	mSU := &mockStatusUpdater{
+		updateSubStatus: func(ctx context.Context, imtx InMemoryTxStateReadOnly, subStatus BaseTxSubStatus, action BaseTxAction, info, err pldtypes.RawJSON, actionOccurred *pldtypes.Timestamp) error {
+			return nil
+		},
+	}
+	mTS.statusUpdater = mSU
+
+	// trigger retrieve gas price
+	assert.Nil(t, it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx))
+	tOut := it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{
+		AvailableToSpend:         nil,
+		PreviousNonceCostUnknown: true,
+	})
+	assert.Empty(t, *tOut)
+	rsc := it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx)
+	assert.Equal(t, InFlightTxStageRetrieveGasPrice, rsc.Stage)
+	currentGeneration := it.stateManager.GetCurrentGeneration(ctx).(*inFlightTransactionStateGeneration)
+
+	it.gasPriceIncreasePercent = 50     // increase 50 percent
+	it.priorityFeeIncreasePercent = 100 // double the priority fee
+	it.priorityFeeIncreaseMax = big.NewInt(30)
+	mTS.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{
+		GasPricing: &pldapi.PublicTxGasPricing{
+			MaxFeePerGas:         pldtypes.Uint64ToUint256(20),
+			MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(4),
+		},
+	})
+
+	// the retrieved max fee is higher, but the retrieved priority fee is not high enough for a replacement
+	currentGeneration.bufferedStageOutputs = make([]*StageOutput, 0)
+	it.stateManager.GetCurrentGeneration(ctx).AddGasPriceOutput(ctx, &pldapi.PublicTxGasPricing{
+		MaxFeePerGas:         pldtypes.Uint64ToUint256(25),
+		MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(5),
+	}, nil)
+	rsc.StageOutputsToBePersisted = nil
+	_ = it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{
+		AvailableToSpend:         nil,
+		PreviousNonceCostUnknown: true,
+	})
+	rsc = it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx)
+	require.NotNil(t, rsc.StageOutputsToBePersisted)
+	assert.Equal(t, big.NewInt(25), rsc.StageOutputsToBePersisted.TxUpdates.GasPricing.MaxFeePerGas.Int())
+	assert.Equal(t, big.NewInt(8), rsc.StageOutputsToBePersisted.TxUpdates.GasPricing.MaxPriorityFeePerGas.Int())
+
+	// the bumped priority fee is capped, and can never exceed the max fee
+	mTS.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{
+		GasPricing: &pldapi.PublicTxGasPricing{
+			MaxFeePerGas:         pldtypes.Uint64ToUint256(20),
+			MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(18),
+		},
+	})
+	currentGeneration.bufferedStageOutputs = make([]*StageOutput, 0)
+	it.stateManager.GetCurrentGeneration(ctx).AddGasPriceOutput(ctx, &pldapi.PublicTxGasPricing{
+		MaxFeePerGas:         pldtypes.Uint64ToUint256(10),
+		MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(1),
+	}, nil)
+	rsc.StageOutputsToBePersisted = nil
+	_ = it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{
+		AvailableToSpend:         nil,
+		PreviousNonceCostUnknown: true,
+	})
+	rsc = it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx)
+	require.NotNil(t, rsc.StageOutputsToBePersisted)
+	assert.Equal(t, big.NewInt(30), rsc.StageOutputsToBePersisted.TxUpdates.GasPricing.MaxFeePerGas.Int())
+	assert.Equal(t, big.NewInt(30), rsc.StageOutputsToBePersisted.TxUpdates.GasPricing.MaxPriorityFeePerGas.Int())
+}
+
 func TestProduceLatestInFlightStageContextRetrieveGasIncrementsEIP1559MismatchFormat(t *testing.T) {
 	ctx, o, _, done := newTestOrchestrator(t)
 	defer done()
@@ -34,6 +34,9 @@ type DBPublicTxn struct {
 	Value           *pldtypes.HexUint256   `gorm:"column:value"`
 	Data            pldtypes.HexBytes      `gorm:"column:data"`
 	Suspended       bool                   `gorm:"column:suspended"`                            // excluded from processing because it's suspended by user
+	Cancelled       bool                   `gorm:"column:cancelled"`                            // replaced by a zero value transfer to self at the user's request
+	NotBefore       *pldtypes.Timestamp    `gorm:"column:not_before"`                           // no nonce is assigned before this time
+	ExpiresAt       *pldtypes.Timestamp    `gorm:"column:expires_at"`                           // no nonce is assigned after this time
 	Completed       *DBPublicTxnCompletion `gorm:"foreignKey:pub_txn_id;references:pub_txn_id"` // excluded from processing because it's done
 	Submissions     []*DBPubTxnSubmission  `gorm:"-"`                                           // we do the aggregation, not GORM
 	// Binding is used only on queries by transaction (GORM doesn't seem to allow us to define a separate struct for this)
@@ -83,11 +86,6 @@ func (s *DBPubTxnSubmission) WriteKey() string {
 	return s.from
 }
 
-type bindingsMatchingSubmission struct {
-	DBPublicTxnBinding `gorm:"embedded"`
-	Submission         *DBPubTxnSubmission `gorm:"foreignKey:pub_txn_id;references:pub_txn_id;"`
-}
-
 type txFromOnly struct {
 	From pldtypes.EthAddress
 }
@@ -60,6 +60,17 @@ const (
 	UpdateDelete                   // Instructs that the transaction should be removed completely from persistence - generally only returned when TX status is TxStatusDeleteRequested
 )
 
+const (
+	// a value transfer between externally owned accounts always uses the intrinsic gas
+	valueTransferGasLimit = 21000
+	// geth (and most other clients) reject a replacement transaction priced less than 10% above the original
+	minReplacementIncreasePercent = 10
+)
+
+func isTransferToSelf(itx *blockindexer.IndexedTransactionNotify) bool {
+	return itx.From != nil && itx.To != nil && itx.From.Equals(itx.To)
+}
+
 type transactionUpdate struct {
 	newPtx  *DBPublicTxn
 	pubTXID uint64
@@ -115,8 +126,10 @@ type pubTxManager struct {
 	balanceManager BalanceManager
 
 	// orchestrator config
-	gasPriceIncreaseMax     *big.Int
-	gasPriceIncreasePercent int
+	gasPriceIncreaseMax        *big.Int
+	gasPriceIncreasePercent    int
+	priorityFeeIncreaseMax     *big.Int
+	priorityFeeIncreasePercent int
 
 	// gas limit config
 	gasEstimateFactor float64
@@ -158,6 +171,8 @@ func NewPublicTransactionManager(ctx context.Context, conf *pldconf.PublicTxMana
 		retry:                       retry.NewRetryIndefinite(&conf.Manager.Retry),
 		gasPriceIncreaseMax:         gasPriceIncreaseMax,
 		gasPriceIncreasePercent:     confutil.Int(conf.GasPrice.IncreasePercentage, *pldconf.PublicTxManagerDefaults.GasPrice.IncreasePercentage),
+		priorityFeeIncreaseMax:      confutil.BigIntOrNil(conf.GasPrice.PriorityFeeIncreaseMax),
+		priorityFeeIncreasePercent:  confutil.Int(conf.GasPrice.PriorityFeeIncreasePercentage, *pldconf.PublicTxManagerDefaults.GasPrice.PriorityFeeIncreasePercentage),
 		activityRecordCache:         cache.NewCache[uint64, *txActivityRecords](&conf.Manager.ActivityRecords.CacheConfig, &pldconf.PublicTxManagerDefaults.Manager.ActivityRecords.CacheConfig),
 		maxActivityRecordsPerTx:     confutil.Int(conf.Manager.ActivityRecords.RecordsPerTransaction, *pldconf.PublicTxManagerDefaults.Manager.ActivityRecords.RecordsPerTransaction),
 		gasEstimateFactor:           gasEstimateFactor,
@@ -260,6 +275,11 @@ func (ptm *pubTxManager) ValidateTransaction(ctx context.Context, dbTX persisten
 		return i18n.NewError(ctx, msgs.MsgInvalidTXMissingFromAddr)
 	}
 
+	// Reject up-front any transaction that the signing policy of the wallet will not allow
+	if err := ptm.keymgr.CheckPublicTransactionPolicy(ctx, dbTX, *txi.From, txi.To, txi.Value); err != nil {
+		return err
+	}
+
 	prepareStart := time.Now()
 	var txType InFlightTxOperation
 
@@ -308,6 +328,8 @@ func (ptm *pubTxManager) WriteNewTransactions(ctx context.Context, dbTX persiste
 			Value:           txi.Value,
 			Data:            txi.Data,
 			FixedGasPricing: pldtypes.JSONString(txi.PublicTxGasPricing),
+			NotBefore:       txi.NotBefore,
+			ExpiresAt:       txi.ExpiresAt,
 		}
 	}
 	// All the nonce processing to this point should have ensured we do not have a conflict on nonces.
@@ -404,6 +426,18 @@ func (ptm *pubTxManager) QueryPublicTxWithBindings(ctx context.Context, dbTX per
 	return ptm.queryPublicTxWithBinding(ctx, dbTX, nil, jq)
 }
 
+func (ptm *pubTxManager) CountPendingTransactions(ctx context.Context, dbTX persistence.DBTX, from pldtypes.EthAddress) (count int64, err error) {
+	err = dbTX.DB().
+		WithContext(ctx).
+		Model(&DBPublicTxn{}).
+		Joins("Completed").
+		Where(`"Completed"."tx_hash" IS NULL`).
+		Where(`"from" = ?`, from).
+		Count(&count).
+		Error
+	return count, err
+}
+
 // Component interface: query the associated public transactions, for a set of parent Paladin transactions
 // Can return the same public transaction multiple times, if bound to multiple private transactions.
 // The results are grouped, so the caller can be assured to have exactly one entry in the map (even if an empty array) per supplied TX ID
@@ -563,20 +597,126 @@ func (ptm *pubTxManager) getTransactionSubmissions(ctx context.Context, dbTX per
 	return ptxs, err
 }
 
+// getIncompleteTransactionByNonce is used by the user actions that identify a transaction by from+nonce,
+// all of which are only valid for a transaction that has not been confirmed on chain
+func (ptm *pubTxManager) getIncompleteTransactionByNonce(ctx context.Context, from pldtypes.EthAddress, nonce uint64) (*DBPublicTxn, error) {
+	ptxs := []*DBPublicTxn{}
+	err := ptm.p.DB().
+		WithContext(ctx).
+		Table("public_txns").
+		Joins("Completed").
+		Where(`"from" = ?`, from).
+		Where("nonce = ?", nonce).
+		Limit(1).
+		Find(&ptxs).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if len(ptxs) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgPublicTxNotFoundForNonce, from, nonce)
+	}
+	if ptxs[0].Completed != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgPublicTxAlreadyComplete, from, nonce)
+	}
+	return ptxs[0], nil
+}
+
 func (ptm *pubTxManager) SuspendTransaction(ctx context.Context, from pldtypes.EthAddress, nonce uint64) error {
-	if err := ptm.dispatchAction(ctx, from, nonce, ActionSuspend); err != nil {
+	ptx, err := ptm.getIncompleteTransactionByNonce(ctx, from, nonce)
+	if err != nil {
 		return err
 	}
+	if err = ptm.dispatchAction(ctx, from, nonce, ActionSuspend); err != nil {
+		return err
+	}
+	ptm.addActivityRecord(ptx.PublicTxnID, i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgPublicTxHistorySuspended), from, nonce))
 	return nil
 }
 
 func (ptm *pubTxManager) ResumeTransaction(ctx context.Context, from pldtypes.EthAddress, nonce uint64) error {
-	if err := ptm.dispatchAction(ctx, from, nonce, ActionResume); err != nil {
+	ptx, err := ptm.getIncompleteTransactionByNonce(ctx, from, nonce)
+	if err != nil {
+		return err
+	}
+	if err = ptm.dispatchAction(ctx, from, nonce, ActionResume); err != nil {
+		return err
+	}
+	ptm.addActivityRecord(ptx.PublicTxnID, i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgPublicTxHistoryResumed), from, nonce))
+	return nil
+}
+
+// CancelTransaction replaces the transaction at the nonce with a zero value transfer to self.
+// The replacement is submitted through the same path as an update, with fixed gas pricing bumped
+// above the last submission so that the node will accept it as a replacement in the mempool.
+// If the replacement is the one that gets mined, the confirmation is flagged as cancelled.
+func (ptm *pubTxManager) CancelTransaction(ctx context.Context, from pldtypes.EthAddress, nonce uint64) error {
+	ptx, err := ptm.getIncompleteTransactionByNonce(ctx, from, nonce)
+	if err != nil {
+		return err
+	}
+	submissions, err := ptm.getTransactionSubmissions(ctx, ptm.p.NOTX(), []uint64{ptx.PublicTxnID})
+	if err != nil {
+		return err
+	}
+
+	newPtx := &DBPublicTxn{
+		From:            from,
+		To:              &from,
+		Gas:             valueTransferGasLimit,
+		Value:           pldtypes.Uint64ToUint256(0),
+		Data:            pldtypes.HexBytes{},
+		FixedGasPricing: ptm.cancelGasPricing(ptx, submissions),
+		Cancelled:       true,
+	}
+
+	ptm.updateMux.Lock()
+	defer ptm.updateMux.Unlock()
+
+	err = ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return ptm.writeUpdatedTransaction(ctx, dbTX, ptx.PublicTxnID, from, newPtx)
+	})
+	if err != nil {
 		return err
 	}
+
+	ptm.dispatchUpdate(&transactionUpdate{
+		pubTXID: ptx.PublicTxnID,
+		from:    &from,
+		newPtx:  newPtx,
+	})
+	ptm.addActivityRecord(ptx.PublicTxnID, i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgPublicTxHistoryCancelRequested), from, nonce, pldtypes.JSONString(recoverGasPriceOptions(newPtx.FixedGasPricing))))
 	return nil
 }
 
+// The replacement must pay more than the last submission for the node to accept it. If nothing
+// has been submitted yet, the fixed gas pricing of the original (if any) is kept as-is, so a
+// transaction without fixed pricing continues to use the gas price oracle.
+func (ptm *pubTxManager) cancelGasPricing(ptx *DBPublicTxn, submissions []*DBPubTxnSubmission) pldtypes.RawJSON {
+	if len(submissions) == 0 {
+		return ptx.FixedGasPricing
+	}
+	// submissions are returned newest first
+	return pldtypes.JSONString(ptm.bumpGasPricingForReplacement(recoverGasPriceOptions(submissions[0].GasPricing)))
+}
+
+// bumpGasPricingForReplacement increases each of the fee fields that were set on the last submission,
+// applying at least the minimum bump that nodes require to accept a replacement transaction
+func (ptm *pubTxManager) bumpGasPricingForReplacement(gasPricing pldapi.PublicTxGasPricing) pldapi.PublicTxGasPricing {
+	gasPriceIncreasePercent := max(ptm.gasPriceIncreasePercent, minReplacementIncreasePercent)
+	priorityFeeIncreasePercent := max(ptm.priorityFeeIncreasePercent, minReplacementIncreasePercent)
+	if gasPricing.GasPrice != nil {
+		gasPricing.GasPrice = (*pldtypes.HexUint256)(increaseByPercentage(gasPricing.GasPrice.Int(), gasPriceIncreasePercent, nil))
+	}
+	if gasPricing.MaxFeePerGas != nil {
+		gasPricing.MaxFeePerGas = (*pldtypes.HexUint256)(increaseByPercentage(gasPricing.MaxFeePerGas.Int(), gasPriceIncreasePercent, nil))
+	}
+	if gasPricing.MaxPriorityFeePerGas != nil {
+		gasPricing.MaxPriorityFeePerGas = (*pldtypes.HexUint256)(increaseByPercentage(gasPricing.MaxPriorityFeePerGas.Int(), priorityFeeIncreasePercent, nil))
+	}
+	return gasPricing
+}
+
 func (ptm *pubTxManager) UpdateTransaction(ctx context.Context, id uuid.UUID, pubTXID uint64, from *pldtypes.EthAddress, tx *pldapi.TransactionInput, publicTxData []byte, txmgrDBUpdate func(dbTX persistence.DBTX) error) error {
 	ptxs := []*DBPublicTxn{}
 	err := ptm.p.DB().
@@ -752,40 +892,89 @@ func (ptm *pubTxManager) MatchUpdateConfirmedTransactions(ctx context.Context, d
 	for i, itx := range itxs {
 		txHashes[i] = itx.Hash
 	}
-	var lookups []*bindingsMatchingSubmission
+	var submissions []*DBPubTxnSubmission
 	err := dbTX.DB().
-		Table("public_txn_bindings").
-		Select(`"transaction"`, `"tx_type"`, `"Submission"."pub_txn_id"`, `"Submission"."tx_hash"`).
-		Joins("Submission").
-		Where(`"Submission"."tx_hash" IN (?)`, txHashes).
-		Find(&lookups).
+		Table("public_submissions").
+		Where("tx_hash IN (?)", txHashes).
+		Find(&submissions).
 		Error
 	if err != nil {
 		return nil, err
 	}
 
+	// Most transactions are bound to a Paladin transaction, but those the node submits for its own
+	// purposes (such as fueling) are not - we still need to complete those.
+	bindings := make(map[uint64]*DBPublicTxnBinding)
+	// A cancel request replaces the transaction, but the original might still be the one that
+	// gets mined - so we only report a cancellation if it was the transfer to self that confirmed
+	cancelledTxns := make(map[uint64]bool)
+	if len(submissions) > 0 {
+		pubTxnIDs := make([]uint64, len(submissions))
+		for i, sub := range submissions {
+			pubTxnIDs[i] = sub.PublicTxnID
+		}
+		var bindingRows []*DBPublicTxnBinding
+		err = dbTX.DB().
+			Table("public_txn_bindings").
+			Where("pub_txn_id IN (?)", pubTxnIDs).
+			Find(&bindingRows).
+			Error
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range bindingRows {
+			bindings[b.PublicTxnID] = b
+		}
+		var cancelledIDs []uint64
+		err = dbTX.DB().
+			Table("public_txns").
+			Where("pub_txn_id IN (?)", pubTxnIDs).
+			Where("cancelled IS TRUE").
+			Pluck("pub_txn_id", &cancelledIDs).
+			Error
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range cancelledIDs {
+			cancelledTxns[id] = true
+		}
+	}
+	cancelRecords := make(map[uint64]string)
+
 	// Correlate our results with the inputs to build - we guarantee to insert and return
 	// the results in the original order
-	results := make([]*components.PublicTxMatch, 0, len(lookups))
-	completions := make([]*DBPublicTxnCompletion, 0, len(lookups))
+	results := make([]*components.PublicTxMatch, 0, len(submissions))
+	completions := make([]*DBPublicTxnCompletion, 0, len(submissions))
+	unbound := make([]*blockindexer.IndexedTransactionNotify, 0)
 	for _, txi := range itxs {
-		for _, match := range lookups {
-			if txi.Hash.Equals(&match.Submission.TransactionHash) {
-				// matched results in the order of the inputs
-				results = append(results, &components.PublicTxMatch{
-					PaladinTXReference: components.PaladinTXReference{
-						TransactionID:   match.Transaction,
-						TransactionType: match.TransactionType,
-					},
-					IndexedTransactionNotify: txi,
-				})
+		for _, sub := range submissions {
+			if txi.Hash.Equals(&sub.TransactionHash) {
 				// completions to insert, in the order of the inputs
 				completions = append(completions, &DBPublicTxnCompletion{
-					PublicTxnID:     match.PublicTxnID,
+					PublicTxnID:     sub.PublicTxnID,
 					TransactionHash: txi.Hash,
 					Success:         txi.Result.V() == pldapi.TXResult_SUCCESS,
 					RevertData:      txi.RevertReason,
 				})
+				binding := bindings[sub.PublicTxnID]
+				if binding == nil {
+					unbound = append(unbound, txi)
+					break
+				}
+				// matched results in the order of the inputs
+				cancelled := cancelledTxns[sub.PublicTxnID] && isTransferToSelf(txi)
+				results = append(results, &components.PublicTxMatch{
+					PaladinTXReference: components.PaladinTXReference{
+						TransactionID:   binding.Transaction,
+						TransactionType: binding.TransactionType,
+					},
+					IndexedTransactionNotify: txi,
+					Cancelled:                cancelled,
+				})
+				if cancelled {
+					cancelRecords[sub.PublicTxnID] = i18n.ExpandWithCode(ctx,
+						i18n.MessageKey(msgs.MsgPublicTxHistoryCancelled), txi.From, txi.Nonce, txi.Hash, txi.BlockNumber)
+				}
 				break
 			}
 		}
@@ -807,6 +996,26 @@ func (ptm *pubTxManager) MatchUpdateConfirmedTransactions(ctx context.Context, d
 		ptm.thMetrics.IncCompletedTransactionsByN(uint64(len(completions)))
 	}
 
+	if len(cancelRecords) > 0 {
+		dbTX.AddPostCommit(func(ctx context.Context) {
+			for pubTxnID, msg := range cancelRecords {
+				ptm.addActivityRecord(pubTxnID, msg)
+			}
+		})
+	}
+
+	if len(unbound) > 0 {
+		// nobody else is going to call NotifyConfirmPersisted for these
+		dbTX.AddPostCommit(func(ctx context.Context) {
+			for _, txi := range unbound {
+				if err := ptm.dispatchAction(ctx, *txi.From, txi.Nonce, ActionCompleted); err != nil {
+					// only fails if the in-flight transaction is already exiting
+					log.L(ctx).Warnf("Failed to notify completion of transaction %s:%d: %s", txi.From, txi.Nonce, err)
+				}
+			}
+		})
+	}
+
 	return results, nil
 }
 
@@ -138,15 +138,17 @@ func (ptm *pubTxManager) poll(ctx context.Context) (polled int, total int) {
 			// (raw SQL as couldn't convince gORM to build this)
 			const dbQueryBase = `SELECT DISTINCT t."from" FROM "public_txns" AS t ` +
 				`LEFT JOIN "public_completions" AS c ON t."pub_txn_id" = c."pub_txn_id" ` +
-				`WHERE c."pub_txn_id" IS NULL AND "suspended" IS FALSE`
+				`WHERE c."pub_txn_id" IS NULL AND "suspended" IS FALSE ` +
+				`AND (t."nonce" IS NOT NULL OR ((t."not_before" IS NULL OR t."not_before" <= ?) AND (t."expires_at" IS NULL OR t."expires_at" > ?)))`
+			now := pldtypes.TimestampNow()
 
 			const dbQueryNothingInFlight = dbQueryBase + ` LIMIT ?`
 			if len(inFlightSigningAddresses) == 0 {
-				return true, ptm.p.DB().Raw(dbQueryNothingInFlight, spaces).Scan(&additionalNonInFlightSigners).Error
+				return true, ptm.p.DB().Raw(dbQueryNothingInFlight, now, now, spaces).Scan(&additionalNonInFlightSigners).Error
 			}
 
 			const dbQueryInFlight = dbQueryBase + ` AND t."from" NOT IN (?) LIMIT ?`
-			return true, ptm.p.DB().Raw(dbQueryInFlight, inFlightSigningAddresses, spaces).Scan(&additionalNonInFlightSigners).Error
+			return true, ptm.p.DB().Raw(dbQueryInFlight, now, now, inFlightSigningAddresses, spaces).Scan(&additionalNonInFlightSigners).Error
 		})
 		if err != nil {
 			log.L(ctx).Infof("Engine polling context cancelled while retrying")
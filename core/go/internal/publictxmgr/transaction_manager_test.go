@@ -156,6 +156,7 @@ func newTestPublicTxManager(t *testing.T, realDBAndSigner bool, extraSetup ...fu
 		mocks.db = mp.Mock
 		dbClose = func() {}
 		mocks.keyManager = componentsmocks.NewKeyManager(t)
+		mocks.keyManager.(*componentsmocks.KeyManager).On("CheckPublicTransactionPolicy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 		mocks.allComponents.On("Persistence").Return(p).Maybe()
 	}
 	mocks.allComponents.On("KeyManager").Return(mocks.keyManager).Maybe()
@@ -738,6 +739,283 @@ func TestUpdateTransactionRealDB(t *testing.T) {
 	require.Len(t, tx.Submissions, 2)
 }
 
+func TestCancelTransactionRealDB(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Manager.Interval = confutil.P("50ms")
+		conf.Orchestrator.Interval = confutil.P("50ms")
+		conf.Manager.OrchestratorIdleTimeout = confutil.P("1ms")
+		conf.GasPrice.FixedGasPrice = nil
+	})
+	defer done()
+
+	keyMapping, err := m.keyManager.ResolveKeyNewDatabaseTX(ctx, "signer1", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+	require.NoError(t, err)
+	resolvedKey := pldtypes.MustEthAddress(keyMapping.Verifier.Verifier)
+
+	// Mock a gas price
+	chainID, _ := rand.Int(rand.Reader, big.NewInt(100000000000000))
+	m.ethClient.On("ChainID").Return(chainID.Int64())
+	m.ethClient.On("GasPrice", mock.Anything).Return(pldtypes.MustParseHexUint256("1000000000000000"), nil)
+
+	txID := uuid.New()
+	pubTxSub := &components.PublicTxSubmission{
+		Bindings: []*components.PaladinTXReference{
+			{TransactionID: txID, TransactionType: pldapi.TransactionTypePublic.Enum()},
+		},
+		PublicTxInput: pldapi.PublicTxInput{
+			From: resolvedKey,
+			To:   pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas: confutil.P(pldtypes.HexUint64(1223451)),
+			},
+		},
+	}
+
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).Return(confutil.P(pldtypes.HexUint64(1122334455)), nil)
+
+	// the original is stuck, but the replacement transfer to self goes through
+	var originalGasPrice *big.Int
+	confirmations := make(chan *blockindexer.IndexedTransactionNotify, 1)
+	srtx := m.ethClient.On("SendRawTransaction", mock.Anything, mock.Anything)
+	srtx.Run(func(args mock.Arguments) {
+		signedMessage := args[1].(pldtypes.HexBytes)
+
+		_, ethTx, err := ethsigner.RecoverRawTransaction(ctx, ethtypes.HexBytes0xPrefix(signedMessage), m.ethClient.ChainID())
+		require.NoError(t, err)
+
+		if ethTx.To != nil && resolvedKey.Equals((*pldtypes.EthAddress)(ethTx.To)) {
+			assert.Equal(t, int64(valueTransferGasLimit), ethTx.GasLimit.Int64())
+			assert.Zero(t, ethTx.Value.BigInt().Sign())
+			assert.Empty(t, ethTx.Data)
+			assert.Equal(t, increaseByPercentage(originalGasPrice, minReplacementIncreasePercent, nil).String(), ethTx.GasPrice.BigInt().String())
+			txHash := calculateTransactionHash(signedMessage)
+			confirmation := &blockindexer.IndexedTransactionNotify{
+				IndexedTransaction: pldapi.IndexedTransaction{
+					Hash:             *txHash,
+					BlockNumber:      11223344,
+					TransactionIndex: 10,
+					From:             resolvedKey,
+					To:               (*pldtypes.EthAddress)(ethTx.To),
+					Nonce:            ethTx.Nonce.Uint64(),
+					Result:           pldapi.TXResult_SUCCESS.Enum(),
+				},
+			}
+			select {
+			case confirmations <- confirmation:
+			default:
+			}
+			srtx.Return(&confirmation.Hash, nil)
+		} else {
+			originalGasPrice = ethTx.GasPrice.BigInt()
+			srtx.Return(nil, fmt.Errorf("pop"))
+		}
+	})
+
+	pubTx, err := ptm.SingleTransactionSubmit(ctx, pubTxSub)
+	require.NoError(t, err)
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	// Wait for the original to be submitted at least once, so the cancel has to bump the fee
+	for {
+		<-ticker.C
+		if t.Failed() {
+			panic("test failed")
+		}
+		submissions, err := ptm.getTransactionSubmissions(ctx, ptm.p.NOTX(), []uint64{*pubTx.LocalID})
+		require.NoError(t, err)
+		if len(submissions) > 0 {
+			break
+		}
+	}
+	o := ptm.getOrchestratorForAddress(*resolvedKey)
+	require.NotNil(t, o)
+	nonce := o.getFirstInFlight().stateManager.GetNonce()
+
+	// unknown nonce
+	err = ptm.CancelTransaction(ctx, *resolvedKey, nonce+1)
+	assert.Regexp(t, "PD011942", err)
+
+	err = ptm.CancelTransaction(ctx, *resolvedKey, nonce)
+	require.NoError(t, err)
+
+	var confirmation *blockindexer.IndexedTransactionNotify
+	for confirmation == nil {
+		select {
+		case confirmation = <-confirmations:
+		case <-ticker.C:
+			if t.Failed() {
+				return
+			}
+		}
+	}
+
+	var matches []*components.PublicTxMatch
+	err = ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		matches, err = ptm.MatchUpdateConfirmedTransactions(ctx, dbTX, []*blockindexer.IndexedTransactionNotify{confirmation})
+		return err
+	})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.True(t, matches[0].Cancelled)
+	assert.Equal(t, txID, matches[0].TransactionID)
+	ptm.NotifyConfirmPersisted(ctx, matches)
+
+	activity := ptm.getActivityRecords(*pubTx.LocalID)
+	require.GreaterOrEqual(t, len(activity), 2)
+	assert.Regexp(t, "PD011947", activity[0].Message)
+
+	// now it is complete, no further actions are possible
+	err = ptm.CancelTransaction(ctx, *resolvedKey, nonce)
+	assert.Regexp(t, "PD011943", err)
+	err = ptm.SuspendTransaction(ctx, *resolvedKey, nonce)
+	assert.Regexp(t, "PD011943", err)
+}
+
+func TestBumpGasPricingForReplacement(t *testing.T) {
+	_, ptm, _, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	ptm.gasPriceIncreasePercent = 20
+	ptm.priorityFeeIncreasePercent = 0
+
+	gasPricing := ptm.bumpGasPricingForReplacement(pldapi.PublicTxGasPricing{
+		MaxFeePerGas:         pldtypes.Uint64ToUint256(1000),
+		MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(100),
+	})
+	assert.Nil(t, gasPricing.GasPrice)
+	assert.Equal(t, uint64(1200), gasPricing.MaxFeePerGas.Int().Uint64())
+	// the priority fee still gets the minimum bump required for a replacement
+	assert.Equal(t, uint64(110), gasPricing.MaxPriorityFeePerGas.Int().Uint64())
+
+	gasPricing = ptm.bumpGasPricingForReplacement(pldapi.PublicTxGasPricing{
+		GasPrice: pldtypes.Uint64ToUint256(1000),
+	})
+	assert.Equal(t, uint64(1200), gasPricing.GasPrice.Int().Uint64())
+	assert.Nil(t, gasPricing.MaxFeePerGas)
+}
+
+func TestCancelGasPricing(t *testing.T) {
+	_, ptm, _, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	// nothing submitted, so the original pricing (or lack of it) is kept
+	assert.Nil(t, ptm.cancelGasPricing(&DBPublicTxn{}, nil))
+	fixed := pldtypes.JSONString(pldapi.PublicTxGasPricing{GasPrice: pldtypes.Uint64ToUint256(1000)})
+	assert.Equal(t, fixed, ptm.cancelGasPricing(&DBPublicTxn{FixedGasPricing: fixed}, nil))
+
+	// the last submission is bumped
+	gasPricing := recoverGasPriceOptions(ptm.cancelGasPricing(&DBPublicTxn{FixedGasPricing: fixed}, []*DBPubTxnSubmission{
+		{GasPricing: pldtypes.JSONString(pldapi.PublicTxGasPricing{GasPrice: pldtypes.Uint64ToUint256(2000)})},
+		{GasPricing: fixed},
+	}))
+	assert.Equal(t, increaseByPercentage(big.NewInt(2000), max(ptm.gasPriceIncreasePercent, minReplacementIncreasePercent), nil).String(), gasPricing.GasPrice.Int().String())
+}
+
+func TestCancelTransactionNotFound(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{}))
+		mocks.db.ExpectQuery("SELECT.*public_txns").WillReturnError(fmt.Errorf("pop"))
+	})
+	defer done()
+
+	err := ptm.CancelTransaction(ctx, *pldtypes.RandAddress(), 12345)
+	assert.Regexp(t, "PD011942", err)
+
+	err = ptm.ResumeTransaction(ctx, *pldtypes.RandAddress(), 12345)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestMatchUpdateConfirmedUnboundTransactionRealDB(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	// a transaction the node submits for itself, with no Paladin transaction bound to it
+	from := pldtypes.RandAddress()
+	pubTx, err := ptm.SingleTransactionSubmit(ctx, &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: from,
+			To:   pldtypes.RandAddress(),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas:   confutil.P(pldtypes.HexUint64(valueTransferGasLimit)),
+				Value: pldtypes.Uint64ToUint256(100),
+			},
+		},
+	})
+	require.NoError(t, err)
+	txHash := pldtypes.RandBytes32()
+	err = ptm.p.DB().Create(&DBPubTxnSubmission{
+		PublicTxnID:     *pubTx.LocalID,
+		Created:         pldtypes.TimestampNow(),
+		TransactionHash: txHash,
+		GasPricing:      pldtypes.RawJSON(`{}`),
+	}).Error
+	require.NoError(t, err)
+
+	// an orchestrator has the transaction in flight, but it is already exiting so cannot be notified
+	oc := NewOrchestrator(ptm, *from, ptm.conf)
+	inflight, inflightState := newInflightTransaction(oc, 0)
+	inflightState.InMemoryTxStateManager.(*inMemoryTxState).mtx.InFlightStatus = InFlightStatusConfirmReceived
+	oc.inFlightTxs = []*inFlightTransactionStageController{inflight}
+	ptm.inFlightOrchestrators = map[pldtypes.EthAddress]*orchestrator{*from: oc}
+
+	var matches []*components.PublicTxMatch
+	err = ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		matches, err = ptm.MatchUpdateConfirmedTransactions(ctx, dbTX, []*blockindexer.IndexedTransactionNotify{
+			{
+				IndexedTransaction: pldapi.IndexedTransaction{
+					Hash:   txHash,
+					From:   from,
+					Nonce:  0,
+					Result: pldapi.TXResult_SUCCESS.Enum(),
+				},
+			},
+			{
+				IndexedTransaction: pldapi.IndexedTransaction{
+					Hash:   pldtypes.RandBytes32(),
+					From:   pldtypes.RandAddress(),
+					Result: pldapi.TXResult_SUCCESS.Enum(),
+				},
+			},
+		})
+		return err
+	})
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+
+	completed, err := ptm.CheckTransactionCompleted(ctx, *pubTx.LocalID)
+	require.NoError(t, err)
+	assert.True(t, completed)
+}
+
+func TestMatchUpdateConfirmedTransactionsQueryFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	txHash := pldtypes.RandBytes32()
+	itxs := []*blockindexer.IndexedTransactionNotify{
+		{IndexedTransaction: pldapi.IndexedTransaction{Hash: txHash}},
+	}
+
+	m.db.ExpectQuery("SELECT.*public_submissions").WillReturnError(fmt.Errorf("pop"))
+	_, err := ptm.MatchUpdateConfirmedTransactions(ctx, ptm.p.NOTX(), itxs)
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*public_submissions").WillReturnRows(sqlmock.NewRows([]string{"pub_txn_id", "tx_hash"}).AddRow(12345, txHash.String()))
+	m.db.ExpectQuery("SELECT.*public_txn_bindings").WillReturnError(fmt.Errorf("pop"))
+	_, err = ptm.MatchUpdateConfirmedTransactions(ctx, ptm.p.NOTX(), itxs)
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*public_submissions").WillReturnRows(sqlmock.NewRows([]string{"pub_txn_id", "tx_hash"}).AddRow(12345, txHash.String()))
+	m.db.ExpectQuery("SELECT.*public_txn_bindings").WillReturnRows(sqlmock.NewRows([]string{}))
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnError(fmt.Errorf("pop"))
+	_, err = ptm.MatchUpdateConfirmedTransactions(ctx, ptm.p.NOTX(), itxs)
+	assert.Regexp(t, "pop", err)
+}
+
 func TestGasEstimateFactor(t *testing.T) {
 	ctx := context.Background()
 	_, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
@@ -758,3 +1036,54 @@ func TestGasEstimateFactor(t *testing.T) {
 	require.NoError(t, ptm.ValidateTransaction(ctx, ptm.p.NOTX(), tx))
 	assert.Equal(t, pldtypes.MustParseHexUint64("0xc5f0"), *tx.Gas)
 }
+
+func TestValidateTransactionSigningPolicyViolation(t *testing.T) {
+	ctx := context.Background()
+	to := pldtypes.RandAddress()
+	_, ptm, _, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mkm := mocks.keyManager.(*componentsmocks.KeyManager)
+		mkm.ExpectedCalls = nil
+		mkm.On("CheckPublicTransactionPolicy", mock.Anything, mock.Anything, mock.Anything, to, mock.Anything).
+			Return(&components.SigningPolicyError{Wallet: "wallet1", Cause: fmt.Errorf("PD010525: not allowed")})
+	})
+	defer done()
+
+	// No gas estimation is attempted for a transaction the policy rejects
+	err := ptm.ValidateTransaction(ctx, ptm.p.NOTX(), &components.PublicTxSubmission{
+		PublicTxInput: pldapi.PublicTxInput{
+			From: pldtypes.RandAddress(),
+			To:   to,
+		},
+	})
+	assert.Regexp(t, "PD010525", err)
+	assert.True(t, components.IsSigningPolicyError(err))
+}
+
+func TestCountPendingTransactionsRealDB(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true)
+	defer done()
+
+	from := *pldtypes.RandAddress()
+	txs := []*DBPublicTxn{
+		{From: from, Gas: 21000},
+		{From: from, Gas: 21000, Suspended: true},
+		{From: from, Gas: 21000},
+		{From: *pldtypes.RandAddress(), Gas: 21000},
+	}
+	err := ptm.p.DB().Omit("Completed", "Binding").Create(txs).Error
+	require.NoError(t, err)
+	err = ptm.p.DB().Create(&DBPublicTxnCompletion{
+		PublicTxnID:     txs[2].PublicTxnID,
+		TransactionHash: pldtypes.RandBytes32(),
+		Success:         true,
+	}).Error
+	require.NoError(t, err)
+
+	count, err := ptm.CountPendingTransactions(ctx, ptm.p.NOTX(), from)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	count, err = ptm.CountPendingTransactions(ctx, ptm.p.NOTX(), *pldtypes.RandAddress())
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
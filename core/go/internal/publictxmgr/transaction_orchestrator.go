@@ -23,6 +23,7 @@ import (
 
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 
@@ -277,7 +278,9 @@ func (oc *orchestrator) initNextNonceFromDB(ctx context.Context) error {
 	return nil
 }
 
-func (oc *orchestrator) allocateNonces(ctx context.Context, txns []*DBPublicTxn) error {
+// Returns the transactions to process, which excludes any that passed their expiry deadline before we
+// assigned a nonce. The transaction manager fails those, holding the same lock to check they have no nonce.
+func (oc *orchestrator) allocateNonces(ctx context.Context, txns []*DBPublicTxn) ([]*DBPublicTxn, error) {
 
 	// Some of the the transactions might have nonces already
 	toAlloc := make([]*DBPublicTxn, 0, len(txns))
@@ -288,7 +291,7 @@ func (oc *orchestrator) allocateNonces(ctx context.Context, txns []*DBPublicTxn)
 	}
 	if len(toAlloc) == 0 {
 		// Nothing to do
-		return nil
+		return txns, nil
 	}
 
 	// We need to ensure we have the next nonce to allocate
@@ -296,7 +299,7 @@ func (oc *orchestrator) allocateNonces(ctx context.Context, txns []*DBPublicTxn)
 		log.L(ctx).Debugf("no cached nonce, or nonce expired for %s (cached=%v)", oc.signingAddress, oc.lastNonceAlloc)
 		txCount, err := oc.ethClient.GetTransactionCount(ctx, oc.signingAddress)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		// See if we have nonces in our DB that are ahead of the mempool.
 		if oc.nextNonce != nil && *oc.nextNonce >= txCount.Uint64() {
@@ -308,16 +311,52 @@ func (oc *orchestrator) allocateNonces(ctx context.Context, txns []*DBPublicTxn)
 		}
 	}
 
-	// Set up the list of nonces we'll allocated, but until it's in the DB we do NOT update the oc.nextNonce beyond the first in the list
-	newNextNonce := *oc.nextNonce
-	newNonces := make([]uint64, len(toAlloc))
-	for i := range newNonces {
-		newNonces[i] = newNextNonce
-		newNextNonce++
-	}
-
-	// Run the DB TXN using a VALUES temp table to update multiple rows in a single operation
+	var newNextNonce uint64
+	var newNonces []uint64
+	expired := make(map[uint64]bool)
 	err := oc.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		if err := oc.p.TakeNamedLock(ctx, dbTX, components.PublicTxNonceLock(oc.signingAddress)); err != nil {
+			return err
+		}
+		pubTxnIDs := make([]uint64, len(toAlloc))
+		for i, tx := range toAlloc {
+			pubTxnIDs[i] = tx.PublicTxnID
+		}
+		var expiredIDs []uint64
+		err := dbTX.DB().WithContext(ctx).
+			Table("public_txns").
+			Where(`"pub_txn_id" IN ?`, pubTxnIDs).
+			Where(`"expires_at" <= ?`, pldtypes.TimestampNow()).
+			Pluck("pub_txn_id", &expiredIDs).
+			Error
+		if err != nil {
+			return err
+		}
+		stillValid := make([]*DBPublicTxn, 0, len(toAlloc))
+		for _, id := range expiredIDs {
+			expired[id] = true
+		}
+		for _, tx := range toAlloc {
+			if expired[tx.PublicTxnID] {
+				log.L(ctx).Infof("Not assigning a nonce to expired transaction %s (pubTxnId=%d)", oc.signingAddress, tx.PublicTxnID)
+			} else {
+				stillValid = append(stillValid, tx)
+			}
+		}
+		toAlloc = stillValid
+		if len(toAlloc) == 0 {
+			return nil
+		}
+
+		// Set up the list of nonces we'll allocated, but until it's in the DB we do NOT update the oc.nextNonce beyond the first in the list
+		newNextNonce = *oc.nextNonce
+		newNonces = make([]uint64, len(toAlloc))
+		for i := range newNonces {
+			newNonces[i] = newNextNonce
+			newNextNonce++
+		}
+
+		// Update using a VALUES temp table to update multiple rows in a single operation
 		sqlQuery := `WITH nonce_updates ("pub_txn_id", "nonce") AS ( VALUES `
 		values := make([]any, 0, len(toAlloc)*2)
 		for i, tx := range toAlloc {
@@ -334,7 +373,7 @@ func (oc *orchestrator) allocateNonces(ctx context.Context, txns []*DBPublicTxn)
 		return dbTX.DB().WithContext(ctx).Exec(sqlQuery, values...).Error
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Update the txns themselves, and our nextNonce
@@ -342,10 +381,18 @@ func (oc *orchestrator) allocateNonces(ctx context.Context, txns []*DBPublicTxn)
 		nonce := newNonces[i]
 		tx.Nonce = &nonce
 	}
-	oc.lastNonceAlloc = time.Now()
-	oc.nextNonce = &newNextNonce
+	if len(toAlloc) > 0 {
+		oc.lastNonceAlloc = time.Now()
+		oc.nextNonce = &newNextNonce
+	}
 
-	return nil
+	ready := make([]*DBPublicTxn, 0, len(txns))
+	for _, tx := range txns {
+		if !expired[tx.PublicTxnID] {
+			ready = append(ready, tx)
+		}
+	}
+	return ready, nil
 }
 
 func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total int) {
@@ -396,6 +443,7 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 		// We retry the get from persistence indefinitely (until the context cancels)
 		var additional []*DBPublicTxn
 		err := oc.retry.Do(ctx, func(attempt int) (retry bool, err error) {
+			now := pldtypes.TimestampNow()
 			q := oc.p.DB().
 				WithContext(ctx).
 				Table("public_txns").
@@ -403,6 +451,8 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 				Where(`"Completed"."tx_hash" IS NULL`).
 				Where("suspended IS FALSE").
 				Where(`"from" = ?`, oc.signingAddress).
+				// a nonce is only assigned to a transaction within its scheduled window
+				Where(`("public_txns"."nonce" IS NOT NULL OR (("not_before" IS NULL OR "not_before" <= ?) AND ("expires_at" IS NULL OR "expires_at" > ?)))`, now, now).
 				Order(`"public_txns"."pub_txn_id"`).
 				Limit(spaces)
 			if len(oc.inFlightTxs) > 0 {
@@ -429,7 +479,11 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 		// of these transactions. Otherwise we might re-order transactions compared to their DB commit order
 		// (which is unacceptable for strict TX ordering).
 		if err := oc.retry.Do(ctx, func(attempt int) (retryable bool, err error) {
-			return true, oc.allocateNonces(ctx, additional)
+			ready, err := oc.allocateNonces(ctx, additional)
+			if err == nil {
+				additional = ready
+			}
+			return true, err
 		}); err != nil {
 			log.L(ctx).Warnf("Orchestrator context cancelled while allocating nonce: %s", err)
 			return
@@ -536,6 +590,17 @@ func (oc *orchestrator) ProcessInFlightTransactions(ctx context.Context, its []*
 		}
 	}
 
+	if !skipBalanceCheck && oc.balanceManager.IsAutoFuelingEnabled(ctx) {
+		// top up the signing address from the fueling source if the in-flight transactions have taken it below threshold
+		fuelingPending, err := oc.balanceManager.TopUpAccount(ctx, addressAccount)
+		if err != nil {
+			log.L(ctx).Errorf("Failed to fuel signing address %s: %s", oc.signingAddress, err)
+		} else if fuelingPending && addressAccount.GetAvailableToSpend(ctx).Sign() == -1 {
+			// transactions for this address cannot proceed until the fuel arrives
+			waitingForBalance = true
+		}
+	}
+
 	log.L(ctx).Debugf("%s ProcessInFlightTransaction exit for signing address: %s", now.String(), oc.signingAddress)
 	log.L(ctx).Debugf("Orchestrator process loop took %s", time.Since(processStart))
 	return waitingForBalance, nil
@@ -18,12 +18,14 @@ package publictxmgr
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
 
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -198,3 +200,162 @@ func TestOrchestratorWaitingForBalance(t *testing.T) {
 	o.Stop()
 	<-oDone
 }
+
+func TestOrchestratorWaitingForFueling(t *testing.T) {
+	ctx, o, m, done := newTestOrchestrator(t, func(m *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.GasPrice.FixedGasPrice = 1
+		conf.BalanceManager.AutoFueling = pldconf.AutoFuelingConfig{
+			Source:        confutil.P("fuel.source"),
+			TargetBalance: confutil.P("1000000"),
+		}
+	})
+	defer done()
+
+	mockIT, txState := newInflightTransaction(o, 1, func(tx *DBPublicTxn) {
+		tx.Gas = 100
+	})
+	txState.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{
+		GasPricing: &pldapi.PublicTxGasPricing{
+			GasPrice: pldtypes.Int64ToInt256(1000),
+		},
+	})
+
+	bm := o.balanceManager.(*BalanceManagerWithInMemoryTracking)
+	bm.fuelingSourceAddress = pldtypes.RandAddress()
+	bm.trackedFuelingTxs[o.signingAddress] = &fuelingTransaction{pubTxnID: 12345, value: big.NewInt(1000000)}
+
+	m.ethClient.On("GetBalance", mock.Anything, o.signingAddress, "latest").Return(pldtypes.Uint64ToUint256(0), nil)
+	// fueling transaction is not yet complete
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{}))
+	// then we fail checking it
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnError(fmt.Errorf("pop"))
+
+	waitingForBalance, err := o.ProcessInFlightTransactions(ctx, []*inFlightTransactionStageController{mockIT})
+	require.NoError(t, err)
+	assert.True(t, waitingForBalance)
+
+	// a fueling error is not fatal to the orchestrator
+	bm.NotifyAddressBalanceChanged(ctx, o.signingAddress)
+	_, err = o.ProcessInFlightTransactions(ctx, []*inFlightTransactionStageController{mockIT})
+	require.NoError(t, err)
+	require.NoError(t, m.db.ExpectationsWereMet())
+}
+
+func TestOrchestratorPollSkipsTransactionsOutsideScheduleRealDB(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	from := pldtypes.RandAddress()
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).Return(confutil.P(pldtypes.HexUint64(100)), nil)
+	m.ethClient.On("GetBalance", mock.Anything, mock.Anything, "latest").Return(pldtypes.Uint64ToUint256(0), nil).Maybe()
+
+	now := time.Now()
+	submit := func(notBefore, expiresAt *time.Time) *pldapi.PublicTx {
+		tx := &components.PublicTxSubmission{
+			PublicTxInput: pldapi.PublicTxInput{
+				From: from,
+				To:   pldtypes.RandAddress(),
+				PublicTxOptions: pldapi.PublicTxOptions{
+					Gas: confutil.P(pldtypes.HexUint64(valueTransferGasLimit)),
+				},
+			},
+		}
+		if notBefore != nil {
+			tx.NotBefore = confutil.P(pldtypes.Timestamp(notBefore.UnixNano()))
+		}
+		if expiresAt != nil {
+			tx.ExpiresAt = confutil.P(pldtypes.Timestamp(expiresAt.UnixNano()))
+		}
+		pubTx, err := ptm.SingleTransactionSubmit(ctx, tx)
+		require.NoError(t, err)
+		return pubTx
+	}
+	held := submit(confutil.P(now.Add(1*time.Hour)), nil)
+	expired := submit(nil, confutil.P(now.Add(-1*time.Second)))
+	ready := submit(confutil.P(now.Add(-1*time.Second)), confutil.P(now.Add(1*time.Hour)))
+
+	o := NewOrchestrator(ptm, *from, ptm.conf)
+	polled, _ := o.pollAndProcess(ctx)
+	assert.Equal(t, 1, polled)
+	assert.Equal(t, *ready.LocalID, o.inFlightTxs[0].stateManager.GetPubTxnID())
+
+	// only the transaction in its window is assigned a nonce
+	var dbTxns []*DBPublicTxn
+	err := ptm.p.DB().Where(`"from" = ?`, from).Order("pub_txn_id").Find(&dbTxns).Error
+	require.NoError(t, err)
+	require.Len(t, dbTxns, 3)
+	assert.Equal(t, *held.LocalID, dbTxns[0].PublicTxnID)
+	assert.Nil(t, dbTxns[0].Nonce)
+	assert.Equal(t, *expired.LocalID, dbTxns[1].PublicTxnID)
+	assert.Nil(t, dbTxns[1].Nonce)
+	assert.Equal(t, uint64(100), *dbTxns[2].Nonce)
+}
+
+func TestOrchestratorAllocateNoncesSkipsExpiredRealDB(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	from := pldtypes.RandAddress()
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).Return(confutil.P(pldtypes.HexUint64(100)), nil)
+
+	submit := func(expiresAt time.Time) {
+		_, err := ptm.SingleTransactionSubmit(ctx, &components.PublicTxSubmission{
+			PublicTxInput: pldapi.PublicTxInput{
+				From: from,
+				To:   pldtypes.RandAddress(),
+				PublicTxOptions: pldapi.PublicTxOptions{
+					Gas: confutil.P(pldtypes.HexUint64(valueTransferGasLimit)),
+				},
+			},
+			ExpiresAt: confutil.P(pldtypes.Timestamp(expiresAt.UnixNano())),
+		})
+		require.NoError(t, err)
+	}
+	// the first two expire after they were polled, but before a nonce is assigned
+	now := time.Now()
+	submit(now.Add(-1 * time.Second))
+	submit(now.Add(1 * time.Hour))
+	submit(now.Add(-1 * time.Second))
+	var polled []*DBPublicTxn
+	err := ptm.p.DB().Where(`"from" = ?`, from).Order("pub_txn_id").Find(&polled).Error
+	require.NoError(t, err)
+	require.Len(t, polled, 3)
+
+	o := NewOrchestrator(ptm, *from, ptm.conf)
+	ready, err := o.allocateNonces(ctx, polled)
+	require.NoError(t, err)
+	require.Len(t, ready, 1)
+	assert.Equal(t, polled[1].PublicTxnID, ready[0].PublicTxnID)
+	assert.Equal(t, uint64(100), *ready[0].Nonce)
+	assert.Equal(t, uint64(101), *o.nextNonce)
+
+	// nothing to assign if they are all expired
+	ready, err = o.allocateNonces(ctx, []*DBPublicTxn{polled[0], polled[2]})
+	require.NoError(t, err)
+	assert.Empty(t, ready)
+	assert.Equal(t, uint64(101), *o.nextNonce)
+
+	var dbTxns []*DBPublicTxn
+	err = ptm.p.DB().Where(`"from" = ?`, from).Order("pub_txn_id").Find(&dbTxns).Error
+	require.NoError(t, err)
+	assert.Nil(t, dbTxns[0].Nonce)
+	assert.Equal(t, uint64(100), *dbTxns[1].Nonce)
+	assert.Nil(t, dbTxns[2].Nonce)
+}
+
+func TestOrchestratorAllocateNoncesExpiryQueryFail(t *testing.T) {
+	ctx, o, m, done := newTestOrchestrator(t)
+	defer done()
+
+	m.ethClient.On("GetTransactionCount", mock.Anything, mock.Anything).Return(confutil.P(pldtypes.HexUint64(100)), nil)
+	m.db.ExpectBegin()
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnError(fmt.Errorf("pop"))
+	m.db.ExpectRollback()
+
+	_, err := o.allocateNonces(ctx, []*DBPublicTxn{{PublicTxnID: 1}})
+	assert.Regexp(t, "pop", err)
+}
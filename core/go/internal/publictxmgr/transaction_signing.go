@@ -40,13 +40,24 @@ func (it *inFlightTransactionStageController) signTx(ctx context.Context, from p
 		it.thMetrics.RecordOperationMetrics(ctx, string(InFlightTxOperationSign), string(GenericStatusFail), time.Since(signStart).Seconds())
 		return nil, nil, err
 	}
+	// The policy of the wallet is checked again here as well as on submission, as the transaction might have
+	// been submitted before the policy was applied, and signing only sees the hash of the transaction
+	var value *pldtypes.HexUint256
+	if ethTx.Value != nil {
+		value = (*pldtypes.HexUint256)(ethTx.Value.BigInt())
+	}
+	err = it.keymgr.CheckPublicTransactionPolicy(ctx, it.pubTxManager.p.NOTX(), from, (*pldtypes.EthAddress)(ethTx.To), value)
+	if err != nil {
+		it.thMetrics.RecordOperationMetrics(ctx, string(InFlightTxOperationSign), string(GenericStatusFail), time.Since(signStart).Seconds())
+		return nil, nil, err
+	}
 	// Sign
 	sigPayload := ethTx.SignaturePayloadEIP1559(it.ethClient.ChainID())
 	sigPayloadHash := sha3.NewLegacyKeccak256()
 	_, err = sigPayloadHash.Write(sigPayload.Bytes())
 	var signatureRSV []byte
 	if err == nil {
-		signatureRSV, err = it.keymgr.Sign(ctx, resolvedKey, signpayloads.OPAQUE_TO_RSV, pldtypes.HexBytes(sigPayloadHash.Sum(nil)))
+		signatureRSV, err = it.keymgr.Sign(ctx, it.pubTxManager.p.NOTX(), resolvedKey, signpayloads.OPAQUE_TO_RSV, pldtypes.HexBytes(sigPayloadHash.Sum(nil)))
 	}
 	var sig *secp256k1.SignatureData
 	if err == nil {
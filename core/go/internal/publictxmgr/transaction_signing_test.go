@@ -21,6 +21,7 @@ import (
 
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/mocks/componentsmocks"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -53,7 +54,7 @@ func TestInFlightTxSignFail(t *testing.T) {
 	mockKeyManager := m.keyManager.(*componentsmocks.KeyManager)
 	mockKeyManager.On("ReverseKeyLookup", mock.Anything, mock.Anything, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, fromAddr.String()).
 		Return(keyMapping, nil)
-	mockKeyManager.On("Sign", mock.Anything, keyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
+	mockKeyManager.On("Sign", mock.Anything, mock.Anything, keyMapping, signpayloads.OPAQUE_TO_RSV, mock.Anything).
 		Return(nil, fmt.Errorf("sign failed")).Once()
 
 	ethTx := &ethsigner.Transaction{
@@ -65,3 +66,40 @@ func TestInFlightTxSignFail(t *testing.T) {
 	assert.Nil(t, txHash)
 
 }
+
+func TestInFlightTxSignPolicyFail(t *testing.T) {
+	ctx, o, m, done := newTestOrchestrator(t)
+	defer done()
+	it, _ := newInflightTransaction(o, 1)
+
+	fromAddr := *pldtypes.RandAddress()
+	toAddr := pldtypes.RandAddress()
+	keyMapping := &pldapi.KeyMappingAndVerifier{
+		KeyMappingWithPath: &pldapi.KeyMappingWithPath{
+			KeyMapping: &pldapi.KeyMapping{
+				Identifier: "any.key",
+			},
+		},
+		Verifier: &pldapi.KeyVerifier{
+			Verifier: fromAddr.String(),
+		},
+	}
+
+	mockKeyManager := m.keyManager.(*componentsmocks.KeyManager)
+	mockKeyManager.ExpectedCalls = nil
+	mockKeyManager.On("ReverseKeyLookup", mock.Anything, mock.Anything, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS, fromAddr.String()).
+		Return(keyMapping, nil)
+	mockKeyManager.On("CheckPublicTransactionPolicy", mock.Anything, mock.Anything, fromAddr, toAddr, pldtypes.Uint64ToUint256(1000)).
+		Return(&components.SigningPolicyError{Wallet: "wallet1", Cause: fmt.Errorf("PD010527: too much")})
+
+	ethTx := &ethsigner.Transaction{
+		Nonce: ethtypes.NewHexInteger64(12345),
+		To:    toAddr.Address0xHex(),
+		Value: ethtypes.NewHexInteger64(1000),
+	}
+
+	// The transaction is not signed
+	_, txHash, err := it.signTx(ctx, fromAddr, ethTx)
+	assert.Regexp(t, "PD010527", err)
+	assert.Nil(t, txHash)
+}
@@ -105,6 +105,10 @@ const (
 type BalanceManager interface {
 	GetAddressBalance(ctx context.Context, address pldtypes.EthAddress) (*AddressAccount, error)
 	NotifyAddressBalanceChanged(ctx context.Context, address pldtypes.EthAddress)
+	IsAutoFuelingEnabled(ctx context.Context) bool
+	// TopUpAccount submits a fueling transaction if the account is below the configured threshold, and
+	// returns true while a fueling transaction for the account is in flight
+	TopUpAccount(ctx context.Context, addAccount *AddressAccount) (fuelingPending bool, err error)
 }
 
 // AddressAccount provides the following feature:
@@ -16,6 +16,7 @@
 package registrymgr
 
 import (
+	"github.com/kaleido-io/paladin/core/internal/filters"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 )
 
@@ -54,3 +55,36 @@ type DBProperty struct {
 func (dbe DBProperty) TableName() string {
 	return "reg_props"
 }
+
+// The name under which changes to the active state of an entry are recorded in the property history
+const EntryActiveHistoryName = ".active"
+
+type DBPropertyChange struct {
+	Sequence         uint64             `gorm:"column:seq;autoIncrement;primaryKey"`
+	Registry         string             `gorm:"column:registry"`
+	EntryID          pldtypes.HexBytes  `gorm:"column:entry_id"`
+	Name             string             `gorm:"column:name"`
+	Value            string             `gorm:"column:value"`
+	Active           bool               `gorm:"column:active"`
+	Created          pldtypes.Timestamp `gorm:"column:created;autoCreateTime:nano"`
+	TransactionHash  *pldtypes.Bytes32  `gorm:"column:tx_hash"`
+	BlockNumber      *int64             `gorm:"column:block_number"`
+	TransactionIndex *int64             `gorm:"column:tx_index"`
+	LogIndex         *int64             `gorm:"column:log_index"`
+}
+
+func (dbc DBPropertyChange) TableName() string {
+	return "reg_prop_history"
+}
+
+var propertyHistoryFilters = filters.FieldMap{
+	"sequence":         filters.Int64Field("seq"),
+	"created":          filters.TimestampField("created"),
+	"entryId":          filters.HexBytesField("entry_id"),
+	"name":             filters.StringField("name"),
+	"value":            filters.StringField("value"),
+	"active":           filters.BooleanField("active"),
+	"blockNumber":      filters.Int64Field("block_number"),
+	"transactionIndex": filters.Int64Field("tx_index"),
+	"logIndex":         filters.Int64Field("log_index"),
+}
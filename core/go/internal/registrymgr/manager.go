@@ -43,6 +43,9 @@ type registryManager struct {
 
 	p            persistence.Persistence
 	blockIndexer blockindexer.BlockIndexer
+	transportMgr components.TransportManager
+	txManager    components.TXManager
+	keyManager   components.KeyManager
 	rpcModule    *rpcserver.RPCModule
 
 	// We provide a high level of customization of how the nodes are looked up in the registry
@@ -50,7 +53,7 @@ type registryManager struct {
 
 	// Due to the high frequency of calls to the registry for node details, we maintain
 	// a cache of resolved nodes by name - which is a global index, across all registries.
-	transportDetailsCache cache.Cache[string, []*components.RegistryNodeTransportEntry]
+	nodeCache cache.Cache[string, *resolvedNode]
 
 	registriesByID   map[uuid.UUID]*registry
 	registriesByName map[string]*registry
@@ -64,7 +67,7 @@ func NewRegistryManager(bgCtx context.Context, conf *pldconf.RegistryManagerConf
 		registriesByID:           make(map[uuid.UUID]*registry),
 		registriesByName:         make(map[string]*registry),
 		registryTransportLookups: make(map[string]*transportLookup),
-		transportDetailsCache:    cache.NewCache[string, []*components.RegistryNodeTransportEntry](&conf.RegistryManager.RegistryCache, pldconf.RegistryCacheDefaults),
+		nodeCache:                cache.NewCache[string, *resolvedNode](&conf.RegistryManager.RegistryCache, pldconf.RegistryCacheDefaults),
 	}
 }
 
@@ -89,6 +92,9 @@ func (rm *registryManager) PreInit(pic components.PreInitComponents) (_ *compone
 
 func (rm *registryManager) PostInit(c components.AllComponents) error {
 	rm.blockIndexer = c.BlockIndexer()
+	rm.transportMgr = c.TransportManager()
+	rm.txManager = c.TxManager()
+	rm.keyManager = c.KeyManager()
 	return nil
 }
 
@@ -175,10 +181,26 @@ func (rm *registryManager) getRegistryNames() []string {
 }
 
 func (rm *registryManager) GetNodeTransports(ctx context.Context, node string) ([]*components.RegistryNodeTransportEntry, error) {
+	resolved, err := rm.resolveNode(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	return resolved.transports, nil
+}
+
+func (rm *registryManager) GetNodeProperties(ctx context.Context, node string) (map[string]string, error) {
+	resolved, err := rm.resolveNode(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	return resolved.properties, nil
+}
+
+func (rm *registryManager) resolveNode(ctx context.Context, node string) (*resolvedNode, error) {
 	// Check cache
-	transports, present := rm.transportDetailsCache.Get(node)
+	resolved, present := rm.nodeCache.Get(node)
 	if present {
-		return transports, nil
+		return resolved, nil
 	}
 
 	regLookupsChecked := 0
@@ -186,16 +208,16 @@ func (rm *registryManager) GetNodeTransports(ctx context.Context, node string) (
 		tl := rm.registryTransportLookups[regName]
 		if tl != nil {
 			regLookupsChecked++
-			regTransports, err := tl.getNodeTransports(ctx, rm.p.NOTX() /* no TX needed */, r, node)
+			resolved, err := tl.getNodeTransports(ctx, rm.p.NOTX() /* no TX needed */, r, node)
 			if err != nil {
 				return nil, err
 			}
 			// we only return entries from a single registry (we do not merge transports across registries)
 			// the requiredPrefix allows node partitioning across registries.
-			if len(regTransports) > 0 {
-				log.L(ctx).Infof("Node '%s' matched to %d transports in registry '%s'", node, len(regTransports), regName)
-				rm.transportDetailsCache.Set(node, regTransports)
-				return regTransports, nil
+			if resolved != nil && len(resolved.transports) > 0 {
+				log.L(ctx).Infof("Node '%s' matched to %d transports in registry '%s'", node, len(resolved.transports), regName)
+				rm.nodeCache.Set(node, resolved)
+				return resolved, nil
 			}
 		}
 	}
@@ -41,6 +41,9 @@ type mockComponents struct {
 	db            sqlmock.Sqlmock
 	allComponents *componentsmocks.AllComponents
 	blockIndexer  *blockindexermocks.BlockIndexer
+	transportMgr  *componentsmocks.TransportManager
+	txManager     *componentsmocks.TXManager
+	keyManager    *componentsmocks.KeyManager
 }
 
 func newTestRegistryManager(t *testing.T, realDB bool, conf *pldconf.RegistryManagerConfig, extraSetup ...func(mc *mockComponents)) (context.Context, *registryManager, *mockComponents, func()) {
@@ -50,8 +53,14 @@ func newTestRegistryManager(t *testing.T, realDB bool, conf *pldconf.RegistryMan
 	mc := &mockComponents{
 		blockIndexer:  blockindexermocks.NewBlockIndexer(t),
 		allComponents: componentsmocks.NewAllComponents(t),
+		transportMgr:  componentsmocks.NewTransportManager(t),
+		txManager:     componentsmocks.NewTXManager(t),
+		keyManager:    componentsmocks.NewKeyManager(t),
 	}
 	mc.allComponents.On("BlockIndexer").Return(mc.blockIndexer).Maybe()
+	mc.allComponents.On("TransportManager").Return(mc.transportMgr).Maybe()
+	mc.allComponents.On("TxManager").Return(mc.txManager).Maybe()
+	mc.allComponents.On("KeyManager").Return(mc.keyManager).Maybe()
 	mc.allComponents.On("MetricsManager").Return(mm).Maybe()
 
 	var p persistence.Persistence
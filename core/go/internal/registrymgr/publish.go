@@ -0,0 +1,289 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package registrymgr
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+func (rm *registryManager) publishLocalTransports(ctx context.Context, registryName string) ([]uuid.UUID, error) {
+	rm.mux.Lock()
+	r := rm.registriesByName[registryName]
+	rm.mux.Unlock()
+	if r == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgRegistryNotFound, registryName)
+	}
+	// An explicit request re-submits everything that is not yet indexed
+	return r.publishLocalTransports(ctx, true)
+}
+
+// Periodically checks the local transport details, so that changes such as
+// rotated TLS certificates or new endpoints are re-published automatically
+func (r *registry) publishLoop() {
+	defer close(r.publishDone)
+
+	interval := confutil.DurationMin(r.conf.Publish.Interval, 1*time.Second, *pldconf.RegistryPublishDefaults.Interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.publishLocalTransports(r.ctx, false); err != nil {
+			log.L(r.ctx).Errorf("Failed to publish local transports: %s", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-r.ctx.Done():
+			log.L(r.ctx).Debugf("publish loop stopped")
+			return
+		}
+	}
+}
+
+// The entry for the local node is found using the same rules as a transport lookup
+// from a remote node, so that they find what we publish.
+func (r *registry) localEntryPath() []string {
+	nodeName := r.rm.transportMgr.LocalNodeName()
+	tl := r.rm.registryTransportLookups[r.name]
+	if tl == nil {
+		return []string{nodeName}
+	}
+	nodeName = strings.TrimPrefix(nodeName, tl.requiredPrefix)
+	if tl.hierarchySplitter != "" {
+		return strings.Split(nodeName, tl.hierarchySplitter)
+	}
+	return []string{nodeName}
+}
+
+// Returns the parent ID, and the entry if it is registered. All parents must already be registered.
+func (r *registry) resolveLocalEntry(ctx context.Context, dbTX persistence.DBTX, path []string) (parentID pldtypes.HexBytes, entry *pldapi.RegistryEntryWithProperties, err error) {
+	for i, entryName := range path {
+		q := query.NewQueryBuilder().Equal(".name", entryName).Limit(1)
+		if parentID == nil {
+			q = q.Null(".parentId")
+		} else {
+			q = q.Equal(".parentId", parentID)
+		}
+		entries, err := r.QueryEntriesWithProps(ctx, dbTX, pldapi.ActiveFilterActive, q.Query())
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(entries) == 0 {
+			// We cannot re-register once we (or a parent) have been revoked
+			revoked, err := r.QueryEntries(ctx, dbTX, pldapi.ActiveFilterInactive, q.Query())
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(revoked) > 0 {
+				return nil, nil, i18n.NewError(ctx, msgs.MsgRegistryNodeRevoked, strings.Join(path, "/"), r.name, revoked[0].ID)
+			}
+		}
+		if i == len(path)-1 {
+			if len(entries) > 0 {
+				entry = entries[0]
+			}
+			break
+		}
+		if len(entries) == 0 {
+			return nil, nil, i18n.NewError(ctx, msgs.MsgRegistryPublishParentMissing, entryName, strings.Join(path, "/"), r.name)
+		}
+		parentID = entries[0].ID
+	}
+	return parentID, entry, nil
+}
+
+// Any transaction we submitted that has failed means the registration or properties it was submitted with
+// need to be submitted again, which we cannot tell from the indexed entry alone.
+func (r *registry) checkPublishReceipts(ctx context.Context) error {
+	if len(r.pendingPublish) == 0 {
+		return nil
+	}
+	txIDs := make([]any, 0, len(r.pendingPublish))
+	for txID := range r.pendingPublish {
+		txIDs = append(txIDs, txID)
+	}
+	receipts, err := r.rm.txManager.QueryTransactionReceipts(ctx, query.NewQueryBuilder().In("id", txIDs).Limit(len(txIDs)).Query())
+	if err != nil {
+		return err
+	}
+	for _, receipt := range receipts {
+		pending := r.pendingPublish[receipt.ID]
+		if pending == nil {
+			continue
+		}
+		delete(r.pendingPublish, receipt.ID)
+		if receipt.Success {
+			continue
+		}
+		log.L(ctx).Warnf("Publish transaction %s failed (register=%t,properties=%v): %s", receipt.ID, pending.register, pending.properties, receipt.FailureMessage)
+		if pending.register {
+			r.registrationSubmitted = false
+		}
+		for _, propName := range pending.properties {
+			delete(r.published, propName)
+		}
+	}
+	return nil
+}
+
+// Registers the local node if required, and sets a property for each local transport (and for the node
+// encryption keys) with details that differ from those indexed from the registry. Unless forced, values
+// already submitted are not submitted again while we wait for them to be indexed (or for them to fail).
+func (r *registry) publishLocalTransports(ctx context.Context, force bool) ([]uuid.UUID, error) {
+	r.publishMux.Lock()
+	defer r.publishMux.Unlock()
+
+	if !r.initialized.Load() {
+		return nil, i18n.NewError(ctx, msgs.MsgRegistryNotInitialized, r.name)
+	}
+	if !r.config.WritesSupported {
+		return nil, i18n.NewError(ctx, msgs.MsgRegistryWritesNotSupported, r.name)
+	}
+	if err := r.checkPublishReceipts(ctx); err != nil {
+		return nil, err
+	}
+	from := r.conf.Publish.From
+	if from == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgRegistryPublishFromMissing, r.name)
+	}
+	registerFrom := confutil.StringNotEmpty(&r.conf.Publish.RegisterFrom, from)
+	propertyPrefix := confutil.StringNotEmpty(r.conf.Publish.PropertyPrefix, *pldconf.RegistryPublishDefaults.PropertyPrefix)
+
+	path := r.localEntryPath()
+	parentID, entry, err := r.resolveLocalEntry(ctx, r.rm.p.NOTX(), path)
+	if err != nil {
+		return nil, err
+	}
+	indexed := map[string]string{}
+	if entry != nil {
+		indexed = entry.Properties
+	}
+
+	transportNames := r.rm.transportMgr.LocalTransportNames()
+	sort.Strings(transportNames)
+	var props []*prototk.RegistryPropertyValue
+	for _, transportName := range transportNames {
+		details, err := r.rm.transportMgr.GetLocalTransportDetails(ctx, transportName)
+		if err != nil {
+			return nil, err
+		}
+		propName := propertyPrefix + transportName
+		if indexed[propName] == details || (!force && r.published[propName] == details) {
+			continue
+		}
+		props = append(props, &prototk.RegistryPropertyValue{Name: propName, Value: details})
+	}
+
+	// The public keys that other nodes use to encrypt payloads to us are published alongside the transports
+	keysPropName, keys := r.rm.transportMgr.LocalNodeKeysProperty()
+	if keysPropName != "" && indexed[keysPropName] != keys && (force || r.published[keysPropName] != keys) {
+		props = append(props, &prototk.RegistryPropertyValue{Name: keysPropName, Value: keys})
+	}
+
+	register := entry == nil && (force || !r.registrationSubmitted)
+	if !register && (len(props) == 0 || entry == nil) {
+		// Nothing has changed, or we are waiting for our registration to be indexed
+		log.L(ctx).Debugf("No changes to publish for '%s' (registered=%t,pendingRegistration=%t)", strings.Join(path, "/"), entry != nil, r.registrationSubmitted)
+		return []uuid.UUID{}, nil
+	}
+	if register {
+		// The properties can only be set by the owner of an entry once it exists, and the registration might be
+		// submitted from a different key (so with no ordering against them). So they are published once it is indexed.
+		props = nil
+	}
+
+	ownerAddr, err := r.rm.keyManager.ResolveEthAddressNewDatabaseTX(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	req := &prototk.PrepareRegistryTransactionsRequest{
+		Name:         path[len(path)-1],
+		OwnerAddress: ownerAddr.String(),
+		Properties:   props,
+	}
+	if parentID != nil {
+		req.ParentId = parentID.String()
+	}
+	if entry != nil {
+		req.EntryId = entry.ID.String()
+	}
+	res, err := r.api.PrepareRegistryTransactions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*pldapi.TransactionInput, len(res.Transactions))
+	for i, ptx := range res.Transactions {
+		contractAddr, err := pldtypes.ParseEthAddress(ptx.ContractAddress)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgRegistryInvalidPreparedTx, r.name, i)
+		}
+		var functionABI abi.Entry
+		if err := json.Unmarshal([]byte(ptx.FunctionAbiJson), &functionABI); err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgRegistryInvalidPreparedTx, r.name, i)
+		}
+		txFrom := from
+		if ptx.RegisterEntry {
+			txFrom = registerFrom
+		}
+		txs[i] = &pldapi.TransactionInput{
+			TransactionBase: pldapi.TransactionBase{
+				Type: pldapi.TransactionTypePublic.Enum(),
+				From: txFrom,
+				To:   contractAddr,
+				Data: pldtypes.RawJSON(ptx.ParamsJson),
+			},
+			ABI: abi.ABI{&functionABI},
+		}
+	}
+
+	var txIDs []uuid.UUID
+	err = r.rm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		txIDs, err = r.rm.txManager.SendTransactions(ctx, dbTX, txs...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if register {
+		r.registrationSubmitted = true
+	}
+	pending := &pendingPublish{register: register}
+	for _, prop := range props {
+		r.published[prop.Name] = prop.Value
+		pending.properties = append(pending.properties, prop.Name)
+	}
+	for _, txID := range txIDs {
+		r.pendingPublish[txID] = pending
+	}
+	log.L(ctx).Infof("Submitted %d transactions to publish '%s' (register=%t,properties=%d)", len(txIDs), strings.Join(path, "/"), register, len(props))
+	return txIDs, nil
+}
@@ -0,0 +1,481 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package registrymgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testRegistryABIFunction = `{"type":"function","name":"setIdentityProperty","inputs":[{"name":"name","type":"string"}]}`
+
+func newTestPublishRegistry(t *testing.T, realDB bool, extraSetup ...func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig)) (context.Context, *registryManager, *testPlugin, *mockComponents, func()) {
+	setup := []func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig){
+		func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+			regConf.WritesSupported = true
+			conf.Registries["test1"].Publish.From = "node1.key"
+		},
+	}
+	setup = append(setup, extraSetup...)
+	setup = append(setup, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		// Only applies if the test has not set its own node name
+		mc.transportMgr.On("LocalNodeName").Return("node1").Maybe()
+		mc.transportMgr.On("LocalNodeKeysProperty").Return("", "").Maybe()
+		mc.txManager.On("QueryTransactionReceipts", mock.Anything, mock.Anything).Return([]*pldapi.TransactionReceipt{}, nil).Maybe()
+	})
+	return newTestRegistry(t, realDB, setup...)
+}
+
+func mockPreparedTransactions(t *testing.T, tp *testPlugin, contractAddr *pldtypes.EthAddress) {
+	tp.Functions.PrepareRegistryTransactions = func(ctx context.Context, req *prototk.PrepareRegistryTransactionsRequest) (*prototk.PrepareRegistryTransactionsResponse, error) {
+		assert.Equal(t, "node1", req.Name)
+		res := &prototk.PrepareRegistryTransactionsResponse{}
+		if req.EntryId == "" {
+			res.Transactions = append(res.Transactions, &prototk.PreparedRegistryTransaction{
+				ContractAddress: contractAddr.String(),
+				FunctionAbiJson: testRegistryABIFunction,
+				ParamsJson:      fmt.Sprintf(`{"name":"%s"}`, req.Name),
+				RegisterEntry:   true,
+			})
+		}
+		for _, prop := range req.Properties {
+			res.Transactions = append(res.Transactions, &prototk.PreparedRegistryTransaction{
+				ContractAddress: contractAddr.String(),
+				FunctionAbiJson: testRegistryABIFunction,
+				ParamsJson:      fmt.Sprintf(`{"name":"%s"}`, prop.Name),
+			})
+		}
+		return res, nil
+	}
+}
+
+func TestPublishLocalTransportsRegisterThenUpdate(t *testing.T) {
+	ownerAddr := pldtypes.RandAddress()
+	contractAddr := pldtypes.RandAddress()
+	grpcDetails := "details1"
+	var submitted []*pldapi.TransactionInput
+	ctx, rm, tp, _, done := newTestPublishRegistry(t, true, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		conf.Registries["test1"].Publish.RegisterFrom = "registry.admin"
+		mc.transportMgr.On("LocalTransportNames").Return([]string{"grpc"})
+		mc.transportMgr.On("GetLocalTransportDetails", mock.Anything, "grpc").Return(func(context.Context, string) (string, error) {
+			return grpcDetails, nil
+		})
+		mc.keyManager.On("ResolveEthAddressNewDatabaseTX", mock.Anything, "node1.key").Return(ownerAddr, nil)
+		mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return(
+			func(ctx context.Context, dbTX persistence.DBTX, txs ...*pldapi.TransactionInput) ([]uuid.UUID, error) {
+				submitted = txs
+				txIDs := make([]uuid.UUID, len(txs))
+				for i := range txs {
+					txIDs[i] = uuid.New()
+				}
+				return txIDs, nil
+			})
+	})
+	defer done()
+	mockPreparedTransactions(t, tp, contractAddr)
+
+	// Not yet registered, so we register - with the property set once the registration is indexed
+	txIDs, err := rm.publishLocalTransports(ctx, "test1")
+	require.NoError(t, err)
+	assert.Len(t, txIDs, 1)
+	require.Len(t, submitted, 1)
+	assert.Equal(t, "registry.admin", submitted[0].From)
+	assert.Equal(t, pldapi.TransactionTypePublic, submitted[0].Type.V())
+	assert.Equal(t, contractAddr, submitted[0].To)
+	assert.JSONEq(t, `{"name":"node1"}`, submitted[0].Data.String())
+
+	// Waiting for the registration to be indexed, so nothing to do
+	txIDs, err = tp.r.publishLocalTransports(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, txIDs)
+
+	// Index the registration, and we set the property
+	entryID := randID()
+	_, err = tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries: []*prototk.RegistryEntry{{Id: entryID, Name: "node1", Location: randChainInfo(), Active: true}},
+	})
+	require.NoError(t, err)
+	txIDs, err = tp.r.publishLocalTransports(ctx, false)
+	require.NoError(t, err)
+	assert.Len(t, txIDs, 1)
+	require.Len(t, submitted, 1)
+	assert.Equal(t, "node1.key", submitted[0].From)
+	assert.JSONEq(t, `{"name":"transport.grpc"}`, submitted[0].Data.String())
+
+	// Index the property
+	_, err = tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Properties: []*prototk.RegistryProperty{newPropFor(entryID, "transport.grpc", "details1")},
+	})
+	require.NoError(t, err)
+	txIDs, err = tp.r.publishLocalTransports(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, txIDs)
+
+	// Rotate the details, and we publish just the property
+	grpcDetails = "details2"
+	submitted = nil
+	txIDs, err = tp.r.publishLocalTransports(ctx, false)
+	require.NoError(t, err)
+	assert.Len(t, txIDs, 1)
+	require.Len(t, submitted, 1)
+	assert.Equal(t, "node1.key", submitted[0].From)
+
+	// Not submitted again while we wait for it to be indexed
+	txIDs, err = tp.r.publishLocalTransports(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, txIDs)
+}
+
+func TestPublishLocalTransportsResubmitFailed(t *testing.T) {
+	var submitted []uuid.UUID
+	failed := map[uuid.UUID]bool{}
+	ctx, _, tp, _, done := newTestPublishRegistry(t, true, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		mc.transportMgr.On("LocalTransportNames").Return([]string{"grpc"})
+		mc.transportMgr.On("GetLocalTransportDetails", mock.Anything, "grpc").Return("details1", nil)
+		mc.keyManager.On("ResolveEthAddressNewDatabaseTX", mock.Anything, "node1.key").Return(pldtypes.RandAddress(), nil)
+		mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return(
+			func(ctx context.Context, dbTX persistence.DBTX, txs ...*pldapi.TransactionInput) ([]uuid.UUID, error) {
+				txIDs := make([]uuid.UUID, len(txs))
+				for i := range txs {
+					txIDs[i] = uuid.New()
+				}
+				submitted = append(submitted, txIDs...)
+				return txIDs, nil
+			})
+		mc.txManager.On("QueryTransactionReceipts", mock.Anything, mock.Anything).Return(
+			func(ctx context.Context, jq *query.QueryJSON) ([]*pldapi.TransactionReceipt, error) {
+				// Every submitted transaction has a receipt, including one we are not tracking
+				receipts := []*pldapi.TransactionReceipt{{ID: uuid.New()}}
+				for _, txID := range submitted {
+					receipts = append(receipts, &pldapi.TransactionReceipt{
+						ID:                     txID,
+						TransactionReceiptData: pldapi.TransactionReceiptData{Success: !failed[txID]},
+					})
+				}
+				return receipts, nil
+			})
+	})
+	defer done()
+	mockPreparedTransactions(t, tp, pldtypes.RandAddress())
+
+	// The registration fails, so is submitted again
+	txIDs, err := tp.r.publishLocalTransports(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, txIDs, 1)
+	failed[txIDs[0]] = true
+	txIDs, err = tp.r.publishLocalTransports(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, txIDs, 1)
+	txIDs, err = tp.r.publishLocalTransports(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, txIDs)
+	assert.Empty(t, tp.r.pendingPublish)
+
+	// Once registered, the property fails (for example if it was mined before the registration), so is submitted again
+	entryID := randID()
+	_, err = tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries: []*prototk.RegistryEntry{{Id: entryID, Name: "node1", Location: randChainInfo(), Active: true}},
+	})
+	require.NoError(t, err)
+	txIDs, err = tp.r.publishLocalTransports(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, txIDs, 1)
+	failed[txIDs[0]] = true
+	txIDs, err = tp.r.publishLocalTransports(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, txIDs, 1)
+	txIDs, err = tp.r.publishLocalTransports(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, txIDs)
+}
+
+func TestPublishLocalTransportsReceiptQueryFail(t *testing.T) {
+	ctx, _, tp, _, done := newTestPublishRegistry(t, false, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		mc.txManager.On("QueryTransactionReceipts", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	})
+	defer done()
+
+	tp.r.pendingPublish[uuid.New()] = &pendingPublish{register: true}
+	_, err := tp.r.publishLocalTransports(ctx, true)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPublishLocalNodeKeys(t *testing.T) {
+	ownerAddr := pldtypes.RandAddress()
+	contractAddr := pldtypes.RandAddress()
+	var submitted []*pldapi.TransactionInput
+	ctx, _, tp, _, done := newTestPublishRegistry(t, true, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		mc.transportMgr.On("LocalTransportNames").Return([]string{})
+		mc.transportMgr.On("LocalNodeKeysProperty").Return("node.keys", `["0x1234"]`)
+		mc.keyManager.On("ResolveEthAddressNewDatabaseTX", mock.Anything, "node1.key").Return(ownerAddr, nil)
+		mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return(
+			func(ctx context.Context, dbTX persistence.DBTX, txs ...*pldapi.TransactionInput) ([]uuid.UUID, error) {
+				submitted = txs
+				return make([]uuid.UUID, len(txs)), nil
+			})
+	})
+	defer done()
+	mockPreparedTransactions(t, tp, contractAddr)
+
+	entryID := randID()
+	_, err := tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries: []*prototk.RegistryEntry{{Id: entryID, Name: "node1", Location: randChainInfo(), Active: true}},
+	})
+	require.NoError(t, err)
+
+	// The keys are published as a property of our entry
+	txIDs, err := tp.r.publishLocalTransports(ctx, false)
+	require.NoError(t, err)
+	assert.Len(t, txIDs, 1)
+	require.Len(t, submitted, 1)
+	assert.JSONEq(t, `{"name":"node.keys"}`, submitted[0].Data.String())
+
+	// Once indexed there is nothing more to do
+	_, err = tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Properties: []*prototk.RegistryProperty{newPropFor(entryID, "node.keys", `["0x1234"]`)},
+	})
+	require.NoError(t, err)
+	txIDs, err = tp.r.publishLocalTransports(ctx, true)
+	require.NoError(t, err)
+	assert.Empty(t, txIDs)
+}
+
+func TestPublishLocalTransportsHierarchy(t *testing.T) {
+	ownerAddr := pldtypes.RandAddress()
+	parentID := randID()
+	ctx, rm, tp, _, done := newTestPublishRegistry(t, true, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		conf.Registries["test1"].Transports.RequiredPrefix = "net1."
+		conf.Registries["test1"].Transports.HierarchySplitter = "/"
+		mc.transportMgr.On("LocalNodeName").Return("net1.org1/node1")
+		mc.transportMgr.On("LocalTransportNames").Return([]string{})
+		mc.keyManager.On("ResolveEthAddressNewDatabaseTX", mock.Anything, "node1.key").Return(ownerAddr, nil)
+		mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]uuid.UUID{uuid.New()}, nil)
+	})
+	defer done()
+
+	tp.Functions.PrepareRegistryTransactions = func(ctx context.Context, req *prototk.PrepareRegistryTransactionsRequest) (*prototk.PrepareRegistryTransactionsResponse, error) {
+		assert.Equal(t, "node1", req.Name)
+		assert.Equal(t, pldtypes.MustParseHexBytes(parentID).String(), req.ParentId)
+		assert.Empty(t, req.EntryId)
+		assert.Equal(t, ownerAddr.String(), req.OwnerAddress)
+		return &prototk.PrepareRegistryTransactionsResponse{
+			Transactions: []*prototk.PreparedRegistryTransaction{{
+				ContractAddress: pldtypes.RandAddress().String(),
+				FunctionAbiJson: testRegistryABIFunction,
+				ParamsJson:      `{}`,
+				RegisterEntry:   true,
+			}},
+		}, nil
+	}
+
+	_, err := rm.publishLocalTransports(ctx, "test1")
+	assert.Regexp(t, "PD012112.*org1", err)
+
+	_, err = tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries: []*prototk.RegistryEntry{{Id: parentID, Name: "org1", Location: randChainInfo(), Active: true}},
+	})
+	require.NoError(t, err)
+
+	txIDs, err := rm.publishLocalTransports(ctx, "test1")
+	require.NoError(t, err)
+	assert.Len(t, txIDs, 1)
+}
+
+func TestPublishLocalTransportsRegistryNotFound(t *testing.T) {
+	ctx, rm, _, _, done := newTestPublishRegistry(t, false)
+	defer done()
+
+	_, err := rm.publishLocalTransports(ctx, "unknown")
+	assert.Regexp(t, "PD012101", err)
+}
+
+func TestPublishLocalTransportsNotInitialized(t *testing.T) {
+	ctx, _, tp, _, done := newTestPublishRegistry(t, false)
+	defer done()
+
+	tp.r.initialized.Store(false)
+	_, err := tp.r.publishLocalTransports(ctx, true)
+	assert.Regexp(t, "PD012113", err)
+}
+
+func TestPublishLocalTransportsWritesNotSupported(t *testing.T) {
+	ctx, _, tp, _, done := newTestPublishRegistry(t, false, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		regConf.WritesSupported = false
+	})
+	defer done()
+
+	_, err := tp.r.publishLocalTransports(ctx, true)
+	assert.Regexp(t, "PD012110", err)
+}
+
+func TestPublishLocalTransportsFromMissing(t *testing.T) {
+	ctx, _, tp, _, done := newTestPublishRegistry(t, false, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		conf.Registries["test1"].Publish.From = ""
+	})
+	defer done()
+
+	_, err := tp.r.publishLocalTransports(ctx, true)
+	assert.Regexp(t, "PD012111", err)
+}
+
+func TestPublishLocalTransportsQueryFail(t *testing.T) {
+	ctx, _, tp, mc, done := newTestPublishRegistry(t, false)
+	defer done()
+
+	mc.db.ExpectQuery("SELECT.*reg_entries").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := tp.r.publishLocalTransports(ctx, true)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPublishLocalTransportsRevokedQueryFail(t *testing.T) {
+	ctx, _, tp, mc, done := newTestPublishRegistry(t, false)
+	defer done()
+
+	mc.db.ExpectQuery("SELECT.*reg_entries").WillReturnRows(sqlmock.NewRows([]string{}))
+	mc.db.ExpectQuery("SELECT.*reg_entries").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := tp.r.publishLocalTransports(ctx, true)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPublishLocalTransportsRevoked(t *testing.T) {
+	ctx, _, tp, mc, done := newTestPublishRegistry(t, true)
+	defer done()
+
+	mc.transportMgr.On("RevalidatePeers").Return().Once()
+	_, err := tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries: []*prototk.RegistryEntry{{Id: randID(), Name: "node1", Location: randChainInfo(), Active: false}},
+	})
+	require.NoError(t, err)
+
+	// We do not attempt to register again
+	_, err = tp.r.publishLocalTransports(ctx, true)
+	assert.Regexp(t, "PD012115.*node1", err)
+}
+
+func TestPublishLocalTransportsNoTransportLookup(t *testing.T) {
+	ctx, _, tp, _, done := newTestPublishRegistry(t, true, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		conf.Registries["test1"].Transports.Enabled = confutil.P(false)
+		mc.transportMgr.On("LocalTransportNames").Return([]string{"grpc"})
+		mc.transportMgr.On("GetLocalTransportDetails", mock.Anything, "grpc").Return("", fmt.Errorf("pop"))
+	})
+	defer done()
+
+	assert.Equal(t, []string{"node1"}, tp.r.localEntryPath())
+	_, err := tp.r.publishLocalTransports(ctx, true)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPublishLocalTransportsResolveKeyFail(t *testing.T) {
+	ctx, _, tp, _, done := newTestPublishRegistry(t, true, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		mc.transportMgr.On("LocalTransportNames").Return([]string{})
+		mc.keyManager.On("ResolveEthAddressNewDatabaseTX", mock.Anything, "node1.key").Return(nil, fmt.Errorf("pop"))
+	})
+	defer done()
+
+	_, err := tp.r.publishLocalTransports(ctx, true)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPublishLocalTransportsPrepareFail(t *testing.T) {
+	ctx, _, tp, _, done := newTestPublishRegistry(t, true, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		mc.transportMgr.On("LocalTransportNames").Return([]string{})
+		mc.keyManager.On("ResolveEthAddressNewDatabaseTX", mock.Anything, "node1.key").Return(pldtypes.RandAddress(), nil)
+	})
+	defer done()
+
+	tp.Functions.PrepareRegistryTransactions = func(ctx context.Context, req *prototk.PrepareRegistryTransactionsRequest) (*prototk.PrepareRegistryTransactionsResponse, error) {
+		return nil, fmt.Errorf("pop")
+	}
+
+	_, err := tp.r.publishLocalTransports(ctx, true)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPublishLocalTransportsBadPreparedTransactions(t *testing.T) {
+	ctx, _, tp, _, done := newTestPublishRegistry(t, true, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		mc.transportMgr.On("LocalTransportNames").Return([]string{})
+		mc.keyManager.On("ResolveEthAddressNewDatabaseTX", mock.Anything, "node1.key").Return(pldtypes.RandAddress(), nil)
+	})
+	defer done()
+
+	preparedTX := &prototk.PreparedRegistryTransaction{ContractAddress: "wrong"}
+	tp.Functions.PrepareRegistryTransactions = func(ctx context.Context, req *prototk.PrepareRegistryTransactionsRequest) (*prototk.PrepareRegistryTransactionsResponse, error) {
+		return &prototk.PrepareRegistryTransactionsResponse{
+			Transactions: []*prototk.PreparedRegistryTransaction{preparedTX},
+		}, nil
+	}
+
+	_, err := tp.r.publishLocalTransports(ctx, true)
+	assert.Regexp(t, "PD012114", err)
+
+	preparedTX.ContractAddress = pldtypes.RandAddress().String()
+	preparedTX.FunctionAbiJson = "!!! wrong"
+	_, err = tp.r.publishLocalTransports(ctx, true)
+	assert.Regexp(t, "PD012114", err)
+}
+
+func TestPublishLocalTransportsSendFail(t *testing.T) {
+	ctx, _, tp, _, done := newTestPublishRegistry(t, true, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		mc.transportMgr.On("LocalTransportNames").Return([]string{})
+		mc.keyManager.On("ResolveEthAddressNewDatabaseTX", mock.Anything, "node1.key").Return(pldtypes.RandAddress(), nil)
+		mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	})
+	defer done()
+	mockPreparedTransactions(t, tp, pldtypes.RandAddress())
+
+	_, err := tp.r.publishLocalTransports(ctx, true)
+	assert.Regexp(t, "pop", err)
+	assert.False(t, tp.r.registrationSubmitted)
+}
+
+func TestPublishLoopOnStartup(t *testing.T) {
+	ready := make(chan struct{})
+	published := make(chan struct{})
+	_, _, tp, _, done := newTestPublishRegistry(t, true, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+		conf.Registries["test1"].Publish.Enabled = confutil.P(true)
+		conf.Registries["test1"].Publish.Interval = confutil.P("1ms")
+		mc.transportMgr.On("LocalTransportNames").Return([]string{})
+		mc.keyManager.On("ResolveEthAddressNewDatabaseTX", mock.Anything, "node1.key").Return(nil, fmt.Errorf("pop")).Once().Run(func(args mock.Arguments) {
+			<-ready
+		})
+		mc.keyManager.On("ResolveEthAddressNewDatabaseTX", mock.Anything, "node1.key").Return(pldtypes.RandAddress(), nil)
+		mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]uuid.UUID{uuid.New()}, nil).Once().Run(func(args mock.Arguments) {
+			close(published)
+		})
+	})
+	defer done()
+	mockPreparedTransactions(t, tp, pldtypes.RandAddress())
+	close(ready)
+
+	// The first attempt fails, and we retry on the next interval
+	<-published
+}
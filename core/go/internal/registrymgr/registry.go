@@ -19,13 +19,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"github.com/google/uuid"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/filters"
@@ -37,6 +40,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -58,17 +62,32 @@ type registry struct {
 
 	config      *prototk.RegistryConfig
 	eventStream *blockindexer.EventStream
+
+	publishMux            sync.Mutex
+	publishDone           chan struct{}
+	published             map[string]string
+	registrationSubmitted bool
+	pendingPublish        map[uuid.UUID]*pendingPublish
+}
+
+// The transactions of each publish are tracked until they have a receipt, so if they fail
+// the registration or properties they contained are submitted again
+type pendingPublish struct {
+	register   bool
+	properties []string
 }
 
 func (rm *registryManager) newRegistry(id uuid.UUID, name string, conf *pldconf.RegistryConfig, toRegistry components.RegistryManagerToRegistry) *registry {
 	r := &registry{
-		rm:        rm,
-		conf:      conf,
-		initRetry: retry.NewRetryIndefinite(&conf.Init.Retry),
-		name:      name,
-		id:        id,
-		api:       toRegistry,
-		initDone:  make(chan struct{}),
+		rm:             rm,
+		conf:           conf,
+		initRetry:      retry.NewRetryIndefinite(&conf.Init.Retry),
+		name:           name,
+		id:             id,
+		api:            toRegistry,
+		initDone:       make(chan struct{}),
+		published:      make(map[string]string),
+		pendingPublish: make(map[uuid.UUID]*pendingPublish),
 	}
 	r.ctx, r.cancelCtx = context.WithCancel(log.WithLogField(rm.bgCtx, "registry", r.name))
 	return r
@@ -100,6 +119,10 @@ func (r *registry) init() {
 		r.initialized.Store(true)
 		// Inform the plugin manager callback
 		r.api.Initialized()
+		if confutil.Bool(r.conf.Publish.Enabled, *pldconf.RegistryPublishDefaults.Enabled) {
+			r.publishDone = make(chan struct{})
+			go r.publishLoop()
+		}
 	}
 }
 
@@ -237,7 +260,7 @@ func (r *registry) upsertRegistryRecords(ctx context.Context, dbTX persistence.D
 			txHash, _ := pldtypes.ParseBytes32(protoEntry.Location.TransactionHash)
 			dbe.TransactionHash = &txHash
 			dbe.BlockNumber = &protoEntry.Location.BlockNumber
-			dbe.TransactionIndex = &protoEntry.Location.LogIndex
+			dbe.TransactionIndex = &protoEntry.Location.TransactionIndex
 			dbe.LogIndex = &protoEntry.Location.LogIndex
 		}
 		dbEntries[i] = dbe
@@ -278,7 +301,7 @@ func (r *registry) upsertRegistryRecords(ctx context.Context, dbTX persistence.D
 			txHash, _ := pldtypes.ParseBytes32(protoProp.Location.TransactionHash)
 			dbp.TransactionHash = &txHash
 			dbp.BlockNumber = &protoProp.Location.BlockNumber
-			dbp.TransactionIndex = &protoProp.Location.LogIndex
+			dbp.TransactionIndex = &protoProp.Location.TransactionIndex
 			dbp.LogIndex = &protoProp.Location.LogIndex
 		}
 		dbProps[i] = dbp
@@ -286,7 +309,13 @@ func (r *registry) upsertRegistryRecords(ctx context.Context, dbTX persistence.D
 
 	var err error
 
+	// Entry changes must be determined before the upsert overwrites the current state
+	var entryChanges []*DBPropertyChange
 	if len(dbEntries) > 0 {
+		entryChanges, err = r.buildEntryChanges(ctx, dbTX, dbEntries)
+	}
+
+	if err == nil && len(dbEntries) > 0 {
 		err = dbTX.DB().
 			WithContext(ctx).
 			Table("reg_entries").
@@ -312,7 +341,12 @@ func (r *registry) upsertRegistryRecords(ctx context.Context, dbTX persistence.D
 		return err
 	}
 
+	var propChanges []*DBPropertyChange
 	if len(dbProps) > 0 {
+		propChanges, err = r.buildPropertyChanges(ctx, dbTX, dbProps)
+	}
+
+	if err == nil && len(dbProps) > 0 {
 		err = dbTX.DB().
 			WithContext(ctx).
 			Table("reg_props").
@@ -336,21 +370,171 @@ func (r *registry) upsertRegistryRecords(ctx context.Context, dbTX persistence.D
 			Error
 	}
 
+	changes := append(entryChanges, propChanges...)
+	if err == nil && len(changes) > 0 {
+		err = dbTX.DB().
+			WithContext(ctx).
+			Table("reg_prop_history").
+			Create(changes).
+			Error
+	}
+
 	if err != nil {
 		return err
 	}
 
+	revocations := false
+	for _, change := range entryChanges {
+		revocations = revocations || !change.Active
+	}
+
 	dbTX.AddPostCommit(func(ctx context.Context) {
 		// It's a lot of work to determine which parts of the node transport cache are affected,
 		// as the upserts above happen simply by storing properties that might/might-not match
 		// queries that resolve node transports to names.
 		//
 		// So instead we just zap the whole cache when we have an update.
-		r.rm.transportDetailsCache.Clear()
+		r.rm.nodeCache.Clear()
+
+		// Any connections to nodes that are no longer resolvable need to be re-checked,
+		// so that we stop sending to a node as soon as it has been revoked.
+		if revocations {
+			r.rm.transportMgr.RevalidatePeers()
+		}
 	})
 	return nil
 }
 
+// Revocation (or reinstatement) of an entry is recorded in the property history under the
+// EntryActiveHistoryName pseudo-property, which cannot clash with a property name. An entry that
+// is new to us is only recorded if it arrives already revoked.
+func (r *registry) buildEntryChanges(ctx context.Context, dbTX persistence.DBTX, dbEntries []*DBEntry) ([]*DBPropertyChange, error) {
+
+	entryIDs := make([]pldtypes.HexBytes, len(dbEntries))
+	for i, dbe := range dbEntries {
+		entryIDs[i] = dbe.ID
+	}
+
+	var existing []*DBEntry
+	err := dbTX.DB().WithContext(ctx).
+		Table("reg_entries").
+		Where("registry = ?", r.name).
+		Where("id IN (?)", entryIDs).
+		Find(&existing).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]bool, len(existing))
+	for _, dbe := range existing {
+		current[dbe.ID.String()] = dbe.Active
+	}
+
+	var changes []*DBPropertyChange
+	for _, dbe := range dbEntries {
+		wasActive, known := current[dbe.ID.String()]
+		current[dbe.ID.String()] = dbe.Active
+		if (known && wasActive == dbe.Active) || (!known && dbe.Active) {
+			continue
+		}
+		changes = append(changes, &DBPropertyChange{
+			Registry:         dbe.Registry,
+			EntryID:          dbe.ID,
+			Name:             EntryActiveHistoryName,
+			Value:            strconv.FormatBool(dbe.Active),
+			Active:           dbe.Active,
+			TransactionHash:  dbe.TransactionHash,
+			BlockNumber:      dbe.BlockNumber,
+			TransactionIndex: dbe.TransactionIndex,
+			LogIndex:         dbe.LogIndex,
+		})
+	}
+	return changes, nil
+}
+
+// The history only records changes, so we compare against the current state of each property
+// (and against earlier properties in the same batch).
+func (r *registry) buildPropertyChanges(ctx context.Context, dbTX persistence.DBTX, dbProps []*DBProperty) ([]*DBPropertyChange, error) {
+
+	entryIDs := make([]pldtypes.HexBytes, 0, len(dbProps))
+	uniqueEntryIDs := make(map[string]bool)
+	for _, dbp := range dbProps {
+		if !uniqueEntryIDs[dbp.EntryID.String()] {
+			uniqueEntryIDs[dbp.EntryID.String()] = true
+			entryIDs = append(entryIDs, dbp.EntryID)
+		}
+	}
+
+	var existing []*DBProperty
+	err := dbTX.DB().WithContext(ctx).
+		Table("reg_props").
+		Where("registry = ?", r.name).
+		Where("entry_id IN (?)", entryIDs).
+		Find(&existing).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]*DBProperty, len(existing))
+	for _, dbp := range existing {
+		current[dbp.EntryID.String()+"/"+dbp.Name] = dbp
+	}
+
+	var changes []*DBPropertyChange
+	for _, dbp := range dbProps {
+		key := dbp.EntryID.String() + "/" + dbp.Name
+		if prev := current[key]; prev != nil && prev.Value == dbp.Value && prev.Active == dbp.Active {
+			continue
+		}
+		current[key] = dbp
+		changes = append(changes, &DBPropertyChange{
+			Registry:         dbp.Registry,
+			EntryID:          dbp.EntryID,
+			Name:             dbp.Name,
+			Value:            dbp.Value,
+			Active:           dbp.Active,
+			TransactionHash:  dbp.TransactionHash,
+			BlockNumber:      dbp.BlockNumber,
+			TransactionIndex: dbp.TransactionIndex,
+			LogIndex:         dbp.LogIndex,
+		})
+	}
+	return changes, nil
+}
+
+func (r *registry) QueryPropertyHistory(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.RegistryPropertyChange, error) {
+	qw := &filters.QueryWrapper[DBPropertyChange, pldapi.RegistryPropertyChange]{
+		P:           r.rm.p,
+		DefaultSort: "-sequence",
+		Filters:     propertyHistoryFilters,
+		Query:       jq,
+		Finalize: func(db *gorm.DB) *gorm.DB {
+			return db.Where("registry = ?", r.name)
+		},
+		MapResult: func(dbc *DBPropertyChange) (*pldapi.RegistryPropertyChange, error) {
+			change := &pldapi.RegistryPropertyChange{
+				Sequence: dbc.Sequence,
+				Created:  dbc.Created,
+				Registry: dbc.Registry,
+				EntryID:  dbc.EntryID,
+				Name:     dbc.Name,
+				Value:    dbc.Value,
+				Active:   dbc.Active,
+			}
+			// For block info, our insert logic ensures if one is set they are all set
+			if dbc.BlockNumber != nil {
+				change.OnChainLocation = &pldapi.OnChainLocation{
+					BlockNumber:      *dbc.BlockNumber,
+					TransactionIndex: *dbc.TransactionIndex,
+					LogIndex:         *dbc.LogIndex,
+				}
+			}
+			return change, nil
+		},
+	}
+	return qw.Run(ctx, dbTX)
+}
+
 type dynamicFieldSet struct {
 	props       []string
 	propIndexes map[string]int
@@ -445,7 +629,7 @@ func (r *registry) QueryEntries(ctx context.Context, dbTX persistence.DBTX, fAct
 			entry.OnChainLocation = &pldapi.OnChainLocation{
 				BlockNumber:      *dbe.BlockNumber,
 				TransactionIndex: *dbe.TransactionIndex,
-				LogIndex:         *dbe.TransactionIndex,
+				LogIndex:         *dbe.LogIndex,
 			}
 		}
 		entries[i] = entry
@@ -495,7 +679,7 @@ func (r *registry) GetEntryProperties(ctx context.Context, dbTX persistence.DBTX
 			prop.OnChainLocation = &pldapi.OnChainLocation{
 				BlockNumber:      *dbp.BlockNumber,
 				TransactionIndex: *dbp.TransactionIndex,
-				LogIndex:         *dbp.TransactionIndex,
+				LogIndex:         *dbp.LogIndex,
 			}
 		}
 		props[i] = prop
@@ -548,4 +732,7 @@ func (r *registry) QueryEntriesWithProps(ctx context.Context, dbTX persistence.D
 func (r *registry) close() {
 	r.cancelCtx()
 	<-r.initDone
+	if r.publishDone != nil {
+		<-r.publishDone
+	}
 }
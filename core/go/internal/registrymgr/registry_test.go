@@ -168,7 +168,7 @@ func newSystemPropFor(id, name, value string) *prototk.RegistryProperty {
 }
 
 func TestUpsertRegistryRecordsRealDBok(t *testing.T) {
-	ctx, rm, tp, _, done := newTestRegistry(t, true)
+	ctx, rm, tp, mc, done := newTestRegistry(t, true)
 	defer done()
 
 	r, err := rm.GetRegistry(ctx, "test1")
@@ -251,6 +251,7 @@ func TestUpsertRegistryRecordsRealDBok(t *testing.T) {
 
 	// Make an entry inactive - this does NOT affect child entries (responsibility
 	// is on the registry plugin to do this if it wishes).
+	mc.transportMgr.On("RevalidatePeers").Return().Once()
 	rootEntry2.Active = false                      // make entry inactive
 	rootEntry2Props2.Active = false                // make one prop inactive
 	rootEntry2Props3 := randPropFor(rootEntry2.Id) // add prop as active
@@ -496,6 +497,7 @@ func TestUpsertRegistryRecordsInsertEntryFail(t *testing.T) {
 	defer done()
 
 	m.db.ExpectBegin()
+	m.db.ExpectQuery("SELECT.*reg_entries").WillReturnRows(sqlmock.NewRows([]string{}))
 	m.db.ExpectExec("INSERT.*reg_entries").WillReturnError(fmt.Errorf("pop"))
 
 	entry1 := &prototk.RegistryEntry{Id: randID(), Name: "entry1", Active: true}
@@ -505,11 +507,26 @@ func TestUpsertRegistryRecordsInsertEntryFail(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 }
 
+func TestUpsertRegistryRecordsQueryEntriesFail(t *testing.T) {
+	ctx, _, tp, m, done := newTestRegistry(t, false)
+	defer done()
+
+	m.db.ExpectBegin()
+	m.db.ExpectQuery("SELECT.*reg_entries").WillReturnError(fmt.Errorf("pop"))
+
+	entry1 := &prototk.RegistryEntry{Id: randID(), Name: "entry1", Active: true}
+	_, err := tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries: []*prototk.RegistryEntry{entry1},
+	})
+	assert.Regexp(t, "pop", err)
+}
+
 func TestUpsertRegistryRecordsInsertPropFail(t *testing.T) {
 	ctx, _, tp, m, done := newTestRegistry(t, false)
 	defer done()
 
 	m.db.ExpectBegin()
+	m.db.ExpectQuery("SELECT.*reg_props").WillReturnRows(sqlmock.NewRows([]string{}))
 	m.db.ExpectExec("INSERT.*reg_props").WillReturnError(fmt.Errorf("pop"))
 
 	_, err := tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
@@ -518,6 +535,140 @@ func TestUpsertRegistryRecordsInsertPropFail(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 }
 
+func TestUpsertRegistryRecordsQueryPropsFail(t *testing.T) {
+	ctx, _, tp, m, done := newTestRegistry(t, false)
+	defer done()
+
+	m.db.ExpectBegin()
+	m.db.ExpectQuery("SELECT.*reg_props").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Properties: []*prototk.RegistryProperty{randPropFor(randID())},
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestUpsertRegistryRecordsInsertHistoryFail(t *testing.T) {
+	ctx, _, tp, m, done := newTestRegistry(t, false)
+	defer done()
+
+	m.db.ExpectBegin()
+	m.db.ExpectQuery("SELECT.*reg_props").WillReturnRows(sqlmock.NewRows([]string{}))
+	m.db.ExpectExec("INSERT.*reg_props").WillReturnResult(driver.ResultNoRows)
+	m.db.ExpectQuery("INSERT.*reg_prop_history").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Properties: []*prototk.RegistryProperty{randPropFor(randID())},
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPropertyHistoryRealDB(t *testing.T) {
+	ctx, rm, tp, mc, done := newTestRegistry(t, true)
+	defer done()
+
+	r, err := rm.GetRegistry(ctx, "test1")
+	require.NoError(t, err)
+
+	entry1 := &prototk.RegistryEntry{Id: randID(), Name: "entry1", Location: randChainInfo(), Active: true}
+	prop1 := newPropFor(entry1.Id, "prop1", "value1")
+	prop1.Location = &prototk.OnChainEventLocation{TransactionHash: pldtypes.RandHex(32), BlockNumber: 100, TransactionIndex: 1, LogIndex: 2}
+	prop2 := newPropFor(entry1.Id, "prop2", "value2")
+	prop2.Location = &prototk.OnChainEventLocation{TransactionHash: pldtypes.RandHex(32), BlockNumber: 100, TransactionIndex: 1, LogIndex: 3}
+	_, err = tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries:    []*prototk.RegistryEntry{entry1},
+		Properties: []*prototk.RegistryProperty{prop1, prop2},
+	})
+	require.NoError(t, err)
+
+	// Re-stating the same value is not a change, but updating the value is (twice in one batch)
+	prop1Update1 := newPropFor(entry1.Id, "prop1", "value1a")
+	prop1Update1.Location = &prototk.OnChainEventLocation{TransactionHash: pldtypes.RandHex(32), BlockNumber: 200, TransactionIndex: 5, LogIndex: 6}
+	prop1Update2 := newPropFor(entry1.Id, "prop1", "value1b")
+	prop1Update2.Location = &prototk.OnChainEventLocation{TransactionHash: pldtypes.RandHex(32), BlockNumber: 200, TransactionIndex: 5, LogIndex: 7}
+	prop2.Active = false
+	_, err = tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Properties: []*prototk.RegistryProperty{newPropFor(entry1.Id, "prop2", "value2"), prop1Update1, prop1Update2, prop2},
+	})
+	require.NoError(t, err)
+
+	// Newest first by default
+	history, err := r.QueryPropertyHistory(ctx, rm.p.NOTX(), query.NewQueryBuilder().Limit(100).Query())
+	require.NoError(t, err)
+	require.Len(t, history, 5)
+	assert.Equal(t, "prop2", history[0].Name)
+	assert.False(t, history[0].Active)
+	assert.Equal(t, "value1b", history[1].Value)
+	assert.Equal(t, &pldapi.OnChainLocation{BlockNumber: 200, TransactionIndex: 5, LogIndex: 7}, history[1].OnChainLocation)
+	assert.Equal(t, "value1a", history[2].Value)
+	assert.Equal(t, "value2", history[3].Value)
+	assert.True(t, history[3].Active)
+	assert.Equal(t, "value1", history[4].Value)
+	assert.Equal(t, &pldapi.OnChainLocation{BlockNumber: 100, TransactionIndex: 1, LogIndex: 2}, history[4].OnChainLocation)
+	assert.Equal(t, "test1", history[4].Registry)
+	assert.Equal(t, entry1.Id, history[4].EntryID.HexString())
+	assert.Greater(t, history[0].Sequence, history[4].Sequence)
+
+	// Query the history of a property up to a block
+	history, err = r.QueryPropertyHistory(ctx, rm.p.NOTX(), query.NewQueryBuilder().
+		Equal("name", "prop1").
+		LessThan("blockNumber", 200).
+		Limit(100).Query())
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "value1", history[0].Value)
+
+	// Entries without on-chain locations are recorded too
+	entry2 := &prototk.RegistryEntry{Id: randID(), Name: "entry2", Active: true}
+	prop3 := newPropFor(entry2.Id, "prop3", "value3")
+	prop3.Location = nil
+	_, err = tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries:    []*prototk.RegistryEntry{entry2},
+		Properties: []*prototk.RegistryProperty{prop3},
+	})
+	require.NoError(t, err)
+	history, err = r.QueryPropertyHistory(ctx, rm.p.NOTX(), query.NewQueryBuilder().
+		Equal("entryId", entry2.Id).
+		Limit(100).Query())
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Nil(t, history[0].OnChainLocation)
+
+	// Revoking an entry is recorded against the entry, once even if re-stated in the batch
+	mc.transportMgr.On("RevalidatePeers").Return().Twice()
+	entry1.Active = false
+	entry1.Location = &prototk.OnChainEventLocation{TransactionHash: pldtypes.RandHex(32), BlockNumber: 300, TransactionIndex: 1, LogIndex: 1}
+	_, err = tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries: []*prototk.RegistryEntry{entry1, entry1},
+	})
+	require.NoError(t, err)
+	// An entry we learn about already revoked is recorded too
+	entry3 := &prototk.RegistryEntry{Id: randID(), Name: "entry3", Active: false}
+	_, err = tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries: []*prototk.RegistryEntry{entry3},
+	})
+	require.NoError(t, err)
+	history, err = r.QueryPropertyHistory(ctx, rm.p.NOTX(), query.NewQueryBuilder().
+		Equal("name", EntryActiveHistoryName).
+		Limit(100).Query())
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, entry3.Id, history[0].EntryID.HexString())
+	assert.False(t, history[0].Active)
+	assert.Equal(t, entry1.Id, history[1].EntryID.HexString())
+	assert.Equal(t, "false", history[1].Value)
+	assert.False(t, history[1].Active)
+	assert.Equal(t, &pldapi.OnChainLocation{BlockNumber: 300, TransactionIndex: 1, LogIndex: 1}, history[1].OnChainLocation)
+}
+
+func TestQueryPropertyHistoryNoLimit(t *testing.T) {
+	ctx, _, tp, _, done := newTestRegistry(t, false)
+	defer done()
+
+	_, err := tp.r.QueryPropertyHistory(ctx, tp.r.rm.p.NOTX(), query.NewQueryBuilder().Query())
+	assert.Regexp(t, "PD010721", err)
+}
+
 func TestQueryEntriesQueryNoLimit(t *testing.T) {
 	ctx, _, tp, _, done := newTestRegistry(t, false)
 	defer done()
@@ -637,6 +788,7 @@ func TestHandleEventBatchOk(t *testing.T) {
 
 	ctx, _, tp, _, done := newTestRegistry(t, false, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
 		mc.db.ExpectBegin()
+		mc.db.ExpectQuery("SELECT.*reg_entries").WillReturnRows(sqlmock.NewRows([]string{}))
 		mc.db.ExpectExec("INSERT.*reg_entries").WillReturnResult(driver.ResultNoRows)
 		mc.db.ExpectCommit()
 	})
@@ -668,8 +820,9 @@ func TestHandleEventBatchOk(t *testing.T) {
 		return &prototk.HandleRegistryEventsResponse{
 			Entries: []*prototk.RegistryEntry{
 				{
-					Id:   randID(),
-					Name: "node1",
+					Id:     randID(),
+					Name:   "node1",
+					Active: true,
 				},
 			},
 		}, nil
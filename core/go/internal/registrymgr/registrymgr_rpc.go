@@ -19,6 +19,7 @@ package registrymgr
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -35,7 +36,9 @@ func (rm *registryManager) initRPC() {
 		Add("reg_registries", rm.rpcListRegistries()).
 		Add("reg_queryEntries", rm.rpcQueryEntries()).
 		Add("reg_queryEntriesWithProps", rm.rpcQueryEntriesWithProps()).
-		Add("reg_getEntryProperties", rm.rpcGetEntryProperties())
+		Add("reg_getEntryProperties", rm.rpcGetEntryProperties()).
+		Add("reg_queryPropertyHistory", rm.rpcQueryPropertyHistory()).
+		Add("reg_publishLocalTransports", rm.rpcPublishLocalTransports())
 }
 
 func (rm *registryManager) rpcListRegistries() rpcserver.RPCHandler {
@@ -94,3 +97,24 @@ func (rm *registryManager) rpcGetEntryProperties() rpcserver.RPCHandler {
 		)
 	})
 }
+
+func (rm *registryManager) rpcQueryPropertyHistory() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		registryName string,
+		jq query.QueryJSON,
+	) ([]*pldapi.RegistryPropertyChange, error) {
+		return withRegistry(ctx, rm, registryName,
+			func(r components.Registry) ([]*pldapi.RegistryPropertyChange, error) {
+				return r.QueryPropertyHistory(ctx, rm.p.NOTX(), &jq)
+			},
+		)
+	})
+}
+
+func (rm *registryManager) rpcPublishLocalTransports() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		registryName string,
+	) ([]uuid.UUID, error) {
+		return rm.publishLocalTransports(ctx, registryName)
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
@@ -78,6 +79,22 @@ func TestRPCQuery(t *testing.T) {
 	require.Equal(t, "prop1", props[0].Name)
 	require.Equal(t, "value1", props[0].Value)
 
+	var history []*pldapi.RegistryPropertyChange
+	err = rpc.CallRPC(ctx, &history, "reg_queryPropertyHistory", tp.r.name,
+		query.NewQueryBuilder().Equal("entryId", entries[0].ID).Limit(10).Query())
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, "prop1", history[0].Name)
+	require.Equal(t, "value1", history[0].Value)
+	require.True(t, history[0].Active)
+
+	err = rpc.CallRPC(ctx, &history, "reg_queryPropertyHistory", "unknown", query.NewQueryBuilder().Limit(1).Query())
+	assert.Regexp(t, "PD012101", err)
+
+	var txIDs []uuid.UUID
+	err = rpc.CallRPC(ctx, &txIDs, "reg_publishLocalTransports", tp.r.name)
+	assert.Regexp(t, "PD012110", err)
+
 }
 
 func newTestRPCServer(t *testing.T, ctx context.Context, rm *registryManager) (rpcclient.Client, func()) {
@@ -62,7 +62,13 @@ func newTransportLookup(ctx context.Context, regName string, conf *pldconf.Regis
 	return tl, nil
 }
 
-func (tl *transportLookup) getNodeTransports(ctx context.Context, dbTX persistence.DBTX, r *registry, fullLookup string) ([]*components.RegistryNodeTransportEntry, error) {
+// The transports of a node resolved from a registry, along with all the properties of its entry
+type resolvedNode struct {
+	transports []*components.RegistryNodeTransportEntry
+	properties map[string]string
+}
+
+func (tl *transportLookup) getNodeTransports(ctx context.Context, dbTX persistence.DBTX, r *registry, fullLookup string) (*resolvedNode, error) {
 
 	lookup := fullLookup
 	if tl.requiredPrefix != "" {
@@ -95,6 +101,14 @@ func (tl *transportLookup) getNodeTransports(ctx context.Context, dbTX persisten
 			return nil, err
 		}
 		if len(entries) == 0 {
+			// A revoked entry (or a revoked parent) must not fall through to another registry
+			revoked, err := r.QueryEntries(ctx, dbTX, pldapi.ActiveFilterInactive, q.Query())
+			if err != nil {
+				return nil, err
+			}
+			if len(revoked) > 0 {
+				return nil, i18n.NewError(ctx, msgs.MsgRegistryNodeRevoked, fullLookup, tl.regName, revoked[0].ID)
+			}
 			log.L(ctx).Infof("Node lookup '%s' did not match an entry in registry '%s' (fullLookup='%s',requiredPrefix='%s',parentId='%s')",
 				entryName, tl.regName, lookup, tl.requiredPrefix, lookupParentID)
 			return nil, nil
@@ -125,5 +139,8 @@ func (tl *transportLookup) getNodeTransports(ctx context.Context, dbTX persisten
 			Details:   v,
 		})
 	}
-	return transports, nil
+	return &resolvedNode{
+		transports: transports,
+		properties: entry.Properties,
+	}, nil
 }
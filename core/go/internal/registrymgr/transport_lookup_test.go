@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
@@ -27,7 +28,7 @@ import (
 )
 
 func TestGetNodeTransportsDefaultsRealDB(t *testing.T) {
-	ctx, rm, tp, _, done := newTestRegistry(t, true)
+	ctx, rm, tp, mc, done := newTestRegistry(t, true)
 	defer done()
 
 	node1Entry := &prototk.RegistryEntry{Id: randID(), Name: "node1", Location: randChainInfo(), Active: true}
@@ -71,14 +72,26 @@ func TestGetNodeTransportsDefaultsRealDB(t *testing.T) {
 		Details:   "proto things",
 	})
 
+	props, err := rm.GetNodeProperties(ctx, "node1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"organization":         "Widgets 4 You",
+		"transport.websockets": "things and stuff",
+	}, props)
+
 	_, err = rm.GetNodeTransports(ctx, "node3")
 	require.Regexp(t, "PD012100", err)
 
+	_, err = rm.GetNodeProperties(ctx, "node3")
+	require.Regexp(t, "PD012100", err)
+
 	// check cache function and clear
 	transports, err = rm.GetNodeTransports(ctx, "node1")
 	require.NoError(t, err)
 	require.Equal(t, "websockets", transports[0].Transport)
 
+	// Revoking the entry causes connected peers to be re-validated
+	mc.transportMgr.On("RevalidatePeers").Return().Once()
 	node1Entry.Active = false
 	_, err = tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
 		Entries: []*prototk.RegistryEntry{node1Entry},
@@ -87,12 +100,12 @@ func TestGetNodeTransportsDefaultsRealDB(t *testing.T) {
 
 	// Cache is cleared, and we no longer see it as it was inactivated
 	_, err = rm.GetNodeTransports(ctx, "node1")
-	require.Regexp(t, "PD012100", err)
+	require.Regexp(t, "PD012115.*node1", err)
 
 }
 
 func TestGetNodeTransportsCustomSettingsRealDB(t *testing.T) {
-	ctx, rm, tp, _, done := newTestRegistry(t, true, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
+	ctx, rm, tp, mc, done := newTestRegistry(t, true, func(mc *mockComponents, conf *pldconf.RegistryManagerConfig, regConf *prototk.RegistryConfig) {
 		conf.Registries["test1"].Transports = pldconf.RegistryTransportsConfig{
 			RequiredPrefix:    "network1.",
 			HierarchySplitter: ".",
@@ -134,6 +147,22 @@ func TestGetNodeTransportsCustomSettingsRealDB(t *testing.T) {
 	_, err = rm.GetNodeTransports(ctx, "network2.org_a.node1")
 	require.Regexp(t, "PD012100", err)
 
+	// Revoking the parent means none of the children can be resolved
+	mc.transportMgr.On("RevalidatePeers").Return().Once()
+	orgAEntry.Active = false
+	_, err = tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries: []*prototk.RegistryEntry{orgAEntry},
+	})
+	require.NoError(t, err)
+
+	_, err = rm.GetNodeTransports(ctx, "network1.org_a.node2")
+	require.Regexp(t, "PD012115.*network1.org_a.node2", err)
+
+	// Other organizations are unaffected
+	transports, err = rm.GetNodeTransports(ctx, "network1.org_b.node1")
+	require.NoError(t, err)
+	require.Len(t, transports, 1)
+
 }
 
 func TestGetNodeTransportsErr(t *testing.T) {
@@ -146,6 +175,17 @@ func TestGetNodeTransportsErr(t *testing.T) {
 	require.Regexp(t, "pop", err)
 }
 
+func TestGetNodeTransportsRevokedQueryErr(t *testing.T) {
+	ctx, rm, _, m, done := newTestRegistry(t, false)
+	defer done()
+
+	m.db.ExpectQuery("SELECT.*reg_entries").WillReturnRows(sqlmock.NewRows([]string{}))
+	m.db.ExpectQuery("SELECT.*reg_entries").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := rm.GetNodeTransports(ctx, "node1")
+	require.Regexp(t, "pop", err)
+}
+
 func TestBadTransportLookupPropertyRegexp(t *testing.T) {
 	_, rm, mc, done := newTestRegistryManager(t, false, &pldconf.RegistryManagerConfig{
 		Registries: map[string]*pldconf.RegistryConfig{
@@ -36,6 +36,7 @@ import (
 
 type transactionStateRecord struct {
 	pldapi.StateBase
+	Transaction    uuid.UUID         `gorm:"column:transaction"`
 	State          pldtypes.HexBytes `gorm:"column:state"`
 	RecordType     string            `gorm:"column:record_type"`
 	SpentState     pldtypes.HexBytes `gorm:"column:spent_state"`
@@ -130,6 +130,15 @@ func (ss *stateManager) WriteStateFinalizations(ctx context.Context, dbTX persis
 }
 
 func (ss *stateManager) GetTransactionStates(ctx context.Context, dbTX persistence.DBTX, txID uuid.UUID) (*pldapi.TransactionStates, error) {
+	txStates, err := ss.GetTransactionStatesBatch(ctx, dbTX, []uuid.UUID{txID})
+	if err != nil {
+		return nil, err
+	}
+	return txStates[txID], nil
+}
+
+// Returns an entry for every transaction supplied, in a single query
+func (ss *stateManager) GetTransactionStatesBatch(ctx context.Context, dbTX persistence.DBTX, txIDs []uuid.UUID) (map[uuid.UUID]*pldapi.TransactionStates, error) {
 
 	// We query from the records table, joining in the other fields
 	var records []*transactionStateRecord
@@ -138,17 +147,29 @@ func (ss *stateManager) GetTransactionStates(ctx context.Context, dbTX persisten
 		// This query joins across three tables in a single query - pushing the complexity to the DB.
 		// The reason we have three tables is to make the queries for available states simpler.
 		Raw(`SELECT * from "states" RIGHT JOIN ( `+
-			`SELECT "transaction", "state", 'spent'     AS "record_type" FROM "state_spend_records"   WHERE "transaction" = ? UNION ALL `+
-			`SELECT "transaction", "state", 'read'      AS "record_type" FROM "state_read_records"    WHERE "transaction" = ? UNION ALL `+
-			`SELECT "transaction", "state", 'confirmed' AS "record_type" FROM "state_confirm_records" WHERE "transaction" = ? UNION ALL `+
-			`SELECT "transaction", "state", 'info'      AS "record_type" FROM "state_info_records"    WHERE "transaction" = ? ) "records" `+
+			`SELECT "transaction", "state", 'spent'     AS "record_type" FROM "state_spend_records"   WHERE "transaction" IN (?) UNION ALL `+
+			`SELECT "transaction", "state", 'read'      AS "record_type" FROM "state_read_records"    WHERE "transaction" IN (?) UNION ALL `+
+			`SELECT "transaction", "state", 'confirmed' AS "record_type" FROM "state_confirm_records" WHERE "transaction" IN (?) UNION ALL `+
+			`SELECT "transaction", "state", 'info'      AS "record_type" FROM "state_info_records"    WHERE "transaction" IN (?) ) "records" `+
 			`ON "states"."id" = "records"."state"`,
-			txID, txID, txID, txID).
+			txIDs, txIDs, txIDs, txIDs).
 		Scan(&records).
 		Error
 	if err != nil {
 		return nil, err
 	}
+	recordsByTx := make(map[uuid.UUID][]*transactionStateRecord, len(txIDs))
+	for _, r := range records {
+		recordsByTx[r.Transaction] = append(recordsByTx[r.Transaction], r)
+	}
+	results := make(map[uuid.UUID]*pldapi.TransactionStates, len(txIDs))
+	for _, txID := range txIDs {
+		results[txID] = buildTransactionStates(recordsByTx[txID])
+	}
+	return results, nil
+}
+
+func buildTransactionStates(records []*transactionStateRecord) *pldapi.TransactionStates {
 	hasUnavailable := false
 	unavailable := &pldapi.UnavailableStates{}
 	txStates := &pldapi.TransactionStates{
@@ -190,6 +211,5 @@ func (ss *stateManager) GetTransactionStates(ctx context.Context, dbTX persisten
 	if hasUnavailable {
 		txStates.Unavailable = unavailable
 	}
-	return txStates, nil
-
+	return txStates
 }
@@ -135,6 +135,40 @@ func TestGetTransactionStatesUnavailable(t *testing.T) {
 	require.Equal(t, []pldtypes.HexBytes{stateID4}, txStates.Unavailable.Info)
 }
 
+func TestGetTransactionStatesBatch(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	txID1 := uuid.New()
+	txID2 := uuid.New()
+	txID3 := uuid.New()
+	stateID1 := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	stateID2 := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	stateID3 := pldtypes.HexBytes(pldtypes.RandBytes(32))
+
+	err := ss.WriteStateFinalizations(ctx, ss.p.NOTX(),
+		[]*pldapi.StateSpendRecord{
+			{DomainName: "domain1", State: stateID1, Transaction: txID1},
+		},
+		[]*pldapi.StateReadRecord{},
+		[]*pldapi.StateConfirmRecord{
+			{DomainName: "domain1", State: stateID2, Transaction: txID1},
+			{DomainName: "domain1", State: stateID3, Transaction: txID2},
+		},
+		[]*pldapi.StateInfoRecord{})
+	require.NoError(t, err)
+
+	txStates, err := ss.GetTransactionStatesBatch(ctx, ss.p.NOTX(), []uuid.UUID{txID1, txID2, txID3})
+	require.NoError(t, err)
+	require.Len(t, txStates, 3)
+	assert.Equal(t, []pldtypes.HexBytes{stateID1}, txStates[txID1].Unavailable.Spent)
+	assert.Equal(t, []pldtypes.HexBytes{stateID2}, txStates[txID1].Unavailable.Confirmed)
+	assert.Equal(t, []pldtypes.HexBytes{stateID3}, txStates[txID2].Unavailable.Confirmed)
+	assert.Empty(t, txStates[txID2].Unavailable.Spent)
+	assert.True(t, txStates[txID3].None)
+}
+
 func TestGetTransactionStatesFail(t *testing.T) {
 
 	ctx, ss, db, _, done := newDBMockStateManager(t)
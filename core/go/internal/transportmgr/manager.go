@@ -28,6 +28,7 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/filters"
 	"github.com/kaleido-io/paladin/core/internal/flushwriter"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/internal/transportmgr/metrics"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
@@ -48,6 +49,7 @@ type transportManager struct {
 	mux       sync.Mutex
 
 	rpcModule        *rpcserver.RPCModule
+	metrics          metrics.TransportManagerMetrics
 	conf             *pldconf.TransportManagerConfig
 	localNodeName    string
 	registryManager  components.RegistryManager
@@ -66,6 +68,7 @@ type transportManager struct {
 	peersLock      sync.RWMutex
 	peers          map[string]*peer
 	peerReaperDone chan struct{}
+	peerRevalidate chan struct{}
 
 	reliableMsgWriter flushwriter.Writer[*reliableMsgOp, *noResult]
 
@@ -78,6 +81,18 @@ type transportManager struct {
 	senderBufferLen         int
 	reliableMessageResend   time.Duration
 	reliableMessagePageSize int
+
+	encryptionEnabled  bool
+	encryptionRequired bool
+	nodeKeys           []*nodeKey
+	nodeKeysProperty   string
+	nodeKeysJSON       string
+	signingKeyLock     sync.Mutex
+	signingKey         *nodeKey
+	signingKeyChecked  time.Time
+	signingKeyRefresh  time.Duration
+
+	reliableMetricNodes map[string]bool // only accessed by the peer reaper
 }
 
 var reliableMessageFilters = filters.FieldMap{
@@ -101,6 +116,7 @@ func NewTransportManager(bgCtx context.Context, conf *pldconf.TransportManagerCo
 		transportsByID:          make(map[uuid.UUID]*transport),
 		transportsByName:        make(map[string]*transport),
 		peers:                   make(map[string]*peer),
+		peerRevalidate:          make(chan struct{}, 1),
 		senderBufferLen:         confutil.IntMin(conf.SendQueueLen, 0, *pldconf.TransportManagerDefaults.SendQueueLen),
 		reliableMessageResend:   confutil.DurationMin(conf.ReliableMessageResend, 100*time.Millisecond, *pldconf.TransportManagerDefaults.ReliableMessageResend),
 		sendShortRetry:          retry.NewRetryLimited(&conf.SendRetry, &pldconf.TransportManagerDefaults.SendRetry),
@@ -118,6 +134,10 @@ func (tm *transportManager) PreInit(pic components.PreInitComponents) (*componen
 	if tm.localNodeName == "" {
 		return nil, i18n.NewError(tm.bgCtx, msgs.MsgTransportNodeNameNotConfigured)
 	}
+	if err := tm.initPayloadEncryption(tm.bgCtx, &tm.conf.PayloadEncryption); err != nil {
+		return nil, err
+	}
+	tm.metrics = metrics.InitMetrics(tm.bgCtx, pic.MetricsManager().Registry())
 	tm.initRPC()
 	return &components.ManagerInitResult{
 		RPCModules: []*rpcserver.RPCModule{tm.rpcModule},
@@ -190,7 +210,7 @@ func (tm *transportManager) ConfiguredTransports() map[string]*pldconf.PluginCon
 	return pluginConf
 }
 
-func (tm *transportManager) getTransportNames() []string {
+func (tm *transportManager) LocalTransportNames() []string {
 	tm.mux.Lock()
 	defer tm.mux.Unlock()
 
@@ -212,7 +232,7 @@ func (tm *transportManager) getTransportByName(ctx context.Context, transportNam
 	return t, nil
 }
 
-func (tm *transportManager) getLocalTransportDetails(ctx context.Context, transportName string) (string, error) {
+func (tm *transportManager) GetLocalTransportDetails(ctx context.Context, transportName string) (string, error) {
 	t, err := tm.getTransportByName(ctx, transportName)
 	if err != nil {
 		return "", err
@@ -351,11 +371,30 @@ func (tm *transportManager) writeAcks(ctx context.Context, dbTX persistence.DBTX
 		log.L(ctx).Infof("ack received for message %s", ack.MessageID)
 		ack.Time = pldtypes.TimestampNow()
 	}
-	return dbTX.DB().
+	return tm.insertAcks(ctx, dbTX, acks...)
+}
+
+// All acks (and nacks) must be inserted here, so the acked flag on the message is kept in
+// step with the ack for the backlog summary. If an ack already exists then that one stands.
+func (tm *transportManager) insertAcks(ctx context.Context, dbTX persistence.DBTX, acks ...*pldapi.ReliableMessageAck) error {
+	ids := make([]uuid.UUID, len(acks))
+	for i, ack := range acks {
+		ids[i] = ack.MessageID
+	}
+	err := dbTX.DB().
 		WithContext(ctx).
 		Clauses(clause.OnConflict{DoNothing: true}).
 		Create(acks).
 		Error
+	if err == nil {
+		err = dbTX.DB().
+			WithContext(ctx).
+			Table("reliable_msgs").
+			Where("id IN (?)", ids).
+			Update("acked", true).
+			Error
+	}
+	return err
 }
 
 func (tm *transportManager) getReliableMessageByID(ctx context.Context, dbTX persistence.DBTX, id uuid.UUID) (*pldapi.ReliableMessage, error) {
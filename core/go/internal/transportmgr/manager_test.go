@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/metrics"
 	"github.com/kaleido-io/paladin/core/mocks/componentsmocks"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
@@ -47,6 +48,7 @@ type mockComponents struct {
 	privateTxManager *componentsmocks.PrivateTxManager
 	identityResolver *componentsmocks.IdentityResolver
 	groupManager     *componentsmocks.GroupManager
+	metricsManager   metrics.Metrics
 }
 
 func newMockComponents(t *testing.T, realDB bool) *mockComponents {
@@ -79,6 +81,8 @@ func newMockComponents(t *testing.T, realDB bool) *mockComponents {
 	mc.c.On("PrivateTxManager").Return(mc.privateTxManager).Maybe()
 	mc.c.On("IdentityResolver").Return(mc.identityResolver).Maybe()
 	mc.c.On("GroupManager").Return(mc.groupManager).Maybe()
+	mc.metricsManager = metrics.NewMetricsManager(context.Background())
+	mc.c.On("MetricsManager").Return(mc.metricsManager).Maybe()
 	return mc
 }
 
@@ -179,7 +183,7 @@ func TestConfigureTransportFail(t *testing.T) {
 func TestGetLocalTransportDetailsNotFound(t *testing.T) {
 	tm := NewTransportManager(context.Background(), &pldconf.TransportManagerConfig{}).(*transportManager)
 
-	_, err := tm.getLocalTransportDetails(context.Background(), "nope")
+	_, err := tm.GetLocalTransportDetails(context.Background(), "nope")
 	assert.Regexp(t, "PD012001", err)
 }
 
@@ -191,7 +195,7 @@ func TestGetLocalTransportDetailsNotFail(t *testing.T) {
 		return nil, fmt.Errorf("pop")
 	}
 
-	_, err := tm.getLocalTransportDetails(ctx, tp.t.name)
+	_, err := tm.GetLocalTransportDetails(ctx, tp.t.name)
 	assert.Regexp(t, "pop", err)
 }
 
@@ -0,0 +1,88 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type TransportManagerMetrics interface {
+	SetReliableBacklog(peer string, backlog uint64, oldestUnackedAge time.Duration)
+	DeleteReliableBacklog(peer string)
+	IncSendErrors(peer string)
+	IncReliableRedrives(peer string)
+	IncReliableAbandoned(peer string)
+}
+
+var METRICS_SUBSYSTEM = "transport_manager"
+
+type transportManagerMetrics struct {
+	reliableBacklog   *prometheus.GaugeVec
+	reliableOldestAge *prometheus.GaugeVec
+	sendErrors        *prometheus.CounterVec
+	reliableRedrives  *prometheus.CounterVec
+	reliableAbandoned *prometheus.CounterVec
+}
+
+func InitMetrics(ctx context.Context, registry *prometheus.Registry) *transportManagerMetrics {
+	metrics := &transportManagerMetrics{}
+
+	labels := []string{"peer"}
+	metrics.reliableBacklog = prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "reliable_backlog",
+		Help: "Reliable messages waiting for an ack from the peer", Subsystem: METRICS_SUBSYSTEM}, labels)
+	metrics.reliableOldestAge = prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "reliable_oldest_unacked_age_seconds",
+		Help: "Age of the oldest reliable message waiting for an ack from the peer", Subsystem: METRICS_SUBSYSTEM}, labels)
+	metrics.sendErrors = prometheus.NewCounterVec(prometheus.CounterOpts{Name: "send_errors_total",
+		Help: "Sends to the peer that failed after retry", Subsystem: METRICS_SUBSYSTEM}, labels)
+	metrics.reliableRedrives = prometheus.NewCounterVec(prometheus.CounterOpts{Name: "reliable_redrives_total",
+		Help: "Manual resend requests for reliable messages to the peer", Subsystem: METRICS_SUBSYSTEM}, labels)
+	metrics.reliableAbandoned = prometheus.NewCounterVec(prometheus.CounterOpts{Name: "reliable_abandoned_total",
+		Help: "Reliable messages to the peer abandoned without an ack", Subsystem: METRICS_SUBSYSTEM}, labels)
+
+	registry.MustRegister(metrics.reliableBacklog)
+	registry.MustRegister(metrics.reliableOldestAge)
+	registry.MustRegister(metrics.sendErrors)
+	registry.MustRegister(metrics.reliableRedrives)
+	registry.MustRegister(metrics.reliableAbandoned)
+	return metrics
+}
+
+func (tmm *transportManagerMetrics) SetReliableBacklog(peer string, backlog uint64, oldestUnackedAge time.Duration) {
+	labels := prometheus.Labels{"peer": peer}
+	tmm.reliableBacklog.With(labels).Set(float64(backlog))
+	tmm.reliableOldestAge.With(labels).Set(oldestUnackedAge.Seconds())
+}
+
+func (tmm *transportManagerMetrics) DeleteReliableBacklog(peer string) {
+	labels := prometheus.Labels{"peer": peer}
+	tmm.reliableBacklog.Delete(labels)
+	tmm.reliableOldestAge.Delete(labels)
+}
+
+func (tmm *transportManagerMetrics) IncSendErrors(peer string) {
+	tmm.sendErrors.With(prometheus.Labels{"peer": peer}).Inc()
+}
+
+func (tmm *transportManagerMetrics) IncReliableRedrives(peer string) {
+	tmm.reliableRedrives.With(prometheus.Labels{"peer": peer}).Inc()
+}
+
+func (tmm *transportManagerMetrics) IncReliableAbandoned(peer string) {
+	tmm.reliableAbandoned.With(prometheus.Labels{"peer": peer}).Inc()
+}
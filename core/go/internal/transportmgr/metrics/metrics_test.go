@@ -0,0 +1,63 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics := InitMetrics(context.Background(), registry)
+	assert.NotNil(t, metrics)
+
+	metrics.SetReliableBacklog("node2", 5, 90*time.Second)
+	metrics.IncSendErrors("node2")
+	metrics.IncSendErrors("node2")
+	metrics.IncReliableRedrives("node2")
+	metrics.IncReliableAbandoned("node2")
+
+	metricFamilies, err := registry.Gather()
+	assert.NoError(t, err, "Unexpected error gathering metrics")
+	values := map[string]float64{}
+	for _, mf := range metricFamilies {
+		m := mf.GetMetric()[0]
+		assert.Equal(t, "node2", m.GetLabel()[0].GetValue())
+		if m.GetGauge() != nil {
+			values[mf.GetName()] = m.GetGauge().GetValue()
+		} else {
+			values[mf.GetName()] = m.GetCounter().GetValue()
+		}
+	}
+	assert.Equal(t, map[string]float64{
+		"transport_manager_reliable_backlog":                    5,
+		"transport_manager_reliable_oldest_unacked_age_seconds": 90,
+		"transport_manager_send_errors_total":                   2,
+		"transport_manager_reliable_redrives_total":             1,
+		"transport_manager_reliable_abandoned_total":            1,
+	}, values)
+
+	// Deleting a peer clears its backlog gauges, but not the counters
+	metrics.DeleteReliableBacklog("node2")
+	metricFamilies, err = registry.Gather()
+	assert.NoError(t, err, "Unexpected error gathering metrics")
+	assert.Len(t, metricFamilies, 3)
+}
@@ -0,0 +1,355 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"golang.org/x/crypto/hkdf"
+	"google.golang.org/protobuf/proto"
+)
+
+// Payloads are sealed by the transport manager, so the protection is the same whichever
+// transport carries the message, and however many hops (relays, TLS terminating proxies)
+// there are between the nodes:
+//
+//   - The payload is encrypted with AES-256-GCM, using a key agreed by ECDH between an ephemeral
+//     key and the current key published in the registry by the recipient
+//   - The sender signs the message fields and the encrypted payload with its own node key,
+//     which the recipient verifies against the keys the sender has published in the registry
+//
+// Each node can hold multiple keys to support rollover. The first configured key is the current
+// key, and the others are retained to decrypt messages sent by nodes that have not yet seen the
+// new key in the registry.
+const payloadSealKDFInfo = "paladin-payload-seal"
+
+// How long we use a signing key that is not our current key, before checking if our current key has been published
+const payloadSigningKeyRefresh = 30 * time.Second
+
+type nodeKey struct {
+	id         string
+	privateKey *ecdsa.PrivateKey
+	ecdhKey    *ecdh.PrivateKey
+}
+
+type publishedKey struct {
+	id        string
+	publicKey *ecdsa.PublicKey
+	ecdhKey   *ecdh.PublicKey
+}
+
+// Keys are identified by a hash of the PKIX DER encoding that is published
+func payloadKeyID(der []byte) string {
+	hash := sha256.Sum256(der)
+	return hex.EncodeToString(hash[0:16])
+}
+
+func (tm *transportManager) initPayloadEncryption(ctx context.Context, conf *pldconf.TransportEncryptionConfig) error {
+	defaults := &pldconf.TransportManagerDefaults.PayloadEncryption
+	tm.encryptionEnabled = confutil.Bool(conf.Enabled, *defaults.Enabled)
+	tm.encryptionRequired = confutil.Bool(conf.Required, *defaults.Required)
+	tm.signingKeyRefresh = payloadSigningKeyRefresh
+	if !tm.encryptionEnabled {
+		return nil
+	}
+	if len(conf.KeyFiles) == 0 {
+		return i18n.NewError(ctx, msgs.MsgTransportEncryptionKeyRequired)
+	}
+	publicKeys := make([]pldtypes.HexBytes, len(conf.KeyFiles))
+	for i, keyFile := range conf.KeyFiles {
+		key, err := loadNodeKey(ctx, keyFile)
+		if err != nil {
+			return err
+		}
+		publicKeys[i], _ = x509.MarshalPKIXPublicKey(&key.PublicKey)
+		ecdhKey, _ := key.ECDH() // cannot fail as we have checked the curve
+		tm.nodeKeys = append(tm.nodeKeys, &nodeKey{
+			id:         payloadKeyID(publicKeys[i]),
+			privateKey: key,
+			ecdhKey:    ecdhKey,
+		})
+	}
+	tm.nodeKeysProperty = confutil.StringNotEmpty(conf.PropertyName, *defaults.PropertyName)
+	publicKeysJSON, _ := json.Marshal(publicKeys)
+	tm.nodeKeysJSON = string(publicKeysJSON)
+	return nil
+}
+
+func loadNodeKey(ctx context.Context, keyFile string) (*ecdsa.PrivateKey, error) {
+	pemBytes, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgTransportEncryptionKeyInvalid, keyFile)
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportEncryptionKeyInvalid, keyFile)
+	}
+	var key any
+	if block.Type == "EC PRIVATE KEY" {
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	} else {
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgTransportEncryptionKeyInvalid, keyFile)
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok || ecKey.Curve != elliptic.P256() {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportEncryptionKeyInvalid, keyFile)
+	}
+	return ecKey, nil
+}
+
+// See docs in components package
+func (tm *transportManager) LocalNodeKeysProperty() (string, string) {
+	return tm.nodeKeysProperty, tm.nodeKeysJSON
+}
+
+// Returns the keys published by a node in the order they were published (current key first),
+// or an empty list if the node does not publish any keys
+func (tm *transportManager) getPublishedKeys(ctx context.Context, node string) ([]*publishedKey, error) {
+	props, err := tm.registryManager.GetNodeProperties(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	keysJSON, ok := props[tm.nodeKeysProperty]
+	if !ok {
+		return nil, nil
+	}
+	var publicKeys []pldtypes.HexBytes
+	if err := json.Unmarshal([]byte(keysJSON), &publicKeys); err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgTransportPeerKeysInvalid, node)
+	}
+	keys := make([]*publishedKey, len(publicKeys))
+	for i, der := range publicKeys {
+		key, err := x509.ParsePKIXPublicKey(der)
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if err != nil || !ok || ecKey.Curve != elliptic.P256() {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgTransportPeerKeysInvalid, node)
+		}
+		ecdhKey, _ := ecKey.ECDH() // cannot fail as we have checked the curve
+		keys[i] = &publishedKey{
+			id:        payloadKeyID(der),
+			publicKey: ecKey,
+			ecdhKey:   ecdhKey,
+		}
+	}
+	return keys, nil
+}
+
+// We sign with the first of our keys that other nodes can see in the registry, so that
+// we only move to a new key once it has been published.
+//
+// The result is cached, so we do not look up our own keys for every message. Once our current key
+// is published there is nothing newer to move to, and while we are using an older key we check
+// again after a refresh interval.
+func (tm *transportManager) getSigningKey(ctx context.Context) *nodeKey {
+	tm.signingKeyLock.Lock()
+	defer tm.signingKeyLock.Unlock()
+
+	if tm.signingKey != nil && (tm.signingKey == tm.nodeKeys[0] || time.Since(tm.signingKeyChecked) < tm.signingKeyRefresh) {
+		return tm.signingKey
+	}
+	published, err := tm.getPublishedKeys(ctx, tm.localNodeName)
+	if err != nil {
+		log.L(ctx).Debugf("Unable to resolve published keys for local node: %s", err)
+	}
+	for _, key := range tm.nodeKeys {
+		for _, pk := range published {
+			if pk.id == key.id {
+				tm.signingKey = key
+				tm.signingKeyChecked = time.Now()
+				return key
+			}
+		}
+	}
+	return tm.nodeKeys[0]
+}
+
+// Fields are length prefixed, so that the boundaries between them cannot be moved
+func payloadSealHeader(fromNode, toNode string, msg *prototk.PaladinMsg, seal *prototk.PayloadSeal) []byte {
+	var header []byte
+	for _, field := range [][]byte{
+		[]byte(payloadSealKDFInfo),
+		[]byte(fromNode),
+		[]byte(toNode),
+		[]byte(msg.MessageId),
+		[]byte(pldtypes.StrOrEmpty(msg.CorrelationId)),
+		[]byte(msg.Component.String()),
+		[]byte(msg.MessageType),
+		[]byte(seal.SenderKeyId),
+		[]byte(seal.RecipientKeyId),
+		seal.EphemeralKey,
+		seal.Nonce,
+	} {
+		header = binary.BigEndian.AppendUint32(header, uint32(len(field)))
+		header = append(header, field...)
+	}
+	return header
+}
+
+func payloadSealSigningHash(header, ciphertext []byte) []byte {
+	hash := sha256.New()
+	hash.Write(header)
+	hash.Write(ciphertext)
+	return hash.Sum(nil)
+}
+
+func payloadCipher(shared, ephemeralKey []byte) cipher.AEAD {
+	aesKey := make([]byte, 32)
+	kdf := hkdf.New(sha256.New, shared, ephemeralKey, []byte(payloadSealKDFInfo))
+	_, _ = io.ReadFull(kdf, aesKey) // well within the HKDF-SHA256 output limit
+	block, _ := aes.NewCipher(aesKey)
+	aead, _ := cipher.NewGCM(block) // cannot fail with the standard nonce size
+	return aead
+}
+
+// Returns the message to send to the node, which is sealed if we have encryption enabled
+// and the node publishes keys (or an error if encryption is required and it does not).
+func (tm *transportManager) sealPayload(ctx context.Context, toNode string, msg *prototk.PaladinMsg) (*prototk.PaladinMsg, error) {
+	if !tm.encryptionEnabled {
+		return msg, nil
+	}
+	recipientKeys, err := tm.getPublishedKeys(ctx, toNode)
+	if err != nil {
+		return nil, err
+	}
+	if len(recipientKeys) == 0 {
+		if tm.encryptionRequired {
+			return nil, i18n.NewError(ctx, msgs.MsgTransportPeerKeysRequired, toNode)
+		}
+		log.L(ctx).Debugf("Sending message %s unencrypted as node '%s' does not publish keys", msg.MessageId, toNode)
+		return msg, nil
+	}
+	recipientKey := recipientKeys[0]
+	signingKey := tm.getSigningKey(ctx)
+
+	ephemeralKey, _ := ecdh.P256().GenerateKey(rand.Reader) // cannot fail with the system random source
+	shared, _ := ephemeralKey.ECDH(recipientKey.ecdhKey)    // cannot fail as both keys are P-256
+	seal := &prototk.PayloadSeal{
+		SenderKeyId:    signingKey.id,
+		RecipientKeyId: recipientKey.id,
+		EphemeralKey:   ephemeralKey.PublicKey().Bytes(),
+		Nonce:          make([]byte, 12),
+	}
+	_, _ = rand.Read(seal.Nonce)
+	header := payloadSealHeader(tm.localNodeName, toNode, msg, seal)
+	ciphertext := payloadCipher(shared, seal.EphemeralKey).Seal(nil, seal.Nonce, msg.Payload, header)
+	seal.Signature, _ = ecdsa.SignASN1(rand.Reader, signingKey.privateKey, payloadSealSigningHash(header, ciphertext)) // cannot fail for a valid key
+
+	sealed := proto.Clone(msg).(*prototk.PaladinMsg)
+	sealed.Payload = ciphertext
+	sealed.Seal = seal
+	return sealed, nil
+}
+
+// Verifies the signature of a sealed message against the keys published by the sending node,
+// and returns the message with the decrypted payload.
+//
+// Unsealed messages are returned as-is, unless encryption is required, or we have encryption enabled
+// and the sender publishes keys. A node that publishes keys always seals messages to a node that
+// publishes keys, so an unsealed message from it has had its seal stripped (by a relay or proxy).
+func (tm *transportManager) openPayload(ctx context.Context, fromNode string, msg *prototk.PaladinMsg) (*prototk.PaladinMsg, error) {
+	seal := msg.GetSeal()
+	if seal == nil {
+		if tm.encryptionRequired {
+			return nil, i18n.NewError(ctx, msgs.MsgTransportSealRequired, fromNode)
+		}
+		if tm.encryptionEnabled {
+			senderKeys, err := tm.getPublishedKeys(ctx, fromNode)
+			if err != nil {
+				return nil, err
+			}
+			if len(senderKeys) > 0 {
+				return nil, i18n.NewError(ctx, msgs.MsgTransportSealMissing, fromNode)
+			}
+		}
+		return msg, nil
+	}
+	if !tm.encryptionEnabled {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportSealNotEnabled, fromNode)
+	}
+
+	var localKey *nodeKey
+	for _, key := range tm.nodeKeys {
+		if key.id == seal.RecipientKeyId {
+			localKey = key
+		}
+	}
+	if localKey == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportLocalKeyNotFound, fromNode, seal.RecipientKeyId)
+	}
+
+	senderKeys, err := tm.getPublishedKeys(ctx, fromNode)
+	if err != nil {
+		return nil, err
+	}
+	var senderKey *publishedKey
+	for _, key := range senderKeys {
+		if key.id == seal.SenderKeyId {
+			senderKey = key
+		}
+	}
+	if senderKey == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportPeerKeyNotPublished, seal.SenderKeyId, fromNode)
+	}
+
+	// The signature is checked before we do anything with the payload
+	header := payloadSealHeader(fromNode, tm.localNodeName, msg, seal)
+	if !ecdsa.VerifyASN1(senderKey.publicKey, payloadSealSigningHash(header, msg.Payload), seal.Signature) {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportSealInvalid, fromNode)
+	}
+	ephemeralKey, err := ecdh.P256().NewPublicKey(seal.EphemeralKey)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgTransportSealInvalid, fromNode)
+	}
+	shared, _ := localKey.ecdhKey.ECDH(ephemeralKey) // cannot fail as both keys are P-256
+	aead := payloadCipher(shared, seal.EphemeralKey)
+	if len(seal.Nonce) != aead.NonceSize() {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportSealInvalid, fromNode)
+	}
+	plaintext, err := aead.Open(nil, seal.Nonce, msg.Payload, header)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgTransportSealInvalid, fromNode)
+	}
+
+	opened := proto.Clone(msg).(*prototk.PaladinMsg)
+	opened.Payload = plaintext
+	opened.Seal = nil
+	return opened, nil
+}
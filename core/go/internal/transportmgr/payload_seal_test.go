@@ -0,0 +1,514 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentsmocks"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestKeyFile(t *testing.T, curve elliptic.Curve, pkcs8 bool) string {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	block := &pem.Block{Type: "EC PRIVATE KEY"}
+	if pkcs8 {
+		block.Type = "PRIVATE KEY"
+		block.Bytes, err = x509.MarshalPKCS8PrivateKey(key)
+	} else {
+		block.Bytes, err = x509.MarshalECPrivateKey(key)
+	}
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "node.key")
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(block), 0600)
+	require.NoError(t, err)
+	return keyFile
+}
+
+// A registry holding the properties of each node, that tests can update
+type testKeyRegistry struct {
+	rm    *componentsmocks.RegistryManager
+	props map[string]map[string]string
+}
+
+func newTestKeyRegistry(t *testing.T) *testKeyRegistry {
+	r := &testKeyRegistry{
+		rm:    componentsmocks.NewRegistryManager(t),
+		props: map[string]map[string]string{},
+	}
+	r.rm.On("GetNodeProperties", mock.Anything, mock.Anything).Return(func(ctx context.Context, node string) (map[string]string, error) {
+		props, ok := r.props[node]
+		if !ok {
+			return nil, fmt.Errorf("node %s not found", node)
+		}
+		return props, nil
+	}).Maybe()
+	return r
+}
+
+func (r *testKeyRegistry) publish(tm *transportManager) {
+	name, value := tm.LocalNodeKeysProperty()
+	r.props[tm.localNodeName] = map[string]string{name: value}
+}
+
+func newTestEncryptionManager(t *testing.T, r *testKeyRegistry, nodeName string, enc pldconf.TransportEncryptionConfig) *transportManager {
+	tm := NewTransportManager(context.Background(), &pldconf.TransportManagerConfig{
+		NodeName:          nodeName,
+		PayloadEncryption: enc,
+	}).(*transportManager)
+	tm.registryManager = r.rm
+	err := tm.initPayloadEncryption(context.Background(), &tm.conf.PayloadEncryption)
+	require.NoError(t, err)
+	return tm
+}
+
+func newTestEncryptedNode(t *testing.T, r *testKeyRegistry, nodeName string, keyFiles ...string) *transportManager {
+	if len(keyFiles) == 0 {
+		keyFiles = []string{writeTestKeyFile(t, elliptic.P256(), false)}
+	}
+	tm := newTestEncryptionManager(t, r, nodeName, pldconf.TransportEncryptionConfig{
+		Enabled:  confutil.P(true),
+		KeyFiles: keyFiles,
+	})
+	r.publish(tm)
+	return tm
+}
+
+func testPaladinMsg() *prototk.PaladinMsg {
+	return &prototk.PaladinMsg{
+		MessageId:     uuid.NewString(),
+		CorrelationId: confutil.P(uuid.NewString()),
+		Component:     prototk.PaladinMsg_RELIABLE_MESSAGE_HANDLER,
+		MessageType:   "myMessageType",
+		Payload:       []byte("some private data"),
+	}
+}
+
+func TestInitPayloadEncryptionErrors(t *testing.T) {
+	ctx := context.Background()
+	notPEM := filepath.Join(t.TempDir(), "not.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not PEM"), 0600))
+	badDER := filepath.Join(t.TempDir(), "bad.pem")
+	require.NoError(t, os.WriteFile(badDER, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("bad")}), 0600))
+
+	for _, tc := range []struct {
+		keyFiles []string
+		error    string
+	}{
+		{keyFiles: nil, error: "PD012023"},
+		{keyFiles: []string{filepath.Join(t.TempDir(), "missing.pem")}, error: "PD012024"},
+		{keyFiles: []string{notPEM}, error: "PD012024"},
+		{keyFiles: []string{badDER}, error: "PD012024"},
+		{keyFiles: []string{writeTestKeyFile(t, elliptic.P384(), true)}, error: "PD012024"},
+	} {
+		tm := NewTransportManager(ctx, &pldconf.TransportManagerConfig{}).(*transportManager)
+		err := tm.initPayloadEncryption(ctx, &pldconf.TransportEncryptionConfig{
+			Enabled:  confutil.P(true),
+			KeyFiles: tc.keyFiles,
+		})
+		assert.Regexp(t, tc.error, err)
+	}
+}
+
+func TestPreInitEncryptionError(t *testing.T) {
+	tm := NewTransportManager(context.Background(), &pldconf.TransportManagerConfig{
+		NodeName: "node1",
+		PayloadEncryption: pldconf.TransportEncryptionConfig{
+			Enabled: confutil.P(true),
+		},
+	})
+	_, err := tm.PreInit(newMockComponents(t, false).c)
+	assert.Regexp(t, "PD012023", err)
+}
+
+func TestLocalNodeKeysProperty(t *testing.T) {
+	r := newTestKeyRegistry(t)
+
+	tm := newTestEncryptionManager(t, r, "node1", pldconf.TransportEncryptionConfig{})
+	name, value := tm.LocalNodeKeysProperty()
+	assert.Empty(t, name)
+	assert.Empty(t, value)
+
+	tm = newTestEncryptionManager(t, r, "node1", pldconf.TransportEncryptionConfig{
+		Enabled:      confutil.P(true),
+		KeyFiles:     []string{writeTestKeyFile(t, elliptic.P256(), false), writeTestKeyFile(t, elliptic.P256(), true)},
+		PropertyName: confutil.P("custom.keys"),
+	})
+	name, value = tm.LocalNodeKeysProperty()
+	assert.Equal(t, "custom.keys", name)
+	var publicKeys []pldtypes.HexBytes
+	require.NoError(t, json.Unmarshal([]byte(value), &publicKeys))
+	require.Len(t, publicKeys, 2)
+	assert.Equal(t, tm.nodeKeys[0].id, payloadKeyID(publicKeys[0]))
+	assert.Equal(t, tm.nodeKeys[1].id, payloadKeyID(publicKeys[1]))
+}
+
+func TestSealOpenPayload(t *testing.T) {
+	ctx := context.Background()
+	r := newTestKeyRegistry(t)
+	node1 := newTestEncryptedNode(t, r, "node1")
+	node2 := newTestEncryptedNode(t, r, "node2")
+
+	msg := testPaladinMsg()
+	sealed, err := node1.sealPayload(ctx, "node2", msg)
+	require.NoError(t, err)
+	require.NotNil(t, sealed.Seal)
+	assert.NotContains(t, string(sealed.Payload), "some private data")
+	assert.Equal(t, node1.nodeKeys[0].id, sealed.Seal.SenderKeyId)
+	assert.Equal(t, node2.nodeKeys[0].id, sealed.Seal.RecipientKeyId)
+	assert.Equal(t, msg.MessageId, sealed.MessageId)
+	assert.Nil(t, msg.Seal) // original is unmodified
+
+	opened, err := node2.openPayload(ctx, "node1", sealed)
+	require.NoError(t, err)
+	assert.Nil(t, opened.Seal)
+	assert.Equal(t, "some private data", string(opened.Payload))
+	assert.Equal(t, msg.MessageType, opened.MessageType)
+
+	// Only the intended recipient can open it
+	_, err = node1.openPayload(ctx, "node2", sealed)
+	assert.Regexp(t, "PD012028", err)
+}
+
+func TestOpenPayloadTampered(t *testing.T) {
+	ctx := context.Background()
+	r := newTestKeyRegistry(t)
+	node1 := newTestEncryptedNode(t, r, "node1")
+	node2 := newTestEncryptedNode(t, r, "node2")
+	newTestEncryptedNode(t, r, "node3")
+
+	for _, tc := range []struct {
+		from   string
+		tamper func(msg *prototk.PaladinMsg)
+		error  string
+	}{
+		{from: "node1", tamper: func(msg *prototk.PaladinMsg) { msg.MessageType = "other" }, error: "PD012031"},
+		{from: "node1", tamper: func(msg *prototk.PaladinMsg) { msg.Component = prototk.PaladinMsg_TRANSACTION_ENGINE }, error: "PD012031"},
+		{from: "node1", tamper: func(msg *prototk.PaladinMsg) { msg.Payload[0] ^= 0xff }, error: "PD012031"},
+		{from: "node1", tamper: func(msg *prototk.PaladinMsg) { msg.Seal.Signature = []byte("wrong") }, error: "PD012031"},
+		{from: "node3", tamper: func(msg *prototk.PaladinMsg) {}, error: "PD012027"},
+		{from: "node4", tamper: func(msg *prototk.PaladinMsg) {}, error: "node node4 not found"},
+	} {
+		sealed, err := node1.sealPayload(ctx, "node2", testPaladinMsg())
+		require.NoError(t, err)
+		tc.tamper(sealed)
+		_, err = node2.openPayload(ctx, tc.from, sealed)
+		assert.Regexp(t, tc.error, err)
+	}
+}
+
+func TestOpenPayloadBadSealFields(t *testing.T) {
+	ctx := context.Background()
+	r := newTestKeyRegistry(t)
+	node1 := newTestEncryptedNode(t, r, "node1")
+	node2 := newTestEncryptedNode(t, r, "node2")
+
+	// The signature covers the seal fields, so we re-sign after changing them to reach the checks after verification
+	for _, tamper := range []func(seal *prototk.PayloadSeal){
+		func(seal *prototk.PayloadSeal) { seal.EphemeralKey = []byte("wrong") },
+		func(seal *prototk.PayloadSeal) { seal.Nonce = []byte("wrong") },
+		func(seal *prototk.PayloadSeal) { seal.Nonce = make([]byte, 12) },
+	} {
+		sealed, err := node1.sealPayload(ctx, "node2", testPaladinMsg())
+		require.NoError(t, err)
+		tamper(sealed.Seal)
+		header := payloadSealHeader("node1", "node2", sealed, sealed.Seal)
+		sealed.Seal.Signature, err = ecdsa.SignASN1(rand.Reader, node1.nodeKeys[0].privateKey, payloadSealSigningHash(header, sealed.Payload))
+		require.NoError(t, err)
+		_, err = node2.openPayload(ctx, "node1", sealed)
+		assert.Regexp(t, "PD012031", err)
+	}
+}
+
+func TestPayloadKeyRollover(t *testing.T) {
+	ctx := context.Background()
+	r := newTestKeyRegistry(t)
+	oldKey1 := writeTestKeyFile(t, elliptic.P256(), false)
+	oldKey2 := writeTestKeyFile(t, elliptic.P256(), false)
+	node1 := newTestEncryptedNode(t, r, "node1", oldKey1)
+	node2 := newTestEncryptedNode(t, r, "node2", oldKey2)
+
+	// node1 and node2 both roll over to new keys, but their new keys are not yet visible in the registry
+	node1Published := r.props["node1"]
+	node2Published := r.props["node2"]
+	node1 = newTestEncryptedNode(t, r, "node1", writeTestKeyFile(t, elliptic.P256(), true), oldKey1)
+	node2 = newTestEncryptedNode(t, r, "node2", writeTestKeyFile(t, elliptic.P256(), true), oldKey2)
+	newNode1Published := r.props["node1"]
+	node1.signingKeyRefresh = 0 // check for our new key on every message
+	r.props["node1"] = node1Published
+	r.props["node2"] = node2Published
+
+	// node1 signs with its old key, and encrypts to the old key of node2 - which node2 can still open
+	sealed, err := node1.sealPayload(ctx, "node2", testPaladinMsg())
+	require.NoError(t, err)
+	assert.Equal(t, node1.nodeKeys[1].id, sealed.Seal.SenderKeyId)
+	assert.Equal(t, node2.nodeKeys[1].id, sealed.Seal.RecipientKeyId)
+	_, err = node2.openPayload(ctx, "node1", sealed)
+	require.NoError(t, err)
+
+	// Once the new key of node1 is published, it is used for signing
+	r.props["node1"] = newNode1Published
+	sealed, err = node1.sealPayload(ctx, "node2", testPaladinMsg())
+	require.NoError(t, err)
+	assert.Equal(t, node1.nodeKeys[0].id, sealed.Seal.SenderKeyId)
+	_, err = node2.openPayload(ctx, "node1", sealed)
+	require.NoError(t, err)
+
+	// If we cannot resolve our own published keys, we use our current key
+	node1.signingKey = nil
+	delete(r.props, "node1")
+	assert.Equal(t, node1.nodeKeys[0].id, node1.getSigningKey(ctx).id)
+}
+
+func TestSigningKeyCached(t *testing.T) {
+	ctx := context.Background()
+	r := newTestKeyRegistry(t)
+	oldKey := writeTestKeyFile(t, elliptic.P256(), false)
+	newTestEncryptedNode(t, r, "node1", oldKey)
+	oldPublished := r.props["node1"]
+	node1 := newTestEncryptedNode(t, r, "node1", writeTestKeyFile(t, elliptic.P256(), false), oldKey)
+	newPublished := r.props["node1"]
+	r.props["node1"] = oldPublished
+
+	lookups := func() int {
+		count := 0
+		for _, c := range r.rm.Calls {
+			if c.Method == "GetNodeProperties" && c.Arguments[1] == "node1" {
+				count++
+			}
+		}
+		return count
+	}
+
+	// While our new key is unpublished, we only check for it after the refresh interval
+	assert.Equal(t, node1.nodeKeys[1].id, node1.getSigningKey(ctx).id)
+	assert.Equal(t, 1, lookups())
+	r.props["node1"] = newPublished
+	assert.Equal(t, node1.nodeKeys[1].id, node1.getSigningKey(ctx).id)
+	assert.Equal(t, 1, lookups())
+
+	node1.signingKeyChecked = time.Now().Add(-node1.signingKeyRefresh)
+	assert.Equal(t, node1.nodeKeys[0].id, node1.getSigningKey(ctx).id)
+	assert.Equal(t, 2, lookups())
+
+	// Once our current key is published, we do not look again
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, node1.nodeKeys[0].id, node1.getSigningKey(ctx).id)
+	}
+	assert.Equal(t, 2, lookups())
+}
+
+func TestOpenUnsealedPayload(t *testing.T) {
+	ctx := context.Background()
+	r := newTestKeyRegistry(t)
+	node1 := newTestEncryptedNode(t, r, "node1")
+	r.props["node2"] = map[string]string{}
+	newTestEncryptedNode(t, r, "node3")
+
+	// A node that does not publish keys can send to us unencrypted
+	msg := testPaladinMsg()
+	opened, err := node1.openPayload(ctx, "node2", msg)
+	require.NoError(t, err)
+	assert.Same(t, msg, opened)
+
+	// A node that publishes keys always seals its messages to us, so the seal has been stripped
+	_, err = node1.openPayload(ctx, "node3", msg)
+	assert.Regexp(t, "PD012036.*node3", err)
+
+	// We cannot check an unknown node
+	_, err = node1.openPayload(ctx, "node4", msg)
+	assert.Regexp(t, "node node4 not found", err)
+}
+
+func TestSealPayloadNotEnabled(t *testing.T) {
+	ctx := context.Background()
+	r := newTestKeyRegistry(t)
+	node1 := newTestEncryptionManager(t, r, "node1", pldconf.TransportEncryptionConfig{})
+	r.props["node1"] = map[string]string{}
+	node2 := newTestEncryptedNode(t, r, "node2")
+
+	// We do not encrypt, even though node2 publishes keys
+	msg := testPaladinMsg()
+	sent, err := node1.sealPayload(ctx, "node2", msg)
+	require.NoError(t, err)
+	assert.Same(t, msg, sent)
+
+	// We cannot open encrypted messages
+	sealed, err := node2.sealPayload(ctx, "node1", msg)
+	require.NoError(t, err)
+	assert.Nil(t, sealed.Seal) // as node1 does not publish keys
+	sealed.Seal = &prototk.PayloadSeal{}
+	_, err = node1.openPayload(ctx, "node2", sealed)
+	assert.Regexp(t, "PD012030", err)
+}
+
+func TestSealPayloadRequired(t *testing.T) {
+	ctx := context.Background()
+	r := newTestKeyRegistry(t)
+	node1 := newTestEncryptionManager(t, r, "node1", pldconf.TransportEncryptionConfig{
+		Enabled:  confutil.P(true),
+		Required: confutil.P(true),
+		KeyFiles: []string{writeTestKeyFile(t, elliptic.P256(), false)},
+	})
+	r.publish(node1)
+	r.props["node2"] = map[string]string{}
+
+	_, err := node1.sealPayload(ctx, "node2", testPaladinMsg())
+	assert.Regexp(t, "PD012026", err)
+
+	_, err = node1.openPayload(ctx, "node2", testPaladinMsg())
+	assert.Regexp(t, "PD012029", err)
+}
+
+func TestSealPayloadPeerKeysErrors(t *testing.T) {
+	ctx := context.Background()
+	r := newTestKeyRegistry(t)
+	node1 := newTestEncryptedNode(t, r, "node1")
+
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	p384DER, err := x509.MarshalPKIXPublicKey(&p384Key.PublicKey)
+	require.NoError(t, err)
+
+	_, err = node1.sealPayload(ctx, "node2", testPaladinMsg())
+	assert.Regexp(t, "node node2 not found", err)
+
+	for _, keys := range []string{
+		`!!! not json`,
+		`["0x1234"]`,
+		fmt.Sprintf(`["%s"]`, pldtypes.HexBytes(p384DER)),
+	} {
+		r.props["node2"] = map[string]string{"node.keys": keys}
+		_, err = node1.sealPayload(ctx, "node2", testPaladinMsg())
+		assert.Regexp(t, "PD012025", err)
+	}
+
+	// Without encryption required, we send unencrypted to nodes that do not publish keys
+	r.props["node2"] = map[string]string{}
+	msg := testPaladinMsg()
+	sent, err := node1.sealPayload(ctx, "node2", msg)
+	require.NoError(t, err)
+	assert.Same(t, msg, sent)
+}
+
+func TestSendReceiveSealedMessage(t *testing.T) {
+	r := newTestKeyRegistry(t)
+	node2 := newTestEncryptedNode(t, r, "node2")
+	node1KeyFile := writeTestKeyFile(t, elliptic.P256(), false)
+
+	receivedMessages := make(chan *components.ReceivedMessage, 1)
+	ctx, tm, tp, done := newTestTransport(t, false,
+		mockEmptyReliableMsgs,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			conf.PayloadEncryption.Enabled = confutil.P(true)
+			conf.PayloadEncryption.KeyFiles = []string{node1KeyFile}
+			mc.registryManager = r.rm
+			mc.c.On("RegistryManager").Unset()
+			mc.c.On("RegistryManager").Return(r.rm).Maybe()
+			r.rm.On("GetNodeTransports", mock.Anything, "node2").Return([]*components.RegistryNodeTransportEntry{
+				{Node: "node2", Transport: "test1", Details: `{"likely":"json stuff"}`},
+			}, nil)
+			mc.identityResolver.On("HandlePaladinMsg", mock.Anything, mock.Anything).Return().Run(func(args mock.Arguments) {
+				receivedMessages <- args[1].(*components.ReceivedMessage)
+			})
+		})
+	defer done()
+	r.publish(tm)
+
+	// Messages we send are sealed to node2
+	sentMessages := make(chan *prototk.PaladinMsg, 1)
+	mockActivateDeactivateOk(tp)
+	tp.Functions.SendMessage = func(ctx context.Context, req *prototk.SendMessageRequest) (*prototk.SendMessageResponse, error) {
+		sentMessages <- req.Message
+		return nil, nil
+	}
+	message := testMessage()
+	err := tm.Send(ctx, message)
+	require.NoError(t, err)
+	sent := <-sentMessages
+	require.NotNil(t, sent.Seal)
+	opened, err := node2.openPayload(ctx, "node1", sent)
+	require.NoError(t, err)
+	assert.Equal(t, message.Payload, opened.Payload)
+
+	// Messages we receive are opened before delivery
+	msg := testPaladinMsg()
+	msg.Component = prototk.PaladinMsg_IDENTITY_RESOLVER
+	sealed, err := node2.sealPayload(ctx, "node1", msg)
+	require.NoError(t, err)
+	_, err = tp.t.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{
+		FromNode: "node2",
+		Message:  sealed,
+	})
+	require.NoError(t, err)
+	received := <-receivedMessages
+	assert.Equal(t, "some private data", string(received.Payload))
+
+	// Messages that fail verification are rejected
+	sealed.MessageType = "other"
+	_, err = tp.t.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{
+		FromNode: "node2",
+		Message:  sealed,
+	})
+	assert.Regexp(t, "PD012031", err)
+}
+
+func TestSendSealFail(t *testing.T) {
+	r := newTestKeyRegistry(t)
+	node1KeyFile := writeTestKeyFile(t, elliptic.P256(), false)
+
+	ctx, tm, tp, done := newTestTransport(t, false,
+		mockEmptyReliableMsgs,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			conf.PayloadEncryption.Enabled = confutil.P(true)
+			conf.PayloadEncryption.Required = confutil.P(true)
+			conf.PayloadEncryption.KeyFiles = []string{node1KeyFile}
+			mc.c.On("RegistryManager").Unset()
+			mc.c.On("RegistryManager").Return(r.rm).Maybe()
+			r.rm.On("GetNodeTransports", mock.Anything, "node2").Return([]*components.RegistryNodeTransportEntry{
+				{Node: "node2", Transport: "test1", Details: `{"likely":"json stuff"}`},
+			}, nil)
+		})
+	defer done()
+	r.publish(tm)
+	r.props["node2"] = map[string]string{}
+	mockActivateDeactivateOk(tp)
+
+	p, err := tm.getPeer(ctx, "node2", true)
+	require.NoError(t, err)
+	err = p.send(testPaladinMsg(), nil)
+	assert.Regexp(t, "PD012026", err)
+}
@@ -26,12 +26,12 @@ import (
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
-	"gorm.io/gorm/clause"
 )
 
 type peer struct {
@@ -44,13 +44,20 @@ type peer struct {
 	pldapi.PeerInfo
 	statsLock sync.Mutex
 
+	lastError     string
+	lastErrorTime *pldtypes.Timestamp
+
 	persistedMsgsAvailable chan struct{}
 	sendQueue              chan *prototk.PaladinMsg
 
+	redriveLock    sync.Mutex
+	pendingRedrive *redriveRequest
+
 	// Send loop state (no lock as only used on the loop)
 	lastFullScan          time.Time
 	lastDrainHWM          *uint64
 	persistentMsgsDrained bool
+	activeRedrive         *redriveRequest
 
 	senderStarted atomic.Bool
 	senderDone    chan struct{}
@@ -94,21 +101,53 @@ func (tm *transportManager) listActivePeers() nameSortedPeers {
 	return peers
 }
 
-func (tm *transportManager) listActivePeerInfo() []*pldapi.PeerInfo {
+func (tm *transportManager) listActivePeerInfo(ctx context.Context) ([]*pldapi.PeerInfo, error) {
 	peers := tm.listActivePeers()
+	if len(peers) == 0 {
+		return []*pldapi.PeerInfo{}, nil
+	}
+	nodes := make([]string, len(peers))
+	for i, p := range peers {
+		nodes[i] = p.Name
+	}
+	backlog, err := tm.queryReliableBacklog(ctx, tm.persistence.NOTX(), nodes...)
+	if err != nil {
+		return nil, err
+	}
 	peerInfo := make([]*pldapi.PeerInfo, len(peers))
 	for i, p := range peers {
-		peerInfo[i] = &p.PeerInfo
+		peerInfo[i] = p.getInfo(backlog[p.Name])
 	}
-	return peerInfo
+	return peerInfo, nil
 }
 
-func (tm *transportManager) getPeerInfo(nodeName string) *pldapi.PeerInfo {
+func (tm *transportManager) getPeerInfo(ctx context.Context, nodeName string) (*pldapi.PeerInfo, error) {
 	peer := tm.getActivePeer(nodeName)
 	if peer == nil {
-		return nil
+		return nil, nil
+	}
+	backlog, err := tm.queryReliableBacklog(ctx, tm.persistence.NOTX(), nodeName)
+	if err != nil {
+		return nil, err
+	}
+	return peer.getInfo(backlog[nodeName]), nil
+}
+
+// returns a point-in-time copy of the peer info, with the summary of the reliable message backlog
+func (p *peer) getInfo(backlog *reliableBacklog) *pldapi.PeerInfo {
+	p.statsLock.Lock()
+	defer p.statsLock.Unlock()
+	info := p.PeerInfo
+	info.Reliable = &pldapi.PeerReliableInfo{
+		LastError:     p.lastError,
+		LastErrorTime: p.lastErrorTime,
+	}
+	if backlog != nil {
+		info.Reliable.Backlog = backlog.Count
+		info.Reliable.OldestUnacked = &backlog.Oldest
+		info.Reliable.OldestUnackedAge = time.Since(backlog.Oldest.Time()).Round(time.Millisecond).String()
 	}
-	return &peer.PeerInfo
+	return &info
 }
 
 // efficient read-locked call to get an active peer connection
@@ -141,23 +180,46 @@ func (tm *transportManager) reapPeer(p *peer) {
 func (tm *transportManager) peerReaper() {
 	defer close(tm.peerReaperDone)
 
+	retryRevalidate := false
 	for {
+		revalidate := false
 		select {
 		case <-tm.bgCtx.Done():
 			log.L(tm.bgCtx).Debugf("peer reaper exiting")
 			return
 		case <-time.After(tm.peerReaperInterval):
+			// A revalidation that could not be completed is retried on the next interval
+			revalidate = retryRevalidate
+		case <-tm.peerRevalidate:
+			revalidate = true
 		}
 
+		tm.updateReliableMetrics()
+
 		candidates := tm.listActivePeers()
 		var reaped []*peer
+		retryRevalidate = false
 		for _, p := range candidates {
-			if p.isInactive() {
+			reap := p.isInactive()
+			if !reap && revalidate {
+				resolvable, err := p.isResolvable()
+				reap = !resolvable
+				retryRevalidate = retryRevalidate || err != nil
+			}
+			if reap {
 				tm.reapPeer(p)
 				reaped = append(reaped, p)
 			}
 		}
-		log.L(tm.bgCtx).Debugf("peer reaper before=%d reaped=%d", len(candidates), len(reaped))
+		log.L(tm.bgCtx).Debugf("peer reaper before=%d reaped=%d revalidate=%t", len(candidates), len(reaped), revalidate)
+	}
+}
+
+func (tm *transportManager) RevalidatePeers() {
+	// The peer reaper performs the revalidation, so we just need to make sure it is notified
+	select {
+	case tm.peerRevalidate <- struct{}{}:
+	default:
 	}
 }
 
@@ -257,13 +319,22 @@ func (p *peer) notifyPersistedMsgAvailable() {
 
 func (p *peer) send(msg *prototk.PaladinMsg, reliableSeq *uint64) error {
 	err := p.tm.sendShortRetry.Do(p.ctx, func(attempt int) (retryable bool, err error) {
-		return true, p.transport.send(p.ctx, p.Name, msg)
+		// Sealing involves a registry lookup of the keys of the peer, so is retried with the send
+		sealed, err := p.tm.sealPayload(p.ctx, p.Name, msg)
+		if err != nil {
+			return true, err
+		}
+		return true, p.transport.send(p.ctx, p.Name, sealed)
 	})
 	log.L(p.ctx).Infof("Sent %s/%s message %s to %s (cid=%s)", msg.Component.String(), msg.MessageType, msg.MessageId, p.Name, pldtypes.StrOrEmpty(msg.CorrelationId))
-	if err == nil {
-		now := pldtypes.TimestampNow()
-		p.statsLock.Lock()
-		defer p.statsLock.Unlock()
+	now := pldtypes.TimestampNow()
+	p.statsLock.Lock()
+	defer p.statsLock.Unlock()
+	if err != nil {
+		p.lastError = err.Error()
+		p.lastErrorTime = &now
+		p.tm.metrics.IncSendErrors(p.Name)
+	} else {
 		p.Stats.LastSend = &now
 		p.Stats.SentMsgs++
 		p.Stats.SentBytes += uint64(len(msg.Payload))
@@ -290,7 +361,12 @@ func (p *peer) updateReceivedStats(msg *prototk.PaladinMsg) {
 
 func (p *peer) reliableMessageScan(checkNew bool) error {
 
-	fullScan := p.lastDrainHWM == nil || time.Since(p.lastFullScan) >= p.tm.reliableMessageResend
+	// A redrive requested by an administrator is kept until a scan completes successfully
+	if redrive := p.takeRedrive(); redrive != nil {
+		p.activeRedrive = redrive.merge(p.activeRedrive)
+	}
+
+	fullScan := p.activeRedrive != nil || p.lastDrainHWM == nil || time.Since(p.lastFullScan) >= p.tm.reliableMessageResend
 	if !fullScan && !checkNew {
 		return nil // Nothing to do
 	}
@@ -352,6 +428,7 @@ func (p *peer) reliableMessageScan(checkNew bool) error {
 		p.persistentMsgsDrained = (total == 0)
 
 		p.lastFullScan = time.Now()
+		p.activeRedrive = nil
 	}
 
 	return nil
@@ -371,7 +448,7 @@ func (p *peer) processReliableMsgPage(dbTX persistence.DBTX, page []*pldapi.Reli
 
 		// Check it's either after our HWM, or eligible for re-send
 		afterHWM := p.lastDrainHWM == nil || *p.lastDrainHWM < rm.Sequence
-		if !afterHWM && time.Since(rm.Created.Time()) < p.tm.reliableMessageResend {
+		if !afterHWM && !p.activeRedrive.includes(rm.ID) && time.Since(rm.Created.Time()) < p.tm.reliableMessageResend {
 			log.L(p.ctx).Infof("Unacknowledged message %s not yet eligible for re-send", rm.ID)
 			continue
 		}
@@ -384,6 +461,8 @@ func (p *peer) processReliableMsgPage(dbTX persistence.DBTX, page []*pldapi.Reli
 			msg, errorAck, err = p.tm.buildStateDistributionMsg(p.ctx, dbTX, rm)
 		case pldapi.RMTPrivacyGroup:
 			msg, errorAck, err = p.tm.buildPrivacyGroupDistributionMsg(p.ctx, dbTX, rm)
+		case pldapi.RMTPrivacyGroupMembers:
+			msg, errorAck, err = p.tm.buildPrivacyGroupMembersMsg(p.ctx, dbTX, rm)
 		case pldapi.RMTPrivacyGroupMessage:
 			msg, errorAck, err = p.tm.buildPrivacyGroupMessageMsg(p.ctx, dbTX, rm)
 		case pldapi.RMTReceipt:
@@ -411,11 +490,9 @@ func (p *peer) processReliableMsgPage(dbTX persistence.DBTX, page []*pldapi.Reli
 
 	// Persist any bad message failures
 	if len(errorAcks) > 0 {
-		err := p.tm.persistence.DB().
-			WithContext(p.ctx).
-			Clauses(clause.OnConflict{DoNothing: true}).
-			Create(errorAcks).
-			Error
+		err := p.tm.persistence.Transaction(p.ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return p.tm.insertAcks(ctx, dbTX, errorAcks...)
+		})
 		if err != nil {
 			return err
 		}
@@ -487,6 +564,25 @@ func (p *peer) isInactive() bool {
 		(p.Stats.LastReceive == nil || now.Sub(p.Stats.LastReceive.Time()) > p.tm.peerInactivityTimeout)
 }
 
+// Checks a connected peer can still be resolved in the registry - such as after it has been revoked.
+// If the lookup itself fails we cannot tell, so the peer is left connected and the error returned.
+func (p *peer) isResolvable() (bool, error) {
+	if !p.senderStarted.Load() {
+		// Nothing to check if we have not connected to send
+		return true, nil
+	}
+	_, err := p.tm.registryManager.GetNodeTransports(p.ctx, p.Name)
+	if err == nil {
+		return true, nil
+	}
+	if components.IsNodeUnresolvable(err) {
+		log.L(p.ctx).Warnf("peer %s can no longer be resolved: %s", p.Name, err)
+		return false, nil
+	}
+	log.L(p.ctx).Errorf("peer %s could not be revalidated: %s", p.Name, err)
+	return true, err
+}
+
 func (p *peer) close() {
 	p.cancelCtx()
 	if p.senderStarted.Load() {
@@ -25,10 +25,12 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -423,6 +425,106 @@ func TestDeactivateFail(t *testing.T) {
 
 }
 
+func TestRevalidatePeersReapsUnresolvable(t *testing.T) {
+
+	node2Transports := []*components.RegistryNodeTransportEntry{
+		{
+			Node:      "node2",
+			Transport: "test1",
+			Details:   `{"likely":"json stuff"}`,
+		},
+	}
+	revalidated := make(chan struct{})
+	ctx, tm, tp, done := newTestTransport(t, false,
+		mockEmptyReliableMsgs,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.registryManager.On("GetNodeTransports", mock.Anything, "node2").Return(node2Transports, nil).Once()
+			mc.registryManager.On("GetNodeTransports", mock.Anything, "node2").Return(node2Transports, nil).Once().
+				Run(func(args mock.Arguments) { close(revalidated) })
+			mc.registryManager.On("GetNodeTransports", mock.Anything, "node2").Return(nil,
+				i18n.NewError(context.Background(), msgs.MsgRegistryNodeRevoked, "node2", "registry1", "0x1234")).Once()
+		},
+	)
+	defer done()
+
+	tm.reliableMessageResend = 1 * time.Second
+	tm.peerInactivityTimeout = 1 * time.Hour
+
+	mockActivateDeactivateOk(tp)
+
+	p2, err := tm.getPeer(ctx, "node2", true)
+	require.NoError(t, err)
+	p2.statsLock.Lock()
+	p2.Stats.LastSend = confutil.P(pldtypes.TimestampNow())
+	p2.statsLock.Unlock()
+	// A peer we have only received from is not re-validated
+	p3, err := tm.getPeer(ctx, "node3", false)
+	require.NoError(t, err)
+	p3.statsLock.Lock()
+	p3.Stats.LastReceive = confutil.P(pldtypes.TimestampNow())
+	p3.statsLock.Unlock()
+
+	// First time around it is still resolvable
+	tm.RevalidatePeers()
+	<-revalidated
+	require.NotNil(t, tm.getActivePeer("node2"))
+
+	// Then it is revoked
+	tm.RevalidatePeers()
+	tm.RevalidatePeers()
+	for tm.getActivePeer("node2") != nil {
+		time.Sleep(1 * time.Millisecond)
+	}
+	require.NotNil(t, tm.getActivePeer("node3"))
+
+}
+
+func TestRevalidatePeersRetriesLookupFailure(t *testing.T) {
+
+	node2Transports := []*components.RegistryNodeTransportEntry{
+		{
+			Node:      "node2",
+			Transport: "test1",
+			Details:   `{"likely":"json stuff"}`,
+		},
+	}
+	lookupFailed := make(chan struct{})
+	ctx, tm, tp, done := newTestTransport(t, false,
+		mockEmptyReliableMsgs,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.registryManager.On("GetNodeTransports", mock.Anything, "node2").Return(node2Transports, nil).Once()
+			mc.registryManager.On("GetNodeTransports", mock.Anything, "node2").Return(nil, fmt.Errorf("pop")).Once().
+				Run(func(args mock.Arguments) { close(lookupFailed) })
+			mc.registryManager.On("GetNodeTransports", mock.Anything, "node2").Return(nil,
+				i18n.NewError(context.Background(), msgs.MsgRegistryNodeEntiresNotFound, "node2")).Once()
+			conf.PeerReaperInterval = confutil.P("100ms")
+		},
+	)
+	defer done()
+
+	tm.reliableMessageResend = 1 * time.Second
+	tm.peerInactivityTimeout = 1 * time.Hour
+
+	mockActivateDeactivateOk(tp)
+
+	p2, err := tm.getPeer(ctx, "node2", true)
+	require.NoError(t, err)
+	p2.statsLock.Lock()
+	p2.Stats.LastSend = confutil.P(pldtypes.TimestampNow())
+	p2.statsLock.Unlock()
+
+	// A failure to perform the lookup does not reap the peer
+	tm.RevalidatePeers()
+	<-lookupFailed
+	require.NotNil(t, tm.getActivePeer("node2"))
+
+	// But the revalidation is retried on the next interval, without another notification
+	for tm.getActivePeer("node2") != nil {
+		time.Sleep(1 * time.Millisecond)
+	}
+
+}
+
 func TestGetReliableMessageByIDFail(t *testing.T) {
 
 	ctx, tm, _, done := newTestTransport(t, false, func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
@@ -595,6 +697,69 @@ func TestProcessReliableMsgPagePrivacyGroup(t *testing.T) {
 	require.Equal(t, "node2", rpg.node)
 }
 
+func TestProcessReliableMsgPagePrivacyGroupMembers(t *testing.T) {
+
+	schemaID := pldtypes.RandBytes32()
+	ctx, tm, tp, done := newTestTransport(t, false,
+		mockGetStateOk,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.db.Mock.ExpectExec("INSERT.*reliable_msgs").WillReturnResult(driver.ResultNoRows)
+		})
+	defer done()
+
+	p := &peer{
+		ctx:       ctx,
+		tm:        tm,
+		transport: tp.t,
+	}
+
+	pgmd := &components.PrivacyGroupMembersDistribution{
+		Group:    pldtypes.RandBytes(32),
+		Revision: 3,
+		MembersState: components.StateDistributionWithData{
+			StateDistribution: components.StateDistribution{
+				Domain:          "domain1",
+				ContractAddress: pldtypes.RandAddress().String(),
+				SchemaID:        schemaID.String(),
+				StateID:         pldtypes.RandHex(32),
+			},
+		},
+	}
+
+	rm := &pldapi.ReliableMessage{
+		ID:          uuid.New(),
+		Sequence:    50,
+		MessageType: pldapi.RMTPrivacyGroupMembers.Enum(),
+		Node:        "node2",
+		Metadata:    pldtypes.JSONString(pgmd),
+		Created:     pldtypes.TimestampNow(),
+	}
+
+	sentMessages := make(chan *prototk.PaladinMsg, 1)
+	tp.Functions.SendMessage = func(ctx context.Context, req *prototk.SendMessageRequest) (*prototk.SendMessageResponse, error) {
+		sent := req.Message
+		sentMessages <- sent
+		return nil, nil
+	}
+
+	err := p.processReliableMsgPage(tm.persistence.NOTX(), []*pldapi.ReliableMessage{rm})
+	require.NoError(t, err)
+
+	sentMsg := <-sentMessages
+
+	rMsg, err := parseReceivedMessage(ctx, "node2", sentMsg)
+	require.NoError(t, err)
+	require.Equal(t, RMHMessageTypePrivacyGroupMembers, rMsg.MessageType)
+
+	rpgm, err := parsePrivacyGroupMembers(ctx, rMsg.MessageID, rMsg.Payload, "node2")
+	require.NoError(t, err)
+	require.Equal(t, "domain1", rpgm.domain)
+	require.JSONEq(t, fmt.Sprintf(`{"dataFor": "%s"}`, rpgm.membersState.ID.HexString()), rpgm.membersState.Data.Pretty())
+	require.Equal(t, pgmd.Group, rpgm.members.Group)
+	require.Equal(t, 3, rpgm.members.Revision)
+	require.Equal(t, "node2", rpgm.node)
+}
+
 func TestProcessReliableMsgPagePrivacyGroupMessage(t *testing.T) {
 
 	origMsg := &pldapi.PrivacyGroupMessage{
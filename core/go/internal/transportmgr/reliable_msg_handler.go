@@ -40,6 +40,7 @@ const (
 	RMHMessageTypePreparedTransaction = string(pldapi.RMTPreparedTransaction)
 	RMHMessageTypePrivacyGroup        = string(pldapi.RMTPrivacyGroup)
 	RMHMessageTypePrivacyGroupMessage = string(pldapi.RMTPrivacyGroupMessage)
+	RMHMessageTypePrivacyGroupMembers = string(pldapi.RMTPrivacyGroupMembers)
 )
 
 type reliableMsgOp struct {
@@ -73,6 +74,14 @@ type receivedPrivacyGroup struct {
 	genesisState *components.StateUpsertOutsideContext
 }
 
+type receivedPrivacyGroupMembers struct {
+	msgID        uuid.UUID
+	node         string
+	domain       string
+	members      *components.PrivacyGroupMembersDistribution
+	membersState *components.StateUpsertOutsideContext
+}
+
 type receivedPrivacyGroupMessage struct {
 	rMsgID  uuid.UUID
 	node    string
@@ -90,6 +99,7 @@ func (tm *transportManager) handleReliableMsgBatch(ctx context.Context, dbTX per
 	var txReceiptsToFinalize []*components.ReceiptInput
 	var msgsToReceive []*receivedPrivacyGroupMessage
 	var privacyGroupsToAdd []*receivedPrivacyGroup
+	var privacyGroupMembersToUpdate []*receivedPrivacyGroupMembers
 
 	dbTX.AddPostCommit(func(ctx context.Context) {
 		// We've committed the database work ok - send the acks/nacks to the other side
@@ -110,7 +120,7 @@ func (tm *transportManager) handleReliableMsgBatch(ctx context.Context, dbTX per
 			if err == nil && sd.NullifierAlgorithm != nil && sd.NullifierVerifierType != nil && sd.NullifierPayloadType != nil {
 				// We need to build any nullifiers that are required, before we dispatch to persistence
 				var nullifier *components.NullifierUpsert
-				nullifier, err = tm.privateTxManager.BuildNullifier(ctx, tm.keyManager.KeyResolverForDBTX(dbTX), sd)
+				nullifier, err = tm.privateTxManager.BuildNullifier(ctx, dbTX, sd)
 				if err == nil {
 					nullifierUpserts[sd.Domain] = append(nullifierUpserts[sd.Domain], nullifier)
 				}
@@ -138,6 +148,19 @@ func (tm *transportManager) handleReliableMsgBatch(ctx context.Context, dbTX per
 				})
 				privacyGroupsToAdd = append(privacyGroupsToAdd, receivedPG)
 			}
+		case RMHMessageTypePrivacyGroupMembers:
+			receivedPGM, err := parsePrivacyGroupMembers(ctx, v.msg.MessageID, v.msg.Payload, v.p.Name)
+			if err != nil {
+				acksToSend = append(acksToSend,
+					&ackInfo{node: v.p.Name, id: v.msg.MessageID, Error: err.Error()}, // reject the message permanently
+				)
+			} else {
+				domainsWithPrivacyGroups[receivedPGM.domain] = true
+				statesToAdd[receivedPGM.domain] = append(statesToAdd[receivedPGM.domain], &stateAndAck{
+					state: receivedPGM.membersState,
+				})
+				privacyGroupMembersToUpdate = append(privacyGroupMembersToUpdate, receivedPGM)
+			}
 		case RMHMessageTypePrivacyGroupMessage:
 			msg, err := parsePrivacyGroupMessage(ctx, v.p.Name, v.msg.MessageID, v.msg.Payload)
 			if err != nil {
@@ -303,6 +326,32 @@ func (tm *transportManager) handleReliableMsgBatch(ctx context.Context, dbTX per
 		acksToSend = append(acksToSend, &ackInfo{node: pg.node, id: pg.msgID, Error: ackErr})
 	}
 
+	// Update the members of any privacy groups, after any new groups are written (new members receive both in order)
+	for _, pgm := range privacyGroupMembersToUpdate {
+		var state *pldapi.State
+		for _, s := range writtenStates[pgm.domain] {
+			if s.ID.Equals(pgm.membersState.ID) {
+				state = s
+				break
+			}
+		}
+		var validationErr error
+		if state == nil {
+			validationErr = i18n.NewError(ctx, msgs.MsgTransportPrivacyGroupStateStorageFailed, pgm.msgID)
+		} else {
+			var persistErr error
+			validationErr, persistErr = tm.groupManager.StoreReceivedGroupMembers(ctx, dbTX, pgm.domain, pgm.node, pgm.members, state)
+			if persistErr != nil {
+				return nil, persistErr
+			}
+		}
+		var ackErr string
+		if validationErr != nil {
+			ackErr = validationErr.Error()
+		}
+		acksToSend = append(acksToSend, &ackInfo{node: pgm.node, id: pgm.msgID, Error: ackErr})
+	}
+
 	// Write an received privacy group messages
 	if len(msgsToReceive) > 0 {
 		msgs := make([]*pldapi.PrivacyGroupMessage, len(msgsToReceive))
@@ -448,6 +497,64 @@ func (tm *transportManager) buildPrivacyGroupDistributionMsg(ctx context.Context
 	}, nil, nil
 }
 
+func parsePrivacyGroupMembersDistributionMetadata(ctx context.Context, msgID uuid.UUID, data []byte) (pgmd *components.PrivacyGroupMembersDistribution, parsed *components.StateUpsertOutsideContext, err error) {
+	err = json.Unmarshal(data, &pgmd)
+	if err == nil {
+		parsed, err = parseState(ctx, &pgmd.MembersState)
+	}
+	if err != nil {
+		return nil, nil, i18n.WrapError(ctx, err, msgs.MsgTransportInvalidMessageData, msgID)
+	}
+	return
+}
+
+func (tm *transportManager) buildPrivacyGroupMembersMsg(ctx context.Context, dbTX persistence.DBTX, rm *pldapi.ReliableMessage) (*prototk.PaladinMsg, error, error) {
+
+	// Validate the message first (not retryable)
+	pgmd, parsed, parseErr := parsePrivacyGroupMembersDistributionMetadata(ctx, rm.ID, rm.Metadata)
+	if parseErr != nil {
+		return nil, parseErr, nil
+	}
+	domainName := pgmd.MembersState.Domain
+
+	// Get the state - distinguishing between not found, vs. a retryable error
+	states, err := tm.stateManager.GetStatesByID(ctx, dbTX, domainName, parsed.ContractAddress, []pldtypes.HexBytes{parsed.ID}, false, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(states) != 1 {
+		return nil,
+			i18n.NewError(ctx, msgs.MsgTransportStateNotAvailableLocally, domainName, parsed.ContractAddress, parsed.ID),
+			nil
+	}
+	pgmd.MembersState.StateData = states[0].Data
+
+	return &prototk.PaladinMsg{
+		MessageId:   rm.ID.String(),
+		Component:   prototk.PaladinMsg_RELIABLE_MESSAGE_HANDLER,
+		MessageType: RMHMessageTypePrivacyGroupMembers,
+		Payload:     pldtypes.JSONString(pgmd),
+	}, nil, nil
+}
+
+func parsePrivacyGroupMembers(ctx context.Context, msgID uuid.UUID, data []byte, node string) (receivedPGM *receivedPrivacyGroupMembers, err error) {
+	var pgmd components.PrivacyGroupMembersDistribution
+	err = json.Unmarshal(data, &pgmd)
+	if err == nil {
+		receivedPGM = &receivedPrivacyGroupMembers{
+			node:    node,
+			domain:  pgmd.MembersState.Domain,
+			msgID:   msgID,
+			members: &pgmd,
+		}
+		receivedPGM.membersState, err = parseState(ctx, &pgmd.MembersState)
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgTransportInvalidMessageData, msgID)
+	}
+	return
+}
+
 func parsePrivacyGroupMessageDistribution(ctx context.Context, msgID uuid.UUID, data []byte) (pmd *components.PrivacyGroupMessageDistribution, err error) {
 	err = json.Unmarshal(data, &pmd)
 	if err != nil {
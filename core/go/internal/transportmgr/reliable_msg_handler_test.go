@@ -72,9 +72,7 @@ func TestReceiveMessageStateWithNullifierSendAckRealDB(t *testing.T) {
 			nullifier := &components.NullifierUpsert{ID: pldtypes.RandBytes(32)}
 			mc.stateManager.On("WriteNullifiersForReceivedStates", mock.Anything, mock.Anything, "domain1", []*components.NullifierUpsert{nullifier}).
 				Return(nil).Once()
-			mkr := componentsmocks.NewKeyResolver(t)
-			mc.privateTxManager.On("BuildNullifier", mock.Anything, mkr, mock.Anything).Return(nullifier, nil)
-			mc.keyManager.On("KeyResolverForDBTX", mock.Anything).Return(mkr).Once()
+			mc.privateTxManager.On("BuildNullifier", mock.Anything, mock.Anything, mock.Anything).Return(nullifier, nil)
 		},
 	)
 	defer done()
@@ -215,9 +213,7 @@ func TestHandleStateDistroBadNullifier(t *testing.T) {
 		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
 			mc.db.Mock.ExpectBegin()
 			mc.db.Mock.ExpectCommit()
-			mkr := componentsmocks.NewKeyResolver(t)
-			mc.privateTxManager.On("BuildNullifier", mock.Anything, mkr, mock.Anything).Return(nil, fmt.Errorf("bad nullifier"))
-			mc.keyManager.On("KeyResolverForDBTX", mock.Anything).Return(mkr).Once()
+			mc.privateTxManager.On("BuildNullifier", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("bad nullifier"))
 		},
 	)
 	defer done()
@@ -501,9 +497,7 @@ func TestHandleNullifierFail(t *testing.T) {
 			nullifier := &components.NullifierUpsert{ID: pldtypes.RandBytes(32)}
 			mc.stateManager.On("WriteNullifiersForReceivedStates", mock.Anything, mock.Anything, "domain1", []*components.NullifierUpsert{nullifier}).
 				Return(fmt.Errorf("pop")).Once()
-			mkr := componentsmocks.NewKeyResolver(t)
-			mc.privateTxManager.On("BuildNullifier", mock.Anything, mkr, mock.Anything).Return(nullifier, nil)
-			mc.keyManager.On("KeyResolverForDBTX", mock.Anything).Return(mkr).Once()
+			mc.privateTxManager.On("BuildNullifier", mock.Anything, mock.Anything, mock.Anything).Return(nullifier, nil)
 		},
 	)
 	defer done()
@@ -1194,3 +1188,273 @@ func TestBuildReceiptDistributionMsgBadMsg(t *testing.T) {
 	require.Regexp(t, "PD012016", parseErr)
 
 }
+
+func testPrivacyGroupMembersDistribution(stateID pldtypes.HexBytes, schemaID pldtypes.Bytes32) *components.PrivacyGroupMembersDistribution {
+	return &components.PrivacyGroupMembersDistribution{
+		Group:    pldtypes.RandBytes(32),
+		Revision: 1,
+		MembersState: components.StateDistributionWithData{
+			StateDistribution: components.StateDistribution{
+				Domain:          "domain1",
+				ContractAddress: pldtypes.RandAddress().String(),
+				SchemaID:        schemaID.String(),
+				StateID:         stateID.String(),
+			},
+			StateData: []byte(`{"some":"data"}`),
+		},
+	}
+}
+
+func TestHandlePrivacyGroupMembersOK(t *testing.T) {
+	var stateID pldtypes.HexBytes = pldtypes.RandBytes(32)
+	schemaID := pldtypes.RandBytes32()
+	schema := componentsmocks.NewSchema(t)
+	pgmd := testPrivacyGroupMembersDistribution(stateID, schemaID)
+	ctx, tm, tp, done := newTestTransport(t, false,
+		mockGoodTransport,
+		mockEmptyReliableMsgs,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.stateManager.On("EnsureABISchemas", mock.Anything, mock.Anything, "domain1", mock.Anything).Return([]components.Schema{
+				schema,
+			}, nil).Once()
+			mc.stateManager.On("WriteReceivedStates", mock.Anything, mock.Anything, "domain1", mock.Anything).
+				Return([]*pldapi.State{
+					{StateBase: pldapi.StateBase{ID: stateID, Schema: schemaID}},
+				}, nil).Once()
+			mc.groupManager.On("StoreReceivedGroupMembers", mock.Anything, mock.Anything, "domain1", "node2", mock.MatchedBy(func(received *components.PrivacyGroupMembersDistribution) bool {
+				return received.Group.Equals(pgmd.Group) && received.Revision == 1
+			}), mock.Anything).Return(nil, nil)
+
+			mc.db.Mock.ExpectBegin()
+			mc.db.Mock.ExpectCommit()
+		},
+	)
+	defer done()
+
+	msg := testReceivedReliableMsg(RMHMessageTypePrivacyGroupMembers, pgmd)
+
+	ackNackCheck := setupAckOrNackCheck(t, tp, msg.MessageID, "")
+
+	p, err := tm.getPeer(ctx, "node2", false)
+	require.NoError(t, err)
+
+	err = tm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := tm.handleReliableMsgBatch(ctx, dbTX, []*reliableMsgOp{
+			{p: p, msg: msg},
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	ackNackCheck()
+}
+
+func TestHandlePrivacyGroupMembersRejected(t *testing.T) {
+	var stateID pldtypes.HexBytes = pldtypes.RandBytes(32)
+	schemaID := pldtypes.RandBytes32()
+	schema := componentsmocks.NewSchema(t)
+	ctx, tm, tp, done := newTestTransport(t, false,
+		mockGoodTransport,
+		mockEmptyReliableMsgs,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.stateManager.On("EnsureABISchemas", mock.Anything, mock.Anything, "domain1", mock.Anything).Return([]components.Schema{
+				schema,
+			}, nil).Once()
+			mc.stateManager.On("WriteReceivedStates", mock.Anything, mock.Anything, "domain1", mock.Anything).
+				Return([]*pldapi.State{
+					{StateBase: pldapi.StateBase{ID: stateID, Schema: schemaID}},
+				}, nil).Once()
+			mc.groupManager.On("StoreReceivedGroupMembers", mock.Anything, mock.Anything, "domain1", "node2", mock.Anything, mock.Anything).
+				Return(fmt.Errorf("rejected"), nil)
+
+			mc.db.Mock.ExpectBegin()
+			mc.db.Mock.ExpectCommit()
+		},
+	)
+	defer done()
+
+	msg := testReceivedReliableMsg(RMHMessageTypePrivacyGroupMembers, testPrivacyGroupMembersDistribution(stateID, schemaID))
+
+	ackNackCheck := setupAckOrNackCheck(t, tp, msg.MessageID, "rejected")
+
+	p, err := tm.getPeer(ctx, "node2", false)
+	require.NoError(t, err)
+
+	err = tm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := tm.handleReliableMsgBatch(ctx, dbTX, []*reliableMsgOp{
+			{p: p, msg: msg},
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	ackNackCheck()
+}
+
+func TestHandlePrivacyGroupMembersBadState(t *testing.T) {
+	var stateID pldtypes.HexBytes = pldtypes.RandBytes(32)
+	schemaID := pldtypes.RandBytes32()
+	schema := componentsmocks.NewSchema(t)
+	ctx, tm, tp, done := newTestTransport(t, false,
+		mockGoodTransport,
+		mockEmptyReliableMsgs,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.stateManager.On("EnsureABISchemas", mock.Anything, mock.Anything, "domain1", mock.Anything).Return([]components.Schema{
+				schema,
+			}, nil).Once()
+			mc.stateManager.On("WriteReceivedStates", mock.Anything, mock.Anything, "domain1", mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+			mc.db.Mock.ExpectBegin()
+			mc.db.Mock.ExpectCommit()
+		},
+	)
+	defer done()
+
+	msg := testReceivedReliableMsg(RMHMessageTypePrivacyGroupMembers, testPrivacyGroupMembersDistribution(stateID, schemaID))
+
+	ackNackCheck := setupAckOrNackCheck(t, tp, msg.MessageID, "PD012022")
+
+	p, err := tm.getPeer(ctx, "node2", false)
+	require.NoError(t, err)
+
+	err = tm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := tm.handleReliableMsgBatch(ctx, dbTX, []*reliableMsgOp{
+			{p: p, msg: msg},
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	ackNackCheck()
+}
+
+func TestHandlePrivacyGroupMembersStoreFail(t *testing.T) {
+	var stateID pldtypes.HexBytes = pldtypes.RandBytes(32)
+	schemaID := pldtypes.RandBytes32()
+	schema := componentsmocks.NewSchema(t)
+	ctx, tm, _, done := newTestTransport(t, false,
+		mockEmptyReliableMsgs,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.stateManager.On("EnsureABISchemas", mock.Anything, mock.Anything, "domain1", mock.Anything).Return([]components.Schema{
+				schema,
+			}, nil).Once()
+			mc.stateManager.On("WriteReceivedStates", mock.Anything, mock.Anything, "domain1", mock.Anything).
+				Return([]*pldapi.State{
+					{StateBase: pldapi.StateBase{ID: stateID, Schema: schemaID}},
+				}, nil).Once()
+			mc.groupManager.On("StoreReceivedGroupMembers", mock.Anything, mock.Anything, "domain1", "node2", mock.Anything, mock.Anything).
+				Return(nil, fmt.Errorf("pop"))
+
+			mc.db.Mock.ExpectBegin()
+			mc.db.Mock.ExpectCommit()
+		},
+	)
+	defer done()
+
+	msg := testReceivedReliableMsg(RMHMessageTypePrivacyGroupMembers, testPrivacyGroupMembersDistribution(stateID, schemaID))
+
+	p, err := tm.getPeer(ctx, "node2", false)
+	require.NoError(t, err)
+
+	err = tm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := tm.handleReliableMsgBatch(ctx, dbTX, []*reliableMsgOp{
+			{p: p, msg: msg},
+		})
+		return err
+	})
+	require.Regexp(t, "pop", err)
+}
+
+func TestHandlePrivacyGroupMembersInvalid(t *testing.T) {
+	ctx, tm, tp, done := newTestTransport(t, false,
+		mockGoodTransport,
+		mockEmptyReliableMsgs,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.db.Mock.ExpectBegin()
+			mc.db.Mock.ExpectCommit()
+		},
+	)
+	defer done()
+
+	msg := testReceivedReliableMsg(
+		RMHMessageTypePrivacyGroupMembers,
+		&components.PrivacyGroupMembersDistribution{
+			/* invalid */
+		})
+
+	ackNackCheck := setupAckOrNackCheck(t, tp, msg.MessageID, "PD012016")
+
+	p, err := tm.getPeer(ctx, "node2", false)
+	require.NoError(t, err)
+
+	err = tm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		_, err := tm.handleReliableMsgBatch(ctx, dbTX, []*reliableMsgOp{
+			{p: p, msg: msg},
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	ackNackCheck()
+}
+
+func TestBuildPrivacyGroupMembersMsgBadMsg(t *testing.T) {
+
+	ctx, tm, _, done := newTestTransport(t, false,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.db.Mock.ExpectBegin()
+			mc.db.Mock.ExpectCommit()
+		},
+	)
+	defer done()
+
+	_, parseErr, err := tm.buildPrivacyGroupMembersMsg(ctx, tm.persistence.NOTX(), &pldapi.ReliableMessage{})
+	require.NoError(t, err)
+	require.Regexp(t, "PD012016", parseErr)
+
+}
+
+func TestBuildPrivacyGroupMembersMsgGetStatesError(t *testing.T) {
+
+	ctx, tm, _, done := newTestTransport(t, false,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.stateManager.On("GetStatesByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, false, false).
+				Return(nil, fmt.Errorf("pop")).Once()
+
+			mc.db.Mock.ExpectBegin()
+			mc.db.Mock.ExpectCommit()
+		},
+	)
+	defer done()
+
+	_, _, err := tm.buildPrivacyGroupMembersMsg(ctx, tm.persistence.NOTX(), &pldapi.ReliableMessage{
+		ID:          uuid.New(),
+		MessageType: pldapi.RMTPrivacyGroupMembers.Enum(),
+		Metadata:    pldtypes.JSONString(testPrivacyGroupMembersDistribution(pldtypes.RandBytes(32), pldtypes.RandBytes32())),
+	})
+	require.Regexp(t, "pop", err)
+
+}
+
+func TestBuildPrivacyGroupMembersMsgGetStatesNotFound(t *testing.T) {
+
+	ctx, tm, _, done := newTestTransport(t, false,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.stateManager.On("GetStatesByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, false, false).
+				Return(nil, nil).Once()
+
+			mc.db.Mock.ExpectBegin()
+			mc.db.Mock.ExpectCommit()
+		},
+	)
+	defer done()
+
+	_, parseErr, err := tm.buildPrivacyGroupMembersMsg(ctx, tm.persistence.NOTX(), &pldapi.ReliableMessage{
+		ID:          uuid.New(),
+		MessageType: pldapi.RMTPrivacyGroupMembers.Enum(),
+		Metadata:    pldtypes.JSONString(testPrivacyGroupMembersDistribution(pldtypes.RandBytes(32), pldtypes.RandBytes32())),
+	})
+	require.NoError(t, err)
+	require.Regexp(t, "PD012014", parseErr)
+
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// A request from an administrator to resend reliable messages to a peer, without waiting
+// for the resend interval to expire since the last send
+type redriveRequest struct {
+	all  bool
+	msgs map[uuid.UUID]bool
+}
+
+// Summary of the unacknowledged reliable messages for a single node
+type reliableBacklog struct {
+	Node   string             `gorm:"column:node"`
+	Count  uint64             `gorm:"column:backlog"`
+	Oldest pldtypes.Timestamp `gorm:"column:oldest"`
+}
+
+func (r *redriveRequest) includes(id uuid.UUID) bool {
+	return r != nil && (r.all || r.msgs[id])
+}
+
+func (r *redriveRequest) merge(prev *redriveRequest) *redriveRequest {
+	if prev != nil {
+		r.all = r.all || prev.all
+		for id := range prev.msgs {
+			r.msgs[id] = true
+		}
+	}
+	return r
+}
+
+// Requests the sender loop performs an immediate full scan, resending the specified
+// message (or all messages if nil) even if they were sent within the resend interval.
+func (p *peer) requestRedrive(msgID *uuid.UUID) {
+	p.redriveLock.Lock()
+	if p.pendingRedrive == nil {
+		p.pendingRedrive = &redriveRequest{msgs: make(map[uuid.UUID]bool)}
+	}
+	if msgID == nil {
+		p.pendingRedrive.all = true
+	} else {
+		p.pendingRedrive.msgs[*msgID] = true
+	}
+	p.redriveLock.Unlock()
+
+	p.tm.metrics.IncReliableRedrives(p.Name)
+	p.notifyPersistedMsgAvailable()
+}
+
+func (p *peer) takeRedrive() *redriveRequest {
+	p.redriveLock.Lock()
+	defer p.redriveLock.Unlock()
+	redrive := p.pendingRedrive
+	p.pendingRedrive = nil
+	return redrive
+}
+
+// The acked flag (rather than a join to the acks) is used so this is served by the partial
+// index of unacknowledged messages, and only scales with the size of the backlog
+func (tm *transportManager) queryReliableBacklog(ctx context.Context, dbTX persistence.DBTX, nodes ...string) (map[string]*reliableBacklog, error) {
+	query := dbTX.DB().
+		WithContext(ctx).
+		Table("reliable_msgs").
+		Select(`"reliable_msgs"."node" AS node, COUNT(*) AS backlog, MIN("reliable_msgs"."created") AS oldest`).
+		Where(`"reliable_msgs"."acked" IS FALSE`).
+		Group(`"reliable_msgs"."node"`)
+	if len(nodes) > 0 {
+		query = query.Where(`"reliable_msgs"."node" IN (?)`, nodes)
+	}
+
+	var rows []*reliableBacklog
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	backlog := make(map[string]*reliableBacklog, len(rows))
+	for _, b := range rows {
+		backlog[b.Node] = b
+	}
+	return backlog, nil
+}
+
+// Called periodically to set the backlog metrics for all nodes, including those that
+// do not currently have an active peer. The gauges are updated in place, so a scrape never
+// sees them missing, and only those for nodes that no longer have a backlog are removed.
+func (tm *transportManager) updateReliableMetrics() {
+	backlog, err := tm.queryReliableBacklog(tm.bgCtx, tm.persistence.NOTX())
+	if err != nil {
+		log.L(tm.bgCtx).Warnf("failed to query reliable message backlog for metrics: %s", err)
+		return
+	}
+	for node := range tm.reliableMetricNodes {
+		if backlog[node] == nil {
+			tm.metrics.DeleteReliableBacklog(node)
+		}
+	}
+	tm.reliableMetricNodes = make(map[string]bool, len(backlog))
+	for node, b := range backlog {
+		tm.metrics.SetReliableBacklog(node, b.Count, time.Since(b.Oldest.Time()))
+		tm.reliableMetricNodes[node] = true
+	}
+}
+
+func (tm *transportManager) getUnackedReliableMessage(ctx context.Context, id uuid.UUID) (*pldapi.ReliableMessage, error) {
+	rm, err := tm.getReliableMessageByID(ctx, tm.persistence.NOTX(), id)
+	if err != nil {
+		return nil, err
+	}
+	if rm == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportReliableMsgNotFound, id)
+	}
+	if rm.Ack != nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportReliableMsgAlreadyAcked, id, rm.Ack.Time)
+	}
+	return rm, nil
+}
+
+func (tm *transportManager) resendReliableMessage(ctx context.Context, id uuid.UUID) (*pldapi.ReliableMessage, error) {
+	rm, err := tm.getUnackedReliableMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// This activates the peer if it has been reaped since the message was queued
+	p, err := tm.getPeer(ctx, rm.Node, true)
+	if err != nil {
+		return nil, err
+	}
+
+	log.L(ctx).Infof("resend requested for reliable message %s to %s", rm.ID, p.Name)
+	p.requestRedrive(&rm.ID)
+	return rm, nil
+}
+
+func (tm *transportManager) resendReliableMessages(ctx context.Context, nodeName string) (uint64, error) {
+	p, err := tm.getPeer(ctx, nodeName, true)
+	if err != nil {
+		return 0, err
+	}
+
+	backlog, err := tm.queryReliableBacklog(ctx, tm.persistence.NOTX(), p.Name)
+	if err != nil {
+		return 0, err
+	}
+	var count uint64
+	if b := backlog[p.Name]; b != nil {
+		count = b.Count
+	}
+
+	log.L(ctx).Infof("resend requested for %d unacknowledged reliable messages to %s", count, p.Name)
+	p.requestRedrive(nil)
+	return count, nil
+}
+
+func (tm *transportManager) abandonReliableMessage(ctx context.Context, id uuid.UUID, reason string) (*pldapi.ReliableMessage, error) {
+	if reason == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgTransportAbandonReasonRequired, id)
+	}
+
+	rm, err := tm.getUnackedReliableMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// We write a nack, so the message is finalized and will not be retried. If an ack
+	// arrives from the peer at the same time then that one stands.
+	err = tm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return tm.insertAcks(ctx, dbTX, &pldapi.ReliableMessageAck{
+			MessageID: rm.ID,
+			Time:      pldtypes.TimestampNow(),
+			Error:     i18n.NewError(ctx, msgs.MsgTransportReliableMsgAbandoned, reason).Error(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.L(ctx).Warnf("reliable message %s to %s abandoned: %s", rm.ID, rm.Node, reason)
+	tm.metrics.IncReliableAbandoned(rm.Node)
+	return tm.getReliableMessageByID(ctx, tm.persistence.NOTX(), rm.ID)
+}
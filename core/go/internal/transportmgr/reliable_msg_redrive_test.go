@@ -0,0 +1,328 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gatherPeerMetrics(t *testing.T, registry *prometheus.Registry, peer string) map[string]float64 {
+	metricFamilies, err := registry.Gather()
+	require.NoError(t, err)
+	values := map[string]float64{}
+	for _, mf := range metricFamilies {
+		for _, m := range mf.GetMetric() {
+			if m.GetLabel()[0].GetValue() != peer {
+				continue
+			}
+			if m.GetGauge() != nil {
+				values[mf.GetName()] = m.GetGauge().GetValue()
+			} else {
+				values[mf.GetName()] = m.GetCounter().GetValue()
+			}
+		}
+	}
+	return values
+}
+
+func TestReliableMessageResendAndAbandonRealDB(t *testing.T) {
+
+	var mc *mockComponents
+	ctx, tm, tp, done := newTestTransport(t, true,
+		func(_mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc = _mc
+			// Long enough that only a redrive causes a resend within the test
+			conf.ReliableMessageResend = confutil.P("1h")
+		},
+		mockGoodTransport,
+		mockGetStateOk,
+	)
+	defer done()
+
+	mockActivateDeactivateOk(tp)
+
+	sentMessages := make(chan *prototk.PaladinMsg, 10)
+	tp.Functions.SendMessage = func(ctx context.Context, req *prototk.SendMessageRequest) (*prototk.SendMessageResponse, error) {
+		sentMessages <- req.Message
+		return nil, nil
+	}
+
+	client, rpcDone := newTestRPCServer(t, ctx, tm)
+	defer rpcDone()
+	transportRPC := pldclient.Wrap(client).Transport()
+
+	rm := &pldapi.ReliableMessage{
+		MessageType: pldapi.RMTState.Enum(),
+		Node:        "node2",
+		Metadata: pldtypes.JSONString(&components.StateDistribution{
+			Domain:          "domain1",
+			ContractAddress: pldtypes.RandAddress().String(),
+			SchemaID:        pldtypes.RandHex(32),
+			StateID:         pldtypes.RandHex(32),
+		}),
+	}
+	err := tm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return tm.SendReliable(ctx, dbTX, rm)
+	})
+	require.NoError(t, err)
+
+	msg := <-sentMessages
+	require.Equal(t, rm.ID.String(), msg.MessageId)
+
+	// The message is in the backlog, as it has not been acked
+	peer, err := transportRPC.PeerInfo(ctx, "node2")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), peer.Reliable.Backlog)
+	require.Equal(t, rm.Created, *peer.Reliable.OldestUnacked)
+	require.NotEmpty(t, peer.Reliable.OldestUnackedAge)
+	require.Empty(t, peer.Reliable.LastError)
+
+	tm.updateReliableMetrics()
+	peerMetrics := gatherPeerMetrics(t, mc.metricsManager.Registry(), "node2")
+	require.Equal(t, float64(1), peerMetrics["transport_manager_reliable_backlog"])
+	require.Greater(t, peerMetrics["transport_manager_reliable_oldest_unacked_age_seconds"], float64(0))
+
+	// Resend the specific message
+	rm1, err := transportRPC.ResendReliableMessage(ctx, rm.ID)
+	require.NoError(t, err)
+	require.Equal(t, rm.ID, rm1.ID)
+	msg = <-sentMessages
+	require.Equal(t, rm.ID.String(), msg.MessageId)
+
+	// Resend everything to the node
+	backlog, err := transportRPC.ResendReliableMessages(ctx, "node2")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), backlog)
+	msg = <-sentMessages
+	require.Equal(t, rm.ID.String(), msg.MessageId)
+
+	// Abandon the message
+	_, err = transportRPC.AbandonReliableMessage(ctx, rm.ID, "")
+	require.Regexp(t, "PD012034", err)
+	rm2, err := transportRPC.AbandonReliableMessage(ctx, rm.ID, "peer decommissioned")
+	require.NoError(t, err)
+	require.Regexp(t, "PD012035.*peer decommissioned", rm2.Ack.Error)
+
+	// Now it cannot be resent or abandoned again
+	_, err = transportRPC.AbandonReliableMessage(ctx, rm.ID, "again")
+	require.Regexp(t, "PD012033", err)
+	_, err = transportRPC.ResendReliableMessage(ctx, rm.ID)
+	require.Regexp(t, "PD012033", err)
+	_, err = transportRPC.ResendReliableMessage(ctx, uuid.New())
+	require.Regexp(t, "PD012032", err)
+
+	// The backlog is empty
+	peers, err := transportRPC.Peers(ctx)
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	require.Zero(t, peers[0].Reliable.Backlog)
+	require.Nil(t, peers[0].Reliable.OldestUnacked)
+	backlog, err = transportRPC.ResendReliableMessages(ctx, "node2")
+	require.NoError(t, err)
+	require.Zero(t, backlog)
+
+	tm.updateReliableMetrics()
+	peerMetrics = gatherPeerMetrics(t, mc.metricsManager.Registry(), "node2")
+	require.Equal(t, map[string]float64{
+		"transport_manager_reliable_redrives_total":  3,
+		"transport_manager_reliable_abandoned_total": 1,
+	}, peerMetrics)
+}
+
+func TestRedriveMergeRetainsPending(t *testing.T) {
+	id1 := uuid.New()
+	id2 := uuid.New()
+	prev := &redriveRequest{msgs: map[uuid.UUID]bool{id1: true}}
+	r := (&redriveRequest{msgs: map[uuid.UUID]bool{id2: true}}).merge(prev)
+	assert.True(t, r.includes(id1))
+	assert.True(t, r.includes(id2))
+	assert.False(t, r.includes(uuid.New()))
+
+	r = (&redriveRequest{msgs: map[uuid.UUID]bool{}}).merge(&redriveRequest{all: true})
+	assert.True(t, r.includes(uuid.New()))
+
+	var noRedrive *redriveRequest
+	assert.False(t, noRedrive.includes(id1))
+}
+
+func newTestIdlePeer(ctx context.Context, tm *transportManager, tp *testPlugin, nodeName string) *peer {
+	p := &peer{
+		tm:                     tm,
+		transport:              tp.t,
+		PeerInfo:               pldapi.PeerInfo{Name: nodeName},
+		persistedMsgsAvailable: make(chan struct{}, 1),
+		senderDone:             make(chan struct{}),
+	}
+	p.ctx, p.cancelCtx = context.WithCancel(ctx)
+	close(p.senderDone)
+	p.senderStarted.Store(true)
+	tm.peers[nodeName] = p
+	return p
+}
+
+func TestRedriveRetainedOnScanFailure(t *testing.T) {
+	var mc *mockComponents
+	ctx, tm, tp, done := newTestTransport(t, false, func(_mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		mc = _mc
+	})
+	defer done()
+
+	id1 := uuid.New()
+	id2 := uuid.New()
+	p := newTestIdlePeer(ctx, tm, tp, "node2")
+	p.requestRedrive(&id1)
+
+	mc.db.Mock.ExpectQuery("SELECT.*reliable_msgs").WillReturnError(fmt.Errorf("pop"))
+	err := p.reliableMessageScan(false)
+	require.Regexp(t, "pop", err)
+	require.True(t, p.activeRedrive.includes(id1))
+
+	// A second request while the first is outstanding is merged
+	p.requestRedrive(&id2)
+	mc.db.Mock.ExpectQuery("SELECT.*reliable_msgs").WillReturnRows(sqlmock.NewRows([]string{}))
+	err = p.reliableMessageScan(false)
+	require.NoError(t, err)
+	require.Nil(t, p.activeRedrive)
+	require.Nil(t, p.takeRedrive())
+}
+
+func TestPeerInfoLastSendError(t *testing.T) {
+	ctx, tm, tp, done := newTestTransport(t, false)
+	defer done()
+
+	tm.sendShortRetry.UTSetMaxAttempts(1)
+	tp.Functions.SendMessage = func(ctx context.Context, req *prototk.SendMessageRequest) (*prototk.SendMessageResponse, error) {
+		return nil, fmt.Errorf("pop")
+	}
+
+	p := newTestIdlePeer(ctx, tm, tp, "node2")
+	err := p.send(testPaladinMsg(), nil)
+	require.Regexp(t, "pop", err)
+
+	info := p.getInfo(nil)
+	require.Regexp(t, "pop", info.Reliable.LastError)
+	require.NotNil(t, info.Reliable.LastErrorTime)
+	require.Zero(t, info.Stats.SentMsgs)
+}
+
+func TestPeerInfoBacklogQueryFail(t *testing.T) {
+	var mc *mockComponents
+	ctx, tm, tp, done := newTestTransport(t, false, func(_mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		mc = _mc
+	})
+	defer done()
+
+	newTestIdlePeer(ctx, tm, tp, "node2")
+
+	mc.db.Mock.ExpectQuery("SELECT.*reliable_msgs").WillReturnError(fmt.Errorf("pop"))
+	_, err := tm.getPeerInfo(ctx, "node2")
+	require.Regexp(t, "pop", err)
+
+	// Only the backlog of the active peers is queried
+	mc.db.Mock.ExpectQuery("SELECT.*reliable_msgs").WithArgs("node2").WillReturnError(fmt.Errorf("pop"))
+	_, err = tm.listActivePeerInfo(ctx)
+	require.Regexp(t, "pop", err)
+
+	mc.db.Mock.ExpectQuery("SELECT.*reliable_msgs").WillReturnError(fmt.Errorf("pop"))
+	_, err = tm.resendReliableMessages(ctx, "node2")
+	require.Regexp(t, "pop", err)
+
+	mc.db.Mock.ExpectQuery("SELECT.*reliable_msgs").WillReturnError(fmt.Errorf("pop"))
+	tm.updateReliableMetrics()
+	require.NoError(t, mc.db.Mock.ExpectationsWereMet())
+}
+
+func TestListActivePeerInfoNoPeers(t *testing.T) {
+	var mc *mockComponents
+	ctx, tm, _, done := newTestTransport(t, false, func(_mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		mc = _mc
+	})
+	defer done()
+
+	peers, err := tm.listActivePeerInfo(ctx)
+	require.NoError(t, err)
+	require.Empty(t, peers)
+	require.NoError(t, mc.db.Mock.ExpectationsWereMet())
+}
+
+func TestResendReliableMessagesBadNode(t *testing.T) {
+	ctx, tm, _, done := newTestTransport(t, false)
+	defer done()
+
+	_, err := tm.resendReliableMessages(ctx, "node1")
+	require.Regexp(t, "PD012007", err)
+}
+
+func TestResendAbandonReliableMessageLookupFail(t *testing.T) {
+	var mc *mockComponents
+	ctx, tm, _, done := newTestTransport(t, false, func(_mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		mc = _mc
+	})
+	defer done()
+
+	mc.db.Mock.ExpectQuery("SELECT.*reliable_msgs").WillReturnError(fmt.Errorf("pop"))
+	_, err := tm.resendReliableMessage(ctx, uuid.New())
+	require.Regexp(t, "pop", err)
+
+	mc.db.Mock.ExpectQuery("SELECT.*reliable_msgs").WillReturnError(fmt.Errorf("pop"))
+	_, err = tm.abandonReliableMessage(ctx, uuid.New(), "reason")
+	require.Regexp(t, "pop", err)
+}
+
+func TestResendReliableMessageBadNode(t *testing.T) {
+	var mc *mockComponents
+	ctx, tm, _, done := newTestTransport(t, false, func(_mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		mc = _mc
+	})
+	defer done()
+
+	id := uuid.New()
+	mc.db.Mock.ExpectQuery("SELECT.*reliable_msgs").WillReturnRows(
+		sqlmock.NewRows([]string{"sequence", "id", "node"}).AddRow(1, id.String(), "node1"))
+	_, err := tm.resendReliableMessage(ctx, id)
+	require.Regexp(t, "PD012007", err)
+}
+
+func TestAbandonReliableMessageInsertFail(t *testing.T) {
+	var mc *mockComponents
+	ctx, tm, _, done := newTestTransport(t, false, func(_mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		mc = _mc
+	})
+	defer done()
+
+	id := uuid.New()
+	mc.db.Mock.ExpectQuery("SELECT.*reliable_msgs").WillReturnRows(
+		sqlmock.NewRows([]string{"sequence", "id", "node"}).AddRow(1, id.String(), "node2"))
+	mc.db.Mock.ExpectExec("INSERT.*reliable_msg_acks").WillReturnError(fmt.Errorf("pop"))
+	_, err := tm.abandonReliableMessage(ctx, id, "reason")
+	require.Regexp(t, "pop", err)
+}
@@ -163,7 +163,11 @@ func (t *transport) ReceiveMessage(ctx context.Context, req *prototk.ReceiveMess
 		return nil, err
 	}
 
-	msg := req.Message
+	// Sealed payloads are verified and decrypted before anything else is done with the message
+	msg, err := t.tm.openPayload(ctx, req.FromNode, req.Message)
+	if err != nil {
+		return nil, err
+	}
 
 	rMsg, err := parseReceivedMessage(ctx, req.FromNode, msg)
 	if err != nil {
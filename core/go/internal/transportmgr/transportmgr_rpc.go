@@ -19,6 +19,7 @@ package transportmgr
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
@@ -36,7 +37,10 @@ func (tm *transportManager) initRPC() {
 		Add("transport_peers", tm.rpcPeers()).
 		Add("transport_peerInfo", tm.rpcPeerInfo()).
 		Add("transport_queryReliableMessages", tm.rpcQueryReliableMessages()).
-		Add("transport_queryReliableMessageAcks", tm.rpcQueryReliableMessageAcks())
+		Add("transport_queryReliableMessageAcks", tm.rpcQueryReliableMessageAcks()).
+		Add("transport_resendReliableMessage", tm.rpcResendReliableMessage()).
+		Add("transport_resendReliableMessages", tm.rpcResendReliableMessages()).
+		Add("transport_abandonReliableMessage", tm.rpcAbandonReliableMessage())
 }
 
 func (tm *transportManager) rpcNodeName() rpcserver.RPCHandler {
@@ -49,7 +53,7 @@ func (tm *transportManager) rpcNodeName() rpcserver.RPCHandler {
 func (tm *transportManager) rpcLocalTransports() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod0(func(ctx context.Context,
 	) ([]string, error) {
-		return tm.getTransportNames(), nil
+		return tm.LocalTransportNames(), nil
 	})
 }
 
@@ -57,19 +61,19 @@ func (tm *transportManager) rpcLocalTransportDetails() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		transportName string,
 	) (string, error) {
-		return tm.getLocalTransportDetails(ctx, transportName)
+		return tm.GetLocalTransportDetails(ctx, transportName)
 	})
 }
 
 func (tm *transportManager) rpcPeers() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*pldapi.PeerInfo, error) {
-		return tm.listActivePeerInfo(), nil
+		return tm.listActivePeerInfo(ctx)
 	})
 }
 
 func (tm *transportManager) rpcPeerInfo() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context, nodeName string) (*pldapi.PeerInfo, error) {
-		return tm.getPeerInfo(nodeName), nil
+		return tm.getPeerInfo(ctx, nodeName)
 	})
 }
 
@@ -84,3 +88,21 @@ func (tm *transportManager) rpcQueryReliableMessageAcks() rpcserver.RPCHandler {
 		return tm.QueryReliableMessageAcks(ctx, tm.persistence.NOTX(), &jq)
 	})
 }
+
+func (tm *transportManager) rpcResendReliableMessage() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, messageID uuid.UUID) (*pldapi.ReliableMessage, error) {
+		return tm.resendReliableMessage(ctx, messageID)
+	})
+}
+
+func (tm *transportManager) rpcResendReliableMessages() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, nodeName string) (uint64, error) {
+		return tm.resendReliableMessages(ctx, nodeName)
+	})
+}
+
+func (tm *transportManager) rpcAbandonReliableMessage() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context, messageID uuid.UUID, reason string) (*pldapi.ReliableMessage, error) {
+		return tm.abandonReliableMessage(ctx, messageID, reason)
+	})
+}
@@ -38,7 +38,7 @@ import (
 )
 
 func TestRPCLocalDetails(t *testing.T) {
-	ctx, tm, tp, done := newTestTransport(t, false)
+	ctx, tm, tp, done := newTestTransport(t, true)
 	defer done()
 
 	client, rpcDone := newTestRPCServer(t, ctx, tm)
@@ -71,10 +71,12 @@ func TestRPCLocalDetails(t *testing.T) {
 	require.NoError(t, rpcErr)
 	require.Len(t, peers, 1)
 	require.Equal(t, "node2", peers[0].Name)
+	require.Equal(t, &pldapi.PeerReliableInfo{}, peers[0].Reliable)
 
 	peer, rpcErr := transportRPC.PeerInfo(ctx, "node2")
 	require.NoError(t, rpcErr)
 	require.Equal(t, "node2", peer.Name)
+	require.Zero(t, peer.Reliable.Backlog)
 	peer, rpcErr = transportRPC.PeerInfo(ctx, "node3")
 	require.NoError(t, rpcErr)
 	require.Nil(t, peer)
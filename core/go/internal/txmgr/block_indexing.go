@@ -18,8 +18,10 @@ package txmgr
 import (
 	"context"
 
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
@@ -43,8 +45,8 @@ func (tm *txManager) blockIndexerPreCommit(
 	// Ok now we have an ordered list of completions that match Paladin transactions
 	// - If they are public paladin transactions - just finalize the receipts on this routine
 	// - If they are private paladin transactions - the private TX manager only needs to be
-	// notified if it was a failure. Because success cases are processed as events on the
-	// separate ordering context of the block listener of that domain (we do not promise
+	// notified if it was a failure (including being cancelled). Because success cases are processed
+	// as events on the separate ordering context of the block listener of that domain (we do not promise
 	// order of confirmation delivery between public and private transactions)
 	finalizeInfo := make([]*components.ReceiptInput, 0, len(txMatches))
 	failedForPrivateTx := make([]*components.PublicTxMatch, 0)
@@ -54,11 +56,12 @@ func (tm *txManager) blockIndexerPreCommit(
 			log.L(ctx).Infof("Writing receipt for transaction %s hash=%s block=%d result=%s",
 				match.TransactionID, match.Hash, match.BlockNumber, match.Result)
 			// Map to the common format for finalizing transactions whether the make it on chain or not
-			finalizeInfo = append(finalizeInfo, tm.mapBlockchainReceipt(match))
+			finalizeInfo = append(finalizeInfo, tm.mapBlockchainReceipt(ctx, match))
 		case pldapi.TransactionTypePrivate:
-			if match.Result.V() != pldapi.TXResult_SUCCESS {
-				log.L(ctx).Infof("Base ledger transaction for private transaction %s FAILED hash=%s block=%d result=%s",
-					match.TransactionID, match.Hash, match.BlockNumber, match.Result)
+			// the transfer to self that replaces a cancelled transaction succeeds, but the private transaction failed
+			if match.Cancelled || match.Result.V() != pldapi.TXResult_SUCCESS {
+				log.L(ctx).Infof("Base ledger transaction for private transaction %s FAILED hash=%s block=%d result=%s cancelled=%t",
+					match.TransactionID, match.Hash, match.BlockNumber, match.Result, match.Cancelled)
 				failedForPrivateTx = append(failedForPrivateTx, match)
 			}
 		}
@@ -90,7 +93,7 @@ func (tm *txManager) blockIndexerPreCommit(
 	return nil
 }
 
-func (tm *txManager) mapBlockchainReceipt(pubTx *components.PublicTxMatch) *components.ReceiptInput {
+func (tm *txManager) mapBlockchainReceipt(ctx context.Context, pubTx *components.PublicTxMatch) *components.ReceiptInput {
 	receipt := &components.ReceiptInput{
 		TransactionID: pubTx.TransactionID,
 		OnChain: pldtypes.OnChainLocation{
@@ -102,7 +105,12 @@ func (tm *txManager) mapBlockchainReceipt(pubTx *components.PublicTxMatch) *comp
 		ContractAddress: pubTx.ContractAddress,
 		RevertData:      pubTx.RevertReason,
 	}
-	if pubTx.Result.V() == pldapi.TXResult_SUCCESS {
+	if pubTx.Cancelled {
+		// the transfer to self that replaced the transaction succeeded, but the transaction itself did not
+		receipt.ReceiptType = components.RT_FailedWithMessage
+		receipt.FailureMessage = i18n.NewError(ctx, msgs.MsgPublicTxCancelled, pubTx.From, pubTx.Nonce, pubTx.Hash).Error()
+		receipt.RevertData = nil
+	} else if pubTx.Result.V() == pldapi.TXResult_SUCCESS {
 		receipt.ReceiptType = components.RT_Success
 	} else {
 		receipt.ReceiptType = components.RT_FailedOnChainWithRevertData
@@ -126,6 +126,67 @@ func TestPublicConfirmWithErrorDecodeRealDB(t *testing.T) {
 	assert.Equal(t, `PD012216: Transaction reverted ErrorNum("12345")`, receipt.FailureMessage)
 }
 
+func TestPublicConfirmCancelledRealDB(t *testing.T) {
+
+	txi := newTestConfirm()
+	txi.To = txi.From
+	txi.ContractAddress = nil
+	var txID uuid.UUID
+
+	ctx, txm, done := newTestTransactionManager(t, true,
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mockResolveKey(t, mc, "sender1", pldtypes.RandAddress())
+
+			mc.publicTxMgr.On("ValidateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mc.publicTxMgr.On("WriteNewTransactions", mock.Anything, mock.Anything, mock.Anything).Return(
+				[]*pldapi.PublicTx{
+					{LocalID: confutil.P(uint64(42))},
+				},
+				nil,
+			)
+
+			mut := mc.publicTxMgr.On("MatchUpdateConfirmedTransactions", mock.Anything, mock.Anything, []*blockindexer.IndexedTransactionNotify{txi})
+			mut.Run(func(args mock.Arguments) {
+				mut.Return([]*components.PublicTxMatch{
+					{
+						PaladinTXReference: components.PaladinTXReference{
+							TransactionID:   txID,
+							TransactionType: pldapi.TransactionTypePublic.Enum(),
+						},
+						IndexedTransactionNotify: txi,
+						Cancelled:                true,
+					},
+				}, nil)
+			})
+
+			mc.publicTxMgr.On("NotifyConfirmPersisted", mock.Anything, mock.Anything)
+		})
+	defer done()
+
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		txIDs, err := txm.SendTransactions(ctx, dbTX, &pldapi.TransactionInput{
+			TransactionBase: pldapi.TransactionBase{
+				Type: pldapi.TransactionTypePublic.Enum(),
+				From: "sender1",
+				To:   pldtypes.MustEthAddress(pldtypes.RandHex(20)),
+			},
+			ABI: abi.ABI{{Type: abi.Function, Name: "doIt", Inputs: abi.ParameterArray{}}},
+		})
+		require.NoError(t, err)
+		txID = txIDs[0]
+
+		return txm.blockIndexerPreCommit(ctx, dbTX, []*pldapi.IndexedBlock{},
+			[]*blockindexer.IndexedTransactionNotify{txi})
+	})
+	require.NoError(t, err)
+
+	receipt, err := txm.GetTransactionReceiptByID(ctx, txID)
+	require.NoError(t, err)
+	assert.False(t, receipt.Success)
+	assert.Equal(t, txi.Hash, *receipt.TransactionHash)
+	assert.Regexp(t, "PD011948.*"+txi.Hash.String(), receipt.FailureMessage)
+}
+
 func mockEmptyReceiptListeners(conf *pldconf.TxManagerConfig, mc *mockComponents) {
 	mc.db.ExpectQuery("SELECT.*receipt_listeners").WillReturnRows(sqlmock.NewRows([]string{}))
 }
@@ -185,12 +246,14 @@ func TestPrivateConfirmMatchPrivateFailures(t *testing.T) {
 	txID1 := uuid.New()
 	txiFail2 := newTestConfirm(revertData) // one failed
 	txID2 := uuid.New()
+	txiCancelled3 := newTestConfirm() // one was replaced by a successful transfer to self
+	txID3 := uuid.New()
 
 	ctx, txm, done := newTestTransactionManager(t, false,
 		mockEmptyReceiptListeners,
 		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
 			mc.publicTxMgr.On("MatchUpdateConfirmedTransactions", mock.Anything, mock.Anything,
-				[]*blockindexer.IndexedTransactionNotify{txiOk1, txiFail2}).
+				[]*blockindexer.IndexedTransactionNotify{txiOk1, txiFail2, txiCancelled3}).
 				Return([]*components.PublicTxMatch{
 					{
 						PaladinTXReference: components.PaladinTXReference{
@@ -206,26 +269,36 @@ func TestPrivateConfirmMatchPrivateFailures(t *testing.T) {
 						},
 						IndexedTransactionNotify: txiFail2,
 					},
+					{
+						PaladinTXReference: components.PaladinTXReference{
+							TransactionID:   txID3,
+							TransactionType: pldapi.TransactionTypePrivate.Enum(),
+						},
+						IndexedTransactionNotify: txiCancelled3,
+						Cancelled:                true,
+					},
 				}, nil)
 
 			mc.db.ExpectBegin()
 			mc.db.ExpectCommit()
 			mc.privateTxMgr.On("NotifyFailedPublicTx", mock.Anything, mock.Anything, mock.MatchedBy(func(matches []*components.PublicTxMatch) bool {
-				return len(matches) == 1 &&
-					matches[0].TransactionID == txID2
+				return len(matches) == 2 &&
+					matches[0].TransactionID == txID2 &&
+					matches[1].TransactionID == txID3 && matches[1].Cancelled
 			})).Return(nil)
 
 			mc.publicTxMgr.On("NotifyConfirmPersisted", mock.Anything, mock.MatchedBy(func(matches []*components.PublicTxMatch) bool {
-				return len(matches) == 2 &&
+				return len(matches) == 3 &&
 					matches[0].TransactionID == txID1 &&
-					matches[1].TransactionID == txID2
+					matches[1].TransactionID == txID2 &&
+					matches[2].TransactionID == txID3
 			}))
 		})
 	defer done()
 
 	err = txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
 		return txm.blockIndexerPreCommit(ctx, dbTX, []*pldapi.IndexedBlock{},
-			[]*blockindexer.IndexedTransactionNotify{txiOk1, txiFail2})
+			[]*blockindexer.IndexedTransactionNotify{txiOk1, txiFail2, txiCancelled3})
 	})
 	require.NoError(t, err)
 }
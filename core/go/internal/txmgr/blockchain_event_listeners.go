@@ -23,13 +23,16 @@ import (
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/deadletters"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 )
 
 var ES_TYPE = blockindexer.EventStreamTypePTXBlockchainEventListener.Enum()
@@ -55,13 +58,26 @@ type blockchainEventListener struct {
 
 	definition *blockindexer.EventStream
 
+	// set before the event stream is added, as delivery can start before the definition is returned
+	name            string
+	maxRedeliveries *int
+
 	receiverLock    sync.Mutex
 	receivers       []*registeredBlockchainEventReceiver
 	newReceivers    chan bool
 	receiverCounter int
+
+	// Replays of dead letters are delivered from a separate routine to the event stream,
+	// so the lock ensures we only deliver one batch at a time
+	deliveryLock   sync.Mutex
+	failedBatchID  uuid.UUID
+	failedAttempts int
+	newReplays     chan bool
+	replayDone     chan struct{}
 }
 
 func (tm *txManager) blockchainEventsInit() {
+	tm.blockchainEventsRetry = retry.NewRetryIndefinite(&pldconf.RetryConfig{}, &pldconf.GenericRetryDefaults.RetryConfig)
 	tm.blockchainEventListeners = make(map[string]*blockchainEventListener)
 	tm.blockchainEventListenersLoadPageSize = 100 /* not currently tunable */
 }
@@ -106,6 +122,18 @@ func (tm *txManager) loadBlockchainEventListener(ctx context.Context, es *blocki
 	el := &blockchainEventListener{
 		tm:           tm,
 		newReceivers: make(chan bool, 1),
+		newReplays:   make(chan bool, 1),
+
+		name:            es.Name,
+		maxRedeliveries: es.Config.MaxRedeliveries,
+	}
+
+	if es.Config.Webhook != nil {
+		wr, err := newWebhookReceiver(ctx, es.Name, es.Config.Webhook)
+		if err != nil {
+			return nil, err
+		}
+		el.addReceiver(wr)
 	}
 
 	el.ctx, el.cancelCtx = context.WithCancel(log.WithLogField(el.tm.bgCtx, "blockchain-event-listener", es.Name))
@@ -119,6 +147,11 @@ func (tm *txManager) loadBlockchainEventListener(ctx context.Context, es *blocki
 		return nil, err
 	}
 	tm.blockchainEventListeners[es.Name] = el
+
+	// Check for any dead letters left marked for replay before we were last stopped
+	el.replayDone = make(chan struct{})
+	el.notifyReplays()
+	go el.replayLoop()
 	return el, nil
 }
 
@@ -130,6 +163,7 @@ func (tm *txManager) stopBlockchainEventListeners() {
 		if err := tm.blockIndexer.StopEventStream(tm.bgCtx, el.definition.ID); err != nil {
 			log.L(tm.bgCtx).Errorf("Error stopping event listener '%s': %s", el.definition.Name, err)
 		}
+		el.stop()
 	}
 }
 
@@ -201,11 +235,62 @@ func (tm *txManager) DeleteBlockchainEventListener(ctx context.Context, name str
 	}
 	err := tm.blockIndexer.RemoveEventStream(ctx, el.definition.ID)
 	if err == nil {
+		el.stop()
 		delete(tm.blockchainEventListeners, name)
+		err = tm.blockchainEventDeadLetters.DeleteAll(ctx, name)
 	}
 	return err
 }
 
+func (tm *txManager) getLoadedBlockchainEventListener(ctx context.Context, name string) (*blockchainEventListener, error) {
+	tm.blockchainEventListenerLock.Lock()
+	defer tm.blockchainEventListenerLock.Unlock()
+
+	el := tm.blockchainEventListeners[name]
+	if el == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrBlockchainEventListenerNotLoaded, name)
+	}
+	return el, nil
+}
+
+func (tm *txManager) QueryBlockchainEventListenerDeadLetters(ctx context.Context, dbTX persistence.DBTX, name string, jq *query.QueryJSON) ([]*pldapi.ListenerDeadLetter, error) {
+	if _, err := tm.getLoadedBlockchainEventListener(ctx, name); err != nil {
+		return nil, err
+	}
+	return tm.blockchainEventDeadLetters.Query(ctx, dbTX, name, jq)
+}
+
+func (tm *txManager) ReplayBlockchainEventListenerDeadLetter(ctx context.Context, name string, id uuid.UUID) error {
+	el, err := tm.getLoadedBlockchainEventListener(ctx, name)
+	if err == nil {
+		err = tm.blockchainEventDeadLetters.MarkForReplay(ctx, name, id)
+	}
+	if err != nil {
+		return err
+	}
+	el.notifyReplays()
+	return nil
+}
+
+func (tm *txManager) DiscardBlockchainEventListenerDeadLetter(ctx context.Context, name string, id uuid.UUID) error {
+	if _, err := tm.getLoadedBlockchainEventListener(ctx, name); err != nil {
+		return err
+	}
+	return tm.blockchainEventDeadLetters.Discard(ctx, name, id)
+}
+
+// Moves the checkpoint of the listener to the supplied block, so that events in the blocks
+// after that block are delivered (again).
+func (tm *txManager) ResetBlockchainEventListenerCheckpoint(ctx context.Context, name string, blockNumber int64) error {
+	tm.blockchainEventListenerLock.Lock()
+	defer tm.blockchainEventListenerLock.Unlock()
+	el := tm.blockchainEventListeners[name]
+	if el == nil {
+		return i18n.NewError(ctx, msgs.MsgTxMgrBlockchainEventListenerNotLoaded, name)
+	}
+	return tm.blockIndexer.ResetEventStreamCheckpoint(ctx, el.definition.ID, blockNumber)
+}
+
 func (tm *txManager) GetBlockchainEventListenerStatus(ctx context.Context, name string) (*pldapi.BlockchainEventListenerStatus, error) {
 	tm.blockchainEventListenerLock.Lock()
 	defer tm.blockchainEventListenerLock.Unlock()
@@ -251,7 +336,12 @@ func (tm *txManager) validateBlockchainEventListenerSpec(ctx context.Context, sp
 			return i18n.NewError(ctx, msgs.MsgTxMgrBlockchainEventListenerInvalidTimeout, *spec.Options.BatchTimeout, err.Error())
 		}
 	}
-	return nil
+	if spec.Options.Webhook != nil {
+		if _, err := newWebhookReceiver(ctx, spec.Name, spec.Options.Webhook); err != nil {
+			return err
+		}
+	}
+	return deadletters.ValidateMaxRedeliveries(ctx, msgs.MsgTxMgrInvalidMaxRedeliveries, spec.Name, spec.Options.MaxRedeliveries)
 }
 
 func (tm *txManager) mapEventStream(el *pldapi.BlockchainEventListener) *blockindexer.EventStream {
@@ -260,9 +350,13 @@ func (tm *txManager) mapEventStream(el *pldapi.BlockchainEventListener) *blockin
 		Type:    ES_TYPE,
 		Started: el.Started,
 		Config: blockindexer.EventStreamConfig{
-			BatchSize:    el.Options.BatchSize,
-			BatchTimeout: el.Options.BatchTimeout,
-			FromBlock:    el.Options.FromBlock,
+			BatchSize:           el.Options.BatchSize,
+			BatchTimeout:        el.Options.BatchTimeout,
+			FromBlock:           el.Options.FromBlock,
+			Webhook:             el.Options.Webhook,
+			MaxRedeliveries:     el.Options.MaxRedeliveries,
+			Unconfirmed:         el.Options.Unconfirmed,
+			DecodeFailurePolicy: el.Options.DecodeFailurePolicy,
 		},
 	}
 
@@ -270,6 +364,8 @@ func (tm *txManager) mapEventStream(el *pldapi.BlockchainEventListener) *blockin
 		es.Sources = append(es.Sources, blockindexer.EventStreamSource{
 			ABI:     source.ABI,
 			Address: source.Address,
+			Topics:  source.Topics,
+			Factory: source.Factory,
 		})
 	}
 
@@ -282,15 +378,21 @@ func (tm *txManager) mapBlockchainEventListener(es *blockindexer.EventStream) *p
 		Started: es.Started,
 		Created: es.Created,
 		Options: pldapi.BlockchainEventListenerOptions{
-			BatchSize:    es.Config.BatchSize,
-			BatchTimeout: es.Config.BatchTimeout,
-			FromBlock:    es.Config.FromBlock,
+			BatchSize:           es.Config.BatchSize,
+			BatchTimeout:        es.Config.BatchTimeout,
+			FromBlock:           es.Config.FromBlock,
+			Webhook:             redactWebhook(es.Config.Webhook),
+			MaxRedeliveries:     es.Config.MaxRedeliveries,
+			Unconfirmed:         es.Config.Unconfirmed,
+			DecodeFailurePolicy: es.Config.DecodeFailurePolicy,
 		},
 	}
 	for _, source := range es.Sources {
 		el.Sources = append(el.Sources, pldapi.BlockchainEventListenerSource{
 			ABI:     source.ABI,
 			Address: source.Address,
+			Topics:  source.Topics,
+			Factory: source.Factory,
 		})
 	}
 
@@ -368,7 +470,23 @@ func (el *blockchainEventListener) nextReceiver() (r components.BlockchainEventR
 	}
 }
 
-func (el *blockchainEventListener) handleEventBatch(_ context.Context, batch *blockindexer.EventDeliveryBatch) error {
+func (el *blockchainEventListener) stop() {
+	if el.cancelCtx != nil {
+		el.cancelCtx()
+	}
+	if el.replayDone != nil {
+		<-el.replayDone
+	}
+}
+
+func (el *blockchainEventListener) notifyReplays() {
+	select {
+	case el.newReplays <- true:
+	default:
+	}
+}
+
+func (el *blockchainEventListener) deliverBatch(batch *blockindexer.EventDeliveryBatch) error {
 	r, err := el.nextReceiver()
 	if err != nil {
 		return err
@@ -378,3 +496,67 @@ func (el *blockchainEventListener) handleEventBatch(_ context.Context, batch *bl
 	log.L(el.ctx).Infof("Delivered blockchain event batch %s (err=%v)", batch.BatchID, err)
 	return err
 }
+
+// The event stream retries a failed batch indefinitely, and only moves its checkpoint once we return
+// success. So we count the attempts for the batch here, and report success once we have parked it
+// as a dead letter.
+func (el *blockchainEventListener) handleEventBatch(_ context.Context, batch *blockindexer.EventDeliveryBatch) error {
+	el.deliveryLock.Lock()
+	defer el.deliveryLock.Unlock()
+
+	deliveryErr := el.deliverBatch(batch)
+	if deliveryErr == nil || el.ctx.Err() != nil {
+		el.failedBatchID = uuid.Nil
+		return deliveryErr
+	}
+	if el.failedBatchID == batch.BatchID {
+		el.failedAttempts++
+	} else {
+		el.failedBatchID = batch.BatchID
+		el.failedAttempts = 1
+	}
+	if !deadletters.RedeliveriesExhausted(el.maxRedeliveries, el.failedAttempts) {
+		return deliveryErr
+	}
+	err := el.tm.blockchainEventDeadLetters.Write(el.ctx, el.tm.blockchainEventsRetry, el.name,
+		batch.BatchID.String(), el.failedAttempts, deliveryErr, batch.Events)
+	if err != nil {
+		return err
+	}
+	el.failedBatchID = uuid.Nil
+	return nil
+}
+
+func (el *blockchainEventListener) replayLoop() {
+	defer close(el.replayDone)
+
+	for {
+		select {
+		case <-el.newReplays:
+		case <-el.ctx.Done():
+			log.L(el.ctx).Debugf("replay loop stopping")
+			return
+		}
+
+		if err := el.replayDeadLetters(); err != nil {
+			log.L(el.ctx).Warnf("replay loop stopping: %s", err) // cancelled context
+			return
+		}
+	}
+}
+
+func (el *blockchainEventListener) replayDeadLetters() error {
+	el.deliveryLock.Lock()
+	defer el.deliveryLock.Unlock()
+
+	return deadletters.Replay(el.ctx, el.tm.blockchainEventDeadLetters, el.tm.blockchainEventsRetry, el.name, el.tm.blockchainEventListenersLoadPageSize, el.maxRedeliveries,
+		func(events []*pldapi.EventWithData) (string, func() error) {
+			batch := &blockindexer.EventDeliveryBatch{
+				BatchID: uuid.New(),
+				Events:  events,
+			}
+			return batch.BatchID.String(), func() error {
+				return el.deliverBatch(batch)
+			}
+		})
+}
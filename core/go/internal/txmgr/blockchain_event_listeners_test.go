@@ -221,18 +221,24 @@ func TestCreateBlockchainEventListener(t *testing.T) {
 		assert.Equal(t, blockindexer.EventStreamTypePTXBlockchainEventListener.Enum(), def.Type)
 		assert.Equal(t, "1m", *def.Config.BatchTimeout)
 		assert.Equal(t, json.RawMessage(`4`), def.Config.FromBlock)
+		assert.True(t, *def.Config.Unconfirmed)
+		assert.Equal(t, pldapi.BlockchainEventDecodeFailurePolicyHalt, def.Config.DecodeFailurePolicy.V())
 		assert.Equal(t, mockABI, def.Sources[0].ABI)
 		assert.Equal(t, mockAddress, def.Sources[0].Address)
+		assert.Equal(t, [][]pldtypes.HexBytes{{mockAddress[:]}}, def.Sources[0].Topics)
 	})
 	err = txm.CreateBlockchainEventListener(ctx, &pldapi.BlockchainEventListener{
 		Name: "bel1",
 		Options: pldapi.BlockchainEventListenerOptions{
-			BatchTimeout: confutil.P("1m"),
-			FromBlock:    json.RawMessage(`4`),
+			BatchTimeout:        confutil.P("1m"),
+			FromBlock:           json.RawMessage(`4`),
+			Unconfirmed:         confutil.P(true),
+			DecodeFailurePolicy: pldapi.BlockchainEventDecodeFailurePolicyHalt.Enum(),
 		},
 		Sources: []pldapi.BlockchainEventListenerSource{{
 			ABI:     mockABI,
 			Address: mockAddress,
+			Topics:  [][]pldtypes.HexBytes{{mockAddress[:]}},
 		}},
 	})
 	assert.NoError(t, err)
@@ -260,13 +266,18 @@ func TestQueryBlockchainEventListeners(t *testing.T) {
 			Name:    "bel1",
 			Started: confutil.P(true),
 			Config: blockindexer.EventStreamConfig{
-				BatchTimeout: confutil.P("1m"),
-				BatchSize:    confutil.P(100),
-				FromBlock:    json.RawMessage(`"latest"`),
+				BatchTimeout:        confutil.P("1m"),
+				BatchSize:           confutil.P(100),
+				FromBlock:           json.RawMessage(`"latest"`),
+				Unconfirmed:         confutil.P(true),
+				DecodeFailurePolicy: pldapi.BlockchainEventDecodeFailurePolicyDeliver.Enum(),
 			},
 			Sources: blockindexer.EventSources{{
-				ABI:     mockABI,
-				Address: mockAddress,
+				ABI: mockABI,
+				Factory: &pldapi.BlockchainEventListenerFactory{
+					Address:      *mockAddress,
+					AddressParam: "addr",
+				},
 			}},
 		}}, nil).Once()
 	mockQuery.Run(func(args mock.Arguments) {
@@ -282,8 +293,10 @@ func TestQueryBlockchainEventListeners(t *testing.T) {
 	assert.Equal(t, "1m", *listeners[0].Options.BatchTimeout)
 	assert.Equal(t, 100, *listeners[0].Options.BatchSize)
 	assert.Equal(t, "\"latest\"", string(listeners[0].Options.FromBlock))
+	assert.True(t, *listeners[0].Options.Unconfirmed)
+	assert.Equal(t, pldapi.BlockchainEventDecodeFailurePolicyDeliver, listeners[0].Options.DecodeFailurePolicy.V())
 	assert.Equal(t, mockABI, listeners[0].Sources[0].ABI)
-	assert.Equal(t, mockAddress, listeners[0].Sources[0].Address)
+	assert.Equal(t, *mockAddress, listeners[0].Sources[0].Factory.Address)
 
 }
 
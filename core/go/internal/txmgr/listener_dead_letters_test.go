@@ -0,0 +1,310 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/deadletters"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func mockNoDeadLetterReplays(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+	mc.db.MatchExpectationsInOrder(false)
+	mc.db.ExpectQuery("SELECT.*listener_dead_letters").WillReturnRows(sqlmock.NewRows([]string{}))
+}
+
+type testFailingReceiver struct {
+	fail     atomic.Bool
+	receipts chan *pldapi.TransactionReceiptFull
+	events   chan *pldapi.EventWithData
+}
+
+func newTestFailingReceiver() *testFailingReceiver {
+	tfr := &testFailingReceiver{
+		receipts: make(chan *pldapi.TransactionReceiptFull, 1),
+		events:   make(chan *pldapi.EventWithData, 1),
+	}
+	tfr.fail.Store(true)
+	return tfr
+}
+
+func (tfr *testFailingReceiver) DeliverReceiptBatch(ctx context.Context, batchID uint64, receipts []*pldapi.TransactionReceiptFull) error {
+	if tfr.fail.Load() {
+		return fmt.Errorf("pop")
+	}
+	for _, r := range receipts {
+		tfr.receipts <- r
+	}
+	return nil
+}
+
+func (tfr *testFailingReceiver) DeliverBlockchainEventBatch(ctx context.Context, batchID uuid.UUID, events []*pldapi.EventWithData) error {
+	if tfr.fail.Load() {
+		return fmt.Errorf("pop")
+	}
+	for _, e := range events {
+		tfr.events <- e
+	}
+	return nil
+}
+
+func waitDeadLetters(t *testing.T, query func() ([]*pldapi.ListenerDeadLetter, error), count int) []*pldapi.ListenerDeadLetter {
+	for {
+		dls, err := query()
+		require.NoError(t, err)
+		if len(dls) == count {
+			return dls
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReceiptListenerDeadLetters(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, true, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		conf.ReceiptListeners.Retry.InitialDelay = confutil.P("1ms")
+	})
+	defer done()
+
+	err := txm.CreateReceiptListener(ctx, &pldapi.TransactionReceiptListener{
+		Name: "listener1",
+		Options: pldapi.TransactionReceiptListenerOptions{
+			MaxRedeliveries: confutil.P(1),
+		},
+	})
+	require.NoError(t, err)
+
+	tfr := newTestFailingReceiver()
+	r, err := txm.AddReceiptReceiver(ctx, "listener1", tfr)
+	require.NoError(t, err)
+	defer r.Close()
+
+	finalizeReceipt := func() uuid.UUID {
+		txID := uuid.New()
+		err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return txm.FinalizeTransactions(ctx, dbTX, []*components.ReceiptInput{{
+				ReceiptType:   components.RT_Success,
+				TransactionID: txID,
+				OnChain:       randOnChain(pldtypes.RandAddress()),
+			}})
+		})
+		require.NoError(t, err)
+		return txID
+	}
+	queryDeadLetters := func() ([]*pldapi.ListenerDeadLetter, error) {
+		return txm.QueryReceiptListenerDeadLetters(ctx, txm.p.NOTX(), "listener1", query.NewQueryBuilder().Limit(100).Query())
+	}
+
+	// The receipt fails delivery twice, and is parked so the checkpoint moves on
+	txID := finalizeReceipt()
+	dls := waitDeadLetters(t, queryDeadLetters, 1)
+	assert.Equal(t, "listener1", dls[0].Listener)
+	assert.Equal(t, "0", dls[0].BatchID)
+	assert.Equal(t, 2, dls[0].Attempts)
+	assert.Equal(t, "pop", dls[0].LastError)
+	assert.Contains(t, dls[0].Batch.String(), txID.String())
+	l := txm.receiptListeners["listener1"]
+	for l.checkpoint == nil {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Replay it successfully
+	err = txm.ReplayReceiptListenerDeadLetter(ctx, "listener1", uuid.New())
+	assert.Regexp(t, "PD012265", err)
+	tfr.fail.Store(false)
+	err = txm.ReplayReceiptListenerDeadLetter(ctx, "listener1", dls[0].ID)
+	require.NoError(t, err)
+	receipt := <-tfr.receipts
+	assert.Equal(t, txID, receipt.ID)
+	waitDeadLetters(t, queryDeadLetters, 0)
+
+	// Reset the checkpoint, and we get it again
+	err = txm.ResetReceiptListenerCheckpoint(ctx, "listener1", 0)
+	require.NoError(t, err)
+	receipt = <-tfr.receipts
+	assert.Equal(t, txID, receipt.ID)
+
+	// Park another one, and discard it
+	tfr.fail.Store(true)
+	finalizeReceipt()
+	dls = waitDeadLetters(t, queryDeadLetters, 1)
+	err = txm.DiscardReceiptListenerDeadLetter(ctx, "listener1", dls[0].ID)
+	require.NoError(t, err)
+	err = txm.DiscardReceiptListenerDeadLetter(ctx, "listener1", dls[0].ID)
+	assert.Regexp(t, "PD012265", err)
+
+	// A failed replay is left in the table
+	finalizeReceipt()
+	dls = waitDeadLetters(t, queryDeadLetters, 1)
+	err = txm.ReplayReceiptListenerDeadLetter(ctx, "listener1", dls[0].ID)
+	require.NoError(t, err)
+	for dls[0].Attempts < 4 {
+		dls = waitDeadLetters(t, queryDeadLetters, 1)
+	}
+	assert.False(t, dls[0].Replay)
+
+	// Deleting the listener removes its dead letters
+	err = txm.DeleteReceiptListener(ctx, "listener1")
+	require.NoError(t, err)
+	var count int64
+	err = txm.p.DB().Model(&deadletters.PersistedDeadLetter{}).Count(&count).Error
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestBlockchainEventListenerDeadLetters(t *testing.T) {
+	var def *blockindexer.InternalEventStream
+	esID := uuid.New()
+	ctx, txm, done := newTestTransactionManager(t, true, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.blockIndexer.On("RemoveEventStream", mock.Anything, esID).Return(nil)
+		mc.blockIndexer.On("ResetEventStreamCheckpoint", mock.Anything, esID, int64(10)).Return(nil)
+		mc.blockIndexer.On("AddEventStream", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				def = args.Get(2).(*blockindexer.InternalEventStream)
+			}).
+			Return(&blockindexer.EventStream{ID: esID, Name: "bel1"}, nil)
+	})
+	defer done()
+	txm.blockchainEventsRetry = retry.NewRetryIndefinite(&pldconf.RetryConfig{InitialDelay: confutil.P("1ms")})
+
+	err := txm.CreateBlockchainEventListener(ctx, &pldapi.BlockchainEventListener{
+		Name: "bel1",
+		Sources: []pldapi.BlockchainEventListenerSource{{
+			ABI: mockABI,
+		}},
+		Options: pldapi.BlockchainEventListenerOptions{
+			MaxRedeliveries: confutil.P(1),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, *def.Definition.Config.MaxRedeliveries)
+	assert.Equal(t, 1, *txm.mapBlockchainEventListener(def.Definition).Options.MaxRedeliveries)
+
+	tfr := newTestFailingReceiver()
+	r, err := txm.AddBlockchainEventReceiver(ctx, "bel1", tfr)
+	require.NoError(t, err)
+	defer r.Close()
+
+	queryDeadLetters := func() ([]*pldapi.ListenerDeadLetter, error) {
+		return txm.QueryBlockchainEventListenerDeadLetters(ctx, txm.p.NOTX(), "bel1", query.NewQueryBuilder().Limit(100).Query())
+	}
+
+	batch := &blockindexer.EventDeliveryBatch{
+		BatchID: uuid.New(),
+		Events: []*pldapi.EventWithData{{
+			IndexedEvent: &pldapi.IndexedEvent{BlockNumber: 12345},
+		}},
+	}
+
+	// The event stream retries the batch until we report success after parking it
+	err = def.HandlerNOTX(ctx, batch)
+	assert.Regexp(t, "pop", err)
+	err = def.HandlerNOTX(ctx, batch)
+	require.NoError(t, err)
+	dls := waitDeadLetters(t, queryDeadLetters, 1)
+	assert.Equal(t, "bel1", dls[0].Listener)
+	assert.Equal(t, batch.BatchID.String(), dls[0].BatchID)
+	assert.Equal(t, 2, dls[0].Attempts)
+
+	// Replay it successfully
+	err = txm.ReplayBlockchainEventListenerDeadLetter(ctx, "bel1", uuid.New())
+	assert.Regexp(t, "PD012265", err)
+	tfr.fail.Store(false)
+	err = txm.ReplayBlockchainEventListenerDeadLetter(ctx, "bel1", dls[0].ID)
+	require.NoError(t, err)
+	event := <-tfr.events
+	assert.Equal(t, int64(12345), event.BlockNumber)
+	waitDeadLetters(t, queryDeadLetters, 0)
+
+	// Park another one, and discard it
+	tfr.fail.Store(true)
+	batch.BatchID = uuid.New()
+	err = def.HandlerNOTX(ctx, batch)
+	assert.Regexp(t, "pop", err)
+	err = def.HandlerNOTX(ctx, batch)
+	require.NoError(t, err)
+	dls = waitDeadLetters(t, queryDeadLetters, 1)
+	err = txm.DiscardBlockchainEventListenerDeadLetter(ctx, "bel1", dls[0].ID)
+	require.NoError(t, err)
+	err = txm.DiscardBlockchainEventListenerDeadLetter(ctx, "bel1", dls[0].ID)
+	assert.Regexp(t, "PD012265", err)
+
+	err = txm.ResetBlockchainEventListenerCheckpoint(ctx, "bel1", 10)
+	require.NoError(t, err)
+
+	err = txm.DeleteBlockchainEventListener(ctx, "bel1")
+	require.NoError(t, err)
+}
+
+func TestListenerDeadLettersNotLoaded(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners)
+	defer done()
+
+	q := query.NewQueryBuilder().Limit(1).Query()
+	_, err := txm.QueryReceiptListenerDeadLetters(ctx, txm.p.NOTX(), "unknown", q)
+	assert.Regexp(t, "PD012238", err)
+	err = txm.ReplayReceiptListenerDeadLetter(ctx, "unknown", uuid.New())
+	assert.Regexp(t, "PD012238", err)
+	err = txm.DiscardReceiptListenerDeadLetter(ctx, "unknown", uuid.New())
+	assert.Regexp(t, "PD012238", err)
+	err = txm.ResetReceiptListenerCheckpoint(ctx, "unknown", 0)
+	assert.Regexp(t, "PD012238", err)
+
+	_, err = txm.QueryBlockchainEventListenerDeadLetters(ctx, txm.p.NOTX(), "unknown", q)
+	assert.Regexp(t, "PD012248", err)
+	err = txm.ReplayBlockchainEventListenerDeadLetter(ctx, "unknown", uuid.New())
+	assert.Regexp(t, "PD012248", err)
+	err = txm.DiscardBlockchainEventListenerDeadLetter(ctx, "unknown", uuid.New())
+	assert.Regexp(t, "PD012248", err)
+	err = txm.ResetBlockchainEventListenerCheckpoint(ctx, "unknown", 0)
+	assert.Regexp(t, "PD012248", err)
+
+	err = txm.CreateReceiptListener(ctx, &pldapi.TransactionReceiptListener{
+		Name: "listener1",
+		Options: pldapi.TransactionReceiptListenerOptions{
+			MaxRedeliveries: confutil.P(-1),
+		},
+	})
+	assert.Regexp(t, "PD012266", err)
+
+	err = txm.CreateBlockchainEventListener(ctx, &pldapi.BlockchainEventListener{
+		Name: "bel1",
+		Sources: []pldapi.BlockchainEventListenerSource{{
+			ABI: mockABI,
+		}},
+		Options: pldapi.BlockchainEventListenerOptions{
+			MaxRedeliveries: confutil.P(-1),
+		},
+	})
+	assert.Regexp(t, "PD012266", err)
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldresty"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+const (
+	webhookHeaderListener  = "X-Paladin-Listener"
+	webhookHeaderBatchID   = "X-Paladin-Batch-Id"
+	webhookHeaderSignature = "X-Paladin-Signature"
+
+	webhookRedactedValue = "[redacted]"
+)
+
+// A webhook receiver is registered against a receipt or blockchain event listener, in the same way
+// as a JSON/RPC subscription. Any non-2xx response is returned as an error, so the listener retries
+// the same batch (with backoff) and does not move its checkpoint forwards until delivery succeeds.
+type webhookReceiver struct {
+	listener   string
+	url        string
+	hmacSecret []byte
+	client     *resty.Client
+}
+
+func newWebhookReceiver(ctx context.Context, listener string, spec *pldapi.ListenerWebhook) (*webhookReceiver, error) {
+	conf := &pldconf.HTTPClientConfig{
+		URL:            spec.URL,
+		RequestTimeout: spec.RequestTimeout,
+		HTTPHeaders:    map[string]any{},
+	}
+	if spec.RequestTimeout != nil {
+		if _, err := time.ParseDuration(*spec.RequestTimeout); err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgTxMgrWebhookInvalidTimeout, *spec.RequestTimeout, err.Error())
+		}
+	}
+	for k, v := range spec.Headers {
+		conf.HTTPHeaders[k] = v
+	}
+	if spec.TLS != nil {
+		conf.TLS = pldconf.TLSConfig{
+			CA:                     spec.TLS.CA,
+			Cert:                   spec.TLS.Cert,
+			Key:                    spec.TLS.Key,
+			InsecureSkipHostVerify: spec.TLS.InsecureSkipHostVerify,
+		}
+	}
+	client, err := pldresty.New(ctx, conf)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgTxMgrWebhookInvalid, listener)
+	}
+	wr := &webhookReceiver{
+		listener: listener,
+		url:      spec.URL,
+		client:   client,
+	}
+	if spec.HMACSecret != "" {
+		wr.hmacSecret = []byte(spec.HMACSecret)
+	}
+	return wr, nil
+}
+
+// The HMAC secret and TLS private key of a webhook are write-only, and are redacted whenever the
+// listener is returned over the API
+func redactWebhook(spec *pldapi.ListenerWebhook) *pldapi.ListenerWebhook {
+	if spec == nil {
+		return nil
+	}
+	redacted := *spec
+	if redacted.HMACSecret != "" {
+		redacted.HMACSecret = webhookRedactedValue
+	}
+	if spec.TLS != nil && spec.TLS.Key != "" {
+		tls := *spec.TLS
+		tls.Key = webhookRedactedValue
+		redacted.TLS = &tls
+	}
+	return &redacted
+}
+
+func (wr *webhookReceiver) DeliverReceiptBatch(ctx context.Context, batchID uint64, receipts []*pldapi.TransactionReceiptFull) error {
+	return wr.post(ctx, fmt.Sprintf("%d", batchID), &pldapi.TransactionReceiptBatch{
+		BatchID:  batchID,
+		Receipts: receipts,
+	})
+}
+
+func (wr *webhookReceiver) DeliverBlockchainEventBatch(ctx context.Context, batchID uuid.UUID, events []*pldapi.EventWithData) error {
+	return wr.post(ctx, batchID.String(), &pldapi.TransactionEventBatch{
+		BatchID: batchID,
+		Events:  events,
+	})
+}
+
+func (wr *webhookReceiver) post(ctx context.Context, batchID string, batch any) error {
+	// We serialize ourselves, so the signature is over the exact bytes we send
+	body := pldtypes.JSONString(batch)
+
+	req := wr.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader(webhookHeaderListener, wr.listener).
+		SetHeader(webhookHeaderBatchID, batchID).
+		SetBody([]byte(body))
+	if wr.hmacSecret != nil {
+		mac := hmac.New(sha256.New, wr.hmacSecret)
+		mac.Write(body)
+		req.SetHeader(webhookHeaderSignature, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	log.L(ctx).Infof("Delivering batch %s to webhook %s (bytes=%d)", batchID, wr.url, len(body))
+	res, err := req.Post("")
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgTxMgrWebhookDeliveryFailed, batchID)
+	}
+	if !res.IsSuccess() {
+		resBody := res.String()
+		if len(resBody) > 256 {
+			resBody = resBody[0:256] + "..."
+		}
+		return i18n.NewError(ctx, msgs.MsgTxMgrWebhookDeliveryRejected, batchID, res.StatusCode(), resBody)
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookRequest struct {
+	headers http.Header
+	body    []byte
+}
+
+// Starts a webhook server that returns each of the supplied status codes in turn (then 204 for every
+// request after that), and passes each request it receives to the returned channel
+func newTestWebhookServer(t *testing.T, tlsServer bool, statusCodes ...int) (*httptest.Server, chan *webhookRequest) {
+	requests := make(chan *webhookRequest, 10)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests <- &webhookRequest{headers: r.Header, body: body}
+		status := http.StatusNoContent
+		if len(statusCodes) > 0 {
+			status = statusCodes[0]
+			statusCodes = statusCodes[1:]
+		}
+		w.WriteHeader(status)
+		if status >= 300 {
+			_, _ = w.Write([]byte(strings.Repeat("pop", 100)))
+		}
+	})
+	var server *httptest.Server
+	if tlsServer {
+		server = httptest.NewTLSServer(handler)
+	} else {
+		server = httptest.NewServer(handler)
+	}
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestReceiptListenerWebhookDelivery(t *testing.T) {
+	server, requests := newTestWebhookServer(t, false, http.StatusInternalServerError)
+
+	ctx, txm, done := newTestTransactionManager(t, true, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		conf.ReceiptListeners.Retry.InitialDelay = confutil.P("1ms")
+	})
+	defer done()
+
+	err := txm.CreateReceiptListener(ctx, &pldapi.TransactionReceiptListener{
+		Name: "listener1",
+		Options: pldapi.TransactionReceiptListenerOptions{
+			Webhook: &pldapi.ListenerWebhook{
+				URL:        server.URL,
+				Headers:    map[string]string{"X-Custom": "value1"},
+				HMACSecret: "secret1",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// The secret is redacted on read, but is still used for delivery
+	assert.Equal(t, "[redacted]", txm.GetReceiptListener(ctx, "listener1").Options.Webhook.HMACSecret)
+	listeners, err := txm.QueryReceiptListeners(ctx, txm.p.NOTX(), query.NewQueryBuilder().Equal("name", "listener1").Limit(1).Query())
+	require.NoError(t, err)
+	require.Len(t, listeners, 1)
+	assert.Equal(t, "[redacted]", listeners[0].Options.Webhook.HMACSecret)
+	assert.Equal(t, server.URL, listeners[0].Options.Webhook.URL)
+	assert.Equal(t, "secret1", txm.receiptListeners["listener1"].spec.Options.Webhook.HMACSecret)
+
+	txID := uuid.New()
+	err = txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return txm.FinalizeTransactions(ctx, dbTX, []*components.ReceiptInput{
+			{
+				ReceiptType:   components.RT_Success,
+				TransactionID: txID,
+				OnChain:       randOnChain(pldtypes.RandAddress()),
+			},
+		})
+	})
+	require.NoError(t, err)
+
+	// The first attempt is rejected, and the checkpoint must not move
+	rejected := <-requests
+	l := txm.receiptListeners["listener1"]
+	assert.Nil(t, l.checkpoint)
+
+	// The retry is accepted with the same batch
+	accepted := <-requests
+	assert.Equal(t, rejected.body, accepted.body)
+	assert.Equal(t, "listener1", accepted.headers.Get("X-Paladin-Listener"))
+	assert.Equal(t, "0", accepted.headers.Get("X-Paladin-Batch-Id"))
+	assert.Equal(t, "value1", accepted.headers.Get("X-Custom"))
+	assert.Equal(t, "application/json", accepted.headers.Get("Content-Type"))
+
+	mac := hmac.New(sha256.New, []byte("secret1"))
+	mac.Write(accepted.body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), accepted.headers.Get("X-Paladin-Signature"))
+
+	var batch pldapi.TransactionReceiptBatch
+	err = json.Unmarshal(accepted.body, &batch)
+	require.NoError(t, err)
+	require.Len(t, batch.Receipts, 1)
+	assert.Equal(t, txID, batch.Receipts[0].ID)
+
+	for l.checkpoint == nil {
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestBlockchainEventListenerWebhookDeliveryTLS(t *testing.T) {
+	server, requests := newTestWebhookServer(t, true, http.StatusBadRequest)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	var def *blockindexer.InternalEventStream
+	ctx, txm, done := newTestTransactionManager(t, true, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.blockIndexer.On("StopEventStream", mock.Anything, mock.Anything).Return(nil)
+		mc.blockIndexer.On("AddEventStream", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				def = args.Get(2).(*blockindexer.InternalEventStream)
+			}).
+			Return(&blockindexer.EventStream{ID: uuid.New()}, nil)
+	})
+	defer done()
+
+	err := txm.CreateBlockchainEventListener(ctx, &pldapi.BlockchainEventListener{
+		Name: "bel1",
+		Sources: []pldapi.BlockchainEventListenerSource{{
+			ABI: mockABI,
+		}},
+		Options: pldapi.BlockchainEventListenerOptions{
+			Webhook: &pldapi.ListenerWebhook{
+				URL:            server.URL,
+				RequestTimeout: confutil.P("5s"),
+				TLS: &pldapi.ListenerWebhookTLS{
+					CA: string(caPEM),
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, server.URL, def.Definition.Config.Webhook.URL)
+	assert.Equal(t, server.URL, txm.mapBlockchainEventListener(def.Definition).Options.Webhook.URL)
+
+	batchID := uuid.New()
+	batch := &blockindexer.EventDeliveryBatch{
+		BatchID: batchID,
+		Events: []*pldapi.EventWithData{{
+			IndexedEvent: &pldapi.IndexedEvent{BlockNumber: 12345},
+		}},
+	}
+
+	// The event stream retries the batch when the webhook rejects it
+	err = def.HandlerNOTX(ctx, batch)
+	assert.Regexp(t, "PD012264.*400.*pop", err)
+	<-requests
+
+	err = def.HandlerNOTX(ctx, batch)
+	require.NoError(t, err)
+	req := <-requests
+	assert.Equal(t, batchID.String(), req.headers.Get("X-Paladin-Batch-Id"))
+	assert.Empty(t, req.headers.Get("X-Paladin-Signature"))
+
+	var received pldapi.TransactionEventBatch
+	err = json.Unmarshal(req.body, &received)
+	require.NoError(t, err)
+	assert.Equal(t, batchID, received.BatchID)
+	require.Len(t, received.Events, 1)
+	assert.Equal(t, int64(12345), received.Events[0].BlockNumber)
+}
+
+func TestWebhookDeliveryFailed(t *testing.T) {
+	server, _ := newTestWebhookServer(t, false)
+	server.Close()
+
+	wr, err := newWebhookReceiver(context.Background(), "listener1", &pldapi.ListenerWebhook{
+		URL: server.URL,
+	})
+	require.NoError(t, err)
+
+	err = wr.DeliverReceiptBatch(context.Background(), 1, []*pldapi.TransactionReceiptFull{})
+	assert.Regexp(t, "PD012263", err)
+}
+
+func TestCreateListenersBadWebhook(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners)
+	defer done()
+
+	err := txm.CreateReceiptListener(ctx, &pldapi.TransactionReceiptListener{
+		Name: "listener1",
+		Options: pldapi.TransactionReceiptListenerOptions{
+			Webhook: &pldapi.ListenerWebhook{URL: "ftp://example.com"},
+		},
+	})
+	assert.Regexp(t, "PD012261.*listener1", err)
+
+	err = txm.CreateReceiptListener(ctx, &pldapi.TransactionReceiptListener{
+		Name: "listener1",
+		Options: pldapi.TransactionReceiptListenerOptions{
+			Webhook: &pldapi.ListenerWebhook{
+				URL:            "https://example.com",
+				RequestTimeout: confutil.P("wrong"),
+			},
+		},
+	})
+	assert.Regexp(t, "PD012262", err)
+
+	err = txm.CreateBlockchainEventListener(ctx, &pldapi.BlockchainEventListener{
+		Name: "bel1",
+		Sources: []pldapi.BlockchainEventListenerSource{{
+			ABI: mockABI,
+		}},
+		Options: pldapi.BlockchainEventListenerOptions{
+			Webhook: &pldapi.ListenerWebhook{
+				URL: "https://example.com",
+				TLS: &pldapi.ListenerWebhookTLS{CA: "not a PEM"},
+			},
+		},
+	})
+	assert.Regexp(t, "PD012261.*bel1", err)
+}
+
+func TestLoadListenersBadWebhook(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners)
+	defer done()
+
+	_, err := txm.loadBlockchainEventListener(ctx, &blockindexer.EventStream{
+		Name: "bel1",
+		Config: blockindexer.EventStreamConfig{
+			Webhook: &pldapi.ListenerWebhook{URL: "wrong"},
+		},
+	}, txm.p.NOTX())
+	assert.Regexp(t, "PD012261", err)
+
+	_, err = txm.loadReceiptListener(ctx, &persistedReceiptListener{
+		Name:    "listener1",
+		Filters: pldtypes.RawJSON(`{}`),
+		Options: pldtypes.RawJSON(`{"webhook":{"url":"wrong"}}`),
+	})
+	assert.Regexp(t, "PD012261", err)
+}
+
+func TestRedactWebhook(t *testing.T) {
+	assert.Nil(t, redactWebhook(nil))
+
+	spec := &pldapi.ListenerWebhook{
+		URL:        "https://example.com",
+		HMACSecret: "secret1",
+		TLS: &pldapi.ListenerWebhookTLS{
+			CA:   "ca1",
+			Cert: "cert1",
+			Key:  "key1",
+		},
+	}
+	redacted := redactWebhook(spec)
+	assert.Equal(t, "https://example.com", redacted.URL)
+	assert.Equal(t, "[redacted]", redacted.HMACSecret)
+	assert.Equal(t, "ca1", redacted.TLS.CA)
+	assert.Equal(t, "cert1", redacted.TLS.Cert)
+	assert.Equal(t, "[redacted]", redacted.TLS.Key)
+
+	// The original is unchanged
+	assert.Equal(t, "secret1", spec.HMACSecret)
+	assert.Equal(t, "key1", spec.TLS.Key)
+
+	// Unset values are left unset
+	redacted = redactWebhook(&pldapi.ListenerWebhook{URL: "https://example.com"})
+	assert.Empty(t, redacted.HMACSecret)
+	assert.Nil(t, redacted.TLS)
+}
@@ -17,6 +17,7 @@ package txmgr
 
 import (
 	"context"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -24,6 +25,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/deadletters"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/internal/txmgr/metrics"
 
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
@@ -45,6 +48,8 @@ func NewTXManager(ctx context.Context, conf *pldconf.TxManagerConfig) components
 	}
 	tm.receiptsInit()
 	tm.blockchainEventsInit()
+	tm.expiryInit()
+	tm.exportInit()
 	tm.rpcEventStreams = newRPCEventStreams(tm)
 	return tm
 }
@@ -75,11 +80,24 @@ type txManager struct {
 	receiptListenersLoadPageSize int
 	receiptListenerLock          sync.Mutex
 	receiptListeners             map[string]*receiptListener
+	receiptDeadLetters           *deadletters.Store
 
+	blockchainEventsRetry                *retry.Retry
 	blockchainEventListenerLock          sync.Mutex
 	blockchainEventListeners             map[string]*blockchainEventListener
 	blockchainEventListenersLoadPageSize int
+	blockchainEventDeadLetters           *deadletters.Store
 	metrics                              metrics.TransactionManagerMetrics
+
+	expiryCheckInterval    time.Duration
+	expiryPageSize         int
+	expiryCancelledPubTxns map[uint64]bool
+	expiryLoopCancel       context.CancelFunc
+	expiryLoopDone         chan struct{}
+
+	exportPageSize         int
+	exportPageWriteTimeout time.Duration
+	exportSettleTime       time.Duration
 }
 
 func (tm *txManager) PreInit(c components.PreInitComponents) (*components.ManagerInitResult, error) {
@@ -88,11 +106,16 @@ func (tm *txManager) PreInit(c components.PreInitComponents) (*components.Manage
 	return &components.ManagerInitResult{
 		RPCModules:       []*rpcserver.RPCModule{tm.rpcModule, tm.debugRpcModule},
 		PreCommitHandler: tm.blockIndexerPreCommit,
+		HTTPHandlers: map[string]http.HandlerFunc{
+			exportPathPrefix: tm.handleExport,
+		},
 	}, nil
 }
 
 func (tm *txManager) PostInit(c components.AllComponents) error {
 	tm.p = c.Persistence()
+	tm.receiptDeadLetters = deadletters.NewStore(tm.p, deadletters.TypeReceipt, msgs.MsgTxMgrDeadLetterNotFound)
+	tm.blockchainEventDeadLetters = deadletters.NewStore(tm.p, deadletters.TypeBlockchainEvent, msgs.MsgTxMgrDeadLetterNotFound)
 	tm.ethClientFactory = c.EthClientFactory()
 	tm.keyManager = c.KeyManager()
 	tm.publicTxMgr = c.PublicTxManager()
@@ -109,11 +132,13 @@ func (tm *txManager) PostInit(c components.AllComponents) error {
 
 func (tm *txManager) Start() error {
 	tm.startReceiptListeners()
+	tm.startExpiryLoop()
 	return nil
 }
 
 func (tm *txManager) Stop() {
 	tm.rpcEventStreams.stop()
+	tm.stopExpiryLoop()
 	tm.stopReceiptListeners()
 	tm.stopBlockchainEventListeners()
 }
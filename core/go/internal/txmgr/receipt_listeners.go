@@ -18,6 +18,7 @@ package txmgr
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 	"sync"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/deadletters"
 	"github.com/kaleido-io/paladin/core/internal/filters"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
@@ -52,6 +54,25 @@ var receiptListenerFilters = filters.FieldMap{
 	"started": filters.BooleanField("started"),
 }
 
+// The query filter on a receipt listener is evaluated in-memory against each receipt,
+// along with the transaction if it was submitted to this node
+var receiptListenerQueryFields = filters.FieldMap{
+	"sequence":        filters.Int64Field("sequence"),
+	"domain":          filters.StringField("domain"),
+	"success":         filters.Int64BoolField("success"),
+	"transactionHash": filters.HexBytesField("tx_hash"),
+	"blockNumber":     filters.Int64Field("block_number"),
+	"source":          filters.HexBytesField("source"),
+	"contractAddress": filters.HexBytesField("contract_address"),
+	"failureMessage":  filters.StringField("failure_message"),
+	"type":            filters.StringField("type"),
+	"from":            filters.StringField("from"),
+	"to":              filters.HexBytesField("to"),
+	"function":        filters.StringField("function"),
+	"abiReference":    filters.Bytes32Field("abi_ref"),
+	"idempotencyKey":  filters.StringField("idempotency_key"),
+}
+
 func (persistedReceiptListener) TableName() string {
 	return "receipt_listeners"
 }
@@ -99,6 +120,7 @@ type receiptListener struct {
 	checkpoint *uint64
 
 	newReceipts chan bool
+	newReplays  chan bool
 
 	nextBatchID  uint64
 	newReceivers chan bool
@@ -134,6 +156,12 @@ func (tm *txManager) CreateReceiptListener(ctx context.Context, spec *pldapi.Tra
 	if err := tm.validateReceiptListenerSpec(ctx, spec); err != nil {
 		return err
 	}
+	// The webhook client is built when the listener is loaded - but we check it can be built before we persist
+	if spec.Options.Webhook != nil {
+		if _, err := newWebhookReceiver(ctx, spec.Name, spec.Options.Webhook); err != nil {
+			return err
+		}
+	}
 
 	started := (spec.Started == nil /* default is true */) || *spec.Started
 	dbSpec := &persistedReceiptListener{
@@ -190,7 +218,7 @@ func (tm *txManager) GetReceiptListener(ctx context.Context, name string) *pldap
 
 	l := tm.receiptListeners[name]
 	if l != nil {
-		return l.spec
+		return redactReceiptListener(l.spec)
 	}
 	return nil
 
@@ -252,6 +280,9 @@ func (tm *txManager) DeleteReceiptListener(ctx context.Context, name string) err
 		Where("name = ?", name).
 		Delete(&persistedReceiptListener{}).
 		Error
+	if err == nil {
+		err = tm.receiptDeadLetters.DeleteAll(ctx, name)
+	}
 	if err != nil {
 		return err
 	}
@@ -260,6 +291,96 @@ func (tm *txManager) DeleteReceiptListener(ctx context.Context, name string) err
 	return nil
 }
 
+func (tm *txManager) getLoadedReceiptListener(ctx context.Context, name string) (*receiptListener, error) {
+	tm.receiptListenerLock.Lock()
+	defer tm.receiptListenerLock.Unlock()
+
+	l := tm.receiptListeners[name]
+	if l == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrReceiptListenerNotLoaded, name)
+	}
+	return l, nil
+}
+
+func (tm *txManager) QueryReceiptListenerDeadLetters(ctx context.Context, dbTX persistence.DBTX, name string, jq *query.QueryJSON) ([]*pldapi.ListenerDeadLetter, error) {
+	if _, err := tm.getLoadedReceiptListener(ctx, name); err != nil {
+		return nil, err
+	}
+	return tm.receiptDeadLetters.Query(ctx, dbTX, name, jq)
+}
+
+func (tm *txManager) ReplayReceiptListenerDeadLetter(ctx context.Context, name string, id uuid.UUID) error {
+	l, err := tm.getLoadedReceiptListener(ctx, name)
+	if err == nil {
+		err = tm.receiptDeadLetters.MarkForReplay(ctx, name, id)
+	}
+	if err != nil {
+		return err
+	}
+	l.notifyReplays()
+	return nil
+}
+
+func (tm *txManager) DiscardReceiptListenerDeadLetter(ctx context.Context, name string, id uuid.UUID) error {
+	if _, err := tm.getLoadedReceiptListener(ctx, name); err != nil {
+		return err
+	}
+	return tm.receiptDeadLetters.Discard(ctx, name, id)
+}
+
+// Moves the checkpoint of the listener to the supplied sequence, so that receipts after that
+// sequence are delivered (again). The listener is stopped while the checkpoint is updated.
+func (tm *txManager) ResetReceiptListenerCheckpoint(ctx context.Context, name string, sequence uint64) error {
+	tm.receiptListenerLock.Lock()
+	defer tm.receiptListenerLock.Unlock()
+
+	l := tm.receiptListeners[name]
+	if l == nil {
+		return i18n.NewError(ctx, msgs.MsgTxMgrReceiptListenerNotLoaded, name)
+	}
+
+	log.L(ctx).Infof("Resetting receipt listener '%s' checkpoint to sequence %d", name, sequence)
+	wasRunning := l.done != nil
+	l.stop()
+
+	err := tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		err := dbTX.DB().
+			WithContext(ctx).
+			Clauses(clause.OnConflict{
+				Columns: []clause.Column{
+					{Name: "listener"},
+				},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"sequence",
+					"time",
+				}),
+			}).
+			Create(&persistedReceiptCheckpoint{
+				Listener: name,
+				Sequence: sequence,
+				Time:     pldtypes.TimestampNow(),
+			}).
+			Error
+		if err == nil {
+			// Any gap after the new checkpoint will be found again when we read from the head
+			err = dbTX.DB().
+				WithContext(ctx).
+				Where("listener = ?", name).
+				Where("sequence > ?", sequence).
+				Delete(&persistedReceiptGap{}).
+				Error
+		}
+		return err
+	})
+	if err == nil {
+		l.checkpoint = &sequence
+	}
+	if wasRunning {
+		l.start()
+	}
+	return err
+}
+
 func (tm *txManager) QueryReceiptListeners(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.TransactionReceiptListener, error) {
 	qw := &filters.QueryWrapper[persistedReceiptListener, pldapi.TransactionReceiptListener]{
 		P:           tm.p,
@@ -268,7 +389,11 @@ func (tm *txManager) QueryReceiptListeners(ctx context.Context, dbTX persistence
 		Filters:     receiptListenerFilters,
 		Query:       jq,
 		MapResult: func(pl *persistedReceiptListener) (*pldapi.TransactionReceiptListener, error) {
-			return tm.mapReceiptListener(ctx, pl)
+			spec, err := tm.mapReceiptListener(ctx, pl)
+			if err != nil {
+				return nil, err
+			}
+			return redactReceiptListener(spec), nil
 		},
 	}
 	return qw.Run(ctx, dbTX)
@@ -368,6 +493,15 @@ func (tm *txManager) validateReceiptListenerSpec(ctx context.Context, spec *plda
 		return err
 	}
 	spec.Options.IncompleteStateReceiptBehavior = icrb.Enum()
+	if spec.Filters.Query != nil {
+		// Evaluate against an empty set of values, to check the fields and values in the query
+		if _, err := filters.EvalQuery(ctx, spec.Filters.Query, receiptListenerQueryFields, filters.ResolvingValueSet{}); err != nil {
+			return i18n.WrapError(ctx, err, msgs.MsgTxMgrBadReceiptListenerQuery, spec.Name)
+		}
+	}
+	if err := deadletters.ValidateMaxRedeliveries(ctx, msgs.MsgTxMgrInvalidMaxRedeliveries, spec.Name, spec.Options.MaxRedeliveries); err != nil {
+		return err
+	}
 	_, err = tm.buildListenerDBQuery(ctx, spec, tm.p.DB())
 	return err
 }
@@ -438,6 +572,64 @@ func (l *receiptListener) checkMatch(r *transactionReceipt) bool {
 	return matches
 }
 
+// The query filter cannot be used in checkMatch(), as it might need the transaction to be loaded
+// from the DB. So it is applied only as a post-filter on the receipts read from the DB.
+func (l *receiptListener) checkQueryMatch(r *transactionReceipt, tx *persistedTransaction) bool {
+	if l.spec.Filters.Query == nil {
+		return true
+	}
+	values := filters.ResolvingValueSet{
+		"sequence":        pldtypes.JSONString(r.Sequence),
+		"domain":          pldtypes.JSONString(r.Domain),
+		"success":         pldtypes.JSONString(r.Success),
+		"transactionHash": pldtypes.JSONString(r.TransactionHash),
+		"blockNumber":     pldtypes.JSONString(r.BlockNumber),
+		"source":          pldtypes.JSONString(r.Source),
+		"contractAddress": pldtypes.JSONString(r.ContractAddress),
+		"failureMessage":  pldtypes.JSONString(r.FailureMessage),
+	}
+	if tx != nil {
+		values["type"] = pldtypes.JSONString(tx.Type)
+		values["from"] = pldtypes.JSONString(tx.From)
+		values["to"] = pldtypes.JSONString(tx.To)
+		values["function"] = pldtypes.JSONString(tx.Function)
+		values["abiReference"] = pldtypes.JSONString(tx.ABIReference)
+		values["idempotencyKey"] = pldtypes.JSONString(tx.IdempotencyKey)
+	}
+	matches, err := filters.EvalQuery(l.ctx, l.spec.Filters.Query, receiptListenerQueryFields, values)
+	if err != nil {
+		// The query is validated on creation, so we do not expect this - but we must not block the listener
+		log.L(l.ctx).Errorf("Receipt %d/%s excluded as query filter failed: %s", r.Sequence, r.TransactionID, err)
+		return false
+	}
+	return matches
+}
+
+// loads the transactions for a page of receipts, when required to evaluate the query filter
+func (l *receiptListener) loadQueryTransactions(page []*transactionReceipt) (map[uuid.UUID]*persistedTransaction, error) {
+	txns := make(map[uuid.UUID]*persistedTransaction)
+	if l.spec.Filters.Query == nil || len(page) == 0 {
+		return txns, nil
+	}
+	txIDs := make([]uuid.UUID, len(page))
+	for i, r := range page {
+		txIDs[i] = r.TransactionID
+	}
+	var ptxs []*persistedTransaction
+	err := l.tm.p.DB().
+		WithContext(l.ctx).
+		Where("id IN (?)", txIDs).
+		Find(&ptxs).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	for _, ptx := range ptxs {
+		txns[ptx.ID] = ptx
+	}
+	return txns, nil
+}
+
 func (tm *txManager) mapReceiptListener(ctx context.Context, pl *persistedReceiptListener) (*pldapi.TransactionReceiptListener, error) {
 	spec := &pldapi.TransactionReceiptListener{
 		Name:    pl.Name,
@@ -456,6 +648,16 @@ func (tm *txManager) mapReceiptListener(ctx context.Context, pl *persistedReceip
 	return spec, nil
 }
 
+// Listeners are returned to callers with any webhook secrets redacted
+func redactReceiptListener(spec *pldapi.TransactionReceiptListener) *pldapi.TransactionReceiptListener {
+	if spec.Options.Webhook == nil {
+		return spec
+	}
+	redacted := *spec
+	redacted.Options.Webhook = redactWebhook(spec.Options.Webhook)
+	return &redacted
+}
+
 func (tm *txManager) loadReceiptListener(ctx context.Context, pl *persistedReceiptListener) (*receiptListener, error) {
 
 	spec, err := tm.mapReceiptListener(ctx, pl)
@@ -468,6 +670,14 @@ func (tm *txManager) loadReceiptListener(ctx context.Context, pl *persistedRecei
 		spec:         spec,
 		newReceivers: make(chan bool, 1),
 		newReceipts:  make(chan bool, 1),
+		newReplays:   make(chan bool, 1),
+	}
+	if spec.Options.Webhook != nil {
+		wr, err := newWebhookReceiver(ctx, spec.Name, spec.Options.Webhook)
+		if err != nil {
+			return nil, err
+		}
+		l.addReceiver(wr)
 	}
 
 	tm.receiptListenerLock.Lock()
@@ -506,6 +716,13 @@ func (l *receiptListener) notifyNewReceipts() {
 	}
 }
 
+func (l *receiptListener) notifyReplays() {
+	select {
+	case l.newReplays <- true:
+	default:
+	}
+}
+
 func (l *receiptListener) addReceiver(r components.ReceiptReceiver) *registeredReceiptReceiver {
 	l.receiverLock.Lock()
 	defer l.receiverLock.Unlock()
@@ -607,8 +824,8 @@ func (l *receiptListener) readGapPage(gap *persistedReceiptGap) ([]*transactionR
 	return receipts, err
 }
 
-func (l *receiptListener) processPersistedReceipt(b *receiptDeliveryBatch, pr *transactionReceipt) error {
-	if !l.checkMatch(pr) {
+func (l *receiptListener) processPersistedReceipt(b *receiptDeliveryBatch, pr *transactionReceipt, tx *persistedTransaction) error {
+	if !l.checkMatch(pr) || !l.checkQueryMatch(pr, tx) {
 		return nil
 	}
 
@@ -725,9 +942,17 @@ func (l *receiptListener) processPage(page []*transactionReceipt) (*receiptDeliv
 	var batch receiptDeliveryBatch
 	batch.ID = l.nextBatchID
 	l.nextBatchID++
+	var txns map[uuid.UUID]*persistedTransaction
+	err := l.tm.receiptsRetry.Do(l.ctx, func(attempt int) (retryable bool, err error) {
+		txns, err = l.loadQueryTransactions(page)
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
 	for _, r := range page {
 		err := l.tm.receiptsRetry.Do(l.ctx, func(attempt int) (retryable bool, err error) {
-			return true, l.processPersistedReceipt(&batch, r)
+			return true, l.processPersistedReceipt(&batch, r, txns[r.TransactionID])
 		})
 		if err != nil {
 			return nil, err
@@ -737,9 +962,12 @@ func (l *receiptListener) processPage(page []*transactionReceipt) (*receiptDeliv
 	// If our batch contains some work, we need to wait for someone to process that work
 	// (note we're not holding any resource open at this point - no DB TX or anything).
 	if len(batch.Receipts) > 0 {
-		err := l.tm.receiptsRetry.Do(l.ctx, func(attempt int) (retryable bool, err error) {
-			return true, l.deliverBatch(&batch)
+		attempts, deliveryErr, err := deadletters.DeliverWithRedeliveryLimit(l.ctx, l.tm.receiptsRetry, l.spec.Options.MaxRedeliveries, func() error {
+			return l.deliverBatch(&batch)
 		})
+		if err == nil && deliveryErr != nil {
+			err = l.tm.receiptDeadLetters.Write(l.ctx, l.tm.receiptsRetry, l.spec.Name, strconv.FormatUint(batch.ID, 10), attempts, deliveryErr, batch.Receipts)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -749,6 +977,20 @@ func (l *receiptListener) processPage(page []*transactionReceipt) (*receiptDeliv
 
 }
 
+func (l *receiptListener) replayDeadLetters() error {
+	return deadletters.Replay(l.ctx, l.tm.receiptDeadLetters, l.tm.receiptsRetry, l.spec.Name, l.tm.receiptListenersLoadPageSize, l.spec.Options.MaxRedeliveries,
+		func(receipts []*pldapi.TransactionReceiptFull) (string, func() error) {
+			batch := &receiptDeliveryBatch{
+				ID:       l.nextBatchID,
+				Receipts: receipts,
+			}
+			l.nextBatchID++
+			return strconv.FormatUint(batch.ID, 10), func() error {
+				return l.deliverBatch(batch)
+			}
+		})
+}
+
 func (l *receiptListener) processStaleGaps() error {
 
 	// We process stale gaps one at a time, as the outcome is to:
@@ -867,11 +1109,22 @@ func (l *receiptListener) runListener() {
 
 	newReceipts := true
 	newStates := true
+	replays := true
 	lastStateCheck := time.Now()
 	stateGapCheckTicker := time.NewTicker(l.tm.receiptsStateGapCheckTime)
 	defer stateGapCheckTicker.Stop()
 	for {
 
+		if replays {
+			// Redeliver any dead letters that have been marked for replay
+			if err := l.replayDeadLetters(); err != nil {
+				log.L(l.ctx).Warnf("listener stopping (replaying dead letters): %s", err) // cancelled context
+				return
+			}
+
+			replays = false
+		}
+
 		if newStates {
 			lastStateCheck = time.Now()
 
@@ -915,10 +1168,12 @@ func (l *receiptListener) runListener() {
 		}
 
 		// If our page was not full, wait for notification of new receipts before we look again
-		for !newReceipts && !newStates {
+		for !newReceipts && !newStates && !replays {
 			select {
 			case <-l.newReceipts:
 				newReceipts = true
+			case <-l.newReplays:
+				replays = true
 			case <-stateGapCheckTicker.C:
 				// Only do the DB check if we've had the tap that new states have been received
 				newStates = pldtypes.Timestamp(l.tm.lastStateUpdateTime.Load()).Time().After(lastStateCheck)
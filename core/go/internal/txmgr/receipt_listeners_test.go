@@ -31,6 +31,7 @@ import (
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -351,6 +352,146 @@ func TestLoadListenersMultiPageFilters(t *testing.T) {
 
 }
 
+func TestReceiptListenerQueryFilter(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockTxStatesAllAvailable,
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mockResolveKey(t, mc, "sender1", pldtypes.RandAddress())
+			mc.publicTxMgr.On("ValidateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mc.publicTxMgr.On("WriteNewTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]*pldapi.PublicTx{}, nil)
+		},
+	)
+	defer done()
+
+	// Two public transactions we submitted, and a private transaction receipt for a transaction submitted elsewhere
+	txIDs := make([]uuid.UUID, 3)
+	for i := range 2 {
+		txID, err := txm.sendTransactionNewDBTX(ctx, &pldapi.TransactionInput{
+			TransactionBase: pldapi.TransactionBase{
+				Type:           pldapi.TransactionTypePublic.Enum(),
+				From:           "sender1",
+				To:             pldtypes.RandAddress(),
+				Function:       "doStuff",
+				IdempotencyKey: fmt.Sprintf("query_%d", i),
+			},
+			ABI: expiryTestABI,
+		})
+		require.NoError(t, err)
+		txIDs[i] = *txID
+	}
+	txIDs[2] = uuid.New()
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return txm.FinalizeTransactions(ctx, dbTX, []*components.ReceiptInput{
+			{TransactionID: txIDs[0], ReceiptType: components.RT_Success, OnChain: randOnChain(nil)},
+			{TransactionID: txIDs[1], ReceiptType: components.RT_FailedWithMessage, FailureMessage: "pop"},
+			{TransactionID: txIDs[2], Domain: "domain1", ReceiptType: components.RT_Success, OnChain: randOnChain(pldtypes.RandAddress())},
+		})
+	})
+	require.NoError(t, err)
+
+	// Matches on a field of the transaction, and a field of the receipt
+	err = txm.CreateReceiptListener(ctx, &pldapi.TransactionReceiptListener{
+		Name: "listener1",
+		Filters: pldapi.TransactionReceiptFilters{
+			Query: query.NewQueryBuilder().
+				Like("idempotencyKey", "query_%").
+				Equal("success", false).
+				Query(),
+		},
+	})
+	require.NoError(t, err)
+	// Matches only the receipt for the transaction not submitted to this node
+	err = txm.CreateReceiptListener(ctx, &pldapi.TransactionReceiptListener{
+		Name: "listener2",
+		Filters: pldapi.TransactionReceiptFilters{
+			Query: query.NewQueryBuilder().Null("from").Query(),
+		},
+	})
+	require.NoError(t, err)
+
+	r1 := newTestReceiptReceiver(nil)
+	close1, err := txm.AddReceiptReceiver(ctx, "listener1", r1)
+	require.NoError(t, err)
+	defer close1.Close()
+	r2 := newTestReceiptReceiver(nil)
+	close2, err := txm.AddReceiptReceiver(ctx, "listener2", r2)
+	require.NoError(t, err)
+	defer close2.Close()
+
+	require.Equal(t, txIDs[1], (<-r1.receipts).ID)
+	require.Equal(t, txIDs[2], (<-r2.receipts).ID)
+
+	// Wait for both to checkpoint past everything, and check nothing else was delivered
+	for _, name := range []string{"listener1", "listener2"} {
+		l := txm.receiptListeners[name]
+		for l.checkpoint == nil || *l.checkpoint < 3 {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	select {
+	case r := <-r1.receipts:
+		require.Fail(t, "unexpected receipt", r.ID)
+	case r := <-r2.receipts:
+		require.Fail(t, "unexpected receipt", r.ID)
+	default:
+	}
+}
+
+func TestCreateListenerBadQuery(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false,
+		mockEmptyReceiptListeners,
+	)
+	defer done()
+
+	err := txm.CreateReceiptListener(ctx, &pldapi.TransactionReceiptListener{
+		Name: "listener1",
+		Filters: pldapi.TransactionReceiptFilters{
+			Query: query.NewQueryBuilder().Equal("wrong", "any").Query(),
+		},
+	})
+	require.Regexp(t, "PD012260.*listener1", err)
+}
+
+func TestCheckQueryMatchEvalFailure(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false,
+		mockEmptyReceiptListeners,
+	)
+	defer done()
+
+	l := &receiptListener{
+		ctx: ctx,
+		tm:  txm,
+		spec: &pldapi.TransactionReceiptListener{
+			Filters: pldapi.TransactionReceiptFilters{
+				Query: query.NewQueryBuilder().Equal("wrong", "any").Query(),
+			},
+		},
+	}
+	assert.False(t, l.checkQueryMatch(&transactionReceipt{}, nil))
+}
+
+func TestLoadQueryTransactionsFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false,
+		mockEmptyReceiptListeners,
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mc.db.ExpectQuery("SELECT.*transactions").WillReturnError(fmt.Errorf("pop"))
+		},
+	)
+	defer done()
+
+	l := &receiptListener{
+		ctx: ctx,
+		tm:  txm,
+		spec: &pldapi.TransactionReceiptListener{
+			Filters: pldapi.TransactionReceiptFilters{
+				Query: query.NewQueryBuilder().Null("from").Query(),
+			},
+		},
+	}
+	_, err := l.loadQueryTransactions([]*transactionReceipt{{TransactionID: uuid.New()}})
+	require.Regexp(t, "pop", err)
+}
+
 func TestGapsDomainsForNonAvailableReceipts(t *testing.T) {
 	testGapsDomainsForNonAvailableReceipts(t, 100)
 }
@@ -816,6 +957,7 @@ func TestClosedRetryingBatchDeliver(t *testing.T) {
 	ctx, txm, done := newTestTransactionManager(t, false,
 		mockEmptyReceiptListeners,
 		mockNoGaps,
+		mockNoDeadLetterReplays,
 		mockPublicReceipts(1),
 		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
 			mc.db.ExpectExec("INSERT.*receipt_listeners").WillReturnResult(driver.ResultNoRows)
@@ -846,6 +988,7 @@ func TestClosedRetryingWritingCheckpoint(t *testing.T) {
 	ctx, txm, done := newTestTransactionManager(t, false,
 		mockEmptyReceiptListeners,
 		mockNoGaps,
+		mockNoDeadLetterReplays,
 		mockPublicReceipts(1),
 		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
 			mc.db.ExpectBegin()
@@ -878,6 +1021,7 @@ func TestClosedRetryingQueryReceiptStates(t *testing.T) {
 	ctx, txm, done := newTestTransactionManager(t, false,
 		mockEmptyReceiptListeners,
 		mockNoGaps,
+		mockNoDeadLetterReplays,
 		mockPrivateReceipt,
 		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
 			mc.db.ExpectExec("INSERT.*receipt_listeners").WillReturnResult(driver.ResultNoRows)
@@ -903,6 +1047,7 @@ func TestClosedRetryingQueryReceipts(t *testing.T) {
 	ctx, txm, done := newTestTransactionManager(t, false,
 		mockEmptyReceiptListeners,
 		mockNoGaps,
+		mockNoDeadLetterReplays,
 		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
 			mc.db.ExpectExec("INSERT.*receipt_listeners").WillReturnResult(driver.ResultNoRows)
 			mc.db.ExpectQuery("SELECT.*receipt_listener_checkpoints").WillReturnRows(sqlmock.NewRows([]string{}))
@@ -1005,7 +1150,7 @@ func TestProcessPersistedReceiptPostFilter(t *testing.T) {
 
 	err = l.processPersistedReceipt(&receiptDeliveryBatch{}, &transactionReceipt{
 		Domain: "domain2",
-	})
+	}, nil)
 	require.NoError(t, err)
 	close(l.done)
 
@@ -51,6 +51,9 @@ func (tm *txManager) buildRPCModule() {
 		Add("ptx_queryPendingPublicTransactions", tm.rpcQueryPendingPublicTransactions()).
 		Add("ptx_getPublicTransactionByNonce", tm.rpcGetPublicTransactionByNonce()).
 		Add("ptx_getPublicTransactionByHash", tm.rpcGetPublicTransactionByHash()).
+		Add("ptx_suspendPublicTransaction", tm.rpcSuspendPublicTransaction()).
+		Add("ptx_resumePublicTransaction", tm.rpcResumePublicTransaction()).
+		Add("ptx_cancelPublicTransaction", tm.rpcCancelPublicTransaction()).
 		Add("ptx_getPreparedTransaction", tm.rpcGetPreparedTransaction()).
 		Add("ptx_queryPreparedTransactions", tm.rpcQueryPreparedTransactions()).
 		Add("ptx_storeABI", tm.rpcStoreABI()).
@@ -66,6 +69,10 @@ func (tm *txManager) buildRPCModule() {
 		Add("ptx_startReceiptListener", tm.rpcStartReceiptListener()).
 		Add("ptx_stopReceiptListener", tm.rpcStopReceiptListener()).
 		Add("ptx_deleteReceiptListener", tm.rpcDeleteReceiptListener()).
+		Add("ptx_queryReceiptListenerDeadLetters", tm.rpcQueryReceiptListenerDeadLetters()).
+		Add("ptx_replayReceiptListenerDeadLetter", tm.rpcReplayReceiptListenerDeadLetter()).
+		Add("ptx_discardReceiptListenerDeadLetter", tm.rpcDiscardReceiptListenerDeadLetter()).
+		Add("ptx_resetReceiptListenerCheckpoint", tm.rpcResetReceiptListenerCheckpoint()).
 		Add("ptx_createBlockchainEventListener", tm.rpcCreateBlockchainEventListener()).
 		Add("ptx_queryBlockchainEventListeners", tm.rpcQueryBlockchainEventListeners()).
 		Add("ptx_getBlockchainEventListener", tm.rpcGetBlockchainEventListener()).
@@ -73,6 +80,10 @@ func (tm *txManager) buildRPCModule() {
 		Add("ptx_stopBlockchainEventListener", tm.rpcStopBlockchainEventListener()).
 		Add("ptx_deleteBlockchainEventListener", tm.rpcDeleteBlockchainEventListener()).
 		Add("ptx_getBlockchainEventListenerStatus", tm.rpcGetBlockchainEventListenerStatus()).
+		Add("ptx_queryBlockchainEventListenerDeadLetters", tm.rpcQueryBlockchainEventListenerDeadLetters()).
+		Add("ptx_replayBlockchainEventListenerDeadLetter", tm.rpcReplayBlockchainEventListenerDeadLetter()).
+		Add("ptx_discardBlockchainEventListenerDeadLetter", tm.rpcDiscardBlockchainEventListenerDeadLetter()).
+		Add("ptx_resetBlockchainEventListenerCheckpoint", tm.rpcResetBlockchainEventListenerCheckpoint()).
 		AddAsync(tm.rpcEventStreams)
 
 	tm.debugRpcModule = rpcserver.NewRPCModule("debug").
@@ -304,6 +315,36 @@ func (tm *txManager) rpcGetPublicTransactionByHash() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcSuspendPublicTransaction() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		from pldtypes.EthAddress,
+		nonce pldtypes.HexUint64,
+	) (bool, error) {
+		tm.metrics.IncRpc("suspendPublicTransaction")
+		return true, tm.publicTxMgr.SuspendTransaction(ctx, from, nonce.Uint64())
+	})
+}
+
+func (tm *txManager) rpcResumePublicTransaction() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		from pldtypes.EthAddress,
+		nonce pldtypes.HexUint64,
+	) (bool, error) {
+		tm.metrics.IncRpc("resumePublicTransaction")
+		return true, tm.publicTxMgr.ResumeTransaction(ctx, from, nonce.Uint64())
+	})
+}
+
+func (tm *txManager) rpcCancelPublicTransaction() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		from pldtypes.EthAddress,
+		nonce pldtypes.HexUint64,
+	) (bool, error) {
+		tm.metrics.IncRpc("cancelPublicTransaction")
+		return true, tm.publicTxMgr.CancelTransaction(ctx, from, nonce.Uint64())
+	})
+}
+
 func (tm *txManager) rpcStoreABI() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		a abi.ABI,
@@ -438,6 +479,46 @@ func (tm *txManager) rpcDeleteReceiptListener() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *txManager) rpcQueryReceiptListenerDeadLetters() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		query query.QueryJSON,
+	) ([]*pldapi.ListenerDeadLetter, error) {
+		tm.metrics.IncRpc("queryReceiptListenerDeadLetters")
+		return tm.QueryReceiptListenerDeadLetters(ctx, tm.p.NOTX(), name, &query)
+	})
+}
+
+func (tm *txManager) rpcReplayReceiptListenerDeadLetter() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		id uuid.UUID,
+	) (bool, error) {
+		tm.metrics.IncRpc("replayReceiptListenerDeadLetter")
+		return true, tm.ReplayReceiptListenerDeadLetter(ctx, name, id)
+	})
+}
+
+func (tm *txManager) rpcDiscardReceiptListenerDeadLetter() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		id uuid.UUID,
+	) (bool, error) {
+		tm.metrics.IncRpc("discardReceiptListenerDeadLetter")
+		return true, tm.DiscardReceiptListenerDeadLetter(ctx, name, id)
+	})
+}
+
+func (tm *txManager) rpcResetReceiptListenerCheckpoint() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		sequence uint64,
+	) (bool, error) {
+		tm.metrics.IncRpc("resetReceiptListenerCheckpoint")
+		return true, tm.ResetReceiptListenerCheckpoint(ctx, name, sequence)
+	})
+}
+
 func (tm *txManager) rpcCreateBlockchainEventListener() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context,
 		listener *pldapi.BlockchainEventListener,
@@ -501,3 +582,43 @@ func (tm *txManager) rpcGetBlockchainEventListenerStatus() rpcserver.RPCHandler
 		return tm.GetBlockchainEventListenerStatus(ctx, name)
 	})
 }
+
+func (tm *txManager) rpcQueryBlockchainEventListenerDeadLetters() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		query query.QueryJSON,
+	) ([]*pldapi.ListenerDeadLetter, error) {
+		tm.metrics.IncRpc("queryBlockchainEventListenerDeadLetters")
+		return tm.QueryBlockchainEventListenerDeadLetters(ctx, tm.p.NOTX(), name, &query)
+	})
+}
+
+func (tm *txManager) rpcReplayBlockchainEventListenerDeadLetter() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		id uuid.UUID,
+	) (bool, error) {
+		tm.metrics.IncRpc("replayBlockchainEventListenerDeadLetter")
+		return true, tm.ReplayBlockchainEventListenerDeadLetter(ctx, name, id)
+	})
+}
+
+func (tm *txManager) rpcDiscardBlockchainEventListenerDeadLetter() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		id uuid.UUID,
+	) (bool, error) {
+		tm.metrics.IncRpc("discardBlockchainEventListenerDeadLetter")
+		return true, tm.DiscardBlockchainEventListenerDeadLetter(ctx, name, id)
+	})
+}
+
+func (tm *txManager) rpcResetBlockchainEventListenerCheckpoint() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context,
+		name string,
+		blockNumber int64,
+	) (bool, error) {
+		tm.metrics.IncRpc("resetBlockchainEventListenerCheckpoint")
+		return true, tm.ResetBlockchainEventListenerCheckpoint(ctx, name, blockNumber)
+	})
+}
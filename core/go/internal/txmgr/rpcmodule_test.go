@@ -498,6 +498,39 @@ func TestPublicTransactionPassthroughQueries(t *testing.T) {
 	assert.Equal(t, sampleTxns[0], txn)
 }
 
+func TestPublicTransactionUserActions(t *testing.T) {
+
+	from := *pldtypes.RandAddress()
+	ctx, url, _, done := newTestTransactionManagerWithRPC(t, func(tmc *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.publicTxMgr.On("SuspendTransaction", mock.Anything, from, uint64(10)).Return(nil)
+		mc.publicTxMgr.On("ResumeTransaction", mock.Anything, from, uint64(10)).Return(nil)
+		mc.publicTxMgr.On("CancelTransaction", mock.Anything, from, uint64(10)).Return(nil)
+		mc.publicTxMgr.On("CancelTransaction", mock.Anything, from, uint64(11)).Return(fmt.Errorf("pop"))
+	})
+	defer done()
+
+	rpcClient, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+
+	var boolRes bool
+	err = rpcClient.CallRPC(ctx, &boolRes, "ptx_suspendPublicTransaction", from, pldtypes.HexUint64(10))
+	require.NoError(t, err)
+	assert.True(t, boolRes)
+
+	boolRes = false
+	err = rpcClient.CallRPC(ctx, &boolRes, "ptx_resumePublicTransaction", from, pldtypes.HexUint64(10))
+	require.NoError(t, err)
+	assert.True(t, boolRes)
+
+	boolRes = false
+	err = rpcClient.CallRPC(ctx, &boolRes, "ptx_cancelPublicTransaction", from, pldtypes.HexUint64(10))
+	require.NoError(t, err)
+	assert.True(t, boolRes)
+
+	err = rpcClient.CallRPC(ctx, &boolRes, "ptx_cancelPublicTransaction", from, pldtypes.HexUint64(11))
+	require.Regexp(t, "pop", err)
+}
+
 func TestDetailedReceiptRPCsNotFound(t *testing.T) {
 
 	ctx, url, _, done := newTestTransactionManagerWithRPC(t, func(tmc *pldconf.TxManagerConfig, mc *mockComponents) {
@@ -0,0 +1,212 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+func (tm *txManager) expiryInit() {
+	tm.expiryCheckInterval = confutil.DurationMin(tm.conf.Transactions.ExpiryCheckInterval, 10*time.Millisecond, *pldconf.TxManagerDefaults.Transactions.ExpiryCheckInterval)
+	tm.expiryPageSize = 100 /* not currently tunable */
+	tm.expiryCancelledPubTxns = make(map[uint64]bool)
+}
+
+func (tm *txManager) startExpiryLoop() {
+	ctx := log.WithLogField(tm.bgCtx, "role", "tx-expiry")
+	ctx, tm.expiryLoopCancel = context.WithCancel(ctx)
+	tm.expiryLoopDone = make(chan struct{})
+	go tm.expiryLoop(ctx)
+}
+
+func (tm *txManager) stopExpiryLoop() {
+	if tm.expiryLoopDone != nil {
+		tm.expiryLoopCancel()
+		<-tm.expiryLoopDone
+	}
+}
+
+func (tm *txManager) expiryLoop(ctx context.Context) {
+	defer close(tm.expiryLoopDone)
+
+	ticker := time.NewTicker(tm.expiryCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.L(ctx).Debugf("Transaction expiry loop exiting")
+			return
+		}
+		if err := tm.processExpiredTransactions(ctx); err != nil {
+			// we just try again on the next tick
+			log.L(ctx).Errorf("Failed to process expired transactions: %s", err)
+		}
+	}
+}
+
+// processExpiredTransactions finalizes transactions that have passed their expiresAt deadline without a receipt.
+//
+// A transaction can only be finalized here while none of its public transactions have been assigned a nonce,
+// as the public transaction manager will never assign one after the deadline. Once a nonce is assigned the
+// transaction might be mined at any point, so instead we cancel it and let the confirmation of either the
+// original or the replacement write the receipt.
+//
+// Private transactions that have not been dispatched are expired by the sequencer, which also releases
+// their state locks. Those that have been dispatched are failed through the private transaction manager,
+// so it can release their state locks.
+func (tm *txManager) processExpiredTransactions(ctx context.Context) error {
+	var expired []*persistedTransaction
+	err := tm.p.DB().
+		WithContext(ctx).
+		Table("transactions").
+		Joins("TransactionReceipt").
+		Where(`"TransactionReceipt"."transaction" IS NULL`).
+		Where(`"transactions"."expires_at" <= ?`, pldtypes.TimestampNow()).
+		Order(`"transactions"."expires_at"`).
+		Limit(tm.expiryPageSize).
+		Find(&expired).
+		Error
+	if err != nil || len(expired) == 0 {
+		return err
+	}
+
+	txIDs := make([]uuid.UUID, len(expired))
+	for i, ptx := range expired {
+		txIDs[i] = ptx.ID
+	}
+	boundPubTxs, err := tm.publicTxMgr.QueryPublicTxForTransactions(ctx, tm.p.NOTX(), txIDs, nil)
+	if err != nil {
+		return err
+	}
+
+	// We only remember the cancellations of public transactions that are still waiting to be confirmed
+	cancelledPubTxns := make(map[uint64]bool)
+	var unassigned []*persistedTransaction
+	unassignedFrom := make(map[pldtypes.EthAddress]bool)
+	for _, ptx := range expired {
+		pubTxs := boundPubTxs[ptx.ID]
+		if len(pubTxs) == 0 && ptx.Type.V() == pldapi.TransactionTypePrivate {
+			continue
+		}
+		nonceAssigned := false
+		for _, pubTx := range pubTxs {
+			if pubTx.Nonce == nil {
+				continue
+			}
+			nonceAssigned = true
+			if pubTx.TransactionHash != nil {
+				continue
+			}
+			if tm.expiryCancelledPubTxns[*pubTx.LocalID] {
+				cancelledPubTxns[*pubTx.LocalID] = true
+				continue
+			}
+			log.L(ctx).Infof("Cancelling public transaction %s:%d for expired transaction %s", pubTx.From, pubTx.Nonce.Uint64(), ptx.ID)
+			if err := tm.publicTxMgr.CancelTransaction(ctx, pubTx.From, pubTx.Nonce.Uint64()); err != nil {
+				log.L(ctx).Errorf("Failed to cancel public transaction %s:%d for expired transaction %s: %s", pubTx.From, pubTx.Nonce.Uint64(), ptx.ID, err)
+				continue
+			}
+			cancelledPubTxns[*pubTx.LocalID] = true
+		}
+		if !nonceAssigned {
+			unassigned = append(unassigned, ptx)
+			for _, pubTx := range pubTxs {
+				unassignedFrom[pubTx.From] = true
+			}
+		}
+	}
+	tm.expiryCancelledPubTxns = cancelledPubTxns
+
+	if len(unassigned) == 0 {
+		return nil
+	}
+	// The nonce locks are always taken in the same order, so two sets of them cannot deadlock
+	fromAddresses := make([]pldtypes.EthAddress, 0, len(unassignedFrom))
+	for from := range unassignedFrom {
+		fromAddresses = append(fromAddresses, from)
+	}
+	slices.SortFunc(fromAddresses, func(a, b pldtypes.EthAddress) int { return bytes.Compare(a[:], b[:]) })
+	return tm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return tm.finalizeExpiredTransactions(ctx, dbTX, unassigned, fromAddresses)
+	})
+}
+
+// The check for a nonce above is outside of a DB transaction, so we check again holding the locks the public
+// transaction manager takes to assign nonces for each from address. It does not assign a nonce to a transaction
+// that has passed its deadline, so any transaction that does not have a nonce now will never be submitted.
+func (tm *txManager) finalizeExpiredTransactions(ctx context.Context, dbTX persistence.DBTX, unassigned []*persistedTransaction, fromAddresses []pldtypes.EthAddress) error {
+	for _, from := range fromAddresses {
+		if err := tm.p.TakeNamedLock(ctx, dbTX, components.PublicTxNonceLock(from)); err != nil {
+			return err
+		}
+	}
+	txIDs := make([]uuid.UUID, len(unassigned))
+	for i, ptx := range unassigned {
+		txIDs[i] = ptx.ID
+	}
+	boundPubTxs, err := tm.publicTxMgr.QueryPublicTxForTransactions(ctx, dbTX, txIDs, nil)
+	if err != nil {
+		return err
+	}
+
+	var publicReceipts, privateReceipts []*components.ReceiptInput
+	for _, ptx := range unassigned {
+		nonceAssigned := false
+		for _, pubTx := range boundPubTxs[ptx.ID] {
+			nonceAssigned = nonceAssigned || pubTx.Nonce != nil
+		}
+		if nonceAssigned {
+			log.L(ctx).Infof("Nonce assigned to expired transaction %s during expiry processing", ptx.ID)
+			continue
+		}
+		receipt := &components.ReceiptInput{
+			ReceiptType:    components.RT_FailedWithMessage,
+			TransactionID:  ptx.ID,
+			Domain:         stringOrEmpty(ptx.Domain),
+			FailureMessage: i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgTxMgrTransactionExpired), ptx.ExpiresAt),
+		}
+		if ptx.Type.V() == pldapi.TransactionTypePrivate {
+			privateReceipts = append(privateReceipts, receipt)
+		} else {
+			publicReceipts = append(publicReceipts, receipt)
+		}
+	}
+
+	if len(publicReceipts) > 0 {
+		if err := tm.FinalizeTransactions(ctx, dbTX, publicReceipts); err != nil {
+			return err
+		}
+	}
+	if len(privateReceipts) > 0 {
+		return tm.privateTxMgr.NotifyExpiredTransactions(ctx, dbTX, privateReceipts)
+	}
+	return nil
+}
@@ -0,0 +1,344 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var expiryTestABI = abi.ABI{{Type: abi.Function, Name: "doStuff"}}
+
+func TestProcessExpiredTransactionsRealDB(t *testing.T) {
+	senderAddr := pldtypes.RandAddress()
+	var publicTxns map[uuid.UUID][]*pldapi.PublicTx
+	var submissions []*components.PublicTxSubmission
+	var mc *mockComponents
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockQueryPublicTxForTransactions(func(ids []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error) {
+			return publicTxns, nil
+		}),
+		func(conf *pldconf.TxManagerConfig, _mc *mockComponents) {
+			mc = _mc
+			mockResolveKey(t, mc, "sender1", senderAddr)
+			mc.publicTxMgr.On("ValidateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mc.publicTxMgr.On("WriteNewTransactions", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				submissions = append(submissions, args[2].([]*components.PublicTxSubmission)...)
+			}).Return([]*pldapi.PublicTx{}, nil)
+		},
+	)
+	defer done()
+
+	expiresAt := pldtypes.TimestampFromUnix(time.Now().Add(-1 * time.Second).Unix())
+	notBefore := pldtypes.TimestampFromUnix(time.Now().Add(-1 * time.Hour).Unix())
+	txIDs := make([]uuid.UUID, 3)
+	for i := range txIDs {
+		txID, err := txm.sendTransactionNewDBTX(ctx, &pldapi.TransactionInput{
+			TransactionBase: pldapi.TransactionBase{
+				Type:     pldapi.TransactionTypePublic.Enum(),
+				From:     "sender1",
+				To:       pldtypes.RandAddress(),
+				Function: "doStuff",
+			},
+			ABI:       expiryTestABI,
+			NotBefore: &notBefore,
+			ExpiresAt: &expiresAt,
+		})
+		require.NoError(t, err)
+		txIDs[i] = *txID
+	}
+
+	// the scheduling is passed to the public transaction manager and visible on query
+	tx, err := txm.GetTransactionByIDFull(ctx, txIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, expiresAt, *tx.ExpiresAt)
+	assert.Equal(t, notBefore, *tx.NotBefore)
+	require.Len(t, submissions, 3)
+	assert.Equal(t, expiresAt, *submissions[0].ExpiresAt)
+	assert.Equal(t, notBefore, *submissions[0].NotBefore)
+
+	// 0: no nonce assigned, so we can finalize it
+	// 1: nonce assigned, so we must cancel it
+	// 2: nonce assigned and the cancel fails, so we retry
+	publicTxns = map[uuid.UUID][]*pldapi.PublicTx{
+		txIDs[0]: {{LocalID: confutil.P(uint64(1)), From: *senderAddr}},
+		txIDs[1]: {{LocalID: confutil.P(uint64(2)), From: *senderAddr, Nonce: confutil.P(pldtypes.HexUint64(10))}},
+		txIDs[2]: {{LocalID: confutil.P(uint64(3)), From: *senderAddr, Nonce: confutil.P(pldtypes.HexUint64(11))}},
+	}
+	mc.publicTxMgr.On("CancelTransaction", mock.Anything, *senderAddr, uint64(10)).Return(nil).Once()
+	mc.publicTxMgr.On("CancelTransaction", mock.Anything, *senderAddr, uint64(11)).Return(fmt.Errorf("pop")).Once()
+	mc.publicTxMgr.On("CancelTransaction", mock.Anything, *senderAddr, uint64(11)).Return(nil).Once()
+
+	for range 2 {
+		err = txm.processExpiredTransactions(ctx)
+		require.NoError(t, err)
+	}
+
+	receipt, err := txm.GetTransactionReceiptByID(ctx, txIDs[0])
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	assert.False(t, receipt.Success)
+	assert.Regexp(t, "PD012255", receipt.FailureMessage)
+
+	for _, txID := range txIDs[1:] {
+		receipt, err = txm.GetTransactionReceiptByID(ctx, txID)
+		require.NoError(t, err)
+		assert.Nil(t, receipt)
+	}
+	assert.Equal(t, map[uint64]bool{2: true, 3: true}, txm.expiryCancelledPubTxns)
+
+	// once a cancelled transaction is mined, we forget we cancelled it
+	publicTxns[txIDs[1]][0].TransactionHash = confutil.P(pldtypes.RandBytes32())
+	err = txm.processExpiredTransactions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]bool{3: true}, txm.expiryCancelledPubTxns)
+}
+
+func insertExpiringPrivateTransaction(t *testing.T, ctx context.Context, txm *txManager, expiresAt pldtypes.Timestamp) uuid.UUID {
+	txID := uuid.New()
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		pa, err := txm.UpsertABI(ctx, dbTX, expiryTestABI)
+		require.NoError(t, err)
+		_, err = txm.insertTransactions(ctx, dbTX, []*components.ValidatedTransaction{{
+			ResolvedTransaction: components.ResolvedTransaction{
+				Transaction: &pldapi.Transaction{
+					ID: &txID,
+					TransactionBase: pldapi.TransactionBase{
+						Type:   pldapi.TransactionTypePrivate.Enum(),
+						Domain: "domain1",
+						From:   "sender1@node1",
+						To:     pldtypes.RandAddress(),
+					},
+				},
+				Function: &components.ResolvedFunction{
+					ABIReference: &pa.Hash,
+					Definition:   expiryTestABI[0],
+					Signature:    "doStuff()",
+				},
+				ExpiresAt: &expiresAt,
+			},
+		}}, false)
+		return err
+	})
+	require.NoError(t, err)
+	return txID
+}
+
+func TestProcessExpiredTransactionsPrivateDispatched(t *testing.T) {
+	var mc *mockComponents
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockQueryPublicTxForTransactions(func(ids []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error) {
+			return map[uuid.UUID][]*pldapi.PublicTx{
+				ids[0]: {{LocalID: confutil.P(uint64(1)), From: *pldtypes.RandAddress()}},
+			}, nil
+		}),
+		func(conf *pldconf.TxManagerConfig, _mc *mockComponents) {
+			mc = _mc
+		},
+	)
+	defer done()
+
+	txID := insertExpiringPrivateTransaction(t, ctx, txm, pldtypes.TimestampNow())
+
+	// the private transaction manager writes the receipt, so it can release the state locks
+	mc.privateTxMgr.On("NotifyExpiredTransactions", mock.Anything, mock.Anything, mock.MatchedBy(func(receipts []*components.ReceiptInput) bool {
+		return len(receipts) == 1 &&
+			receipts[0].TransactionID == txID &&
+			receipts[0].ReceiptType == components.RT_FailedWithMessage &&
+			receipts[0].Domain == "domain1"
+	})).Return(nil)
+
+	err := txm.processExpiredTransactions(ctx)
+	require.NoError(t, err)
+}
+
+func TestProcessExpiredTransactionsNonceAssignedDuringCheck(t *testing.T) {
+	queries := 0
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockQueryPublicTxForTransactions(func(ids []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error) {
+			queries++
+			pubTx := &pldapi.PublicTx{LocalID: confutil.P(uint64(1)), From: *pldtypes.RandAddress()}
+			if queries > 1 {
+				// assigned before we took the lock
+				pubTx.Nonce = confutil.P(pldtypes.HexUint64(10))
+			}
+			return map[uuid.UUID][]*pldapi.PublicTx{ids[0]: {pubTx}}, nil
+		}),
+	)
+	defer done()
+
+	txID := insertExpiringPrivateTransaction(t, ctx, txm, pldtypes.TimestampNow())
+
+	err := txm.processExpiredTransactions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, queries)
+
+	receipt, err := txm.GetTransactionReceiptByID(ctx, txID)
+	require.NoError(t, err)
+	assert.Nil(t, receipt)
+}
+
+func TestProcessExpiredTransactionsRecheckFail(t *testing.T) {
+	queries := 0
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockQueryPublicTxForTransactions(func(ids []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error) {
+			queries++
+			if queries > 1 {
+				return nil, fmt.Errorf("pop")
+			}
+			return map[uuid.UUID][]*pldapi.PublicTx{
+				ids[0]: {{LocalID: confutil.P(uint64(1)), From: *pldtypes.RandAddress()}},
+			}, nil
+		}),
+	)
+	defer done()
+
+	insertExpiringPrivateTransaction(t, ctx, txm, pldtypes.TimestampNow())
+
+	err := txm.processExpiredTransactions(ctx)
+	assert.Regexp(t, "pop", err)
+}
+
+type lockRecordingPersistence struct {
+	persistence.Persistence
+	locks   []string
+	lockErr error
+}
+
+func (p *lockRecordingPersistence) TakeNamedLock(ctx context.Context, dbTX persistence.DBTX, lockName string) error {
+	p.locks = append(p.locks, lockName)
+	return p.lockErr
+}
+
+func TestProcessExpiredTransactionsNonceLockPerAddress(t *testing.T) {
+	from1 := pldtypes.MustEthAddress("0x1111111111111111111111111111111111111111")
+	from2 := pldtypes.MustEthAddress("0x2222222222222222222222222222222222222222")
+	var mc *mockComponents
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockQueryPublicTxForTransactions(func(ids []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error) {
+			return map[uuid.UUID][]*pldapi.PublicTx{
+				ids[0]: {
+					{LocalID: confutil.P(uint64(1)), From: *from2},
+					{LocalID: confutil.P(uint64(2)), From: *from1},
+					{LocalID: confutil.P(uint64(3)), From: *from2},
+				},
+			}, nil
+		}),
+		func(conf *pldconf.TxManagerConfig, _mc *mockComponents) {
+			mc = _mc
+		},
+	)
+	defer done()
+
+	txID := insertExpiringPrivateTransaction(t, ctx, txm, pldtypes.TimestampNow())
+	mc.privateTxMgr.On("NotifyExpiredTransactions", mock.Anything, mock.Anything, mock.MatchedBy(func(receipts []*components.ReceiptInput) bool {
+		return len(receipts) == 1 && receipts[0].TransactionID == txID
+	})).Return(nil)
+
+	// only the nonces of the from addresses of the transaction are locked, in a consistent order
+	lp := &lockRecordingPersistence{Persistence: txm.p, lockErr: fmt.Errorf("pop")}
+	txm.p = lp
+	err := txm.processExpiredTransactions(ctx)
+	assert.Regexp(t, "pop", err)
+	assert.Equal(t, []string{components.PublicTxNonceLock(*from1)}, lp.locks)
+
+	lp.locks, lp.lockErr = nil, nil
+	err = txm.processExpiredTransactions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		components.PublicTxNonceLock(*from1),
+		components.PublicTxNonceLock(*from2),
+	}, lp.locks)
+}
+
+func TestProcessExpiredTransactionsPrivateUndispatched(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockQueryPublicTxForTransactions(func(ids []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error) {
+			return map[uuid.UUID][]*pldapi.PublicTx{}, nil
+		}),
+	)
+	defer done()
+
+	txID := insertExpiringPrivateTransaction(t, ctx, txm, pldtypes.TimestampNow())
+
+	// the sequencer is responsible for this one
+	err := txm.processExpiredTransactions(ctx)
+	require.NoError(t, err)
+
+	receipt, err := txm.GetTransactionReceiptByID(ctx, txID)
+	require.NoError(t, err)
+	assert.Nil(t, receipt)
+}
+
+func TestProcessExpiredTransactionsQueryFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectQuery("SELECT.*transactions").WillReturnError(fmt.Errorf("pop"))
+	})
+	defer done()
+
+	err := txm.processExpiredTransactions(ctx)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestProcessExpiredTransactionsPublicQueryFail(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false,
+		mockEmptyReceiptListeners,
+		mockQueryPublicTxForTransactions(func(ids []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error) {
+			return nil, fmt.Errorf("pop")
+		}),
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mc.db.ExpectQuery("SELECT.*transactions").WillReturnRows(mc.db.NewRows([]string{"id"}).AddRow(uuid.New()))
+		},
+	)
+	defer done()
+
+	err := txm.processExpiredTransactions(ctx)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestExpiryLoop(t *testing.T) {
+	var mc *mockComponents
+	_, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, _mc *mockComponents) {
+		mc = _mc
+	})
+	defer done()
+
+	// restart the loop with a short interval, and check it survives an error
+	txm.stopExpiryLoop()
+	mc.db.ExpectQuery("SELECT.*transactions").WillReturnError(fmt.Errorf("pop"))
+	mc.db.ExpectQuery("SELECT.*transactions").WillReturnRows(mc.db.NewRows([]string{}))
+	txm.expiryCheckInterval = 1 * time.Millisecond
+	txm.startExpiryLoop()
+	require.Eventually(t, func() bool {
+		return mc.db.ExpectationsWereMet() == nil
+	}, 5*time.Second, 5*time.Millisecond)
+	txm.stopExpiryLoop()
+}
@@ -0,0 +1,418 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+const (
+	exportPathPrefix = "/export/transactions"
+
+	// Set at the start of the export to the highest receipt sequence that will be included,
+	// so the client knows the export is complete when it has seen a record with this sequence.
+	// Receipts indexed within the settle time are excluded, so they are exported by a later
+	// request that resumes after this sequence.
+	exportHeaderUpTo = "X-Paladin-Export-Upto"
+
+	exportFormatNDJSON = "ndjson"
+	exportFormatCSV    = "csv"
+
+	exportIncludeTransaction = "transaction"
+	exportIncludePublic      = "public"
+	exportIncludeStates      = "states"
+)
+
+var exportCSVColumns = []string{
+	"sequence", "id", "indexed", "domain", "success",
+	"transactionHash", "blockNumber", "transactionIndex", "logIndex", "source",
+	"contractAddress", "failureMessage",
+	"type", "from", "to", "function", "idempotencyKey", "created",
+	"public", "states",
+}
+
+type exportRequest struct {
+	format      string
+	after       uint64
+	limit       int // zero for no limit
+	transaction bool
+	public      bool
+	states      bool
+}
+
+type exportWriter interface {
+	writeRecord(r *pldapi.TransactionExportRecord) error
+	flush() error
+}
+
+func (tm *txManager) exportInit() {
+	tm.exportPageSize = confutil.IntMin(tm.conf.Export.PageSize, 1, *pldconf.TxManagerDefaults.Export.PageSize)
+	tm.exportPageWriteTimeout = confutil.DurationMin(tm.conf.Export.PageWriteTimeout, 1*time.Second, *pldconf.TxManagerDefaults.Export.PageWriteTimeout)
+	tm.exportSettleTime = confutil.DurationMin(tm.conf.Export.SettleTime, 0, *pldconf.TxManagerDefaults.Export.SettleTime)
+}
+
+func parseExportRequest(ctx context.Context, params url.Values) (*exportRequest, error) {
+	er := &exportRequest{
+		format:      exportFormatNDJSON,
+		transaction: true,
+		public:      true,
+		states:      true,
+	}
+	if format := params.Get("format"); format != "" {
+		er.format = strings.ToLower(format)
+		if er.format != exportFormatNDJSON && er.format != exportFormatCSV {
+			return nil, i18n.NewError(ctx, msgs.MsgTxMgrExportInvalidFormat, format)
+		}
+	}
+	if after := params.Get("after"); after != "" {
+		v, err := strconv.ParseUint(after, 10, 64)
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgTxMgrExportInvalidParam, "after", err)
+		}
+		er.after = v
+	}
+	if limit := params.Get("limit"); limit != "" {
+		v, err := strconv.ParseUint(limit, 10, 31)
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgTxMgrExportInvalidParam, "limit", err)
+		}
+		er.limit = int(v)
+	}
+	if include, set := params["include"]; set {
+		er.transaction, er.public, er.states = false, false, false
+		for _, i := range strings.Split(strings.Join(include, ","), ",") {
+			switch strings.TrimSpace(i) {
+			case exportIncludeTransaction:
+				er.transaction = true
+			case exportIncludePublic:
+				er.public = true
+			case exportIncludeStates:
+				er.states = true
+			case "":
+			default:
+				return nil, i18n.NewError(ctx, msgs.MsgTxMgrExportInvalidInclude, i)
+			}
+		}
+	}
+	return er, nil
+}
+
+func writeExportError(ctx context.Context, res http.ResponseWriter, status int, err error) {
+	log.L(ctx).Errorf("Export failed: %s", err)
+	res.Header().Set("Content-Type", "application/json; charset=utf-8")
+	res.WriteHeader(status)
+	_ = json.NewEncoder(res).Encode(map[string]string{"error": err.Error()})
+}
+
+// handleExport streams receipts in sequence order, along with the transactions, public transactions and
+// state receipts associated with them. The export is read page-by-page from the DB using the receipt
+// sequence as a cursor, so an interrupted export can be resumed by passing the last sequence as "after".
+//
+// Sequences are allocated before the DB transaction that writes a receipt commits, so a receipt can
+// become visible after one with a higher sequence. To avoid a resumed export skipping such a receipt,
+// the export stops at a committed watermark: the highest sequence indexed before the settle time.
+func (tm *txManager) handleExport(res http.ResponseWriter, req *http.Request) {
+	ctx, cancelCtx := exportContext(req.Context())
+	defer cancelCtx()
+	ctx = log.WithLogField(ctx, "export", uuid.NewString())
+
+	if req.Method != http.MethodGet {
+		res.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	er, err := parseExportRequest(ctx, req.URL.Query())
+	if err != nil {
+		writeExportError(ctx, res, http.StatusBadRequest, err)
+		return
+	}
+
+	// Fix the end of the export before we start, so that we export a consistent set of
+	// receipts however long the export takes
+	var upTo *uint64
+	err = tm.p.DB().WithContext(ctx).
+		Table("transaction_receipts").
+		Select("MAX(sequence)").
+		Where("indexed <= ?", pldtypes.TimestampNow()-pldtypes.Timestamp(tm.exportSettleTime)).
+		Scan(&upTo).
+		Error
+	if err != nil {
+		writeExportError(ctx, res, http.StatusInternalServerError, err)
+		return
+	}
+	if upTo == nil {
+		upTo = confutil.P(uint64(0))
+	}
+
+	log.L(ctx).Infof("Exporting format=%s after=%d upTo=%d limit=%d", er.format, er.after, *upTo, er.limit)
+	var w exportWriter
+	if er.format == exportFormatCSV {
+		res.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w = newCSVExportWriter(res)
+	} else {
+		res.Header().Set("Content-Type", "application/x-ndjson")
+		w = &ndjsonExportWriter{enc: json.NewEncoder(res)}
+	}
+	res.Header().Set(exportHeaderUpTo, strconv.FormatUint(*upTo, 10))
+	res.WriteHeader(http.StatusOK)
+
+	// Once we have started streaming, the status is sent - so all we can do is terminate the response
+	count, err := tm.streamExport(ctx, res, w, er, *upTo)
+	if err != nil {
+		log.L(ctx).Errorf("Export terminated after %d records: %s", count, err)
+		return
+	}
+	log.L(ctx).Infof("Export complete with %d records", count)
+}
+
+// We are not bound by the request timeout, as an export might run much longer than a normal API call.
+// Instead the write deadline is extended as each page is written. The export is still stopped if the
+// request is cancelled for any other reason, such as the client disconnecting.
+func exportContext(reqCtx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancelCtx := context.WithCancel(context.WithoutCancel(reqCtx))
+	stop := context.AfterFunc(reqCtx, func() {
+		if !errors.Is(reqCtx.Err(), context.DeadlineExceeded) {
+			cancelCtx()
+		}
+	})
+	return ctx, func() {
+		stop()
+		cancelCtx()
+	}
+}
+
+func (tm *txManager) streamExport(ctx context.Context, res http.ResponseWriter, w exportWriter, er *exportRequest, upTo uint64) (count int, err error) {
+	rc := http.NewResponseController(res)
+	if err := w.flush(); err != nil {
+		return 0, err
+	}
+	cursor := er.after
+	for cursor < upTo && (er.limit == 0 || count < er.limit) {
+		pageSize := tm.exportPageSize
+		if er.limit > 0 && er.limit-count < pageSize {
+			pageSize = er.limit - count
+		}
+		records, err := tm.readExportPage(ctx, er, cursor, upTo, pageSize)
+		if err != nil || len(records) == 0 {
+			return count, err
+		}
+		if err := rc.SetWriteDeadline(time.Now().Add(tm.exportPageWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return count, err
+		}
+		for _, r := range records {
+			if err := w.writeRecord(r); err != nil {
+				return count, err
+			}
+		}
+		if err := w.flush(); err != nil {
+			return count, err
+		}
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return count, err
+		}
+		count += len(records)
+		cursor = records[len(records)-1].Sequence
+	}
+	return count, nil
+}
+
+func (tm *txManager) readExportPage(ctx context.Context, er *exportRequest, after, upTo uint64, limit int) ([]*pldapi.TransactionExportRecord, error) {
+	var receipts []*transactionReceipt
+	err := tm.p.DB().WithContext(ctx).
+		Where("sequence > ?", after).
+		Where("sequence <= ?", upTo).
+		Order("sequence").
+		Limit(limit).
+		Find(&receipts).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*pldapi.TransactionExportRecord, len(receipts))
+	txIDs := make([]uuid.UUID, len(receipts))
+	for i, r := range receipts {
+		txIDs[i] = r.TransactionID
+		records[i] = &pldapi.TransactionExportRecord{
+			Sequence: r.Sequence,
+			Receipt: &pldapi.TransactionReceipt{
+				ID:                     r.TransactionID,
+				TransactionReceiptData: *mapPersistedReceipt(r),
+			},
+		}
+	}
+	if len(records) == 0 {
+		return records, nil
+	}
+
+	if er.transaction {
+		var ptxs []*persistedTransaction
+		err := tm.p.DB().WithContext(ctx).
+			Where("id IN (?)", txIDs).
+			Find(&ptxs).
+			Error
+		if err != nil {
+			return nil, err
+		}
+		ptxByID := make(map[uuid.UUID]*persistedTransaction, len(ptxs))
+		for _, ptx := range ptxs {
+			ptxByID[ptx.ID] = ptx
+		}
+		for _, r := range records {
+			if ptx := ptxByID[r.Receipt.ID]; ptx != nil {
+				r.Transaction = tm.mapPersistedTXBase(ptx)
+			}
+		}
+	}
+
+	if er.public {
+		pubTxns, err := tm.publicTxMgr.QueryPublicTxForTransactions(ctx, tm.p.NOTX(), txIDs, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range records {
+			if len(pubTxns[r.Receipt.ID]) > 0 {
+				r.Public = pubTxns[r.Receipt.ID]
+			}
+		}
+	}
+
+	if er.states {
+		var domainTxIDs []uuid.UUID
+		for _, r := range records {
+			if r.Receipt.Domain != "" {
+				domainTxIDs = append(domainTxIDs, r.Receipt.ID)
+			}
+		}
+		if len(domainTxIDs) > 0 {
+			txStates, err := tm.stateMgr.GetTransactionStatesBatch(ctx, tm.p.NOTX(), domainTxIDs)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range records {
+				r.States = txStates[r.Receipt.ID]
+			}
+		}
+	}
+
+	return records, nil
+}
+
+type ndjsonExportWriter struct {
+	enc *json.Encoder
+}
+
+func (w *ndjsonExportWriter) writeRecord(r *pldapi.TransactionExportRecord) error {
+	return w.enc.Encode(r)
+}
+
+func (w *ndjsonExportWriter) flush() error {
+	return nil
+}
+
+type csvExportWriter struct {
+	csv *csv.Writer
+}
+
+func newCSVExportWriter(out io.Writer) *csvExportWriter {
+	w := &csvExportWriter{csv: csv.NewWriter(out)}
+	_ = w.csv.Write(exportCSVColumns) // buffered until the first flush, where any error is returned
+	return w
+}
+
+func (w *csvExportWriter) writeRecord(r *pldapi.TransactionExportRecord) error {
+	optString := func(s interface{ String() string }, isNil bool) string {
+		if isNil {
+			return ""
+		}
+		return s.String()
+	}
+	optJSON := func(v any, isNil bool) string {
+		if isNil {
+			return ""
+		}
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+
+	receipt := r.Receipt
+	row := make([]string, 0, len(exportCSVColumns))
+	row = append(row,
+		strconv.FormatUint(r.Sequence, 10),
+		receipt.ID.String(),
+		receipt.Indexed.String(),
+		receipt.Domain,
+		strconv.FormatBool(receipt.Success),
+	)
+	if oc := receipt.TransactionReceiptDataOnchain; oc != nil {
+		row = append(row,
+			optString(oc.TransactionHash, oc.TransactionHash == nil),
+			strconv.FormatInt(oc.BlockNumber, 10),
+			strconv.FormatInt(oc.TransactionIndex, 10),
+		)
+	} else {
+		row = append(row, "", "", "")
+	}
+	if oce := receipt.TransactionReceiptDataOnchainEvent; oce != nil {
+		row = append(row, strconv.FormatInt(oce.LogIndex, 10), oce.Source.String())
+	} else {
+		row = append(row, "", "")
+	}
+	row = append(row,
+		optString(receipt.ContractAddress, receipt.ContractAddress == nil),
+		receipt.FailureMessage,
+	)
+	if tx := r.Transaction; tx != nil {
+		row = append(row,
+			string(tx.Type),
+			tx.From,
+			optString(tx.To, tx.To == nil),
+			tx.Function,
+			tx.IdempotencyKey,
+			tx.Created.String(),
+		)
+	} else {
+		row = append(row, "", "", "", "", "", "")
+	}
+	row = append(row,
+		optJSON(r.Public, r.Public == nil),
+		optJSON(r.States, r.States == nil),
+	)
+	return w.csv.Write(row)
+}
+
+func (w *csvExportWriter) flush() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
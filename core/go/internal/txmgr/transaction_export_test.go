@@ -0,0 +1,340 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package txmgr
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupExportTestData(t *testing.T, pageSize int) (context.Context, *txManager, *mockComponents, []uuid.UUID, func()) {
+	senderAddr := pldtypes.RandAddress()
+	var mc *mockComponents
+	ctx, txm, done := newTestTransactionManager(t, true,
+		mockQueryPublicTxForTransactions(func(ids []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error) {
+			results := map[uuid.UUID][]*pldapi.PublicTx{}
+			for _, id := range ids {
+				results[id] = []*pldapi.PublicTx{}
+			}
+			results[ids[0]] = []*pldapi.PublicTx{{
+				From:        *senderAddr,
+				Nonce:       confutil.P(pldtypes.HexUint64(1)),
+				Submissions: []*pldapi.PublicTxSubmissionData{{TransactionHash: pldtypes.RandBytes32()}},
+			}}
+			return results, nil
+		}),
+		func(conf *pldconf.TxManagerConfig, _mc *mockComponents) {
+			mc = _mc
+			mockResolveKey(t, mc, "sender1", senderAddr)
+			mc.publicTxMgr.On("ValidateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mc.publicTxMgr.On("WriteNewTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]*pldapi.PublicTx{}, nil)
+		},
+	)
+	// Config is read when the manager is constructed, before the test config functions run
+	txm.exportPageSize = pageSize
+	txm.exportSettleTime = 0
+
+	// Two public transactions we submitted, and a private transaction receipt for a transaction submitted elsewhere
+	txIDs := make([]uuid.UUID, 3)
+	for i := range 2 {
+		txID, err := txm.sendTransactionNewDBTX(ctx, &pldapi.TransactionInput{
+			TransactionBase: pldapi.TransactionBase{
+				Type:           pldapi.TransactionTypePublic.Enum(),
+				From:           "sender1",
+				To:             pldtypes.RandAddress(),
+				Function:       "doStuff",
+				IdempotencyKey: fmt.Sprintf("export_%d", i),
+			},
+			ABI: expiryTestABI,
+		})
+		require.NoError(t, err)
+		txIDs[i] = *txID
+	}
+	txIDs[2] = uuid.New()
+
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return txm.FinalizeTransactions(ctx, dbTX, []*components.ReceiptInput{
+			{
+				TransactionID: txIDs[0],
+				ReceiptType:   components.RT_Success,
+				OnChain: pldtypes.OnChainLocation{
+					Type:             pldtypes.OnChainTransaction,
+					TransactionHash:  pldtypes.RandBytes32(),
+					BlockNumber:      12345,
+					TransactionIndex: 10,
+				},
+			},
+			{
+				TransactionID:  txIDs[1],
+				ReceiptType:    components.RT_FailedWithMessage,
+				FailureMessage: "pop",
+			},
+			{
+				TransactionID: txIDs[2],
+				Domain:        "domain1",
+				ReceiptType:   components.RT_Success,
+				OnChain: pldtypes.OnChainLocation{
+					Type:             pldtypes.OnChainEvent,
+					TransactionHash:  pldtypes.RandBytes32(),
+					BlockNumber:      12346,
+					TransactionIndex: 1,
+					LogIndex:         5,
+					Source:           pldtypes.RandAddress(),
+				},
+			},
+		})
+	})
+	require.NoError(t, err)
+
+	return ctx, txm, mc, txIDs, done
+}
+
+func readNDJSONExport(t *testing.T, res *httptest.ResponseRecorder) []*pldapi.TransactionExportRecord {
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "application/x-ndjson", res.Header().Get("Content-Type"))
+	var records []*pldapi.TransactionExportRecord
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		var r pldapi.TransactionExportRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		records = append(records, &r)
+	}
+	return records
+}
+
+func TestExportNDJSONRealDB(t *testing.T) {
+	_, txm, mc, txIDs, done := setupExportTestData(t, 2)
+	defer done()
+
+	// Only the domain receipt needs states, and they are read in one call for the page
+	mc.stateMgr.On("GetTransactionStatesBatch", mock.Anything, mock.Anything, []uuid.UUID{txIDs[2]}).Return(map[uuid.UUID]*pldapi.TransactionStates{
+		txIDs[2]: {Confirmed: []*pldapi.StateBase{{ID: pldtypes.RandBytes(32)}}},
+	}, nil).Once()
+
+	res := httptest.NewRecorder()
+	txm.handleExport(res, httptest.NewRequest(http.MethodGet, exportPathPrefix, nil))
+	records := readNDJSONExport(t, res)
+	require.Len(t, records, 3)
+	assert.Equal(t, fmt.Sprintf("%d", records[2].Sequence), res.Header().Get(exportHeaderUpTo))
+
+	assert.Equal(t, txIDs[0], records[0].Receipt.ID)
+	assert.True(t, records[0].Receipt.Success)
+	assert.Equal(t, "export_0", records[0].Transaction.IdempotencyKey)
+	require.Len(t, records[0].Public, 1)
+	assert.Len(t, records[0].Public[0].Submissions, 1)
+	assert.Nil(t, records[0].States)
+
+	assert.Equal(t, txIDs[1], records[1].Receipt.ID)
+	assert.Equal(t, "pop", records[1].Receipt.FailureMessage)
+	assert.Equal(t, "export_1", records[1].Transaction.IdempotencyKey)
+	assert.Nil(t, records[1].Public)
+
+	assert.Equal(t, txIDs[2], records[2].Receipt.ID)
+	assert.Nil(t, records[2].Transaction)
+	require.NotNil(t, records[2].States)
+	assert.Len(t, records[2].States.Confirmed, 1)
+
+	// Resume from the first record, limiting to one record, with only the receipts
+	res = httptest.NewRecorder()
+	txm.handleExport(res, httptest.NewRequest(http.MethodGet,
+		fmt.Sprintf("%s?after=%d&limit=1&include=", exportPathPrefix, records[0].Sequence), nil))
+	resumed := readNDJSONExport(t, res)
+	require.Len(t, resumed, 1)
+	assert.Equal(t, records[1].Sequence, resumed[0].Sequence)
+	assert.Nil(t, resumed[0].Transaction)
+}
+
+func TestExportExcludesUnsettledReceipts(t *testing.T) {
+	_, txm, _, _, done := setupExportTestData(t, 10)
+	defer done()
+
+	// The receipts have only just been indexed, so a lower sequence might still be uncommitted
+	txm.exportSettleTime = 1 * time.Hour
+
+	res := httptest.NewRecorder()
+	txm.handleExport(res, httptest.NewRequest(http.MethodGet, exportPathPrefix, nil))
+	assert.Empty(t, readNDJSONExport(t, res))
+	assert.Equal(t, "0", res.Header().Get(exportHeaderUpTo))
+
+	// Once they have settled, they are all exported
+	txm.exportSettleTime = 0
+	res = httptest.NewRecorder()
+	txm.handleExport(res, httptest.NewRequest(http.MethodGet, exportPathPrefix+"?include=public", nil))
+	assert.Len(t, readNDJSONExport(t, res), 3)
+}
+
+func TestExportCSVRealDB(t *testing.T) {
+	_, txm, _, txIDs, done := setupExportTestData(t, 1)
+	defer done()
+
+	res := httptest.NewRecorder()
+	txm.handleExport(res, httptest.NewRequest(http.MethodGet, exportPathPrefix+"?format=CSV&include=transaction,public", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", res.Header().Get("Content-Type"))
+
+	rows, err := csv.NewReader(res.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, exportCSVColumns, rows[0])
+	col := func(row []string, name string) string {
+		for i, c := range exportCSVColumns {
+			if c == name {
+				return row[i]
+			}
+		}
+		panic(name)
+	}
+
+	assert.Equal(t, txIDs[0].String(), col(rows[1], "id"))
+	assert.Equal(t, "true", col(rows[1], "success"))
+	assert.Equal(t, "12345", col(rows[1], "blockNumber"))
+	assert.Equal(t, "", col(rows[1], "logIndex"))
+	assert.Equal(t, "public", col(rows[1], "type"))
+	assert.Equal(t, "sender1@node1", col(rows[1], "from"))
+	assert.Contains(t, col(rows[1], "public"), "submissions")
+	assert.Equal(t, "", col(rows[1], "states"))
+
+	assert.Equal(t, txIDs[1].String(), col(rows[2], "id"))
+	assert.Equal(t, "pop", col(rows[2], "failureMessage"))
+	assert.Equal(t, "", col(rows[2], "transactionHash"))
+
+	assert.Equal(t, txIDs[2].String(), col(rows[3], "id"))
+	assert.Equal(t, "domain1", col(rows[3], "domain"))
+	assert.Equal(t, "5", col(rows[3], "logIndex"))
+	assert.Equal(t, "", col(rows[3], "type"))
+}
+
+func TestExportEmpty(t *testing.T) {
+	_, txm, done := newTestTransactionManager(t, true)
+	defer done()
+
+	res := httptest.NewRecorder()
+	txm.handleExport(res, httptest.NewRequest(http.MethodGet, exportPathPrefix+"?format=csv", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "0", res.Header().Get(exportHeaderUpTo))
+	assert.Equal(t, strings.Join(exportCSVColumns, ",")+"\n", res.Body.String())
+}
+
+func TestExportBadRequests(t *testing.T) {
+	_, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners)
+	defer done()
+
+	for path, errMatch := range map[string]string{
+		"?format=xml":         "PD012257",
+		"?after=-1":           "PD012258",
+		"?limit=wrong":        "PD012258",
+		"?include=everything": "PD012259",
+	} {
+		res := httptest.NewRecorder()
+		txm.handleExport(res, httptest.NewRequest(http.MethodGet, exportPathPrefix+path, nil))
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Regexp(t, errMatch, res.Body.String())
+	}
+
+	res := httptest.NewRecorder()
+	txm.handleExport(res, httptest.NewRequest(http.MethodPost, exportPathPrefix, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, res.Code)
+}
+
+func TestExportMaxSequenceFail(t *testing.T) {
+	_, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+		mc.db.ExpectQuery("SELECT MAX").WillReturnError(fmt.Errorf("pop"))
+	})
+	defer done()
+
+	res := httptest.NewRecorder()
+	txm.handleExport(res, httptest.NewRequest(http.MethodGet, exportPathPrefix, nil))
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+	assert.Regexp(t, "pop", res.Body.String())
+}
+
+func TestExportPageReadErrors(t *testing.T) {
+	for _, fail := range []string{"receipts", "transactions", "public", "states"} {
+		t.Run(fail, func(t *testing.T) {
+			_, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners, func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+				mc.db.ExpectQuery("SELECT MAX").WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(10))
+				if fail == "receipts" {
+					mc.db.ExpectQuery("SELECT.*transaction_receipts").WillReturnError(fmt.Errorf("pop"))
+					return
+				}
+				txID := uuid.New()
+				mc.db.ExpectQuery("SELECT.*transaction_receipts").WillReturnRows(sqlmock.NewRows([]string{"transaction", "sequence", "domain"}).AddRow(txID, 10, "domain1"))
+				if fail == "transactions" {
+					mc.db.ExpectQuery("SELECT.*transactions").WillReturnError(fmt.Errorf("pop"))
+					return
+				}
+				mc.db.ExpectQuery("SELECT.*transactions").WillReturnRows(sqlmock.NewRows([]string{}))
+				if fail == "public" {
+					mc.publicTxMgr.On("QueryPublicTxForTransactions", mock.Anything, mock.Anything, []uuid.UUID{txID}, mock.Anything).Return(nil, fmt.Errorf("pop"))
+					return
+				}
+				mc.publicTxMgr.On("QueryPublicTxForTransactions", mock.Anything, mock.Anything, []uuid.UUID{txID}, mock.Anything).Return(map[uuid.UUID][]*pldapi.PublicTx{}, nil)
+				mc.stateMgr.On("GetTransactionStatesBatch", mock.Anything, mock.Anything, []uuid.UUID{txID}).Return(nil, fmt.Errorf("pop"))
+			})
+			defer done()
+
+			// The status has been sent before the failure, so we just get an empty response
+			res := httptest.NewRecorder()
+			txm.handleExport(res, httptest.NewRequest(http.MethodGet, exportPathPrefix, nil))
+			assert.Equal(t, http.StatusOK, res.Code)
+			assert.Equal(t, "10", res.Header().Get(exportHeaderUpTo))
+			assert.Empty(t, res.Body.String())
+		})
+	}
+}
+
+func TestExportContext(t *testing.T) {
+	type ctxKey struct{}
+	reqCtx := context.WithValue(context.Background(), ctxKey{}, "value1")
+
+	// The request timeout does not stop the export
+	timeoutCtx, cancelTimeout := context.WithTimeout(reqCtx, 1*time.Millisecond)
+	defer cancelTimeout()
+	ctx, cancelCtx := exportContext(timeoutCtx)
+	<-timeoutCtx.Done()
+	assert.NoError(t, ctx.Err())
+	assert.Equal(t, "value1", ctx.Value(ctxKey{}))
+	cancelCtx()
+	assert.Error(t, ctx.Err())
+
+	// Cancellation of the request, such as the client disconnecting, does
+	disconnectCtx, disconnect := context.WithCancel(reqCtx)
+	ctx, cancelCtx = exportContext(disconnectCtx)
+	defer cancelCtx()
+	disconnect()
+	<-ctx.Done()
+}
@@ -41,6 +41,8 @@ var transactionFilters = filters.FieldMap{
 	"from":           filters.StringField(`"from"`),
 	"to":             filters.HexBytesField(`"to"`),
 	"type":           filters.StringField(`"type"`),
+	"notBefore":      filters.TimestampField("not_before"),
+	"expiresAt":      filters.TimestampField("expires_at"),
 }
 
 func (tm *txManager) mapPersistedTXBase(pt *persistedTransaction) *pldapi.Transaction {
@@ -65,6 +67,8 @@ func (tm *txManager) mapPersistedTXBase(pt *persistedTransaction) *pldapi.Transa
 func (tm *txManager) mapPersistedTXFull(pt *persistedTransaction) *pldapi.TransactionFull {
 	res := &pldapi.TransactionFull{
 		Transaction: tm.mapPersistedTXBase(pt),
+		NotBefore:   pt.NotBefore,
+		ExpiresAt:   pt.ExpiresAt,
 	}
 	receipt := pt.TransactionReceipt
 	if receipt != nil {
@@ -105,6 +109,8 @@ func (tm *txManager) mapPersistedTXHistory(pth *persistedTransactionHistory) *pl
 func (tm *txManager) mapPersistedTXResolved(pt *persistedTransaction) *components.ResolvedTransaction {
 	res := &components.ResolvedTransaction{
 		Transaction: tm.mapPersistedTXBase(pt),
+		NotBefore:   pt.NotBefore,
+		ExpiresAt:   pt.ExpiresAt,
 	}
 	for _, dep := range pt.TransactionDeps {
 		res.DependsOn = append(res.DependsOn, dep.DependsOn)
@@ -53,6 +53,8 @@ type persistedTransaction struct {
 	From               string                                `gorm:"column:from"`
 	To                 *pldtypes.EthAddress                  `gorm:"column:to"`
 	Data               pldtypes.RawJSON                      `gorm:"column:data"` // we always store in JSON object format
+	NotBefore          *pldtypes.Timestamp                   `gorm:"column:not_before"`
+	ExpiresAt          *pldtypes.Timestamp                   `gorm:"column:expires_at"`
 	TransactionDeps    []*transactionDep                     `gorm:"foreignKey:transaction;references:id"`
 	TransactionReceipt *transactionReceipt                   `gorm:"foreignKey:transaction;references:id"`
 }
@@ -442,6 +444,8 @@ func (tm *txManager) processNewTransactions(ctx context.Context, dbTX persistenc
 					Data:            txi.PublicTxData,
 					PublicTxOptions: tx.PublicTxOptions,
 				},
+				NotBefore: tx.NotBefore,
+				ExpiresAt: tx.ExpiresAt,
 			})
 			publicTxSenders = append(publicTxSenders, txi.LocalFrom)
 		}
@@ -550,6 +554,9 @@ func (tm *txManager) resolveNewTransaction(ctx context.Context, dbTX persistence
 		if err := tm.resolvePrivateDomain(ctx, dbTX, tx); err != nil {
 			return nil, err
 		}
+		if tx.To == nil && (tx.NotBefore != nil || tx.ExpiresAt != nil) {
+			return nil, i18n.NewError(ctx, msgs.MsgTxMgrScheduleNotSupportedPrivateDeploy)
+		}
 	case pldapi.TransactionTypePublic:
 		if submitMode == pldapi.SubmitModeExternal {
 			return nil, i18n.NewError(ctx, msgs.MsgTxMgrPrivateOnlyForPrepare)
@@ -558,6 +565,10 @@ func (tm *txManager) resolveNewTransaction(ctx context.Context, dbTX persistence
 		return nil, i18n.NewError(ctx, msgs.MsgTxMgrInvalidTXType)
 	}
 
+	if tx.NotBefore != nil && tx.ExpiresAt != nil && *tx.ExpiresAt <= *tx.NotBefore {
+		return nil, i18n.NewError(ctx, msgs.MsgTxMgrExpiryNotAfterNotBefore, tx.ExpiresAt, tx.NotBefore)
+	}
+
 	var publicTxData []byte
 	fn, cv, normalizedJSON, err := tm.ResolveTransactionInputs(ctx, dbTX, tx)
 	if err == nil && tx.Type.V() == pldapi.TransactionTypePublic {
@@ -604,6 +615,8 @@ func (tm *txManager) resolveNewTransaction(ctx context.Context, dbTX persistence
 				SubmitMode:      submitMode.Enum(),
 			},
 			DependsOn: tx.DependsOn,
+			NotBefore: tx.NotBefore,
+			ExpiresAt: tx.ExpiresAt,
 			Function:  fn,
 		},
 		PublicTxData: publicTxData,
@@ -672,6 +685,8 @@ func (tm *txManager) insertTransactions(ctx context.Context, dbTX persistence.DB
 			From:           tx.From,
 			To:             tx.To,
 			Data:           tx.Data,
+			NotBefore:      txi.NotBefore,
+			ExpiresAt:      txi.ExpiresAt,
 		}
 		for _, d := range txi.DependsOn {
 			transactionDeps = append(transactionDeps, &transactionDep{
@@ -733,6 +748,8 @@ func (tm *txManager) insertTransactions(ctx context.Context, dbTX persistence.DB
 				tm.txCache.Set(*tx.Transaction.ID, &components.ResolvedTransaction{
 					Transaction: tx.Transaction,
 					DependsOn:   tx.DependsOn,
+					NotBefore:   tx.NotBefore,
+					ExpiresAt:   tx.ExpiresAt,
 					Function:    tx.Function,
 				})
 			}
@@ -596,6 +596,40 @@ func TestParseInputsPrivateDeployNoDomain(t *testing.T) {
 	assert.Regexp(t, "PD012232", err)
 }
 
+func TestParseInputsPrivateDeployScheduleNotSupported(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false,
+		mockEmptyReceiptListeners,
+		mockBeginRollback)
+	defer done()
+
+	_, err := txm.sendTransactionNewDBTX(ctx, &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			Type:   pldapi.TransactionTypePrivate.Enum(),
+			Domain: "domain1",
+		},
+		ExpiresAt: confutil.P(pldtypes.TimestampNow()),
+	})
+	assert.Regexp(t, "PD012256", err)
+}
+
+func TestParseInputsExpiresBeforeNotBefore(t *testing.T) {
+	ctx, txm, done := newTestTransactionManager(t, false,
+		mockEmptyReceiptListeners,
+		mockBeginRollback)
+	defer done()
+
+	now := pldtypes.TimestampNow()
+	_, err := txm.sendTransactionNewDBTX(ctx, &pldapi.TransactionInput{
+		TransactionBase: pldapi.TransactionBase{
+			Type: pldapi.TransactionTypePublic.Enum(),
+			To:   pldtypes.RandAddress(),
+		},
+		NotBefore: &now,
+		ExpiresAt: &now,
+	})
+	assert.Regexp(t, "PD012254", err)
+}
+
 func TestParseInputsBadFromRemoteNode(t *testing.T) {
 	ctx, txm, done := newTestTransactionManager(t, false,
 		mockEmptyReceiptListeners,
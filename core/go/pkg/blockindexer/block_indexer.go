@@ -54,6 +54,7 @@ type BlockIndexer interface {
 	QueryEventStreamDefinitions(ctx context.Context, dbTX persistence.DBTX, esType pldtypes.Enum[EventStreamType], jq *query.QueryJSON) ([]*EventStream, error)
 	StartEventStream(ctx context.Context, id uuid.UUID) error
 	StopEventStream(ctx context.Context, id uuid.UUID) error
+	ResetEventStreamCheckpoint(ctx context.Context, id uuid.UUID, blockNumber int64) error
 	GetIndexedBlockByNumber(ctx context.Context, number uint64) (*pldapi.IndexedBlock, error)
 	GetIndexedTransactionByHash(ctx context.Context, hash pldtypes.Bytes32) (*pldapi.IndexedTransaction, error)
 	GetIndexedTransactionByNonce(ctx context.Context, from pldtypes.EthAddress, nonce uint64) (*pldapi.IndexedTransaction, error)
@@ -325,6 +326,7 @@ func (bi *blockIndexer) notificationProcessor(ctx context.Context) {
 		select {
 		case block := <-bi.blockListener.channel():
 			bi.processBlockNotification(ctx, block)
+			bi.notifyHeadEventStreams(ctx, block)
 		case <-ctx.Done():
 			log.L(ctx).Debugf("Confirmed block listener stopping")
 			return
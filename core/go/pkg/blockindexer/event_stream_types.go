@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
@@ -33,9 +34,13 @@ import (
 )
 
 type EventStreamConfig struct {
-	BatchSize    *int            `json:"batchSize,omitempty"`
-	BatchTimeout *string         `json:"batchTimeout,omitempty"`
-	FromBlock    json.RawMessage `json:"fromBlock,omitempty"`
+	BatchSize           *int                                                     `json:"batchSize,omitempty"`
+	BatchTimeout        *string                                                  `json:"batchTimeout,omitempty"`
+	FromBlock           json.RawMessage                                          `json:"fromBlock,omitempty"`
+	Webhook             *pldapi.ListenerWebhook                                  `json:"webhook,omitempty"`         // not used by the block indexer, stored for the PTX blockchain event listener
+	MaxRedeliveries     *int                                                     `json:"maxRedeliveries,omitempty"` // not used by the block indexer, stored for the PTX blockchain event listener
+	Unconfirmed         *bool                                                    `json:"unconfirmed,omitempty"`     // also deliver events from the head of the chain, before they are confirmed
+	DecodeFailurePolicy pldtypes.Enum[pldapi.BlockchainEventDecodeFailurePolicy] `json:"decodeFailurePolicy,omitempty"`
 }
 
 var EventStreamDefaults = &EventStreamConfig{
@@ -92,6 +97,13 @@ func (ess EventSources) Hash(ctx context.Context) (*pldtypes.Bytes32, error) {
 		} else {
 			sourceHashes[i] = fmt.Sprintf("*:%s", hash)
 		}
+		// The filters are only factored in when set, so the hash of existing sources does not change
+		if s.Factory != nil && s.Factory.Event != nil {
+			sourceHashes[i] += fmt.Sprintf(":factory=%s/%s/%s", &s.Factory.Address, s.Factory.Event.SolString(), s.Factory.AddressParam)
+		}
+		if len(s.Topics) > 0 {
+			sourceHashes[i] += ":topics=" + topicFiltersHashString(s.Topics)
+		}
 	}
 	sort.Strings(sourceHashes)
 	hash := sha3.NewLegacyKeccak256()
@@ -103,9 +115,33 @@ func (ess EventSources) Hash(ctx context.Context) (*pldtypes.Bytes32, error) {
 	return &h32, nil
 }
 
+// Each value is normalized to the full topic it matches, and sorted within its position, so equivalent
+// filters have the same hash
+func topicFiltersHashString(filters [][]pldtypes.HexBytes) string {
+	positions := make([]string, len(filters))
+	for i, values := range filters {
+		topics := make([]string, len(values))
+		for j, v := range values {
+			topics[j] = topicFilterValue(v).String()
+		}
+		sort.Strings(topics)
+		positions[i] = "[" + strings.Join(topics, ",") + "]"
+	}
+	return strings.Join(positions, ",")
+}
+
 type EventStreamSource struct {
-	ABI     abi.ABI              `json:"abi,omitempty"`
-	Address *pldtypes.EthAddress `json:"address,omitempty"` // optional
+	ABI     abi.ABI                                `json:"abi,omitempty"`
+	Address *pldtypes.EthAddress                   `json:"address,omitempty"` // optional
+	Topics  [][]pldtypes.HexBytes                  `json:"topics,omitempty"`  // optional filters on the indexed topics after the signature
+	Factory *pldapi.BlockchainEventListenerFactory `json:"factory,omitempty"` // optional - matches any contract deployed by the factory
+}
+
+type EventStreamDeployment struct {
+	Stream      uuid.UUID           `json:"stream"             gorm:"primaryKey"`
+	Factory     pldtypes.EthAddress `json:"factory"            gorm:"primaryKey"`
+	Address     pldtypes.EthAddress `json:"address"            gorm:"primaryKey"`
+	BlockNumber int64               `json:"blockNumber"`
 }
 
 type EventStreamCheckpoint struct {
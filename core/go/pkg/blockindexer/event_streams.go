@@ -18,6 +18,9 @@ package blockindexer
 
 import (
 	"context"
+	"fmt"
+	"math/big"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -59,10 +62,28 @@ type eventStream struct {
 	fromBlock         *ethtypes.HexUint64 // nil == latest
 	checkpoint        atomic.Int64        // set after we persist checkpoint
 	catchup           atomic.Bool
+	decodeFailure     pldapi.BlockchainEventDecodeFailurePolicy
+
+	// contracts deployed by the factories in our sources, keyed by factory address
+	deploymentsLock sync.Mutex
+	deployments     map[pldtypes.EthAddress]map[pldtypes.EthAddress]bool
+	// deployments seen at the head of the chain in blocks that are not yet confirmed
+	headDeployments map[pldtypes.EthAddress]map[pldtypes.EthAddress]headDeployment
+
+	// only used when the stream is configured to deliver unconfirmed events from the head of the chain
+	unconfirmed         bool
+	headBlocks          chan *BlockInfoJSONRPC
+	headDetectorDone    chan struct{}
+	headLock            sync.Mutex
+	headDelivered       []*unconfirmedBlock
+	headConfirmed       int64
+	headConfirmedAtHead bool
 }
 
 type eventBatch struct {
 	EventDeliveryBatch
+	noCheckpoint         bool // only unconfirmed events are in the batch
+	deliveredAtHead      int  // confirmed events not in the batch, as they were delivered from the head of the chain
 	checkpointAfterBatch int64
 	opened               time.Time
 	timeoutContext       context.Context
@@ -70,8 +91,10 @@ type eventBatch struct {
 }
 
 type eventDispatch struct {
-	event       *pldapi.EventWithData
-	lastInBlock bool
+	event           *pldapi.EventWithData
+	lastInBlock     bool
+	unconfirmed     bool // delivered from the head of the chain (or a removal notification), so does not move the checkpoint
+	deliveredAtHead bool // confirmed, but already delivered from the head of the chain, so only moves the checkpoint
 }
 
 // event streams get notified of every confirmed block to process the data in that block,
@@ -144,6 +167,16 @@ func (bi *blockIndexer) upsertInternalEventStream(ctx context.Context, dbTX pers
 		return nil, err
 	}
 
+	// Validate the decode failure policy
+	if _, err := def.Config.DecodeFailurePolicy.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Validate the filters on the sources
+	if err := validateEventStreamSources(ctx, def.Sources); err != nil {
+		return nil, err
+	}
+
 	// Find if one exists - as we need to check it matches, and get its uuid
 	var existing []*EventStream
 	err := dbTX.DB().
@@ -169,7 +202,9 @@ func (bi *blockIndexer) upsertInternalEventStream(ctx context.Context, dbTX pers
 			); err != nil {
 				return nil, err
 			}
-			if !existing[0].Sources[i].Address.Equals(def.Sources[i].Address) {
+			if !existing[0].Sources[i].Address.Equals(def.Sources[i].Address) ||
+				pldtypes.JSONString(existing[0].Sources[i].Topics).String() != pldtypes.JSONString(def.Sources[i].Topics).String() ||
+				pldtypes.JSONString(existing[0].Sources[i].Factory).String() != pldtypes.JSONString(def.Sources[i].Factory).String() {
 				return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerESSourceError)
 			}
 		}
@@ -206,6 +241,44 @@ func (bi *blockIndexer) upsertInternalEventStream(ctx context.Context, dbTX pers
 	return bi.initEventStreamNOTX(ctx, def, ies.HandlerNOTX), nil
 }
 
+func validateEventStreamSources(ctx context.Context, sources EventSources) error {
+	for i, source := range sources {
+		// The signature is always the first topic, so there are at most 3 indexed topics
+		if len(source.Topics) > 3 {
+			return i18n.NewError(ctx, msgs.MsgBlockIndexerESTopicFilterTooLong, i, len(source.Topics))
+		}
+		for position, values := range source.Topics {
+			for _, v := range values {
+				if len(v) > 32 {
+					return i18n.NewError(ctx, msgs.MsgBlockIndexerESTopicFilterInvalid, v, position, i)
+				}
+			}
+		}
+		if source.Factory != nil {
+			if source.Address != nil {
+				return i18n.NewError(ctx, msgs.MsgBlockIndexerESFactoryWithAddress, i)
+			}
+			if factoryAddressParam(source.Factory) < 0 {
+				return i18n.NewError(ctx, msgs.MsgBlockIndexerESFactoryEventInvalid, i, source.Factory.AddressParam)
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the index of the address parameter in the factory event, or -1 if it does not have one
+func factoryAddressParam(factory *pldapi.BlockchainEventListenerFactory) int {
+	if factory.Event == nil || factory.Event.Type != abi.Event {
+		return -1
+	}
+	for i, input := range factory.Event.Inputs {
+		if input.Name == factory.AddressParam && input.Type == "address" {
+			return i
+		}
+	}
+	return -1
+}
+
 func (bi *blockIndexer) initEventStreamNOTX(ctx context.Context, definition *EventStream, handlerNOTX InternalStreamCallbackNOTX) *eventStream {
 	bi.eventStreamsLock.Lock()
 	defer bi.eventStreamsLock.Unlock()
@@ -237,13 +310,16 @@ func (bi *blockIndexer) initEventStream(ctx context.Context, definition *EventSt
 		es.definition.Config = definition.Config
 	} else {
 		es = &eventStream{
-			bi:         bi,
-			definition: definition,
-			signatures: make(map[string]bool),
-			blocks:     make(chan *eventStreamBlock, bi.esBlockDispatchQueueLength),
-			dispatch:   make(chan *eventDispatch, batchSize),
-			serializer: definition.Format.GetABISerializerIgnoreErrors(ctx),
+			bi:          bi,
+			definition:  definition,
+			signatures:  make(map[string]bool),
+			blocks:      make(chan *eventStreamBlock, bi.esBlockDispatchQueueLength),
+			headBlocks:  make(chan *BlockInfoJSONRPC, bi.esBlockDispatchQueueLength),
+			dispatch:    make(chan *eventDispatch, batchSize),
+			serializer:  definition.Format.GetABISerializerIgnoreErrors(ctx),
+			deployments: make(map[pldtypes.EthAddress]map[pldtypes.EthAddress]bool),
 		}
+		es.headConfirmed = -1
 	}
 
 	// Set the batch config
@@ -253,6 +329,14 @@ func (bi *blockIndexer) initEventStream(ctx context.Context, definition *EventSt
 	es.fromBlock, _ = es.bi.getFromBlock(ctx, definition.Config.FromBlock, EventStreamDefaults.FromBlock)
 	es.checkpoint.Store(-1)
 	es.catchup.Store(true)
+	es.unconfirmed = definition.Config.Unconfirmed != nil && *definition.Config.Unconfirmed
+	// The error is already checked before writing to the DB
+	es.decodeFailure, _ = definition.Config.DecodeFailurePolicy.Validate()
+	if es.unconfirmed {
+		bi.eventStreamsHeadSet[definition.ID] = es
+	} else {
+		delete(bi.eventStreamsHeadSet, definition.ID)
+	}
 
 	// Calculate all the signatures we require
 	for _, source := range definition.Sources {
@@ -262,19 +346,27 @@ func (bi *blockIndexer) initEventStream(ctx context.Context, definition *EventSt
 			location = source.Address.String()
 		}
 
+		addSignature := func(abiEntry *abi.Entry) {
+			sig := pldtypes.NewBytes32FromSlice(abiEntry.SignatureHashBytes())
+			sigStr := sig.String()
+			if _, dup := es.signatures[sigStr]; !dup {
+				es.signatures[sigStr] = true
+				solStrings = append(solStrings, abiEntry.SolString())
+				es.signatureList = append(es.signatureList, sig)
+			}
+		}
 		for _, abiEntry := range source.ABI {
 			if abiEntry.Type == abi.Event {
-				sig := pldtypes.NewBytes32FromSlice(abiEntry.SignatureHashBytes())
-				sigStr := sig.String()
-				if _, dup := es.signatures[sigStr]; !dup {
-					es.signatures[sigStr] = true
-					solStrings = append(solStrings, abiEntry.SolString())
-					es.signatureList = append(es.signatureList, sig)
-				}
+				addSignature(abiEntry)
 			}
 		}
+		// We also need the deployment events from the factory, to know which contracts it has deployed
+		if source.Factory != nil && source.Factory.Event != nil {
+			location = fmt.Sprintf("factory:%s", &source.Factory.Address)
+			addSignature(source.Factory.Event)
+		}
 
-		log.L(ctx).Infof("Event stream %s configured address=%s events=%s", es.definition.ID, location, solStrings)
+		log.L(ctx).Infof("Event stream %s configured address=%s events=%s topics=%v", es.definition.ID, location, solStrings, source.Topics)
 	}
 
 	// ok - all looks good, put ourselves in the blockindexer list
@@ -341,6 +433,32 @@ func (bi *blockIndexer) StopEventStream(ctx context.Context, id uuid.UUID) error
 	return bi.eventStreams[id].stop(true)
 }
 
+// Moves the checkpoint of an event stream, so that delivery resumes from the block after the
+// supplied block number. If the stream is running it is stopped while the checkpoint is updated,
+// and restarted afterwards so it catches up from the new checkpoint.
+func (bi *blockIndexer) ResetEventStreamCheckpoint(ctx context.Context, id uuid.UUID, blockNumber int64) error {
+	bi.eventStreamsLock.Lock()
+	defer bi.eventStreamsLock.Unlock()
+
+	es := bi.eventStreams[id]
+	if es == nil {
+		return i18n.NewError(ctx, msgs.MsgBlockIndexerEventStreamNotFound, id)
+	}
+
+	wasRunning := es.detectorDone != nil
+	// no possibility of error if not updating DB
+	_ = es.stop(false)
+	log.L(ctx).Infof("Resetting checkpoint of event stream %s [%s] to block %d", es.definition.Name, id, blockNumber)
+	es.resetHead()
+	err := es.updateCheckpoint(ctx, bi.persistence.NOTX(), blockNumber)
+	if wasRunning {
+		if startErr := es.start(false); err == nil {
+			err = startErr
+		}
+	}
+	return err
+}
+
 func (bi *blockIndexer) GetEventStreamStatus(ctx context.Context, id uuid.UUID) (*EventStreamStatus, error) {
 	bi.eventStreamsLock.Lock()
 	defer bi.eventStreamsLock.Unlock()
@@ -400,6 +518,10 @@ func (es *eventStream) start(updateDB bool) error {
 		es.dispatcherStarted = make(chan struct{})
 		go es.detector()
 		go es.dispatcher()
+		if es.unconfirmed {
+			es.headDetectorDone = make(chan struct{})
+			go es.headDetector()
+		}
 	}
 	return nil
 }
@@ -428,6 +550,10 @@ func (es *eventStream) stop(updateDB bool) error {
 		<-es.dispatcherDone
 		es.dispatcherDone = nil
 	}
+	if es.headDetectorDone != nil {
+		<-es.headDetectorDone
+		es.headDetectorDone = nil
+	}
 	return nil
 }
 
@@ -498,6 +624,9 @@ func (es *eventStream) detector() {
 	// but never writes it back.
 	// The checkpoint is updated on the dispatcher after each batch is confirmed downstream.
 	checkpointBlock, err := es.processCheckpoint()
+	if err == nil {
+		err = es.loadDeployments()
+	}
 	if err != nil {
 		log.L(es.ctx).Debugf("exiting before retrieving checkpoint")
 		close(es.detectorStarted)
@@ -520,7 +649,10 @@ func (es *eventStream) detector() {
 		select {
 		case block := <-es.blocks:
 			checkpointBlock = confutil.P(int64(block.block.Number))
-			es.processNotifiedBlock(block, true)
+			if err := es.processNotifiedBlock(block, true); err != nil {
+				log.L(es.ctx).Errorf("event stream halted: %s", err)
+				return
+			}
 		case <-es.ctx.Done():
 			log.L(es.ctx).Debugf("exiting")
 			return
@@ -552,8 +684,12 @@ func (es *eventStream) detector() {
 				}
 				if block.block.Number == ethtypes.HexUint64(*checkpointBlock+1) {
 					// Happy place
+					es.catchup.Store(false)
 					checkpointBlock = confutil.P(int64(block.block.Number))
-					es.processNotifiedBlock(block, true)
+					if err := es.processNotifiedBlock(block, true); err != nil {
+						log.L(es.ctx).Errorf("event stream halted: %s", err)
+						return
+					}
 				} else {
 					// Entering catchup - defer processing of this block until catchup complete,
 					// and we won't pick up anything else off the channel until then
@@ -575,7 +711,7 @@ func (es *eventStream) detector() {
 			var caughtUp bool
 			caughtUp, lastCatchupEvent, err = es.processCatchupEventPage(lastCatchupEvent, *checkpointBlock, catchUpToBlockNumber)
 			if err != nil {
-				log.L(es.ctx).Debugf("exiting during catchup phase")
+				log.L(es.ctx).Debugf("exiting during catchup phase: %s", err)
 				return
 			}
 			if caughtUp {
@@ -583,7 +719,10 @@ func (es *eventStream) detector() {
 				lastCatchupEvent = nil
 				if startupBlock == nil {
 					// Process the deferred notified block, and back to normal operation
-					es.processNotifiedBlock(catchUpToBlock, true)
+					if err := es.processNotifiedBlock(catchUpToBlock, true); err != nil {
+						log.L(es.ctx).Errorf("event stream halted: %s", err)
+						return
+					}
 					checkpointBlock = confutil.P(int64(catchUpToBlock.block.Number))
 					catchUpToBlock = nil
 				} else {
@@ -596,29 +735,263 @@ func (es *eventStream) detector() {
 	}
 }
 
-func (es *eventStream) processNotifiedBlock(block *eventStreamBlock, fullBlock bool) {
+func (es *eventStream) processNotifiedBlock(block *eventStreamBlock, fullBlock bool) error {
+	indexedBlock := es.bi.blockInfoToIndexedBlock(block.block)
+	deliveredAtHead := es.unconfirmed && es.reconcileConfirmedBlock(indexedBlock)
 	for i, l := range block.events {
-		indexedEvent := es.bi.logToIndexedEvent(l)
-		indexedEvent.Block = es.bi.blockInfoToIndexedBlock(block.block)
-		event := &pldapi.EventWithData{
-			IndexedEvent: indexedEvent,
+		if err := es.detectDeployment(l, true); err != nil {
+			return err
 		}
 		// Only dispatch events that were completed by the validation against our ABI
-		for _, source := range es.definition.Sources {
-			if es.bi.matchLog(es.ctx, source.ABI, l, event, source.Address, es.serializer) {
-				es.sendToDispatcher(event,
-					// Can only move checkpoint past this block once we know we've processed the last one
-					fullBlock && i == (len(block.events)-1))
+		event, err := es.matchEvent(indexedBlock, l)
+		if err != nil {
+			return err
+		}
+		if event != nil {
+			es.sendToDispatcher(&eventDispatch{
+				event: event,
+				// Can only move checkpoint past this block once we know we've processed the last one
+				lastInBlock:     fullBlock && i == (len(block.events)-1),
+				deliveredAtHead: deliveredAtHead,
+			})
+		}
+	}
+	return nil
+}
+
+// The sources are evaluated in order, and the first that the log matches against the address, the
+// topic filters, and the ABI is used to decode it.
+// If the log matches the address and the topic filters of a source, and the signature of one of the
+// events in its ABI, but no source can decode it - then the decode failure policy of the stream applies.
+func (es *eventStream) matchEvent(block *pldapi.IndexedBlock, l *LogJSONRPC) (*pldapi.EventWithData, error) {
+	indexedEvent := es.bi.logToIndexedEvent(l)
+	indexedEvent.Block = block
+	event := &pldapi.EventWithData{
+		IndexedEvent: indexedEvent,
+	}
+	decodeFailure := false
+	for _, source := range es.definition.Sources {
+		if !es.matchSourceAddress(&source, l) || !matchTopicFilters(source.Topics, l.Topics) {
+			continue
+		}
+		if es.bi.matchLog(es.ctx, source.ABI, l, event, source.Address, es.serializer) {
+			return event, nil
+		}
+		decodeFailure = decodeFailure || abiHasSignature(source.ABI, indexedEvent.Signature)
+	}
+	if !decodeFailure {
+		return nil, nil
+	}
+	switch es.decodeFailure {
+	case pldapi.BlockchainEventDecodeFailurePolicyDeliver:
+		log.L(es.ctx).Warnf("Delivering event %d/%d/%d (tx=%s,address=%s) that could not be decoded", l.BlockNumber, l.TransactionIndex, l.LogIndex, l.TransactionHash, l.Address)
+		event.SoliditySignature = ""
+		if l.Address != nil {
+			event.Address = pldtypes.EthAddress(*l.Address)
+		}
+		return event, nil
+	case pldapi.BlockchainEventDecodeFailurePolicyHalt:
+		return nil, i18n.NewError(es.ctx, msgs.MsgBlockIndexerESDecodeFailure, l.BlockNumber, l.TransactionIndex, l.LogIndex, l.TransactionHash, l.Address)
+	default:
+		log.L(es.ctx).Debugf("Skipping event %d/%d/%d (tx=%s,address=%s) that could not be decoded", l.BlockNumber, l.TransactionIndex, l.LogIndex, l.TransactionHash, l.Address)
+		return nil, nil
+	}
+}
+
+func (es *eventStream) matchSourceAddress(source *EventStreamSource, l *LogJSONRPC) bool {
+	if source.Factory == nil {
+		return source.Address.IsZero() || source.Address.Equals((*pldtypes.EthAddress)(l.Address))
+	}
+	if l.Address == nil {
+		return false
+	}
+	address := pldtypes.EthAddress(*l.Address)
+	es.deploymentsLock.Lock()
+	defer es.deploymentsLock.Unlock()
+	_, atHead := es.headDeployments[source.Factory.Address][address]
+	return atHead || es.deployments[source.Factory.Address][address]
+}
+
+// Each position in the filters applies to the indexed topic in the same position after the signature.
+// Any of the values in a position can match, and an empty position matches anything.
+func matchTopicFilters(filters [][]pldtypes.HexBytes, topics []ethtypes.HexBytes0xPrefix) bool {
+	for i, values := range filters {
+		if len(values) == 0 {
+			continue
+		}
+		if len(topics) <= i+1 {
+			return false
+		}
+		matched := false
+		for _, v := range values {
+			if topicFilterValue(v) == pldtypes.NewBytes32FromSlice(topics[i+1]) {
+				matched = true
 				break
 			}
 		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// Values shorter than a topic are left padded, so addresses and small integers can be supplied as-is
+func topicFilterValue(v pldtypes.HexBytes) (topic pldtypes.Bytes32) {
+	if len(v) <= len(topic) {
+		copy(topic[len(topic)-len(v):], v)
 	}
+	return topic
 }
 
-func (es *eventStream) sendToDispatcher(event *pldapi.EventWithData, lastInBlock bool) {
-	log.L(es.ctx).Debugf("passing event to dispatcher %d/%d/%d (tx=%s,address=%s)", event.BlockNumber, event.TransactionIndex, event.LogIndex, event.TransactionHash, &event.Address)
+func abiHasSignature(a abi.ABI, sig pldtypes.Bytes32) bool {
+	for _, abiEntry := range a {
+		if abiEntry.Type == abi.Event && pldtypes.NewBytes32FromSlice(abiEntry.SignatureHashBytes()) == sig {
+			return true
+		}
+	}
+	return false
+}
+
+func (es *eventStream) hasFactories() bool {
+	for _, source := range es.definition.Sources {
+		if source.Factory != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (es *eventStream) loadDeployments() error {
+	if !es.hasFactories() {
+		return nil
+	}
+	var deployments []*EventStreamDeployment
+	err := es.bi.retry.Do(es.ctx, func(attempt int) (retryable bool, err error) {
+		return true, es.bi.persistence.DB().
+			Table("event_stream_deployments").
+			Where("stream = ?", es.definition.ID).
+			WithContext(es.ctx).
+			Find(&deployments).
+			Error
+	})
+	if err != nil {
+		return err
+	}
+	log.L(es.ctx).Infof("loaded %d contracts deployed by factories", len(deployments))
+	for _, d := range deployments {
+		es.addDeployment(d.Factory, d.Address)
+	}
+	return nil
+}
+
+func (es *eventStream) addDeployment(factory, address pldtypes.EthAddress) {
+	es.deploymentsLock.Lock()
+	defer es.deploymentsLock.Unlock()
+	if es.deployments[factory] == nil {
+		es.deployments[factory] = make(map[pldtypes.EthAddress]bool)
+	}
+	es.deployments[factory][address] = true
+}
+
+// The block a deployment was seen in at the head of the chain
+type headDeployment struct {
+	number int64
+	hash   pldtypes.Bytes32
+}
+
+func (es *eventStream) addHeadDeployment(factory, address pldtypes.EthAddress, l *LogJSONRPC) {
+	es.deploymentsLock.Lock()
+	defer es.deploymentsLock.Unlock()
+	if es.headDeployments == nil {
+		es.headDeployments = make(map[pldtypes.EthAddress]map[pldtypes.EthAddress]headDeployment)
+	}
+	if es.headDeployments[factory] == nil {
+		es.headDeployments[factory] = make(map[pldtypes.EthAddress]headDeployment)
+	}
+	es.headDeployments[factory][address] = headDeployment{
+		number: int64(l.BlockNumber),
+		hash:   pldtypes.NewBytes32FromSlice(l.BlockHash),
+	}
+}
+
+// Removes the deployments seen at the head of the chain that the remove function selects, which is
+// used to roll them back when their block is replaced, and to discard them once their block is confirmed
+// (at which point the deployments in the confirmed block have been recorded).
+func (es *eventStream) removeHeadDeployments(remove func(d headDeployment) bool) {
+	es.deploymentsLock.Lock()
+	defer es.deploymentsLock.Unlock()
+	for factory, deployed := range es.headDeployments {
+		for address, d := range deployed {
+			if remove(d) {
+				log.L(es.ctx).Infof("Removing contract %s deployed by factory %s in block %d/%s", &address, &factory, d.number, d.hash)
+				delete(deployed, address)
+			}
+		}
+		if len(deployed) == 0 {
+			delete(es.headDeployments, factory)
+		}
+	}
+}
+
+// Checks whether the log is a deployment event from one of the factories in our sources, and if
+// so records the deployed contract. Deployments are only persisted once confirmed.
+//
+// Deployments detected at the head of the chain are recorded against their block, and removed if
+// that block is replaced by a re-org.
+func (es *eventStream) detectDeployment(l *LogJSONRPC, confirmed bool) error {
+	if l.Address == nil {
+		return nil
+	}
+	for _, source := range es.definition.Sources {
+		factory := source.Factory
+		if factory == nil || !factory.Address.Equals((*pldtypes.EthAddress)(l.Address)) {
+			continue
+		}
+		iParam := factoryAddressParam(factory)
+		if iParam < 0 {
+			continue
+		}
+		cv, err := factory.Event.DecodeEventDataCtx(es.ctx, l.Topics, l.Data)
+		if err != nil {
+			log.L(es.ctx).Tracef("Event %d/%d/%d from factory %s is not a deployment event: %s", l.BlockNumber, l.TransactionIndex, l.LogIndex, l.Address, err)
+			continue
+		}
+		var deployed pldtypes.EthAddress
+		if addr, ok := cv.Children[iParam].Value.(*big.Int); ok {
+			addr.FillBytes(deployed[:])
+		}
+		log.L(es.ctx).Infof("Factory %s deployed contract %s in block %d (confirmed=%t)", &factory.Address, &deployed, l.BlockNumber, confirmed)
+		if !confirmed {
+			es.addHeadDeployment(factory.Address, deployed, l)
+			continue
+		}
+		es.addDeployment(factory.Address, deployed)
+		err = es.bi.retry.Do(es.ctx, func(attempt int) (retryable bool, err error) {
+			return true, es.bi.persistence.DB().
+				Table("event_stream_deployments").
+				WithContext(es.ctx).
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&EventStreamDeployment{
+					Stream:      es.definition.ID,
+					Factory:     factory.Address,
+					Address:     deployed,
+					BlockNumber: int64(l.BlockNumber),
+				}).
+				Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (es *eventStream) sendToDispatcher(d *eventDispatch) {
+	event := d.event
+	log.L(es.ctx).Debugf("passing event to dispatcher %d/%d/%d (tx=%s,address=%s,unconfirmed=%t,removed=%t)", event.BlockNumber, event.TransactionIndex, event.LogIndex, event.TransactionHash, &event.Address, d.unconfirmed, event.Removed)
 	select {
-	case es.dispatch <- &eventDispatch{event, lastInBlock}:
+	case es.dispatch <- d:
 	case <-es.ctx.Done():
 	}
 }
@@ -648,20 +1021,28 @@ func (es *eventStream) dispatcher() {
 						StreamName: es.definition.Name,
 						BatchID:    uuid.New(),
 					},
-					opened: time.Now(),
+					opened:       time.Now(),
+					noCheckpoint: true,
 				}
 				batch.timeoutContext, batch.timeoutCancel = context.WithTimeout(es.ctx, es.batchTimeout)
 			}
 			event := d.event
-			if d.lastInBlock {
-				// We know we can move our checkpoint now to this block, as we've processed the last event in it
-				batch.checkpointAfterBatch = d.event.BlockNumber
-			} else if d.event.BlockNumber > 0 {
-				// Otherwise we have to set our checkpoint one behind
-				batch.checkpointAfterBatch = d.event.BlockNumber - 1
+			if !d.unconfirmed {
+				batch.noCheckpoint = false
+				if d.lastInBlock {
+					// We know we can move our checkpoint now to this block, as we've processed the last event in it
+					batch.checkpointAfterBatch = d.event.BlockNumber
+				} else if d.event.BlockNumber > 0 {
+					// Otherwise we have to set our checkpoint one behind
+					batch.checkpointAfterBatch = d.event.BlockNumber - 1
+				}
+			}
+			if d.deliveredAtHead {
+				batch.deliveredAtHead++
+			} else {
+				batch.Events = append(batch.Events, event)
+				l.Debugf("Added event %d/%d/%d to batch %s (len=%d)", event.BlockNumber, event.TransactionIndex, event.LogIndex, batch.BatchID, len(batch.Events))
 			}
-			batch.Events = append(batch.Events, event)
-			l.Debugf("Added event %d/%d/%d to batch %s (len=%d)", event.BlockNumber, event.TransactionIndex, event.LogIndex, batch.BatchID, len(batch.Events))
 		case <-timeoutContext.Done():
 			timedOut = true
 			select {
@@ -706,18 +1087,25 @@ func (es *eventStream) updateCheckpoint(ctx context.Context, dbTX persistence.DB
 	return err
 }
 
+// Events delivered from the head of the chain do not move the checkpoint, and a batch where all
+// the events were already delivered from the head of the chain only moves the checkpoint.
 func (es *eventStream) runBatch(batch *eventBatch) error {
+	callHandler := len(batch.Events) > 0 || batch.deliveredAtHead == 0
 	return es.bi.retry.Do(es.ctx, func(attempt int) (retryable bool, err error) {
 		if es.useNOTXHandler {
-			err = es.handlerNOTX(es.ctx, &batch.EventDeliveryBatch)
-			if err == nil {
+			if callHandler {
+				err = es.handlerNOTX(es.ctx, &batch.EventDeliveryBatch)
+			}
+			if err == nil && !batch.noCheckpoint {
 				err = es.updateCheckpoint(es.ctx, es.bi.persistence.NOTX(), int64(batch.checkpointAfterBatch))
 			}
 			return true, err
 		}
 		err = es.bi.persistence.Transaction(es.ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
-			err = es.handlerDBTX(ctx, dbTX, &batch.EventDeliveryBatch)
-			if err == nil {
+			if callHandler {
+				err = es.handlerDBTX(ctx, dbTX, &batch.EventDeliveryBatch)
+			}
+			if err == nil && !batch.noCheckpoint {
 				err = es.updateCheckpoint(ctx, dbTX, int64(batch.checkpointAfterBatch))
 			}
 			return err
@@ -772,12 +1160,9 @@ func (es *eventStream) processCatchupEventPage(lastCatchupEvent *pldapi.IndexedE
 
 	// Because we're in catch up here, we have to query the chain ourselves for the receipts.
 	// That's done by transaction (not by event) - so we've got to group
-	byTxID := make(map[string][]*pldapi.EventWithData)
+	receipts := make(map[string]*TXReceiptJSONRPC)
 	for _, event := range page {
-		byTxID[event.TransactionHash.String()] = append(byTxID[event.TransactionHash.String()], &pldapi.EventWithData{
-			IndexedEvent: event,
-			// Leave Address and Data as that's what we'll fill in, if it works
-		})
+		receipts[event.TransactionHash.String()] = nil
 	}
 
 	// Parallel query for the TXs - note we require the transactions to exist here, because they have been
@@ -787,24 +1172,29 @@ func (es *eventStream) processCatchupEventPage(lastCatchupEvent *pldapi.IndexedE
 	// to be rebuilt. This would be a very significant event in a production network.
 	//
 	// In early phase dev, it's just about consistently resetting both your chain and your index.
-	enrichments := make(chan error)
-	for txStr, _events := range byTxID {
-		events := _events // not safe to pass loop pointer
+	type txReceiptResult struct {
+		tx      string
+		receipt *TXReceiptJSONRPC
+		err     error
+	}
+	results := make(chan *txReceiptResult)
+	for txStr := range receipts {
 		tx := pldtypes.MustParseBytes32(txStr)
-		for _, _source := range es.definition.Sources {
-			source := _source // not safe to pass loop pointer
-			go func() {
-				enrichments <- es.bi.enrichTransactionEvents(es.ctx, source.ABI, source.Address, tx, events, es.serializer, true /* retry indefinitely */)
-			}()
-		}
+		go func() {
+			var receipt *TXReceiptJSONRPC
+			err := es.bi.retry.Do(es.ctx, func(attempt int) (_ bool, err error) {
+				receipt, err = es.bi.getConfirmedTransactionReceipt(es.ctx, tx[:])
+				return true /* retry indefinitely */, err
+			})
+			results <- &txReceiptResult{tx: txStr, receipt: receipt, err: err}
+		}()
 	}
 	// Collect all the results
-	for range byTxID {
-		for range es.definition.Sources {
-			txErr := <-enrichments
-			if txErr != nil && err == nil {
-				err = txErr
-			}
+	for range receipts {
+		r := <-results
+		receipts[r.tx] = r.receipt
+		if r.err != nil && err == nil {
+			err = r.err
 		}
 	}
 	if err != nil {
@@ -812,19 +1202,33 @@ func (es *eventStream) processCatchupEventPage(lastCatchupEvent *pldapi.IndexedE
 		return false, nil, err
 	}
 
-	// Now reconstruct the final set in the original order, but only where we
-	// successfully extracted the event data
+	// Now process the logs in the original order. We process every log, even if we do not deliver
+	// it, as it might be the deployment of a contract by a factory that we need to know about.
 	for iPage, origEntry := range page {
 		lastEvent = origEntry // need to keep track of the last we saw for the looping round this code
-		eventsForTX := byTxID[origEntry.TransactionHash.String()]
-		for iEvent, event := range eventsForTX {
-			if event.LogIndex == origEntry.LogIndex && event.Data != nil {
+		for _, l := range receipts[origEntry.TransactionHash.String()].Logs {
+			if ethtypes.HexUint64(origEntry.LogIndex) != l.LogIndex {
+				continue
+			}
+			if err := es.detectDeployment(l, true); err != nil {
+				return false, nil, err
+			}
+			event, err := es.matchEvent(origEntry.Block, l)
+			if err != nil {
+				return false, nil, err
+			}
+			if event != nil {
 				// can only update our checkpoint to the block itself (vs. one before)
 				// when we are caught up, and dispatching the last block
-				lastInBlock := caughtUp && (iPage == len(page)-1) && (iEvent == len(eventsForTX)-1)
+				lastInBlock := caughtUp && (iPage == len(page)-1)
 				// Dispatch this event.
-				es.sendToDispatcher(event, lastInBlock)
+				es.sendToDispatcher(&eventDispatch{
+					event:           event,
+					lastInBlock:     lastInBlock,
+					deliveredAtHead: es.unconfirmed && es.reconcileConfirmedBlock(event.Block),
+				})
 			}
+			break
 		}
 	}
 	return caughtUp, lastEvent, nil
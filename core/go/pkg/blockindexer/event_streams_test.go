@@ -337,6 +337,14 @@ func TestInternalEventStreamDeliveryCatchUp(t *testing.T) {
 		panic("redelivery")
 	case <-time.After(5 * time.Millisecond):
 	}
+
+	// Reset the checkpoint back, and check we get redelivery of the last three blocks
+	err = bi.ResetEventStreamCheckpoint(ctx, uuid.MustParse(esID), 11)
+	require.NoError(t, err)
+	for i := 0; i < 3*2; i++ {
+		e := <-eventCollector
+		assert.Equal(t, int64(12+i/2), e.BlockNumber)
+	}
 }
 
 func TestNoMatchingEvents(t *testing.T) {
@@ -732,6 +740,38 @@ func TestStartStopEventStream(t *testing.T) {
 	assert.Nil(t, eventStream.dispatcherDone)
 }
 
+func TestResetEventStreamCheckpoint(t *testing.T) {
+	ctx, bi, _, p, done := newMockBlockIndexer(t, &pldconf.BlockIndexerConfig{})
+	defer done()
+	esID := uuid.New()
+
+	// doesn't exist
+	err := bi.ResetEventStreamCheckpoint(ctx, esID, 10)
+	require.ErrorContains(t, err, "PD011312")
+
+	eventStream := &eventStream{
+		definition: &EventStream{
+			ID: esID,
+		},
+		bi: bi,
+	}
+	eventStream.checkpoint.Store(25)
+	bi.eventStreams[esID] = eventStream
+
+	p.Mock.ExpectExec("INSERT.*event_stream_checkpoints").WillReturnError(errors.New("pop"))
+	err = bi.ResetEventStreamCheckpoint(ctx, esID, 10)
+	require.ErrorContains(t, err, "pop")
+	assert.Equal(t, int64(25), eventStream.checkpoint.Load())
+
+	p.Mock.ExpectExec("INSERT.*event_stream_checkpoints").WillReturnResult(sqlmock.NewResult(1, 1))
+	err = bi.ResetEventStreamCheckpoint(ctx, esID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), eventStream.checkpoint.Load())
+	assert.Nil(t, eventStream.detectorDone)
+
+	require.NoError(t, p.Mock.ExpectationsWereMet())
+}
+
 func TestGetEventStreamStatus(t *testing.T) {
 	ctx, bi, _, _, done := newMockBlockIndexer(t, &pldconf.BlockIndexerConfig{})
 	defer done()
@@ -1005,9 +1045,11 @@ func TestSendToDispatcherClosedNoBlock(t *testing.T) {
 		ctx:      ctx,
 		dispatch: make(chan *eventDispatch),
 	}
-	es.sendToDispatcher(&pldapi.EventWithData{
-		IndexedEvent: &pldapi.IndexedEvent{},
-	}, false)
+	es.sendToDispatcher(&eventDispatch{
+		event: &pldapi.EventWithData{
+			IndexedEvent: &pldapi.IndexedEvent{},
+		},
+	})
 }
 
 func TestDispatcherDispatchClosed(t *testing.T) {
@@ -1239,6 +1281,29 @@ func TestEventSourcesHashing(t *testing.T) {
 		mustHash(EventSources{{ABI: abi.ABI{abiEventIndexed}, Address: address2}}),
 	)
 
+	// topic filters matter
+	assert.NotEqual(t,
+		mustHash(EventSources{{ABI: abi.ABI{abiEventIndexed}, Topics: [][]pldtypes.HexBytes{{address1[:]}}}}),
+		mustHash(EventSources{{ABI: abi.ABI{abiEventIndexed}, Topics: [][]pldtypes.HexBytes{{address2[:]}}}}),
+	)
+
+	// topic filters are compared in their padded form, regardless of order within a position
+	assert.Equal(t,
+		mustHash(EventSources{{ABI: abi.ABI{abiEventIndexed}, Topics: [][]pldtypes.HexBytes{{pldtypes.HexBytes{0x01}, address1[:]}}}}),
+		mustHash(EventSources{{ABI: abi.ABI{abiEventIndexed}, Topics: [][]pldtypes.HexBytes{{append(make(pldtypes.HexBytes, 12), address1[:]...), pldtypes.MustParseHexBytes("0x0000000000000000000000000000000000000000000000000000000000000001")}}}}),
+	)
+	assert.NotEqual(t,
+		mustHash(EventSources{{ABI: abi.ABI{abiEventIndexed}, Topics: [][]pldtypes.HexBytes{{}, {address1[:]}}}}),
+		mustHash(EventSources{{ABI: abi.ABI{abiEventIndexed}, Topics: [][]pldtypes.HexBytes{{address1[:]}, {}}}}),
+	)
+
+	// factories matter
+	factoryEvent := &abi.Entry{Type: abi.Event, Name: "Deployed", Inputs: abi.ParameterArray{{Name: "addr", Type: "address"}}}
+	assert.NotEqual(t,
+		mustHash(EventSources{{ABI: abi.ABI{abiEventIndexed}, Factory: &pldapi.BlockchainEventListenerFactory{Address: *address1, Event: factoryEvent, AddressParam: "addr"}}}),
+		mustHash(EventSources{{ABI: abi.ABI{abiEventIndexed}, Factory: &pldapi.BlockchainEventListenerFactory{Address: *address2, Event: factoryEvent, AddressParam: "addr"}}}),
+	)
+
 	// error case
 	ess := EventSources{{ABI: abi.ABI{{Type: abi.Event, Inputs: abi.ParameterArray{{Type: "wrong"}}}}}}
 	_, err := ess.Hash(context.Background())
@@ -1289,3 +1354,300 @@ func testTimeout(t *testing.T) time.Duration {
 	}
 	return 30 * time.Second // Default timeout if no deadline is set
 }
+
+func TestEventStreamTopicFiltersFactoryAndDecodeFailures(t *testing.T) {
+	ctx, bi, _, done := newTestBlockIndexer(t)
+	defer done()
+
+	transferABI := testParseABI([]byte(`[{
+		"type": "event",
+		"name": "Transfer",
+		"inputs": [
+			{"name": "from", "type": "address", "indexed": true},
+			{"name": "to", "type": "address", "indexed": true},
+			{"name": "value", "type": "uint256"}
+		]
+	}]`))
+	deployedEvent := testParseABI([]byte(`[{
+		"type": "event",
+		"name": "Deployed",
+		"inputs": [{"name": "addr", "type": "address"}]
+	}]`))[0]
+	factory := pldtypes.RandAddress()
+	child := pldtypes.RandAddress()
+	us := pldtypes.RandAddress()
+	them := pldtypes.RandAddress()
+
+	definition, err := bi.AddEventStream(ctx, bi.persistence.NOTX(), &InternalEventStream{
+		Type: IESTypeEventStreamNOTX,
+		Definition: &EventStream{
+			Name: "filtered",
+			Config: EventStreamConfig{
+				DecodeFailurePolicy: pldapi.BlockchainEventDecodeFailurePolicyDeliver.Enum(),
+			},
+			Sources: []EventStreamSource{{
+				ABI: transferABI,
+				// Only transfers to us, with an empty filter for "from"
+				Topics: [][]pldtypes.HexBytes{{}, {us[:]}},
+				Factory: &pldapi.BlockchainEventListenerFactory{
+					Address:      *factory,
+					Event:        deployedEvent,
+					AddressParam: "addr",
+				},
+			}},
+		},
+		HandlerNOTX: func(ctx context.Context, batch *EventDeliveryBatch) error { return nil },
+	})
+	require.NoError(t, err)
+	es := bi.eventStreams[definition.ID]
+	es.ctx = ctx
+	es.dispatch = make(chan *eventDispatch, 10)
+	assert.True(t, es.signatures[pldtypes.NewBytes32FromSlice(deployedEvent.SignatureHashBytes()).String()])
+
+	transferSig := ethtypes.HexBytes0xPrefix(transferABI[0].SignatureHashBytes())
+	topic := func(a *pldtypes.EthAddress) ethtypes.HexBytes0xPrefix {
+		t := topicFilterValue(a[:])
+		return t[:]
+	}
+	value, err := transferABI[0].Inputs[2:].EncodeABIDataValues([]any{"1000"})
+	require.NoError(t, err)
+	deployedData, err := deployedEvent.Inputs.EncodeABIDataValues(map[string]any{"addr": child.String()})
+	require.NoError(t, err)
+	newLog := func(logIndex int, address *pldtypes.EthAddress, topics []ethtypes.HexBytes0xPrefix, data []byte) *LogJSONRPC {
+		return &LogJSONRPC{
+			Address:         address.Address0xHex(),
+			BlockNumber:     1,
+			LogIndex:        ethtypes.HexUint64(logIndex),
+			TransactionHash: ethtypes.MustNewHexBytes0xPrefix(pldtypes.RandHex(32)),
+			Topics:          topics,
+			Data:            data,
+		}
+	}
+	block := &eventStreamBlock{
+		block: &BlockInfoJSONRPC{Number: 1, Hash: ethtypes.MustNewHexBytes0xPrefix(pldtypes.RandHex(32))},
+		events: []*LogJSONRPC{
+			// before the child is deployed
+			newLog(0, child, []ethtypes.HexBytes0xPrefix{transferSig, topic(them), topic(us)}, value),
+			// deployment of the child
+			newLog(1, factory, []ethtypes.HexBytes0xPrefix{ethtypes.HexBytes0xPrefix(deployedEvent.SignatureHashBytes())}, deployedData),
+			// matches
+			newLog(2, child, []ethtypes.HexBytes0xPrefix{transferSig, topic(them), topic(us)}, value),
+			// filtered by topic
+			newLog(3, child, []ethtypes.HexBytes0xPrefix{transferSig, topic(us), topic(them)}, value),
+			newLog(4, child, []ethtypes.HexBytes0xPrefix{transferSig}, nil),
+			// not from a contract deployed by the factory
+			newLog(5, them, []ethtypes.HexBytes0xPrefix{transferSig, topic(them), topic(us)}, value),
+			// cannot be decoded
+			newLog(6, child, []ethtypes.HexBytes0xPrefix{transferSig, topic(them), topic(us)}, nil),
+		},
+	}
+	err = es.processNotifiedBlock(block, true)
+	require.NoError(t, err)
+
+	d := <-es.dispatch
+	assert.Equal(t, int64(2), d.event.LogIndex)
+	assert.Equal(t, *child, d.event.Address)
+	assert.JSONEq(t, fmt.Sprintf(`{"from":"%s","to":"%s","value":"1000"}`, them, us), d.event.Data.Pretty())
+	d = <-es.dispatch
+	assert.Equal(t, int64(6), d.event.LogIndex)
+	assert.Equal(t, *child, d.event.Address)
+	assert.Nil(t, d.event.Data)
+	assert.True(t, d.lastInBlock)
+	assert.Empty(t, es.dispatch)
+
+	// The deployment was persisted, and is loaded on restart
+	es.deployments = make(map[pldtypes.EthAddress]map[pldtypes.EthAddress]bool)
+	require.NoError(t, es.loadDeployments())
+	assert.True(t, es.deployments[*factory][*child])
+
+	// Skip the decode failure - now the child is known the first event also matches
+	es.decodeFailure = pldapi.BlockchainEventDecodeFailurePolicySkip
+	err = es.processNotifiedBlock(block, true)
+	require.NoError(t, err)
+	d = <-es.dispatch
+	assert.Equal(t, int64(0), d.event.LogIndex)
+	d = <-es.dispatch
+	assert.Equal(t, int64(2), d.event.LogIndex)
+	assert.Empty(t, es.dispatch)
+
+	// Halt on the decode failure
+	es.decodeFailure = pldapi.BlockchainEventDecodeFailurePolicyHalt
+	err = es.processNotifiedBlock(block, true)
+	assert.Regexp(t, "PD011318", err)
+	d = <-es.dispatch
+	assert.Equal(t, int64(0), d.event.LogIndex)
+	d = <-es.dispatch
+	assert.Equal(t, int64(2), d.event.LogIndex)
+	assert.False(t, d.lastInBlock)
+
+	// The filters cannot be changed
+	definition.Sources[0].Topics = [][]pldtypes.HexBytes{{them[:]}}
+	_, err = bi.AddEventStream(ctx, bi.persistence.NOTX(), &InternalEventStream{
+		Type:       IESTypeEventStreamNOTX,
+		Definition: definition,
+	})
+	assert.Regexp(t, "PD011302", err)
+}
+
+func TestEventStreamSourceAddressNotDecodeFailure(t *testing.T) {
+	ctx, bi, _, done := newTestBlockIndexer(t)
+	defer done()
+
+	transferABI := testParseABI([]byte(`[{
+		"type": "event",
+		"name": "Transfer",
+		"inputs": [
+			{"name": "from", "type": "address", "indexed": true},
+			{"name": "to", "type": "address", "indexed": true},
+			{"name": "value", "type": "uint256"}
+		]
+	}]`))
+	ours := pldtypes.RandAddress()
+	theirs := pldtypes.RandAddress()
+
+	definition, err := bi.AddEventStream(ctx, bi.persistence.NOTX(), &InternalEventStream{
+		Type: IESTypeEventStreamNOTX,
+		Definition: &EventStream{
+			Name: "single-contract",
+			Config: EventStreamConfig{
+				DecodeFailurePolicy: pldapi.BlockchainEventDecodeFailurePolicyHalt.Enum(),
+			},
+			Sources: []EventStreamSource{{
+				ABI:     transferABI,
+				Address: ours,
+			}},
+		},
+		HandlerNOTX: func(ctx context.Context, batch *EventDeliveryBatch) error { return nil },
+	})
+	require.NoError(t, err)
+	es := bi.eventStreams[definition.ID]
+	es.ctx = ctx
+	es.dispatch = make(chan *eventDispatch, 10)
+
+	transferSig := ethtypes.HexBytes0xPrefix(transferABI[0].SignatureHashBytes())
+	from := topicFilterValue(pldtypes.RandAddress()[:])
+	to := topicFilterValue(pldtypes.RandAddress()[:])
+	value, err := transferABI[0].Inputs[2:].EncodeABIDataValues([]any{"1000"})
+	require.NoError(t, err)
+	newLog := func(logIndex int, address *pldtypes.EthAddress, topics []ethtypes.HexBytes0xPrefix) *LogJSONRPC {
+		return &LogJSONRPC{
+			Address:         address.Address0xHex(),
+			BlockNumber:     1,
+			LogIndex:        ethtypes.HexUint64(logIndex),
+			TransactionHash: ethtypes.MustNewHexBytes0xPrefix(pldtypes.RandHex(32)),
+			Topics:          topics,
+			Data:            value,
+		}
+	}
+	block := &eventStreamBlock{
+		block: &BlockInfoJSONRPC{Number: 1, Hash: ethtypes.MustNewHexBytes0xPrefix(pldtypes.RandHex(32))},
+		events: []*LogJSONRPC{
+			// another contract emitting the same event, including one that would not decode against our ABI
+			newLog(0, theirs, []ethtypes.HexBytes0xPrefix{transferSig, from[:], to[:]}),
+			newLog(1, theirs, []ethtypes.HexBytes0xPrefix{transferSig}),
+			newLog(2, ours, []ethtypes.HexBytes0xPrefix{transferSig, from[:], to[:]}),
+		},
+	}
+
+	for _, policy := range []pldapi.BlockchainEventDecodeFailurePolicy{
+		pldapi.BlockchainEventDecodeFailurePolicyHalt,
+		pldapi.BlockchainEventDecodeFailurePolicyDeliver,
+	} {
+		es.decodeFailure = policy
+		err = es.processNotifiedBlock(block, true)
+		require.NoError(t, err)
+		d := <-es.dispatch
+		assert.Equal(t, int64(2), d.event.LogIndex)
+		assert.Equal(t, *ours, d.event.Address)
+		assert.True(t, d.lastInBlock)
+		assert.Empty(t, es.dispatch)
+	}
+}
+
+func TestEventStreamSourceValidation(t *testing.T) {
+	ctx, bi, _, _, done := newMockBlockIndexer(t, &pldconf.BlockIndexerConfig{})
+	defer done()
+
+	deployedEvent := &abi.Entry{Type: abi.Event, Name: "Deployed", Inputs: abi.ParameterArray{{Name: "addr", Type: "address"}}}
+	addEventStream := func(config EventStreamConfig, source EventStreamSource) error {
+		_, err := bi.AddEventStream(ctx, bi.persistence.NOTX(), &InternalEventStream{
+			Definition: &EventStream{
+				Name:    "testing",
+				Config:  config,
+				Sources: []EventStreamSource{source},
+			},
+		})
+		return err
+	}
+
+	err := addEventStream(EventStreamConfig{DecodeFailurePolicy: "wrong"}, EventStreamSource{ABI: testABI})
+	assert.Regexp(t, "PD020003", err)
+
+	err = addEventStream(EventStreamConfig{}, EventStreamSource{ABI: testABI, Topics: [][]pldtypes.HexBytes{{}, {}, {}, {}}})
+	assert.Regexp(t, "PD011315", err)
+
+	err = addEventStream(EventStreamConfig{}, EventStreamSource{ABI: testABI, Topics: [][]pldtypes.HexBytes{{pldtypes.RandBytes(33)}}})
+	assert.Regexp(t, "PD011314", err)
+
+	err = addEventStream(EventStreamConfig{}, EventStreamSource{ABI: testABI, Address: pldtypes.RandAddress(), Factory: &pldapi.BlockchainEventListenerFactory{
+		Event: deployedEvent, AddressParam: "addr",
+	}})
+	assert.Regexp(t, "PD011316", err)
+
+	err = addEventStream(EventStreamConfig{}, EventStreamSource{ABI: testABI, Factory: &pldapi.BlockchainEventListenerFactory{
+		Event: deployedEvent, AddressParam: "wrong",
+	}})
+	assert.Regexp(t, "PD011317", err)
+
+	err = addEventStream(EventStreamConfig{}, EventStreamSource{ABI: testABI, Factory: &pldapi.BlockchainEventListenerFactory{
+		AddressParam: "addr",
+	}})
+	assert.Regexp(t, "PD011317", err)
+}
+
+func TestEventStreamDeploymentsDBErrors(t *testing.T) {
+	ctx, bi, _, p, done := newMockBlockIndexer(t, &pldconf.BlockIndexerConfig{})
+	defer done()
+
+	deployedEvent := &abi.Entry{Type: abi.Event, Name: "Deployed", Inputs: abi.ParameterArray{{Name: "addr", Type: "address"}}}
+	factory := pldtypes.RandAddress()
+	cancelledCtx, cancelCtx := context.WithCancel(ctx)
+	cancelCtx()
+	es := &eventStream{
+		bi:  bi,
+		ctx: cancelledCtx,
+		definition: &EventStream{
+			ID: uuid.New(),
+			Sources: []EventStreamSource{{
+				ABI: testABI,
+				Factory: &pldapi.BlockchainEventListenerFactory{
+					Address:      *factory,
+					Event:        deployedEvent,
+					AddressParam: "addr",
+				},
+			}},
+		},
+		deployments: make(map[pldtypes.EthAddress]map[pldtypes.EthAddress]bool),
+	}
+
+	p.Mock.ExpectQuery("SELECT.*event_stream_deployments").WillReturnError(fmt.Errorf("pop"))
+	err := es.loadDeployments()
+	assert.Regexp(t, "PD020000", err)
+
+	deployedData, err := deployedEvent.Inputs.EncodeABIDataValues([]any{pldtypes.RandAddress().String()})
+	require.NoError(t, err)
+	p.Mock.ExpectExec("INSERT.*event_stream_deployments").WillReturnError(fmt.Errorf("pop"))
+	err = es.detectDeployment(&LogJSONRPC{
+		Address: factory.Address0xHex(),
+		Topics:  []ethtypes.HexBytes0xPrefix{deployedEvent.SignatureHashBytes()},
+		Data:    deployedData,
+	}, true)
+	assert.Regexp(t, "PD020000", err)
+
+	// Not a deployment event
+	err = es.detectDeployment(&LogJSONRPC{
+		Address: factory.Address0xHex(),
+		Topics:  []ethtypes.HexBytes0xPrefix{topicA},
+	}, true)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,235 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockindexer
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// Event streams configured to deliver unconfirmed events are fed every block the block listener
+// notifies at the head of the chain, in addition to the confirmed blocks from the block indexer.
+//
+// Events are delivered as soon as their block is seen at the head, and we keep a record in memory
+// of the blocks we have delivered events from. If one of those blocks is replaced by a re-org,
+// each event delivered from it is delivered again with the removed flag set.
+//
+// When a block is confirmed, the detector reconciles it against that record. The events in a block
+// that was delivered at the head with the same hash are not delivered a second time, but they do
+// move the checkpoint. Events delivered at the head never move the checkpoint themselves.
+//
+// The record is not persisted, so after a restart events between the checkpoint and the head are
+// delivered again when they are confirmed.
+type unconfirmedBlock struct {
+	number int64
+	hash   pldtypes.Bytes32
+	events []*pldapi.EventWithData
+}
+
+func (bi *blockIndexer) notifyHeadEventStreams(ctx context.Context, block *BlockInfoJSONRPC) {
+	bi.eventStreamsLock.Lock()
+	defer bi.eventStreamsLock.Unlock()
+	for _, es := range bi.eventStreamsHeadSet {
+		// Best effort dispatch here, as for confirmed blocks. Any events in blocks we miss
+		// are delivered when they are confirmed, and any removals are detected at that point.
+		select {
+		case es.headBlocks <- block:
+		default:
+			log.L(ctx).Debugf("ES %s missed head block %d/%s", es.definition.ID, block.Number, block.Hash)
+		}
+	}
+}
+
+func (es *eventStream) headDetector() {
+	defer close(es.headDetectorDone)
+
+	log.L(es.ctx).Debugf("Head detector started for event stream %s [%s]", es.definition.Name, es.definition.ID)
+
+	for {
+		select {
+		case block := <-es.headBlocks:
+			es.processHeadBlock(block)
+		case <-es.ctx.Done():
+			log.L(es.ctx).Debugf("head detector exiting")
+			return
+		}
+	}
+}
+
+func (es *eventStream) resetHead() {
+	es.headLock.Lock()
+	defer es.headLock.Unlock()
+	es.headDelivered = nil
+	es.headConfirmed = -1
+	es.headConfirmedAtHead = false
+}
+
+func (es *eventStream) processHeadBlock(block *BlockInfoJSONRPC) {
+	// While we are catching up, events are only delivered as they are confirmed
+	if es.catchup.Load() {
+		log.L(es.ctx).Debugf("ignoring head block %d/%s during catchup", block.Number, block.Hash)
+		return
+	}
+
+	events, err := es.getHeadBlockEvents(block)
+	if err != nil {
+		// Most likely the block has been replaced already, in which case we will be notified of the replacement
+		log.L(es.ctx).Warnf("Unable to process head block %d/%s: %s", block.Number, block.Hash, err)
+		return
+	}
+
+	es.headLock.Lock()
+	defer es.headLock.Unlock()
+
+	number := int64(block.Number)
+	hash := pldtypes.NewBytes32FromSlice(block.Hash)
+	if number <= es.headConfirmed {
+		log.L(es.ctx).Debugf("head block %d/%s already confirmed", block.Number, block.Hash)
+		es.removeHeadDeployments(func(d headDeployment) bool { return d.number <= es.headConfirmed })
+		return
+	}
+
+	// Any deployments we saw in a different block at this height, or above, are no longer on the canonical chain
+	es.removeHeadDeployments(func(d headDeployment) bool {
+		return d.number > number || (d.number == number && d.hash != hash)
+	})
+
+	i := 0
+	for i < len(es.headDelivered) && es.headDelivered[i].number < number {
+		i++
+	}
+	if i < len(es.headDelivered) && es.headDelivered[i].number == number && es.headDelivered[i].hash == hash {
+		log.L(es.ctx).Debugf("duplicate notification of head block %d/%s", block.Number, block.Hash)
+		return
+	}
+
+	// Anything we delivered from this block onwards is no longer on the canonical chain
+	es.sendRemoved(es.headDelivered[i:])
+	es.headDelivered = es.headDelivered[0:i]
+
+	if len(events) > 0 {
+		es.headDelivered = append(es.headDelivered, &unconfirmedBlock{
+			number: number,
+			hash:   hash,
+			events: events,
+		})
+		for _, event := range events {
+			es.sendToDispatcher(&eventDispatch{event: event, unconfirmed: true})
+		}
+	}
+}
+
+func (es *eventStream) getHeadBlockEvents(block *BlockInfoJSONRPC) ([]*pldapi.EventWithData, error) {
+	if len(block.Transactions) == 0 {
+		return nil, nil
+	}
+
+	var receipts []*TXReceiptJSONRPC
+	err := es.bi.retry.Do(es.ctx, func(attempt int) (bool, error) {
+		rpcErr := es.bi.wsConn.CallRPC(es.ctx, &receipts, "eth_getBlockReceipts", block.Hash)
+		if rpcErr != nil {
+			// No point retrying if the block has gone
+			return !isNotFound(rpcErr), rpcErr
+		}
+		if receipts == nil {
+			return false, i18n.NewError(es.ctx, msgs.MsgBlockIndexerHeadBlockNotFound, block.Hash, block.Number)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*pldapi.EventWithData
+	indexedBlock := es.bi.blockInfoToIndexedBlock(block)
+	for _, r := range receipts {
+		for _, l := range r.Logs {
+			if len(l.Topics) > 0 && es.signatures[l.Topics[0].String()] {
+				if err := es.detectDeployment(l, false); err != nil {
+					return nil, err
+				}
+				event, err := es.matchEvent(indexedBlock, l)
+				if err != nil {
+					return nil, err
+				}
+				if event != nil {
+					events = append(events, event)
+				}
+			}
+		}
+	}
+	return events, nil
+}
+
+// Called by the detector for each confirmed block, and for each event found during catchup (so it
+// might be called multiple times for the same block). Returns true if the events in the block were
+// already delivered at the head of the chain.
+// Any blocks we delivered at the head that are not part of the confirmed chain have their events removed.
+func (es *eventStream) reconcileConfirmedBlock(block *pldapi.IndexedBlock) (deliveredAtHead bool) {
+	if block == nil {
+		return false
+	}
+
+	es.headLock.Lock()
+	defer es.headLock.Unlock()
+
+	if block.Number == es.headConfirmed {
+		return es.headConfirmedAtHead
+	}
+
+	var removed []*unconfirmedBlock
+	for len(es.headDelivered) > 0 && es.headDelivered[0].number < block.Number {
+		removed = append(removed, es.headDelivered[0])
+		es.headDelivered = es.headDelivered[1:]
+	}
+	if len(es.headDelivered) > 0 && es.headDelivered[0].number == block.Number {
+		if es.headDelivered[0].hash == block.Hash {
+			deliveredAtHead = true
+			es.headDelivered = es.headDelivered[1:]
+		} else {
+			// We did not see the re-org at the head, so everything we delivered from here is invalid
+			removed = append(removed, es.headDelivered...)
+			es.headDelivered = nil
+		}
+	}
+	es.sendRemoved(removed)
+
+	// Deployments in confirmed blocks are recorded by the detector as it processes the block
+	es.removeHeadDeployments(func(d headDeployment) bool { return d.number <= block.Number })
+
+	es.headConfirmed = block.Number
+	es.headConfirmedAtHead = deliveredAtHead
+	return deliveredAtHead
+}
+
+// Removals are delivered in the reverse order to the original delivery
+func (es *eventStream) sendRemoved(blocks []*unconfirmedBlock) {
+	for i := len(blocks) - 1; i >= 0; i-- {
+		b := blocks[i]
+		log.L(es.ctx).Infof("Removing %d events delivered from block %d/%s that is no longer on the canonical chain", len(b.events), b.number, b.hash)
+		for j := len(b.events) - 1; j >= 0; j-- {
+			removed := *b.events[j]
+			removed.Removed = true
+			es.sendToDispatcher(&eventDispatch{event: &removed, unconfirmed: true})
+		}
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockindexer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnconfirmedEventStreamHeadDeliveryAndReorg(t *testing.T) {
+	ctx, bi, mRPC, done := newTestBlockIndexer(t)
+	defer done()
+
+	blocks, receipts := testBlockArray(t, 5)
+	forkBlocks, forkReceipts := testBlockArray(t, 3)
+	fork2 := forkBlocks[2]
+	fork2.ParentHash = blocks[1].Hash
+	receipts[fork2.Hash.String()] = forkReceipts[fork2.Hash.String()]
+	mockBlocksRPCCalls(mRPC, blocks, receipts)
+
+	events := make(chan *pldapi.EventWithData, 10)
+	definition, err := bi.AddEventStream(ctx, bi.persistence.NOTX(), &InternalEventStream{
+		Type: IESTypeEventStreamNOTX,
+		Definition: &EventStream{
+			Name: "unconfirmed",
+			Config: EventStreamConfig{
+				BatchTimeout: confutil.P("1ms"),
+				Unconfirmed:  confutil.P(true),
+			},
+			Sources: []EventStreamSource{{
+				ABI: abi.ABI{testABI[1]},
+			}},
+		},
+		HandlerNOTX: func(ctx context.Context, batch *EventDeliveryBatch) error {
+			assert.NotEmpty(t, batch.Events)
+			for _, e := range batch.Events {
+				events <- e
+			}
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	es := bi.eventStreams[definition.ID]
+	assert.Equal(t, es, bi.eventStreamsHeadSet[definition.ID])
+	require.NoError(t, bi.startEventStream(es, false))
+	defer func() { _ = es.stop(false) }()
+
+	confirm := func(b *BlockInfoJSONRPC) {
+		esb := &eventStreamBlock{block: b}
+		for _, r := range receipts[b.Hash.String()] {
+			for _, l := range r.Logs {
+				if es.signatures[l.Topics[0].String()] {
+					esb.events = append(esb.events, l)
+				}
+			}
+		}
+		es.blocks <- esb
+	}
+	checkEvent := func(b *BlockInfoJSONRPC, removed bool) {
+		e := <-events
+		assert.Equal(t, int64(b.Number), e.BlockNumber)
+		assert.Equal(t, pldtypes.NewBytes32FromSlice(b.Hash), e.Block.Hash)
+		assert.Equal(t, removed, e.Removed)
+	}
+
+	// Block 0 is confirmed before we see it at the head
+	confirm(blocks[0])
+	checkEvent(blocks[0], false)
+	bi.notifyHeadEventStreams(ctx, blocks[0])
+
+	// Blocks 1 and 2 are delivered at the head
+	bi.notifyHeadEventStreams(ctx, blocks[1])
+	bi.notifyHeadEventStreams(ctx, blocks[2])
+	checkEvent(blocks[1], false)
+	checkEvent(blocks[2], false)
+
+	// Block 2 is replaced by a fork, which we are notified of twice
+	bi.notifyHeadEventStreams(ctx, fork2)
+	checkEvent(blocks[2], true)
+	checkEvent(fork2, false)
+	bi.notifyHeadEventStreams(ctx, fork2)
+
+	// Block 1 is confirmed without redelivery, but then the original block 2 is confirmed
+	confirm(blocks[1])
+	confirm(blocks[2])
+	checkEvent(fork2, true)
+	checkEvent(blocks[2], false)
+
+	// A late notification of block 2 at the head is ignored, and block 3 is delivered at the head
+	bi.notifyHeadEventStreams(ctx, blocks[2])
+	bi.notifyHeadEventStreams(ctx, blocks[3])
+	checkEvent(blocks[3], false)
+
+	// Block 4 is confirmed, so we know block 3 was on a fork
+	confirm(blocks[4])
+	checkEvent(blocks[3], true)
+	checkEvent(blocks[4], false)
+
+	for es.checkpoint.Load() != 4 {
+		time.Sleep(1 * time.Millisecond)
+	}
+	assert.Empty(t, events)
+	assert.Empty(t, es.headDelivered)
+
+	// Resetting the checkpoint clears the head state
+	err = bi.ResetEventStreamCheckpoint(ctx, definition.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), es.headConfirmed)
+}
+
+func TestUnconfirmedEventStreamHeadDeployments(t *testing.T) {
+	ctx, bi, _, _, done := newMockBlockIndexer(t, &pldconf.BlockIndexerConfig{})
+	defer done()
+
+	factory := pldtypes.RandAddress()
+	deployedEvent := &abi.Entry{Type: abi.Event, Name: "Deployed", Inputs: abi.ParameterArray{{Name: "addr", Type: "address"}}}
+	es := &eventStream{
+		ctx:         ctx,
+		bi:          bi,
+		deployments: make(map[pldtypes.EthAddress]map[pldtypes.EthAddress]bool),
+		definition: &EventStream{
+			Sources: []EventStreamSource{{
+				Factory: &pldapi.BlockchainEventListenerFactory{Address: *factory, Event: deployedEvent, AddressParam: "addr"},
+			}},
+		},
+	}
+	es.resetHead()
+
+	deployedAt := func(number int64, hash ethtypes.HexBytes0xPrefix) *pldtypes.EthAddress {
+		child := pldtypes.RandAddress()
+		es.addHeadDeployment(*factory, *child, &LogJSONRPC{BlockNumber: ethtypes.HexUint64(number), BlockHash: hash})
+		return child
+	}
+	isDeployed := func(child *pldtypes.EthAddress) bool {
+		return es.matchSourceAddress(&es.definition.Sources[0], &LogJSONRPC{Address: child.Address0xHex()})
+	}
+	block := func(number int64) *BlockInfoJSONRPC {
+		return &BlockInfoJSONRPC{Number: ethtypes.HexUint64(number), Hash: ethtypes.MustNewHexBytes0xPrefix(pldtypes.RandHex(32))}
+	}
+
+	// A re-org at block 2 removes the deployments in block 2 and above
+	b1, b2, b3 := block(1), block(2), block(3)
+	child1 := pldtypes.RandAddress()
+	deployedData, err := deployedEvent.Inputs.EncodeABIDataValues(map[string]any{"addr": child1.String()})
+	require.NoError(t, err)
+	err = es.detectDeployment(&LogJSONRPC{
+		Address:     factory.Address0xHex(),
+		BlockNumber: 1,
+		BlockHash:   b1.Hash,
+		Topics:      []ethtypes.HexBytes0xPrefix{deployedEvent.SignatureHashBytes()},
+		Data:        deployedData,
+	}, false)
+	require.NoError(t, err)
+	assert.Empty(t, es.deployments)
+	child2 := deployedAt(2, b2.Hash)
+	child3 := deployedAt(3, b3.Hash)
+	assert.True(t, isDeployed(child1))
+	assert.True(t, isDeployed(child2))
+	assert.True(t, isDeployed(child3))
+	assert.False(t, es.matchSourceAddress(&es.definition.Sources[0], &LogJSONRPC{}))
+	es.processHeadBlock(b2)
+	assert.True(t, isDeployed(child1))
+	assert.True(t, isDeployed(child2))
+	assert.False(t, isDeployed(child3))
+	es.processHeadBlock(block(2))
+	assert.True(t, isDeployed(child1))
+	assert.False(t, isDeployed(child2))
+	assert.False(t, isDeployed(child3))
+
+	// Confirming block 1 discards the head record, as the detector records the confirmed deployment
+	es.reconcileConfirmedBlock(&pldapi.IndexedBlock{Number: 1, Hash: pldtypes.NewBytes32FromSlice(b1.Hash)})
+	assert.False(t, isDeployed(child1))
+	assert.Empty(t, es.headDeployments)
+
+	// A late deployment notified for an already confirmed block is discarded too
+	child1 = deployedAt(1, b1.Hash)
+	es.processHeadBlock(b1)
+	assert.False(t, isDeployed(child1))
+}
+
+func TestUnconfirmedEventStreamHeadErrors(t *testing.T) {
+	ctx, bi, mRPC, _, done := newMockBlockIndexer(t, &pldconf.BlockIndexerConfig{})
+	defer done()
+
+	bi.retry.UTSetMaxAttempts(1)
+	es := &eventStream{
+		bi:  bi,
+		ctx: ctx,
+		definition: &EventStream{
+			ID: uuid.New(),
+		},
+		headBlocks: make(chan *BlockInfoJSONRPC),
+		dispatch:   make(chan *eventDispatch),
+	}
+	bi.eventStreamsHeadSet[es.definition.ID] = es
+
+	// Does not block
+	block := &BlockInfoJSONRPC{
+		Number:       12345,
+		Hash:         ethtypes.MustNewHexBytes0xPrefix(pldtypes.RandHex(32)),
+		Transactions: []*PartialTransactionInfo{{}},
+	}
+	bi.notifyHeadEventStreams(ctx, block)
+
+	// Ignored in catchup
+	es.catchup.Store(true)
+	es.processHeadBlock(block)
+
+	// Block gone
+	es.catchup.Store(false)
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockReceipts", mock.Anything).
+		Return(rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, fmt.Errorf("not found"))).Once()
+	es.processHeadBlock(block)
+
+	// Null receipts
+	mRPC.On("CallRPC", mock.Anything, mock.Anything, "eth_getBlockReceipts", mock.Anything).
+		Return(nil).Once()
+	_, err := es.getHeadBlockEvents(block)
+	assert.Regexp(t, "PD011313", err)
+
+	// Empty block
+	events, err := es.getHeadBlockEvents(&BlockInfoJSONRPC{})
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	assert.False(t, es.reconcileConfirmedBlock(nil))
+	assert.Empty(t, es.headDelivered)
+}
@@ -46,6 +46,7 @@ type EthClient interface {
 	ChainID() int64
 
 	GasPrice(ctx context.Context) (gasPrice *pldtypes.HexUint256, err error)
+	FeeHistory(ctx context.Context, blockCount int, newestBlock string, rewardPercentiles []float64) (*FeeHistoryResult, error)
 	GetBalance(ctx context.Context, address pldtypes.EthAddress, block string) (balance *pldtypes.HexUint256, err error)
 
 	EstimateGasNoResolve(ctx context.Context, tx *ethsigner.Transaction, opts ...CallOption) (res EstimateGasResult, err error)
@@ -111,6 +112,15 @@ type EstimateGasResult struct {
 	RevertData pldtypes.HexBytes
 }
 
+// FeeHistoryResult is the response of eth_feeHistory. BaseFeePerGas contains one more entry than
+// the number of blocks requested, with the last entry being the base fee of the next block.
+type FeeHistoryResult struct {
+	OldestBlock   pldtypes.HexUint64       `json:"oldestBlock"`
+	BaseFeePerGas []*pldtypes.HexUint256   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64                `json:"gasUsedRatio"`
+	Reward        [][]*pldtypes.HexUint256 `json:"reward,omitempty"`
+}
+
 type CallResult struct {
 	serializer    *abi.Serializer
 	Data          pldtypes.HexBytes
@@ -293,6 +303,16 @@ func (ec *ethClient) GasPrice(ctx context.Context) (*pldtypes.HexUint256, error)
 	return &gasPrice, nil
 }
 
+func (ec *ethClient) FeeHistory(ctx context.Context, blockCount int, newestBlock string, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	var feeHistory FeeHistoryResult
+
+	if rpcErr := ec.rpc.CallRPC(ctx, &feeHistory, "eth_feeHistory", pldtypes.HexUint64(blockCount), newestBlock, rewardPercentiles); rpcErr != nil {
+		log.L(ctx).Errorf("eth_feeHistory failed: %+v", rpcErr)
+		return nil, rpcErr
+	}
+	return &feeHistory, nil
+}
+
 func (ec *ethClient) EstimateGas(ctx context.Context, from *string, tx *ethsigner.Transaction, opts ...CallOption) (res EstimateGasResult, err error) {
 	if _, _, err := ec.resolveFrom(ctx, from, tx); err != nil {
 		return res, err
@@ -136,6 +136,44 @@ func TestGasPriceFail(t *testing.T) {
 
 }
 
+func TestFeeHistory(t *testing.T) {
+	ctx, ec, done := newTestClientAndServer(t, &mockEth{
+		eth_feeHistory: func(ctx context.Context, blockCount pldtypes.HexUint64, newestBlock string, percentiles []float64) (*FeeHistoryResult, error) {
+			assert.Equal(t, pldtypes.HexUint64(2), blockCount)
+			assert.Equal(t, "latest", newestBlock)
+			assert.Equal(t, []float64{50}, percentiles)
+			return &FeeHistoryResult{
+				OldestBlock:   100,
+				BaseFeePerGas: []*pldtypes.HexUint256{pldtypes.Uint64ToUint256(10), pldtypes.Uint64ToUint256(11), pldtypes.Uint64ToUint256(12)},
+				GasUsedRatio:  []float64{0.5, 0.6},
+				Reward:        [][]*pldtypes.HexUint256{{pldtypes.Uint64ToUint256(1)}, {pldtypes.Uint64ToUint256(2)}},
+			}, nil
+		},
+	})
+	defer done()
+
+	feeHistory, err := ec.HTTPClient().FeeHistory(ctx, 2, "latest", []float64{50})
+	require.NoError(t, err)
+	assert.Equal(t, pldtypes.HexUint64(100), feeHistory.OldestBlock)
+	assert.Len(t, feeHistory.BaseFeePerGas, 3)
+	assert.Equal(t, uint64(12), feeHistory.BaseFeePerGas[2].Int().Uint64())
+	assert.Equal(t, uint64(2), feeHistory.Reward[1][0].Int().Uint64())
+
+}
+
+func TestFeeHistoryFail(t *testing.T) {
+	ctx, ec, done := newTestClientAndServer(t, &mockEth{
+		eth_feeHistory: func(ctx context.Context, blockCount pldtypes.HexUint64, newestBlock string, percentiles []float64) (*FeeHistoryResult, error) {
+			return nil, fmt.Errorf("pop")
+		},
+	})
+	defer done()
+
+	_, err := ec.HTTPClient().FeeHistory(ctx, 2, "latest", []float64{50})
+	assert.Regexp(t, "pop", err)
+
+}
+
 func TestEstimateGas(t *testing.T) {
 	gasEstimateHexInt := pldtypes.HexUint64(200000)
 	ctx, ec, done := newTestClientAndServer(t, &mockEth{
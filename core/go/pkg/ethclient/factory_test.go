@@ -35,6 +35,7 @@ import (
 type mockEth struct {
 	eth_getBalance          func(context.Context, pldtypes.EthAddress, string) (*pldtypes.HexUint256, error)
 	eth_gasPrice            func(context.Context) (*pldtypes.HexUint256, error)
+	eth_feeHistory          func(context.Context, pldtypes.HexUint64, string, []float64) (*FeeHistoryResult, error)
 	eth_gasLimit            func(context.Context, ethsigner.Transaction) (*pldtypes.HexUint256, error)
 	eth_chainId             func(context.Context) (pldtypes.HexUint64, error)
 	eth_getTransactionCount func(context.Context, pldtypes.EthAddress, string) (pldtypes.HexUint64, error)
@@ -87,6 +88,7 @@ func newTestServer(t *testing.T, ctx context.Context, isWS bool, mEth *mockEth)
 		Add("eth_call", primarySecondary(mEth.eth_callErr, checkNil(mEth.eth_call, rpcserver.RPCMethod2))).
 		Add("eth_getBalance", checkNil(mEth.eth_getBalance, rpcserver.RPCMethod2)).
 		Add("eth_gasPrice", checkNil(mEth.eth_gasPrice, rpcserver.RPCMethod0)).
+		Add("eth_feeHistory", checkNil(mEth.eth_feeHistory, rpcserver.RPCMethod3)).
 		Add("eth_gasLimit", checkNil(mEth.eth_gasLimit, rpcserver.RPCMethod1)),
 	)
 
@@ -123,7 +123,7 @@ func (tb *testbed) gatherSignatures(ctx context.Context, tx *testbedTransaction)
 				if err != nil {
 					return fmt.Errorf("failed to resolve local signer for %s (algorithm=%s): %s", partyName, ar.Algorithm, err)
 				}
-				signaturePayload, err := tb.c.KeyManager().Sign(ctx, resolvedKey, ar.PayloadType, ar.Payload)
+				signaturePayload, err := tb.c.KeyManager().Sign(ctx, tb.c.Persistence().NOTX(), resolvedKey, ar.PayloadType, ar.Payload)
 				if err != nil {
 					return fmt.Errorf("failed to sign for party %s (verifier=%s,algorithm=%s): %s", partyName, resolvedKey.Verifier.Verifier, ar.Algorithm, err)
 				}
@@ -224,7 +224,7 @@ func (tb *testbed) gatherEndorsements(dCtx components.DomainContext, tx *testbed
 					return fmt.Errorf("reverted: %s", revertReason)
 				case prototk.EndorseTransactionResponse_SIGN:
 					// Build the signature
-					signaturePayload, err := keyMgr.Sign(dCtx.Ctx(), resolvedKey, ar.PayloadType, endorseRes.Payload)
+					signaturePayload, err := keyMgr.Sign(dCtx.Ctx(), tb.c.Persistence().NOTX(), resolvedKey, ar.PayloadType, endorseRes.Payload)
 					if err != nil {
 						return fmt.Errorf("failed to endorse for party %s (verifier=%s,algorithm=%s): %s", partyName, resolvedKey.Verifier.Verifier, ar.Algorithm, err)
 					}
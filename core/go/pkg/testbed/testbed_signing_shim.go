@@ -51,7 +51,7 @@ func (e *ethClientKeyMgrShim) Sign(ctx context.Context, req *prototk.SignWithKey
 	if mapping == nil {
 		return nil, fmt.Errorf("combination not resolved in this shim: keyHandle=%s, algorithm=%s", req.KeyHandle, req.Algorithm)
 	}
-	signedPayload, err := e.tb.c.KeyManager().Sign(ctx, mapping, req.PayloadType, req.Payload)
+	signedPayload, err := e.tb.c.KeyManager().Sign(ctx, e.tb.c.Persistence().NOTX(), mapping, req.PayloadType, req.Payload)
 	if err != nil {
 		return nil, err
 	}
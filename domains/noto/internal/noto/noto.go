@@ -825,3 +825,7 @@ func (n *Noto) InitPrivacyGroup(ctx context.Context, req *prototk.InitPrivacyGro
 func (n *Noto) WrapPrivacyGroupEVMTX(ctx context.Context, req *prototk.WrapPrivacyGroupEVMTXRequest) (*prototk.WrapPrivacyGroupEVMTXResponse, error) {
 	return nil, i18n.NewError(ctx, msgs.MsgNotImplemented)
 }
+
+func (n *Noto) UpdatePrivacyGroupMembers(ctx context.Context, req *prototk.UpdatePrivacyGroupMembersRequest) (*prototk.UpdatePrivacyGroupMembersResponse, error) {
+	return nil, plugintk.NewNotSupportedError(i18n.NewError(ctx, msgs.MsgNotImplemented))
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
 	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
 	"github.com/stretchr/testify/assert"
@@ -674,6 +675,12 @@ func TestUnimplementedMethods(t *testing.T) {
 
 	_, err = n.ValidateStateHashes(ctx, nil)
 	assert.ErrorContains(t, err, "PD200022")
+
+	_, err = n.UpdatePrivacyGroupMembers(ctx, nil)
+	assert.ErrorContains(t, err, "PD200022")
+	var pluginError *plugintk.PluginError
+	require.ErrorAs(t, err, &pluginError)
+	assert.Equal(t, prototk.Header_NOT_SUPPORTED, pluginError.ErrorType)
 }
 
 func TestDecodeConfigInvalid(t *testing.T) {
@@ -827,6 +827,14 @@ import java.util.concurrent.ExecutionException;
          }
      }
 
+     @Override
+     protected CompletableFuture<UpdatePrivacyGroupMembersResponse> updatePrivacyGroupMembers(UpdatePrivacyGroupMembersRequest request) {
+         // The endorsement set of a Pente privacy group is fixed on-chain when the privacy group contract
+         // is deployed, so there is no way to reconfigure the members of an existing group.
+         return CompletableFuture.failedFuture(new UnsupportedOperationException(
+                 "Pente privacy groups do not support changes to the member list after deployment"));
+     }
+
      @NotNull
      private static JsonNodeFactory getInstance() {
          return JsonNodeFactory.instance;
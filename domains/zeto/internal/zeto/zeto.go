@@ -715,3 +715,7 @@ func (z *Zeto) InitPrivacyGroup(ctx context.Context, req *prototk.InitPrivacyGro
 func (z *Zeto) WrapPrivacyGroupEVMTX(ctx context.Context, req *prototk.WrapPrivacyGroupEVMTXRequest) (*prototk.WrapPrivacyGroupEVMTXResponse, error) {
 	return nil, i18n.NewError(ctx, msgs.MsgNotImplemented)
 }
+
+func (z *Zeto) UpdatePrivacyGroupMembers(ctx context.Context, req *prototk.UpdatePrivacyGroupMembersRequest) (*prototk.UpdatePrivacyGroupMembersResponse, error) {
+	return nil, plugintk.NewNotSupportedError(i18n.NewError(ctx, msgs.MsgNotImplemented))
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/kaleido-io/paladin/domains/zeto/pkg/zetosigner/zetosignerapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	pb "github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/stretchr/testify/assert"
@@ -892,6 +893,12 @@ func TestUnimplementedMethods(t *testing.T) {
 	z := &Zeto{}
 	_, err := z.BuildReceipt(context.Background(), nil)
 	assert.ErrorContains(t, err, "PD210102: Not implemented")
+
+	_, err = z.UpdatePrivacyGroupMembers(context.Background(), nil)
+	assert.ErrorContains(t, err, "PD210102: Not implemented")
+	var pluginError *plugintk.PluginError
+	require.ErrorAs(t, err, &pluginError)
+	assert.Equal(t, prototk.Header_NOT_SUPPORTED, pluginError.ErrorType)
 }
 
 func TestGetStateSchemas(t *testing.T) {
@@ -17,10 +17,12 @@ package evmregistry
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 
 	_ "embed"
 
+	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/registries/evm/internal/msgs"
@@ -78,9 +80,14 @@ func (r *evmRegistry) ConfigureRegistry(ctx context.Context, req *prototk.Config
 	// - The node name is the name of the entry
 	// - Each property of this top-level object is a transport type (such as "grpc")
 	// - The value of the property is the transport details
+	// - An identity revoked by the owner of its parent is marked inactive, so it cannot be resolved
+	//
+	// Writes are supported, so the node can register itself and publish its own transport details
+	// (with a signing key that owns the parent entry, and a signing key that will own its entry)
 
 	return &prototk.ConfigureRegistryResponse{
 		RegistryConfig: &prototk.RegistryConfig{
+			WritesSupported: true,
 			EventSources: []*prototk.RegistryEventSource{
 				{
 					ContractAddress: r.conf.ContractAddress.String(),
@@ -158,6 +165,37 @@ func (r *evmRegistry) handlePropertySet(ctx context.Context, inEvent *prototk.On
 	}, nil
 }
 
+func (r *evmRegistry) handleIdentityRevoked(ctx context.Context, inEvent *prototk.OnChainEvent) (*prototk.RegistryEntry, error) {
+	// We should be able to parse this
+	var parsedEvent IdentityRevokedEvent
+	if err := json.Unmarshal([]byte(inEvent.DataJson), &parsedEvent); err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgInvalidRegistryEvent, inEvent.Location)
+	}
+
+	// We will have discarded the registration of an entry with an invalid name, so discard the revocation too
+	if err := pldtypes.ValidateSafeCharsStartEndAlphaNum(ctx, parsedEvent.Name, pldtypes.DefaultNameMaxLen, "name"); err != nil {
+		log.L(ctx).Warnf("Discarding %s event due to invalid entity name (%d/%d/%d): %s",
+			inEvent.SoliditySignature, inEvent.Location.BlockNumber, inEvent.Location.TransactionIndex, inEvent.Location.LogIndex, err)
+		// Not an error in our code
+		return nil, nil
+	}
+
+	parentID := ""
+	if !parsedEvent.ParentIdentityHash.IsZero() {
+		parentID = parsedEvent.ParentIdentityHash.String()
+	}
+
+	// The entry is re-stated as inactive. The properties remain as they were, but will
+	// not be used for transport lookup as the entry is no longer active.
+	return &prototk.RegistryEntry{
+		Id:       parsedEvent.IdentityHash.String(),
+		ParentId: parentID,
+		Name:     parsedEvent.Name,
+		Active:   false,
+		Location: inEvent.Location,
+	}, nil
+}
+
 func (r *evmRegistry) HandleRegistryEvents(ctx context.Context, req *prototk.HandleRegistryEventsRequest) (*prototk.HandleRegistryEventsResponse, error) {
 
 	entries := []*prototk.RegistryEntry{}
@@ -187,6 +225,14 @@ func (r *evmRegistry) HandleRegistryEvents(ctx context.Context, req *prototk.Han
 			if newProp != nil {
 				properties = append(properties, newProp)
 			}
+		case contractDetail.identityRevokedSignature.Equals(&inSig):
+			revokedEntry, err := r.handleIdentityRevoked(ctx, inEvent)
+			if err != nil {
+				return nil, err
+			}
+			if revokedEntry != nil {
+				entries = append(entries, revokedEntry)
+			}
 		default:
 			log.L(ctx).Infof("Discarding event unhandled by registry (%d/%d/%d): %s",
 				inEvent.Location.BlockNumber, inEvent.Location.TransactionIndex, inEvent.Location.LogIndex, inEvent.SoliditySignature)
@@ -199,3 +245,60 @@ func (r *evmRegistry) HandleRegistryEvents(ctx context.Context, req *prototk.Han
 		Properties: properties,
 	}, nil
 }
+
+func (r *evmRegistry) PrepareRegistryTransactions(ctx context.Context, req *prototk.PrepareRegistryTransactionsRequest) (*prototk.PrepareRegistryTransactionsResponse, error) {
+
+	// Root entries have a zero parent hash on-chain
+	var parentIdentityHash pldtypes.Bytes32
+	if req.ParentId != "" {
+		parentID, err := pldtypes.ParseBytes32(req.ParentId)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgInvalidEntryID, req.ParentId)
+		}
+		parentIdentityHash = parentID
+	}
+
+	var transactions []*prototk.PreparedRegistryTransaction
+	var identityHash pldtypes.Bytes32
+	if req.EntryId != "" {
+		entryID, err := pldtypes.ParseBytes32(req.EntryId)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgInvalidEntryID, req.EntryId)
+		}
+		identityHash = entryID
+	} else {
+		owner, err := pldtypes.ParseEthAddress(req.OwnerAddress)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgInvalidOwnerAddress, req.OwnerAddress)
+		}
+		// The contract calculates the hash as sha256(abi.encodePacked(parentIdentityHash, name)),
+		// which we need to set the properties in the same batch as the registration
+		identityHash = sha256.Sum256(append(parentIdentityHash[:], []byte(req.Name)...))
+		transactions = append(transactions, r.prepareTransaction(contractDetail.registerIdentityFunction, &RegisterIdentityParams{
+			ParentIdentityHash: parentIdentityHash,
+			Name:               req.Name,
+			Owner:              *owner,
+		}, true))
+	}
+
+	for _, prop := range req.Properties {
+		transactions = append(transactions, r.prepareTransaction(contractDetail.setIdentityPropertyFunction, &SetIdentityPropertyParams{
+			IdentityHash: identityHash,
+			Name:         prop.Name,
+			Value:        prop.Value,
+		}, false))
+	}
+
+	return &prototk.PrepareRegistryTransactionsResponse{
+		Transactions: transactions,
+	}, nil
+}
+
+func (r *evmRegistry) prepareTransaction(function *abi.Entry, params any, registerEntry bool) *prototk.PreparedRegistryTransaction {
+	return &prototk.PreparedRegistryTransaction{
+		ContractAddress: r.conf.ContractAddress.String(),
+		FunctionAbiJson: pldtypes.JSONString(function).String(),
+		ParamsJson:      pldtypes.JSONString(params).String(),
+		RegisterEntry:   registerEntry,
+	}
+}
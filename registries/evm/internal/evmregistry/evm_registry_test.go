@@ -17,6 +17,7 @@ package evmregistry
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"testing"
 
@@ -93,9 +94,101 @@ func TestGoodConfigJSON(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, addr.String(), res.RegistryConfig.EventSources[0].ContractAddress)
+	assert.True(t, res.RegistryConfig.WritesSupported)
 
 }
 
+func newConfiguredTestRegistry(t *testing.T) (*evmRegistry, *pldtypes.EthAddress) {
+	addr := pldtypes.RandAddress()
+	registry := NewEVMRegistry(&testCallbacks{}).(*evmRegistry)
+	_, err := registry.ConfigureRegistry(registry.bgCtx, &prototk.ConfigureRegistryRequest{
+		Name:       "evm",
+		ConfigJson: fmt.Sprintf(`{"contractAddress": "%s"}`, addr),
+	})
+	require.NoError(t, err)
+	return registry, addr
+}
+
+func TestPrepareRegistryTransactionsRegisterRoot(t *testing.T) {
+	registry, addr := newConfiguredTestRegistry(t)
+	owner := pldtypes.RandAddress()
+
+	res, err := registry.PrepareRegistryTransactions(registry.bgCtx, &prototk.PrepareRegistryTransactionsRequest{
+		Name:         "node1",
+		OwnerAddress: owner.String(),
+		Properties: []*prototk.RegistryPropertyValue{
+			{Name: "transport.grpc", Value: "details1"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Transactions, 2)
+
+	register := res.Transactions[0]
+	assert.True(t, register.RegisterEntry)
+	assert.Equal(t, addr.String(), register.ContractAddress)
+	assert.Contains(t, register.FunctionAbiJson, `"registerIdentity"`)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"parentIdentityHash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+		"name": "node1",
+		"owner": "%s"
+	}`, owner), register.ParamsJson)
+
+	// Calculated as the contract does, so it matches the IdentityRegistered event
+	expectedHash := sha256.Sum256(append(make([]byte, 32), []byte("node1")...))
+	setProp := res.Transactions[1]
+	assert.False(t, setProp.RegisterEntry)
+	assert.Contains(t, setProp.FunctionAbiJson, `"setIdentityProperty"`)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"identityHash": "%s",
+		"name": "transport.grpc",
+		"value": "details1"
+	}`, pldtypes.Bytes32(expectedHash)), setProp.ParamsJson)
+}
+
+func TestPrepareRegistryTransactionsExistingChild(t *testing.T) {
+	registry, _ := newConfiguredTestRegistry(t)
+	parentID := pldtypes.RandBytes32()
+	entryID := pldtypes.RandBytes32()
+
+	res, err := registry.PrepareRegistryTransactions(registry.bgCtx, &prototk.PrepareRegistryTransactionsRequest{
+		Name:     "node1",
+		ParentId: parentID.String(),
+		EntryId:  entryID.String(),
+		Properties: []*prototk.RegistryPropertyValue{
+			{Name: "transport.grpc", Value: "details2"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Transactions, 1)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"identityHash": "%s",
+		"name": "transport.grpc",
+		"value": "details2"
+	}`, entryID), res.Transactions[0].ParamsJson)
+}
+
+func TestPrepareRegistryTransactionsBadInput(t *testing.T) {
+	registry, _ := newConfiguredTestRegistry(t)
+
+	_, err := registry.PrepareRegistryTransactions(registry.bgCtx, &prototk.PrepareRegistryTransactionsRequest{
+		Name:     "node1",
+		ParentId: "wrong",
+	})
+	assert.Regexp(t, "PD060004", err)
+
+	_, err = registry.PrepareRegistryTransactions(registry.bgCtx, &prototk.PrepareRegistryTransactionsRequest{
+		Name:    "node1",
+		EntryId: "wrong",
+	})
+	assert.Regexp(t, "PD060004", err)
+
+	_, err = registry.PrepareRegistryTransactions(registry.bgCtx, &prototk.PrepareRegistryTransactionsRequest{
+		Name:         "node1",
+		OwnerAddress: "wrong",
+	})
+	assert.Regexp(t, "PD060005", err)
+}
+
 func TestHandleEventBatchOk(t *testing.T) {
 
 	txHash1 := pldtypes.RandBytes32().String()
@@ -327,6 +420,114 @@ func TestHandleEventBatchPropBadName(t *testing.T) {
 
 }
 
+func TestHandleEventIdentityRevoked(t *testing.T) {
+
+	txHash := pldtypes.RandBytes32().String()
+
+	identityRevoked := IdentityRevokedEvent{
+		ParentIdentityHash: pldtypes.RandBytes32(),
+		IdentityHash:       pldtypes.RandBytes32(),
+		Name:               "node1",
+	}
+
+	transport := NewEVMRegistry(&testCallbacks{}).(*evmRegistry)
+	res, err := transport.HandleRegistryEvents(transport.bgCtx, &prototk.HandleRegistryEventsRequest{
+		BatchId: uuid.New().String(),
+		Events: []*prototk.OnChainEvent{
+			{
+				Location:          &prototk.OnChainEventLocation{TransactionHash: txHash, BlockNumber: 300, TransactionIndex: 30, LogIndex: 15},
+				Signature:         contractDetail.identityRevokedSignature.String(),
+				SoliditySignature: identityRevokedEventSolSig,
+				DataJson:          pldtypes.JSONString(&identityRevoked).Pretty(),
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, res.Properties)
+	require.Len(t, res.Entries, 1)
+	require.Equal(t, &prototk.RegistryEntry{
+		Id:       identityRevoked.IdentityHash.String(),
+		ParentId: identityRevoked.ParentIdentityHash.String(),
+		Name:     "node1",
+		Active:   false,
+		Location: &prototk.OnChainEventLocation{
+			TransactionHash:  txHash,
+			BlockNumber:      300,
+			TransactionIndex: 30,
+			LogIndex:         15,
+		},
+	}, res.Entries[0])
+
+}
+
+func TestHandleEventIdentityRevokedRoot(t *testing.T) {
+
+	identityRevoked := IdentityRevokedEvent{
+		IdentityHash: pldtypes.RandBytes32(),
+		Name:         "node1",
+	}
+
+	transport := NewEVMRegistry(&testCallbacks{}).(*evmRegistry)
+	res, err := transport.HandleRegistryEvents(transport.bgCtx, &prototk.HandleRegistryEventsRequest{
+		BatchId: uuid.New().String(),
+		Events: []*prototk.OnChainEvent{
+			{
+				Location:          &prototk.OnChainEventLocation{BlockNumber: 300},
+				Signature:         contractDetail.identityRevokedSignature.String(),
+				SoliditySignature: identityRevokedEventSolSig,
+				DataJson:          pldtypes.JSONString(&identityRevoked).Pretty(),
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Entries, 1)
+	require.Empty(t, res.Entries[0].ParentId)
+	require.False(t, res.Entries[0].Active)
+
+}
+
+func TestHandleEventBadIdentityRevoked(t *testing.T) {
+
+	transport := NewEVMRegistry(&testCallbacks{}).(*evmRegistry)
+	_, err := transport.HandleRegistryEvents(transport.bgCtx, &prototk.HandleRegistryEventsRequest{
+		BatchId: uuid.New().String(),
+		Events: []*prototk.OnChainEvent{
+			{
+				Location:          &prototk.OnChainEventLocation{BlockNumber: 100},
+				Signature:         contractDetail.identityRevokedSignature.String(),
+				SoliditySignature: identityRevokedEventSolSig,
+				DataJson:          `{"identityHash": "WRONG"}`,
+			},
+		},
+	})
+	require.Regexp(t, "PD060002", err)
+
+}
+
+func TestHandleEventIdentityRevokedBadName(t *testing.T) {
+
+	identityRevoked := IdentityRevokedEvent{
+		IdentityHash: pldtypes.RandBytes32(),
+		Name:         "___ wrong",
+	}
+
+	transport := NewEVMRegistry(&testCallbacks{}).(*evmRegistry)
+	res, err := transport.HandleRegistryEvents(transport.bgCtx, &prototk.HandleRegistryEventsRequest{
+		BatchId: uuid.New().String(),
+		Events: []*prototk.OnChainEvent{
+			{
+				Location:          &prototk.OnChainEventLocation{BlockNumber: 100},
+				Signature:         contractDetail.identityRevokedSignature.String(),
+				SoliditySignature: identityRevokedEventSolSig,
+				DataJson:          pldtypes.JSONString(&identityRevoked).Pretty(),
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, res.Entries)
+
+}
+
 func TestHandleRoot(t *testing.T) {
 
 	rootNotification := []byte(`{
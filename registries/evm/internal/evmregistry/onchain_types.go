@@ -32,6 +32,9 @@ type identityRegistryContractDefinition struct {
 	abi                         abi.ABI
 	identityRegisteredSignature pldtypes.Bytes32
 	propertySetSignature        pldtypes.Bytes32
+	identityRevokedSignature    pldtypes.Bytes32
+	registerIdentityFunction    *abi.Entry
+	setIdentityPropertyFunction *abi.Entry
 }
 
 const identityRegisteredEventSolSig = "event IdentityRegistered(bytes32 parentIdentityHash, bytes32 identityHash, string name, address owner)"
@@ -51,6 +54,30 @@ type PropertySetEvent struct {
 	Value        string           `json:"value"`
 }
 
+const identityRevokedEventSolSig = "event IdentityRevoked(bytes32 parentIdentityHash, bytes32 identityHash, string name)"
+
+type IdentityRevokedEvent struct {
+	ParentIdentityHash pldtypes.Bytes32 `json:"parentIdentityHash"`
+	IdentityHash       pldtypes.Bytes32 `json:"identityHash"`
+	Name               string           `json:"name"`
+}
+
+const registerIdentityFunctionSig = "registerIdentity(bytes32,string,address)"
+
+type RegisterIdentityParams struct {
+	ParentIdentityHash pldtypes.Bytes32    `json:"parentIdentityHash"`
+	Name               string              `json:"name"`
+	Owner              pldtypes.EthAddress `json:"owner"`
+}
+
+const setIdentityPropertyFunctionSig = "setIdentityProperty(bytes32,string,string)"
+
+type SetIdentityPropertyParams struct {
+	IdentityHash pldtypes.Bytes32 `json:"identityHash"`
+	Name         string           `json:"name"`
+	Value        string           `json:"value"`
+}
+
 func mustLoadIdentityRegistryContractDetail(buildOutput []byte) *identityRegistryContractDefinition {
 	var build SolidityBuild
 	err := json.Unmarshal(buildOutput, &build)
@@ -70,9 +97,29 @@ func mustLoadIdentityRegistryContractDetail(buildOutput []byte) *identityRegistr
 		panic(fmt.Sprintf("contract signature has changed: %s", propertySetEvent.SolString()))
 	}
 
+	identityRevokedEvent := build.ABI.Events()["IdentityRevoked"]
+	if identityRevokedEvent.SolString() != identityRevokedEventSolSig {
+		panic(fmt.Sprintf("contract signature has changed: %s", identityRevokedEvent.SolString()))
+	}
+
+	// The same applies to the functions we invoke to publish entries
+
+	registerIdentityFunction := build.ABI.Functions()["registerIdentity"]
+	if registerIdentityFunction.String() != registerIdentityFunctionSig {
+		panic(fmt.Sprintf("contract signature has changed: %s", registerIdentityFunction.SolString()))
+	}
+
+	setIdentityPropertyFunction := build.ABI.Functions()["setIdentityProperty"]
+	if setIdentityPropertyFunction.String() != setIdentityPropertyFunctionSig {
+		panic(fmt.Sprintf("contract signature has changed: %s", setIdentityPropertyFunction.SolString()))
+	}
+
 	return &identityRegistryContractDefinition{
 		abi:                         build.ABI,
 		identityRegisteredSignature: pldtypes.Bytes32(identityRegisteredEvent.SignatureHashBytes()),
 		propertySetSignature:        pldtypes.Bytes32(propertySetEvent.SignatureHashBytes()),
+		identityRevokedSignature:    pldtypes.Bytes32(identityRevokedEvent.SignatureHashBytes()),
+		registerIdentityFunction:    registerIdentityFunction,
+		setIdentityPropertyFunction: setIdentityPropertyFunction,
 	}
 }
@@ -54,6 +54,57 @@ func TestDetectsContractChangeAtBuildTime(t *testing.T) {
 		}))
 	})
 
+	assert.PanicsWithValue(t, "contract signature has changed: event IdentityRevoked(address different)", func() {
+		mustLoadIdentityRegistryContractDetail(pldtypes.JSONString(SolidityBuild{
+			ABI: abi.ABI{
+				contractDetail.abi.Events()["IdentityRegistered"],
+				contractDetail.abi.Events()["PropertySet"],
+				{
+					Type: abi.Event,
+					Name: "IdentityRevoked",
+					Inputs: abi.ParameterArray{
+						{Name: "different", Type: "address"},
+					},
+				},
+			},
+		}))
+	})
+
+	assert.PanicsWithValue(t, "contract signature has changed: function registerIdentity(address different) external { }", func() {
+		mustLoadIdentityRegistryContractDetail(pldtypes.JSONString(SolidityBuild{
+			ABI: abi.ABI{
+				contractDetail.abi.Events()["IdentityRegistered"],
+				contractDetail.abi.Events()["PropertySet"],
+				contractDetail.abi.Events()["IdentityRevoked"],
+				{
+					Type: abi.Function,
+					Name: "registerIdentity",
+					Inputs: abi.ParameterArray{
+						{Name: "different", Type: "address"},
+					},
+				},
+			},
+		}))
+	})
+
+	assert.PanicsWithValue(t, "contract signature has changed: function setIdentityProperty(address different) external { }", func() {
+		mustLoadIdentityRegistryContractDetail(pldtypes.JSONString(SolidityBuild{
+			ABI: abi.ABI{
+				contractDetail.abi.Events()["IdentityRegistered"],
+				contractDetail.abi.Events()["PropertySet"],
+				contractDetail.abi.Events()["IdentityRevoked"],
+				contractDetail.abi.Functions()["registerIdentity"],
+				{
+					Type: abi.Function,
+					Name: "setIdentityProperty",
+					Inputs: abi.ParameterArray{
+						{Name: "different", Type: "address"},
+					},
+				},
+			},
+		}))
+	})
+
 }
 
 func TestBreaksIfBuildIsBroken(t *testing.T) {
@@ -36,4 +36,6 @@ var (
 	MsgInvalidRegistryConfig  = pde("PD060001", "Invalid registry configuration")
 	MsgInvalidRegistryEvent   = pde("PD060002", "Invalid registry event %+v")
 	MsgMissingContractAddress = pde("PD060003", "contractAddress is required in registry config")
+	MsgInvalidEntryID         = pde("PD060004", "Invalid entry ID '%s'")
+	MsgInvalidOwnerAddress    = pde("PD060005", "Invalid owner address '%s'")
 )
@@ -24,4 +24,6 @@ type Config struct {
 type StaticEntry struct {
 	Properties map[string]pldtypes.RawJSON `json:"properties"`
 	Children   map[string]*StaticEntry     `json:"children"`
+	// Revoked entries are stored as inactive, so they (and any children) cannot be resolved for transport
+	Revoked bool `json:"revoked"`
 }
@@ -82,6 +82,11 @@ func (r *staticRegistry) HandleRegistryEvents(ctx context.Context, req *prototk.
 	return nil, i18n.NewError(ctx, msgs.MsgFunctionUnsupported)
 }
 
+func (r *staticRegistry) PrepareRegistryTransactions(ctx context.Context, req *prototk.PrepareRegistryTransactionsRequest) (*prototk.PrepareRegistryTransactionsResponse, error) {
+	// The static registry is read-only, as all entries come from the configuration
+	return nil, i18n.NewError(ctx, msgs.MsgFunctionUnsupported)
+}
+
 func (r *staticRegistry) recurseBuildUpsert(ctx context.Context, req *prototk.UpsertRegistryRecordsRequest, parentID pldtypes.HexBytes, name string, inEntry *StaticEntry) error {
 
 	idHash := sha3.NewLegacyKeccak256()
@@ -94,7 +99,7 @@ func (r *staticRegistry) recurseBuildUpsert(ctx context.Context, req *prototk.Up
 		Id:       entryID.String(),
 		Name:     name,
 		ParentId: parentID.String(),
-		Active:   true,
+		Active:   !inEntry.Revoked,
 	}
 	properties := make([]*prototk.RegistryProperty, 0, len(inEntry.Properties))
 	for propName, jsonValue := range inEntry.Properties {
@@ -122,6 +122,36 @@ func TestRegistryHierarchicalEntry(t *testing.T) {
 
 }
 
+func TestRegistryRevokedEntry(t *testing.T) {
+
+	callbacks := &testCallbacks{
+		upsertRegistryRecords: func(ctx context.Context, req *prototk.UpsertRegistryRecordsRequest) (*prototk.UpsertRegistryRecordsResponse, error) {
+			require.Len(t, req.Entries, 1)
+			assert.Equal(t, "node1", req.Entries[0].Name)
+			assert.False(t, req.Entries[0].Active)
+			require.Len(t, req.Properties, 1)
+			assert.Equal(t, req.Entries[0].Id, req.Properties[0].EntryId)
+			return &prototk.UpsertRegistryRecordsResponse{}, nil
+		},
+	}
+	transport := NewStatic(callbacks).(*staticRegistry)
+	_, err := transport.ConfigureRegistry(transport.bgCtx, &prototk.ConfigureRegistryRequest{
+		Name: "registry1",
+		ConfigJson: `{
+		  "entries": {
+		     "node1": {
+			   "revoked": true,
+			   "properties": {
+			      "transport.grpc": "these are directly the details of the transport"
+			   }
+			 }
+		  }
+		}`,
+	})
+	require.NoError(t, err)
+
+}
+
 func TestRegistryObjectEntry(t *testing.T) {
 
 	callbacks := &testCallbacks{
@@ -188,6 +218,9 @@ func TestRegistryEventBatch(t *testing.T) {
 	_, err := transport.HandleRegistryEvents(context.Background(), &prototk.HandleRegistryEventsRequest{})
 	assert.Regexp(t, "PD040002", err)
 
+	_, err = transport.PrepareRegistryTransactions(context.Background(), &prototk.PrepareRegistryTransactionsRequest{})
+	assert.Regexp(t, "PD040002", err)
+
 }
 
 func TestRegistryUpsertBadData(t *testing.T) {
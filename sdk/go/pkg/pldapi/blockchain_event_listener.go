@@ -32,14 +32,50 @@ type BlockchainEventListener struct {
 }
 
 type BlockchainEventListenerOptions struct {
-	BatchSize    *int            `docstruct:"BlockchainEventListenerOptions" json:"batchSize,omitempty"`
-	BatchTimeout *string         `docstruct:"BlockchainEventListenerOptions" json:"batchTimeout,omitempty"`
-	FromBlock    json.RawMessage `docstruct:"BlockchainEventListenerOptions" json:"fromBlock,omitempty"`
+	BatchSize           *int                                              `docstruct:"BlockchainEventListenerOptions" json:"batchSize,omitempty"`
+	BatchTimeout        *string                                           `docstruct:"BlockchainEventListenerOptions" json:"batchTimeout,omitempty"`
+	FromBlock           json.RawMessage                                   `docstruct:"BlockchainEventListenerOptions" json:"fromBlock,omitempty"`
+	Webhook             *ListenerWebhook                                  `docstruct:"BlockchainEventListenerOptions" json:"webhook,omitempty"`
+	MaxRedeliveries     *int                                              `docstruct:"BlockchainEventListenerOptions" json:"maxRedeliveries,omitempty"`
+	Unconfirmed         *bool                                             `docstruct:"BlockchainEventListenerOptions" json:"unconfirmed,omitempty"`
+	DecodeFailurePolicy pldtypes.Enum[BlockchainEventDecodeFailurePolicy] `docstruct:"BlockchainEventListenerOptions" json:"decodeFailurePolicy,omitempty"`
+}
+
+type BlockchainEventDecodeFailurePolicy string
+
+const (
+	BlockchainEventDecodeFailurePolicySkip    BlockchainEventDecodeFailurePolicy = "skip"    // the event is not delivered
+	BlockchainEventDecodeFailurePolicyDeliver BlockchainEventDecodeFailurePolicy = "deliver" // the event is delivered without any data
+	BlockchainEventDecodeFailurePolicyHalt    BlockchainEventDecodeFailurePolicy = "halt"    // the listener stops before the event, until its checkpoint is reset
+)
+
+func (p BlockchainEventDecodeFailurePolicy) Enum() pldtypes.Enum[BlockchainEventDecodeFailurePolicy] {
+	return pldtypes.Enum[BlockchainEventDecodeFailurePolicy](p)
+}
+
+func (p BlockchainEventDecodeFailurePolicy) Options() []string {
+	return []string{
+		string(BlockchainEventDecodeFailurePolicySkip),
+		string(BlockchainEventDecodeFailurePolicyDeliver),
+		string(BlockchainEventDecodeFailurePolicyHalt),
+	}
+}
+
+func (p BlockchainEventDecodeFailurePolicy) Default() string {
+	return string(BlockchainEventDecodeFailurePolicySkip)
 }
 
 type BlockchainEventListenerSource struct {
-	ABI     abi.ABI              `docstruct:"BlockchainEventListenerSource" json:"abi"`
-	Address *pldtypes.EthAddress `docstruct:"BlockchainEventListenerSource" json:"address,omitempty"`
+	ABI     abi.ABI                         `docstruct:"BlockchainEventListenerSource" json:"abi"`
+	Address *pldtypes.EthAddress            `docstruct:"BlockchainEventListenerSource" json:"address,omitempty"`
+	Topics  [][]pldtypes.HexBytes           `docstruct:"BlockchainEventListenerSource" json:"topics,omitempty"`
+	Factory *BlockchainEventListenerFactory `docstruct:"BlockchainEventListenerSource" json:"factory,omitempty"`
+}
+
+type BlockchainEventListenerFactory struct {
+	Address      pldtypes.EthAddress `docstruct:"BlockchainEventListenerFactory" json:"address"`
+	Event        *abi.Entry          `docstruct:"BlockchainEventListenerFactory" json:"event"`
+	AddressParam string              `docstruct:"BlockchainEventListenerFactory" json:"addressParam"`
 }
 
 type BlockchainEventListenerStatus struct {
@@ -82,4 +82,8 @@ type EventWithData struct {
 
 	Address pldtypes.EthAddress `docstruct:"EventWithData" json:"address"`
 	Data    pldtypes.RawJSON    `docstruct:"EventWithData" json:"data"`
+
+	// Only set on events delivered by event streams that deliver unconfirmed events, when the block containing
+	// an event that was previously delivered has been replaced by a re-org of the chain
+	Removed bool `docstruct:"EventWithData" json:"removed,omitempty"`
 }
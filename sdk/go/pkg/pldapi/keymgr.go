@@ -16,6 +16,8 @@
 
 package pldapi
 
+import "github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+
 type WalletInfo struct {
 	Name                    string `docstruct:"WalletInfo" json:"name"`
 	KeySelector             string `docstruct:"WalletInfo" json:"keySelector"`
@@ -23,9 +25,11 @@ type WalletInfo struct {
 }
 
 type KeyMapping struct {
-	Identifier string `docstruct:"KeyMapping" json:"identifier"` // the full identifier used to look up this key (including "." separators)
-	Wallet     string `docstruct:"KeyMapping" json:"wallet"`     // the name of the wallet containing this key
-	KeyHandle  string `docstruct:"KeyMapping" json:"keyHandle"`  // the handle within the wallet containing the key
+	Identifier string              `docstruct:"KeyMapping" json:"identifier"`         // the full identifier used to look up this key (including "." separators)
+	Wallet     string              `docstruct:"KeyMapping" json:"wallet"`             // the name of the wallet containing this key
+	KeyHandle  string              `docstruct:"KeyMapping" json:"keyHandle"`          // the handle within the wallet containing the key
+	Rotation   int64               `docstruct:"KeyMapping" json:"rotation,omitempty"` // the number of times the key has been rotated
+	Disabled   *pldtypes.Timestamp `docstruct:"KeyMapping" json:"disabled,omitempty"` // set if the key is disabled for new signing
 }
 
 type KeyMappingWithPath struct {
@@ -35,7 +39,14 @@ type KeyMappingWithPath struct {
 
 type KeyMappingAndVerifier struct {
 	*KeyMappingWithPath `json:",inline"`
-	Verifier            *KeyVerifier `docstruct:"KeyMappingAndVerifier" json:"verifier"`
+	Verifier            *KeyVerifier        `docstruct:"KeyMappingAndVerifier" json:"verifier"`
+	Retired             *pldtypes.Timestamp `docstruct:"KeyMappingAndVerifier" json:"retired,omitempty"` // set if the verifier belongs to a key that has since been rotated
+}
+
+type KeyRotation struct {
+	*KeyMappingWithPath `json:",inline"`
+	Verifiers           []*KeyVerifier `docstruct:"KeyRotation" json:"verifiers"`        // the verifiers of the new key
+	RetiredVerifiers    []*KeyVerifier `docstruct:"KeyRotation" json:"retiredVerifiers"` // the verifiers of the previous key, which remain reverse resolvable
 }
 
 type KeyVerifierWithKeyRef struct {
@@ -55,13 +66,28 @@ type KeyPathSegment struct {
 }
 
 type KeyQueryEntry struct {
-	IsKey       bool           `docstruct:"KeyListEntry" json:"isKey"`
-	HasChildren bool           `docstruct:"KeyListEntry" json:"hasChildren"`
-	Parent      string         `docstruct:"KeyListEntry" json:"parent"`
-	Path        string         `docstruct:"KeyListEntry" json:"path"`
-	Name        string         `docstruct:"KeyListEntry" json:"name"`
-	Index       int64          `docstruct:"KeyListEntry" json:"index"`
-	Wallet      string         `docstruct:"KeyListEntry" json:"wallet"`
-	KeyHandle   string         `docstruct:"KeyListEntry" json:"keyHandle"`
-	Verifiers   []*KeyVerifier `docstruct:"KeyListEntry" json:"verifiers" gorm:"-"`
+	IsKey       bool                `docstruct:"KeyListEntry" json:"isKey"`
+	HasChildren bool                `docstruct:"KeyListEntry" json:"hasChildren"`
+	Parent      string              `docstruct:"KeyListEntry" json:"parent"`
+	Path        string              `docstruct:"KeyListEntry" json:"path"`
+	Name        string              `docstruct:"KeyListEntry" json:"name"`
+	Index       int64               `docstruct:"KeyListEntry" json:"index"`
+	Wallet      string              `docstruct:"KeyListEntry" json:"wallet"`
+	KeyHandle   string              `docstruct:"KeyListEntry" json:"keyHandle"`
+	Rotation    int64               `docstruct:"KeyListEntry" json:"rotation,omitempty"`
+	Disabled    *pldtypes.Timestamp `docstruct:"KeyListEntry" json:"disabled,omitempty"`
+	Verifiers   []*KeyVerifier      `docstruct:"KeyListEntry" json:"verifiers" gorm:"-"`
+}
+
+type KeySigningAuditEntry struct {
+	Sequence    uint64             `docstruct:"KeySigningAuditEntry" json:"sequence"`
+	Created     pldtypes.Timestamp `docstruct:"KeySigningAuditEntry" json:"created"`
+	Identifier  string             `docstruct:"KeySigningAuditEntry" json:"identifier"`
+	Wallet      string             `docstruct:"KeySigningAuditEntry" json:"wallet"`
+	KeyHandle   string             `docstruct:"KeySigningAuditEntry" json:"keyHandle"`
+	Algorithm   string             `docstruct:"KeySigningAuditEntry" json:"algorithm"`
+	Verifier    string             `docstruct:"KeySigningAuditEntry" json:"verifier"`
+	PayloadType string             `docstruct:"KeySigningAuditEntry" json:"payloadType"`
+	PayloadHash pldtypes.Bytes32   `docstruct:"KeySigningAuditEntry" json:"payloadHash"`
+	Error       string             `docstruct:"KeySigningAuditEntry" json:"error,omitempty"`
 }
@@ -0,0 +1,35 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+import (
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// A batch that could not be delivered by a listener within its maxRedeliveries limit.
+// The listener moves past the batch, and it is held until it is replayed or discarded.
+type ListenerDeadLetter struct {
+	ID        uuid.UUID          `docstruct:"ListenerDeadLetter" json:"id"`
+	Listener  string             `docstruct:"ListenerDeadLetter" json:"listener"`
+	Created   pldtypes.Timestamp `docstruct:"ListenerDeadLetter" json:"created"`
+	BatchID   string             `docstruct:"ListenerDeadLetter" json:"batchId"`
+	Attempts  int                `docstruct:"ListenerDeadLetter" json:"attempts"`
+	LastError string             `docstruct:"ListenerDeadLetter" json:"lastError"`
+	Replay    bool               `docstruct:"ListenerDeadLetter" json:"replay"`
+	Batch     pldtypes.RawJSON   `docstruct:"ListenerDeadLetter" json:"batch"`
+}
@@ -0,0 +1,34 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+// Delivers each batch from a listener as an HTTP POST to a URL, as an alternative to
+// consuming over a JSON/RPC WebSocket subscription
+type ListenerWebhook struct {
+	URL            string              `docstruct:"ListenerWebhook" json:"url"`
+	Headers        map[string]string   `docstruct:"ListenerWebhook" json:"headers,omitempty"`
+	HMACSecret     string              `docstruct:"ListenerWebhook" json:"hmacSecret,omitempty"`
+	RequestTimeout *string             `docstruct:"ListenerWebhook" json:"requestTimeout,omitempty"`
+	TLS            *ListenerWebhookTLS `docstruct:"ListenerWebhook" json:"tls,omitempty"`
+}
+
+type ListenerWebhookTLS struct {
+	CA                     string `docstruct:"ListenerWebhookTLS" json:"ca,omitempty"`
+	Cert                   string `docstruct:"ListenerWebhookTLS" json:"cert,omitempty"`
+	Key                    string `docstruct:"ListenerWebhookTLS" json:"key,omitempty"`
+	InsecureSkipHostVerify bool   `docstruct:"ListenerWebhookTLS" json:"insecureSkipHostVerify,omitempty"`
+}
@@ -25,6 +25,7 @@ type PeerInfo struct {
 	OutboundTransport string             `docstruct:"PeerInfo" json:"outboundTransport,omitempty"`
 	Outbound          map[string]any     `docstruct:"PeerInfo" json:"outbound,omitempty"`
 	OutboundError     error              `docstruct:"PeerInfo" json:"outboundError,omitempty"`
+	Reliable          *PeerReliableInfo  `docstruct:"PeerInfo" json:"reliable,omitempty"`
 }
 
 type PeerStats struct {
@@ -37,3 +38,11 @@ type PeerStats struct {
 	ReliableHighestSent uint64              `docstruct:"PeerStats" json:"reliableHighestSent"`
 	ReliableAckBase     uint64              `docstruct:"PeerStats" json:"reliableAckBase"`
 }
+
+type PeerReliableInfo struct {
+	Backlog          uint64              `docstruct:"PeerReliableInfo" json:"backlog"`
+	OldestUnacked    *pldtypes.Timestamp `docstruct:"PeerReliableInfo" json:"oldestUnacked,omitempty"`
+	OldestUnackedAge string              `docstruct:"PeerReliableInfo" json:"oldestUnackedAge,omitempty"`
+	LastError        string              `docstruct:"PeerReliableInfo" json:"lastError,omitempty"`
+	LastErrorTime    *pldtypes.Timestamp `docstruct:"PeerReliableInfo" json:"lastErrorTime,omitempty"`
+}
@@ -36,6 +36,8 @@ type PrivacyGroup struct {
 	GenesisSchema      pldtypes.Bytes32     `docstruct:"PrivacyGroup" json:"genesisSchema"`
 	GenesisTransaction uuid.UUID            `docstruct:"PrivacyGroup" json:"genesisTransaction"`
 	ContractAddress    *pldtypes.EthAddress `docstruct:"PrivacyGroup" json:"contractAddress"`
+	MembersRevision    int                  `docstruct:"PrivacyGroup" json:"membersRevision"`
+	MembersTransaction *uuid.UUID           `docstruct:"PrivacyGroup" json:"membersTransaction,omitempty"`
 }
 
 type PrivacyGroupTXOptions struct {
@@ -44,11 +46,12 @@ type PrivacyGroupTXOptions struct {
 }
 
 type PrivacyGroupMessage struct {
-	ID            uuid.UUID          `docstruct:"PrivacyGroupMessage" json:"id"`
-	LocalSequence uint64             `docstruct:"PrivacyGroupMessage" json:"localSequence"`
-	Sent          pldtypes.Timestamp `docstruct:"PrivacyGroupMessage" json:"sent"`
-	Received      pldtypes.Timestamp `docstruct:"PrivacyGroupMessage" json:"received"`
-	Node          string             `docstruct:"PrivacyGroupMessage" json:"node"`
+	ID            uuid.UUID           `docstruct:"PrivacyGroupMessage" json:"id"`
+	LocalSequence uint64              `docstruct:"PrivacyGroupMessage" json:"localSequence"`
+	Sent          pldtypes.Timestamp  `docstruct:"PrivacyGroupMessage" json:"sent"`
+	Received      pldtypes.Timestamp  `docstruct:"PrivacyGroupMessage" json:"received"`
+	Node          string              `docstruct:"PrivacyGroupMessage" json:"node"`
+	Redacted      *pldtypes.Timestamp `docstruct:"PrivacyGroupMessage" json:"redacted,omitempty"`
 	PrivacyGroupMessageInput
 }
 
@@ -69,6 +72,32 @@ type PrivacyGroupInput struct {
 	TransactionOptions *PrivacyGroupTXOptions `docstruct:"PrivacyGroupInput" json:"transactionOptions,omitempty"`
 }
 
+type PrivacyGroupMembersInput struct {
+	Domain             string                 `docstruct:"PrivacyGroupMembersInput" json:"domain"`
+	Group              pldtypes.HexBytes      `docstruct:"PrivacyGroupMembersInput" json:"group"`
+	Members            []string               `docstruct:"PrivacyGroupMembersInput" json:"members"`
+	TransactionOptions *PrivacyGroupTXOptions `docstruct:"PrivacyGroupMembersInput" json:"transactionOptions,omitempty"`
+}
+
+// Local policy of this node for the messages of a privacy group
+type PrivacyGroupMessagePolicy struct {
+	Domain    string                       `docstruct:"PrivacyGroupMessagePolicy" json:"domain"`
+	Group     pldtypes.HexBytes            `docstruct:"PrivacyGroupMessagePolicy" json:"group"`
+	Updated   pldtypes.Timestamp           `docstruct:"PrivacyGroupMessagePolicy" json:"updated"`
+	Retention PrivacyGroupMessageRetention `docstruct:"PrivacyGroupMessagePolicy" json:"retention"`
+	TopicACLs []*PrivacyGroupTopicACL      `docstruct:"PrivacyGroupMessagePolicy" json:"topicACLs,omitempty"`
+}
+
+type PrivacyGroupMessageRetention struct {
+	MaxAge   *string `docstruct:"PrivacyGroupMessageRetention" json:"maxAge,omitempty"`
+	MaxCount *int    `docstruct:"PrivacyGroupMessageRetention" json:"maxCount,omitempty"`
+}
+
+type PrivacyGroupTopicACL struct {
+	Topic string   `docstruct:"PrivacyGroupTopicACL" json:"topic"`
+	Nodes []string `docstruct:"PrivacyGroupTopicACL" json:"nodes"`
+}
+
 type PrivacyGroupEVMTX struct {
 	From     string               `docstruct:"PrivacyGroupEVMTX" json:"from,omitempty"` // signing key reference
 	To       *pldtypes.EthAddress `docstruct:"PrivacyGroupEVMTX" json:"to,omitempty"`
@@ -118,7 +147,8 @@ type PrivacyGroupMessageListenerFilters struct {
 }
 
 type PrivacyGroupMessageListenerOptions struct {
-	ExcludeLocal bool `docstruct:"MessageListenerOptions" json:"excludeLocal,omitempty"`
+	ExcludeLocal    bool `docstruct:"MessageListenerOptions" json:"excludeLocal,omitempty"`
+	MaxRedeliveries *int `docstruct:"MessageListenerOptions" json:"maxRedeliveries,omitempty"`
 }
 
 type PGroupEventType string
@@ -37,6 +37,18 @@ type RegistryProperty struct {
 	*ActiveFlag      `json:",omitempty"` // only returned from queries that explicitly look for inactive entries
 }
 
+// A record of a change to the value, or active state, of a registry property
+type RegistryPropertyChange struct {
+	Sequence         uint64              `docstruct:"RegistryPropertyChange" json:"sequence"`
+	Created          pldtypes.Timestamp  `docstruct:"RegistryPropertyChange" json:"created"`
+	Registry         string              `docstruct:"RegistryPropertyChange" json:"registry"`
+	EntryID          pldtypes.HexBytes   `docstruct:"RegistryPropertyChange" json:"entryId"`
+	Name             string              `docstruct:"RegistryPropertyChange" json:"name"`
+	Value            string              `docstruct:"RegistryPropertyChange" json:"value"`
+	Active           bool                `docstruct:"RegistryPropertyChange" json:"active"`
+	*OnChainLocation `json:",omitempty"` // only included if the registry uses blockchain indexing
+}
+
 type ActiveFlag struct {
 	Active bool `docstruct:"ActiveFlag" json:"active"`
 }
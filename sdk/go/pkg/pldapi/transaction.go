@@ -109,9 +109,11 @@ type Transaction struct {
 // The input structure, containing the base input/output fields, along with some convenience fields resolved on input
 type TransactionInput struct {
 	TransactionBase
-	DependsOn []uuid.UUID       `docstruct:"TransactionInput" json:"dependsOn,omitempty"` // these transactions must be mined on the blockchain successfully (or deleted) before this transaction submits. Failure of pre-reqs results in failure of this TX
-	ABI       abi.ABI           `docstruct:"TransactionInput" json:"abi,omitempty"`       // required if abiReference not supplied
-	Bytecode  pldtypes.HexBytes `docstruct:"TransactionInput" json:"bytecode,omitempty"`  // for deploy this is prepended to the encoded data inputs
+	DependsOn []uuid.UUID         `docstruct:"TransactionInput" json:"dependsOn,omitempty"` // these transactions must be mined on the blockchain successfully (or deleted) before this transaction submits. Failure of pre-reqs results in failure of this TX
+	NotBefore *pldtypes.Timestamp `docstruct:"TransactionInput" json:"notBefore,omitempty"` // the transaction is held until this time before it is processed
+	ExpiresAt *pldtypes.Timestamp `docstruct:"TransactionInput" json:"expiresAt,omitempty"` // if the transaction has not been mined by this time, it is finalized with a failure receipt
+	ABI       abi.ABI             `docstruct:"TransactionInput" json:"abi,omitempty"`       // required if abiReference not supplied
+	Bytecode  pldtypes.HexBytes   `docstruct:"TransactionInput" json:"bytecode,omitempty"`  // for deploy this is prepended to the encoded data inputs
 }
 
 // Call also provides some options on how to execute the call
@@ -130,6 +132,8 @@ type TransactionHistory struct {
 type TransactionFull struct {
 	*Transaction
 	DependsOn []uuid.UUID             `docstruct:"TransactionFull" json:"dependsOn,omitempty"` // transactions registered as dependencies when the transaction was created
+	NotBefore *pldtypes.Timestamp     `docstruct:"TransactionFull" json:"notBefore,omitempty"` // the time the transaction was held until before processing
+	ExpiresAt *pldtypes.Timestamp     `docstruct:"TransactionFull" json:"expiresAt,omitempty"` // the deadline for the transaction to be mined
 	Receipt   *TransactionReceiptData `docstruct:"TransactionFull" json:"receipt"`             // available if the transaction has reached a final state
 	Public    []*PublicTx             `docstruct:"TransactionFull" json:"public"`              // list of public transactions associated
 	History   []*TransactionHistory   `docstruct:"TransactionFull" json:"history,omitempty"`   // list of values previously provided for this transaction
@@ -155,6 +159,15 @@ type TransactionReceiptFull struct {
 	DomainReceiptError string             `docstruct:"TransactionReceiptFull" json:"domainReceiptError,omitempty"`
 }
 
+// TransactionExportRecord is a single line of a bulk export, driven by the receipt sequence
+type TransactionExportRecord struct {
+	Sequence    uint64              `docstruct:"TransactionExportRecord" json:"sequence"`
+	Receipt     *TransactionReceipt `docstruct:"TransactionExportRecord" json:"receipt"`
+	Transaction *Transaction        `docstruct:"TransactionExportRecord" json:"transaction,omitempty"`
+	Public      []*PublicTx         `docstruct:"TransactionExportRecord" json:"public,omitempty"`
+	States      *TransactionStates  `docstruct:"TransactionExportRecord" json:"states,omitempty"`
+}
+
 type TransactionReceiptBatch struct {
 	BatchID  uint64                    `docstruct:"TransactionReceiptBatch" json:"batchId,omitempty"`
 	Receipts []*TransactionReceiptFull `docstruct:"TransactionReceiptBatch" json:"receipts,omitempty"`
@@ -29,6 +29,7 @@ const (
 	RMTPreparedTransaction ReliableMessageType = "prepared_txn"
 	RMTPrivacyGroup        ReliableMessageType = "privacy_group"
 	RMTPrivacyGroupMessage ReliableMessageType = "privacy_group_message"
+	RMTPrivacyGroupMembers ReliableMessageType = "privacy_group_members"
 )
 
 func (t ReliableMessageType) Enum() pldtypes.Enum[ReliableMessageType] {
@@ -42,6 +43,7 @@ func (t ReliableMessageType) Options() []string {
 		string(RMTPreparedTransaction),
 		string(RMTPrivacyGroup),
 		string(RMTPrivacyGroupMessage),
+		string(RMTPrivacyGroupMembers),
 	}
 }
 
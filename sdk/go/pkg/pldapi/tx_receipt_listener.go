@@ -16,7 +16,10 @@
 
 package pldapi
 
-import "github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+import (
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
 
 type TransactionReceiptListener struct {
 	Name    string                            `docstruct:"TransactionReceiptListener" json:"name"`
@@ -30,6 +33,7 @@ type TransactionReceiptFilters struct {
 	SequenceAbove *uint64                         `docstruct:"TransactionReceiptFilters" json:"sequenceAbove,omitempty"`
 	Type          *pldtypes.Enum[TransactionType] `docstruct:"TransactionReceiptFilters" json:"type,omitempty"`
 	Domain        string                          `docstruct:"TransactionReceiptFilters" json:"domain,omitempty"`
+	Query         *query.QueryJSON                `docstruct:"TransactionReceiptFilters" json:"query,omitempty"`
 }
 
 type IncompleteStateReceiptBehavior string
@@ -57,4 +61,6 @@ func (tt IncompleteStateReceiptBehavior) Default() string {
 type TransactionReceiptListenerOptions struct {
 	DomainReceipts                 bool                                          `docstruct:"TransactionReceiptOptions" json:"domainReceipts"`
 	IncompleteStateReceiptBehavior pldtypes.Enum[IncompleteStateReceiptBehavior] `docstruct:"TransactionReceiptOptions" json:"incompleteStateReceiptBehavior,omitempty"`
+	Webhook                        *ListenerWebhook                              `docstruct:"TransactionReceiptOptions" json:"webhook,omitempty"`
+	MaxRedeliveries                *int                                          `docstruct:"TransactionReceiptOptions" json:"maxRedeliveries,omitempty"`
 }
@@ -20,6 +20,7 @@ import (
 
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 )
 
 type KeyManager interface {
@@ -29,6 +30,10 @@ type KeyManager interface {
 	ResolveKey(ctx context.Context, keyIdentifier, algorithm, verifierType string) (mapping *pldapi.KeyMappingAndVerifier, err error)
 	ResolveEthAddress(ctx context.Context, keyIdentifier string) (ethAddress *pldtypes.EthAddress, err error)
 	ReverseKeyLookup(ctx context.Context, algorithm, verifierType, verifier string) (mapping *pldapi.KeyMappingAndVerifier, err error)
+	DisableKey(ctx context.Context, keyIdentifier string) (success bool, err error)
+	EnableKey(ctx context.Context, keyIdentifier string) (success bool, err error)
+	RotateKey(ctx context.Context, keyIdentifier string) (rotation *pldapi.KeyRotation, err error)
+	QuerySigningAudit(ctx context.Context, query *query.QueryJSON) (entries []*pldapi.KeySigningAuditEntry, err error)
 }
 
 // This is necessary because there's no way to introspect function parameter names via reflection
@@ -51,6 +56,22 @@ var keymgrInfo = &rpcModuleInfo{
 			Inputs: []string{"algorithm", "verifierType", "verifier"},
 			Output: "mapping",
 		},
+		"keymgr_disableKey": {
+			Inputs: []string{"keyIdentifier"},
+			Output: "success",
+		},
+		"keymgr_enableKey": {
+			Inputs: []string{"keyIdentifier"},
+			Output: "success",
+		},
+		"keymgr_rotateKey": {
+			Inputs: []string{"keyIdentifier"},
+			Output: "rotation",
+		},
+		"keymgr_querySigningAudit": {
+			Inputs: []string{"query"},
+			Output: "entries",
+		},
 	},
 }
 
@@ -82,3 +103,23 @@ func (k *keymgr) ReverseKeyLookup(ctx context.Context, algorithm, verifierType,
 	err = k.c.CallRPC(ctx, &mapping, "keymgr_reverseKeyLookup", algorithm, verifierType, verifier)
 	return
 }
+
+func (k *keymgr) DisableKey(ctx context.Context, keyIdentifier string) (success bool, err error) {
+	err = k.c.CallRPC(ctx, &success, "keymgr_disableKey", keyIdentifier)
+	return
+}
+
+func (k *keymgr) EnableKey(ctx context.Context, keyIdentifier string) (success bool, err error) {
+	err = k.c.CallRPC(ctx, &success, "keymgr_enableKey", keyIdentifier)
+	return
+}
+
+func (k *keymgr) RotateKey(ctx context.Context, keyIdentifier string) (rotation *pldapi.KeyRotation, err error) {
+	err = k.c.CallRPC(ctx, &rotation, "keymgr_rotateKey", keyIdentifier)
+	return
+}
+
+func (k *keymgr) QuerySigningAudit(ctx context.Context, query *query.QueryJSON) (entries []*pldapi.KeySigningAuditEntry, err error) {
+	err = k.c.CallRPC(ctx, &entries, "keymgr_querySigningAudit", query)
+	return
+}
@@ -33,12 +33,17 @@ type PrivacyGroups interface {
 	GetGroupByAddress(ctx context.Context, addr pldtypes.EthAddress) (group *pldapi.PrivacyGroup, err error)
 	QueryGroups(ctx context.Context, jq *query.QueryJSON) (groups []*pldapi.PrivacyGroup, err error)
 	QueryGroupsWithMember(ctx context.Context, member string, jq *query.QueryJSON) (groups []*pldapi.PrivacyGroup, err error)
+	AddMembers(ctx context.Context, spec *pldapi.PrivacyGroupMembersInput) (group *pldapi.PrivacyGroup, err error)
+	RemoveMembers(ctx context.Context, spec *pldapi.PrivacyGroupMembersInput) (group *pldapi.PrivacyGroup, err error)
 	SendTransaction(ctx context.Context, tx *pldapi.PrivacyGroupEVMTXInput) (txID uuid.UUID, err error)
 	Call(ctx context.Context, call *pldapi.PrivacyGroupEVMCall) (data pldtypes.RawJSON, err error)
 
 	SendMessage(ctx context.Context, msg *pldapi.PrivacyGroupMessageInput) (msgID uuid.UUID, err error)
 	GetMessageById(ctx context.Context, id uuid.UUID) (msg *pldapi.PrivacyGroupMessage, err error)
 	QueryMessages(ctx context.Context, q *query.QueryJSON) (msgs []*pldapi.PrivacyGroupMessage, err error)
+	RedactMessage(ctx context.Context, id uuid.UUID) (msg *pldapi.PrivacyGroupMessage, err error)
+	SetMessagePolicy(ctx context.Context, policy *pldapi.PrivacyGroupMessagePolicy) (result *pldapi.PrivacyGroupMessagePolicy, err error)
+	GetMessagePolicy(ctx context.Context, domainName string, id pldtypes.HexBytes) (policy *pldapi.PrivacyGroupMessagePolicy, err error)
 
 	CreateMessageListener(ctx context.Context, listener *pldapi.PrivacyGroupMessageListener) (success bool, err error)
 	QueryMessageListeners(ctx context.Context, jq *query.QueryJSON) (listeners []*pldapi.PrivacyGroupMessageListener, err error)
@@ -46,6 +51,10 @@ type PrivacyGroups interface {
 	StartMessageListener(ctx context.Context, listenerName string) (success bool, err error)
 	StopMessageListener(ctx context.Context, listenerName string) (success bool, err error)
 	DeleteMessageListener(ctx context.Context, listenerName string) (success bool, err error)
+	QueryMessageListenerDeadLetters(ctx context.Context, listenerName string, jq *query.QueryJSON) (deadLetters []*pldapi.ListenerDeadLetter, err error)
+	ReplayMessageListenerDeadLetter(ctx context.Context, listenerName string, id uuid.UUID) (success bool, err error)
+	DiscardMessageListenerDeadLetter(ctx context.Context, listenerName string, id uuid.UUID) (success bool, err error)
+	ResetMessageListenerCheckpoint(ctx context.Context, listenerName string, sequence uint64) (success bool, err error)
 
 	SubscribeMessages(ctx context.Context, listenerName string) (sub rpcclient.Subscription, err error)
 }
@@ -82,6 +91,14 @@ var privacyGroupsInfo = &rpcModuleInfo{
 			Inputs: []string{"member", "query"},
 			Output: "pgroups",
 		},
+		"pgroup_addMembers": {
+			Inputs: []string{"spec"},
+			Output: "pgroup",
+		},
+		"pgroup_removeMembers": {
+			Inputs: []string{"spec"},
+			Output: "pgroup",
+		},
 		"pgroup_sendTransaction": {
 			Inputs: []string{"tx"},
 			Output: "transactionId",
@@ -102,6 +119,18 @@ var privacyGroupsInfo = &rpcModuleInfo{
 			Inputs: []string{"query"},
 			Output: "msgs",
 		},
+		"pgroup_redactMessage": {
+			Inputs: []string{"id"},
+			Output: "msg",
+		},
+		"pgroup_setMessagePolicy": {
+			Inputs: []string{"policy"},
+			Output: "policy",
+		},
+		"pgroup_getMessagePolicy": {
+			Inputs: []string{"domainName", "id"},
+			Output: "policy",
+		},
 		"pgroup_createMessageListener": {
 			Inputs: []string{"listener"},
 			Output: "success",
@@ -126,6 +155,22 @@ var privacyGroupsInfo = &rpcModuleInfo{
 			Inputs: []string{"listenerName"},
 			Output: "success",
 		},
+		"pgroup_queryMessageListenerDeadLetters": {
+			Inputs: []string{"listenerName", "query"},
+			Output: "deadLetters",
+		},
+		"pgroup_replayMessageListenerDeadLetter": {
+			Inputs: []string{"listenerName", "id"},
+			Output: "success",
+		},
+		"pgroup_discardMessageListenerDeadLetter": {
+			Inputs: []string{"listenerName", "id"},
+			Output: "success",
+		},
+		"pgroup_resetMessageListenerCheckpoint": {
+			Inputs: []string{"listenerName", "sequence"},
+			Output: "success",
+		},
 	},
 	subscriptions: []RPCSubscriptionInfo{
 		{
@@ -170,6 +215,16 @@ func (r *pgroup) QueryGroupsWithMember(ctx context.Context, member string, jq *q
 	return
 }
 
+func (r *pgroup) AddMembers(ctx context.Context, spec *pldapi.PrivacyGroupMembersInput) (group *pldapi.PrivacyGroup, err error) {
+	err = r.c.CallRPC(ctx, &group, "pgroup_addMembers", spec)
+	return
+}
+
+func (r *pgroup) RemoveMembers(ctx context.Context, spec *pldapi.PrivacyGroupMembersInput) (group *pldapi.PrivacyGroup, err error) {
+	err = r.c.CallRPC(ctx, &group, "pgroup_removeMembers", spec)
+	return
+}
+
 func (r *pgroup) SendTransaction(ctx context.Context, tx *pldapi.PrivacyGroupEVMTXInput) (txID uuid.UUID, err error) {
 	err = r.c.CallRPC(ctx, &txID, "pgroup_sendTransaction", tx)
 	return
@@ -195,6 +250,21 @@ func (r *pgroup) QueryMessages(ctx context.Context, jq *query.QueryJSON) (msgs [
 	return
 }
 
+func (r *pgroup) RedactMessage(ctx context.Context, id uuid.UUID) (msg *pldapi.PrivacyGroupMessage, err error) {
+	err = r.c.CallRPC(ctx, &msg, "pgroup_redactMessage", id)
+	return
+}
+
+func (r *pgroup) SetMessagePolicy(ctx context.Context, policy *pldapi.PrivacyGroupMessagePolicy) (result *pldapi.PrivacyGroupMessagePolicy, err error) {
+	err = r.c.CallRPC(ctx, &result, "pgroup_setMessagePolicy", policy)
+	return
+}
+
+func (r *pgroup) GetMessagePolicy(ctx context.Context, domainName string, id pldtypes.HexBytes) (policy *pldapi.PrivacyGroupMessagePolicy, err error) {
+	err = r.c.CallRPC(ctx, &policy, "pgroup_getMessagePolicy", domainName, id)
+	return
+}
+
 func (r *pgroup) CreateMessageListener(ctx context.Context, listener *pldapi.PrivacyGroupMessageListener) (success bool, err error) {
 	err = r.c.CallRPC(ctx, &success, "pgroup_createMessageListener", listener)
 	return
@@ -225,6 +295,26 @@ func (r *pgroup) DeleteMessageListener(ctx context.Context, listenerName string)
 	return
 }
 
+func (r *pgroup) QueryMessageListenerDeadLetters(ctx context.Context, listenerName string, jq *query.QueryJSON) (deadLetters []*pldapi.ListenerDeadLetter, err error) {
+	err = r.c.CallRPC(ctx, &deadLetters, "pgroup_queryMessageListenerDeadLetters", listenerName, jq)
+	return
+}
+
+func (r *pgroup) ReplayMessageListenerDeadLetter(ctx context.Context, listenerName string, id uuid.UUID) (success bool, err error) {
+	err = r.c.CallRPC(ctx, &success, "pgroup_replayMessageListenerDeadLetter", listenerName, id)
+	return
+}
+
+func (r *pgroup) DiscardMessageListenerDeadLetter(ctx context.Context, listenerName string, id uuid.UUID) (success bool, err error) {
+	err = r.c.CallRPC(ctx, &success, "pgroup_discardMessageListenerDeadLetter", listenerName, id)
+	return
+}
+
+func (r *pgroup) ResetMessageListenerCheckpoint(ctx context.Context, listenerName string, sequence uint64) (success bool, err error) {
+	err = r.c.CallRPC(ctx, &success, "pgroup_resetMessageListenerCheckpoint", listenerName, sequence)
+	return
+}
+
 func (r *pgroup) SubscribeMessages(ctx context.Context, listenerName string) (sub rpcclient.Subscription, err error) {
 	ws, err := r.c.WSClient(ctx)
 	if err != nil {
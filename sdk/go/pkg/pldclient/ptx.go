@@ -59,12 +59,20 @@ type PTX interface {
 
 	ResolveVerifier(ctx context.Context, keyIdentifier string, algorithm string, verifierType string) (verifier string, err error)
 
+	SuspendPublicTransaction(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (success bool, err error)
+	ResumePublicTransaction(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (success bool, err error)
+	CancelPublicTransaction(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (success bool, err error)
+
 	CreateReceiptListener(ctx context.Context, listener *pldapi.TransactionReceiptListener) (success bool, err error)
 	QueryReceiptListeners(ctx context.Context, jq *query.QueryJSON) (listeners []*pldapi.TransactionReceiptListener, err error)
 	GetReceiptListener(ctx context.Context, listenerName string) (listener *pldapi.TransactionReceiptListener, err error)
 	StartReceiptListener(ctx context.Context, listenerName string) (success bool, err error)
 	StopReceiptListener(ctx context.Context, listenerName string) (success bool, err error)
 	DeleteReceiptListener(ctx context.Context, listenerName string) (success bool, err error)
+	QueryReceiptListenerDeadLetters(ctx context.Context, listenerName string, jq *query.QueryJSON) (deadLetters []*pldapi.ListenerDeadLetter, err error)
+	ReplayReceiptListenerDeadLetter(ctx context.Context, listenerName string, id uuid.UUID) (success bool, err error)
+	DiscardReceiptListenerDeadLetter(ctx context.Context, listenerName string, id uuid.UUID) (success bool, err error)
+	ResetReceiptListenerCheckpoint(ctx context.Context, listenerName string, sequence uint64) (success bool, err error)
 
 	CreateBlockchainEventListener(ctx context.Context, listener *pldapi.BlockchainEventListener) (success bool, err error)
 	QueryBlockchainEventListeners(ctx context.Context, jq *query.QueryJSON) (listeners []*pldapi.BlockchainEventListener, err error)
@@ -73,6 +81,10 @@ type PTX interface {
 	StopBlockchainEventListener(ctx context.Context, listenerName string) (success bool, err error)
 	DeleteBlockchainEventListener(ctx context.Context, listenerName string) (success bool, err error)
 	GetBlockchainEventListenerStatus(ctx context.Context, name string) (*pldapi.BlockchainEventListenerStatus, error)
+	QueryBlockchainEventListenerDeadLetters(ctx context.Context, listenerName string, jq *query.QueryJSON) (deadLetters []*pldapi.ListenerDeadLetter, err error)
+	ReplayBlockchainEventListenerDeadLetter(ctx context.Context, listenerName string, id uuid.UUID) (success bool, err error)
+	DiscardBlockchainEventListenerDeadLetter(ctx context.Context, listenerName string, id uuid.UUID) (success bool, err error)
+	ResetBlockchainEventListenerCheckpoint(ctx context.Context, listenerName string, blockNumber int64) (success bool, err error)
 
 	SubscribeReceipts(ctx context.Context, listenerName string) (sub rpcclient.Subscription, err error)
 	SubscribeBlockchainEvents(ctx context.Context, listenerName string) (sub rpcclient.Subscription, err error)
@@ -190,6 +202,18 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"keyIdentifier", "algorithm", "verifierType"},
 			Output: "verifier",
 		},
+		"ptx_suspendPublicTransaction": {
+			Inputs: []string{"from", "nonce"},
+			Output: "success",
+		},
+		"ptx_resumePublicTransaction": {
+			Inputs: []string{"from", "nonce"},
+			Output: "success",
+		},
+		"ptx_cancelPublicTransaction": {
+			Inputs: []string{"from", "nonce"},
+			Output: "success",
+		},
 		"ptx_createReceiptListener": {
 			Inputs: []string{"listener"},
 			Output: "success",
@@ -214,6 +238,22 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"listenerName"},
 			Output: "success",
 		},
+		"ptx_queryReceiptListenerDeadLetters": {
+			Inputs: []string{"listenerName", "query"},
+			Output: "deadLetters",
+		},
+		"ptx_replayReceiptListenerDeadLetter": {
+			Inputs: []string{"listenerName", "id"},
+			Output: "success",
+		},
+		"ptx_discardReceiptListenerDeadLetter": {
+			Inputs: []string{"listenerName", "id"},
+			Output: "success",
+		},
+		"ptx_resetReceiptListenerCheckpoint": {
+			Inputs: []string{"listenerName", "sequence"},
+			Output: "success",
+		},
 		"ptx_createBlockchainEventListener": {
 			Inputs: []string{"listener"},
 			Output: "success",
@@ -242,6 +282,22 @@ var ptxInfo = &rpcModuleInfo{
 			Inputs: []string{"listenerName"},
 			Output: "listenerStatus",
 		},
+		"ptx_queryBlockchainEventListenerDeadLetters": {
+			Inputs: []string{"listenerName", "query"},
+			Output: "deadLetters",
+		},
+		"ptx_replayBlockchainEventListenerDeadLetter": {
+			Inputs: []string{"listenerName", "id"},
+			Output: "success",
+		},
+		"ptx_discardBlockchainEventListenerDeadLetter": {
+			Inputs: []string{"listenerName", "id"},
+			Output: "success",
+		},
+		"ptx_resetBlockchainEventListenerCheckpoint": {
+			Inputs: []string{"listenerName", "blockNumber"},
+			Output: "success",
+		},
 	},
 	subscriptions: []RPCSubscriptionInfo{
 		{
@@ -406,6 +462,21 @@ func (p *ptx) GetReceiptListener(ctx context.Context, listenerName string) (list
 	return
 }
 
+func (p *ptx) SuspendPublicTransaction(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (success bool, err error) {
+	err = p.c.CallRPC(ctx, &success, "ptx_suspendPublicTransaction", from, nonce)
+	return
+}
+
+func (p *ptx) ResumePublicTransaction(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (success bool, err error) {
+	err = p.c.CallRPC(ctx, &success, "ptx_resumePublicTransaction", from, nonce)
+	return
+}
+
+func (p *ptx) CancelPublicTransaction(ctx context.Context, from pldtypes.EthAddress, nonce pldtypes.HexUint64) (success bool, err error) {
+	err = p.c.CallRPC(ctx, &success, "ptx_cancelPublicTransaction", from, nonce)
+	return
+}
+
 func (p *ptx) StartReceiptListener(ctx context.Context, listenerName string) (success bool, err error) {
 	err = p.c.CallRPC(ctx, &success, "ptx_startReceiptListener", listenerName)
 	return
@@ -421,6 +492,26 @@ func (p *ptx) DeleteReceiptListener(ctx context.Context, listenerName string) (s
 	return
 }
 
+func (p *ptx) QueryReceiptListenerDeadLetters(ctx context.Context, listenerName string, jq *query.QueryJSON) (deadLetters []*pldapi.ListenerDeadLetter, err error) {
+	err = p.c.CallRPC(ctx, &deadLetters, "ptx_queryReceiptListenerDeadLetters", listenerName, jq)
+	return
+}
+
+func (p *ptx) ReplayReceiptListenerDeadLetter(ctx context.Context, listenerName string, id uuid.UUID) (success bool, err error) {
+	err = p.c.CallRPC(ctx, &success, "ptx_replayReceiptListenerDeadLetter", listenerName, id)
+	return
+}
+
+func (p *ptx) DiscardReceiptListenerDeadLetter(ctx context.Context, listenerName string, id uuid.UUID) (success bool, err error) {
+	err = p.c.CallRPC(ctx, &success, "ptx_discardReceiptListenerDeadLetter", listenerName, id)
+	return
+}
+
+func (p *ptx) ResetReceiptListenerCheckpoint(ctx context.Context, listenerName string, sequence uint64) (success bool, err error) {
+	err = p.c.CallRPC(ctx, &success, "ptx_resetReceiptListenerCheckpoint", listenerName, sequence)
+	return
+}
+
 func (p *ptx) CreateBlockchainEventListener(ctx context.Context, listener *pldapi.BlockchainEventListener) (success bool, err error) {
 	err = p.c.CallRPC(ctx, &success, "ptx_createBlockchainEventListener", listener)
 	return
@@ -456,6 +547,26 @@ func (p *ptx) GetBlockchainEventListenerStatus(ctx context.Context, listenerName
 	return
 }
 
+func (p *ptx) QueryBlockchainEventListenerDeadLetters(ctx context.Context, listenerName string, jq *query.QueryJSON) (deadLetters []*pldapi.ListenerDeadLetter, err error) {
+	err = p.c.CallRPC(ctx, &deadLetters, "ptx_queryBlockchainEventListenerDeadLetters", listenerName, jq)
+	return
+}
+
+func (p *ptx) ReplayBlockchainEventListenerDeadLetter(ctx context.Context, listenerName string, id uuid.UUID) (success bool, err error) {
+	err = p.c.CallRPC(ctx, &success, "ptx_replayBlockchainEventListenerDeadLetter", listenerName, id)
+	return
+}
+
+func (p *ptx) DiscardBlockchainEventListenerDeadLetter(ctx context.Context, listenerName string, id uuid.UUID) (success bool, err error) {
+	err = p.c.CallRPC(ctx, &success, "ptx_discardBlockchainEventListenerDeadLetter", listenerName, id)
+	return
+}
+
+func (p *ptx) ResetBlockchainEventListenerCheckpoint(ctx context.Context, listenerName string, blockNumber int64) (success bool, err error) {
+	err = p.c.CallRPC(ctx, &success, "ptx_resetBlockchainEventListenerCheckpoint", listenerName, blockNumber)
+	return
+}
+
 func (p *ptx) SubscribeReceipts(ctx context.Context, listenerName string) (sub rpcclient.Subscription, err error) {
 	ws, err := p.c.WSClient(ctx)
 	if err != nil {
@@ -18,6 +18,7 @@ package pldclient
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
@@ -30,6 +31,8 @@ type Registry interface {
 	QueryEntries(ctx context.Context, registryName string, jq query.QueryJSON, activeFilter pldtypes.Enum[pldapi.ActiveFilter]) (entries []*pldapi.RegistryEntry, err error)
 	QueryEntriesWithProps(ctx context.Context, registryName string, jq query.QueryJSON, activeFilter pldtypes.Enum[pldapi.ActiveFilter]) (entries []*pldapi.RegistryEntryWithProperties, err error)
 	GetEntryProperties(ctx context.Context, registryName string, entryID pldtypes.HexBytes, activeFilter pldtypes.Enum[pldapi.ActiveFilter]) (entries []*pldapi.RegistryProperty, err error)
+	QueryPropertyHistory(ctx context.Context, registryName string, jq query.QueryJSON) (changes []*pldapi.RegistryPropertyChange, err error)
+	PublishLocalTransports(ctx context.Context, registryName string) (txIDs []uuid.UUID, err error)
 }
 
 // This is necessary because there's no way to introspect function parameter names via reflection
@@ -52,6 +55,14 @@ var registryInfo = &rpcModuleInfo{
 			Inputs: []string{"registryName", "entryId", "activeFilter"},
 			Output: "properties",
 		},
+		"reg_queryPropertyHistory": {
+			Inputs: []string{"registryName", "query"},
+			Output: "changes",
+		},
+		"reg_publishLocalTransports": {
+			Inputs: []string{"registryName"},
+			Output: "transactionIds",
+		},
 	},
 }
 
@@ -83,3 +94,13 @@ func (r *registry) GetEntryProperties(ctx context.Context, registryName string,
 	err = r.c.CallRPC(ctx, &properties, "reg_getEntryProperties", registryName, entryID, activeFilter)
 	return
 }
+
+func (r *registry) QueryPropertyHistory(ctx context.Context, registryName string, jq query.QueryJSON) (changes []*pldapi.RegistryPropertyChange, err error) {
+	err = r.c.CallRPC(ctx, &changes, "reg_queryPropertyHistory", registryName, jq)
+	return
+}
+
+func (r *registry) PublishLocalTransports(ctx context.Context, registryName string) (txIDs []uuid.UUID, err error) {
+	err = r.c.CallRPC(ctx, &txIDs, "reg_publishLocalTransports", registryName)
+	return
+}
@@ -18,6 +18,7 @@ package pldclient
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 )
@@ -32,6 +33,9 @@ type Transport interface {
 	PeerInfo(ctx context.Context, nodeName string) (peer *pldapi.PeerInfo, err error)
 	QueryReliableMessages(ctx context.Context, query *query.QueryJSON) (reliableMessages []*pldapi.ReliableMessage, err error)
 	QueryReliableMessageAcks(ctx context.Context, query *query.QueryJSON) (reliableMessageAcks []*pldapi.ReliableMessageAck, err error)
+	ResendReliableMessage(ctx context.Context, messageID uuid.UUID) (reliableMessage *pldapi.ReliableMessage, err error)
+	ResendReliableMessages(ctx context.Context, nodeName string) (backlog uint64, err error)
+	AbandonReliableMessage(ctx context.Context, messageID uuid.UUID, reason string) (reliableMessage *pldapi.ReliableMessage, err error)
 }
 
 // This is necessary because there's no way to introspect function parameter names via reflection
@@ -66,6 +70,18 @@ var transportInfo = &rpcModuleInfo{
 			Inputs: []string{"query"},
 			Output: "reliableMessageAcks",
 		},
+		"transport_resendReliableMessage": {
+			Inputs: []string{"messageId"},
+			Output: "reliableMessage",
+		},
+		"transport_resendReliableMessages": {
+			Inputs: []string{"nodeName"},
+			Output: "backlog",
+		},
+		"transport_abandonReliableMessage": {
+			Inputs: []string{"messageId", "reason"},
+			Output: "reliableMessage",
+		},
 	},
 }
 
@@ -114,3 +130,18 @@ func (t *transport) QueryReliableMessageAcks(ctx context.Context, query *query.Q
 	err = t.c.CallRPC(ctx, &reliableMessageAcks, "transport_queryReliableMessageAcks", query)
 	return
 }
+
+func (t *transport) ResendReliableMessage(ctx context.Context, messageID uuid.UUID) (reliableMessage *pldapi.ReliableMessage, err error) {
+	err = t.c.CallRPC(ctx, &reliableMessage, "transport_resendReliableMessage", messageID)
+	return
+}
+
+func (t *transport) ResendReliableMessages(ctx context.Context, nodeName string) (backlog uint64, err error) {
+	err = t.c.CallRPC(ctx, &backlog, "transport_resendReliableMessages", nodeName)
+	return
+}
+
+func (t *transport) AbandonReliableMessage(ctx context.Context, messageID uuid.UUID, reason string) (reliableMessage *pldapi.ReliableMessage, err error) {
+	err = t.c.CallRPC(ctx, &reliableMessage, "transport_abandonReliableMessage", messageID, reason)
+	return
+}
@@ -1,6 +1,11 @@
 const Prefix_ECDSA = "ecdsa";
+const Prefix_EDDSA = "eddsa";
 const Curve_SECP256K1 = "secp256k1";
+const Curve_SECP256R1 = "secp256r1";
+const Curve_ED25519 = "ed25519";
 
 export enum Algorithms {
   ECDSA_SECP256K1 = Prefix_ECDSA + ":" + Curve_SECP256K1,
+  ECDSA_SECP256R1 = Prefix_ECDSA + ":" + Curve_SECP256R1,
+  EDDSA_ED25519 = Prefix_EDDSA + ":" + Curve_ED25519,
 }
@@ -10,9 +10,45 @@ export interface IBlockchainEventListenerOptions {
   batchSize?: number;
   batchTimeout?: string;
   fromBlock?: string;
+  webhook?: IListenerWebhook;
+  maxRedeliveries?: number;
+  unconfirmed?: boolean;
+  decodeFailurePolicy?: "skip" | "deliver" | "halt";
+}
+
+export interface IListenerWebhook {
+  url: string;
+  headers?: Record<string, string>;
+  hmacSecret?: string;
+  requestTimeout?: string;
+  tls?: {
+    ca?: string;
+    cert?: string;
+    key?: string;
+    insecureSkipHostVerify?: boolean;
+  };
+}
+
+export interface IListenerDeadLetter {
+  id: string;
+  listener: string;
+  created: string;
+  batchId: string;
+  attempts: number;
+  lastError: string;
+  replay: boolean;
+  batch: any;
 }
 
 export interface IBlockchainEventListenerSource {
   abi: ethers.JsonFragment[];
   address?: string;
+  topics?: string[][];
+  factory?: IBlockchainEventListenerFactory;
+}
+
+export interface IBlockchainEventListenerFactory {
+  address: string;
+  event: ethers.JsonFragment;
+  addressParam: string;
 }
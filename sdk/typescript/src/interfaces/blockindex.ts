@@ -30,4 +30,5 @@ export interface IEventWithData extends IIndexedEvent {
   soliditySignature: string;
   address: string;
   data: any;
+  removed?: boolean;
 }
@@ -17,6 +17,9 @@ export interface IKeyMappingAndVerifier {
     algorithm: string;
   };
   wallet: string;
+  rotation?: number;
+  disabled?: string;
+  retired?: string;
 }
 
 export interface IEthAddress {
@@ -27,9 +30,44 @@ export interface IKeyQueryEntry {
   identifier: string;
   keyHandle: string;
   wallet: string;
+  rotation?: number;
+  disabled?: string;
   verifiers: {
     verifier: string;
     type: string;
     algorithm: string;
   }[];
 }
+
+export interface IKeyVerifier {
+  verifier: string;
+  type: string;
+  algorithm: string;
+}
+
+export interface IKeyRotation {
+  identifier: string;
+  keyHandle: string;
+  wallet: string;
+  rotation: number;
+  disabled?: string;
+  path: {
+    index: number;
+    name: string;
+  }[];
+  verifiers: IKeyVerifier[];
+  retiredVerifiers: IKeyVerifier[];
+}
+
+export interface IKeySigningAuditEntry {
+  sequence: number;
+  created: string;
+  identifier: string;
+  wallet: string;
+  keyHandle: string;
+  algorithm: string;
+  verifier: string;
+  payloadType: string;
+  payloadHash: string;
+  error?: string;
+}
@@ -10,6 +10,13 @@ export interface IPrivacyGroupInput {
   transactionOptions?: IPrivacyGroupTXOptions;
 }
 
+export interface IPrivacyGroupMembersInput {
+  domain: string;
+  group: string;
+  members: string[];
+  transactionOptions?: IPrivacyGroupTXOptions;
+}
+
 export interface IPrivacyGroupResume {
   id: string;
 }
@@ -27,6 +34,8 @@ export interface IPrivacyGroup {
   genesisTransaction?: string;
   genesisSchema?: string;
   genesisSalt?: string;
+  membersRevision: number;
+  membersTransaction?: string;
 }
 
 export interface IPrivacyGroupEVMTX {
@@ -50,4 +59,22 @@ export interface IPrivacyGroupEVMCall extends IPrivacyGroupEVMTX {
   domain: string;
   group: string;
   dataFormat?: string;
-}
\ No newline at end of file
+}
+
+export interface IPrivacyGroupMessageRetention {
+  maxAge?: string;
+  maxCount?: number;
+}
+
+export interface IPrivacyGroupTopicACL {
+  topic: string;
+  nodes: string[];
+}
+
+export interface IPrivacyGroupMessagePolicy {
+  domain: string;
+  group: string;
+  updated?: string;
+  retention: IPrivacyGroupMessageRetention;
+  topicACLs?: IPrivacyGroupTopicACL[];
+}
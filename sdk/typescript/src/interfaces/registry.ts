@@ -20,6 +20,19 @@ export interface IRegistryProperty {
   active?: boolean;
 }
 
+export interface IRegistryPropertyChange {
+  sequence: number;
+  created: string;
+  registry: string;
+  entryId: string;
+  name: string;
+  value: string;
+  active: boolean;
+  blockNumber?: number;
+  transactionIndex?: number;
+  logIndex?: number;
+}
+
 export interface IRegistryEntryWithProperties extends IRegistryEntry {
   properties: { [key: string]: string };
 }
@@ -1,5 +1,7 @@
 import { BigNumberish, ethers } from "ethers";
 import { NotoUnlockPublicParams } from "../domains/noto";
+import { IListenerWebhook } from "./blockchainevent";
+import { IQuery } from "./query";
 import { IStateBase } from "./states";
 
 export interface IBlock {
@@ -58,6 +60,8 @@ export interface ITransactionInput extends ITransactionBase {
   abiReference?: string;
   abi?: ethers.InterfaceAbi;
   bytecode?: string;
+  notBefore?: string;
+  expiresAt?: string;
 }
 
 export interface ITransactionCall extends ITransactionInput {}
@@ -172,9 +176,12 @@ export interface ITransactionReceiptListener {
     sequenceAbove?: number;
     type?: TransactionType;
     domain?: string;
+    query?: IQuery;
   };
   options?: {
     domainReceipts?: boolean;
     incompleteStateReceiptBehavior?: "block_contract" | "process";
+    webhook?: IListenerWebhook;
+    maxRedeliveries?: number;
   };
 }
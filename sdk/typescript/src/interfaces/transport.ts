@@ -5,6 +5,15 @@ export interface IPeerInfo {
   outboundTransport?: string;
   outbound?: any;
   outboundError?: any;
+  reliable?: IPeerReliableInfo;
+}
+
+export interface IPeerReliableInfo {
+  backlog: number;
+  oldestUnacked?: string;
+  oldestUnackedAge?: string;
+  lastError?: string;
+  lastErrorTime?: string;
 }
 
 export interface IPeerStats {
@@ -3,4 +3,8 @@ export enum Verifiers {
   ETH_ADDRESS_CHECKSUM = "eth_address_checksum",
   HEX_ECDSA_PUBKEY_UNCOMPRESSED = "hex_ecdsa_pubkey_uncompressed",
   HEX_ECDSA_PUBKEY_UNCOMPRESSED_0X = "hex_ecdsa_pubkey_uncompressed_0x",
+  HEX_ED25519_PUBKEY = "hex_ed25519_pubkey",
+  HEX_ED25519_PUBKEY_0X = "hex_ed25519_pubkey_0x",
+  DID_KEY = "did_key",
+  JWK = "jwk",
 }
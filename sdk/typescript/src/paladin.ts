@@ -9,17 +9,25 @@ import {
   IEventWithData,
   IKeyMappingAndVerifier,
   IKeyQueryEntry,
+  IKeyRotation,
+  IKeySigningAuditEntry,
+  IListenerDeadLetter,
   INotoDomainReceipt,
+  IPeerInfo,
   IPenteDomainReceipt,
   IPreparedTransaction,
   IPrivacyGroup,
   IPrivacyGroupEVMCall,
   IPrivacyGroupEVMTXInput,
   IPrivacyGroupInput,
+  IPrivacyGroupMembersInput,
+  IPrivacyGroupMessagePolicy,
   IQuery,
   IRegistryEntry,
   IRegistryEntryWithProperties,
   IRegistryProperty,
+  IRegistryPropertyChange,
+  IReliableMessage,
   ISchema,
   IState,
   IStoredABI,
@@ -419,6 +427,38 @@ export default class PaladinClient {
       );
       return res.data.result;
     },
+
+    disableKey: async (identifier: string) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "keymgr_disableKey",
+        [identifier]
+      );
+      return res.data.result;
+    },
+
+    enableKey: async (identifier: string) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "keymgr_enableKey",
+        [identifier]
+      );
+      return res.data.result;
+    },
+
+    rotateKey: async (identifier: string) => {
+      const res = await this.post<JsonRpcResult<IKeyRotation>>(
+        "keymgr_rotateKey",
+        [identifier]
+      );
+      return res.data.result;
+    },
+
+    querySigningAudit: async (query: IQuery) => {
+      const res = await this.post<JsonRpcResult<IKeySigningAuditEntry[]>>(
+        "keymgr_querySigningAudit",
+        [query]
+      );
+      return res.data.result;
+    },
   };
 
   ptx = {
@@ -630,6 +670,30 @@ export default class PaladinClient {
       return res.status === 404 ? undefined : res.data.result;
     },
 
+    suspendPublicTransaction: async (from: string, nonce: number) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "ptx_suspendPublicTransaction",
+        [from, nonce]
+      );
+      return res.data.result;
+    },
+
+    resumePublicTransaction: async (from: string, nonce: number) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "ptx_resumePublicTransaction",
+        [from, nonce]
+      );
+      return res.data.result;
+    },
+
+    cancelPublicTransaction: async (from: string, nonce: number) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "ptx_cancelPublicTransaction",
+        [from, nonce]
+      );
+      return res.data.result;
+    },
+
     getPreparedTransaction: async (txID: string) => {
       const res = await this.post<JsonRpcResult<IPreparedTransaction>>(
         "ptx_getPreparedTransaction",
@@ -762,6 +826,38 @@ export default class PaladinClient {
       return res.data.result;
     },
 
+    queryReceiptListenerDeadLetters: async (name: string, query: IQuery) => {
+      const res = await this.post<JsonRpcResult<IListenerDeadLetter[]>>(
+        "ptx_queryReceiptListenerDeadLetters",
+        [name, query]
+      );
+      return res.data.result;
+    },
+
+    replayReceiptListenerDeadLetter: async (name: string, id: string) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "ptx_replayReceiptListenerDeadLetter",
+        [name, id]
+      );
+      return res.data.result;
+    },
+
+    discardReceiptListenerDeadLetter: async (name: string, id: string) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "ptx_discardReceiptListenerDeadLetter",
+        [name, id]
+      );
+      return res.data.result;
+    },
+
+    resetReceiptListenerCheckpoint: async (name: string, sequence: number) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "ptx_resetReceiptListenerCheckpoint",
+        [name, sequence]
+      );
+      return res.data.result;
+    },
+
     createBlockchainEventListener: async (
       listener: IBlockchainEventListener
     ) => {
@@ -821,6 +917,38 @@ export default class PaladinClient {
       );
       return res.status === 404 ? undefined : res.data.result;
     },
+
+    queryBlockchainEventListenerDeadLetters: async (name: string, query: IQuery) => {
+      const res = await this.post<JsonRpcResult<IListenerDeadLetter[]>>(
+        "ptx_queryBlockchainEventListenerDeadLetters",
+        [name, query]
+      );
+      return res.data.result;
+    },
+
+    replayBlockchainEventListenerDeadLetter: async (name: string, id: string) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "ptx_replayBlockchainEventListenerDeadLetter",
+        [name, id]
+      );
+      return res.data.result;
+    },
+
+    discardBlockchainEventListenerDeadLetter: async (name: string, id: string) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "ptx_discardBlockchainEventListenerDeadLetter",
+        [name, id]
+      );
+      return res.data.result;
+    },
+
+    resetBlockchainEventListenerCheckpoint: async (name: string, blockNumber: number) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "ptx_resetBlockchainEventListenerCheckpoint",
+        [name, blockNumber]
+      );
+      return res.data.result;
+    },
   };
 
   pstate = {
@@ -920,6 +1048,22 @@ export default class PaladinClient {
       return res.data.result;
     },
 
+    addMembers: async (spec: IPrivacyGroupMembersInput) => {
+      const res = await this.post<JsonRpcResult<IPrivacyGroup>>(
+        "pgroup_addMembers",
+        [spec]
+      );
+      return res.data.result;
+    },
+
+    removeMembers: async (spec: IPrivacyGroupMembersInput) => {
+      const res = await this.post<JsonRpcResult<IPrivacyGroup>>(
+        "pgroup_removeMembers",
+        [spec]
+      );
+      return res.data.result;
+    },
+
     getGroupById: async (domainName: string, id: string) => {
       const res = await this.post<JsonRpcResult<IPrivacyGroup>>(
         "pgroup_getGroupById",
@@ -989,6 +1133,28 @@ export default class PaladinClient {
       return res.data.result;
     },
 
+    redactMessage: async (id: string) => {
+      const res = await this.post<JsonRpcResult<any>>("pgroup_redactMessage", [
+        id,
+      ]);
+      return res.data.result;
+    },
+
+    setMessagePolicy: async (policy: IPrivacyGroupMessagePolicy) => {
+      const res = await this.post<JsonRpcResult<IPrivacyGroupMessagePolicy>>(
+        "pgroup_setMessagePolicy",
+        [policy]
+      );
+      return res.data.result;
+    },
+
+    getMessagePolicy: async (domainName: string, id: string) => {
+      const res = await this.post<
+        JsonRpcResult<IPrivacyGroupMessagePolicy | undefined>
+      >("pgroup_getMessagePolicy", [domainName, id]);
+      return res.data.result;
+    },
+
     createMessageListener: async (listener: any) => {
       const res = await this.post<JsonRpcResult<boolean>>(
         "pgroup_createMessageListener",
@@ -1037,6 +1203,38 @@ export default class PaladinClient {
       );
       return res.data.result;
     },
+
+    queryMessageListenerDeadLetters: async (name: string, query: IQuery) => {
+      const res = await this.post<JsonRpcResult<IListenerDeadLetter[]>>(
+        "pgroup_queryMessageListenerDeadLetters",
+        [name, query]
+      );
+      return res.data.result;
+    },
+
+    replayMessageListenerDeadLetter: async (name: string, id: string) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "pgroup_replayMessageListenerDeadLetter",
+        [name, id]
+      );
+      return res.data.result;
+    },
+
+    discardMessageListenerDeadLetter: async (name: string, id: string) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "pgroup_discardMessageListenerDeadLetter",
+        [name, id]
+      );
+      return res.data.result;
+    },
+
+    resetMessageListenerCheckpoint: async (name: string, sequence: number) => {
+      const res = await this.post<JsonRpcResult<boolean>>(
+        "pgroup_resetMessageListenerCheckpoint",
+        [name, sequence]
+      );
+      return res.data.result;
+    },
   };
 
   transport = {
@@ -1065,12 +1263,15 @@ export default class PaladinClient {
     },
 
     peers: async () => {
-      const res = await this.post<JsonRpcResult<any[]>>("transport_peers", []);
+      const res = await this.post<JsonRpcResult<IPeerInfo[]>>(
+        "transport_peers",
+        []
+      );
       return res.data.result;
     },
 
     peerInfo: async (nodeName: string) => {
-      const res = await this.post<JsonRpcResult<any>>(
+      const res = await this.post<JsonRpcResult<IPeerInfo>>(
         "transport_peerInfo",
         [nodeName],
         { validateStatus: (status) => status < 300 || status === 404 }
@@ -1093,6 +1294,30 @@ export default class PaladinClient {
       );
       return res.data.result;
     },
+
+    resendReliableMessage: async (messageId: string) => {
+      const res = await this.post<JsonRpcResult<IReliableMessage>>(
+        "transport_resendReliableMessage",
+        [messageId]
+      );
+      return res.data.result;
+    },
+
+    resendReliableMessages: async (nodeName: string) => {
+      const res = await this.post<JsonRpcResult<number>>(
+        "transport_resendReliableMessages",
+        [nodeName]
+      );
+      return res.data.result;
+    },
+
+    abandonReliableMessage: async (messageId: string, reason: string) => {
+      const res = await this.post<JsonRpcResult<IReliableMessage>>(
+        "transport_abandonReliableMessage",
+        [messageId, reason]
+      );
+      return res.data.result;
+    },
   };
 
   domain = {
@@ -1283,5 +1508,21 @@ export default class PaladinClient {
       );
       return res.data.result;
     },
+
+    queryPropertyHistory: async (registryName: string, query: IQuery) => {
+      const res = await this.post<JsonRpcResult<IRegistryPropertyChange[]>>(
+        "reg_queryPropertyHistory",
+        [registryName, query]
+      );
+      return res.data.result;
+    },
+
+    publishLocalTransports: async (registryName: string) => {
+      const res = await this.post<JsonRpcResult<string[]>>(
+        "reg_publishLocalTransports",
+        [registryName]
+      );
+      return res.data.result;
+    },
   };
 }
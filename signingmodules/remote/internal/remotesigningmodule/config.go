@@ -0,0 +1,44 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package remotesigningmodule
+
+import (
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+)
+
+type Config struct {
+	// HTTP connectivity to the remote signer, including mutual TLS and authentication
+	HTTP pldconf.HTTPClientConfig `json:"http"`
+	// Retry of requests that fail to reach the remote signer (errors returned by the remote signer are not retried)
+	Retry pldconf.RetryConfigWithMax `json:"retry"`
+	// Cache of resolved keys, so repeat resolution of the same key does not require a call to the remote signer
+	KeyCache pldconf.CacheConfig `json:"keyCache"`
+}
+
+var ConfigDefaults = &Config{
+	Retry: pldconf.RetryConfigWithMax{
+		RetryConfig: pldconf.RetryConfig{
+			InitialDelay: confutil.P("250ms"),
+			MaxDelay:     confutil.P("5s"),
+			Factor:       confutil.P(2.0),
+		},
+		MaxAttempts: confutil.P(5),
+	},
+	KeyCache: pldconf.CacheConfig{
+		Capacity: confutil.P(1000),
+	},
+}
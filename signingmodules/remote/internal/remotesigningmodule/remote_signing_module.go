@@ -0,0 +1,235 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package remotesigningmodule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldresty"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/signingmodules/remote/internal/msgs"
+	"github.com/kaleido-io/paladin/signingmodules/remote/pkg/remotesigner"
+	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+// The remote signer protocol is defined by the remotesigner package, and is independent of the
+// signing module plugin interface - so the requests and responses are mapped in each direction.
+//
+// Ethereum signer APIs such as Web3Signer (eth1/sign) and eth_sign hash the data they are given
+// (with keccak256, or the EIP-191 prefix) before signing. Paladin passes the digest to sign for
+// payload types such as opaque:rsv, so those APIs cannot produce the signatures it needs.
+
+// Where the remote signer (or a proxy in front of it) did not return a JSON/RPC response, these
+// HTTP statuses mean it is unavailable or overloaded, so the request is retried
+var retryableHTTPStatus = map[int]bool{
+	http.StatusRequestTimeout:     true,
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+type remoteSigningModule struct {
+	bgCtx          context.Context
+	callbacks      plugintk.SigningModuleCallbacks
+	conf           *Config
+	name           string
+	client         *resty.Client
+	requestCounter atomic.Int64
+	retry          *retry.Retry
+	keyCache       cache.Cache[string, *prototk.ResolveKeyResponse]
+}
+
+func NewPlugin(ctx context.Context) plugintk.PluginBase {
+	return plugintk.NewSigningModule(NewRemoteSigningModule)
+}
+
+func NewRemoteSigningModule(callbacks plugintk.SigningModuleCallbacks) plugintk.SigningModuleAPI {
+	return &remoteSigningModule{
+		bgCtx:     context.Background(),
+		callbacks: callbacks,
+	}
+}
+
+func (rsm *remoteSigningModule) ConfigureSigningModule(ctx context.Context, req *prototk.ConfigureSigningModuleRequest) (*prototk.ConfigureSigningModuleResponse, error) {
+	rsm.name = req.Name
+
+	// Extract the config
+	err := json.Unmarshal([]byte(req.ConfigJson), &rsm.conf)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgInvalidSigningModuleConfig)
+	}
+	if rsm.conf == nil || rsm.conf.HTTP.URL == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgRemoteSignerURLRequired)
+	}
+
+	// The HTTP client handles TLS (including mutual TLS), authentication and custom headers
+	rsm.client, err = pldresty.New(ctx, &rsm.conf.HTTP)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgInvalidSigningModuleConfig)
+	}
+	rsm.retry = retry.NewRetryLimited(&rsm.conf.Retry, &ConfigDefaults.Retry)
+	rsm.keyCache = cache.NewCache[string, *prototk.ResolveKeyResponse](&rsm.conf.KeyCache, &ConfigDefaults.KeyCache)
+
+	log.L(ctx).Infof("Remote signing module %s configured for %s", rsm.name, rsm.conf.HTTP.URL)
+	return &prototk.ConfigureSigningModuleResponse{}, nil
+}
+
+func (rsm *remoteSigningModule) ResolveKey(ctx context.Context, req *prototk.ResolveKeyRequest) (*prototk.ResolveKeyResponse, error) {
+	rsReq := mapResolveKeyRequest(req)
+	// The same request always resolves to the same key, so we can cache the response
+	cacheKey := pldtypes.JSONString(rsReq).String()
+	if res, ok := rsm.keyCache.Get(cacheKey); ok {
+		return res, nil
+	}
+	var rsRes remotesigner.ResolveKeyResponse
+	if err := rsm.call(ctx, remotesigner.RPCMethodResolveKey, rsReq, &rsRes); err != nil {
+		return nil, err
+	}
+	res := &prototk.ResolveKeyResponse{
+		KeyHandle:   rsRes.KeyHandle,
+		Identifiers: mapIdentifiers(rsRes.Identifiers),
+	}
+	rsm.keyCache.Set(cacheKey, res)
+	return res, nil
+}
+
+func (rsm *remoteSigningModule) Sign(ctx context.Context, req *prototk.SignWithKeyRequest) (*prototk.SignWithKeyResponse, error) {
+	var rsRes remotesigner.SignResponse
+	if err := rsm.call(ctx, remotesigner.RPCMethodSign, &remotesigner.SignRequest{
+		KeyHandle:   req.KeyHandle,
+		Algorithm:   req.Algorithm,
+		PayloadType: req.PayloadType,
+		Payload:     req.Payload,
+	}, &rsRes); err != nil {
+		return nil, err
+	}
+	return &prototk.SignWithKeyResponse{Payload: rsRes.Signature}, nil
+}
+
+func (rsm *remoteSigningModule) ListKeys(ctx context.Context, req *prototk.ListKeysRequest) (*prototk.ListKeysResponse, error) {
+	var rsRes remotesigner.ListKeysResponse
+	if err := rsm.call(ctx, remotesigner.RPCMethodListKeys, &remotesigner.ListKeysRequest{
+		Limit:    int(req.Limit),
+		Continue: req.Continue,
+	}, &rsRes); err != nil {
+		return nil, err
+	}
+	res := &prototk.ListKeysResponse{
+		Items: make([]*prototk.ListKeyEntry, len(rsRes.Items)),
+		Next:  rsRes.Next,
+	}
+	for i, item := range rsRes.Items {
+		entry := &prototk.ListKeyEntry{
+			Name:        item.Name,
+			KeyHandle:   item.KeyHandle,
+			Attributes:  item.Attributes,
+			Path:        make([]*prototk.ListKeyPathSegment, len(item.Path)),
+			Identifiers: mapIdentifiers(item.Identifiers),
+		}
+		for j, segment := range item.Path {
+			entry.Path[j] = &prototk.ListKeyPathSegment{Name: segment.Name}
+		}
+		res.Items[i] = entry
+	}
+	return res, nil
+}
+
+func mapResolveKeyRequest(req *prototk.ResolveKeyRequest) *remotesigner.ResolveKeyRequest {
+	rsReq := &remotesigner.ResolveKeyRequest{
+		Name:       req.Name,
+		Index:      req.Index,
+		Attributes: req.Attributes,
+	}
+	for _, segment := range req.Path {
+		rsReq.Path = append(rsReq.Path, &remotesigner.ResolveKeyPathSegment{Name: segment.Name, Index: segment.Index})
+	}
+	for _, required := range req.RequiredIdentifiers {
+		rsReq.RequiredIdentifiers = append(rsReq.RequiredIdentifiers, &remotesigner.PublicKeyIdentifierType{
+			Algorithm:    required.Algorithm,
+			VerifierType: required.VerifierType,
+		})
+	}
+	return rsReq
+}
+
+func mapIdentifiers(identifiers []*remotesigner.PublicKeyIdentifier) []*prototk.PublicKeyIdentifier {
+	mapped := make([]*prototk.PublicKeyIdentifier, len(identifiers))
+	for i, identifier := range identifiers {
+		mapped[i] = &prototk.PublicKeyIdentifier{
+			Algorithm:    identifier.Algorithm,
+			VerifierType: identifier.VerifierType,
+			Verifier:     identifier.Verifier,
+		}
+	}
+	return mapped
+}
+
+func (rsm *remoteSigningModule) Close(ctx context.Context, req *prototk.CloseRequest) (*prototk.CloseResponse, error) {
+	if rsm.keyCache != nil {
+		rsm.keyCache.Clear()
+	}
+	return &prototk.CloseResponse{}, nil
+}
+
+func (rsm *remoteSigningModule) call(ctx context.Context, method string, req, res any) error {
+	rpcReq := &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.JSONString(fmt.Sprintf("%.9d", rsm.requestCounter.Add(1))),
+		Method:  method,
+		Params:  []pldtypes.RawJSON{pldtypes.JSONString(req)},
+	}
+	var rpcRes *rpcclient.RPCResponse
+	err := rsm.retry.Do(ctx, func(attempt int) (retryable bool, err error) {
+		rpcRes = &rpcclient.RPCResponse{}
+		httpRes, err := rsm.client.R().
+			SetContext(ctx).
+			SetBody(rpcReq).
+			SetResult(rpcRes).
+			SetError(rpcRes).
+			Post("")
+		if err != nil {
+			// We did not reach the remote signer, or the connection failed before we got a response
+			return true, i18n.NewError(ctx, msgs.MsgRemoteSignerRequestFailed, method, err)
+		}
+		if rpcRes.Error != nil && rpcRes.Error.Code != 0 {
+			// An error returned by the remote signer itself (such as an unknown key) is final
+			return false, i18n.NewError(ctx, msgs.MsgRemoteSignerRequestFailed, method, rpcRes.Error.Message)
+		}
+		if httpRes.IsError() {
+			return retryableHTTPStatus[httpRes.StatusCode()], i18n.NewError(ctx, msgs.MsgRemoteSignerRequestFailed, method, httpRes.Status())
+		}
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(rpcRes.Result, res); err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgRemoteSignerInvalidResult, method)
+	}
+	return nil
+}
@@ -0,0 +1,507 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package remotesigningmodule
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/signingmodules/remote/pkg/remotesigner"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signer"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSeedSignerConfig = `{"keyDerivation": { "type": "bip32"}, "keyStore": { "type": "static", "static": { "keys": { "seed": { "encoding": "none", "inline": "field audit weird now route order gentle magnet plastic girl tree lake before super useful unit credit atom person crystal hair drama hole dove"}}}}}`
+
+type testCallbacks struct{}
+
+type testRemoteSigner struct {
+	server        rpcserver.RPCServer
+	url           string
+	resolveCalls  atomic.Int32
+	signCalls     atomic.Int32
+	listKeysCalls atomic.Int32
+}
+
+func buildTestCertificate(t *testing.T, subject pkix.Name, ca *x509.Certificate, caKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey, string, string) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024 /* smallish key to make the test faster */)
+	require.NoError(t, err)
+	privateKeyPEM := &strings.Builder{}
+	err = pem.Encode(privateKeyPEM, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	require.NoError(t, err)
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	require.NoError(t, err)
+	x509Template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               subject,
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(100 * time.Second),
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		DNSNames:              []string{"127.0.0.1", "localhost"},
+	}
+	if ca == nil {
+		ca = x509Template
+		caKey = privateKey
+		x509Template.IsCA = true
+		x509Template.KeyUsage |= x509.KeyUsageCertSign
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, x509Template, ca, &privateKey.PublicKey, caKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(derBytes)
+	require.NoError(t, err)
+	certPEM := &strings.Builder{}
+	err = pem.Encode(certPEM, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+	require.NoError(t, err)
+	return cert, privateKey, certPEM.String(), privateKeyPEM.String()
+}
+
+// The mock remote signer maps the remote signer protocol onto an in-memory signing module
+func resolveKeyHandler(sm signer.SigningModule, calls *atomic.Int32) rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, req remotesigner.ResolveKeyRequest) (*remotesigner.ResolveKeyResponse, error) {
+		calls.Add(1)
+		smReq := &prototk.ResolveKeyRequest{Name: req.Name, Index: req.Index, Attributes: req.Attributes}
+		for _, segment := range req.Path {
+			smReq.Path = append(smReq.Path, &prototk.ResolveKeyPathSegment{Name: segment.Name, Index: segment.Index})
+		}
+		for _, required := range req.RequiredIdentifiers {
+			smReq.RequiredIdentifiers = append(smReq.RequiredIdentifiers, &prototk.PublicKeyIdentifierType{Algorithm: required.Algorithm, VerifierType: required.VerifierType})
+		}
+		smRes, err := sm.Resolve(ctx, smReq)
+		if err != nil {
+			return nil, err
+		}
+		res := &remotesigner.ResolveKeyResponse{KeyHandle: smRes.KeyHandle}
+		for _, identifier := range smRes.Identifiers {
+			res.Identifiers = append(res.Identifiers, &remotesigner.PublicKeyIdentifier{Algorithm: identifier.Algorithm, VerifierType: identifier.VerifierType, Verifier: identifier.Verifier})
+		}
+		return res, nil
+	})
+}
+
+func signHandler(sm signer.SigningModule, calls *atomic.Int32) rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, req remotesigner.SignRequest) (*remotesigner.SignResponse, error) {
+		calls.Add(1)
+		smRes, err := sm.Sign(ctx, &prototk.SignWithKeyRequest{KeyHandle: req.KeyHandle, Algorithm: req.Algorithm, PayloadType: req.PayloadType, Payload: req.Payload})
+		if err != nil {
+			return nil, err
+		}
+		return &remotesigner.SignResponse{Signature: smRes.Payload}, nil
+	})
+}
+
+func listKeysHandler(sm signer.SigningModule, calls *atomic.Int32) rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context, req remotesigner.ListKeysRequest) (*remotesigner.ListKeysResponse, error) {
+		calls.Add(1)
+		_, err := sm.List(ctx, &prototk.ListKeysRequest{Limit: int32(req.Limit), Continue: req.Continue})
+		return nil, err // the in-memory signing module does not support listing keys
+	})
+}
+
+// A mock remote signer, serving the remote signer JSON/RPC protocol from an in-memory signing module
+func newTestRemoteSigner(t *testing.T, tlsConf pldconf.TLSConfig) (*testRemoteSigner, func()) {
+	ctx := context.Background()
+
+	var signerConf *pldconf.SignerConfig
+	err := json.Unmarshal([]byte(testSeedSignerConfig), &signerConf)
+	require.NoError(t, err)
+	sm, err := signer.NewSigningModule(ctx, (*signerapi.ConfigNoExt)(signerConf))
+	require.NoError(t, err)
+
+	s, err := rpcserver.NewRPCServer(ctx, &pldconf.RPCServerConfig{
+		HTTP: pldconf.RPCServerConfigHTTP{
+			HTTPServerConfig: pldconf.HTTPServerConfig{Address: confutil.P("127.0.0.1"), Port: confutil.P(0), TLS: tlsConf},
+		},
+		WS: pldconf.RPCServerConfigWS{Disabled: true},
+	})
+	require.NoError(t, err)
+	trs := &testRemoteSigner{server: s}
+	s.Register(rpcserver.NewRPCModule("signerv1").
+		Add(remotesigner.RPCMethodResolveKey, resolveKeyHandler(sm, &trs.resolveCalls)).
+		Add(remotesigner.RPCMethodSign, signHandler(sm, &trs.signCalls)).
+		Add(remotesigner.RPCMethodListKeys, listKeysHandler(sm, &trs.listKeysCalls)),
+	)
+	err = s.Start()
+	require.NoError(t, err)
+
+	scheme := "http"
+	if tlsConf.Enabled {
+		scheme = "https"
+	}
+	trs.url = fmt.Sprintf("%s://%s", scheme, s.HTTPAddr())
+	return trs, func() {
+		s.Stop()
+		sm.Close()
+	}
+}
+
+func newTestRemoteSigningModule(t *testing.T, conf *Config) *remoteSigningModule {
+	confJSON, err := json.Marshal(conf)
+	require.NoError(t, err)
+	rsm := NewRemoteSigningModule(&testCallbacks{}).(*remoteSigningModule)
+	_, err = rsm.ConfigureSigningModule(rsm.bgCtx, &prototk.ConfigureSigningModuleRequest{
+		Name:       "remote",
+		ConfigJson: string(confJSON),
+	})
+	require.NoError(t, err)
+	return rsm
+}
+
+func testFastRetry() pldconf.RetryConfigWithMax {
+	return pldconf.RetryConfigWithMax{
+		RetryConfig: pldconf.RetryConfig{InitialDelay: confutil.P("1ms")},
+		MaxAttempts: confutil.P(2),
+	}
+}
+
+func TestPluginLifecycle(t *testing.T) {
+	pb := NewPlugin(context.Background())
+	assert.NotNil(t, pb)
+}
+
+func TestBadConfigJSON(t *testing.T) {
+	rsm := NewRemoteSigningModule(&testCallbacks{}).(*remoteSigningModule)
+	_, err := rsm.ConfigureSigningModule(rsm.bgCtx, &prototk.ConfigureSigningModuleRequest{
+		Name:       "remote",
+		ConfigJson: `{!!!!`,
+	})
+	assert.Regexp(t, "PD080001", err)
+}
+
+func TestMissingURL(t *testing.T) {
+	rsm := NewRemoteSigningModule(&testCallbacks{}).(*remoteSigningModule)
+	_, err := rsm.ConfigureSigningModule(rsm.bgCtx, &prototk.ConfigureSigningModuleRequest{
+		Name:       "remote",
+		ConfigJson: `{"http":{}}`,
+	})
+	assert.Regexp(t, "PD080002", err)
+}
+
+func TestBadTLSConfig(t *testing.T) {
+	rsm := NewRemoteSigningModule(&testCallbacks{}).(*remoteSigningModule)
+	_, err := rsm.ConfigureSigningModule(rsm.bgCtx, &prototk.ConfigureSigningModuleRequest{
+		Name:       "remote",
+		ConfigJson: `{"http":{"url":"https://localhost:12345","tls":{"enabled":true,"caFile":"/does/not/exist"}}}`,
+	})
+	assert.Regexp(t, "PD080001", err)
+}
+
+func TestResolveSignListMutualTLS(t *testing.T) {
+	caCert, caKey, caPEM, _ := buildTestCertificate(t, pkix.Name{CommonName: "ca"}, nil, nil)
+	_, _, serverCert, serverKey := buildTestCertificate(t, pkix.Name{CommonName: "server"}, caCert, caKey)
+	_, _, clientCert, clientKey := buildTestCertificate(t, pkix.Name{CommonName: "client"}, caCert, caKey)
+
+	trs, done := newTestRemoteSigner(t, pldconf.TLSConfig{
+		Enabled:    true,
+		Cert:       serverCert,
+		Key:        serverKey,
+		CA:         caPEM,
+		ClientAuth: true,
+	})
+	defer done()
+
+	rsm := newTestRemoteSigningModule(t, &Config{
+		HTTP: pldconf.HTTPClientConfig{
+			URL: trs.url,
+			TLS: pldconf.TLSConfig{
+				Enabled: true,
+				CA:      caPEM,
+				Cert:    clientCert,
+				Key:     clientKey,
+			},
+		},
+	})
+	ctx := rsm.bgCtx
+
+	resolveReq := &prototk.ResolveKeyRequest{
+		Name: "testKey",
+		RequiredIdentifiers: []*prototk.PublicKeyIdentifierType{
+			{Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+		},
+	}
+	res, err := rsm.ResolveKey(ctx, resolveReq)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/60'/0'", res.KeyHandle)
+	assert.Equal(t, "0x1a6c36b04874844e3a11b81f28e08802d086c4df", res.Identifiers[0].Verifier)
+
+	// Second resolution is served from the cache
+	res, err = rsm.ResolveKey(ctx, resolveReq)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/60'/0'", res.KeyHandle)
+	assert.Equal(t, int32(1), trs.resolveCalls.Load())
+
+	signRes, err := rsm.Sign(ctx, &prototk.SignWithKeyRequest{
+		KeyHandle:   res.KeyHandle,
+		Algorithm:   algorithms.ECDSA_SECP256K1,
+		PayloadType: signpayloads.OPAQUE_TO_RSV,
+		Payload:     ([]byte)("some data"),
+	})
+	require.NoError(t, err)
+	assert.Len(t, signRes.Payload, 65)
+
+	// Errors returned by the remote signer are not retried
+	_, err = rsm.Sign(ctx, &prototk.SignWithKeyRequest{
+		KeyHandle:   res.KeyHandle,
+		Algorithm:   "invalid_algorithm",
+		PayloadType: signpayloads.OPAQUE_TO_RSV,
+		Payload:     ([]byte)("some data"),
+	})
+	assert.Regexp(t, "PD080003.*signerv1_sign.*PD020810", err)
+	assert.Equal(t, int32(2), trs.signCalls.Load())
+
+	_, err = rsm.ListKeys(ctx, &prototk.ListKeysRequest{Limit: 10})
+	assert.Regexp(t, "PD080003.*signerv1_listKeys.*PD020815", err)
+	assert.Equal(t, int32(1), trs.listKeysCalls.Load())
+
+	_, err = rsm.Close(ctx, &prototk.CloseRequest{})
+	require.NoError(t, err)
+}
+
+func TestClientCertRequired(t *testing.T) {
+	caCert, caKey, caPEM, _ := buildTestCertificate(t, pkix.Name{CommonName: "ca"}, nil, nil)
+	_, _, serverCert, serverKey := buildTestCertificate(t, pkix.Name{CommonName: "server"}, caCert, caKey)
+
+	trs, done := newTestRemoteSigner(t, pldconf.TLSConfig{
+		Enabled:    true,
+		Cert:       serverCert,
+		Key:        serverKey,
+		CA:         caPEM,
+		ClientAuth: true,
+	})
+	defer done()
+
+	rsm := newTestRemoteSigningModule(t, &Config{
+		HTTP: pldconf.HTTPClientConfig{
+			URL: trs.url,
+			TLS: pldconf.TLSConfig{Enabled: true, CA: caPEM},
+		},
+		Retry: testFastRetry(),
+	})
+
+	_, err := rsm.ListKeys(rsm.bgCtx, &prototk.ListKeysRequest{})
+	assert.Regexp(t, "PD080003.*certificate required", err)
+	assert.Equal(t, int32(0), trs.listKeysCalls.Load())
+}
+
+func TestRetryConnectionFailure(t *testing.T) {
+	rsm := newTestRemoteSigningModule(t, &Config{
+		HTTP:  pldconf.HTTPClientConfig{URL: "http://localhost:0"},
+		Retry: testFastRetry(),
+	})
+
+	_, err := rsm.ResolveKey(rsm.bgCtx, &prototk.ResolveKeyRequest{Name: "testKey"})
+	assert.Regexp(t, "PD080003.*signerv1_resolveKey.*connection refused", err)
+}
+
+func TestRetryUnavailableThenOK(t *testing.T) {
+	trs, done := newTestRemoteSigner(t, pldconf.TLSConfig{})
+	defer done()
+
+	var requests atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		trs.server.HTTPHandler(w, r)
+	}))
+	defer proxy.Close()
+
+	rsm := newTestRemoteSigningModule(t, &Config{
+		HTTP:  pldconf.HTTPClientConfig{URL: proxy.URL},
+		Retry: testFastRetry(),
+	})
+
+	res, err := rsm.ResolveKey(rsm.bgCtx, &prototk.ResolveKeyRequest{
+		Name: "testKey",
+		RequiredIdentifiers: []*prototk.PublicKeyIdentifierType{
+			{Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/60'/0'", res.KeyHandle)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, int32(1), trs.resolveCalls.Load())
+}
+
+func TestNoRetryRemoteErrors(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if strings.Contains(r.Header.Get("Authorization"), "Basic") {
+			// A JSON/RPC error from the signer is final, whatever the HTTP status
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","error":{"code":-32000,"message":"key not found"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	rsm := newTestRemoteSigningModule(t, &Config{
+		HTTP:  pldconf.HTTPClientConfig{URL: server.URL},
+		Retry: testFastRetry(),
+	})
+	_, err := rsm.Sign(rsm.bgCtx, &prototk.SignWithKeyRequest{KeyHandle: "key1"})
+	assert.Regexp(t, "PD080003.*signerv1_sign.*401", err)
+	assert.Equal(t, int32(1), requests.Load())
+
+	rsm = newTestRemoteSigningModule(t, &Config{
+		HTTP: pldconf.HTTPClientConfig{
+			URL:  server.URL,
+			Auth: pldconf.HTTPBasicAuthConfig{Username: "user", Password: "pass"},
+		},
+		Retry: testFastRetry(),
+	})
+	_, err = rsm.Sign(rsm.bgCtx, &prototk.SignWithKeyRequest{KeyHandle: "key1"})
+	assert.Regexp(t, "PD080003.*signerv1_sign.*key not found", err)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestInvalidResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":"not an object"}`))
+	}))
+	defer server.Close()
+
+	rsm := newTestRemoteSigningModule(t, &Config{
+		HTTP: pldconf.HTTPClientConfig{URL: server.URL},
+	})
+
+	_, err := rsm.Sign(rsm.bgCtx, &prototk.SignWithKeyRequest{KeyHandle: "key1"})
+	assert.Regexp(t, "PD080004.*signerv1_sign", err)
+}
+
+func TestCloseUnconfigured(t *testing.T) {
+	rsm := NewRemoteSigningModule(&testCallbacks{}).(*remoteSigningModule)
+	_, err := rsm.Close(rsm.bgCtx, &prototk.CloseRequest{})
+	require.NoError(t, err)
+}
+
+func TestListKeysOK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{
+			"items": [{"name":"key1","keyHandle":"handle1","path":[{"name":"folder1"}],"identifiers":[{"algorithm":"ecdsa:secp256k1","verifierType":"eth_address","verifier":"0x1a6c36b04874844e3a11b81f28e08802d086c4df"}]}],
+			"next": "handle1",
+			"futureField": true
+		}}`))
+	}))
+	defer server.Close()
+
+	rsm := newTestRemoteSigningModule(t, &Config{
+		HTTP: pldconf.HTTPClientConfig{URL: server.URL},
+	})
+
+	res, err := rsm.ListKeys(rsm.bgCtx, &prototk.ListKeysRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, "handle1", res.Items[0].KeyHandle)
+	assert.Equal(t, "folder1", res.Items[0].Path[0].Name)
+	assert.Equal(t, verifiers.ETH_ADDRESS, res.Items[0].Identifiers[0].VerifierType)
+	assert.Equal(t, "handle1", res.Next)
+}
+
+func TestProtocolWireFormat(t *testing.T) {
+	var requests []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		err := json.NewDecoder(r.Body).Decode(&req)
+		require.NoError(t, err)
+		requests = append(requests, req)
+		w.Header().Set("Content-Type", "application/json")
+		switch req["method"] {
+		case remotesigner.RPCMethodResolveKey:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"keyHandle":"handle1","identifiers":[]}}`))
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"signature":"0xfeedbeef"}}`))
+		}
+	}))
+	defer server.Close()
+
+	rsm := newTestRemoteSigningModule(t, &Config{
+		HTTP: pldconf.HTTPClientConfig{URL: server.URL},
+	})
+
+	res, err := rsm.ResolveKey(rsm.bgCtx, &prototk.ResolveKeyRequest{
+		Name:  "key1",
+		Index: 3,
+		Path: []*prototk.ResolveKeyPathSegment{
+			{Name: "folder1", Index: 1},
+		},
+		Attributes: map[string]string{"attr1": "value1"},
+		RequiredIdentifiers: []*prototk.PublicKeyIdentifierType{
+			{Algorithm: algorithms.ECDSA_SECP256K1, VerifierType: verifiers.ETH_ADDRESS},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "handle1", res.KeyHandle)
+
+	signRes, err := rsm.Sign(rsm.bgCtx, &prototk.SignWithKeyRequest{
+		KeyHandle:   "handle1",
+		Algorithm:   algorithms.ECDSA_SECP256K1,
+		PayloadType: signpayloads.OPAQUE_TO_RSV,
+		Payload:     []byte{0x01, 0x02},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xfe, 0xed, 0xbe, 0xef}, signRes.Payload)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "signerv1_resolveKey", requests[0]["method"])
+	params, _ := json.Marshal(requests[0]["params"])
+	assert.JSONEq(t, `[{
+		"name": "key1",
+		"index": 3,
+		"path": [{"name": "folder1", "index": 1}],
+		"attributes": {"attr1": "value1"},
+		"requiredIdentifiers": [{"algorithm": "ecdsa:secp256k1", "verifierType": "eth_address"}]
+	}]`, string(params))
+	assert.Equal(t, "signerv1_sign", requests[1]["method"])
+	params, _ = json.Marshal(requests[1]["params"])
+	assert.JSONEq(t, `[{
+		"keyHandle": "handle1",
+		"algorithm": "ecdsa:secp256k1",
+		"payloadType": "opaque:rsv",
+		"payload": "0x0102"
+	}]`, string(params))
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package remotesigner defines version 1 of the remote signer protocol, used by the remote signing module
+// to forward key resolution, signing and key listing to a signer outside of the Paladin process.
+//
+// The protocol is JSON/RPC 2.0 over HTTP(S). Each method takes a single parameter, and returns a single result,
+// with the types defined in this package. Binary data is hex encoded with a 0x prefix.
+//
+// These types are the public contract with remote signers, and are deliberately independent of the plugin
+// interface between Paladin and its signing modules, so that interface can change without breaking remote
+// signers. Incompatible changes will only be made in a new version of the protocol, with new method names.
+package remotesigner
+
+import "github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+
+const ProtocolVersion = "v1"
+
+const (
+	RPCMethodResolveKey = "signerv1_resolveKey" // ResolveKeyRequest -> ResolveKeyResponse
+	RPCMethodSign       = "signerv1_sign"       // SignRequest -> SignResponse
+	RPCMethodListKeys   = "signerv1_listKeys"   // ListKeysRequest -> ListKeysResponse
+)
+
+// Resolves a key name, within an optional hierarchy of path segments, to a handle for the key material.
+// The same request must always resolve to the same key.
+type ResolveKeyRequest struct {
+	Name                string                     `json:"name"`                          // a name assured to be unique at this path
+	Index               uint64                     `json:"index"`                         // a unique index at this path, that can be used for key derivation (BIP32)
+	Attributes          map[string]string          `json:"attributes,omitempty"`          // attributes of the key from the Paladin key manager
+	Path                []*ResolveKeyPathSegment   `json:"path,omitempty"`                // hierarchical path to the key (optional)
+	RequiredIdentifiers []*PublicKeyIdentifierType `json:"requiredIdentifiers,omitempty"` // public key identifiers to return for the key (optional)
+}
+
+type ResolveKeyPathSegment struct {
+	Name  string `json:"name"`  // the name of the path segment (folder)
+	Index uint64 `json:"index"` // a unique index at this level in the path, that can be used for key derivation (BIP32)
+}
+
+type ResolveKeyResponse struct {
+	KeyHandle   string                 `json:"keyHandle"`   // the handle to pass in sign requests for this key
+	Identifiers []*PublicKeyIdentifier `json:"identifiers"` // one entry for each of the required identifiers
+}
+
+// Signs a payload with a key previously resolved - potentially a very long time ago.
+// The payload is passed exactly as it is to be processed by the algorithm, so for example for
+// the "opaque:rsv" payload type it is the 32 byte digest to sign, and must not be hashed again.
+type SignRequest struct {
+	KeyHandle   string            `json:"keyHandle"`   // the key handle from a previous resolveKey
+	Algorithm   string            `json:"algorithm"`   // the signing algorithm, such as "ecdsa:secp256k1"
+	PayloadType string            `json:"payloadType"` // the input and output payload combination, such as "opaque:rsv"
+	Payload     pldtypes.HexBytes `json:"payload"`     // the input payload
+}
+
+type SignResponse struct {
+	Signature pldtypes.HexBytes `json:"signature"` // the output payload for the payload type
+}
+
+// Lists the keys known to the remote signer, one page at a time
+type ListKeysRequest struct {
+	Limit    int    `json:"limit"`              // the maximum number of keys to return
+	Continue string `json:"continue,omitempty"` // the "next" value from the previous page
+}
+
+type ListKeysResponse struct {
+	Items []*ListKeyEntry `json:"items"`          // a page of keys - fewer than the limit does not mean there are no more
+	Next  string          `json:"next,omitempty"` // set when there might be more keys to list
+}
+
+type ListKeyEntry struct {
+	Name        string                 `json:"name"`                 // the name of the key
+	KeyHandle   string                 `json:"keyHandle"`            // the handle to pass in sign requests for this key
+	Attributes  map[string]string      `json:"attributes,omitempty"` // attributes of the key
+	Path        []*ListKeyPathSegment  `json:"path,omitempty"`       // hierarchical path to the key
+	Identifiers []*PublicKeyIdentifier `json:"identifiers"`          // public key identifiers of the key
+}
+
+type ListKeyPathSegment struct {
+	Name string `json:"name"` // the name of the path segment (folder)
+}
+
+type PublicKeyIdentifierType struct {
+	Algorithm    string `json:"algorithm"`    // such as "ecdsa:secp256k1"
+	VerifierType string `json:"verifierType"` // such as "eth_address"
+}
+
+type PublicKeyIdentifier struct {
+	Algorithm    string `json:"algorithm"`    // such as "ecdsa:secp256k1"
+	VerifierType string `json:"verifierType"` // such as "eth_address"
+	Verifier     string `json:"verifier"`     // the public key encoded as the verifier type, such as a 0x address
+}
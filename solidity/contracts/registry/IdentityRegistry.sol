@@ -29,6 +29,12 @@ contract IdentityRegistry {
         string value
     );
 
+    event IdentityRevoked (
+        bytes32 parentIdentityHash,
+        bytes32 identityHash,
+        string name
+    );
+
     // Each identity has a unique hash, calculated as a hash of its name and the hash of the parent
     // Identities are stored in a map, from identity hash to identity struct
     // The root identity has key value 0
@@ -42,6 +48,10 @@ contract IdentityRegistry {
     // This is used to store property names and values for each identity
     mapping(bytes32 => mapping(bytes32 => Property)) private properties;
 
+    // Identities that have been revoked by the owner of their parent identity
+    // A revoked identity cannot have properties set, or children registered
+    mapping(bytes32 => bool) private revoked;
+
     constructor() {
         // Root identity is created
         Identity memory rootIdentity = Identity(
@@ -69,6 +79,9 @@ contract IdentityRegistry {
         // Ensure sender owns parent identity
         require(identities[parentIdentityHash].owner == msg.sender, "Forbidden");
 
+        // Ensure parent identity has not been revoked
+        require(!revoked[parentIdentityHash], "Identity revoked");
+
         // Calculate identiy hash based on its name and the hash of the parent identity
         bytes32 hash = sha256(abi.encodePacked(parentIdentityHash, name));
 
@@ -85,6 +98,30 @@ contract IdentityRegistry {
         emit IdentityRegistered(parentIdentityHash, hash, name, owner);
     }
 
+    function revokeIdentity(bytes32 identityHash) public {
+        // The root identity cannot be revoked
+        require(identityHash != 0, "Cannot revoke root");
+
+        // Check identity exists
+        Identity storage identity = identities[identityHash];
+        require(bytes(identity.name).length > 0, "Identity not found");
+
+        // Ensure sender owns parent identity, so a compromised identity owner cannot prevent revocation
+        require(identities[identity.parent].owner == msg.sender, "Forbidden");
+
+        // Ensure identity is not already revoked
+        require(!revoked[identityHash], "Identity revoked");
+
+        revoked[identityHash] = true;
+
+        // Emit identity revoked event
+        emit IdentityRevoked(identity.parent, identityHash, identity.name);
+    }
+
+    function isIdentityRevoked(bytes32 identityHash) public view returns (bool) {
+        return revoked[identityHash];
+    }
+
     function getRootIdentity() public view returns (Identity memory identity) {
         // Returns the root identity which has key 0
         identity = identities[0];
@@ -105,6 +142,9 @@ contract IdentityRegistry {
         // Ensure sender owns identity
         require(identities[identityHash].owner == msg.sender, "Forbidden");
 
+        // Ensure identity has not been revoked
+        require(!revoked[identityHash], "Identity revoked");
+
         // Calculate property name hash
         bytes32 nameHash = sha256(abi.encodePacked(name));
 
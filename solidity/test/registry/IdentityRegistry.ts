@@ -31,6 +31,8 @@ import { ContractTransactionResponse, EventLog } from 'ethers';
  *    │   └── identity-a-b  (owned by accounts[4])
  *    └── identity-b        (owned by accounts[2])
  * 
+ * identity-a-b is revoked by the owner of identity-a at the end of the test.
+ *
  * The following properties are set:
  * 
  *   root      key=key-root-1, value=value-root-1/updated
@@ -297,6 +299,43 @@ describe('Identity Registry', () => {
       .to.be.revertedWith('Property not found');
   });
 
+  it('Check only parent identity owner can revoke identities', async () => {
+    // The owner of identity-a-b cannot revoke itself
+    await expect(identityRegistry.connect(account_a_b).revokeIdentity(identity_a_b_hash))
+      .to.be.revertedWith('Forbidden');
+
+    // The root identity cannot be revoked
+    await expect(identityRegistry.connect(root_account).revokeIdentity(hre.ethers.ZeroHash))
+      .to.be.revertedWith('Cannot revoke root');
+
+    // Unknown identities cannot be revoked
+    await expect(identityRegistry.connect(root_account).revokeIdentity(identity_a_a_hash.replace(/.$/, '0')))
+      .to.be.revertedWith('Identity not found');
+  });
+
+  it('Revoke identity-a-b', async () => {
+    // Owner of identity-a revokes its child identity-a-b
+    await expect(identityRegistry.connect(account_a).revokeIdentity(identity_a_b_hash))
+      .to.emit(identityRegistry, 'IdentityRevoked')
+      .withArgs(identity_a_hash, identity_a_b_hash, 'identity-a-b');
+    expect(await identityRegistry.isIdentityRevoked(identity_a_b_hash)).to.equal(true);
+    expect(await identityRegistry.isIdentityRevoked(identity_a_a_hash)).to.equal(false);
+
+    // A revoked identity cannot be revoked again
+    await expect(identityRegistry.connect(account_a).revokeIdentity(identity_a_b_hash))
+      .to.be.revertedWith('Identity revoked');
+
+    // A revoked identity cannot set properties, or register children
+    await expect(identityRegistry.connect(account_a_b).setIdentityProperty(identity_a_b_hash, 'key-x', 'value-x'))
+      .to.be.revertedWith('Identity revoked');
+    await expect(identityRegistry.connect(account_a_b).registerIdentity(identity_a_b_hash, 'identity_x', other_account))
+      .to.be.revertedWith('Identity revoked');
+
+    // The name cannot be re-used under the same parent
+    await expect(identityRegistry.connect(account_a).registerIdentity(identity_a_hash, 'identity-a-b', other_account))
+      .to.be.revertedWith('Name already taken');
+  });
+
 });
 
 const getEvents = async (response: ContractTransactionResponse) => {
@@ -21,3 +21,15 @@ const ECDSA_SECP256K1 = Prefix_ECDSA + ":" + Curve_SECP256K1
 const Prefix_ECDSA = "ecdsa"
 
 const Curve_SECP256K1 = "secp256k1"
+
+// ECDSA algorithm with the NIST P-256 (secp256r1) curve
+const ECDSA_SECP256R1 = Prefix_ECDSA + ":" + Curve_SECP256R1
+
+const Curve_SECP256R1 = "secp256r1"
+
+// EdDSA algorithm with the Ed25519 curve
+const EDDSA_ED25519 = Prefix_EDDSA + ":" + Curve_ED25519
+
+const Prefix_EDDSA = "eddsa"
+
+const Curve_ED25519 = "ed25519"
@@ -18,6 +18,7 @@ package cache
 
 import (
 	"sync/atomic"
+	"time"
 
 	cacheimpl "github.com/Code-Hex/go-generics-cache"
 	"github.com/Code-Hex/go-generics-cache/policy/lru"
@@ -36,12 +37,14 @@ type Cache[K comparable, V any] interface {
 type cache[K comparable, V any] struct {
 	cache    atomic.Pointer[cacheimpl.Cache[K, V]]
 	capacity int
+	ttl      time.Duration
 }
 
 func NewCache[K comparable, V any](conf *pldconf.CacheConfig, defs *pldconf.CacheConfig) Cache[K, V] {
 	capacity := confutil.Int(conf.Capacity, *defs.Capacity)
 	c := &cache[K, V]{
 		capacity: capacity,
+		ttl:      confutil.DurationMin(conf.TTL, 0, confutil.StringOrEmpty(defs.TTL, "")),
 	}
 	// go-generics-cache provides its own thread safety wrapper
 	// and janitor for expiry of old records.
@@ -55,7 +58,11 @@ func (c *cache[K, V]) Get(key K) (V, bool) {
 }
 
 func (c *cache[K, V]) Set(key K, val V) {
-	c.cache.Load().Set(key, val)
+	if c.ttl > 0 {
+		c.cache.Load().Set(key, val, cacheimpl.WithExpiration(c.ttl))
+	} else {
+		c.cache.Load().Set(key, val)
+	}
 }
 
 func (c *cache[K, V]) Delete(key K) {
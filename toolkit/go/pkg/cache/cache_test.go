@@ -18,6 +18,7 @@ package cache
 
 import (
 	"testing"
+	"time"
 
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
@@ -47,3 +48,18 @@ func TestCache(t *testing.T) {
 
 	assert.Equal(t, 1, c.Capacity())
 }
+
+func TestCacheTTL(t *testing.T) {
+
+	c := NewCache[string, string](&pldconf.CacheConfig{TTL: confutil.P("10ms")}, &pldconf.CacheConfig{Capacity: confutil.P(1)})
+
+	c.Set("key1", "val1")
+	v, ok := c.Get("key1")
+	assert.True(t, ok)
+	assert.Equal(t, "val1", v)
+
+	time.Sleep(20 * time.Millisecond)
+	_, ok = c.Get("key1")
+	assert.False(t, ok)
+
+}
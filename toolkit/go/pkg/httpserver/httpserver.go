@@ -168,6 +168,12 @@ func (lc *logCapture) WriteHeader(statusCode int) {
 	lc.res.WriteHeader(statusCode)
 }
 
+// Unwrap allows http.ResponseController to reach the underlying writer, for handlers
+// that stream responses and need to flush or extend the write deadline
+func (lc *logCapture) Unwrap() http.ResponseWriter {
+	return lc.res
+}
+
 func (lc *logCapture) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	hj, ok := lc.res.(http.Hijacker)
 	if !ok {
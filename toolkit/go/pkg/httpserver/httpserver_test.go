@@ -196,3 +196,20 @@ func TestWSUpgradeSupported(t *testing.T) {
 	require.NoError(t, err)
 	_ = c.Close()
 }
+
+func TestStreamingFlushAndWriteDeadline(t *testing.T) {
+	url, _, done := newTestServer(t, &pldconf.HTTPServerConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		rc := http.NewResponseController(w)
+		require.NoError(t, rc.SetWriteDeadline(time.Now().Add(1*time.Minute)))
+		_, err := w.Write(([]byte)(`line1`))
+		require.NoError(t, err)
+		require.NoError(t, rc.Flush())
+	})
+	defer done()
+
+	res, err := http.Get(url)
+	require.NoError(t, err)
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "line1", (string)(data))
+}
@@ -206,6 +206,7 @@ func TestUnimplementedDoesNotPanic(t *testing.T) {
 	}, func(res *prototk.DomainMessage) {
 		// Get an error back saying this request hasn't been implemented by the plugin
 		assert.Regexp(t, "PD020302", *res.Header.ErrorMessage)
+		assert.Equal(t, prototk.Header_NOT_SUPPORTED, res.Header.ErrorType)
 	})
 }
 
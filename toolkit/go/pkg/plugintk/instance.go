@@ -31,6 +31,28 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// Returned by a plugin implementation to classify the error in the reply to Paladin
+type PluginError struct {
+	ErrorType prototk.Header_ErrorType
+	Cause     error
+}
+
+func (e *PluginError) Error() string {
+	return e.Cause.Error()
+}
+
+func (e *PluginError) Unwrap() error {
+	return e.Cause
+}
+
+// Tells Paladin the plugin does not support the requested operation, rather than failing to perform it
+func NewNotSupportedError(cause error) *PluginError {
+	return &PluginError{
+		ErrorType: prototk.Header_NOT_SUPPORTED,
+		Cause:     cause,
+	}
+}
+
 type pluginInstance[M any] struct {
 	pluginType string
 	id         string
@@ -219,6 +241,10 @@ func (pr *pluginRun[M]) handleRequestToPlugin(msg PluginMessage[M]) {
 		errorMessage := err.Error()
 		replyHeader.MessageType = prototk.Header_ERROR_RESPONSE
 		replyHeader.ErrorMessage = &errorMessage
+		var pluginError *PluginError
+		if errors.As(err, &pluginError) {
+			replyHeader.ErrorType = pluginError.ErrorType
+		}
 		log.L(pr.ctx).Errorf("[%s] <-- [%s] ERROR [%s]: %s", header.MessageId, replyID, time.Since(timeReceived), errorMessage)
 	} else {
 		// The handler generated a reply - we just update the header
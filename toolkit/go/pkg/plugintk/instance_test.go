@@ -16,6 +16,7 @@ package plugintk
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/uuid"
@@ -233,3 +234,11 @@ func TestEmptyErrorFromServer(t *testing.T) {
 	_, err := callbacks.FindAvailableStates(ctx, &prototk.FindAvailableStatesRequest{})
 	assert.Regexp(t, "PD020303", err)
 }
+
+func TestNotSupportedError(t *testing.T) {
+	cause := fmt.Errorf("pop")
+	err := NewNotSupportedError(cause)
+	assert.Equal(t, prototk.Header_NOT_SUPPORTED, err.ErrorType)
+	assert.Equal(t, "pop", err.Error())
+	assert.ErrorIs(t, err, cause)
+}
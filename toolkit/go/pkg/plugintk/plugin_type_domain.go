@@ -44,6 +44,7 @@ type DomainAPI interface {
 	ConfigurePrivacyGroup(context.Context, *prototk.ConfigurePrivacyGroupRequest) (*prototk.ConfigurePrivacyGroupResponse, error)
 	InitPrivacyGroup(context.Context, *prototk.InitPrivacyGroupRequest) (*prototk.InitPrivacyGroupResponse, error)
 	WrapPrivacyGroupEVMTX(context.Context, *prototk.WrapPrivacyGroupEVMTXRequest) (*prototk.WrapPrivacyGroupEVMTXResponse, error)
+	UpdatePrivacyGroupMembers(context.Context, *prototk.UpdatePrivacyGroupMembersRequest) (*prototk.UpdatePrivacyGroupMembersResponse, error)
 }
 
 type DomainCallbacks interface {
@@ -213,6 +214,10 @@ func (dp *domainHandler) RequestToPlugin(ctx context.Context, iReq PluginMessage
 		resMsg := &prototk.DomainMessage_WrapPrivacyGroupEvmtxRes{}
 		resMsg.WrapPrivacyGroupEvmtxRes, err = dp.api.WrapPrivacyGroupEVMTX(ctx, input.WrapPrivacyGroupEvmtx)
 		res.ResponseFromDomain = resMsg
+	case *prototk.DomainMessage_UpdatePrivacyGroupMembers:
+		resMsg := &prototk.DomainMessage_UpdatePrivacyGroupMembersRes{}
+		resMsg.UpdatePrivacyGroupMembersRes, err = dp.api.UpdatePrivacyGroupMembers(ctx, input.UpdatePrivacyGroupMembers)
+		res.ResponseFromDomain = resMsg
 	default:
 		err = i18n.NewError(ctx, pldmsgs.MsgPluginUnsupportedRequest, input)
 	}
@@ -297,25 +302,26 @@ func (dp *domainHandler) GetStatesByID(ctx context.Context, req *prototk.GetStat
 }
 
 type DomainAPIFunctions struct {
-	ConfigureDomain       func(context.Context, *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error)
-	InitDomain            func(context.Context, *prototk.InitDomainRequest) (*prototk.InitDomainResponse, error)
-	InitDeploy            func(context.Context, *prototk.InitDeployRequest) (*prototk.InitDeployResponse, error)
-	PrepareDeploy         func(context.Context, *prototk.PrepareDeployRequest) (*prototk.PrepareDeployResponse, error)
-	InitContract          func(context.Context, *prototk.InitContractRequest) (*prototk.InitContractResponse, error)
-	InitTransaction       func(context.Context, *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error)
-	AssembleTransaction   func(context.Context, *prototk.AssembleTransactionRequest) (*prototk.AssembleTransactionResponse, error)
-	EndorseTransaction    func(context.Context, *prototk.EndorseTransactionRequest) (*prototk.EndorseTransactionResponse, error)
-	PrepareTransaction    func(context.Context, *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error)
-	HandleEventBatch      func(context.Context, *prototk.HandleEventBatchRequest) (*prototk.HandleEventBatchResponse, error)
-	Sign                  func(context.Context, *prototk.SignRequest) (*prototk.SignResponse, error)
-	GetVerifier           func(context.Context, *prototk.GetVerifierRequest) (*prototk.GetVerifierResponse, error)
-	ValidateStateHashes   func(context.Context, *prototk.ValidateStateHashesRequest) (*prototk.ValidateStateHashesResponse, error)
-	InitCall              func(context.Context, *prototk.InitCallRequest) (*prototk.InitCallResponse, error)
-	ExecCall              func(context.Context, *prototk.ExecCallRequest) (*prototk.ExecCallResponse, error)
-	BuildReceipt          func(context.Context, *prototk.BuildReceiptRequest) (*prototk.BuildReceiptResponse, error)
-	ConfigurePrivacyGroup func(context.Context, *prototk.ConfigurePrivacyGroupRequest) (*prototk.ConfigurePrivacyGroupResponse, error)
-	InitPrivacyGroup      func(context.Context, *prototk.InitPrivacyGroupRequest) (*prototk.InitPrivacyGroupResponse, error)
-	WrapPrivacyGroupEVMTX func(context.Context, *prototk.WrapPrivacyGroupEVMTXRequest) (*prototk.WrapPrivacyGroupEVMTXResponse, error)
+	ConfigureDomain           func(context.Context, *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error)
+	InitDomain                func(context.Context, *prototk.InitDomainRequest) (*prototk.InitDomainResponse, error)
+	InitDeploy                func(context.Context, *prototk.InitDeployRequest) (*prototk.InitDeployResponse, error)
+	PrepareDeploy             func(context.Context, *prototk.PrepareDeployRequest) (*prototk.PrepareDeployResponse, error)
+	InitContract              func(context.Context, *prototk.InitContractRequest) (*prototk.InitContractResponse, error)
+	InitTransaction           func(context.Context, *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error)
+	AssembleTransaction       func(context.Context, *prototk.AssembleTransactionRequest) (*prototk.AssembleTransactionResponse, error)
+	EndorseTransaction        func(context.Context, *prototk.EndorseTransactionRequest) (*prototk.EndorseTransactionResponse, error)
+	PrepareTransaction        func(context.Context, *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error)
+	HandleEventBatch          func(context.Context, *prototk.HandleEventBatchRequest) (*prototk.HandleEventBatchResponse, error)
+	Sign                      func(context.Context, *prototk.SignRequest) (*prototk.SignResponse, error)
+	GetVerifier               func(context.Context, *prototk.GetVerifierRequest) (*prototk.GetVerifierResponse, error)
+	ValidateStateHashes       func(context.Context, *prototk.ValidateStateHashesRequest) (*prototk.ValidateStateHashesResponse, error)
+	InitCall                  func(context.Context, *prototk.InitCallRequest) (*prototk.InitCallResponse, error)
+	ExecCall                  func(context.Context, *prototk.ExecCallRequest) (*prototk.ExecCallResponse, error)
+	BuildReceipt              func(context.Context, *prototk.BuildReceiptRequest) (*prototk.BuildReceiptResponse, error)
+	ConfigurePrivacyGroup     func(context.Context, *prototk.ConfigurePrivacyGroupRequest) (*prototk.ConfigurePrivacyGroupResponse, error)
+	InitPrivacyGroup          func(context.Context, *prototk.InitPrivacyGroupRequest) (*prototk.InitPrivacyGroupResponse, error)
+	WrapPrivacyGroupEVMTX     func(context.Context, *prototk.WrapPrivacyGroupEVMTXRequest) (*prototk.WrapPrivacyGroupEVMTXResponse, error)
+	UpdatePrivacyGroupMembers func(context.Context, *prototk.UpdatePrivacyGroupMembersRequest) (*prototk.UpdatePrivacyGroupMembersResponse, error)
 }
 
 type DomainAPIBase struct {
@@ -397,3 +403,7 @@ func (db *DomainAPIBase) InitPrivacyGroup(ctx context.Context, req *prototk.Init
 func (db *DomainAPIBase) WrapPrivacyGroupEVMTX(ctx context.Context, req *prototk.WrapPrivacyGroupEVMTXRequest) (*prototk.WrapPrivacyGroupEVMTXResponse, error) {
 	return callPluginImpl(ctx, req, db.Functions.WrapPrivacyGroupEVMTX)
 }
+
+func (db *DomainAPIBase) UpdatePrivacyGroupMembers(ctx context.Context, req *prototk.UpdatePrivacyGroupMembersRequest) (*prototk.UpdatePrivacyGroupMembersResponse, error) {
+	return callPluginImpl(ctx, req, db.Functions.UpdatePrivacyGroupMembers)
+}
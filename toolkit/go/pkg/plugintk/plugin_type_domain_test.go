@@ -476,6 +476,41 @@ func TestDomainFunction_WrapPrivacyGroupEVMTX(t *testing.T) {
 	})
 }
 
+func TestDomainFunction_UpdatePrivacyGroupMembers(t *testing.T) {
+	_, exerciser, funcs, _, _, done := setupDomainTests(t)
+	defer done()
+
+	// UpdatePrivacyGroupMembers - paladin to domain
+	funcs.UpdatePrivacyGroupMembers = func(ctx context.Context, cdr *prototk.UpdatePrivacyGroupMembersRequest) (*prototk.UpdatePrivacyGroupMembersResponse, error) {
+		return &prototk.UpdatePrivacyGroupMembersResponse{}, nil
+	}
+	exerciser.doExchangeToPlugin(func(req *prototk.DomainMessage) {
+		req.RequestToDomain = &prototk.DomainMessage_UpdatePrivacyGroupMembers{
+			UpdatePrivacyGroupMembers: &prototk.UpdatePrivacyGroupMembersRequest{},
+		}
+	}, func(res *prototk.DomainMessage) {
+		assert.IsType(t, &prototk.DomainMessage_UpdatePrivacyGroupMembersRes{}, res.ResponseFromDomain)
+	})
+}
+
+func TestDomainFunction_UpdatePrivacyGroupMembersNotSupported(t *testing.T) {
+	_, exerciser, funcs, _, _, done := setupDomainTests(t)
+	defer done()
+
+	funcs.UpdatePrivacyGroupMembers = func(ctx context.Context, cdr *prototk.UpdatePrivacyGroupMembersRequest) (*prototk.UpdatePrivacyGroupMembersResponse, error) {
+		return nil, NewNotSupportedError(fmt.Errorf("pop"))
+	}
+	exerciser.doExchangeToPlugin(func(req *prototk.DomainMessage) {
+		req.RequestToDomain = &prototk.DomainMessage_UpdatePrivacyGroupMembers{
+			UpdatePrivacyGroupMembers: &prototk.UpdatePrivacyGroupMembersRequest{},
+		}
+	}, func(res *prototk.DomainMessage) {
+		assert.Equal(t, prototk.Header_ERROR_RESPONSE, res.Header.MessageType)
+		assert.Equal(t, prototk.Header_NOT_SUPPORTED, res.Header.ErrorType)
+		assert.Equal(t, "pop", *res.Header.ErrorMessage)
+	})
+}
+
 func TestDomainRequestError(t *testing.T) {
 	_, exerciser, _, _, _, done := setupDomainTests(t)
 	defer done()
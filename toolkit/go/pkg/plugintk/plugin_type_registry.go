@@ -27,6 +27,7 @@ import (
 type RegistryAPI interface {
 	ConfigureRegistry(context.Context, *prototk.ConfigureRegistryRequest) (*prototk.ConfigureRegistryResponse, error)
 	HandleRegistryEvents(context.Context, *prototk.HandleRegistryEventsRequest) (*prototk.HandleRegistryEventsResponse, error)
+	PrepareRegistryTransactions(context.Context, *prototk.PrepareRegistryTransactionsRequest) (*prototk.PrepareRegistryTransactionsResponse, error)
 }
 
 type RegistryCallbacks interface {
@@ -122,6 +123,10 @@ func (th *registryHandler) RequestToPlugin(ctx context.Context, iReq PluginMessa
 		resMsg := &prototk.RegistryMessage_HandleRegistryEventsRes{}
 		resMsg.HandleRegistryEventsRes, err = th.api.HandleRegistryEvents(ctx, input.HandleRegistryEvents)
 		res.ResponseFromRegistry = resMsg
+	case *prototk.RegistryMessage_PrepareRegistryTransactions:
+		resMsg := &prototk.RegistryMessage_PrepareRegistryTransactionsRes{}
+		resMsg.PrepareRegistryTransactionsRes, err = th.api.PrepareRegistryTransactions(ctx, input.PrepareRegistryTransactions)
+		res.ResponseFromRegistry = resMsg
 	default:
 		err = i18n.NewError(ctx, pldmsgs.MsgPluginUnsupportedRequest, input)
 	}
@@ -140,8 +145,9 @@ func (dh *registryHandler) UpsertRegistryRecords(ctx context.Context, req *proto
 }
 
 type RegistryAPIFunctions struct {
-	ConfigureRegistry    func(context.Context, *prototk.ConfigureRegistryRequest) (*prototk.ConfigureRegistryResponse, error)
-	HandleRegistryEvents func(context.Context, *prototk.HandleRegistryEventsRequest) (*prototk.HandleRegistryEventsResponse, error)
+	ConfigureRegistry           func(context.Context, *prototk.ConfigureRegistryRequest) (*prototk.ConfigureRegistryResponse, error)
+	HandleRegistryEvents        func(context.Context, *prototk.HandleRegistryEventsRequest) (*prototk.HandleRegistryEventsResponse, error)
+	PrepareRegistryTransactions func(context.Context, *prototk.PrepareRegistryTransactionsRequest) (*prototk.PrepareRegistryTransactionsResponse, error)
 }
 
 type RegistryAPIBase struct {
@@ -155,3 +161,7 @@ func (tb *RegistryAPIBase) ConfigureRegistry(ctx context.Context, req *prototk.C
 func (tb *RegistryAPIBase) HandleRegistryEvents(ctx context.Context, req *prototk.HandleRegistryEventsRequest) (*prototk.HandleRegistryEventsResponse, error) {
 	return callPluginImpl(ctx, req, tb.Functions.HandleRegistryEvents)
 }
+
+func (tb *RegistryAPIBase) PrepareRegistryTransactions(ctx context.Context, req *prototk.PrepareRegistryTransactionsRequest) (*prototk.PrepareRegistryTransactionsResponse, error) {
+	return callPluginImpl(ctx, req, tb.Functions.PrepareRegistryTransactions)
+}
@@ -109,6 +109,23 @@ func TestRegistryFunction_HandleRegistryEvents(t *testing.T) {
 	})
 }
 
+func TestRegistryFunction_PrepareRegistryTransactions(t *testing.T) {
+	_, exerciser, funcs, _, _, done := setupRegistryTests(t)
+	defer done()
+
+	// PrepareRegistryTransactions - paladin to registry
+	funcs.PrepareRegistryTransactions = func(ctx context.Context, cdr *prototk.PrepareRegistryTransactionsRequest) (*prototk.PrepareRegistryTransactionsResponse, error) {
+		return &prototk.PrepareRegistryTransactionsResponse{}, nil
+	}
+	exerciser.doExchangeToPlugin(func(req *prototk.RegistryMessage) {
+		req.RequestToRegistry = &prototk.RegistryMessage_PrepareRegistryTransactions{
+			PrepareRegistryTransactions: &prototk.PrepareRegistryTransactionsRequest{},
+		}
+	}, func(res *prototk.RegistryMessage) {
+		assert.IsType(t, &prototk.RegistryMessage_PrepareRegistryTransactionsRes{}, res.ResponseFromRegistry)
+	})
+}
+
 func TestRegistryRequestError(t *testing.T) {
 	_, exerciser, _, _, _, done := setupRegistryTests(t)
 	defer done()
@@ -94,7 +94,7 @@ func responseToPluginAs[M, ResType, T any](ctx context.Context, msg PluginMessag
 
 func callPluginImpl[IN, OUT any](ctx context.Context, in *IN, fn func(context.Context, *IN) (*OUT, error)) (*OUT, error) {
 	if fn == nil {
-		return nil, i18n.NewError(ctx, pldmsgs.MsgPluginUnimplementedRequest, new(IN))
+		return nil, NewNotSupportedError(i18n.NewError(ctx, pldmsgs.MsgPluginUnimplementedRequest, new(IN)))
 	}
 	return fn(ctx, in)
 }
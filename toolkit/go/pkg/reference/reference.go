@@ -78,6 +78,7 @@ var allTypes = []interface{}{
 	pldapi.IndexedEvent{},
 	pldapi.TransactionReceipt{},
 	pldapi.TransactionReceiptFull{},
+	pldapi.TransactionExportRecord{},
 	pldapi.TransactionReceiptListener{},
 	pldapi.TransactionReceiptFilters{},
 	pldapi.TransactionReceiptListenerOptions{},
@@ -110,6 +111,7 @@ var allTypes = []interface{}{
 		},
 	},
 	pldapi.RegistryProperty{},
+	pldapi.RegistryPropertyChange{OnChainLocation: &pldapi.OnChainLocation{}},
 	pldapi.OnChainLocation{},
 	pldapi.IndexedBlock{},
 	pldapi.IndexedTransaction{},
@@ -118,15 +120,19 @@ var allTypes = []interface{}{
 	pldapi.ABIDecodedData{},
 	pldapi.PeerInfo{},
 	pldapi.KeyMappingAndVerifier{},
+	pldapi.KeyRotation{},
+	pldapi.KeySigningAuditEntry{},
 	pldapi.ReliableMessageAck{},
 	pldapi.ReliableMessage{},
 	pldapi.PrivacyGroup{},
 	pldapi.PrivacyGroupEVMCall{},
 	pldapi.PrivacyGroupEVMTXInput{},
 	pldapi.PrivacyGroupInput{},
+	pldapi.PrivacyGroupMembersInput{},
 	pldapi.PrivacyGroupMessageListener{},
 	pldapi.PrivacyGroupMessage{},
 	pldapi.PrivacyGroupMessageInput{},
+	pldapi.PrivacyGroupMessagePolicy{},
 	pldtypes.JSONFormatOptions(""),
 	pldapi.StateStatusQualifier(""),
 	query.QueryJSON{
@@ -229,8 +235,10 @@ var allTypes = []interface{}{
 	pldapi.BlockchainEventListener{},
 	pldapi.BlockchainEventListenerOptions{},
 	pldapi.BlockchainEventListenerSource{},
+	pldapi.BlockchainEventListenerFactory{},
 	pldapi.BlockchainEventListenerStatus{},
 	pldapi.BlockchainEventListenerCheckpoint{},
+	pldapi.ListenerDeadLetter{},
 }
 var allAPITypes = []pldclient.RPCModule{
 	pldclient.New().PTX(),
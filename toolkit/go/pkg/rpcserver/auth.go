@@ -0,0 +1,152 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+)
+
+// Principal is the authenticated caller of a JSON/RPC method
+type Principal struct {
+	Identity string
+	Roles    []string
+	Expires  time.Time // zero if the credential does not expire
+}
+
+type principalContextKey struct{}
+
+// AuthenticatedPrincipal returns the authenticated caller, or nil if authentication is not enabled
+func AuthenticatedPrincipal(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalContextKey{}).(*Principal)
+	return p
+}
+
+func withPrincipal(ctx context.Context, p *Principal) context.Context {
+	if p == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+type authenticator struct {
+	staticTokens map[[32]byte]*Principal // keyed by hash, so the lookup does not leak timing information about the token
+	jwt          *jwtValidator
+	mtls         bool
+	mtlsRoles    map[string][]string
+	roleMethods  map[string][]string // method patterns each role can call
+}
+
+// Returns nil if no authentication is configured
+func newAuthenticator(ctx context.Context, conf *pldconf.RPCAuthConfig) (a *authenticator, err error) {
+	if len(conf.StaticTokens) == 0 && conf.JWT.JWKSFile == "" && !conf.MTLS.Enabled {
+		return nil, nil
+	}
+	a = &authenticator{
+		staticTokens: make(map[[32]byte]*Principal),
+		mtls:         conf.MTLS.Enabled,
+		mtlsRoles:    conf.MTLS.Roles,
+		roleMethods:  make(map[string][]string),
+	}
+	for _, st := range conf.StaticTokens {
+		token := st.Token
+		if st.TokenFile != "" {
+			b, err := os.ReadFile(st.TokenFile)
+			if err != nil {
+				return nil, i18n.WrapError(ctx, err, pldmsgs.MsgJSONRPCAuthConfigInvalid, st.TokenFile)
+			}
+			token = strings.TrimSpace(string(b))
+		}
+		if st.Identity == "" || token == "" {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCAuthConfigInvalid, "static tokens require an identity and a token")
+		}
+		a.staticTokens[sha256.Sum256([]byte(token))] = &Principal{Identity: st.Identity, Roles: st.Roles}
+	}
+	if conf.JWT.JWKSFile != "" {
+		if a.jwt, err = newJWTValidator(ctx, &conf.JWT); err != nil {
+			return nil, err
+		}
+	}
+	for role, groups := range conf.Roles {
+		for _, group := range groups {
+			patterns, ok := conf.MethodGroups[group]
+			if !ok {
+				return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCAuthConfigInvalid, "role '"+role+"' refers to unknown method group '"+group+"'")
+			}
+			a.roleMethods[role] = append(a.roleMethods[role], patterns...)
+		}
+	}
+	return a, nil
+}
+
+func (a *authenticator) authenticate(req *http.Request) (*Principal, error) {
+	ctx := req.Context()
+	if a == nil {
+		return nil, nil
+	}
+	if token, isBearer := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer "); isBearer {
+		if p := a.staticTokens[sha256.Sum256([]byte(token))]; p != nil {
+			return p, nil
+		}
+		if a.jwt != nil {
+			return a.jwt.validate(ctx, token)
+		}
+	} else if a.mtls && req.TLS != nil && len(req.TLS.PeerCertificates) > 0 {
+		// The certificate has been verified by the TLS server, so we just need the subject
+		subject := req.TLS.PeerCertificates[0].Subject.String()
+		return &Principal{Identity: subject, Roles: a.mtlsRoles[subject]}, nil
+	}
+	return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCUnauthenticated)
+}
+
+func (a *authenticator) authorize(ctx context.Context, method string) error {
+	if a == nil || len(a.roleMethods) == 0 {
+		return nil
+	}
+	p := AuthenticatedPrincipal(ctx)
+	if p != nil {
+		for _, role := range p.Roles {
+			for _, pattern := range a.roleMethods[role] {
+				if methodMatches(pattern, method) {
+					return nil
+				}
+			}
+		}
+	}
+	identity := ""
+	if p != nil {
+		identity = p.Identity
+	}
+	log.L(ctx).Warnf("Identity '%s' not authorized to call %s", identity, method)
+	return i18n.NewError(ctx, pldmsgs.MsgJSONRPCUnauthorized, identity, method)
+}
+
+func methodMatches(pattern, method string) bool {
+	if prefix, isWildcard := strings.CutSuffix(pattern, "*"); isWildcard {
+		return strings.HasPrefix(method, prefix)
+	}
+	return pattern == method
+}
@@ -0,0 +1,262 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+)
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Validates JWTs signed with RSA (RS256/384/512), ECDSA (ES256/384/512) or Ed25519 (EdDSA)
+// keys, loaded from a JSON Web Key Set (JWKS) file
+type jwtValidator struct {
+	keys          map[string]crypto.PublicKey
+	issuer        string
+	audience      string
+	identityClaim string
+	rolesClaim    string
+	clockSkew     time.Duration
+}
+
+func newJWTValidator(ctx context.Context, conf *pldconf.RPCAuthJWTConfig) (*jwtValidator, error) {
+	v := &jwtValidator{
+		keys:          make(map[string]crypto.PublicKey),
+		issuer:        conf.Issuer,
+		audience:      conf.Audience,
+		identityClaim: confutil.StringNotEmpty(conf.IdentityClaim, *pldconf.RPCAuthDefaults.JWT.IdentityClaim),
+		rolesClaim:    confutil.StringNotEmpty(conf.RolesClaim, *pldconf.RPCAuthDefaults.JWT.RolesClaim),
+		clockSkew:     confutil.DurationMin(conf.ClockSkew, 0, *pldconf.RPCAuthDefaults.JWT.ClockSkew),
+	}
+	var jwks struct {
+		Keys []*jwk `json:"keys"`
+	}
+	b, err := os.ReadFile(conf.JWKSFile)
+	if err == nil {
+		err = json.Unmarshal(b, &jwks)
+	}
+	for i := 0; err == nil && i < len(jwks.Keys); i++ {
+		var key crypto.PublicKey
+		if key, err = jwks.Keys[i].publicKey(); err == nil {
+			v.keys[jwks.Keys[i].Kid] = key
+		}
+	}
+	if err == nil && len(v.keys) == 0 {
+		err = i18n.NewError(ctx, pldmsgs.MsgJSONRPCAuthConfigInvalid, "no keys")
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgJSONRPCJWKSLoadFailed, conf.JWKSFile)
+	}
+	return v, nil
+}
+
+func b64BigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (k *jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := b64BigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := b64BigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, i18n.NewError(context.Background(), pldmsgs.MsgJSONRPCAuthConfigInvalid, "unsupported curve "+k.Crv)
+		}
+		x, err := b64BigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := b64BigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if k.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, i18n.NewError(context.Background(), pldmsgs.MsgJSONRPCAuthConfigInvalid, "unsupported curve "+k.Crv)
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, i18n.NewError(context.Background(), pldmsgs.MsgJSONRPCAuthConfigInvalid, "unsupported key type "+k.Kty)
+	}
+}
+
+func (v *jwtValidator) validate(ctx context.Context, token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCJWTInvalid, "format")
+	}
+	var header jwtHeader
+	var claims map[string]any
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err == nil {
+		err = json.Unmarshal(headerBytes, &header)
+	}
+	claimsBytes, err2 := base64.RawURLEncoding.DecodeString(parts[1])
+	if err2 == nil {
+		err2 = json.Unmarshal(claimsBytes, &claims)
+	}
+	sig, err3 := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || err2 != nil || err3 != nil {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCJWTInvalid, "encoding")
+	}
+
+	key := v.keys[header.Kid]
+	if key == nil {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCJWTInvalid, "unknown key")
+	}
+	if !verifyJWTSignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), sig) {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCJWTInvalid, "signature")
+	}
+
+	now := time.Now()
+	if exp, ok := claims["exp"].(float64); ok && now.After(time.Unix(int64(exp), 0).Add(v.clockSkew)) {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCJWTInvalid, "expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(v.clockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCJWTInvalid, "not yet valid")
+	}
+	if v.issuer != "" && claims["iss"] != v.issuer {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCJWTInvalid, "issuer")
+	}
+	if v.audience != "" && !claimContains(claims["aud"], v.audience) {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCJWTInvalid, "audience")
+	}
+	identity, _ := claims[v.identityClaim].(string)
+	if identity == "" {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCJWTInvalid, "missing "+v.identityClaim)
+	}
+	p := &Principal{Identity: identity, Roles: claimStrings(claims[v.rolesClaim])}
+	if exp, ok := claims["exp"].(float64); ok {
+		p.Expires = time.Unix(int64(exp), 0).Add(v.clockSkew)
+	}
+	return p, nil
+}
+
+func verifyJWTSignature(alg string, key crypto.PublicKey, signed, sig []byte) bool {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "ES512":
+		hash = crypto.SHA512
+	case "EdDSA":
+		edKey, ok := key.(ed25519.PublicKey)
+		return ok && ed25519.Verify(edKey, signed, sig)
+	default:
+		return false
+	}
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return strings.HasPrefix(alg, "RS") && rsa.VerifyPKCS1v15(k, hash, digest, sig) == nil
+	case *ecdsa.PublicKey:
+		// JWS uses the fixed length R||S encoding, rather than ASN.1
+		byteLen := (k.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(alg, "ES") || len(sig) != 2*byteLen {
+			return false
+		}
+		r := new(big.Int).SetBytes(sig[:byteLen])
+		s := new(big.Int).SetBytes(sig[byteLen:])
+		return ecdsa.Verify(k, digest, r, s)
+	default:
+		return false
+	}
+}
+
+func claimStrings(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		strs := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				strs = append(strs, s)
+			}
+		}
+		return strs
+	default:
+		return nil
+	}
+}
+
+func claimContains(v any, s string) bool {
+	for _, e := range claimStrings(v) {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
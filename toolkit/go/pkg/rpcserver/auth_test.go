@@ -0,0 +1,444 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAuthConfig() pldconf.RPCAuthConfig {
+	return pldconf.RPCAuthConfig{
+		StaticTokens: []pldconf.RPCAuthStaticTokenConfig{
+			{Identity: "reader", Token: "reader-token", Roles: []string{"reader"}},
+			{Identity: "admin", Token: "admin-token", Roles: []string{"admin"}},
+		},
+		MethodGroups: map[string][]string{
+			"read": {"test_query*", "test_get"},
+			"all":  {"*"},
+		},
+		Roles: map[string][]string{
+			"reader": {"read"},
+			"admin":  {"all"},
+		},
+	}
+}
+
+func regTestAuthMethods(s *rpcServer) {
+	identityMethod := RPCMethod0(func(ctx context.Context) (string, error) {
+		return AuthenticatedPrincipal(ctx).Identity, nil
+	})
+	regTestRPC(s, "test_queryThings", identityMethod)
+	regTestRPC(s, "test_send", identityMethod)
+}
+
+func newTestHTTPClient(t *testing.T, url, token string) rpcclient.Client {
+	conf := &pldconf.HTTPClientConfig{URL: url}
+	if token != "" {
+		conf.HTTPHeaders = map[string]interface{}{"Authorization": "Bearer " + token}
+	}
+	c, err := rpcclient.NewHTTPClient(context.Background(), conf)
+	require.NoError(t, err)
+	return c
+}
+
+func TestAuthStaticTokensHTTP(t *testing.T) {
+	ctx := context.Background()
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{Auth: testAuthConfig()})
+	defer done()
+	regTestAuthMethods(s)
+
+	var identity string
+	err := newTestHTTPClient(t, url, "").CallRPC(ctx, &identity, "test_queryThings")
+	assert.Regexp(t, "PD020707", err)
+	err = newTestHTTPClient(t, url, "wrong").CallRPC(ctx, &identity, "test_queryThings")
+	assert.Regexp(t, "PD020707", err)
+
+	reader := newTestHTTPClient(t, url, "reader-token")
+	err = reader.CallRPC(ctx, &identity, "test_queryThings")
+	require.NoError(t, err)
+	assert.Equal(t, "reader", identity)
+	err = reader.CallRPC(ctx, &identity, "test_send")
+	assert.Regexp(t, "PD020708.*reader.*test_send", err)
+
+	admin := newTestHTTPClient(t, url, "admin-token")
+	err = admin.CallRPC(ctx, &identity, "test_send")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", identity)
+
+	res, httpErr := http.DefaultClient.Post(url, "application/json", nil)
+	require.NoError(t, httpErr)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func TestAuthStaticTokensWebSocket(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelCtx()
+	url, s, done := newTestServerWebSockets(t, &pldconf.RPCServerConfig{Auth: testAuthConfig()})
+	defer done()
+	regTestAuthMethods(s)
+
+	wsConfig := &pldconf.WSClientConfig{InitialConnectAttempts: confutil.P(1)}
+	wsConfig.URL = url
+	client := rpcclient.WrapWSConfig(wsConfig)
+	err := client.Connect(ctx)
+	assert.Error(t, err)
+
+	wsConfig.HTTPHeaders = map[string]interface{}{"Authorization": "Bearer reader-token"}
+	client = rpcclient.WrapWSConfig(wsConfig)
+	defer client.Close()
+	err = client.Connect(ctx)
+	require.NoError(t, err)
+
+	var identity string
+	rpcErr := client.CallRPC(ctx, &identity, "test_queryThings")
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "reader", identity)
+	rpcErr = client.CallRPC(ctx, &identity, "test_send")
+	assert.Regexp(t, "PD020708", rpcErr)
+}
+
+func TestAuthNoRolesAllowsAnyAuthenticated(t *testing.T) {
+	ctx := context.Background()
+	authConf := testAuthConfig()
+	authConf.Roles = nil
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{Auth: authConf})
+	defer done()
+	regTestAuthMethods(s)
+
+	var identity string
+	err := newTestHTTPClient(t, url, "reader-token").CallRPC(ctx, &identity, "test_send")
+	require.NoError(t, err)
+	assert.Equal(t, "reader", identity)
+}
+
+func TestAuthMTLS(t *testing.T) {
+	a, err := newAuthenticator(context.Background(), &pldconf.RPCAuthConfig{
+		MTLS: pldconf.RPCAuthMTLSConfig{
+			Enabled: true,
+			Roles:   map[string][]string{"CN=app1,O=acme": {"reader"}},
+		},
+		MethodGroups: map[string][]string{"read": {"test_query*"}},
+		Roles:        map[string][]string{"reader": {"read"}},
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	require.NoError(t, err)
+	_, err = a.authenticate(req)
+	assert.Regexp(t, "PD020707", err)
+
+	req.TLS = &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "app1", Organization: []string{"acme"}}}},
+	}
+	p, err := a.authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, "CN=app1,O=acme", p.Identity)
+
+	ctx := withPrincipal(context.Background(), p)
+	require.NoError(t, a.authorize(ctx, "test_queryThings"))
+	assert.Regexp(t, "PD020708", a.authorize(ctx, "test_send"))
+	assert.Regexp(t, "PD020708", a.authorize(context.Background(), "test_send"))
+}
+
+type testJWTKey struct {
+	kid string
+	alg string
+	key crypto.Signer
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (k *testJWTKey) jwk() map[string]string {
+	switch pub := k.key.Public().(type) {
+	case *rsa.PublicKey:
+		return map[string]string{"kty": "RSA", "kid": k.kid, "n": b64(pub.N.Bytes()), "e": b64(big.NewInt(int64(pub.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		return map[string]string{"kty": "EC", "kid": k.kid, "crv": pub.Curve.Params().Name, "x": b64(pub.X.Bytes()), "y": b64(pub.Y.Bytes())}
+	default:
+		return map[string]string{"kty": "OKP", "kid": k.kid, "crv": "Ed25519", "x": b64(pub.(ed25519.PublicKey))}
+	}
+}
+
+func (k *testJWTKey) sign(t *testing.T, claims map[string]any) string {
+	header, err := json.Marshal(map[string]string{"alg": k.alg, "kid": k.kid})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed := b64(header) + "." + b64(payload)
+	var sig []byte
+	switch key := k.key.(type) {
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signed))
+		sig, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signed))
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, key, digest[:])
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	default:
+		sig, err = k.key.Sign(rand.Reader, []byte(signed), crypto.Hash(0))
+	}
+	require.NoError(t, err)
+	return signed + "." + b64(sig)
+}
+
+func writeJWKS(t *testing.T, keys ...map[string]string) string {
+	b, err := json.Marshal(map[string]any{"keys": keys})
+	require.NoError(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, b, 0644))
+	return jwksFile
+}
+
+func newTestJWTKeys(t *testing.T) (*testJWTKey, *testJWTKey, *testJWTKey) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return &testJWTKey{kid: "rsa1", alg: "RS256", key: rsaKey},
+		&testJWTKey{kid: "ec1", alg: "ES256", key: ecKey},
+		&testJWTKey{kid: "ed1", alg: "EdDSA", key: edKey}
+}
+
+func TestAuthJWTHTTP(t *testing.T) {
+	ctx := context.Background()
+	rsaKey, ecKey, edKey := newTestJWTKeys(t)
+	authConf := testAuthConfig()
+	authConf.StaticTokens = nil
+	authConf.JWT = pldconf.RPCAuthJWTConfig{
+		JWKSFile: writeJWKS(t, rsaKey.jwk(), ecKey.jwk(), edKey.jwk()),
+		Issuer:   "https://issuer.example.com",
+		Audience: "paladin",
+	}
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{Auth: authConf})
+	defer done()
+	regTestAuthMethods(s)
+
+	exp := time.Now().Add(1 * time.Hour).Unix()
+	for _, k := range []*testJWTKey{rsaKey, ecKey, edKey} {
+		token := k.sign(t, map[string]any{
+			"sub":   "user-" + k.kid,
+			"iss":   "https://issuer.example.com",
+			"aud":   []string{"other", "paladin"},
+			"exp":   exp,
+			"roles": "reader",
+		})
+		client := newTestHTTPClient(t, url, token)
+		var identity string
+		err := client.CallRPC(ctx, &identity, "test_queryThings")
+		require.NoError(t, err)
+		assert.Equal(t, "user-"+k.kid, identity)
+		err = client.CallRPC(ctx, &identity, "test_send")
+		assert.Regexp(t, "PD020708", err)
+	}
+}
+
+func TestAuthJWTWebSocketClosedOnExpiry(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+	_, ecKey, _ := newTestJWTKeys(t)
+	authConf := testAuthConfig()
+	authConf.StaticTokens = nil
+	authConf.JWT = pldconf.RPCAuthJWTConfig{
+		JWKSFile:  writeJWKS(t, ecKey.jwk()),
+		ClockSkew: confutil.P("0s"),
+	}
+	url, s, done := newTestServerWebSockets(t, &pldconf.RPCServerConfig{Auth: authConf})
+	defer done()
+	regTestAuthMethods(s)
+
+	token := ecKey.sign(t, map[string]any{
+		"sub":   "user1",
+		"exp":   time.Now().Add(1 * time.Second).Unix(),
+		"roles": "reader",
+	})
+	wsConfig := &pldconf.WSClientConfig{InitialConnectAttempts: confutil.P(1)}
+	wsConfig.URL = url
+	wsConfig.HTTPHeaders = map[string]interface{}{"Authorization": "Bearer " + token}
+	client := rpcclient.WrapWSConfig(wsConfig)
+	defer client.Close()
+	err := client.Connect(ctx)
+	require.NoError(t, err)
+
+	var identity string
+	rpcErr := client.CallRPC(ctx, &identity, "test_queryThings")
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "user1", identity)
+
+	// The server closes the connection once the token expires
+	for {
+		s.wsMux.Lock()
+		connections := len(s.wsConnections)
+		s.wsMux.Unlock()
+		if connections == 0 {
+			break
+		}
+		require.NoError(t, ctx.Err())
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestAuthJWTValidation(t *testing.T) {
+	ctx := context.Background()
+	rsaKey, ecKey, edKey := newTestJWTKeys(t)
+	v, err := newJWTValidator(ctx, &pldconf.RPCAuthJWTConfig{
+		JWKSFile:      writeJWKS(t, rsaKey.jwk(), ecKey.jwk(), edKey.jwk()),
+		Issuer:        "iss1",
+		Audience:      "aud1",
+		IdentityClaim: confutil.P("email"),
+		RolesClaim:    confutil.P("groups"),
+		ClockSkew:     confutil.P("0s"),
+	})
+	require.NoError(t, err)
+
+	now := time.Now().Unix()
+	valid := func() map[string]any {
+		return map[string]any{"email": "user@example.com", "iss": "iss1", "aud": "aud1", "exp": now + 60, "nbf": now - 60, "groups": []string{"a", "b"}}
+	}
+
+	p, err := v.validate(ctx, ecKey.sign(t, valid()))
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", p.Identity)
+	assert.Equal(t, []string{"a", "b"}, p.Roles)
+	assert.Equal(t, now+60, p.Expires.Unix())
+
+	for reason, modify := range map[string]func(claims map[string]any){
+		"expired":       func(c map[string]any) { c["exp"] = now - 60 },
+		"not yet valid": func(c map[string]any) { c["nbf"] = now + 60 },
+		"issuer":        func(c map[string]any) { c["iss"] = "iss2" },
+		"audience":      func(c map[string]any) { c["aud"] = "aud2" },
+		"missing email": func(c map[string]any) { delete(c, "email") },
+	} {
+		claims := valid()
+		modify(claims)
+		_, err = v.validate(ctx, rsaKey.sign(t, claims))
+		assert.Regexp(t, "PD020711.*"+reason, err)
+	}
+
+	// Wrong key for the kid, and mismatched algorithms
+	wrongKey := &testJWTKey{kid: "ec1", alg: "ES256", key: rsaKey.key}
+	_, err = v.validate(ctx, wrongKey.sign(t, valid()))
+	assert.Regexp(t, "PD020711.*signature", err)
+	for _, alg := range []string{"RS256", "RS384", "RS512", "ES384", "ES512", "EdDSA", "none"} {
+		wrongAlg := &testJWTKey{kid: "ec1", alg: alg, key: ecKey.key}
+		_, err = v.validate(ctx, wrongAlg.sign(t, valid()))
+		assert.Regexp(t, "PD020711.*signature", err)
+	}
+	wrongAlg := &testJWTKey{kid: "rsa1", alg: "ES256", key: ecKey.key}
+	_, err = v.validate(ctx, wrongAlg.sign(t, valid()))
+	assert.Regexp(t, "PD020711.*signature", err)
+	wrongAlg = &testJWTKey{kid: "ed1", alg: "ES256", key: ecKey.key}
+	_, err = v.validate(ctx, wrongAlg.sign(t, valid()))
+	assert.Regexp(t, "PD020711.*signature", err)
+
+	unknownKey := &testJWTKey{kid: "unknown", alg: "ES256", key: ecKey.key}
+	_, err = v.validate(ctx, unknownKey.sign(t, valid()))
+	assert.Regexp(t, "PD020711.*unknown key", err)
+
+	_, err = v.validate(ctx, "not.a-jwt")
+	assert.Regexp(t, "PD020711.*format", err)
+	_, err = v.validate(ctx, "!!!.!!!.!!!")
+	assert.Regexp(t, "PD020711.*encoding", err)
+
+	assert.Nil(t, claimStrings(12345))
+	assert.Equal(t, []string{"a"}, claimStrings([]any{"a", 1}))
+}
+
+func TestAuthConfigErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewRPCServer(ctx, &pldconf.RPCServerConfig{
+		Auth: pldconf.RPCAuthConfig{
+			StaticTokens: []pldconf.RPCAuthStaticTokenConfig{{Token: "no identity"}},
+		},
+	})
+	assert.Regexp(t, "PD020709", err)
+
+	_, err = newAuthenticator(ctx, &pldconf.RPCAuthConfig{
+		StaticTokens: []pldconf.RPCAuthStaticTokenConfig{{Identity: "id1", TokenFile: t.TempDir()}},
+	})
+	assert.Regexp(t, "PD020709", err)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token1\n"), 0600))
+	a, err := newAuthenticator(ctx, &pldconf.RPCAuthConfig{
+		StaticTokens: []pldconf.RPCAuthStaticTokenConfig{{Identity: "id1", TokenFile: tokenFile}},
+	})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer token1")
+	p, err := a.authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, "id1", p.Identity)
+
+	_, err = newAuthenticator(ctx, &pldconf.RPCAuthConfig{
+		MTLS:  pldconf.RPCAuthMTLSConfig{Enabled: true},
+		Roles: map[string][]string{"role1": {"missing"}},
+	})
+	assert.Regexp(t, "PD020709.*missing", err)
+
+	_, err = newAuthenticator(ctx, &pldconf.RPCAuthConfig{
+		JWT: pldconf.RPCAuthJWTConfig{JWKSFile: filepath.Join(t.TempDir(), "missing.json")},
+	})
+	assert.Regexp(t, "PD020710", err)
+
+	for _, badKey := range []map[string]string{
+		{},
+		{"kty": "unknown"},
+		{"kty": "RSA", "n": "!!!"},
+		{"kty": "RSA", "n": "AQAB", "e": "!!!"},
+		{"kty": "EC", "crv": "P-999"},
+		{"kty": "EC", "crv": "P-384", "x": "!!!"},
+		{"kty": "EC", "crv": "P-521", "x": "AQAB", "y": "!!!"},
+		{"kty": "OKP", "crv": "X25519", "x": "AQAB"},
+		{"kty": "OKP", "x": "!!!"},
+	} {
+		_, err = newJWTValidator(ctx, &pldconf.RPCAuthJWTConfig{JWKSFile: writeJWKS(t, badKey)})
+		assert.Regexp(t, "PD020710", err)
+	}
+	_, err = newJWTValidator(ctx, &pldconf.RPCAuthJWTConfig{JWKSFile: writeJWKS(t)})
+	assert.Regexp(t, "PD020710", err)
+}
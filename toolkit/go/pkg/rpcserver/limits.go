@@ -0,0 +1,195 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+)
+
+// Buckets that have refilled are discarded on this interval, so idle clients do not consume memory
+const rateLimitPruneInterval = 1 * time.Minute
+
+type clientContextKey struct{}
+
+// Rate limits are applied to the authenticated identity if there is one, otherwise to the remote IP
+func clientID(req *http.Request, principal *Principal) string {
+	if principal != nil {
+		return principal.Identity
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+func withClientID(ctx context.Context, client string) context.Context {
+	return context.WithValue(ctx, clientContextKey{}, client)
+}
+
+func clientIDFromContext(ctx context.Context) string {
+	client, _ := ctx.Value(clientContextKey{}).(string)
+	return client
+}
+
+type limiter struct {
+	maxRequestSize   int64
+	maxBatchSize     int
+	maxSubscriptions int
+	rateLimits       []*rateLimit
+	subsMux          sync.Mutex
+	subscriptions    map[string]int // by client, across all of its connections
+}
+
+type rateLimit struct {
+	name      string
+	methods   []string
+	rate      float64 // tokens per second
+	burst     float64
+	mux       sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastPrune time.Time
+}
+
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newLimiter(ctx context.Context, conf *pldconf.RPCLimitsConfig) (*limiter, error) {
+	l := &limiter{
+		maxRequestSize:   confutil.ByteSize(conf.MaxRequestSize, 0, "0"),
+		maxBatchSize:     confutil.Int(conf.MaxBatchSize, 0),
+		maxSubscriptions: confutil.Int(conf.MaxSubscriptionsPerClient, 0),
+		subscriptions:    make(map[string]int),
+	}
+	for _, rlc := range conf.RateLimits {
+		if rlc.Name == "" || len(rlc.Methods) == 0 {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgJSONRPCLimitsConfigInvalid, "rate limits require a name and a list of methods")
+		}
+		rl := &rateLimit{
+			name:      rlc.Name,
+			methods:   rlc.Methods,
+			rate:      confutil.Float64Min(rlc.RequestsPerSecond, 0, *pldconf.RPCRateLimitDefaults.RequestsPerSecond),
+			burst:     float64(confutil.IntMin(rlc.Burst, 1, *pldconf.RPCRateLimitDefaults.Burst)),
+			buckets:   make(map[string]*tokenBucket),
+			lastPrune: time.Now(),
+		}
+		log.L(ctx).Infof("JSON/RPC rate limit '%s' requestsPerSecond=%f burst=%d methods=%v", rl.name, rl.rate, int(rl.burst), rl.methods)
+		l.rateLimits = append(l.rateLimits, rl)
+	}
+	return l, nil
+}
+
+// Returns the first rate limit that does not allow the request, or nil.
+// A token is only consumed from each matching bucket if all of them allow the request.
+func (l *limiter) checkRateLimits(client, method string) *rateLimit {
+	now := time.Now()
+	var matched []*rateLimit
+	for _, rl := range l.rateLimits {
+		for _, pattern := range rl.methods {
+			if methodMatches(pattern, method) {
+				matched = append(matched, rl)
+				break
+			}
+		}
+	}
+	// The check and the take happen under the locks of all the matching rate limits, so concurrent
+	// requests cannot both pass the check for the last token. Locks are always taken in config order.
+	for _, rl := range matched {
+		rl.mux.Lock()
+		defer rl.mux.Unlock()
+	}
+	buckets := make([]*tokenBucket, len(matched))
+	for i, rl := range matched {
+		buckets[i] = rl.refilledBucket(client, now)
+		if buckets[i].tokens < 1 {
+			return rl
+		}
+	}
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return nil
+}
+
+func (rl *rateLimit) refilled(b *tokenBucket, now time.Time) float64 {
+	return math.Min(rl.burst, b.tokens+now.Sub(b.lastRefill).Seconds()*rl.rate)
+}
+
+// Must be called holding the lock
+func (rl *rateLimit) refilledBucket(client string, now time.Time) *tokenBucket {
+	if now.Sub(rl.lastPrune) > rateLimitPruneInterval {
+		for c, b := range rl.buckets {
+			if rl.refilled(b, now) >= rl.burst {
+				delete(rl.buckets, c)
+			}
+		}
+		rl.lastPrune = now
+	}
+
+	b := rl.buckets[client]
+	if b == nil {
+		b = &tokenBucket{tokens: rl.burst, lastRefill: now}
+		rl.buckets[client] = b
+	} else {
+		b.tokens = rl.refilled(b, now)
+		b.lastRefill = now
+	}
+	return b
+}
+
+// Subscriptions are counted for each client across all of its WebSocket connections,
+// so a client cannot avoid the limit by opening more connections.
+func (l *limiter) reserveSubscription(client string) bool {
+	if l.maxSubscriptions <= 0 {
+		return true
+	}
+	l.subsMux.Lock()
+	defer l.subsMux.Unlock()
+
+	if l.subscriptions[client] >= l.maxSubscriptions {
+		return false
+	}
+	l.subscriptions[client]++
+	return true
+}
+
+func (l *limiter) releaseSubscriptions(client string, count int) {
+	if l.maxSubscriptions <= 0 || count == 0 {
+		return
+	}
+	l.subsMux.Lock()
+	defer l.subsMux.Unlock()
+
+	if remaining := l.subscriptions[client] - count; remaining > 0 {
+		l.subscriptions[client] = remaining
+	} else {
+		delete(l.subscriptions, client)
+	}
+}
@@ -0,0 +1,397 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func regTestLimitMethods(s *rpcServer) {
+	clientMethod := RPCMethod0(func(ctx context.Context) (string, error) {
+		return clientIDFromContext(ctx), nil
+	})
+	regTestRPC(s, "test_send", clientMethod)
+	regTestRPC(s, "test_queryThings", clientMethod)
+}
+
+func TestRateLimitsHTTP(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelCtx()
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		Limits: pldconf.RPCLimitsConfig{
+			RateLimits: []pldconf.RPCRateLimitConfig{
+				{Name: "writes", Methods: []string{"test_send"}, RequestsPerSecond: confutil.P(0.0), Burst: confutil.P(2)},
+				{Name: "all", Methods: []string{"test_*"}, RequestsPerSecond: confutil.P(0.0), Burst: confutil.P(4)},
+			},
+		},
+	})
+	defer done()
+	regTestLimitMethods(s)
+	registry := prometheus.NewRegistry()
+	s.RegisterMetrics(registry)
+
+	client := newTestHTTPClient(t, url, "")
+	var clientID string
+	for i := 0; i < 2; i++ {
+		err := client.CallRPC(ctx, &clientID, "test_send")
+		require.Nil(t, err)
+		assert.Equal(t, "127.0.0.1", clientID)
+	}
+	err := client.CallRPC(ctx, &clientID, "test_send")
+	assert.Regexp(t, "PD020713.*writes.*test_send", err)
+
+	// The rejected request did not consume a token from the "all" limit
+	for i := 0; i < 2; i++ {
+		err := client.CallRPC(ctx, &clientID, "test_queryThings")
+		require.Nil(t, err)
+	}
+	err = client.CallRPC(ctx, &clientID, "test_queryThings")
+	assert.Regexp(t, "PD020713.*all.*test_queryThings", err)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.metrics.rateLimitedRequests.WithLabelValues("writes")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.metrics.rateLimitedRequests.WithLabelValues("all")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(s.metrics.rejectedRequests.WithLabelValues(rejectReasonRateLimited)))
+	count, gatherErr := testutil.GatherAndCount(registry, "rpc_server_rejected_requests_total")
+	require.NoError(t, gatherErr)
+	assert.Equal(t, 1, count)
+}
+
+func TestRateLimitsPerAuthenticatedIdentity(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelCtx()
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		Auth: testAuthConfig(),
+		Limits: pldconf.RPCLimitsConfig{
+			RateLimits: []pldconf.RPCRateLimitConfig{
+				{Name: "queries", Methods: []string{"test_queryThings"}, RequestsPerSecond: confutil.P(0.0), Burst: confutil.P(1)},
+			},
+		},
+	})
+	defer done()
+	regTestLimitMethods(s)
+
+	var clientID string
+	reader := newTestHTTPClient(t, url, "reader-token")
+	err := reader.CallRPC(ctx, &clientID, "test_queryThings")
+	require.Nil(t, err)
+	assert.Equal(t, "reader", clientID)
+	err = reader.CallRPC(ctx, &clientID, "test_queryThings")
+	assert.Regexp(t, "PD020713", err)
+
+	// Each identity has its own bucket, even from the same IP
+	err = newTestHTTPClient(t, url, "admin-token").CallRPC(ctx, &clientID, "test_queryThings")
+	require.Nil(t, err)
+	assert.Equal(t, "admin", clientID)
+}
+
+func TestRateLimitRefillAndPrune(t *testing.T) {
+	l, err := newLimiter(context.Background(), &pldconf.RPCLimitsConfig{
+		RateLimits: []pldconf.RPCRateLimitConfig{
+			{Name: "default", Methods: []string{"*"}},
+		},
+	})
+	require.NoError(t, err)
+	rl := l.rateLimits[0]
+	assert.Equal(t, float64(10), rl.rate)
+	assert.Equal(t, float64(20), rl.burst)
+
+	for i := 0; i < 20; i++ {
+		assert.Nil(t, l.checkRateLimits("client1", "any_method"))
+	}
+	assert.Equal(t, rl, l.checkRateLimits("client1", "any_method"))
+
+	// Half a second refills 5 tokens
+	rl.buckets["client1"].lastRefill = time.Now().Add(-500 * time.Millisecond)
+	for i := 0; i < 5; i++ {
+		assert.Nil(t, l.checkRateLimits("client1", "any_method"))
+	}
+	assert.Equal(t, rl, l.checkRateLimits("client1", "any_method"))
+
+	// Buckets that have refilled are pruned
+	assert.Nil(t, l.checkRateLimits("client2", "any_method"))
+	rl.buckets["client1"].lastRefill = time.Now().Add(-1 * time.Hour)
+	rl.lastPrune = time.Now().Add(-1 * time.Hour)
+	assert.Nil(t, l.checkRateLimits("client2", "any_method"))
+	assert.Len(t, rl.buckets, 1)
+	assert.NotNil(t, rl.buckets["client2"])
+
+	// Tokens are never taken below zero
+	rl.buckets["client2"].tokens = 0.5
+	assert.Equal(t, rl, l.checkRateLimits("client2", "any_method"))
+	assert.GreaterOrEqual(t, rl.buckets["client2"].tokens, 0.5)
+}
+
+func TestRateLimitConcurrentLastToken(t *testing.T) {
+	l, err := newLimiter(context.Background(), &pldconf.RPCLimitsConfig{
+		RateLimits: []pldconf.RPCRateLimitConfig{
+			{Name: "limit1", Methods: []string{"*"}, RequestsPerSecond: confutil.P(0.0), Burst: confutil.P(5)},
+			{Name: "limit2", Methods: []string{"test_*"}, RequestsPerSecond: confutil.P(0.0), Burst: confutil.P(10)},
+		},
+	})
+	require.NoError(t, err)
+
+	// Only as many requests as there are tokens are allowed, however many race for them
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if l.checkRateLimits("client1", "test_method") == nil {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(5), allowed.Load())
+	assert.Equal(t, float64(0), l.rateLimits[0].buckets["client1"].tokens)
+	assert.Equal(t, float64(5), l.rateLimits[1].buckets["client1"].tokens)
+}
+
+func TestMaxBatchSize(t *testing.T) {
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		Limits: pldconf.RPCLimitsConfig{
+			MaxBatchSize: confutil.P(2),
+		},
+	})
+	defer done()
+	regTestLimitMethods(s)
+
+	var results []*rpcclient.RPCResponse
+	res, err := resty.New().R().
+		SetBody(`[{"jsonrpc":"2.0","id":1,"method":"test_send"},{"jsonrpc":"2.0","id":2,"method":"test_send"}]`).
+		SetResult(&results).
+		Post(url)
+	require.NoError(t, err)
+	assert.True(t, res.IsSuccess())
+	assert.Len(t, results, 2)
+
+	var errRes rpcclient.RPCResponse
+	res, err = resty.New().R().
+		SetBody(`[{"jsonrpc":"2.0","id":1,"method":"test_send"},{"jsonrpc":"2.0","id":2,"method":"test_send"},{"jsonrpc":"2.0","id":3,"method":"test_send"}]`).
+		SetError(&errRes).
+		Post(url)
+	require.NoError(t, err)
+	assert.False(t, res.IsSuccess())
+	assert.Regexp(t, "PD020714.*3.*2", errRes.Error.Message)
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.metrics.rejectedRequests.WithLabelValues(rejectReasonBatchTooLarge)))
+}
+
+func TestMaxRequestSizeHTTP(t *testing.T) {
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		Limits: pldconf.RPCLimitsConfig{
+			MaxRequestSize: confutil.P("1KB"),
+		},
+	})
+	defer done()
+	regTestLimitMethods(s)
+
+	var clientID string
+	rpcErr := newTestHTTPClient(t, url, "").CallRPC(context.Background(), &clientID, "test_send", strings.Repeat("a", 2048))
+	assert.Regexp(t, "PD020715.*1,024", rpcErr)
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.metrics.rejectedRequests.WithLabelValues(rejectReasonRequestTooLarge)))
+}
+
+func TestMaxRequestSizeWebSocket(t *testing.T) {
+	url, s, done := newTestServerWebSockets(t, &pldconf.RPCServerConfig{
+		Limits: pldconf.RPCLimitsConfig{
+			MaxRequestSize: confutil.P("1KB"),
+		},
+	})
+	defer done()
+	regTestLimitMethods(s)
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	err = conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"method":"test_send"}`))
+	require.NoError(t, err)
+	var res rpcclient.RPCResponse
+	err = conn.ReadJSON(&res)
+	require.NoError(t, err)
+	assert.Equal(t, `"127.0.0.1"`, res.Result.String())
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.metrics.wsConnections))
+
+	// The server closes the connection on an oversized message
+	err = conn.WriteMessage(websocket.TextMessage, []byte(strings.Repeat(" ", 2048)))
+	require.NoError(t, err)
+	_, _, err = conn.ReadMessage()
+	assert.Error(t, err)
+	for testutil.ToFloat64(s.metrics.wsConnections) != 0 {
+		time.Sleep(1 * time.Millisecond)
+	}
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.metrics.rejectedRequests.WithLabelValues(rejectReasonRequestTooLarge)))
+}
+
+func TestMaxSubscriptionsPerClient(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelCtx()
+	url, s, done := newTestServerWebSockets(t, &pldconf.RPCServerConfig{
+		Auth: pldconf.RPCAuthConfig{
+			StaticTokens: []pldconf.RPCAuthStaticTokenConfig{
+				{Identity: "user1", Token: "user1-token", Roles: []string{"subscriber"}},
+				{Identity: "user2", Token: "user2-token", Roles: []string{"subscriber"}},
+			},
+			MethodGroups: map[string][]string{"all": {"*"}},
+			Roles:        map[string][]string{"subscriber": {"all"}},
+		},
+		Limits: pldconf.RPCLimitsConfig{
+			MaxSubscriptionsPerClient: confutil.P(1),
+		},
+	})
+	defer done()
+
+	ethSubs := NewEthSubscribe()
+	s.Register(NewRPCModule("eth").AddAsync(ethSubs.RPCAsyncHandler()))
+
+	connect := func(token string) rpcclient.WSClient {
+		wsConfig := &pldconf.WSClientConfig{}
+		wsConfig.URL = url
+		wsConfig.HTTPHeaders = map[string]interface{}{"Authorization": "Bearer " + token}
+		client := rpcclient.WrapWSConfig(wsConfig)
+		err := client.Connect(ctx)
+		require.NoError(t, err)
+		return client
+	}
+	user1Conn1 := connect("user1-token")
+	defer user1Conn1.Close()
+	user1Conn2 := connect("user1-token")
+	defer user1Conn2.Close()
+	user2Conn := connect("user2-token")
+	defer user2Conn.Close()
+
+	// Failed starts do not count towards the limit
+	rpcErr := user1Conn1.CallRPC(ctx, &pldtypes.RawJSON{}, "eth_subscribe")
+	assert.Regexp(t, "eth_subscribe requires a type parameter", rpcErr)
+
+	sub1, rpcErr := user1Conn1.Subscribe(ctx, rpcclient.EthSubscribeConfig(), "myEvents")
+	require.Nil(t, rpcErr)
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.metrics.wsSubscriptions))
+
+	// The limit applies across all the connections of the client
+	_, rpcErr = user1Conn2.Subscribe(ctx, rpcclient.EthSubscribeConfig(), "otherEvents")
+	assert.Regexp(t, "PD020716.*1", rpcErr)
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.metrics.rejectedRequests.WithLabelValues(rejectReasonMaxSubscriptions)))
+
+	// Other clients have their own limit
+	_, rpcErr = user2Conn.Subscribe(ctx, rpcclient.EthSubscribeConfig(), "myEvents")
+	require.Nil(t, rpcErr)
+	assert.Equal(t, float64(2), testutil.ToFloat64(s.metrics.wsSubscriptions))
+
+	// Unsubscribing frees up the slot
+	rpcErr = sub1.Unsubscribe(ctx)
+	require.Nil(t, rpcErr)
+	_, rpcErr = user1Conn2.Subscribe(ctx, rpcclient.EthSubscribeConfig(), "otherEvents")
+	require.Nil(t, rpcErr)
+
+	// Subscriptions are released when the connection closes
+	user1Conn2.Close()
+	for testutil.ToFloat64(s.metrics.wsSubscriptions) != 1 {
+		time.Sleep(1 * time.Millisecond)
+	}
+	_, rpcErr = user1Conn1.Subscribe(ctx, rpcclient.EthSubscribeConfig(), "myEvents")
+	require.Nil(t, rpcErr)
+
+	user1Conn1.Close()
+	user2Conn.Close()
+	for testutil.ToFloat64(s.metrics.wsSubscriptions) != 0 || testutil.ToFloat64(s.metrics.wsConnections) != 0 {
+		time.Sleep(1 * time.Millisecond)
+	}
+	s.limits.subsMux.Lock()
+	assert.Empty(t, s.limits.subscriptions)
+	s.limits.subsMux.Unlock()
+}
+
+func TestSubscriptionStartedAfterConnectionClosed(t *testing.T) {
+	_, s, done := newTestServerWebSockets(t, &pldconf.RPCServerConfig{
+		Limits: pldconf.RPCLimitsConfig{
+			MaxSubscriptionsPerClient: confutil.P(1),
+		},
+	})
+	defer done()
+
+	ethSubs := NewEthSubscribe()
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+	c := &webSocketConnection{
+		ctx:            ctx,
+		server:         s,
+		client:         "client1",
+		asyncInstances: make(map[uuid.UUID]*asyncWrapper),
+	}
+	res := c.handleNewAsync(ctx, &rpcclient.RPCRequest{
+		Method: "eth_subscribe",
+		Params: []pldtypes.RawJSON{pldtypes.JSONString("myEvents")},
+	}, ethSubs.RPCAsyncHandler())
+	require.Nil(t, res.Error)
+
+	// The subscription is not tracked against a closed connection, and does not count towards the limit
+	assert.Empty(t, c.asyncInstances)
+	assert.Empty(t, c.server.limits.subscriptions)
+}
+
+func TestReleaseSomeSubscriptions(t *testing.T) {
+	l, err := newLimiter(context.Background(), &pldconf.RPCLimitsConfig{
+		MaxSubscriptionsPerClient: confutil.P(3),
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, l.reserveSubscription("client1"))
+	}
+	assert.False(t, l.reserveSubscription("client1"))
+	l.releaseSubscriptions("client1", 2)
+	assert.Equal(t, 1, l.subscriptions["client1"])
+	l.releaseSubscriptions("client1", 1)
+	assert.Empty(t, l.subscriptions)
+}
+
+func TestClientIDFallback(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.RemoteAddr = "not-host-port"
+	assert.Equal(t, "not-host-port", clientID(req, nil))
+	assert.Equal(t, "me", clientID(req, &Principal{Identity: "me"}))
+}
+
+func TestLimitsConfigErrors(t *testing.T) {
+	_, err := NewRPCServer(context.Background(), &pldconf.RPCServerConfig{
+		Limits: pldconf.RPCLimitsConfig{
+			RateLimits: []pldconf.RPCRateLimitConfig{{Name: "nomethods"}},
+		},
+	})
+	assert.Regexp(t, "PD020712", err)
+}
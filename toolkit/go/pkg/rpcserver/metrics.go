@@ -0,0 +1,66 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var METRICS_SUBSYSTEM = "rpc_server"
+
+const (
+	rejectReasonRateLimited      = "rate_limited"
+	rejectReasonBatchTooLarge    = "batch_too_large"
+	rejectReasonRequestTooLarge  = "request_too_large"
+	rejectReasonMaxSubscriptions = "max_subscriptions"
+	rejectReasonUnauthenticated  = "unauthenticated"
+	rejectReasonUnauthorized     = "unauthorized"
+)
+
+// The metrics are always collected, and are only exposed once registered
+type rpcServerMetrics struct {
+	rejectedRequests    *prometheus.CounterVec
+	rateLimitedRequests *prometheus.CounterVec
+	wsConnections       prometheus.Gauge
+	wsSubscriptions     prometheus.Gauge
+}
+
+func newRPCServerMetrics() *rpcServerMetrics {
+	return &rpcServerMetrics{
+		rejectedRequests: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "rejected_requests_total",
+			Help: "JSON/RPC requests rejected by the RPC server", Subsystem: METRICS_SUBSYSTEM}, []string{"reason"}),
+		rateLimitedRequests: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "rate_limited_requests_total",
+			Help: "JSON/RPC requests rejected by each rate limit", Subsystem: METRICS_SUBSYSTEM}, []string{"limit"}),
+		wsConnections: prometheus.NewGauge(prometheus.GaugeOpts{Name: "ws_connections",
+			Help: "Open WebSocket connections", Subsystem: METRICS_SUBSYSTEM}),
+		wsSubscriptions: prometheus.NewGauge(prometheus.GaugeOpts{Name: "ws_subscriptions",
+			Help: "Active subscriptions across all WebSocket connections", Subsystem: METRICS_SUBSYSTEM}),
+	}
+}
+
+func (m *rpcServerMetrics) register(registry *prometheus.Registry) {
+	registry.MustRegister(m.rejectedRequests, m.rateLimitedRequests, m.wsConnections, m.wsSubscriptions)
+}
+
+func (m *rpcServerMetrics) incRejected(reason string) {
+	m.rejectedRequests.With(prometheus.Labels{"reason": reason}).Inc()
+}
+
+func (m *rpcServerMetrics) incRateLimited(limit string) {
+	m.incRejected(rejectReasonRateLimited)
+	m.rateLimitedRequests.With(prometheus.Labels{"limit": limit}).Inc()
+}
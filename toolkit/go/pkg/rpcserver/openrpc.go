@@ -0,0 +1,279 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+const OpenRPCVersion = "1.3.2"
+
+const rpcDiscoverMethod = "rpc_discover"
+
+// OpenRPCDocument is the subset of the OpenRPC specification (https://spec.open-rpc.org)
+// that is generated by rpc_discover from the registered modules
+type OpenRPCDocument struct {
+	OpenRPC    string            `json:"openrpc"`
+	Info       OpenRPCInfo       `json:"info"`
+	Methods    []*OpenRPCMethod  `json:"methods"`
+	Components OpenRPCComponents `json:"components"`
+}
+
+type OpenRPCInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenRPCMethod struct {
+	Name           string                      `json:"name"`
+	Description    string                      `json:"description,omitempty"`
+	ParamStructure string                      `json:"paramStructure"`
+	Params         []*OpenRPCContentDescriptor `json:"params"`
+	Result         *OpenRPCContentDescriptor   `json:"result"`
+}
+
+type OpenRPCContentDescriptor struct {
+	Name   string      `json:"name"`
+	Schema *JSONSchema `json:"schema"`
+}
+
+type OpenRPCComponents struct {
+	Schemas map[string]*JSONSchema `json:"schemas"`
+}
+
+type JSONSchema struct {
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	ContentEncoding      string                 `json:"contentEncoding,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+}
+
+// RPCMethodNamer returns the names of the parameters and result of a method, which cannot be
+// introspected via reflection. Methods it does not know (ok=false) use positional names.
+type RPCMethodNamer func(method string) (params []string, result string, ok bool)
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	rawJSONType       = reflect.TypeFor[pldtypes.RawJSON]()
+	jsonRawType       = reflect.TypeFor[json.RawMessage]()
+)
+
+type enumType interface {
+	Options() []string
+}
+
+type openRPCGenerator struct {
+	ctx         context.Context
+	schemas     map[string]*JSONSchema
+	schemaNames map[reflect.Type]string
+}
+
+func (s *rpcServer) SetOpenRPCInfo(info OpenRPCInfo, methodNamer RPCMethodNamer) {
+	s.openRPCInfo = info
+	s.methodNamer = methodNamer
+}
+
+func (s *rpcServer) rpcDiscover(ctx context.Context) (*OpenRPCDocument, error) {
+	g := &openRPCGenerator{
+		ctx:         ctx,
+		schemas:     make(map[string]*JSONSchema),
+		schemaNames: make(map[reflect.Type]string),
+	}
+	doc := &OpenRPCDocument{
+		OpenRPC:    OpenRPCVersion,
+		Info:       s.openRPCInfo,
+		Methods:    []*OpenRPCMethod{},
+		Components: OpenRPCComponents{Schemas: g.schemas},
+	}
+
+	var methodNames []string
+	for _, module := range s.rpcModules {
+		methodNames = append(methodNames, module.MethodNames()...)
+	}
+	sort.Strings(methodNames)
+	for _, methodName := range methodNames {
+		if methodName == rpcDiscoverMethod {
+			// Listing the discovery method is optional in the specification
+			continue
+		}
+		var paramNames []string
+		resultName := "result"
+		if s.methodNamer != nil {
+			if names, result, ok := s.methodNamer(methodName); ok {
+				paramNames, resultName = names, result
+			}
+		}
+		entry := s.rpcModules[strings.SplitN(methodName, "_", 2)[0]].methods[methodName]
+		doc.Methods = append(doc.Methods, g.method(methodName, entry, paramNames, resultName))
+	}
+	return doc, nil
+}
+
+func (g *openRPCGenerator) method(name string, entry *rpcMethodEntry, paramNames []string, resultName string) *OpenRPCMethod {
+	m := &OpenRPCMethod{
+		Name:           name,
+		ParamStructure: "by-position",
+		Params:         []*OpenRPCContentDescriptor{},
+	}
+	typed, isTyped := entry.handler.(*rpcTypedHandler)
+	if !isTyped {
+		if entry.methodType != rpcMethodTypeMethod {
+			m.Description = "Only available on WebSocket connections"
+		}
+		// Without type information, we can only describe the parameters we are told about
+		for _, paramName := range paramNames {
+			m.Params = append(m.Params, &OpenRPCContentDescriptor{Name: paramName, Schema: &JSONSchema{}})
+		}
+		m.Result = &OpenRPCContentDescriptor{Name: resultName, Schema: &JSONSchema{}}
+		return m
+	}
+	for i, paramType := range typed.paramTypes {
+		paramName := fmt.Sprintf("param%d", i)
+		if len(paramNames) == len(typed.paramTypes) {
+			paramName = paramNames[i]
+		}
+		m.Params = append(m.Params, &OpenRPCContentDescriptor{Name: paramName, Schema: g.schema(paramType)})
+	}
+	m.Result = &OpenRPCContentDescriptor{Name: resultName, Schema: g.schema(typed.resultType)}
+	return m
+}
+
+func isMarshaler(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return t.Implements(jsonMarshalerType) || pt.Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || pt.Implements(textMarshalerType)
+}
+
+// Named types from other packages are added to the components, and referenced
+func (g *openRPCGenerator) schema(t reflect.Type) *JSONSchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == rawJSONType || t == jsonRawType {
+		return &JSONSchema{}
+	}
+	if t.PkgPath() == "" || t.Name() == "" {
+		return g.inlineSchema(t)
+	}
+	name, exists := g.schemaNames[t]
+	if !exists {
+		name = g.schemaName(t)
+		g.schemaNames[t] = name
+		// Register before building, to handle recursive types
+		g.schemas[name] = &JSONSchema{}
+		*g.schemas[name] = *g.inlineSchema(t)
+	}
+	return &JSONSchema{Ref: "#/components/schemas/" + name}
+}
+
+func (g *openRPCGenerator) schemaName(t reflect.Type) string {
+	// Generic types like "Enum[github.com/.../pldapi.TransactionType]" use the short names of the type parameters
+	name := t.Name()
+	if base, typeParams, isGeneric := strings.Cut(name, "["); isGeneric {
+		name = base
+		for _, tp := range strings.Split(strings.TrimSuffix(typeParams, "]"), ",") {
+			name += tp[strings.LastIndex(tp, ".")+1:]
+		}
+	}
+	if _, clash := g.schemas[name]; clash {
+		pkgPath := t.PkgPath()
+		name = pkgPath[strings.LastIndex(pkgPath, "/")+1:] + "." + name
+	}
+	return name
+}
+
+func (g *openRPCGenerator) inlineSchema(t reflect.Type) *JSONSchema {
+	if e, isEnum := reflect.New(t).Elem().Interface().(enumType); isEnum {
+		return &JSONSchema{Type: "string", Enum: e.Options()}
+	}
+	if isMarshaler(t) {
+		// All the custom serialized types we use (such as hex bytes and numbers) are strings
+		return &JSONSchema{Type: "string"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &JSONSchema{Type: "string", ContentEncoding: "base64"}
+		}
+		return &JSONSchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		s := &JSONSchema{Type: "object", Properties: make(map[string]*JSONSchema)}
+		g.addStructFields(t, s)
+		return s
+	default:
+		// interfaces, and anything else we cannot describe
+		return &JSONSchema{}
+	}
+}
+
+func (g *openRPCGenerator) addStructFields(t reflect.Type, s *JSONSchema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && jsonName == "" {
+			// Embedded structs are flattened, as in the JSON serialization
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && !isMarshaler(embedded) {
+				g.addStructFields(embedded, s)
+				continue
+			}
+		}
+		if !field.IsExported() || jsonName == "-" {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		fieldSchema := g.schema(field.Type)
+		// Field descriptions are shared with the reference documentation, via the docstruct tag
+		if docStruct := field.Tag.Get("docstruct"); docStruct != "" {
+			key := docStruct + "." + jsonName
+			if description := i18n.Expand(g.ctx, i18n.MessageKey(key)); description != key {
+				fieldSchema.Description = description
+			}
+		}
+		s.Properties[jsonName] = fieldSchema
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Clashes with pldtypes.Timestamp
+type Timestamp struct {
+	Seconds int64 `json:"seconds"`
+}
+
+type TestTags []string
+
+type testEmbedded struct {
+	Embedded string `json:"embedded"`
+}
+
+type testTreeNode struct {
+	*testEmbedded
+	TestTags
+	Domain      string                           `docstruct:"Transaction" json:"domain"`
+	Type        pldtypes.Enum[pldapi.SubmitMode] `json:"type"`
+	Children    []*testTreeNode                  `json:"children,omitempty"`
+	Labels      map[string]bool                  `json:"labels"`
+	Data        []byte                           `json:"data"`
+	Raw         pldtypes.RawJSON                 `json:"raw"`
+	Any         any                              `json:"any"`
+	Score       float64                          `json:"score"`
+	Updated     *pldtypes.Timestamp              `docstruct:"NoSuchStruct" json:"updated"`
+	Created     Timestamp                        `json:"created"`
+	Hash        pldtypes.Bytes32                 `json:"hash"`
+	NoTag       bool
+	Skipped     string    `json:"-"`
+	unexported  string    //nolint:unused
+	Fingerprint [4]uint64 `json:"fingerprint"`
+}
+
+func TestRPCDiscover(t *testing.T) {
+	ctx := context.Background()
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{})
+	defer done()
+
+	regTestRPC(s, "tree_get", RPCMethod2(func(ctx context.Context, id uuid.UUID, depth int) (*testTreeNode, error) {
+		return nil, nil
+	}))
+	regTestRPC(s, "tree_put", RPCMethod1(func(ctx context.Context, node testTreeNode) (bool, error) {
+		return true, nil
+	}))
+	regTestRPC(s, "tree_raw", HandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
+		return nil
+	}))
+	s.Register(NewRPCModule("eth").AddAsync(NewEthSubscribe().RPCAsyncHandler()))
+	s.SetOpenRPCInfo(OpenRPCInfo{Title: "Test API", Version: "1.2.3"}, func(method string) ([]string, string, bool) {
+		switch method {
+		case "tree_get":
+			return []string{"id", "depth"}, "node", true
+		case "eth_subscribe":
+			return []string{"eventType"}, "subscriptionId", true
+		case "tree_put":
+			return []string{"wrong", "count"}, "stored", true
+		}
+		return nil, "", false
+	})
+
+	var doc OpenRPCDocument
+	c, err := rpcclient.NewHTTPClient(ctx, &pldconf.HTTPClientConfig{URL: url})
+	require.NoError(t, err)
+	rpcErr := c.CallRPC(ctx, &doc, "rpc_discover")
+	require.Nil(t, rpcErr)
+
+	assert.Equal(t, OpenRPCVersion, doc.OpenRPC)
+	assert.Equal(t, OpenRPCInfo{Title: "Test API", Version: "1.2.3"}, doc.Info)
+	require.Len(t, doc.Methods, 5)
+
+	ethSubscribe := doc.Methods[0]
+	assert.Equal(t, "eth_subscribe", ethSubscribe.Name)
+	assert.Equal(t, "Only available on WebSocket connections", ethSubscribe.Description)
+	assert.Equal(t, []*OpenRPCContentDescriptor{{Name: "eventType", Schema: &JSONSchema{}}}, ethSubscribe.Params)
+	assert.Equal(t, "subscriptionId", ethSubscribe.Result.Name)
+	assert.Equal(t, "eth_unsubscribe", doc.Methods[1].Name)
+
+	treeGet := doc.Methods[2]
+	assert.Equal(t, "tree_get", treeGet.Name)
+	assert.Equal(t, "by-position", treeGet.ParamStructure)
+	assert.Equal(t, []*OpenRPCContentDescriptor{
+		{Name: "id", Schema: &JSONSchema{Ref: "#/components/schemas/UUID"}},
+		{Name: "depth", Schema: &JSONSchema{Type: "integer"}},
+	}, treeGet.Params)
+	assert.Equal(t, &OpenRPCContentDescriptor{Name: "node", Schema: &JSONSchema{Ref: "#/components/schemas/testTreeNode"}}, treeGet.Result)
+
+	// Names that do not match the parameter count are ignored
+	treePut := doc.Methods[3]
+	assert.Equal(t, "param0", treePut.Params[0].Name)
+	assert.Equal(t, "stored", treePut.Result.Name)
+
+	treeRaw := doc.Methods[4]
+	assert.Equal(t, "tree_raw", treeRaw.Name)
+	assert.Empty(t, treeRaw.Params)
+	assert.Equal(t, &OpenRPCContentDescriptor{Name: "result", Schema: &JSONSchema{}}, treeRaw.Result)
+
+	schemas := doc.Components.Schemas
+	assert.Equal(t, &JSONSchema{Type: "string"}, schemas["UUID"])
+	assert.Equal(t, &JSONSchema{Type: "string"}, schemas["Timestamp"])
+	assert.Equal(t, &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{
+		"seconds": {Type: "integer"},
+	}}, schemas["rpcserver.Timestamp"])
+	assert.Equal(t, &JSONSchema{Type: "array", Items: &JSONSchema{Type: "string"}}, schemas["TestTags"])
+	assert.Equal(t, &JSONSchema{Type: "string", Enum: []string{"auto", "external", "call"}}, schemas["EnumSubmitMode"])
+	assert.Equal(t, &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{
+		"embedded":    {Type: "string"},
+		"TestTags":    {Ref: "#/components/schemas/TestTags"},
+		"domain":      {Type: "string", Description: "Name of a domain - only required on input for private deploy transactions"},
+		"type":        {Ref: "#/components/schemas/EnumSubmitMode"},
+		"children":    {Type: "array", Items: &JSONSchema{Ref: "#/components/schemas/testTreeNode"}},
+		"labels":      {Type: "object", AdditionalProperties: &JSONSchema{Type: "boolean"}},
+		"data":        {Type: "string", ContentEncoding: "base64"},
+		"raw":         {},
+		"any":         {},
+		"score":       {Type: "number"},
+		"updated":     {Ref: "#/components/schemas/Timestamp"},
+		"created":     {Ref: "#/components/schemas/rpcserver.Timestamp"},
+		"hash":        {Ref: "#/components/schemas/Bytes32"},
+		"NoTag":       {Type: "boolean"},
+		"fingerprint": {Type: "array", Items: &JSONSchema{Type: "integer"}},
+	}}, schemas["testTreeNode"])
+}
@@ -19,7 +19,9 @@ package rpcserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"net/http"
 	"time"
 	"unicode"
 
@@ -40,6 +42,12 @@ func (s *rpcServer) rpcHandler(ctx context.Context, r io.Reader, wsc *webSocketC
 
 	b, err := io.ReadAll(r)
 	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			log.L(ctx).Errorf("Request exceeded maximum size %d", maxBytesErr.Limit)
+			s.metrics.incRejected(rejectReasonRequestTooLarge)
+			return s.replyRPCError(i18n.NewError(ctx, pldmsgs.MsgJSONRPCRequestTooLarge, maxBytesErr.Limit))
+		}
 		return s.replyRPCParseError(ctx, b, err)
 	}
 
@@ -54,6 +62,11 @@ func (s *rpcServer) rpcHandler(ctx context.Context, r io.Reader, wsc *webSocketC
 			log.L(ctx).Errorf("Bad RPC array received %s", b)
 			return s.replyRPCParseError(ctx, b, err)
 		}
+		if s.limits.maxBatchSize > 0 && len(rpcArray) > s.limits.maxBatchSize {
+			log.L(ctx).Errorf("Batch of %d requests exceeds maximum batch size %d", len(rpcArray), s.limits.maxBatchSize)
+			s.metrics.incRejected(rejectReasonBatchTooLarge)
+			return s.replyRPCError(i18n.NewError(ctx, pldmsgs.MsgJSONRPCBatchTooLarge, len(rpcArray), s.limits.maxBatchSize))
+		}
 		batchRes, isOK := s.handleRPCBatch(ctx, rpcArray, wsc)
 		return handlerResult{isOK: isOK, sendRes: true, res: batchRes}
 	}
@@ -81,11 +94,15 @@ func (s *rpcServer) rpcHandler(ctx context.Context, r io.Reader, wsc *webSocketC
 
 func (s *rpcServer) replyRPCParseError(ctx context.Context, b []byte, err error) handlerResult {
 	log.L(ctx).Errorf("Request could not be parsed (err=%v): %s", err, b)
+	return s.replyRPCError(i18n.NewError(ctx, pldmsgs.MsgJSONRPCInvalidRequest))
+}
+
+func (s *rpcServer) replyRPCError(err error) handlerResult {
 	return handlerResult{
 		isOK:    false,
 		sendRes: true,
 		res: rpcclient.NewRPCErrorResponse(
-			i18n.NewError(ctx, pldmsgs.MsgJSONRPCInvalidRequest),
+			err,
 			pldtypes.RawJSON(`"1"`),
 			rpcclient.RPCCodeInvalidRequest,
 		),
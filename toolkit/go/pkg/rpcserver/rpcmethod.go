@@ -19,6 +19,7 @@ package rpcserver
 import (
 	"context"
 	"encoding/json"
+	"reflect"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
@@ -61,19 +62,34 @@ func (hf *rpcHandlerFunc) Handle(ctx context.Context, req *rpcclient.RPCRequest)
 	return hf.fn(ctx, req)
 }
 
+// The RPCMethod0 ... RPCMethod5 handlers retain the parameter and result types, for the OpenRPC document
+type rpcTypedHandler struct {
+	rpcHandlerFunc
+	resultType reflect.Type
+	paramTypes []reflect.Type
+}
+
+func typedHandlerFunc(fn func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse, resultType reflect.Type, paramTypes ...reflect.Type) RPCHandler {
+	return &rpcTypedHandler{
+		rpcHandlerFunc: rpcHandlerFunc{fn: fn},
+		resultType:     resultType,
+		paramTypes:     paramTypes,
+	}
+}
+
 func RPCMethod0[R any](impl func(ctx context.Context) (R, error)) RPCHandler {
-	return HandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
+	return typedHandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
 		var result R
 		code, err := parseParams(ctx, req)
 		if err == nil {
 			result, err = impl(ctx)
 		}
 		return mapResponse(ctx, req, result, code, err)
-	})
+	}, reflect.TypeFor[R]())
 }
 
 func RPCMethod1[R any, P0 any](impl func(ctx context.Context, param0 P0) (R, error)) RPCHandler {
-	return HandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
+	return typedHandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
 		var result R
 		param0 := new(P0)
 		code, err := parseParams(ctx, req, param0)
@@ -81,11 +97,11 @@ func RPCMethod1[R any, P0 any](impl func(ctx context.Context, param0 P0) (R, err
 			result, err = impl(ctx, *param0)
 		}
 		return mapResponse(ctx, req, result, code, err)
-	})
+	}, reflect.TypeFor[R](), reflect.TypeFor[P0]())
 }
 
 func RPCMethod2[R any, P0 any, P1 any](impl func(ctx context.Context, param0 P0, param1 P1) (R, error)) RPCHandler {
-	return HandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
+	return typedHandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
 		var result R
 		param0 := new(P0)
 		param1 := new(P1)
@@ -94,11 +110,11 @@ func RPCMethod2[R any, P0 any, P1 any](impl func(ctx context.Context, param0 P0,
 			result, err = impl(ctx, *param0, *param1)
 		}
 		return mapResponse(ctx, req, result, code, err)
-	})
+	}, reflect.TypeFor[R](), reflect.TypeFor[P0](), reflect.TypeFor[P1]())
 }
 
 func RPCMethod3[R any, P0 any, P1 any, P2 any](impl func(ctx context.Context, param0 P0, param1 P1, param2 P2) (R, error)) RPCHandler {
-	return HandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
+	return typedHandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
 		var result R
 		param0 := new(P0)
 		param1 := new(P1)
@@ -108,11 +124,11 @@ func RPCMethod3[R any, P0 any, P1 any, P2 any](impl func(ctx context.Context, pa
 			result, err = impl(ctx, *param0, *param1, *param2)
 		}
 		return mapResponse(ctx, req, result, code, err)
-	})
+	}, reflect.TypeFor[R](), reflect.TypeFor[P0](), reflect.TypeFor[P1](), reflect.TypeFor[P2]())
 }
 
 func RPCMethod4[R any, P0 any, P1 any, P2 any, P3 any](impl func(ctx context.Context, param0 P0, param1 P1, param2 P2, param3 P3) (R, error)) RPCHandler {
-	return HandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
+	return typedHandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
 		var result R
 		param0 := new(P0)
 		param1 := new(P1)
@@ -123,11 +139,11 @@ func RPCMethod4[R any, P0 any, P1 any, P2 any, P3 any](impl func(ctx context.Con
 			result, err = impl(ctx, *param0, *param1, *param2, *param3)
 		}
 		return mapResponse(ctx, req, result, code, err)
-	})
+	}, reflect.TypeFor[R](), reflect.TypeFor[P0](), reflect.TypeFor[P1](), reflect.TypeFor[P2](), reflect.TypeFor[P3]())
 }
 
 func RPCMethod5[R any, P0 any, P1 any, P2 any, P3 any, P4 any](impl func(ctx context.Context, param0 P0, param1 P1, param2 P2, param3 P3, param4 P4) (R, error)) RPCHandler {
-	return HandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
+	return typedHandlerFunc(func(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
 		var result R
 		param0 := new(P0)
 		param1 := new(P1)
@@ -139,7 +155,7 @@ func RPCMethod5[R any, P0 any, P1 any, P2 any, P3 any, P4 any](impl func(ctx con
 			result, err = impl(ctx, *param0, *param1, *param2, *param3, *param4)
 		}
 		return mapResponse(ctx, req, result, code, err)
-	})
+	}, reflect.TypeFor[R](), reflect.TypeFor[P0](), reflect.TypeFor[P1](), reflect.TypeFor[P2](), reflect.TypeFor[P3](), reflect.TypeFor[P4]())
 }
 
 func parseParams(ctx context.Context, req *rpcclient.RPCRequest, params ...interface{}) (rpcclient.RPCCode, error) {
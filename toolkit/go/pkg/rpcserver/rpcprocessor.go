@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 )
@@ -44,6 +45,16 @@ func (s *rpcServer) processRPC(ctx context.Context, rpcReq *rpcclient.RPCRequest
 		err := i18n.NewError(ctx, pldmsgs.MsgJSONRPCUnsupportedMethod, rpcReq.Method)
 		return rpcclient.NewRPCErrorResponse(err, rpcReq.ID, rpcclient.RPCCodeInvalidRequest), false
 	}
+	if err := s.auth.authorize(ctx, rpcReq.Method); err != nil {
+		s.metrics.incRejected(rejectReasonUnauthorized)
+		return rpcclient.NewRPCErrorResponse(err, rpcReq.ID, rpcclient.RPCCodeInvalidRequest), false
+	}
+	if rl := s.limits.checkRateLimits(clientIDFromContext(ctx), rpcReq.Method); rl != nil {
+		log.L(ctx).Warnf("Rate limit '%s' exceeded by '%s' calling %s", rl.name, clientIDFromContext(ctx), rpcReq.Method)
+		s.metrics.incRateLimited(rl.name)
+		err := i18n.NewError(ctx, pldmsgs.MsgJSONRPCRateLimited, rl.name, rpcReq.Method)
+		return rpcclient.NewRPCErrorResponse(err, rpcReq.ID, rpcclient.RPCCodeInvalidRequest), false
+	}
 
 	var rpcRes *rpcclient.RPCResponse
 	if mh.methodType == rpcMethodTypeMethod {
@@ -24,12 +24,17 @@ import (
 	"sync"
 
 	"github.com/gorilla/websocket"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 	"github.com/kaleido-io/paladin/toolkit/pkg/httpserver"
 	"github.com/kaleido-io/paladin/toolkit/pkg/router"
 	"github.com/kaleido-io/paladin/toolkit/pkg/staticserver"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type RPCServer interface {
@@ -39,6 +44,9 @@ type RPCServer interface {
 	WSAddr() net.Addr
 
 	Register(module *RPCModule)
+	RegisterHTTPHandler(pathPrefix string, handler http.HandlerFunc) // Adds a plain HTTP handler alongside JSON/RPC on the HTTP server, with the same auth and limits
+	RegisterMetrics(registry *prometheus.Registry)                   // Exposes the RPC server metrics (such as requests rejected by limits) on the registry
+	SetOpenRPCInfo(info OpenRPCInfo, methodNamer RPCMethodNamer)     // Sets the info, and optionally the parameter names, for the OpenRPC document returned by rpc_discover
 
 	WSHandler(w http.ResponseWriter, r *http.Request)   // Provides access to the WebSocket handler directly to be able to install it into another server
 	HTTPHandler(w http.ResponseWriter, r *http.Request) // Provides access to the http handler directly to be able to install it into another server
@@ -49,6 +57,16 @@ func NewRPCServer(ctx context.Context, conf *pldconf.RPCServerConfig) (_ *rpcSer
 		bgCtx:         ctx,
 		wsConnections: make(map[string]*webSocketConnection),
 		rpcModules:    make(map[string]*RPCModule),
+		metrics:       newRPCServerMetrics(),
+		openRPCInfo:   OpenRPCInfo{Title: "JSON/RPC API", Version: "1.0.0"},
+	}
+	s.Register(NewRPCModule("rpc").Add(rpcDiscoverMethod, RPCMethod0(s.rpcDiscover)))
+
+	if s.auth, err = newAuthenticator(ctx, &conf.Auth); err != nil {
+		return nil, err
+	}
+	if s.limits, err = newLimiter(ctx, &conf.Limits); err != nil {
+		return nil, err
 	}
 
 	// Add the HTTP server
@@ -71,6 +89,7 @@ func NewRPCServer(ctx context.Context, conf *pldconf.RPCServerConfig) (_ *rpcSer
 		r.HandleFunc("/", s.httpHandler)
 
 		s.httpServer = r
+		s.httpRouter = r
 	}
 
 	// Add the WebSocket server
@@ -94,11 +113,17 @@ var _ RPCServer = &rpcServer{}
 type rpcServer struct {
 	bgCtx         context.Context
 	httpServer    httpserver.Server
+	httpRouter    router.Router
 	wsServer      httpserver.Server
 	wsMux         sync.Mutex
 	wsUpgrader    *websocket.Upgrader
 	wsConnections map[string]*webSocketConnection
 	rpcModules    map[string]*RPCModule
+	auth          *authenticator
+	limits        *limiter
+	metrics       *rpcServerMetrics
+	openRPCInfo   OpenRPCInfo
+	methodNamer   RPCMethodNamer
 }
 
 func (s *rpcServer) Register(module *RPCModule) {
@@ -106,6 +131,50 @@ func (s *rpcServer) Register(module *RPCModule) {
 	s.rpcModules[module.group] = module
 }
 
+func (s *rpcServer) RegisterHTTPHandler(pathPrefix string, handler http.HandlerFunc) {
+	if s.httpRouter == nil {
+		log.L(s.bgCtx).Warnf("HTTP handler for %s not registered as HTTP server is disabled", pathPrefix)
+		return
+	}
+	log.L(s.bgCtx).Debugf("HTTP handler registered at %s", pathPrefix)
+	s.httpRouter.PathPrefixHandleFunc(pathPrefix, s.plainHTTPHandler(pathPrefix, handler))
+}
+
+// Plain HTTP handlers are subject to the same authentication, authorization and limits as JSON/RPC.
+// The path prefix is used in place of the method name when matching method groups and rate limits.
+func (s *rpcServer) plainHTTPHandler(pathPrefix string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		principal, err := s.auth.authenticate(req)
+		if err != nil {
+			s.replyUnauthenticated(res, req, err)
+			return
+		}
+
+		client := clientID(req, principal)
+		ctx := withClientID(withPrincipal(req.Context(), principal), client)
+		if err := s.auth.authorize(ctx, pathPrefix); err != nil {
+			s.metrics.incRejected(rejectReasonUnauthorized)
+			s.replyHTTPError(res, http.StatusForbidden, err)
+			return
+		}
+		if rl := s.limits.checkRateLimits(client, pathPrefix); rl != nil {
+			log.L(ctx).Warnf("Rate limit '%s' exceeded by '%s' calling %s", rl.name, client, pathPrefix)
+			s.metrics.incRateLimited(rl.name)
+			s.replyHTTPError(res, http.StatusTooManyRequests, i18n.NewError(ctx, pldmsgs.MsgJSONRPCRateLimited, rl.name, pathPrefix))
+			return
+		}
+
+		if s.limits.maxRequestSize > 0 {
+			req.Body = http.MaxBytesReader(res, req.Body, s.limits.maxRequestSize)
+		}
+		handler(res, req.WithContext(ctx))
+	}
+}
+
+func (s *rpcServer) RegisterMetrics(registry *prometheus.Registry) {
+	s.metrics.register(registry)
+}
+
 func (s *rpcServer) HTTPAddr() (a net.Addr) {
 	if s.httpServer != nil {
 		a = s.httpServer.Addr()
@@ -133,7 +202,18 @@ func (s *rpcServer) httpHandler(res http.ResponseWriter, req *http.Request) {
 		res.WriteHeader(http.StatusMethodNotAllowed)
 	}
 
-	r := s.rpcHandler(req.Context(), req.Body, nil /* not websockets */)
+	principal, err := s.auth.authenticate(req)
+	if err != nil {
+		s.replyUnauthenticated(res, req, err)
+		return
+	}
+
+	body := req.Body
+	if s.limits.maxRequestSize > 0 {
+		body = http.MaxBytesReader(res, req.Body, s.limits.maxRequestSize)
+	}
+	ctx := withClientID(withPrincipal(req.Context(), principal), clientID(req, principal))
+	r := s.rpcHandler(ctx, body, nil /* not websockets */)
 
 	res.Header().Set("Content-Type", "application/json; charset=utf-8")
 	status := http.StatusOK
@@ -145,12 +225,30 @@ func (s *rpcServer) httpHandler(res http.ResponseWriter, req *http.Request) {
 }
 
 func (s *rpcServer) wsHandler(res http.ResponseWriter, req *http.Request) {
+	// Authentication happens once on upgrade, and applies to every request on the connection
+	principal, err := s.auth.authenticate(req)
+	if err != nil {
+		s.replyUnauthenticated(res, req, err)
+		return
+	}
 	conn, err := s.wsUpgrader.Upgrade(res, req, nil)
 	if err != nil {
 		log.L(req.Context()).Errorf("WebSocket upgrade failed: %s", err)
 		return
 	}
-	s.newWSConnection(conn)
+	s.newWSConnection(conn, principal, clientID(req, principal))
+}
+
+func (s *rpcServer) replyUnauthenticated(res http.ResponseWriter, req *http.Request, err error) {
+	log.L(req.Context()).Warnf("Unauthenticated request from %s: %s", req.RemoteAddr, err)
+	s.metrics.incRejected(rejectReasonUnauthenticated)
+	s.replyHTTPError(res, http.StatusUnauthorized, err)
+}
+
+func (s *rpcServer) replyHTTPError(res http.ResponseWriter, status int, err error) {
+	res.Header().Set("Content-Type", "application/json; charset=utf-8")
+	res.WriteHeader(status)
+	_ = json.NewEncoder(res).Encode(rpcclient.NewRPCErrorResponse(err, pldtypes.RawJSON(`"1"`), rpcclient.RPCCodeInvalidRequest))
 }
 
 func (s *rpcServer) Start() (err error) {
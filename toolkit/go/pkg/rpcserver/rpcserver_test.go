@@ -266,3 +266,83 @@ func TestNewRPCServerWithStaticServerDisabled(t *testing.T) {
 	// Verify the status code
 	require.Equal(t, http.StatusNotFound, res.StatusCode)
 }
+
+func TestRegisterHTTPHandler(t *testing.T) {
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{})
+	defer done()
+
+	s.RegisterHTTPHandler("/custom", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(r.URL.Path))
+	})
+
+	res, err := http.Get(url + "/custom/path")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	body, _ := io.ReadAll(res.Body)
+	assert.Equal(t, "/custom/path", string(body))
+}
+
+func TestRegisterHTTPHandlerAuthAndLimits(t *testing.T) {
+	authConf := testAuthConfig()
+	authConf.MethodGroups["export"] = []string{"/custom"}
+	authConf.Roles["exporter"] = []string{"export"}
+	authConf.StaticTokens = append(authConf.StaticTokens, pldconf.RPCAuthStaticTokenConfig{
+		Identity: "exporter", Token: "exporter-token", Roles: []string{"exporter"},
+	})
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		Auth: authConf,
+		Limits: pldconf.RPCLimitsConfig{
+			MaxRequestSize: confutil.P("10B"),
+			RateLimits: []pldconf.RPCRateLimitConfig{
+				{Name: "custom", Methods: []string{"/custom"}, RequestsPerSecond: confutil.P(0.001), Burst: confutil.P(2)},
+			},
+		},
+	})
+	defer done()
+
+	s.RegisterHTTPHandler("/custom", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		_, _ = w.Write([]byte(AuthenticatedPrincipal(r.Context()).Identity))
+	})
+
+	call := func(token, body string) (int, string) {
+		req, err := http.NewRequest(http.MethodPost, url+"/custom/path", strings.NewReader(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		b, _ := io.ReadAll(res.Body)
+		return res.StatusCode, string(b)
+	}
+
+	status, _ := call("", "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, body := call("reader-token", "")
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Regexp(t, "PD020708", body)
+
+	status, body = call("exporter-token", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "exporter", body)
+	status, _ = call("exporter-token", "more than ten bytes")
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+	status, body = call("exporter-token", "")
+	assert.Equal(t, http.StatusTooManyRequests, status)
+	assert.Regexp(t, "custom", body)
+}
+
+func TestRegisterHTTPHandlerHTTPDisabled(t *testing.T) {
+	_, s, done := newTestServerWebSockets(t, &pldconf.RPCServerConfig{})
+	defer done()
+
+	s.RegisterHTTPHandler("/custom", func(w http.ResponseWriter, r *http.Request) {})
+	assert.Nil(t, s.httpRouter)
+}
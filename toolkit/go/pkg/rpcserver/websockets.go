@@ -20,31 +20,48 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 )
 
-func (s *rpcServer) newWSConnection(conn *websocket.Conn) {
+func (s *rpcServer) newWSConnection(conn *websocket.Conn, principal *Principal, client string) {
 	s.wsMux.Lock()
 	defer s.wsMux.Unlock()
 
 	c := &webSocketConnection{
 		id:             pldtypes.ShortID(),
 		server:         s,
+		client:         client,
 		conn:           conn,
 		asyncInstances: make(map[uuid.UUID]*asyncWrapper),
 		send:           make(chan []byte),
 		closing:        make(chan struct{}),
 	}
-	c.ctx, c.cancelCtx = context.WithCancel(log.WithLogField(s.bgCtx, "wsconn", c.id))
+	c.ctx, c.cancelCtx = context.WithCancel(withClientID(withPrincipal(log.WithLogField(s.bgCtx, "wsconn", c.id), principal), client))
+	if s.limits.maxRequestSize > 0 {
+		conn.SetReadLimit(s.limits.maxRequestSize)
+	}
+
+	// Authentication only happens on upgrade, so the connection must not outlive the credential
+	if principal != nil && !principal.Expires.IsZero() {
+		c.expiryTimer = time.AfterFunc(time.Until(principal.Expires), func() {
+			log.L(c.ctx).Infof("Closing WS connection as credentials for '%s' expired", principal.Identity)
+			c.close()
+		})
+	}
 
 	s.wsConnections[c.id] = c
+	s.metrics.wsConnections.Inc()
 	go c.listen()
 	go c.sender()
 }
@@ -53,7 +70,10 @@ func (s *rpcServer) wsClosed(id string) {
 	s.wsMux.Lock()
 	defer s.wsMux.Unlock()
 
-	delete(s.wsConnections, id)
+	if _, ok := s.wsConnections[id]; ok {
+		delete(s.wsConnections, id)
+		s.metrics.wsConnections.Dec()
+	}
 }
 
 type webSocketConnection struct {
@@ -61,8 +81,10 @@ type webSocketConnection struct {
 	cancelCtx      context.CancelFunc
 	server         *rpcServer
 	id             string
+	client         string
 	closeMux       sync.Mutex
 	closed         bool
+	expiryTimer    *time.Timer
 	conn           *websocket.Conn
 	asyncMux       sync.Mutex
 	asyncInstances map[uuid.UUID]*asyncWrapper
@@ -107,11 +129,27 @@ func (c *webSocketConnection) handleCloseAsync(aw *asyncWrapper) {
 	c.asyncMux.Lock()
 	defer c.asyncMux.Unlock()
 
-	delete(c.asyncInstances, aw.id)
+	if _, ok := c.asyncInstances[aw.id]; ok {
+		delete(c.asyncInstances, aw.id)
+		c.server.metrics.wsSubscriptions.Dec()
+		c.server.limits.releaseSubscriptions(c.client, 1)
+	}
+}
+
+func (c *webSocketConnection) reserveAsync(ctx context.Context) error {
+	if !c.server.limits.reserveSubscription(c.client) {
+		c.server.metrics.incRejected(rejectReasonMaxSubscriptions)
+		return i18n.NewError(ctx, pldmsgs.MsgJSONRPCMaxSubscriptions, c.server.limits.maxSubscriptions)
+	}
+	return nil
 }
 
 func (c *webSocketConnection) handleNewAsync(ctx context.Context, rpcReq *rpcclient.RPCRequest, ash RPCAsyncHandler) (res *rpcclient.RPCResponse) {
 
+	if err := c.reserveAsync(ctx); err != nil {
+		return rpcclient.NewRPCErrorResponse(err, rpcReq.ID, rpcclient.RPCCodeInvalidRequest)
+	}
+
 	aw := &asyncWrapper{wsc: c, id: uuid.New()}
 	aw.instance, res = ash.HandleStart(ctx, rpcReq, aw)
 
@@ -119,8 +157,16 @@ func (c *webSocketConnection) handleNewAsync(ctx context.Context, rpcReq *rpccli
 	defer c.asyncMux.Unlock()
 
 	isOK := res.Error == nil
-	if isOK && aw.instance != nil {
+	switch {
+	case !isOK || aw.instance == nil:
+		c.server.limits.releaseSubscriptions(c.client, 1)
+	case c.ctx.Err() != nil:
+		// The connection closed while the handler was starting
+		aw.instance.ConnectionClosed()
+		c.server.limits.releaseSubscriptions(c.client, 1)
+	default:
 		c.asyncInstances[aw.id] = aw
+		c.server.metrics.wsSubscriptions.Inc()
 	}
 	return res
 }
@@ -135,6 +181,9 @@ func (c *webSocketConnection) close() {
 	c.closeMux.Lock()
 	if !c.closed {
 		c.closed = true
+		if c.expiryTimer != nil {
+			c.expiryTimer.Stop()
+		}
 		c.conn.Close()
 		close(c.closing)
 		c.cancelCtx()
@@ -145,6 +194,11 @@ func (c *webSocketConnection) close() {
 	for _, ah := range c.asyncHandlerList() {
 		ah.ConnectionClosed()
 	}
+	c.asyncMux.Lock()
+	c.server.metrics.wsSubscriptions.Sub(float64(len(c.asyncInstances)))
+	c.server.limits.releaseSubscriptions(c.client, len(c.asyncInstances))
+	c.asyncInstances = make(map[uuid.UUID]*asyncWrapper)
+	c.asyncMux.Unlock()
 
 	c.server.wsClosed(c.id)
 	log.L(c.ctx).Infof("WS disconnected")
@@ -194,6 +248,9 @@ func (c *webSocketConnection) listen() {
 		_, b, err := c.conn.ReadMessage()
 		if err != nil {
 			log.L(c.ctx).Errorf("Error: %s", err)
+			if errors.Is(err, websocket.ErrReadLimit) {
+				c.server.metrics.incRejected(rejectReasonRequestTooLarge)
+			}
 			return
 		}
 		log.L(c.ctx).Tracef("Received: %s", b)
@@ -17,6 +17,7 @@ package keystores
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/fs"
 	"net/url"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/hyperledger/firefly-signer/pkg/keystorev3"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
@@ -36,17 +38,40 @@ import (
 	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
 )
 
-type filesystemStoreFactory[C signerapi.ExtensibleConfig] struct{}
+type filesystemStoreFactory[C signerapi.ExtensibleConfig] struct {
+	masterKeyProviders map[string]signerapi.MasterKeyProviderFactory[C]
+}
 
 type filesystemStore struct {
-	cache    cache.Cache[string, keystorev3.WalletFile]
-	path     string
-	fileMode os.FileMode
-	dirMode  os.FileMode
+	cache     cache.Cache[string, keystorev3.WalletFile]
+	path      string
+	fileMode  os.FileMode
+	dirMode   os.FileMode
+	masterKey signerapi.MasterKeyProvider
+}
+
+// When a master key is configured, the password for each key file is stored in this
+// wrapped form in a ".wpwd" file, rather than in plaintext in a ".pwd" file.
+type wrappedPasswordFile struct {
+	KeyID   string            `json:"keyId"`
+	Wrapped pldtypes.HexBytes `json:"wrapped"`
 }
 
-func NewFilesystemStoreFactory[C signerapi.ExtensibleConfig]() signerapi.KeyStoreFactory[C] {
-	return &filesystemStoreFactory[C]{}
+// Additional master key providers can be supplied in extensions, such as one that delegates
+// to a Key Management System (KMS)
+func NewFilesystemStoreFactory[C signerapi.ExtensibleConfig](extensions ...*signerapi.Extensions[C]) signerapi.KeyStoreFactory[C] {
+	fsf := &filesystemStoreFactory[C]{
+		masterKeyProviders: map[string]signerapi.MasterKeyProviderFactory[C]{
+			pldconf.MasterKeyTypeFile: NewLocalMasterKeyProviderFactory[C](),
+			pldconf.MasterKeyTypeEnv:  NewLocalMasterKeyProviderFactory[C](),
+		},
+	}
+	for _, e := range extensions {
+		for name, mkpf := range e.MasterKeyProviderFactories {
+			fsf.masterKeyProviders[name] = mkpf
+		}
+	}
+	return fsf
 }
 
 func (fsf *filesystemStoreFactory[C]) NewKeyStore(ctx context.Context, eConf C) (fss signerapi.KeyStore, err error) {
@@ -61,12 +86,109 @@ func (fsf *filesystemStoreFactory[C]) NewKeyStore(ctx context.Context, eConf C)
 	if err != nil || !pathInfo.IsDir() {
 		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleBadPathError, *pldconf.FileSystemDefaults.Path)
 	}
-	return &filesystemStore{
+	store := &filesystemStore{
 		cache:    cache.NewCache[string, keystorev3.WalletFile](&conf.Cache, &pldconf.FileSystemDefaults.Cache),
 		fileMode: confutil.UnixFileMode(conf.FileMode, *pldconf.FileSystemDefaults.FileMode),
 		dirMode:  confutil.UnixFileMode(conf.DirMode, *pldconf.FileSystemDefaults.DirMode),
 		path:     path,
-	}, nil
+	}
+
+	if conf.MasterKey.Type != "" {
+		mkpf := fsf.masterKeyProviders[strings.ToLower(conf.MasterKey.Type)]
+		if mkpf == nil {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgSigningUnsupportedMasterKeyType, conf.MasterKey.Type)
+		}
+		if store.masterKey, err = mkpf.NewMasterKeyProvider(ctx, eConf); err != nil {
+			return nil, err
+		}
+		if err := store.rewrapPasswords(ctx, conf.MasterKey.MigratePlaintext); err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+// On startup we ensure every wrapped password is wrapped with the current master key, so that
+// once a rotation is complete the previous master key can be removed from the configuration.
+// Plaintext passwords from before a master key was configured are optionally migrated too.
+func (fss *filesystemStore) rewrapPasswords(ctx context.Context, migratePlaintext bool) error {
+	currentKeyID := fss.masterKey.CurrentKeyID()
+	rewrapped, migrated := 0, 0
+	err := filepath.WalkDir(fss.path, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		switch {
+		case strings.HasSuffix(filePath, ".wpwd"):
+			wpf, err := fss.readWrappedPasswordFile(ctx, filePath)
+			if err != nil || wpf.KeyID == currentKeyID {
+				return err
+			}
+			password, err := fss.masterKey.Unwrap(ctx, wpf.KeyID, wpf.Wrapped, fss.wrappedPasswordAAD(filePath))
+			if err == nil {
+				err = fss.writeWrappedPasswordFile(ctx, filePath, password)
+			}
+			if err != nil {
+				return err
+			}
+			rewrapped++
+		case migratePlaintext && strings.HasSuffix(filePath, ".pwd"):
+			password, err := os.ReadFile(filePath)
+			if err == nil {
+				err = fss.writeWrappedPasswordFile(ctx, strings.TrimSuffix(filePath, ".pwd")+".wpwd", password)
+			}
+			if err == nil {
+				// Only remove the plaintext once the wrapped version is safely written
+				err = os.Remove(filePath)
+			}
+			if err != nil {
+				return err
+			}
+			migrated++
+		}
+		return nil
+	})
+	if err != nil {
+		return i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleFSError)
+	}
+	log.L(ctx).Infof("Master key %s: re-wrapped %d and migrated %d password files", currentKeyID, rewrapped, migrated)
+	return nil
+}
+
+// Each wrapped password is bound to its path in the key store, so it cannot be copied over the password
+// file of a different key. The path is relative to the root of the store, so the store can be relocated.
+func (fss *filesystemStore) wrappedPasswordAAD(filePath string) []byte {
+	relPath, _ := filepath.Rel(fss.path, filePath) // cannot fail, as every file path is built from the store path
+	return []byte(filepath.ToSlash(relPath))
+}
+
+func (fss *filesystemStore) readWrappedPasswordFile(ctx context.Context, filePath string) (*wrappedPasswordFile, error) {
+	var wpf wrappedPasswordFile
+	b, err := os.ReadFile(filePath)
+	if err == nil {
+		err = json.Unmarshal(b, &wpf)
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleBadWrappedPassFile, filePath)
+	}
+	return &wpf, nil
+}
+
+func (fss *filesystemStore) writeWrappedPasswordFile(ctx context.Context, filePath string, password []byte) error {
+	keyID, wrapped, err := fss.masterKey.Wrap(ctx, password, fss.wrappedPasswordAAD(filePath))
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file and rename, so an existing password file is replaced atomically
+	tmpPath := filePath + ".tmp"
+	err = os.WriteFile(tmpPath, pldtypes.JSONString(&wrappedPasswordFile{
+		KeyID:   keyID,
+		Wrapped: wrapped,
+	}), fss.fileMode)
+	if err == nil {
+		err = os.Rename(tmpPath, filePath)
+	}
+	return err
 }
 
 func (fss *filesystemStore) validateFilePathKeyHandle(ctx context.Context, keyHandle string, forCreate bool) (absPath string, err error) {
@@ -107,7 +229,7 @@ func (fss *filesystemStore) validateFilePathKeyHandle(ctx context.Context, keyHa
 
 }
 
-func (fss *filesystemStore) createWalletFile(ctx context.Context, keyFilePath, passwordFilePath string, newKeyMaterial func() ([]byte, error)) (keystorev3.WalletFile, error) {
+func (fss *filesystemStore) createWalletFile(ctx context.Context, absPathPrefix string, newKeyMaterial func() ([]byte, error)) (keystorev3.WalletFile, error) {
 
 	privateKey, err := newKeyMaterial()
 	if err != nil {
@@ -126,9 +248,13 @@ func (fss *filesystemStore) createWalletFile(ctx context.Context, keyFilePath, p
 	// So we use the feature from https://github.com/hyperledger/firefly-signer/pull/70 to remove it entirely
 	wf.Metadata()["address"] = nil
 
-	err = os.WriteFile(passwordFilePath, []byte(password), fss.fileMode)
+	if fss.masterKey != nil {
+		err = fss.writeWrappedPasswordFile(ctx, fmt.Sprintf("%s.wpwd", absPathPrefix), []byte(password))
+	} else {
+		err = os.WriteFile(fmt.Sprintf("%s.pwd", absPathPrefix), []byte(password), fss.fileMode)
+	}
 	if err == nil {
-		err = os.WriteFile(keyFilePath, wf.JSON(), fss.fileMode)
+		err = os.WriteFile(fmt.Sprintf("%s.key", absPathPrefix), wf.JSON(), fss.fileMode)
 	}
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleFSError)
@@ -148,13 +274,12 @@ func (fss *filesystemStore) getOrCreateWalletFile(ctx context.Context, keyHandle
 		return cached, nil
 	}
 	keyFilePath := fmt.Sprintf("%s.key", absPathPrefix)
-	passwordFilePath := fmt.Sprintf("%s.pwd", absPathPrefix)
 
 	_, checkNotExist := os.Stat(keyFilePath)
 	if os.IsNotExist(checkNotExist) {
 		if newKeyMaterialFactory != nil {
 			// We need to create it
-			wf, err := fss.createWalletFile(ctx, keyFilePath, passwordFilePath, newKeyMaterialFactory)
+			wf, err := fss.createWalletFile(ctx, absPathPrefix, newKeyMaterialFactory)
 			if err == nil {
 				fss.cache.Set(keyHandle, wf)
 			}
@@ -164,28 +289,52 @@ func (fss *filesystemStore) getOrCreateWalletFile(ctx context.Context, keyHandle
 		}
 	}
 	// we need to read it
-	wf, err := fss.readWalletFile(ctx, keyFilePath, passwordFilePath)
+	wf, err := fss.readWalletFile(ctx, absPathPrefix)
 	if err == nil {
 		fss.cache.Set(keyHandle, wf)
 	}
 	return wf, err
 }
 
-func (fss *filesystemStore) readWalletFile(ctx context.Context, keyFilePath, passwordFilePath string) (keystorev3.WalletFile, error) {
+func (fss *filesystemStore) readWalletFile(ctx context.Context, absPathPrefix string) (keystorev3.WalletFile, error) {
 
+	keyFilePath := fmt.Sprintf("%s.key", absPathPrefix)
 	keyData, err := os.ReadFile(keyFilePath)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleBadKeyFile, keyFilePath)
 	}
 
-	passData, err := os.ReadFile(passwordFilePath)
+	passData, err := fss.readPassword(ctx, absPathPrefix)
 	if err != nil {
-		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleBadPassFile, passwordFilePath)
+		return nil, err
 	}
 
 	return keystorev3.ReadWalletFile(keyData, passData)
 }
 
+func (fss *filesystemStore) readPassword(ctx context.Context, absPathPrefix string) ([]byte, error) {
+	// A wrapped password takes precedence, as during migration both might briefly exist
+	wrappedPasswordFilePath := fmt.Sprintf("%s.wpwd", absPathPrefix)
+	if _, err := os.Stat(wrappedPasswordFilePath); err == nil {
+		if fss.masterKey == nil {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgSigningModuleMasterKeyRequired, wrappedPasswordFilePath)
+		}
+		wpf, err := fss.readWrappedPasswordFile(ctx, wrappedPasswordFilePath)
+		if err != nil {
+			return nil, err
+		}
+		return fss.masterKey.Unwrap(ctx, wpf.KeyID, wpf.Wrapped, fss.wrappedPasswordAAD(wrappedPasswordFilePath))
+	}
+
+	// Plaintext passwords are still readable when a master key is configured, until they are migrated
+	passwordFilePath := fmt.Sprintf("%s.pwd", absPathPrefix)
+	passData, err := os.ReadFile(passwordFilePath)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningModuleBadPassFile, passwordFilePath)
+	}
+	return passData, nil
+}
+
 func (fss *filesystemStore) FindOrCreateLoadableKey(ctx context.Context, req *prototk.ResolveKeyRequest, newKeyMaterial func() ([]byte, error)) (keyMaterial []byte, keyHandle string, err error) {
 	for _, segment := range req.Path {
 		if len(segment.Name) == 0 {
@@ -178,11 +178,11 @@ func TestCreateWalletFileFail(t *testing.T) {
 	err := os.MkdirAll(path.Join(fs.path, "clash.key"), fs.dirMode)
 	require.NoError(t, err)
 
-	_, err = fs.createWalletFile(ctx, path.Join(fs.path, "clash.key"), path.Join(fs.path, "clash.pwd"),
+	_, err = fs.createWalletFile(ctx, path.Join(fs.path, "clash"),
 		func() ([]byte, error) { return []byte{}, nil })
 	assert.Regexp(t, "PD020804", err)
 
-	_, err = fs.createWalletFile(ctx, path.Join(fs.path, "ok.key"), path.Join(fs.path, "ok.pwd"),
+	_, err = fs.createWalletFile(ctx, path.Join(fs.path, "ok"),
 		func() ([]byte, error) { return nil, fmt.Errorf("pop") })
 	assert.Regexp(t, "pop", err)
 
@@ -194,7 +194,7 @@ func TestReadWalletFileFail(t *testing.T) {
 	err := os.MkdirAll(path.Join(fs.path, "dir.key"), fs.dirMode)
 	require.NoError(t, err)
 
-	_, err = fs.readWalletFile(ctx, path.Join(fs.path, "dir"), "")
+	_, err = fs.readWalletFile(ctx, path.Join(fs.path, "dir"))
 	assert.Regexp(t, "PD020801", err)
 
 }
@@ -202,16 +202,16 @@ func TestReadWalletFileFail(t *testing.T) {
 func TestReadPassFileFail(t *testing.T) {
 	ctx, fs := newTestFilesystemStore(t)
 
-	keyFilePath, passwordFilePath := path.Join(fs.path, "ok.key"), path.Join(fs.path, "fail.pass")
+	absPathPrefix := path.Join(fs.path, "ok")
 
-	_, err := fs.createWalletFile(ctx, keyFilePath, passwordFilePath,
+	_, err := fs.createWalletFile(ctx, absPathPrefix,
 		func() ([]byte, error) { return []byte{0x01}, nil })
 	require.NoError(t, err)
 
-	err = os.Remove(passwordFilePath)
+	err = os.Remove(absPathPrefix + ".pwd")
 	require.NoError(t, err)
 
-	_, err = fs.readWalletFile(ctx, keyFilePath, passwordFilePath)
+	_, err = fs.readWalletFile(ctx, absPathPrefix)
 	assert.Regexp(t, "PD020802", err)
 }
 
@@ -221,3 +221,227 @@ func TestLoadKeyFail(t *testing.T) {
 	_, err := fs.LoadKeyMaterial(ctx, "wrong")
 	assert.Regexp(t, "PD020806", err)
 }
+
+type testMasterKeyProviderFactory struct {
+	mkp *testMasterKeyProvider
+	err error
+}
+
+func (tmf *testMasterKeyProviderFactory) NewMasterKeyProvider(ctx context.Context, conf *signerapi.ConfigNoExt) (signerapi.MasterKeyProvider, error) {
+	return tmf.mkp, tmf.err
+}
+
+type testMasterKeyProvider struct {
+	wrapErr error
+}
+
+func (tmp *testMasterKeyProvider) CurrentKeyID() string {
+	return "kms-key"
+}
+
+func (tmp *testMasterKeyProvider) Wrap(ctx context.Context, plaintext, aad []byte) (keyID string, wrapped []byte, err error) {
+	return "kms-key", plaintext, tmp.wrapErr
+}
+
+func (tmp *testMasterKeyProvider) Unwrap(ctx context.Context, keyID string, wrapped, aad []byte) (plaintext []byte, err error) {
+	return wrapped, nil
+}
+
+func newTestFilesystemStoreMasterKey(t *testing.T, dir string, mkConf pldconf.MasterKeyConfig, extensions ...*signerapi.Extensions[*signerapi.ConfigNoExt]) (context.Context, *filesystemStore, error) {
+	ctx := context.Background()
+	sf := NewFilesystemStoreFactory(extensions...)
+	store, err := sf.NewKeyStore(ctx, &signerapi.ConfigNoExt{
+		KeyStore: pldconf.KeyStoreConfig{
+			Type: pldconf.KeyStoreTypeFilesystem,
+			FileSystem: pldconf.FileSystemKeyStoreConfig{
+				Path:      confutil.P(dir),
+				MasterKey: mkConf,
+			},
+		},
+	})
+	if err != nil {
+		return ctx, nil, err
+	}
+	return ctx, store.(*filesystemStore), nil
+}
+
+func TestFileSystemStoreMasterKeyMigrateAndRotate(t *testing.T) {
+	dir := t.TempDir()
+	masterKeyFile := path.Join(t.TempDir(), "master.key")
+	err := os.WriteFile(masterKeyFile, []byte("first master key"), 0600)
+	require.NoError(t, err)
+
+	createKey := func(ctx context.Context, fs *filesystemStore, name string) []byte {
+		keyBytes, _, err := fs.FindOrCreateLoadableKey(ctx, &prototk.ResolveKeyRequest{
+			Name: name,
+			Path: []*prototk.ResolveKeyPathSegment{{Name: "folder"}},
+		}, func() ([]byte, error) { return []byte(name + " key material"), nil })
+		require.NoError(t, err)
+		return keyBytes
+	}
+	loadKey := func(ctx context.Context, fs *filesystemStore, name string) ([]byte, error) {
+		fs.cache.Delete("folder/" + name)
+		return fs.LoadKeyMaterial(ctx, "folder/"+name)
+	}
+	readWrappedKeyID := func(name string) string {
+		var wpf wrappedPasswordFile
+		b, err := os.ReadFile(path.Join(dir, "_folder", "-"+name+".wpwd"))
+		require.NoError(t, err)
+		err = json.Unmarshal(b, &wpf)
+		require.NoError(t, err)
+		return wpf.KeyID
+	}
+
+	// Start with a plaintext store
+	ctx, fs, err := newTestFilesystemStoreMasterKey(t, dir, pldconf.MasterKeyConfig{})
+	require.NoError(t, err)
+	legacyKey := createKey(ctx, fs, "legacy")
+	assert.FileExists(t, path.Join(dir, "_folder", "-legacy.pwd"))
+
+	// Configure a master key without migration - plaintext is still readable, new keys are wrapped
+	fileMasterKey := pldconf.MasterKeyConfig{
+		Type:                  pldconf.MasterKeyTypeFile,
+		MasterKeySourceConfig: pldconf.MasterKeySourceConfig{File: masterKeyFile},
+	}
+	ctx, fs, err = newTestFilesystemStoreMasterKey(t, dir, fileMasterKey)
+	require.NoError(t, err)
+	keyBytes, err := loadKey(ctx, fs, "legacy")
+	require.NoError(t, err)
+	assert.Equal(t, legacyKey, keyBytes)
+	wrappedKey := createKey(ctx, fs, "wrapped")
+	assert.NoFileExists(t, path.Join(dir, "_folder", "-wrapped.pwd"))
+	firstKeyID := readWrappedKeyID("wrapped")
+	assert.Equal(t, fs.masterKey.CurrentKeyID(), firstKeyID)
+
+	// Migrate the plaintext
+	fileMasterKey.MigratePlaintext = true
+	ctx, fs, err = newTestFilesystemStoreMasterKey(t, dir, fileMasterKey)
+	require.NoError(t, err)
+	assert.NoFileExists(t, path.Join(dir, "_folder", "-legacy.pwd"))
+	assert.Equal(t, firstKeyID, readWrappedKeyID("legacy"))
+	keyBytes, err = loadKey(ctx, fs, "legacy")
+	require.NoError(t, err)
+	assert.Equal(t, legacyKey, keyBytes)
+
+	// Without the master key, the wrapped passwords cannot be read
+	ctx, fs, err = newTestFilesystemStoreMasterKey(t, dir, pldconf.MasterKeyConfig{})
+	require.NoError(t, err)
+	_, err = loadKey(ctx, fs, "wrapped")
+	assert.Regexp(t, "PD020834", err)
+
+	// Nor with a different master key, which fails on startup
+	t.Setenv("TEST_MASTER_KEY", "second master key")
+	envMasterKey := pldconf.MasterKeyConfig{
+		Type:                  pldconf.MasterKeyTypeEnv,
+		MasterKeySourceConfig: pldconf.MasterKeySourceConfig{Env: "TEST_MASTER_KEY"},
+	}
+	_, _, err = newTestFilesystemStoreMasterKey(t, dir, envMasterKey)
+	assert.Regexp(t, "PD020831", err)
+
+	// Rotate with the old key available
+	envMasterKey.PreviousKeys = []pldconf.MasterKeySourceConfig{{File: masterKeyFile}}
+	ctx, fs, err = newTestFilesystemStoreMasterKey(t, dir, envMasterKey)
+	require.NoError(t, err)
+	secondKeyID := fs.masterKey.CurrentKeyID()
+	assert.NotEqual(t, firstKeyID, secondKeyID)
+	assert.Equal(t, secondKeyID, readWrappedKeyID("legacy"))
+	assert.Equal(t, secondKeyID, readWrappedKeyID("wrapped"))
+
+	// Now the old key can be removed
+	envMasterKey.PreviousKeys = nil
+	ctx, fs, err = newTestFilesystemStoreMasterKey(t, dir, envMasterKey)
+	require.NoError(t, err)
+	keyBytes, err = loadKey(ctx, fs, "legacy")
+	require.NoError(t, err)
+	assert.Equal(t, legacyKey, keyBytes)
+	keyBytes, err = loadKey(ctx, fs, "wrapped")
+	require.NoError(t, err)
+	assert.Equal(t, wrappedKey, keyBytes)
+
+	// A wrapped password is bound to its key file, so cannot be copied over the password of another key
+	b, err := os.ReadFile(path.Join(dir, "_folder", "-wrapped.wpwd"))
+	require.NoError(t, err)
+	err = os.WriteFile(path.Join(dir, "_folder", "-legacy.wpwd"), b, 0600)
+	require.NoError(t, err)
+	_, err = loadKey(ctx, fs, "legacy")
+	assert.Regexp(t, "PD020832", err)
+}
+
+func TestFileSystemStoreMasterKeyExtension(t *testing.T) {
+	dir := t.TempDir()
+	kmsConf := pldconf.MasterKeyConfig{Type: "kms"}
+
+	_, _, err := newTestFilesystemStoreMasterKey(t, dir, kmsConf)
+	assert.Regexp(t, "PD020828", err)
+
+	_, _, err = newTestFilesystemStoreMasterKey(t, dir, kmsConf, &signerapi.Extensions[*signerapi.ConfigNoExt]{
+		MasterKeyProviderFactories: map[string]signerapi.MasterKeyProviderFactory[*signerapi.ConfigNoExt]{
+			"kms": &testMasterKeyProviderFactory{err: fmt.Errorf("pop")},
+		},
+	})
+	assert.Regexp(t, "pop", err)
+
+	mkp := &testMasterKeyProvider{}
+	ctx, fs, err := newTestFilesystemStoreMasterKey(t, dir, kmsConf, &signerapi.Extensions[*signerapi.ConfigNoExt]{
+		MasterKeyProviderFactories: map[string]signerapi.MasterKeyProviderFactory[*signerapi.ConfigNoExt]{
+			"kms": &testMasterKeyProviderFactory{mkp: mkp},
+		},
+	})
+	require.NoError(t, err)
+
+	keyBytes, keyHandle, err := fs.FindOrCreateLoadableKey(ctx, &prototk.ResolveKeyRequest{Name: "key1"},
+		func() ([]byte, error) { return []byte("key material"), nil })
+	require.NoError(t, err)
+	fs.cache.Delete(keyHandle)
+	loaded, err := fs.LoadKeyMaterial(ctx, keyHandle)
+	require.NoError(t, err)
+	assert.Equal(t, keyBytes, loaded)
+
+	mkp.wrapErr = fmt.Errorf("pop")
+	_, _, err = fs.FindOrCreateLoadableKey(ctx, &prototk.ResolveKeyRequest{Name: "key2"},
+		func() ([]byte, error) { return []byte("key material"), nil })
+	assert.Regexp(t, "pop", err)
+}
+
+func TestFileSystemStoreMasterKeyBadWrappedFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TEST_MASTER_KEY", "master key")
+	envMasterKey := pldconf.MasterKeyConfig{
+		Type:                  pldconf.MasterKeyTypeEnv,
+		MasterKeySourceConfig: pldconf.MasterKeySourceConfig{Env: "TEST_MASTER_KEY"},
+	}
+
+	ctx, fs, err := newTestFilesystemStoreMasterKey(t, dir, envMasterKey)
+	require.NoError(t, err)
+	_, keyHandle, err := fs.FindOrCreateLoadableKey(ctx, &prototk.ResolveKeyRequest{Name: "key1"},
+		func() ([]byte, error) { return []byte("key material"), nil })
+	require.NoError(t, err)
+
+	err = os.WriteFile(path.Join(dir, "-key1.wpwd"), []byte("!!! not JSON"), 0600)
+	require.NoError(t, err)
+	fs.cache.Delete(keyHandle)
+	_, err = fs.LoadKeyMaterial(ctx, keyHandle)
+	assert.Regexp(t, "PD020833", err)
+
+	_, _, err = newTestFilesystemStoreMasterKey(t, dir, envMasterKey)
+	assert.Regexp(t, "PD020804.*PD020833", err)
+}
+
+func TestFileSystemStoreMasterKeyMigrateFail(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TEST_MASTER_KEY", "master key")
+
+	// A directory in the way of the wrapped password file
+	err := os.WriteFile(path.Join(dir, "-key1.pwd"), []byte("password"), 0600)
+	require.NoError(t, err)
+	err = os.Mkdir(path.Join(dir, "-key1.wpwd.tmp"), 0700)
+	require.NoError(t, err)
+
+	_, _, err = newTestFilesystemStoreMasterKey(t, dir, pldconf.MasterKeyConfig{
+		Type:                  pldconf.MasterKeyTypeEnv,
+		MasterKeySourceConfig: pldconf.MasterKeySourceConfig{Env: "TEST_MASTER_KEY"},
+		MigratePlaintext:      true,
+	})
+	assert.Regexp(t, "PD020804", err)
+	assert.FileExists(t, path.Join(dir, "-key1.pwd"))
+}
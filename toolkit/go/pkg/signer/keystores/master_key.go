@@ -0,0 +1,190 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package keystores
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/hkdf"
+)
+
+type localMasterKeyProviderFactory[C signerapi.ExtensibleConfig] struct{}
+
+// The local master key provider holds the master keys in memory, having loaded them from
+// files or environment variables. AES-256-GCM is used to wrap secrets, with the AES key
+// derived from the master key material using a random salt stored with each wrapped secret.
+//
+// Master key material is expected to be high-entropy (such as 32 random bytes, hex encoded),
+// and is expanded with HKDF-SHA256. A human-chosen passphrase must be configured as such,
+// so that it is stretched with Argon2id to make brute-forcing it expensive.
+type localMasterKeyProvider struct {
+	currentKeyID string
+	wrapSalt     []byte // a random salt per provider, so wrapping does not derive a new AES key each time
+	keys         map[string]*localMasterKey
+}
+
+type localMasterKey struct {
+	material   []byte
+	passphrase bool
+	aeadsMux   sync.Mutex
+	aeads      map[string]cipher.AEAD // by salt, as derivation from a passphrase is deliberately slow
+}
+
+const (
+	masterKeySaltLen  = 16
+	masterKeyWrapInfo = "paladin-master-key-wrap"
+	masterKeyIDInfo   = "paladin-master-key-id"
+)
+
+// Argon2id parameters recommended in RFC 9106 for memory constrained environments
+const (
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+)
+
+func NewLocalMasterKeyProviderFactory[C signerapi.ExtensibleConfig]() signerapi.MasterKeyProviderFactory[C] {
+	return &localMasterKeyProviderFactory[C]{}
+}
+
+func (mkf *localMasterKeyProviderFactory[C]) NewMasterKeyProvider(ctx context.Context, eConf C) (signerapi.MasterKeyProvider, error) {
+	conf := &eConf.KeyStoreConfig().FileSystem.MasterKey
+
+	// The type of the current key must match the source it has configured
+	current := conf.MasterKeySourceConfig
+	if (strings.ToLower(conf.Type) == pldconf.MasterKeyTypeFile && current.Env != "") ||
+		(strings.ToLower(conf.Type) == pldconf.MasterKeyTypeEnv && current.File != "") {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningMasterKeySourceInvalid)
+	}
+
+	mkp := &localMasterKeyProvider{
+		wrapSalt: pldtypes.RandBytes(masterKeySaltLen),
+		keys:     make(map[string]*localMasterKey),
+	}
+	var err error
+	if mkp.currentKeyID, err = mkp.loadKey(ctx, &current); err != nil {
+		return nil, err
+	}
+	for _, prev := range conf.PreviousKeys {
+		if _, err := mkp.loadKey(ctx, &prev); err != nil {
+			return nil, err
+		}
+	}
+	return mkp, nil
+}
+
+func (mkp *localMasterKeyProvider) loadKey(ctx context.Context, source *pldconf.MasterKeySourceConfig) (string, error) {
+	var keyMaterial []byte
+	var err error
+	switch {
+	case source.File != "" && source.Env == "":
+		keyMaterial, err = os.ReadFile(source.File)
+		if err != nil {
+			log.L(ctx).Errorf("Failed to load master key file %s: %s", source.File, err)
+			return "", i18n.NewError(ctx, pldmsgs.MsgSigningMasterKeyLoadFailed, source.File)
+		}
+	case source.Env != "" && source.File == "":
+		keyMaterial = []byte(os.Getenv(source.Env))
+	default:
+		return "", i18n.NewError(ctx, pldmsgs.MsgSigningMasterKeySourceInvalid)
+	}
+	keyMaterial = []byte(strings.TrimSpace(string(keyMaterial)))
+	if len(keyMaterial) == 0 {
+		return "", i18n.NewError(ctx, pldmsgs.MsgSigningMasterKeyLoadFailed, source.File+source.Env)
+	}
+
+	mk := &localMasterKey{
+		material:   keyMaterial,
+		passphrase: source.Passphrase,
+		aeads:      make(map[string]cipher.AEAD),
+	}
+	// The ID is derived with a fixed salt, separately from any wrapping key, so it can be
+	// safely stored alongside the wrapped data
+	keyID := hex.EncodeToString(mk.deriveKey([]byte(masterKeyIDInfo), masterKeyIDInfo)[0:8])
+	mkp.keys[keyID] = mk
+	return keyID, nil
+}
+
+func (mk *localMasterKey) deriveKey(salt []byte, info string) []byte {
+	ikm := mk.material
+	if mk.passphrase {
+		ikm = argon2.IDKey(mk.material, salt, argon2Time, argon2Memory, argon2Threads, 32)
+	}
+	key := make([]byte, 32)
+	_, _ = io.ReadFull(hkdf.New(sha256.New, ikm, salt, []byte(info)), key) // cannot fail for 32 bytes
+	return key
+}
+
+func (mk *localMasterKey) aead(salt []byte) cipher.AEAD {
+	mk.aeadsMux.Lock()
+	defer mk.aeadsMux.Unlock()
+
+	aead := mk.aeads[string(salt)]
+	if aead == nil {
+		block, _ := aes.NewCipher(mk.deriveKey(salt, masterKeyWrapInfo)) // cannot fail with a 32 byte key
+		aead, _ = cipher.NewGCM(block)
+		mk.aeads[string(salt)] = aead
+	}
+	return aead
+}
+
+func (mkp *localMasterKeyProvider) CurrentKeyID() string {
+	return mkp.currentKeyID
+}
+
+func (mkp *localMasterKeyProvider) Wrap(ctx context.Context, plaintext, aad []byte) (keyID string, wrapped []byte, err error) {
+	aead := mkp.keys[mkp.currentKeyID].aead(mkp.wrapSalt)
+	nonce := pldtypes.RandBytes(aead.NonceSize())
+	// The salt and nonce are prepended to the ciphertext
+	prefix := append(append([]byte{}, mkp.wrapSalt...), nonce...)
+	return mkp.currentKeyID, aead.Seal(prefix, nonce, plaintext, aad), nil
+}
+
+func (mkp *localMasterKeyProvider) Unwrap(ctx context.Context, keyID string, wrapped, aad []byte) (plaintext []byte, err error) {
+	mk := mkp.keys[keyID]
+	if mk == nil {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningMasterKeyNotAvailable, keyID)
+	}
+	if len(wrapped) < masterKeySaltLen {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningMasterKeyUnwrapFailed, keyID)
+	}
+	salt, wrapped := wrapped[0:masterKeySaltLen], wrapped[masterKeySaltLen:]
+	aead := mk.aead(salt)
+	if len(wrapped) < aead.NonceSize() {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningMasterKeyUnwrapFailed, keyID)
+	}
+	nonce, ciphertext := wrapped[0:aead.NonceSize()], wrapped[aead.NonceSize():]
+	plaintext, err = aead.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningMasterKeyUnwrapFailed, keyID)
+	}
+	return plaintext, nil
+}
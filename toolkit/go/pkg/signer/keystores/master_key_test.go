@@ -0,0 +1,184 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package keystores
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMasterKeyProvider(t *testing.T, conf pldconf.MasterKeyConfig) (signerapi.MasterKeyProvider, error) {
+	return NewLocalMasterKeyProviderFactory[*signerapi.ConfigNoExt]().NewMasterKeyProvider(context.Background(), &signerapi.ConfigNoExt{
+		KeyStore: pldconf.KeyStoreConfig{
+			Type: pldconf.KeyStoreTypeFilesystem,
+			FileSystem: pldconf.FileSystemKeyStoreConfig{
+				MasterKey: conf,
+			},
+		},
+	})
+}
+
+func TestLocalMasterKeyWrapUnwrapRotate(t *testing.T) {
+	ctx := context.Background()
+
+	keyFile := path.Join(t.TempDir(), "master.key")
+	err := os.WriteFile(keyFile, []byte("first master key\n"), 0600)
+	require.NoError(t, err)
+
+	mkp1, err := newTestMasterKeyProvider(t, pldconf.MasterKeyConfig{
+		Type:                  pldconf.MasterKeyTypeFile,
+		MasterKeySourceConfig: pldconf.MasterKeySourceConfig{File: keyFile},
+	})
+	require.NoError(t, err)
+
+	aad := []byte("some/key.wpwd")
+	keyID1, wrapped, err := mkp1.Wrap(ctx, []byte("secret"), aad)
+	require.NoError(t, err)
+	assert.Equal(t, mkp1.CurrentKeyID(), keyID1)
+	assert.NotContains(t, string(wrapped), "secret")
+
+	// Same key material results in the same ID, but a different nonce
+	_, wrapped2, err := mkp1.Wrap(ctx, []byte("secret"), aad)
+	require.NoError(t, err)
+	assert.NotEqual(t, wrapped, wrapped2)
+
+	plaintext, err := mkp1.Unwrap(ctx, keyID1, wrapped, aad)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+
+	// The wrapped secret is bound to the AAD
+	_, err = mkp1.Unwrap(ctx, keyID1, wrapped, []byte("other/key.wpwd"))
+	assert.Regexp(t, "PD020832", err)
+
+	// Rotate to a key in an env var, with the file as a previous key
+	t.Setenv("TEST_MASTER_KEY", "second master key")
+	mkp2, err := newTestMasterKeyProvider(t, pldconf.MasterKeyConfig{
+		Type:                  pldconf.MasterKeyTypeEnv,
+		MasterKeySourceConfig: pldconf.MasterKeySourceConfig{Env: "TEST_MASTER_KEY"},
+		PreviousKeys:          []pldconf.MasterKeySourceConfig{{File: keyFile}},
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, keyID1, mkp2.CurrentKeyID())
+
+	plaintext, err = mkp2.Unwrap(ctx, keyID1, wrapped, aad)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+
+	keyID2, wrapped, err := mkp2.Wrap(ctx, []byte("secret"), aad)
+	require.NoError(t, err)
+	assert.Equal(t, mkp2.CurrentKeyID(), keyID2)
+
+	// The old provider cannot unwrap with the new key
+	_, err = mkp1.Unwrap(ctx, keyID2, wrapped, aad)
+	assert.Regexp(t, "PD020831", err)
+
+	// Corrupted data fails to unwrap
+	_, err = mkp2.Unwrap(ctx, keyID2, wrapped[0:4], aad)
+	assert.Regexp(t, "PD020832", err)
+	_, err = mkp2.Unwrap(ctx, keyID2, wrapped[0:masterKeySaltLen+4], aad)
+	assert.Regexp(t, "PD020832", err)
+	wrapped[len(wrapped)-1] ^= 0xff
+	_, err = mkp2.Unwrap(ctx, keyID2, wrapped, aad)
+	assert.Regexp(t, "PD020832", err)
+}
+
+func TestLocalMasterKeyPassphrase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("TEST_MASTER_KEY", "correct horse battery staple")
+	passphraseConf := pldconf.MasterKeyConfig{
+		Type:                  pldconf.MasterKeyTypeEnv,
+		MasterKeySourceConfig: pldconf.MasterKeySourceConfig{Env: "TEST_MASTER_KEY", Passphrase: true},
+	}
+	mkp1, err := newTestMasterKeyProvider(t, passphraseConf)
+	require.NoError(t, err)
+
+	// The same material used as raw key material derives a different key
+	mkpRaw, err := newTestMasterKeyProvider(t, pldconf.MasterKeyConfig{
+		Type:                  pldconf.MasterKeyTypeEnv,
+		MasterKeySourceConfig: pldconf.MasterKeySourceConfig{Env: "TEST_MASTER_KEY"},
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, mkpRaw.CurrentKeyID(), mkp1.CurrentKeyID())
+
+	keyID, wrapped, err := mkp1.Wrap(ctx, []byte("secret"), nil)
+	require.NoError(t, err)
+	_, err = mkpRaw.Unwrap(ctx, keyID, wrapped, nil)
+	assert.Regexp(t, "PD020831", err)
+
+	// A new provider with the same passphrase has a different salt for wrapping, but can unwrap
+	mkp2, err := newTestMasterKeyProvider(t, passphraseConf)
+	require.NoError(t, err)
+	assert.Equal(t, keyID, mkp2.CurrentKeyID())
+	assert.NotEqual(t, mkp1.(*localMasterKeyProvider).wrapSalt, mkp2.(*localMasterKeyProvider).wrapSalt)
+	plaintext, err := mkp2.Unwrap(ctx, keyID, wrapped, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+	_, wrapped2, err := mkp2.Wrap(ctx, []byte("secret"), nil)
+	require.NoError(t, err)
+	plaintext, err = mkp1.Unwrap(ctx, keyID, wrapped2, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+}
+
+func TestLocalMasterKeyBadConfig(t *testing.T) {
+	keyFile := path.Join(t.TempDir(), "master.key")
+
+	_, err := newTestMasterKeyProvider(t, pldconf.MasterKeyConfig{
+		Type:                  pldconf.MasterKeyTypeFile,
+		MasterKeySourceConfig: pldconf.MasterKeySourceConfig{Env: "TEST_MASTER_KEY"},
+	})
+	assert.Regexp(t, "PD020829", err)
+
+	_, err = newTestMasterKeyProvider(t, pldconf.MasterKeyConfig{
+		Type:                  pldconf.MasterKeyTypeEnv,
+		MasterKeySourceConfig: pldconf.MasterKeySourceConfig{File: keyFile},
+	})
+	assert.Regexp(t, "PD020829", err)
+
+	_, err = newTestMasterKeyProvider(t, pldconf.MasterKeyConfig{
+		Type: pldconf.MasterKeyTypeFile,
+	})
+	assert.Regexp(t, "PD020829", err)
+
+	_, err = newTestMasterKeyProvider(t, pldconf.MasterKeyConfig{
+		Type:                  pldconf.MasterKeyTypeFile,
+		MasterKeySourceConfig: pldconf.MasterKeySourceConfig{File: keyFile},
+	})
+	assert.Regexp(t, "PD020830", err)
+
+	t.Setenv("TEST_MASTER_KEY", "")
+	_, err = newTestMasterKeyProvider(t, pldconf.MasterKeyConfig{
+		Type:                  pldconf.MasterKeyTypeEnv,
+		MasterKeySourceConfig: pldconf.MasterKeySourceConfig{Env: "TEST_MASTER_KEY"},
+	})
+	assert.Regexp(t, "PD020830", err)
+
+	t.Setenv("TEST_MASTER_KEY", "some key")
+	_, err = newTestMasterKeyProvider(t, pldconf.MasterKeyConfig{
+		Type:                  pldconf.MasterKeyTypeEnv,
+		MasterKeySourceConfig: pldconf.MasterKeySourceConfig{Env: "TEST_MASTER_KEY"},
+		PreviousKeys:          []pldconf.MasterKeySourceConfig{{File: keyFile, Env: "TEST_MASTER_KEY"}},
+	})
+	assert.Regexp(t, "PD020829", err)
+}
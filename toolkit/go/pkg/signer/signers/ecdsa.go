@@ -17,7 +17,12 @@ package signers
 
 import (
 	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"encoding/hex"
+	"math/big"
 	"strings"
 
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
@@ -37,6 +42,8 @@ func (s *ecdsaSigner) Sign(ctx context.Context, algorithm, payloadType string, p
 	switch curve {
 	case algorithms.Curve_SECP256K1:
 		return s.Sign_secp256k1(ctx, algorithm, payloadType, privateKey, payload)
+	case algorithms.Curve_SECP256R1:
+		return s.Sign_secp256r1(ctx, algorithm, payloadType, privateKey, payload)
 	default:
 		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningUnsupportedECDSACurve, curve)
 	}
@@ -48,6 +55,8 @@ func (s *ecdsaSigner) GetVerifier(ctx context.Context, algorithm, verifierType s
 	switch curve {
 	case algorithms.Curve_SECP256K1:
 		return s.GetVerifier_secp256k1(ctx, algorithm, verifierType, privateKey)
+	case algorithms.Curve_SECP256R1:
+		return s.GetVerifier_secp256r1(ctx, algorithm, verifierType, privateKey)
 	default:
 		return "", i18n.NewError(ctx, pldmsgs.MsgSigningUnsupportedECDSACurve, curve)
 	}
@@ -84,15 +93,88 @@ func (s *ecdsaSigner) GetVerifier_secp256k1(ctx context.Context, algorithm, veri
 		return "0x" + hex.EncodeToString(kp.PublicKeyBytes()), nil
 	case verifiers.HEX_ECDSA_PUBKEY_UNCOMPRESSED:
 		return hex.EncodeToString(kp.PublicKeyBytes()), nil
+	case verifiers.DID_KEY:
+		return didKey(multicodecSecp256k1Pub, kp.PublicKey.SerializeCompressed()), nil
+	case verifiers.JWK:
+		return jwkEC("secp256k1", kp.PublicKey.X(), kp.PublicKey.Y()), nil
 	default:
 		return "", i18n.NewError(ctx, pldmsgs.MsgSigningUnsupportedVerifierCombination, verifierType, algorithm)
 	}
 }
 
+// The first 32 bytes of key material are used directly as the P-256 private key scalar, which
+// must be in the range [1,n-1] - we do not reduce it, so the key is the same as in other tools.
+func p256KeyFromBytes(ctx context.Context, algorithm string, privateKey []byte) (*ecdsa.PrivateKey, error) {
+	if len(privateKey) < 32 {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningKeyMaterialTooShort, algorithm, 32)
+	}
+	// NewPrivateKey rejects d==0 and d>=n
+	ecdhKey, err := ecdh.P256().NewPrivateKey(privateKey[0:32])
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, pldmsgs.MsgSigningKeyMaterialInvalidForCurve, algorithm)
+	}
+	pubKey := ecdhKey.PublicKey().Bytes() // uncompressed 0x04 || X || Y
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(pubKey[1:33]),
+			Y:     new(big.Int).SetBytes(pubKey[33:65]),
+		},
+		D: new(big.Int).SetBytes(privateKey[0:32]),
+	}, nil
+}
+
+func (s *ecdsaSigner) Sign_secp256r1(ctx context.Context, algorithm, payloadType string, privateKey, payload []byte) ([]byte, error) {
+	key, err := p256KeyFromBytes(ctx, algorithm, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	switch payloadType {
+	case signpayloads.OPAQUE_TO_RS:
+		if len(payload) == 0 {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgSigningEmptyPayload)
+		}
+		r, sv, _ := ecdsa.Sign(rand.Reader, key, payload) // cannot fail for a valid key with the system random source
+		sig := make([]byte, 64)
+		r.FillBytes(sig[0:32])
+		sv.FillBytes(sig[32:64])
+		return sig, nil
+	default:
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningUnsupportedPayloadCombination, payloadType, algorithm)
+	}
+}
+
+func (s *ecdsaSigner) GetVerifier_secp256r1(ctx context.Context, algorithm, verifierType string, privateKey []byte) (string, error) {
+	key, err := p256KeyFromBytes(ctx, algorithm, privateKey)
+	if err != nil {
+		return "", err
+	}
+	switch verifierType {
+	case verifiers.HEX_ECDSA_PUBKEY_UNCOMPRESSED_0X:
+		return "0x" + hex.EncodeToString(p256PubKeyUncompressed(key)), nil
+	case verifiers.HEX_ECDSA_PUBKEY_UNCOMPRESSED:
+		return hex.EncodeToString(p256PubKeyUncompressed(key)), nil
+	case verifiers.DID_KEY:
+		return didKey(multicodecP256Pub, elliptic.MarshalCompressed(key.Curve, key.X, key.Y)), nil
+	case verifiers.JWK:
+		return jwkEC("P-256", key.X, key.Y), nil
+	default:
+		return "", i18n.NewError(ctx, pldmsgs.MsgSigningUnsupportedVerifierCombination, verifierType, algorithm)
+	}
+}
+
+// Consistent with secp256k1, the uncompressed key is the 64 byte X || Y without the 0x04 prefix
+func p256PubKeyUncompressed(key *ecdsa.PrivateKey) []byte {
+	pubKey := make([]byte, 64)
+	key.X.FillBytes(pubKey[0:32])
+	key.Y.FillBytes(pubKey[32:64])
+	return pubKey
+}
+
 func (s *ecdsaSigner) GetMinimumKeyLen(ctx context.Context, algorithm string) (int, error) {
 	curve := strings.TrimPrefix(strings.ToLower(algorithm), algorithms.Prefix_ECDSA+":")
 	switch curve {
-	case algorithms.Curve_SECP256K1:
+	case algorithms.Curve_SECP256K1, algorithms.Curve_SECP256R1:
 		return 32, nil
 	default:
 		return -1, i18n.NewError(ctx, pldmsgs.MsgSigningUnsupportedECDSACurve, curve)
@@ -17,10 +17,16 @@ package signers
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"math/big"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcutil/base58"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
 	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
@@ -118,3 +124,107 @@ func TestECDSAVerifiers_secp256k1(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, pubKey, verifier)
 }
+
+func TestECDSAVerifiersDIDAndJWK_secp256k1(t *testing.T) {
+	privKey := ethtypes.MustNewHexBytes0xPrefix(
+		"4afef2e65381d2667f0af4347d01d1813f6a7e1824c65c0210a104cc80d3aa15")
+
+	ctx, signer, kp := newTestSigner(t, privKey)
+
+	verifier, err := signer.GetVerifier(ctx, algorithms.ECDSA_SECP256K1, verifiers.DID_KEY, privKey)
+	require.NoError(t, err)
+	assert.Regexp(t, "^did:key:zQ3s", verifier)
+	decoded := base58.Decode(verifier[len("did:key:z"):])
+	assert.Equal(t, append([]byte{0xe7, 0x01}, kp.PublicKey.SerializeCompressed()...), decoded)
+
+	verifier, err = signer.GetVerifier(ctx, algorithms.ECDSA_SECP256K1, verifiers.JWK, privKey)
+	require.NoError(t, err)
+	var jwk map[string]string
+	require.NoError(t, json.Unmarshal([]byte(verifier), &jwk))
+	assert.Equal(t, "EC", jwk["kty"])
+	assert.Equal(t, "secp256k1", jwk["crv"])
+	x, _ := base64.RawURLEncoding.DecodeString(jwk["x"])
+	y, _ := base64.RawURLEncoding.DecodeString(jwk["y"])
+	assert.Equal(t, kp.PublicKeyBytes(), append(x, y...))
+}
+
+func TestECDSAErrors_secp256r1(t *testing.T) {
+	ctx, signer, _ := newTestSigner(t, pldtypes.RandBytes(32))
+
+	_, err := signer.Sign(ctx, algorithms.ECDSA_SECP256R1, signpayloads.OPAQUE_TO_RS, []byte("short"), nil)
+	assert.Regexp(t, "PD020836", err)
+
+	_, err = signer.GetVerifier(ctx, algorithms.ECDSA_SECP256R1, verifiers.JWK, []byte("short"))
+	assert.Regexp(t, "PD020836", err)
+
+	_, err = signer.GetVerifier(ctx, algorithms.ECDSA_SECP256R1, verifiers.JWK, make([]byte, 32))
+	assert.Regexp(t, "PD020837", err)
+
+	_, err = signer.Sign(ctx, algorithms.ECDSA_SECP256R1, signpayloads.OPAQUE_TO_RS, elliptic.P256().Params().N.Bytes(), []byte("data"))
+	assert.Regexp(t, "PD020837", err)
+
+	_, err = signer.Sign(ctx, algorithms.ECDSA_SECP256R1, signpayloads.OPAQUE_TO_RSV, pldtypes.RandBytes(32), nil)
+	assert.Regexp(t, "PD020824", err)
+
+	_, err = signer.GetVerifier(ctx, algorithms.ECDSA_SECP256R1, verifiers.ETH_ADDRESS, pldtypes.RandBytes(32))
+	assert.Regexp(t, "PD020823", err)
+
+	_, err = signer.Sign(ctx, algorithms.ECDSA_SECP256R1, signpayloads.OPAQUE_TO_RS, pldtypes.RandBytes(32), nil)
+	assert.Regexp(t, "PD020825", err)
+}
+
+func TestECDSASigning_secp256r1(t *testing.T) {
+	ctx, signer, _ := newTestSigner(t, pldtypes.RandBytes(32))
+
+	privKey := pldtypes.RandBytes(32)
+	digest := pldtypes.RandBytes(32)
+
+	keyLen, err := signer.GetMinimumKeyLen(ctx, algorithms.ECDSA_SECP256R1)
+	require.NoError(t, err)
+	assert.Equal(t, 32, keyLen)
+
+	sig, err := signer.Sign(ctx, algorithms.ECDSA_SECP256R1, signpayloads.OPAQUE_TO_RS, privKey, digest)
+	require.NoError(t, err)
+	assert.Len(t, sig, 64)
+
+	pubKeyHex, err := signer.GetVerifier(ctx, algorithms.ECDSA_SECP256R1, verifiers.HEX_ECDSA_PUBKEY_UNCOMPRESSED, privKey)
+	require.NoError(t, err)
+	pubKeyBytes, err := hex.DecodeString(pubKeyHex)
+	require.NoError(t, err)
+	pubKey := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(pubKeyBytes[0:32]),
+		Y:     new(big.Int).SetBytes(pubKeyBytes[32:64]),
+	}
+	assert.True(t, ecdsa.Verify(pubKey, digest, new(big.Int).SetBytes(sig[0:32]), new(big.Int).SetBytes(sig[32:64])))
+}
+
+func TestECDSAVerifiers_secp256r1(t *testing.T) {
+	// Known answer from RFC 6979 A.2.5 (P-256 key pair)
+	privKey, err := hex.DecodeString("c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721")
+	require.NoError(t, err)
+	x2, _ := new(big.Int).SetString("60fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6", 16)
+	y2, _ := new(big.Int).SetString("7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299", 16)
+	pubKey := hex.EncodeToString(append(x2.FillBytes(make([]byte, 32)), y2.FillBytes(make([]byte, 32))...))
+
+	ctx, signer, _ := newTestSigner(t, privKey)
+
+	verifier, err := signer.GetVerifier(ctx, algorithms.ECDSA_SECP256R1, verifiers.HEX_ECDSA_PUBKEY_UNCOMPRESSED, privKey)
+	require.NoError(t, err)
+	assert.Equal(t, pubKey, verifier)
+
+	verifier, err = signer.GetVerifier(ctx, algorithms.ECDSA_SECP256R1, verifiers.HEX_ECDSA_PUBKEY_UNCOMPRESSED_0X, privKey)
+	require.NoError(t, err)
+	assert.Equal(t, "0x"+pubKey, verifier)
+
+	verifier, err = signer.GetVerifier(ctx, algorithms.ECDSA_SECP256R1, verifiers.DID_KEY, privKey)
+	require.NoError(t, err)
+	assert.Regexp(t, "^did:key:zDn", verifier)
+	decoded := base58.Decode(verifier[len("did:key:z"):])
+	assert.Equal(t, append([]byte{0x80, 0x24}, elliptic.MarshalCompressed(elliptic.P256(), x2, y2)...), decoded)
+
+	verifier, err = signer.GetVerifier(ctx, algorithms.ECDSA_SECP256R1, verifiers.JWK, privKey)
+	require.NoError(t, err)
+	assert.Equal(t, `{"crv":"P-256","kty":"EC","x":"`+base64.RawURLEncoding.EncodeToString(x2.FillBytes(make([]byte, 32)))+
+		`","y":"`+base64.RawURLEncoding.EncodeToString(y2.FillBytes(make([]byte, 32)))+`"}`, verifier)
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package signers
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+type eddsaSigner struct{}
+
+func (s *eddsaSigner) Sign(ctx context.Context, algorithm, payloadType string, privateKey, payload []byte) ([]byte, error) {
+	// We register for all EdDSA algorithms
+	curve := strings.TrimPrefix(strings.ToLower(algorithm), algorithms.Prefix_EDDSA+":")
+	switch curve {
+	case algorithms.Curve_ED25519:
+		return s.Sign_ed25519(ctx, algorithm, payloadType, privateKey, payload)
+	default:
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningUnsupportedEdDSACurve, curve)
+	}
+}
+
+func (s *eddsaSigner) GetVerifier(ctx context.Context, algorithm, verifierType string, privateKey []byte) (string, error) {
+	// We register for all EdDSA algorithms
+	curve := strings.TrimPrefix(strings.ToLower(algorithm), algorithms.Prefix_EDDSA+":")
+	switch curve {
+	case algorithms.Curve_ED25519:
+		return s.GetVerifier_ed25519(ctx, algorithm, verifierType, privateKey)
+	default:
+		return "", i18n.NewError(ctx, pldmsgs.MsgSigningUnsupportedEdDSACurve, curve)
+	}
+}
+
+// The first 32 bytes of key material are used as the RFC8032 private key seed
+func ed25519KeyFromBytes(ctx context.Context, algorithm string, privateKey []byte) (ed25519.PrivateKey, error) {
+	if len(privateKey) < ed25519.SeedSize {
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningKeyMaterialTooShort, algorithm, ed25519.SeedSize)
+	}
+	return ed25519.NewKeyFromSeed(privateKey[0:ed25519.SeedSize]), nil
+}
+
+func (s *eddsaSigner) Sign_ed25519(ctx context.Context, algorithm, payloadType string, privateKey, payload []byte) ([]byte, error) {
+	key, err := ed25519KeyFromBytes(ctx, algorithm, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	switch payloadType {
+	case signpayloads.OPAQUE_TO_ED25519:
+		if len(payload) == 0 {
+			return nil, i18n.NewError(ctx, pldmsgs.MsgSigningEmptyPayload)
+		}
+		return ed25519.Sign(key, payload), nil
+	default:
+		return nil, i18n.NewError(ctx, pldmsgs.MsgSigningUnsupportedPayloadCombination, payloadType, algorithm)
+	}
+}
+
+func (s *eddsaSigner) GetVerifier_ed25519(ctx context.Context, algorithm, verifierType string, privateKey []byte) (string, error) {
+	key, err := ed25519KeyFromBytes(ctx, algorithm, privateKey)
+	if err != nil {
+		return "", err
+	}
+	pubKey := key.Public().(ed25519.PublicKey)
+	switch verifierType {
+	case verifiers.HEX_ED25519_PUBKEY_0X:
+		return "0x" + hex.EncodeToString(pubKey), nil
+	case verifiers.HEX_ED25519_PUBKEY:
+		return hex.EncodeToString(pubKey), nil
+	case verifiers.DID_KEY:
+		return didKey(multicodecEd25519Pub, pubKey), nil
+	case verifiers.JWK:
+		return jwkOKP("Ed25519", pubKey), nil
+	default:
+		return "", i18n.NewError(ctx, pldmsgs.MsgSigningUnsupportedVerifierCombination, verifierType, algorithm)
+	}
+}
+
+func (s *eddsaSigner) GetMinimumKeyLen(ctx context.Context, algorithm string) (int, error) {
+	curve := strings.TrimPrefix(strings.ToLower(algorithm), algorithms.Prefix_EDDSA+":")
+	switch curve {
+	case algorithms.Curve_ED25519:
+		return ed25519.SeedSize, nil
+	default:
+		return -1, i18n.NewError(ctx, pldmsgs.MsgSigningUnsupportedEdDSACurve, curve)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package signers
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEdDSASigner(t *testing.T) (context.Context, *eddsaSigner) {
+	ctx := context.Background()
+
+	signer, err := NewEdDSASignerFactory[*signerapi.ConfigNoExt]().NewSigner(ctx, &signerapi.ConfigNoExt{})
+	require.NoError(t, err)
+
+	return ctx, signer.(*eddsaSigner)
+}
+
+func TestEdDSAErrors(t *testing.T) {
+
+	ctx, signer := newTestEdDSASigner(t)
+
+	_, err := signer.Sign(ctx, "eddsa:unknown", "", nil, nil)
+	assert.Regexp(t, "PD020835", err)
+
+	_, err = signer.GetVerifier(ctx, "eddsa:unknown", "", nil)
+	assert.Regexp(t, "PD020835", err)
+
+	_, err = signer.GetMinimumKeyLen(ctx, "eddsa:unknown")
+	assert.Regexp(t, "PD020835", err)
+
+	_, err = signer.Sign(ctx, algorithms.EDDSA_ED25519, signpayloads.OPAQUE_TO_ED25519, []byte("short"), nil)
+	assert.Regexp(t, "PD020836", err)
+
+	_, err = signer.GetVerifier(ctx, algorithms.EDDSA_ED25519, verifiers.DID_KEY, []byte("short"))
+	assert.Regexp(t, "PD020836", err)
+
+	_, err = signer.Sign(ctx, algorithms.EDDSA_ED25519, "wrong", pldtypes.RandBytes(32), nil)
+	assert.Regexp(t, "PD020824", err)
+
+	_, err = signer.GetVerifier(ctx, algorithms.EDDSA_ED25519, "wrong", pldtypes.RandBytes(32))
+	assert.Regexp(t, "PD020823", err)
+
+	_, err = signer.Sign(ctx, algorithms.EDDSA_ED25519, signpayloads.OPAQUE_TO_ED25519, pldtypes.RandBytes(32), nil)
+	assert.Regexp(t, "PD020825", err)
+
+}
+
+func TestEdDSASigning_ed25519(t *testing.T) {
+	ctx, signer := newTestEdDSASigner(t)
+
+	privKey := pldtypes.RandBytes(32)
+	testData := pldtypes.RandBytes(128)
+
+	keyLen, err := signer.GetMinimumKeyLen(ctx, algorithms.EDDSA_ED25519)
+	require.NoError(t, err)
+	assert.Equal(t, 32, keyLen)
+
+	sig, err := signer.Sign(ctx, algorithms.EDDSA_ED25519, signpayloads.OPAQUE_TO_ED25519, privKey, testData)
+	require.NoError(t, err)
+	assert.Len(t, sig, ed25519.SignatureSize)
+
+	pubKeyHex, err := signer.GetVerifier(ctx, algorithms.EDDSA_ED25519, verifiers.HEX_ED25519_PUBKEY, privKey)
+	require.NoError(t, err)
+	pubKey, err := hex.DecodeString(pubKeyHex)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(pubKey, testData, sig))
+}
+
+func TestEdDSAVerifiers_ed25519(t *testing.T) {
+	// Test vector 1 from RFC8032 section 7.1
+	privKey, _ := hex.DecodeString("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60")
+	pubKey := "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a"
+
+	ctx, signer := newTestEdDSASigner(t)
+
+	verifier, err := signer.GetVerifier(ctx, algorithms.EDDSA_ED25519, verifiers.HEX_ED25519_PUBKEY, privKey)
+	require.NoError(t, err)
+	assert.Equal(t, pubKey, verifier)
+
+	verifier, err = signer.GetVerifier(ctx, algorithms.EDDSA_ED25519, verifiers.HEX_ED25519_PUBKEY_0X, privKey)
+	require.NoError(t, err)
+	assert.Equal(t, "0x"+pubKey, verifier)
+
+	verifier, err = signer.GetVerifier(ctx, algorithms.EDDSA_ED25519, verifiers.DID_KEY, privKey)
+	require.NoError(t, err)
+	assert.Regexp(t, "^did:key:z6Mk", verifier)
+	decoded := base58.Decode(verifier[len("did:key:z"):])
+	assert.Equal(t, "ed01"+pubKey, hex.EncodeToString(decoded))
+
+	verifier, err = signer.GetVerifier(ctx, algorithms.EDDSA_ED25519, verifiers.JWK, privKey)
+	require.NoError(t, err)
+	assert.Equal(t, `{"crv":"Ed25519","kty":"OKP","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`, verifier)
+}
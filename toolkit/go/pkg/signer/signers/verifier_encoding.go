@@ -0,0 +1,61 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package signers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+)
+
+// Multicodec prefixes (unsigned varint encoded) for the public key types we support in did:key
+// verifiers - see https://github.com/multiformats/multicodec/blob/master/table.csv
+var (
+	multicodecEd25519Pub   = []byte{0xed, 0x01}
+	multicodecSecp256k1Pub = []byte{0xe7, 0x01}
+	multicodecP256Pub      = []byte{0x80, 0x24}
+)
+
+// didKey builds a did:key DID from the multicodec prefix and the public key bytes,
+// multibase encoded with the base58btc ('z') prefix
+func didKey(multicodec []byte, pubKey []byte) string {
+	return "did:key:z" + base58.Encode(append(append([]byte{}, multicodec...), pubKey...))
+}
+
+func jwkB64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func jwkCoord(i *big.Int) string {
+	return jwkB64(i.FillBytes(make([]byte, 32)))
+}
+
+// jwkString serializes a public key JWK in compact form. A map is used so that the
+// members are in lexicographic order, as per the RFC7638 thumbprint canonical form.
+func jwkString(jwk map[string]string) string {
+	b, _ := json.Marshal(jwk)
+	return string(b)
+}
+
+func jwkOKP(crv string, x []byte) string {
+	return jwkString(map[string]string{"kty": "OKP", "crv": crv, "x": jwkB64(x)})
+}
+
+func jwkEC(crv string, x, y *big.Int) string {
+	return jwkString(map[string]string{"kty": "EC", "crv": crv, "x": jwkCoord(x), "y": jwkCoord(y)})
+}
@@ -84,13 +84,15 @@ type signingModule[C signerapi.ExtensibleConfig] struct {
 func NewSigningModule[C signerapi.ExtensibleConfig](ctx context.Context, conf C, extensions ...*signerapi.Extensions[C]) (_ SigningModule, err error) {
 
 	ecdsaSigner, _ := signers.NewECDSASignerFactory[C]().NewSigner(ctx, conf) // this factory has no errors as it does not parse any config
+	eddsaSigner, _ := signers.NewEdDSASignerFactory[C]().NewSigner(ctx, conf) // this factory has no errors as it does not parse any config
 	sm := &signingModule[C]{
 		signingImplementations: map[string]signerapi.InMemorySigner{
 			algorithms.Prefix_ECDSA: ecdsaSigner,
+			algorithms.Prefix_EDDSA: eddsaSigner,
 		},
 	}
 	keyStoreImplementations := map[string]signerapi.KeyStoreFactory[C]{
-		pldconf.KeyStoreTypeFilesystem: keystores.NewFilesystemStoreFactory[C](),
+		pldconf.KeyStoreTypeFilesystem: keystores.NewFilesystemStoreFactory[C](extensions...),
 		pldconf.KeyStoreTypeStatic:     keystores.NewStaticStoreFactory[C](),
 	}
 
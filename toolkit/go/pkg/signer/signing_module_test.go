@@ -443,6 +443,50 @@ func TestResolveSignWithNewKeyCreation(t *testing.T) {
 
 }
 
+func TestResolveSignEd25519AndSECP256R1(t *testing.T) {
+
+	sm, err := NewSigningModule(context.Background(), &signerapi.ConfigNoExt{
+		KeyStore: pldconf.KeyStoreConfig{
+			Type: pldconf.KeyStoreTypeFilesystem,
+			FileSystem: pldconf.FileSystemKeyStoreConfig{
+				Path: confutil.P(t.TempDir()),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	resolveRes, err := sm.Resolve(context.Background(), &prototk.ResolveKeyRequest{
+		RequiredIdentifiers: []*prototk.PublicKeyIdentifierType{
+			{Algorithm: algorithms.EDDSA_ED25519, VerifierType: verifiers.DID_KEY},
+			{Algorithm: algorithms.ECDSA_SECP256R1, VerifierType: verifiers.JWK},
+		},
+		Name: "key1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "key1", resolveRes.KeyHandle)
+	assert.Regexp(t, "^did:key:z6Mk", resolveRes.Identifiers[0].Verifier)
+	assert.Regexp(t, `"crv":"P-256"`, resolveRes.Identifiers[1].Verifier)
+
+	signRes, err := sm.Sign(context.Background(), &prototk.SignWithKeyRequest{
+		KeyHandle:   resolveRes.KeyHandle,
+		Algorithm:   algorithms.EDDSA_ED25519,
+		PayloadType: signpayloads.OPAQUE_TO_ED25519,
+		Payload:     ([]byte)("sign me"),
+	})
+	require.NoError(t, err)
+	assert.Len(t, signRes.Payload, 64)
+
+	signRes, err = sm.Sign(context.Background(), &prototk.SignWithKeyRequest{
+		KeyHandle:   resolveRes.KeyHandle,
+		Algorithm:   algorithms.ECDSA_SECP256R1,
+		PayloadType: signpayloads.OPAQUE_TO_RS,
+		Payload:     ([]byte)("sign me"),
+	})
+	require.NoError(t, err)
+	assert.Len(t, signRes.Payload, 64)
+
+}
+
 func TestResolveUnsupportedAlgo(t *testing.T) {
 
 	sm, err := NewSigningModule(context.Background(), &signerapi.ConfigNoExt{
@@ -37,6 +37,7 @@ type ExtensibleConfig interface {
 }
 
 type Extensions[C ExtensibleConfig] struct {
-	KeyStoreFactories       map[string]KeyStoreFactory[C]
-	InMemorySignerFactories map[string]InMemorySignerFactory[C]
+	KeyStoreFactories          map[string]KeyStoreFactory[C]
+	InMemorySignerFactories    map[string]InMemorySignerFactory[C]
+	MasterKeyProviderFactories map[string]MasterKeyProviderFactory[C]
 }
@@ -0,0 +1,44 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package signerapi
+
+import (
+	"context"
+)
+
+type MasterKeyProviderFactory[C ExtensibleConfig] interface {
+	NewMasterKeyProvider(ctx context.Context, conf C) (MasterKeyProvider, error)
+}
+
+// A master key provider performs envelope encryption of the secrets a key store writes to its
+// backing storage - such as the per-key passwords of the filesystem key store.
+//
+// The built-in providers load the master key material into memory, but an implementation might
+// equally delegate the wrap/unwrap operations to an external Key Management System (KMS) so that
+// the master key never leaves that system.
+//
+// Each wrapped secret is stored with the ID of the master key that wrapped it. This allows the
+// master key to be rotated, by unwrapping secrets with a previous master key and re-wrapping
+// them with the current master key.
+//
+// The additional authenticated data (AAD) passed to wrap must be passed unchanged to unwrap.
+// Key stores use it to bind each wrapped secret to where it is stored, such as the path of the
+// key file it protects, so a wrapped secret cannot be copied to protect a different key.
+type MasterKeyProvider interface {
+	CurrentKeyID() string
+	Wrap(ctx context.Context, plaintext, aad []byte) (keyID string, wrapped []byte, err error)
+	Unwrap(ctx context.Context, keyID string, wrapped, aad []byte) (plaintext []byte, err error)
+}
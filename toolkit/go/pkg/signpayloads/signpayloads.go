@@ -23,3 +23,17 @@ package signpayloads
 // according to the Bitcoin/Eth standard of 27+recid (27 or 28)
 // denoting an uncompressed public key.
 const OPAQUE_TO_RSV = "opaque:rsv"
+
+// Input:
+// An opaque payload goes into the signing module, which must be the digest to sign (such as a SHA-256 hash).
+// No validation, or other processing of the payload is performed before signing.
+// Output:
+// A 64 byte encoded R,S byte string (R=32b, S=32b) as used in JWS [RFC7518] ES256 signatures.
+const OPAQUE_TO_RS = "opaque:rs"
+
+// Input:
+// An opaque payload goes into the signing module. As per [RFC8032] the full message is signed,
+// with hashing performed as part of the Ed25519 signature algorithm.
+// Output:
+// A 64 byte Ed25519 signature.
+const OPAQUE_TO_ED25519 = "opaque:ed25519"
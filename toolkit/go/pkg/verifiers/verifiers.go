@@ -26,3 +26,16 @@ const HEX_ECDSA_PUBKEY_UNCOMPRESSED = "hex_ecdsa_pubkey_uncompressed"
 
 // ECDSA public key in uncompressed form hex encoded (x and y [FIPS186] in uncompressed form [X9.62] without leading 0x04 "uncompressed" constant prefix)
 const HEX_ECDSA_PUBKEY_UNCOMPRESSED_0X = "hex_ecdsa_pubkey_uncompressed_0x"
+
+// Ed25519 public key (32 bytes [RFC8032]) hex encoded
+const HEX_ED25519_PUBKEY = "hex_ed25519_pubkey"
+
+// Ed25519 public key (32 bytes [RFC8032]) hex encoded with 0x prefix
+const HEX_ED25519_PUBKEY_0X = "hex_ed25519_pubkey_0x"
+
+// A did:key DID [W3C did:key method] with the multicodec prefixed public key multibase (base58btc) encoded.
+// Public keys for ECDSA curves are in compressed form.
+const DID_KEY = "did_key"
+
+// A JSON Web Key [RFC7517] containing only the public key, with the required members in lexicographic order [RFC7638]
+const JWK = "jwk"
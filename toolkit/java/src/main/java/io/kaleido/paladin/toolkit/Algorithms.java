@@ -17,4 +17,8 @@ package io.kaleido.paladin.toolkit;
 
 public class Algorithms {
     public static final String ECDSA_SECP256K1 = "ecdsa:secp256k1";
+
+    public static final String ECDSA_SECP256R1 = "ecdsa:secp256r1";
+
+    public static final String EDDSA_ED25519 = "eddsa:ed25519";
 }
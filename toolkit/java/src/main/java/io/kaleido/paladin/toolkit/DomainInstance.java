@@ -45,6 +45,7 @@
      protected abstract CompletableFuture<ConfigurePrivacyGroupResponse> configurePrivacyGroup(ConfigurePrivacyGroupRequest request);
      protected abstract CompletableFuture<InitPrivacyGroupResponse> initPrivacyGroup(InitPrivacyGroupRequest request);
      protected abstract CompletableFuture<WrapPrivacyGroupEVMTXResponse> wrapPrivacyGroupTransaction(WrapPrivacyGroupEVMTXRequest request);
+     protected abstract CompletableFuture<UpdatePrivacyGroupMembersResponse> updatePrivacyGroupMembers(UpdatePrivacyGroupMembersRequest request);
 
      protected DomainInstance(String grpcTarget, String instanceId) {
          super(grpcTarget, instanceId);
@@ -123,6 +124,7 @@
                  case CONFIGURE_PRIVACY_GROUP -> configurePrivacyGroup(request.getConfigurePrivacyGroup()).thenApply(response::setConfigurePrivacyGroupRes);
                  case INIT_PRIVACY_GROUP -> initPrivacyGroup(request.getInitPrivacyGroup()).thenApply(response::setInitPrivacyGroupRes);
                  case WRAP_PRIVACY_GROUP_EVMTX -> wrapPrivacyGroupTransaction(request.getWrapPrivacyGroupEvmtx()).thenApply(response::setWrapPrivacyGroupEvmtxRes);
+                 case UPDATE_PRIVACY_GROUP_MEMBERS -> updatePrivacyGroupMembers(request.getUpdatePrivacyGroupMembers()).thenApply(response::setUpdatePrivacyGroupMembersRes);
                  default -> throw new IllegalArgumentException("unknown request: %s".formatted(request.getRequestToDomainCase()));
              };
              return resultApplied.thenApply((ra) -> {
@@ -17,6 +17,7 @@
 
  import java.util.UUID;
  import java.util.concurrent.CompletableFuture;
+ import java.util.concurrent.CompletionException;
  import java.util.concurrent.ExecutorService;
  import java.util.concurrent.Executors;
  import java.util.concurrent.TimeUnit;
@@ -191,6 +192,12 @@ import io.kaleido.paladin.logging.PaladinLogging;
          return null;
      }
  
+     private static Header.ErrorType errorTypeOf(Throwable t) {
+         // Async failures arrive wrapped, so check the cause for operations the plugin does not support
+         Throwable cause = (t instanceof CompletionException && t.getCause() != null) ? t.getCause() : t;
+         return (cause instanceof UnsupportedOperationException) ? Header.ErrorType.NOT_SUPPORTED : Header.ErrorType.UNKNOWN;
+     }
+ 
      private synchronized Void sendErrorReply(Header reqHeader, Throwable t) {
          Header resHeader = Header.newBuilder().
                  setPluginId(pluginId).
@@ -198,6 +205,7 @@ import io.kaleido.paladin.logging.PaladinLogging;
                  setCorrelationId(reqHeader.getMessageId()).
                  setMessageType(Header.MessageType.ERROR_RESPONSE).
                  setErrorMessage(t.getMessage()).
+                 setErrorType(errorTypeOf(t)).
                  build();
          LOGGER.error(new FormattedMessage("sending error reply {} to {}", resHeader.getMessageId(), reqHeader.getMessageId()), t);
          sendStream.onNext(buildMessage(resHeader));
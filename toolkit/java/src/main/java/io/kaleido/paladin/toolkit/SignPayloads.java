@@ -17,4 +17,8 @@ package io.kaleido.paladin.toolkit;
 
 public class SignPayloads {
     public static final String OPAQUE_TO_RSV = "opaque:rsv";
+
+    public static final String OPAQUE_TO_RS = "opaque:rs";
+
+    public static final String OPAQUE_TO_ED25519 = "opaque:ed25519";
 }
@@ -23,4 +23,12 @@ public class Verifiers {
     public static final String HEX_PUBKEY_NO_PREFIX = "hex_pubkey_no_prefix";
 
     public static final String HEX_PUBKEY_0X_PREFIX = "hex_pubkey_0x_prefix";
+
+    public static final String HEX_ED25519_PUBKEY = "hex_ed25519_pubkey";
+
+    public static final String HEX_ED25519_PUBKEY_0X = "hex_ed25519_pubkey_0x";
+
+    public static final String DID_KEY = "did_key";
+
+    public static final String JWK = "jwk";
 }
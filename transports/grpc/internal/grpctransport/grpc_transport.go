@@ -197,6 +197,7 @@ func (t *grpcTransport) ConnectSendStream(stream grpc.ClientStreamingServer[prot
 				Component:     prototk.PaladinMsg_Component(msg.Component),
 				MessageType:   msg.MessageType,
 				Payload:       msg.Payload,
+				Seal:          fromGRPCSeal(msg.Seal),
 			},
 		})
 		if err != nil {
@@ -283,6 +284,7 @@ func (t *grpcTransport) SendMessage(ctx context.Context, req *prototk.SendMessag
 		Component:     int32(msg.Component),
 		MessageType:   msg.MessageType,
 		Payload:       msg.Payload,
+		Seal:          toGRPCSeal(msg.Seal),
 	})
 	if err != nil {
 		return nil, err
@@ -290,6 +292,32 @@ func (t *grpcTransport) SendMessage(ctx context.Context, req *prototk.SendMessag
 	return &prototk.SendMessageResponse{}, nil
 }
 
+func toGRPCSeal(seal *prototk.PayloadSeal) *proto.PayloadSeal {
+	if seal == nil {
+		return nil
+	}
+	return &proto.PayloadSeal{
+		SenderKeyId:    seal.SenderKeyId,
+		RecipientKeyId: seal.RecipientKeyId,
+		EphemeralKey:   seal.EphemeralKey,
+		Nonce:          seal.Nonce,
+		Signature:      seal.Signature,
+	}
+}
+
+func fromGRPCSeal(seal *proto.PayloadSeal) *prototk.PayloadSeal {
+	if seal == nil {
+		return nil
+	}
+	return &prototk.PayloadSeal{
+		SenderKeyId:    seal.SenderKeyId,
+		RecipientKeyId: seal.RecipientKeyId,
+		EphemeralKey:   seal.EphemeralKey,
+		Nonce:          seal.Nonce,
+		Signature:      seal.Signature,
+	}
+}
+
 func (t *grpcTransport) GetLocalDetails(ctx context.Context, req *prototk.GetLocalDetailsRequest) (*prototk.GetLocalDetailsResponse, error) {
 
 	certList := t.localCertificate.Certificate
@@ -236,6 +236,44 @@ func TestGRPCTransport_DirectCertVerification_OK(t *testing.T) {
 
 }
 
+func TestGRPCTransport_SealedMessagePassThrough(t *testing.T) {
+	ctx := context.Background()
+
+	received := make(chan *prototk.PaladinMsg, 1)
+	plugin1, _, done := newSuccessfulVerifiedConnection(t, func(_, callbacks2 *testCallbacks) {
+		callbacks2.receiveMessage = func(ctx context.Context, rmr *prototk.ReceiveMessageRequest) (*prototk.ReceiveMessageResponse, error) {
+			received <- rmr.Message
+			return &prototk.ReceiveMessageResponse{}, nil
+		}
+	})
+	defer done()
+
+	seal := &prototk.PayloadSeal{
+		SenderKeyId:    "key1",
+		RecipientKeyId: "key2",
+		EphemeralKey:   []byte("ephemeral"),
+		Nonce:          []byte("nonce"),
+		Signature:      []byte("signature"),
+	}
+	_, err := plugin1.SendMessage(ctx, &prototk.SendMessageRequest{
+		Node: "node2",
+		Message: &prototk.PaladinMsg{
+			Component: prototk.PaladinMsg_TRANSACTION_ENGINE,
+			Payload:   []byte("encrypted"),
+			Seal:      seal,
+		},
+	})
+	require.NoError(t, err)
+
+	msg := <-received
+	assert.Equal(t, []byte("encrypted"), msg.Payload)
+	assert.Equal(t, seal.SenderKeyId, msg.Seal.SenderKeyId)
+	assert.Equal(t, seal.RecipientKeyId, msg.Seal.RecipientKeyId)
+	assert.Equal(t, seal.EphemeralKey, msg.Seal.EphemeralKey)
+	assert.Equal(t, seal.Nonce, msg.Seal.Nonce)
+	assert.Equal(t, seal.Signature, msg.Seal.Signature)
+}
+
 func TestGRPCTransport_DirectCertVerificationWithKeyRotation_OK(t *testing.T) {
 	ctx := context.Background()
 
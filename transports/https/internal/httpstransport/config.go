@@ -0,0 +1,105 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package httpstransport
+
+import (
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+type Config struct {
+	// The name of the local node - must match the name it is registered under, as it is used to identify
+	// the sender inside each encrypted envelope
+	NodeName *string `json:"nodeName"`
+	// PEM encoded P-256 private key for this node, used to encrypt/decrypt payloads end-to-end between
+	// nodes, and to authenticate to the mailbox on a relay. The public key is published in the registry.
+	PrivateKey *string `json:"privateKey,omitempty"`
+	// File containing the PEM encoded private key (alternative to privateKey)
+	PrivateKeyFile *string `json:"privateKeyFile,omitempty"`
+	// Optional HTTPS listener for direct inbound delivery (and for the relay mailbox if enabled).
+	// Nodes behind egress-only firewalls omit this, and configure a relay instead.
+	Server pldconf.HTTPServerConfig `json:"server"`
+	// The external URL that other nodes use to reach the server - defaults to https://address:port
+	ExternalURL *string `json:"externalURL,omitempty"`
+	// Outbound HTTPS client configuration used for delivery to peers (TLS CAs, timeouts etc.)
+	Client pldconf.HTTPClientConfig `json:"client"`
+	// A relay that holds a mailbox for this node, which is long-polled for inbound messages
+	Relay RelayConfig `json:"relay"`
+	// Run a store-and-forward mailbox for other nodes on the server of this node
+	Mailbox MailboxConfig `json:"mailbox"`
+	// Maximum size of an inbound message envelope
+	MaxMessageSize *string `json:"maxMessageSize,omitempty"`
+}
+
+type RelayConfig struct {
+	pldconf.HTTPClientConfig `json:",inline"`
+	// How long the relay holds each poll open waiting for messages
+	PollTimeout *string `json:"pollTimeout,omitempty"`
+	// Delay before polling again after a failure
+	RetryDelay *string `json:"retryDelay,omitempty"`
+	// How long to keep retrying a message from a node whose transport details cannot be looked up (for example because
+	// its registry entry is not yet indexed) before the message is discarded from the mailbox
+	LookupRetryTimeout *string `json:"lookupRetryTimeout,omitempty"`
+}
+
+type MailboxConfig struct {
+	Enabled bool `json:"enabled"`
+	// Maximum number of undelivered messages held for each node, before senders are pushed back
+	MaxQueueDepth *int `json:"maxQueueDepth,omitempty"`
+	// Undelivered messages are discarded after this time
+	MessageTTL *string `json:"messageTTL,omitempty"`
+	// Maximum number of mailboxes held across all nodes. Mailboxes are removed once they are empty
+	MaxMailboxes *int `json:"maxMailboxes,omitempty"`
+	// Maximum total size of the undelivered messages held across all mailboxes
+	MaxTotalSize *string `json:"maxTotalSize,omitempty"`
+	// The maximum poll timeout a node can request
+	MaxPollTimeout *string `json:"maxPollTimeout,omitempty"`
+	// Allowed difference between the timestamp in a signed poll request and the relay clock
+	MaxClockSkew *string `json:"maxClockSkew,omitempty"`
+}
+
+var ConfigDefaults = &Config{
+	MaxMessageSize: confutil.P("16Mb"),
+	Relay: RelayConfig{
+		PollTimeout:        confutil.P("20s"),
+		RetryDelay:         confutil.P("5s"),
+		LookupRetryTimeout: confutil.P("10m"),
+	},
+	Mailbox: MailboxDefaults,
+}
+
+var MailboxDefaults = MailboxConfig{
+	MaxQueueDepth:  confutil.P(1000),
+	MessageTTL:     confutil.P("24h"),
+	MaxMailboxes:   confutil.P(1000),
+	MaxTotalSize:   confutil.P("1Gb"),
+	MaxPollTimeout: confutil.P("60s"),
+	MaxClockSkew:   confutil.P("5m"),
+}
+
+// This is the JSON structure that any node in the network must share to be reachable
+// by this plugin. At least one of endpoint or relay must be set.
+type PublishedTransportDetails struct {
+	Endpoint  string            `json:"endpoint,omitempty"` // base URL for direct delivery to the node
+	Relay     string            `json:"relay,omitempty"`    // base URL of a relay holding a mailbox for the node
+	PublicKey pldtypes.HexBytes `json:"publicKey"`          // uncompressed P-256 public key of the node
+}
+
+type PeerInfo struct {
+	URL     string `json:"url"`
+	Relayed bool   `json:"relayed"`
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package httpstransport
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"os"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/transports/https/internal/msgs"
+	"golang.org/x/crypto/hkdf"
+	"google.golang.org/protobuf/proto"
+)
+
+// The envelope is the only thing visible to a relay (or any TLS terminating proxy) between
+// the two nodes. The message is encrypted with an AES-256-GCM key derived from a static-static
+// ECDH agreement between the sender and recipient keys published in the registry, so a
+// successful decrypt also authenticates the sender.
+type envelope struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+const envelopeKDFInfo = "paladin-https-transport"
+
+func loadPrivateKey(ctx context.Context, conf *Config) (*ecdsa.PrivateKey, error) {
+	var pemBytes []byte
+	switch {
+	case conf.PrivateKey != nil && *conf.PrivateKey != "":
+		pemBytes = []byte(*conf.PrivateKey)
+	case conf.PrivateKeyFile != nil && *conf.PrivateKeyFile != "":
+		b, err := os.ReadFile(*conf.PrivateKeyFile)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, msgs.MsgPrivateKeyInvalid)
+		}
+		pemBytes = b
+	default:
+		return nil, i18n.NewError(ctx, msgs.MsgPrivateKeyRequired)
+	}
+
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgPrivateKeyInvalid)
+	}
+	var key any
+	var err error
+	if block.Type == "EC PRIVATE KEY" {
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	} else {
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgPrivateKeyInvalid)
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok || ecKey.Curve != elliptic.P256() {
+		return nil, i18n.NewError(ctx, msgs.MsgPrivateKeyInvalid)
+	}
+	return ecKey, nil
+}
+
+// Public keys are exchanged as PKIX DER, as that lets us use the same key for ECDSA and ECDH
+func parsePublicKey(der []byte) (*ecdsa.PublicKey, bool) {
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, false
+	}
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok || ecKey.Curve != elliptic.P256() {
+		return nil, false
+	}
+	return ecKey, true
+}
+
+func marshalPublicKey(key *ecdsa.PublicKey) []byte {
+	der, _ := x509.MarshalPKIXPublicKey(key)
+	return der
+}
+
+// The mailbox on a relay is identified by the hash of the public key of the recipient,
+// so the relay can authenticate polls without needing access to the registry
+func mailboxID(publicKey []byte) string {
+	hash := sha256.Sum256(publicKey)
+	return hex.EncodeToString(hash[:])
+}
+
+func envelopeCipher(localKey *ecdsa.PrivateKey, peerKey *ecdsa.PublicKey, salt []byte, from, to string) (cipher.AEAD, error) {
+	// Both keys have been validated as P-256 keys on load, so the conversions cannot fail
+	localECDH, _ := localKey.ECDH()
+	peerECDH, _ := peerKey.ECDH()
+	shared, err := localECDH.ECDH(peerECDH)
+	if err != nil {
+		return nil, err
+	}
+	aesKey := make([]byte, 32)
+	kdf := hkdf.New(sha256.New, shared, salt, []byte(envelopeKDFInfo+"/"+from+"/"+to))
+	_, _ = io.ReadFull(kdf, aesKey) // well within the HKDF-SHA256 output limit
+	block, _ := aes.NewCipher(aesKey)
+	return cipher.NewGCM(block)
+}
+
+func sealEnvelope(localKey *ecdsa.PrivateKey, peerKey *ecdsa.PublicKey, from, to string, msg *prototk.PaladinMsg) (*envelope, error) {
+	plaintext, _ := proto.Marshal(msg)
+	env := &envelope{
+		From:  from,
+		To:    to,
+		Salt:  make([]byte, 32),
+		Nonce: make([]byte, 12),
+	}
+	_, _ = rand.Read(env.Salt)
+	_, _ = rand.Read(env.Nonce)
+	aead, err := envelopeCipher(localKey, peerKey, env.Salt, from, to)
+	if err != nil {
+		return nil, err
+	}
+	env.Data = aead.Seal(nil, env.Nonce, plaintext, nil)
+	return env, nil
+}
+
+func openEnvelope(ctx context.Context, localKey *ecdsa.PrivateKey, peerKey *ecdsa.PublicKey, env *envelope) (*prototk.PaladinMsg, error) {
+	aead, err := envelopeCipher(localKey, peerKey, env.Salt, env.From, env.To)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgEnvelopeDecryptFailed, env.From)
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, i18n.NewError(ctx, msgs.MsgEnvelopeDecryptFailed, env.From)
+	}
+	plaintext, err := aead.Open(nil, env.Nonce, env.Data, nil)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgEnvelopeDecryptFailed, env.From)
+	}
+	var msg prototk.PaladinMsg
+	if err := proto.Unmarshal(plaintext, &msg); err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgEnvelopeInvalid)
+	}
+	return &msg, nil
+}
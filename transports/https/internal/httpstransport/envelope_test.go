@@ -0,0 +1,112 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package httpstransport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildTestCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-1 * time.Hour),
+		NotAfter:              time.Now().Add(1 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pemString("CERTIFICATE", certDER), pemString("EC PRIVATE KEY", keyDER)
+}
+
+func TestLoadPrivateKeyWrongCurve(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	_, err = loadPrivateKey(context.Background(), &Config{PrivateKey: confutil.P(pemString("EC PRIVATE KEY", der))})
+	assert.Regexp(t, "PD090004", err)
+}
+
+func TestParsePublicKeyWrongType(t *testing.T) {
+	_, ok := parsePublicKey([]byte("wrong"))
+	assert.False(t, ok)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	_, ok = parsePublicKey(der)
+	assert.False(t, ok)
+}
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	key1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	env, err := sealEnvelope(key1, &key2.PublicKey, "node1", "node2", &prototk.PaladinMsg{MessageType: "ping"})
+	require.NoError(t, err)
+
+	msg, err := openEnvelope(context.Background(), key2, &key1.PublicKey, env)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", msg.MessageType)
+
+	// The names are bound into the key, so a relay cannot re-address the envelope
+	env.From = "node3"
+	_, err = openEnvelope(context.Background(), key2, &key1.PublicKey, env)
+	assert.Regexp(t, "PD090012", err)
+}
+
+func TestOpenEnvelopeBadPayload(t *testing.T) {
+	key1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	env := &envelope{From: "node1", To: "node2", Salt: []byte("salt"), Nonce: make([]byte, 12)}
+	aead, err := envelopeCipher(key1, &key2.PublicKey, env.Salt, env.From, env.To)
+	require.NoError(t, err)
+	env.Data = aead.Seal(nil, env.Nonce, []byte{0xff, 0xff, 0xff}, nil)
+
+	_, err = openEnvelope(context.Background(), key2, &key1.PublicKey, env)
+	assert.Regexp(t, "PD090010", err)
+}
@@ -0,0 +1,319 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package httpstransport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/httpserver"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/transports/https/internal/msgs"
+)
+
+type httpsTransport struct {
+	bgCtx     context.Context
+	callbacks plugintk.TransportCallbacks
+
+	name           string
+	conf           Config
+	nodeName       string
+	privateKey     *ecdsa.PrivateKey
+	publicKey      pldtypes.HexBytes
+	maxMessageSize int64
+
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+	server    httpserver.Server
+	mailboxes *mailboxes
+	poller    *relayPoller
+
+	peersLock sync.RWMutex
+	peers     map[string]*peer
+}
+
+func NewPlugin(ctx context.Context) plugintk.PluginBase {
+	return plugintk.NewTransport(NewHTTPSTransport)
+}
+
+func NewHTTPSTransport(callbacks plugintk.TransportCallbacks) plugintk.TransportAPI {
+	return &httpsTransport{
+		bgCtx:     context.Background(),
+		callbacks: callbacks,
+		peers:     make(map[string]*peer),
+	}
+}
+
+func (t *httpsTransport) ConfigureTransport(ctx context.Context, req *prototk.ConfigureTransportRequest) (*prototk.ConfigureTransportResponse, error) {
+	// Hold the peers lock while setting our state (as we'll read it when activating peers)
+	t.peersLock.Lock()
+	defer t.peersLock.Unlock()
+
+	// Configuration can be re-applied, so stop anything we started last time
+	t.stop()
+
+	t.name = req.Name
+	t.conf = Config{}
+	err := json.Unmarshal([]byte(req.ConfigJson), &t.conf)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgInvalidTransportConfig)
+	}
+
+	t.nodeName = confutil.StringOrEmpty(t.conf.NodeName, "")
+	if t.nodeName == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgNodeNameRequired)
+	}
+	if t.conf.Server.Port == nil && t.conf.Relay.URL == "" {
+		return nil, i18n.NewError(ctx, msgs.MsgServerOrRelayRequired)
+	}
+	if t.privateKey, err = loadPrivateKey(ctx, &t.conf); err != nil {
+		return nil, err
+	}
+	t.publicKey = marshalPublicKey(&t.privateKey.PublicKey)
+	t.maxMessageSize = confutil.ByteSize(t.conf.MaxMessageSize, 1024, *ConfigDefaults.MaxMessageSize)
+
+	t.ctx, t.cancelCtx = context.WithCancel(log.WithLogField(t.bgCtx, "transport", t.name))
+
+	if t.conf.Server.Port != nil {
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /messages", t.handleMessage)
+		if t.conf.Mailbox.Enabled {
+			t.mailboxes = newMailboxes(t.ctx, &t.conf.Mailbox, t.maxMessageSize)
+			t.mailboxes.register(mux)
+		}
+		if t.server, err = httpserver.NewServer(t.ctx, "HTTPS transport", &t.conf.Server, mux); err != nil {
+			return nil, err
+		}
+		_ = t.server.Start()
+	}
+
+	if t.conf.Relay.URL != "" {
+		if t.poller, err = t.newRelayPoller(ctx); err != nil {
+			t.stop()
+			return nil, err
+		}
+		go t.poller.run(t.ctx)
+	}
+
+	return &prototk.ConfigureTransportResponse{}, nil
+}
+
+func (t *httpsTransport) stop() {
+	if t.cancelCtx != nil {
+		t.cancelCtx()
+	}
+	if t.poller != nil {
+		<-t.poller.done
+		t.poller = nil
+	}
+	if t.server != nil {
+		t.server.Stop()
+		t.server = nil
+	}
+	t.mailboxes = nil
+}
+
+func (t *httpsTransport) parseTransportDetails(ctx context.Context, node, transportDetailsJSON string) (*PublishedTransportDetails, *ecdsa.PublicKey, error) {
+	var transportDetails PublishedTransportDetails
+	var publicKey *ecdsa.PublicKey
+	ok := false
+	err := json.Unmarshal([]byte(transportDetailsJSON), &transportDetails)
+	if err == nil {
+		publicKey, ok = parsePublicKey(transportDetails.PublicKey)
+	}
+	if !ok {
+		return nil, nil, i18n.WrapError(ctx, err, msgs.MsgPeerTransportDetailsInvalid, node)
+	}
+	return &transportDetails, publicKey, nil
+}
+
+func (t *httpsTransport) getPeerPublicKey(ctx context.Context, node string) (*ecdsa.PublicKey, error) {
+	gtdr, err := t.callbacks.GetTransportDetails(ctx, &prototk.GetTransportDetailsRequest{
+		Node: node,
+	})
+	if err != nil {
+		log.L(ctx).Errorf("lookup failed for node %s: %s", node, err)
+		return nil, &peerLookupError{i18n.WrapError(ctx, err, msgs.MsgPeerLookupFailed, node).(i18n.PDError)}
+	}
+	_, publicKey, err := t.parseTransportDetails(ctx, node, gtdr.TransportDetails)
+	return publicKey, err
+}
+
+// A failure to look up the details of the sending node might be transient - such as when the registry entry
+// of the sender is not yet indexed - so the message is retried rather than discarded
+type peerLookupError struct {
+	i18n.PDError
+}
+
+// Checks and decrypts a received envelope. Errors here (other than a peerLookupError) mean the envelope
+// can never be delivered, so they are reported back to the sender (or discarded from the mailbox)
+func (t *httpsTransport) openReceived(ctx context.Context, data []byte) (string, *prototk.PaladinMsg, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return "", nil, i18n.WrapError(ctx, err, msgs.MsgEnvelopeInvalid)
+	}
+	if env.To != t.nodeName {
+		return "", nil, i18n.NewError(ctx, msgs.MsgEnvelopeWrongRecipient, env.From, env.To)
+	}
+	peerKey, err := t.getPeerPublicKey(ctx, env.From)
+	if err != nil {
+		return "", nil, err
+	}
+	msg, err := openEnvelope(ctx, t.privateKey, peerKey, &env)
+	if err != nil {
+		return "", nil, err
+	}
+	return env.From, msg, nil
+}
+
+func (t *httpsTransport) receive(ctx context.Context, fromNode string, msg *prototk.PaladinMsg) error {
+	log.L(ctx).Infof("HTTPS received message id=%s cid=%v component=%s messageType=%s from peer %s",
+		msg.MessageId, msg.CorrelationId, msg.Component, msg.MessageType, fromNode)
+
+	_, err := t.callbacks.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{
+		FromNode: fromNode,
+		Message:  msg,
+	})
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgReceiveFailed, fromNode)
+	}
+	return nil
+}
+
+// Direct delivery of a message from a peer to this node
+func (t *httpsTransport) handleMessage(res http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	var fromNode string
+	var msg *prototk.PaladinMsg
+	data, err := readBody(ctx, res, req, t.maxMessageSize)
+	if err == nil {
+		fromNode, msg, err = t.openReceived(ctx, data)
+	}
+	if err == nil {
+		err = t.receive(ctx, fromNode, msg)
+	}
+	if err != nil {
+		writeError(ctx, res, err)
+		return
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
+
+func (t *httpsTransport) ActivatePeer(ctx context.Context, req *prototk.ActivatePeerRequest) (*prototk.ActivatePeerResponse, error) {
+	t.peersLock.Lock()
+	defer t.peersLock.Unlock()
+
+	if t.peers[req.NodeName] != nil {
+		// Replace an existing activation - unexpected as Paladin shouldn't do this
+		log.L(ctx).Warnf("replacing existing activation for node '%s'", req.NodeName)
+		delete(t.peers, req.NodeName)
+	}
+	p, err := t.newPeer(ctx, req.NodeName, req.TransportDetails)
+	if err != nil {
+		return nil, err
+	}
+	t.peers[req.NodeName] = p
+	peerInfoJSON, _ := json.Marshal(&p.info)
+	return &prototk.ActivatePeerResponse{
+		PeerInfoJson: string(peerInfoJSON),
+	}, nil
+}
+
+func (t *httpsTransport) DeactivatePeer(ctx context.Context, req *prototk.DeactivatePeerRequest) (*prototk.DeactivatePeerResponse, error) {
+	t.peersLock.Lock()
+	defer t.peersLock.Unlock()
+
+	delete(t.peers, req.NodeName)
+	return &prototk.DeactivatePeerResponse{}, nil
+}
+
+func (t *httpsTransport) getPeer(nodeName string) *peer {
+	t.peersLock.RLock()
+	defer t.peersLock.RUnlock()
+
+	return t.peers[nodeName]
+}
+
+func (t *httpsTransport) SendMessage(ctx context.Context, req *prototk.SendMessageRequest) (*prototk.SendMessageResponse, error) {
+	msg := req.Message
+	p := t.getPeer(req.Node)
+	if p == nil {
+		// This is an error in the Paladin layer
+		return nil, i18n.NewError(ctx, msgs.MsgNodeNotActive, req.Node)
+	}
+	log.L(ctx).Infof("HTTPS sending message id=%s cid=%v component=%s messageType=%s to peer %s (relayed=%t)",
+		msg.MessageId, msg.CorrelationId, msg.Component, msg.MessageType, req.Node, p.info.Relayed)
+	if err := p.send(ctx, t.privateKey, t.nodeName, msg); err != nil {
+		return nil, err
+	}
+	return &prototk.SendMessageResponse{}, nil
+}
+
+func (t *httpsTransport) GetLocalDetails(ctx context.Context, req *prototk.GetLocalDetailsRequest) (*prototk.GetLocalDetailsResponse, error) {
+	localDetails := &PublishedTransportDetails{
+		Relay:     t.conf.Relay.URL,
+		PublicKey: t.publicKey,
+	}
+	if t.server != nil {
+		scheme := "http"
+		if t.conf.Server.TLS.Enabled {
+			scheme = "https"
+		}
+		localDetails.Endpoint = confutil.StringNotEmpty(t.conf.ExternalURL, fmt.Sprintf("%s://%s", scheme, t.server.Addr()))
+	}
+	jsonDetails, _ := json.Marshal(&localDetails)
+
+	return &prototk.GetLocalDetailsResponse{
+		TransportDetails: string(jsonDetails),
+	}, nil
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func readBody(ctx context.Context, res http.ResponseWriter, req *http.Request, maxSize int64) ([]byte, error) {
+	data, err := io.ReadAll(http.MaxBytesReader(res, req.Body, maxSize))
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgEnvelopeInvalid)
+	}
+	return data, nil
+}
+
+func writeJSON(res http.ResponseWriter, status int, body any) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(status)
+	_ = json.NewEncoder(res).Encode(body)
+}
+
+func writeError(ctx context.Context, res http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if pde, ok := err.(i18n.PDError); ok {
+		status = pde.HTTPStatus()
+	}
+	log.L(ctx).Errorf("HTTPS transport request failed [%d]: %s", status, err)
+	writeJSON(res, status, &errorResponse{Error: err.Error()})
+}
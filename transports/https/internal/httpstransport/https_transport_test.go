@@ -0,0 +1,596 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package httpstransport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCallbacks struct {
+	getTransportDetails func(context.Context, *prototk.GetTransportDetailsRequest) (*prototk.GetTransportDetailsResponse, error)
+	receiveMessage      func(context.Context, *prototk.ReceiveMessageRequest) (*prototk.ReceiveMessageResponse, error)
+}
+
+func (tc *testCallbacks) GetTransportDetails(ctx context.Context, req *prototk.GetTransportDetailsRequest) (*prototk.GetTransportDetailsResponse, error) {
+	return tc.getTransportDetails(ctx, req)
+}
+
+func (tc *testCallbacks) ReceiveMessage(ctx context.Context, req *prototk.ReceiveMessageRequest) (*prototk.ReceiveMessageResponse, error) {
+	return tc.receiveMessage(ctx, req)
+}
+
+func pemString(pemType string, der []byte) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: der}))
+}
+
+func buildTestKeyPEM(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pemString("EC PRIVATE KEY", der)
+}
+
+func relayClientConfig(url string) RelayConfig {
+	return RelayConfig{HTTPClientConfig: pldconf.HTTPClientConfig{URL: url}}
+}
+
+func newTestRelay(t *testing.T, conf *RelayServerConfig) (Relay, func()) {
+	conf.Server.Address = confutil.P("127.0.0.1")
+	conf.Server.Port = confutil.P(0)
+	r, err := NewRelay(context.Background(), conf)
+	require.NoError(t, err)
+	require.NoError(t, r.Start())
+	return r, r.Stop
+}
+
+func newTestHTTPSTransport(t *testing.T, nodeName string, conf *Config) (*httpsTransport, *testCallbacks, func()) {
+	conf.NodeName = &nodeName
+	if conf.PrivateKey == nil && conf.PrivateKeyFile == nil {
+		conf.PrivateKey = confutil.P(buildTestKeyPEM(t))
+	}
+	if conf.Relay.URL == "" && conf.Server.Port == nil {
+		conf.Server.Address = confutil.P("127.0.0.1")
+		conf.Server.Port = confutil.P(0)
+	}
+	if conf.Relay.RetryDelay == nil {
+		conf.Relay.RetryDelay = confutil.P("10ms")
+	}
+	jsonConf, err := json.Marshal(conf)
+	require.NoError(t, err)
+
+	callbacks := &testCallbacks{
+		receiveMessage: func(ctx context.Context, rmr *prototk.ReceiveMessageRequest) (*prototk.ReceiveMessageResponse, error) {
+			return &prototk.ReceiveMessageResponse{}, nil
+		},
+	}
+	transport := NewHTTPSTransport(callbacks).(*httpsTransport)
+	_, err = transport.ConfigureTransport(transport.bgCtx, &prototk.ConfigureTransportRequest{
+		Name:       "https",
+		ConfigJson: string(jsonConf),
+	})
+	require.NoError(t, err)
+
+	return transport, callbacks, transport.stop
+}
+
+func mockRegistry(cb *testCallbacks, transports map[string]*httpsTransport) {
+	cb.getTransportDetails = func(ctx context.Context, req *prototk.GetTransportDetailsRequest) (*prototk.GetTransportDetailsResponse, error) {
+		transport := transports[req.Node]
+		if transport == nil {
+			return nil, fmt.Errorf("not found")
+		}
+		ld, _ := transport.GetLocalDetails(ctx, &prototk.GetLocalDetailsRequest{})
+		return &prototk.GetTransportDetailsResponse{TransportDetails: ld.TransportDetails}, nil
+	}
+}
+
+func testActivatePeer(t *testing.T, sender, receiver *httpsTransport) *PeerInfo {
+	ld, err := receiver.GetLocalDetails(context.Background(), &prototk.GetLocalDetailsRequest{})
+	require.NoError(t, err)
+	res, err := sender.ActivatePeer(context.Background(), &prototk.ActivatePeerRequest{
+		NodeName:         receiver.nodeName,
+		TransportDetails: ld.TransportDetails,
+	})
+	require.NoError(t, err)
+	var peerInfo PeerInfo
+	err = json.Unmarshal([]byte(res.PeerInfoJson), &peerInfo)
+	require.NoError(t, err)
+	return &peerInfo
+}
+
+func captureReceived(cb *testCallbacks) chan *prototk.ReceiveMessageRequest {
+	received := make(chan *prototk.ReceiveMessageRequest, 10)
+	cb.receiveMessage = func(ctx context.Context, rmr *prototk.ReceiveMessageRequest) (*prototk.ReceiveMessageResponse, error) {
+		received <- rmr
+		return &prototk.ReceiveMessageResponse{}, nil
+	}
+	return received
+}
+
+func testSend(t *testing.T, sender *httpsTransport, node string, messageType string) error {
+	_, err := sender.SendMessage(context.Background(), &prototk.SendMessageRequest{
+		Node: node,
+		Message: &prototk.PaladinMsg{
+			MessageId:     "msg-" + messageType,
+			CorrelationId: confutil.P("corr-" + messageType),
+			Component:     prototk.PaladinMsg_TRANSACTION_ENGINE,
+			MessageType:   messageType,
+			Payload:       []byte(`{"some":"data"}`),
+		},
+	})
+	return err
+}
+
+func testReceive(t *testing.T, received chan *prototk.ReceiveMessageRequest, fromNode string, messageType string) {
+	select {
+	case rmr := <-received:
+		assert.Equal(t, fromNode, rmr.FromNode)
+		assert.Equal(t, "msg-"+messageType, rmr.Message.MessageId)
+		assert.Equal(t, "corr-"+messageType, *rmr.Message.CorrelationId)
+		assert.Equal(t, prototk.PaladinMsg_TRANSACTION_ENGINE, rmr.Message.Component)
+		assert.Equal(t, messageType, rmr.Message.MessageType)
+		assert.JSONEq(t, `{"some":"data"}`, string(rmr.Message.Payload))
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for message")
+	}
+}
+
+func TestPluginLifecycle(t *testing.T) {
+	pb := NewPlugin(context.Background())
+	assert.NotNil(t, pb)
+}
+
+func TestConfigErrors(t *testing.T) {
+	keyPEM := buildTestKeyPEM(t)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	rsaKeyDER, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		conf  string
+		error string
+	}{
+		{conf: `{!!!!`, error: "PD090000"},
+		{conf: `{}`, error: "PD090001"},
+		{conf: `{"nodeName": "node1"}`, error: "PD090002"},
+		{conf: `{"nodeName": "node1", "server": {"port": 0}}`, error: "PD090003"},
+		{conf: `{"nodeName": "node1", "server": {"port": 0}, "privateKey": "not PEM"}`, error: "PD090004"},
+		{conf: `{"nodeName": "node1", "server": {"port": 0}, "privateKeyFile": "` + t.TempDir() + `"}`, error: "PD090004"},
+		{conf: `{"nodeName": "node1", "server": {"port": 0}, "privateKey": ` + pldtypes.JSONString(pemString("EC PRIVATE KEY", []byte("wrong"))).String() + `}`, error: "PD090004"},
+		{conf: `{"nodeName": "node1", "server": {"port": 0}, "privateKey": ` + pldtypes.JSONString(pemString("PRIVATE KEY", rsaKeyDER)).String() + `}`, error: "PD090004"},
+		{conf: `{"nodeName": "node1", "server": {"port": 0, "address": "::::::"}, "privateKey": ` + pldtypes.JSONString(keyPEM).String() + `}`, error: "PD020600"},
+		{conf: `{"nodeName": "node1", "relay": {"url": "wrong://"}, "privateKey": ` + pldtypes.JSONString(keyPEM).String() + `}`, error: "PD020501"},
+	} {
+		transport := NewHTTPSTransport(&testCallbacks{}).(*httpsTransport)
+		_, err := transport.ConfigureTransport(transport.bgCtx, &prototk.ConfigureTransportRequest{
+			Name:       "https",
+			ConfigJson: tc.conf,
+		})
+		assert.Regexp(t, tc.error, err, tc.conf)
+		transport.stop()
+	}
+}
+
+func TestPrivateKeyFile(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyFile := t.TempDir() + "/key.pem"
+	require.NoError(t, os.WriteFile(keyFile, []byte(pemString("PRIVATE KEY", der)), 0644))
+
+	transport, _, done := newTestHTTPSTransport(t, "node1", &Config{PrivateKeyFile: &keyFile})
+	defer done()
+	assert.True(t, key.PublicKey.Equal(&transport.privateKey.PublicKey))
+}
+
+func TestDirectSendReceive(t *testing.T) {
+	node1, callbacks1, done1 := newTestHTTPSTransport(t, "node1", &Config{})
+	defer done1()
+	node2, callbacks2, done2 := newTestHTTPSTransport(t, "node2", &Config{})
+	defer done2()
+
+	transports := map[string]*httpsTransport{"node1": node1, "node2": node2}
+	mockRegistry(callbacks1, transports)
+	mockRegistry(callbacks2, transports)
+	received1 := captureReceived(callbacks1)
+	received2 := captureReceived(callbacks2)
+
+	peerInfo := testActivatePeer(t, node1, node2)
+	assert.False(t, peerInfo.Relayed)
+	assert.Equal(t, fmt.Sprintf("http://%s/messages", node2.server.Addr()), peerInfo.URL)
+	testActivatePeer(t, node2, node1)
+
+	require.NoError(t, testSend(t, node1, "node2", "ping"))
+	testReceive(t, received2, "node1", "ping")
+	require.NoError(t, testSend(t, node2, "node1", "pong"))
+	testReceive(t, received1, "node2", "pong")
+
+	_, err := node1.DeactivatePeer(context.Background(), &prototk.DeactivatePeerRequest{NodeName: "node2"})
+	require.NoError(t, err)
+	err = testSend(t, node1, "node2", "ping")
+	assert.Regexp(t, "PD090007", err)
+}
+
+func TestRelayedSendReceive(t *testing.T) {
+	relay, relayDone := newTestRelay(t, &RelayServerConfig{})
+	defer relayDone()
+
+	// Both nodes are behind egress-only firewalls, so have no server
+	node1, callbacks1, done1 := newTestHTTPSTransport(t, "node1", &Config{Relay: relayClientConfig(relay.URL())})
+	defer done1()
+	node2, callbacks2, done2 := newTestHTTPSTransport(t, "node2", &Config{Relay: relayClientConfig(relay.URL())})
+	defer done2()
+	assert.Nil(t, node1.server)
+
+	transports := map[string]*httpsTransport{"node1": node1, "node2": node2}
+	mockRegistry(callbacks1, transports)
+	mockRegistry(callbacks2, transports)
+	received1 := captureReceived(callbacks1)
+	received2 := captureReceived(callbacks2)
+
+	peerInfo := testActivatePeer(t, node1, node2)
+	assert.True(t, peerInfo.Relayed)
+	assert.Equal(t, relay.URL()+"/mailbox/"+mailboxID(node2.publicKey), peerInfo.URL)
+	testActivatePeer(t, node2, node1)
+
+	require.NoError(t, testSend(t, node1, "node2", "ping"))
+	testReceive(t, received2, "node1", "ping")
+	require.NoError(t, testSend(t, node2, "node1", "pong"))
+	testReceive(t, received1, "node2", "pong")
+}
+
+func TestNodeRunsMailboxForPeer(t *testing.T) {
+	// node1 is publicly reachable and hosts a mailbox for node2, which can only make outbound connections
+	node1, callbacks1, done1 := newTestHTTPSTransport(t, "node1", &Config{Mailbox: MailboxConfig{Enabled: true}})
+	defer done1()
+	node1URL := fmt.Sprintf("http://%s", node1.server.Addr())
+	node2, callbacks2, done2 := newTestHTTPSTransport(t, "node2", &Config{Relay: relayClientConfig(node1URL)})
+	defer done2()
+
+	transports := map[string]*httpsTransport{"node1": node1, "node2": node2}
+	mockRegistry(callbacks1, transports)
+	mockRegistry(callbacks2, transports)
+	received1 := captureReceived(callbacks1)
+	received2 := captureReceived(callbacks2)
+
+	assert.True(t, testActivatePeer(t, node1, node2).Relayed)
+	assert.False(t, testActivatePeer(t, node2, node1).Relayed)
+
+	require.NoError(t, testSend(t, node1, "node2", "ping"))
+	testReceive(t, received2, "node1", "ping")
+	require.NoError(t, testSend(t, node2, "node1", "pong"))
+	testReceive(t, received1, "node2", "pong")
+}
+
+func TestRelayRedeliversAfterReceiveFailure(t *testing.T) {
+	relay, relayDone := newTestRelay(t, &RelayServerConfig{})
+	defer relayDone()
+
+	node1, callbacks1, done1 := newTestHTTPSTransport(t, "node1", &Config{})
+	defer done1()
+	node2, callbacks2, done2 := newTestHTTPSTransport(t, "node2", &Config{Relay: relayClientConfig(relay.URL())})
+	defer done2()
+
+	transports := map[string]*httpsTransport{"node1": node1, "node2": node2}
+	mockRegistry(callbacks1, transports)
+	mockRegistry(callbacks2, transports)
+
+	received := make(chan string, 10)
+	failed := false
+	callbacks2.receiveMessage = func(ctx context.Context, rmr *prototk.ReceiveMessageRequest) (*prototk.ReceiveMessageResponse, error) {
+		if !failed {
+			failed = true
+			return nil, fmt.Errorf("pop")
+		}
+		received <- rmr.Message.MessageType
+		return &prototk.ReceiveMessageResponse{}, nil
+	}
+
+	// Post an undeliverable message into the mailbox first, which is discarded
+	res, err := resty.New().R().SetBody(`{"from":"node1","to":"node3"}`).Post(relay.URL() + "/mailbox/" + mailboxID(node2.publicKey))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode())
+
+	testActivatePeer(t, node1, node2)
+	require.NoError(t, testSend(t, node1, "node2", "ping"))
+	require.NoError(t, testSend(t, node1, "node2", "pong"))
+
+	// The first message is redelivered after the failure, and the order preserved
+	assert.Equal(t, "ping", <-received)
+	assert.Equal(t, "pong", <-received)
+	assert.True(t, failed)
+}
+
+func TestRelayRetriesPeerLookupFailure(t *testing.T) {
+	relay, relayDone := newTestRelay(t, &RelayServerConfig{})
+	defer relayDone()
+
+	node1, callbacks1, done1 := newTestHTTPSTransport(t, "node1", &Config{})
+	defer done1()
+	relayConf := relayClientConfig(relay.URL())
+	relayConf.RetryDelay = confutil.P("1ms")
+	node2, callbacks2, done2 := newTestHTTPSTransport(t, "node2", &Config{Relay: relayConf})
+	defer done2()
+
+	transports := map[string]*httpsTransport{"node1": node1, "node2": node2}
+	mockRegistry(callbacks1, transports)
+	received := captureReceived(callbacks2)
+
+	// node2 cannot look up node1 until its registry entry is "indexed"
+	lookups := make(chan bool, 1)
+	callbacks2.getTransportDetails = func(ctx context.Context, req *prototk.GetTransportDetailsRequest) (*prototk.GetTransportDetailsResponse, error) {
+		select {
+		case lookups <- true:
+			return nil, fmt.Errorf("not found")
+		default:
+		}
+		ld, _ := node1.GetLocalDetails(ctx, &prototk.GetLocalDetailsRequest{})
+		return &prototk.GetTransportDetailsResponse{TransportDetails: ld.TransportDetails}, nil
+	}
+
+	testActivatePeer(t, node1, node2)
+	require.NoError(t, testSend(t, node1, "node2", "ping"))
+
+	// The message is not discarded, and is delivered once the lookup succeeds
+	testReceive(t, received, "node1", "ping")
+	assert.Len(t, lookups, 1)
+}
+
+func TestRelayDiscardsAfterLookupRetryTimeout(t *testing.T) {
+	relay, relayDone := newTestRelay(t, &RelayServerConfig{})
+	defer relayDone()
+
+	node1, callbacks1, done1 := newTestHTTPSTransport(t, "node1", &Config{})
+	defer done1()
+	relayConf := relayClientConfig(relay.URL())
+	relayConf.LookupRetryTimeout = confutil.P("0")
+	node2, callbacks2, done2 := newTestHTTPSTransport(t, "node2", &Config{Relay: relayConf})
+	defer done2()
+
+	transports := map[string]*httpsTransport{"node1": node1, "node2": node2}
+	mockRegistry(callbacks1, transports)
+	mockRegistry(callbacks2, transports)
+	received := captureReceived(callbacks2)
+
+	// A message from a node that will never be in the registry does not block the mailbox
+	res, err := resty.New().R().SetBody(`{"from":"node4","to":"node2"}`).Post(relay.URL() + "/mailbox/" + mailboxID(node2.publicKey))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode())
+
+	testActivatePeer(t, node1, node2)
+	require.NoError(t, testSend(t, node1, "node2", "ping"))
+	testReceive(t, received, "node1", "ping")
+}
+
+func TestRelayRestartResetsCursor(t *testing.T) {
+	testRelay, relayDone := newTestRelay(t, &RelayServerConfig{})
+	defer relayDone()
+
+	node1, callbacks1, done1 := newTestHTTPSTransport(t, "node1", &Config{})
+	defer done1()
+	node2, callbacks2, done2 := newTestHTTPSTransport(t, "node2", &Config{Relay: relayClientConfig(testRelay.URL())})
+	defer done2()
+
+	transports := map[string]*httpsTransport{"node1": node1, "node2": node2}
+	mockRegistry(callbacks1, transports)
+	mockRegistry(callbacks2, transports)
+	received2 := captureReceived(callbacks2)
+
+	testActivatePeer(t, node1, node2)
+	require.NoError(t, testSend(t, node1, "node2", "ping"))
+	testReceive(t, received2, "node1", "ping")
+
+	// Simulate a restart of the relay by swapping the instance, and resetting the sequences
+	mb := testRelay.(*relay).mailboxes
+	mb.lock.Lock()
+	mb.instance = "restarted"
+	mb.boxes = map[string]*mailbox{}
+	mb.lock.Unlock()
+
+	require.NoError(t, testSend(t, node1, "node2", "pong"))
+	testReceive(t, received2, "node1", "pong")
+}
+
+func TestRelayPollFailRetry(t *testing.T) {
+	relay, relayDone := newTestRelay(t, &RelayServerConfig{})
+	relayURL := relay.URL()
+	relayDone()
+
+	// The relay is down, so the poller keeps retrying
+	node2, _, done2 := newTestHTTPSTransport(t, "node2", &Config{Relay: relayClientConfig(relayURL)})
+	poller := node2.poller
+	time.Sleep(50 * time.Millisecond)
+	done2()
+	assert.Empty(t, poller.instance)
+}
+
+func TestRelayPollRejectedRetry(t *testing.T) {
+	polls := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		polls <- struct{}{}
+		res.WriteHeader(http.StatusUnauthorized)
+		_, _ = res.Write([]byte(strings.Repeat("!", 300)))
+	}))
+	defer server.Close()
+
+	_, _, done2 := newTestHTTPSTransport(t, "node2", &Config{Relay: relayClientConfig(server.URL)})
+	defer done2()
+
+	<-polls
+	<-polls
+}
+
+func TestSendErrors(t *testing.T) {
+	node1, callbacks1, done1 := newTestHTTPSTransport(t, "node1", &Config{})
+	defer done1()
+	node2, callbacks2, done2 := newTestHTTPSTransport(t, "node2", &Config{})
+
+	transports := map[string]*httpsTransport{"node1": node1, "node2": node2}
+	mockRegistry(callbacks1, transports)
+	mockRegistry(callbacks2, transports)
+	callbacks2.receiveMessage = func(ctx context.Context, rmr *prototk.ReceiveMessageRequest) (*prototk.ReceiveMessageResponse, error) {
+		return nil, fmt.Errorf("pop")
+	}
+
+	// Activating twice replaces the peer
+	testActivatePeer(t, node1, node2)
+	testActivatePeer(t, node1, node2)
+
+	err := testSend(t, node1, "node2", "ping")
+	assert.Regexp(t, "PD090009.*500.*PD090013.*pop", err)
+
+	done2()
+	err = testSend(t, node1, "node2", "ping")
+	assert.Regexp(t, "PD090008", err)
+}
+
+func TestSendRejectedLongResponse(t *testing.T) {
+	node1, _, done1 := newTestHTTPSTransport(t, "node1", &Config{})
+	defer done1()
+
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusBadGateway)
+		_, _ = res.Write([]byte(strings.Repeat("!", 300)))
+	}))
+	defer server.Close()
+
+	_, err := node1.ActivatePeer(context.Background(), &prototk.ActivatePeerRequest{
+		NodeName:         "node2",
+		TransportDetails: pldtypes.JSONString(&PublishedTransportDetails{Endpoint: server.URL + "/", PublicKey: node1.publicKey}).String(),
+	})
+	require.NoError(t, err)
+
+	err = testSend(t, node1, "node2", "ping")
+	assert.Regexp(t, `PD090009.*502.*\.\.\.`, err)
+}
+
+func TestActivatePeerErrors(t *testing.T) {
+	node1, _, done1 := newTestHTTPSTransport(t, "node1", &Config{})
+	defer done1()
+
+	for _, tc := range []struct {
+		details string
+		error   string
+	}{
+		{details: `{"endpoint": false}`, error: "PD090005"},
+		{details: `{"endpoint": "http://localhost"}`, error: "PD090005"},
+		{details: `{"publicKey": "` + node1.publicKey.HexString() + `"}`, error: "PD090006"},
+		{details: `{"endpoint": "wrong://", "publicKey": "` + node1.publicKey.HexString() + `"}`, error: "PD020501"},
+	} {
+		_, err := node1.ActivatePeer(context.Background(), &prototk.ActivatePeerRequest{
+			NodeName:         "node2",
+			TransportDetails: tc.details,
+		})
+		assert.Regexp(t, tc.error, err, tc.details)
+	}
+}
+
+func TestReceiveErrors(t *testing.T) {
+	node1, callbacks1, done1 := newTestHTTPSTransport(t, "node1", &Config{MaxMessageSize: confutil.P("1Kb")})
+	defer done1()
+	node2, callbacks2, done2 := newTestHTTPSTransport(t, "node2", &Config{})
+	defer done2()
+	node3, _, done3 := newTestHTTPSTransport(t, "node3", &Config{})
+	defer done3()
+
+	// node1 believes that node2 has the key of node3
+	mockRegistry(callbacks1, map[string]*httpsTransport{"node2": node3})
+	mockRegistry(callbacks2, map[string]*httpsTransport{"node1": node1, "node2": node2})
+	testActivatePeer(t, node2, node1)
+
+	err := testSend(t, node2, "node1", "ping")
+	assert.Regexp(t, "PD090009.*400.*PD090012", err)
+
+	postURL := fmt.Sprintf("http://%s/messages", node1.server.Addr())
+	for _, tc := range []struct {
+		body   string
+		status int
+		error  string
+	}{
+		{body: `!!!`, status: 400, error: "PD090010"},
+		{body: strings.Repeat(" ", 2048), status: 400, error: "PD090010"},
+		{body: `{"from": "node2", "to": "node3"}`, status: 400, error: "PD090011"},
+		{body: `{"from": "node4", "to": "node1"}`, status: 503, error: "PD090014.*not found"},
+		{body: `{"from": "node2", "to": "node1", "nonce": "AA=="}`, status: 400, error: "PD090012"},
+	} {
+		res, err := resty.New().R().SetBody(tc.body).Post(postURL)
+		require.NoError(t, err)
+		assert.Equal(t, tc.status, res.StatusCode(), tc.body)
+		assert.Regexp(t, tc.error, res.String(), tc.body)
+	}
+}
+
+func TestGetLocalDetails(t *testing.T) {
+	node1, _, done1 := newTestHTTPSTransport(t, "node1", &Config{
+		Server:      pldconf.HTTPServerConfig{Address: confutil.P("127.0.0.1"), Port: confutil.P(0)},
+		ExternalURL: confutil.P("https://node1.example.com"),
+		Relay:       relayClientConfig("https://relay.example.com"),
+	})
+	defer done1()
+
+	ld, err := node1.GetLocalDetails(context.Background(), &prototk.GetLocalDetailsRequest{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"endpoint": "https://node1.example.com",
+		"relay": "https://relay.example.com",
+		"publicKey": "`+node1.publicKey.HexString0xPrefix()+`"
+	}`, ld.TransportDetails)
+}
+
+func TestReconfigure(t *testing.T) {
+	relay, relayDone := newTestRelay(t, &RelayServerConfig{})
+	defer relayDone()
+
+	node1, _, done1 := newTestHTTPSTransport(t, "node1", &Config{
+		Relay: relayClientConfig(relay.URL()),
+	})
+	defer done1()
+	firstPoller := node1.poller
+
+	_, err := node1.ConfigureTransport(node1.bgCtx, &prototk.ConfigureTransportRequest{
+		Name:       "https",
+		ConfigJson: pldtypes.JSONString(&Config{NodeName: confutil.P("node1"), PrivateKey: confutil.P(buildTestKeyPEM(t)), Server: pldconf.HTTPServerConfig{Address: confutil.P("127.0.0.1"), Port: confutil.P(0)}}).String(),
+	})
+	require.NoError(t, err)
+	<-firstPoller.done
+	assert.Nil(t, node1.poller)
+	assert.NotNil(t, node1.server)
+}
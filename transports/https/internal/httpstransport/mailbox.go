@@ -0,0 +1,317 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package httpstransport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/transports/https/internal/msgs"
+)
+
+const (
+	headerPublicKey = "X-Paladin-Public-Key"
+	headerTimestamp = "X-Paladin-Timestamp"
+	headerSignature = "X-Paladin-Signature"
+
+	maxPollBatch = 100
+)
+
+var mailboxIDRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// The mailboxes are a store-and-forward relay for nodes that cannot accept inbound connections.
+// Anyone can post an envelope into a mailbox (the recipient authenticates the sender by decrypting it),
+// but only the holder of the private key for a mailbox can poll and acknowledge messages.
+//
+// Storage is in-memory, so messages still in a mailbox are lost if the relay restarts. Each poll
+// response includes the instance ID of the relay, which the poller uses to reset its cursor,
+// and the reliable delivery in the Paladin transport manager will redeliver the lost messages.
+//
+// As anyone can post, the number of mailboxes and the total size of the messages across all of them
+// are capped. Mailboxes are removed as soon as they are empty and nobody is polling them, and expired
+// messages are swept from all mailboxes before a post is rejected for reaching one of the caps.
+// Sequences are allocated across all mailboxes, so they never go backwards for a removed mailbox.
+type mailboxes struct {
+	ctx            context.Context
+	instance       string
+	maxQueueDepth  int
+	maxMailboxes   int
+	maxTotalSize   int64
+	messageTTL     time.Duration
+	maxPollTimeout time.Duration
+	maxClockSkew   time.Duration
+	maxMessageSize int64
+
+	lock      sync.Mutex
+	boxes     map[string]*mailbox
+	lastSeq   uint64
+	totalSize int64
+}
+
+type mailbox struct {
+	messages []*mailboxMessage
+	changed  chan struct{}
+	pollers  int
+}
+
+type mailboxMessage struct {
+	Sequence uint64          `json:"sequence"`
+	Envelope json.RawMessage `json:"envelope"`
+	received time.Time
+}
+
+type mailboxPollResponse struct {
+	Instance string            `json:"instance"`
+	Messages []*mailboxMessage `json:"messages"`
+}
+
+func newMailboxes(ctx context.Context, conf *MailboxConfig, maxMessageSize int64) *mailboxes {
+	return &mailboxes{
+		ctx:            ctx,
+		instance:       uuid.NewString(),
+		maxQueueDepth:  confutil.IntMin(conf.MaxQueueDepth, 1, *MailboxDefaults.MaxQueueDepth),
+		maxMailboxes:   confutil.IntMin(conf.MaxMailboxes, 1, *MailboxDefaults.MaxMailboxes),
+		maxTotalSize:   confutil.ByteSize(conf.MaxTotalSize, 1024, *MailboxDefaults.MaxTotalSize),
+		messageTTL:     confutil.DurationMin(conf.MessageTTL, 0, *MailboxDefaults.MessageTTL),
+		maxPollTimeout: confutil.DurationMin(conf.MaxPollTimeout, 0, *MailboxDefaults.MaxPollTimeout),
+		maxClockSkew:   confutil.DurationMin(conf.MaxClockSkew, 0, *MailboxDefaults.MaxClockSkew),
+		maxMessageSize: maxMessageSize,
+		boxes:          make(map[string]*mailbox),
+	}
+}
+
+func (mb *mailboxes) register(mux *http.ServeMux) {
+	mux.HandleFunc("POST /mailbox/{id}", mb.handlePost)
+	mux.HandleFunc("GET /mailbox/{id}", mb.handlePoll)
+}
+
+func pollSigningHash(id string, after uint64, timestamp int64) []byte {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("GET /mailbox/%s?after=%d\n%d", id, after, timestamp)))
+	return hash[:]
+}
+
+func newMailbox() *mailbox {
+	return &mailbox{changed: make(chan struct{})}
+}
+
+// must be called with the lock held
+func (mb *mailboxes) trim(box *mailbox, after uint64, expiry time.Time) {
+	i := 0
+	for i < len(box.messages) && (box.messages[i].Sequence <= after || box.messages[i].received.Before(expiry)) {
+		mb.totalSize -= int64(len(box.messages[i].Envelope))
+		i++
+	}
+	box.messages = box.messages[i:]
+}
+
+// must be called with the lock held
+func (mb *mailboxes) sweep(expiry time.Time) {
+	for id, box := range mb.boxes {
+		mb.trim(box, 0, expiry)
+		if len(box.messages) == 0 && box.pollers == 0 {
+			delete(mb.boxes, id)
+		}
+	}
+}
+
+func (mb *mailboxes) deliver(ctx context.Context, id string, data []byte) error {
+	mb.lock.Lock()
+	defer mb.lock.Unlock()
+
+	now := time.Now()
+	expiry := now.Add(-mb.messageTTL)
+	if box := mb.boxes[id]; box != nil {
+		mb.trim(box, 0, expiry)
+		if len(box.messages) >= mb.maxQueueDepth {
+			return i18n.NewError(ctx, msgs.MsgMailboxFull, id)
+		}
+	}
+	if mb.totalSize+int64(len(data)) > mb.maxTotalSize {
+		mb.sweep(expiry)
+		if mb.totalSize+int64(len(data)) > mb.maxTotalSize {
+			return i18n.NewError(ctx, msgs.MsgRelayStorageLimit, mb.maxTotalSize)
+		}
+	}
+	box := mb.boxes[id]
+	if box == nil {
+		if len(mb.boxes) >= mb.maxMailboxes {
+			mb.sweep(expiry)
+			if len(mb.boxes) >= mb.maxMailboxes {
+				return i18n.NewError(ctx, msgs.MsgRelayMailboxLimit, mb.maxMailboxes)
+			}
+		}
+		box = newMailbox()
+		mb.boxes[id] = box
+	}
+	mb.lastSeq++
+	box.messages = append(box.messages, &mailboxMessage{
+		Sequence: mb.lastSeq,
+		Envelope: data,
+		received: now,
+	})
+	mb.totalSize += int64(len(data))
+	log.L(ctx).Debugf("mailbox %s stored message seq=%d depth=%d", id, mb.lastSeq, len(box.messages))
+
+	// wake any poller
+	close(box.changed)
+	box.changed = make(chan struct{})
+	return nil
+}
+
+func (mb *mailboxes) poll(ctx context.Context, id string, after uint64, timeout time.Duration) []*mailboxMessage {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	// The mailbox cannot be removed while we are polling it, and is removed when we finish if it is empty
+	mb.lock.Lock()
+	box := mb.boxes[id]
+	if box == nil {
+		box = newMailbox()
+		mb.boxes[id] = box
+	}
+	box.pollers++
+	mb.lock.Unlock()
+	defer func() {
+		mb.lock.Lock()
+		defer mb.lock.Unlock()
+		box.pollers--
+		if len(box.messages) == 0 && box.pollers == 0 {
+			delete(mb.boxes, id)
+		}
+	}()
+
+	for {
+		mb.lock.Lock()
+		mb.trim(box, after, time.Now().Add(-mb.messageTTL))
+		messages := box.messages
+		if len(messages) > maxPollBatch {
+			messages = messages[0:maxPollBatch]
+		}
+		changed := box.changed
+		mb.lock.Unlock()
+
+		if len(messages) > 0 {
+			return messages
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return messages
+		case <-ctx.Done():
+			return messages
+		case <-mb.ctx.Done():
+			return messages
+		}
+	}
+}
+
+func (mb *mailboxes) authenticate(ctx context.Context, req *http.Request, id string, after uint64) error {
+	publicKeyBytes, err := hex.DecodeString(req.Header.Get(headerPublicKey))
+	var publicKey *ecdsa.PublicKey
+	ok := false
+	if err == nil {
+		publicKey, ok = parsePublicKey(publicKeyBytes)
+	}
+	if !ok || mailboxID(publicKeyBytes) != id {
+		return i18n.NewError(ctx, msgs.MsgMailboxAuthFailed)
+	}
+
+	timestamp, err := strconv.ParseInt(req.Header.Get(headerTimestamp), 10, 64)
+	if err != nil {
+		return i18n.NewError(ctx, msgs.MsgMailboxAuthFailed)
+	}
+	skew := time.Since(time.Unix(timestamp, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > mb.maxClockSkew {
+		return i18n.NewError(ctx, msgs.MsgMailboxRequestExpired, timestamp, mb.maxClockSkew)
+	}
+
+	signature, err := hex.DecodeString(req.Header.Get(headerSignature))
+	if err != nil || !ecdsa.VerifyASN1(publicKey, pollSigningHash(id, after, timestamp), signature) {
+		return i18n.NewError(ctx, msgs.MsgMailboxAuthFailed)
+	}
+	return nil
+}
+
+func (mb *mailboxes) handlePost(res http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	id := req.PathValue("id")
+	if !mailboxIDRegex.MatchString(id) {
+		writeError(ctx, res, i18n.NewError(ctx, msgs.MsgMailboxInvalidID, id))
+		return
+	}
+	data, err := readBody(ctx, res, req, mb.maxMessageSize)
+	if err == nil && !json.Valid(data) {
+		err = i18n.NewError(ctx, msgs.MsgEnvelopeInvalid)
+	}
+	if err == nil {
+		err = mb.deliver(ctx, id, data)
+	}
+	if err != nil {
+		writeError(ctx, res, err)
+		return
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
+
+func (mb *mailboxes) handlePoll(res http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	id := req.PathValue("id")
+	query := req.URL.Query()
+
+	var after uint64
+	var err error
+	if afterStr := query.Get("after"); afterStr != "" {
+		if after, err = strconv.ParseUint(afterStr, 10, 64); err != nil {
+			writeError(ctx, res, i18n.NewError(ctx, msgs.MsgMailboxInvalidCursor, afterStr))
+			return
+		}
+	}
+	timeout := confutil.DurationMin(confutil.P(query.Get("timeout")), 0, "0")
+	if timeout > mb.maxPollTimeout {
+		timeout = mb.maxPollTimeout
+	}
+
+	if err := mb.authenticate(ctx, req, id, after); err != nil {
+		writeError(ctx, res, err)
+		return
+	}
+
+	// A cursor from a previous instance of the relay is meaningless, as the sequences restart
+	if query.Get("instance") != mb.instance {
+		after = 0
+	}
+
+	writeJSON(res, http.StatusOK, &mailboxPollResponse{
+		Instance: mb.instance,
+		Messages: mb.poll(ctx, id, after, timeout),
+	})
+}
@@ -0,0 +1,351 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package httpstransport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMailboxKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key, mailboxID(marshalPublicKey(&key.PublicKey))
+}
+
+func signedPoll(t *testing.T, key *ecdsa.PrivateKey, id string, after uint64, timestamp int64) *resty.Request {
+	signature, err := ecdsa.SignASN1(rand.Reader, key, pollSigningHash(id, after, timestamp))
+	require.NoError(t, err)
+	return resty.New().R().
+		SetHeader(headerPublicKey, hex.EncodeToString(marshalPublicKey(&key.PublicKey))).
+		SetHeader(headerTimestamp, strconv.FormatInt(timestamp, 10)).
+		SetHeader(headerSignature, hex.EncodeToString(signature)).
+		SetQueryParam("after", strconv.FormatUint(after, 10))
+}
+
+func testPost(t *testing.T, url, body string) *resty.Response {
+	res, err := resty.New().R().SetBody(body).Post(url)
+	require.NoError(t, err)
+	return res
+}
+
+func TestNewRelayMissingPort(t *testing.T) {
+	_, err := NewRelay(context.Background(), &RelayServerConfig{})
+	assert.Regexp(t, "PD020601", err)
+}
+
+func TestMailboxPostErrors(t *testing.T) {
+	relay, done := newTestRelay(t, &RelayServerConfig{
+		MaxMessageSize: confutil.P("1Kb"),
+		Mailbox: MailboxConfig{
+			MaxQueueDepth: confutil.P(1),
+		},
+	})
+	defer done()
+	_, id := newTestMailboxKey(t)
+	mailboxURL := relay.URL() + "/mailbox/" + id
+
+	res := testPost(t, relay.URL()+"/mailbox/wrong", `{}`)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+	assert.Regexp(t, "PD090100", res.String())
+
+	res = testPost(t, mailboxURL, `!!!`)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+	assert.Regexp(t, "PD090010", res.String())
+
+	res = testPost(t, mailboxURL, `"`+strings.Repeat("!", 2048)+`"`)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+	assert.Regexp(t, "PD090010", res.String())
+
+	res = testPost(t, mailboxURL, `{}`)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode())
+
+	res = testPost(t, mailboxURL, `{}`)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode())
+	assert.Regexp(t, "PD090101", res.String())
+}
+
+func TestMailboxRelayLimits(t *testing.T) {
+	relay, done := newTestRelay(t, &RelayServerConfig{
+		Mailbox: MailboxConfig{
+			MaxMailboxes: confutil.P(1),
+			MaxTotalSize: confutil.P("1Kb"),
+		},
+	})
+	defer done()
+	key1, id1 := newTestMailboxKey(t)
+	mailbox1URL := relay.URL() + "/mailbox/" + id1
+	_, id2 := newTestMailboxKey(t)
+	mailbox2URL := relay.URL() + "/mailbox/" + id2
+
+	largeMsg := `"` + strings.Repeat("a", 600) + `"`
+	res := testPost(t, mailbox1URL, largeMsg)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode())
+
+	// The total size is limited across all mailboxes
+	res = testPost(t, mailbox1URL, largeMsg)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode())
+	assert.Regexp(t, "PD090108", res.String())
+
+	// As is the number of mailboxes
+	res = testPost(t, mailbox2URL, `{}`)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode())
+	assert.Regexp(t, "PD090107", res.String())
+
+	// Acknowledge the message, which removes the empty mailbox at the end of the poll
+	var pollRes mailboxPollResponse
+	_, err := signedPoll(t, key1, id1, 0, time.Now().Unix()).SetResult(&pollRes).Get(mailbox1URL)
+	require.NoError(t, err)
+	require.Len(t, pollRes.Messages, 1)
+	_, err = signedPoll(t, key1, id1, pollRes.Messages[0].Sequence, time.Now().Unix()).
+		SetQueryParam("instance", pollRes.Instance).SetResult(&pollRes).Get(mailbox1URL)
+	require.NoError(t, err)
+	require.Empty(t, pollRes.Messages)
+
+	res = testPost(t, mailbox2URL, largeMsg)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode())
+}
+
+func TestMailboxSweepsExpiredBeforeLimits(t *testing.T) {
+	testRelay, done := newTestRelay(t, &RelayServerConfig{
+		Mailbox: MailboxConfig{
+			MaxMailboxes: confutil.P(1),
+			MaxTotalSize: confutil.P("1Kb"),
+			MessageTTL:   confutil.P("1ns"),
+		},
+	})
+	defer done()
+	key1, id1 := newTestMailboxKey(t)
+	mailbox1URL := testRelay.URL() + "/mailbox/" + id1
+	_, id2 := newTestMailboxKey(t)
+
+	largeMsg := `"` + strings.Repeat("a", 600) + `"`
+	res := testPost(t, mailbox1URL, largeMsg)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode())
+
+	// The expired message in the first mailbox is swept, and the empty mailbox removed
+	res = testPost(t, testRelay.URL()+"/mailbox/"+id2, largeMsg)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode())
+
+	// Sequences do not restart for a removed mailbox
+	var pollRes mailboxPollResponse
+	_, err := signedPoll(t, key1, id1, 0, time.Now().Unix()).SetResult(&pollRes).Get(mailbox1URL)
+	require.NoError(t, err)
+	require.Empty(t, pollRes.Messages)
+	res = testPost(t, mailbox1URL, `{}`)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode())
+	r := testRelay.(*relay)
+	r.mailboxes.lock.Lock()
+	defer r.mailboxes.lock.Unlock()
+	assert.Equal(t, uint64(3), r.mailboxes.lastSeq)
+	assert.Len(t, r.mailboxes.boxes, 1)
+}
+
+func TestMailboxExpiry(t *testing.T) {
+	relay, done := newTestRelay(t, &RelayServerConfig{
+		Mailbox: MailboxConfig{
+			MaxQueueDepth: confutil.P(1),
+			MessageTTL:    confutil.P("1ns"),
+		},
+	})
+	defer done()
+	key, id := newTestMailboxKey(t)
+	mailboxURL := relay.URL() + "/mailbox/" + id
+
+	// The mailbox is never full, as messages expire before the next arrives
+	for i := 0; i < 2; i++ {
+		res := testPost(t, mailboxURL, `{}`)
+		assert.Equal(t, http.StatusNoContent, res.StatusCode())
+	}
+
+	var pollRes mailboxPollResponse
+	res, err := signedPoll(t, key, id, 0, time.Now().Unix()).SetResult(&pollRes).Get(mailboxURL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode())
+	assert.Empty(t, pollRes.Messages)
+}
+
+func TestMailboxPollBatchAndAck(t *testing.T) {
+	relay, done := newTestRelay(t, &RelayServerConfig{})
+	defer done()
+	key, id := newTestMailboxKey(t)
+	mailboxURL := relay.URL() + "/mailbox/" + id
+
+	for i := 0; i < maxPollBatch+1; i++ {
+		res := testPost(t, mailboxURL, fmt.Sprintf(`{"i":%d}`, i))
+		require.Equal(t, http.StatusNoContent, res.StatusCode())
+	}
+
+	var pollRes mailboxPollResponse
+	_, err := signedPoll(t, key, id, 0, time.Now().Unix()).SetResult(&pollRes).Get(mailboxURL)
+	require.NoError(t, err)
+	require.Len(t, pollRes.Messages, maxPollBatch)
+	assert.Equal(t, uint64(1), pollRes.Messages[0].Sequence)
+	assert.JSONEq(t, `{"i":0}`, string(pollRes.Messages[0].Envelope))
+	instance := pollRes.Instance
+
+	// A cursor without the right instance does not acknowledge anything
+	_, err = signedPoll(t, key, id, maxPollBatch, time.Now().Unix()).SetResult(&pollRes).Get(mailboxURL)
+	require.NoError(t, err)
+	require.Len(t, pollRes.Messages, maxPollBatch)
+	assert.Equal(t, uint64(1), pollRes.Messages[0].Sequence)
+
+	_, err = signedPoll(t, key, id, maxPollBatch, time.Now().Unix()).SetQueryParam("instance", instance).SetResult(&pollRes).Get(mailboxURL)
+	require.NoError(t, err)
+	require.Len(t, pollRes.Messages, 1)
+	assert.Equal(t, uint64(maxPollBatch+1), pollRes.Messages[0].Sequence)
+}
+
+func TestMailboxPollTimeoutCapped(t *testing.T) {
+	relay, done := newTestRelay(t, &RelayServerConfig{
+		Mailbox: MailboxConfig{
+			MaxPollTimeout: confutil.P("10ms"),
+		},
+	})
+	defer done()
+	key, id := newTestMailboxKey(t)
+
+	var pollRes mailboxPollResponse
+	res, err := signedPoll(t, key, id, 0, time.Now().Unix()).
+		SetQueryParam("timeout", "1h").
+		SetResult(&pollRes).
+		Get(relay.URL() + "/mailbox/" + id)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode())
+	assert.Empty(t, pollRes.Messages)
+}
+
+func TestMailboxPollEndsOnClientCancel(t *testing.T) {
+	mb := newMailboxes(context.Background(), &MailboxConfig{}, 1024)
+	_, id := newTestMailboxKey(t)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+	messages := mb.poll(ctx, id, 0, time.Minute)
+	assert.Empty(t, messages)
+}
+
+func TestMailboxPollEndsOnRelayStop(t *testing.T) {
+	testRelay, done := newTestRelay(t, &RelayServerConfig{})
+	key, id := newTestMailboxKey(t)
+
+	polled := make(chan *resty.Response)
+	go func() {
+		res, _ := signedPoll(t, key, id, 0, time.Now().Unix()).
+			SetQueryParam("timeout", "1m").
+			Get(testRelay.URL() + "/mailbox/" + id)
+		polled <- res
+	}()
+	require.Eventually(t, func() bool {
+		mb := testRelay.(*relay).mailboxes
+		mb.lock.Lock()
+		defer mb.lock.Unlock()
+		return mb.boxes[id] != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	done()
+	<-polled
+}
+
+func TestMailboxPollAuthErrors(t *testing.T) {
+	relay, done := newTestRelay(t, &RelayServerConfig{})
+	defer done()
+	key, id := newTestMailboxKey(t)
+	_, otherID := newTestMailboxKey(t)
+	mailboxURL := relay.URL() + "/mailbox/" + id
+	now := time.Now().Unix()
+
+	for _, tc := range []struct {
+		req    *resty.Request
+		url    string
+		status int
+		error  string
+	}{
+		{req: signedPoll(t, key, id, 0, now).SetQueryParam("after", "wrong"), url: mailboxURL, status: 400, error: "PD090104"},
+		{req: signedPoll(t, key, id, 0, now).SetHeader(headerPublicKey, "!!!"), url: mailboxURL, status: 401, error: "PD090102"},
+		{req: signedPoll(t, key, id, 0, now).SetHeader(headerPublicKey, "abcd"), url: mailboxURL, status: 401, error: "PD090102"},
+		{req: signedPoll(t, key, otherID, 0, now), url: relay.URL() + "/mailbox/" + otherID, status: 401, error: "PD090102"},
+		{req: signedPoll(t, key, id, 0, now).SetHeader(headerTimestamp, "wrong"), url: mailboxURL, status: 401, error: "PD090102"},
+		{req: signedPoll(t, key, id, 0, now-3600), url: mailboxURL, status: 401, error: "PD090103"},
+		{req: signedPoll(t, key, id, 0, now+3600), url: mailboxURL, status: 401, error: "PD090103"},
+		{req: signedPoll(t, key, id, 0, now).SetHeader(headerSignature, "!!!"), url: mailboxURL, status: 401, error: "PD090102"},
+		{req: signedPoll(t, key, id, 1, now).SetQueryParam("after", "0"), url: mailboxURL, status: 401, error: "PD090102"},
+	} {
+		res, err := tc.req.Get(tc.url)
+		require.NoError(t, err)
+		assert.Equal(t, tc.status, res.StatusCode())
+		assert.Regexp(t, tc.error, res.String())
+	}
+}
+
+func TestRelayOverTLS(t *testing.T) {
+	certPEM, keyPEM := buildTestCertificate(t)
+	relay, relayDone := newTestRelay(t, &RelayServerConfig{
+		Server: pldconf.HTTPServerConfig{
+			TLS: pldconf.TLSConfig{Enabled: true, Cert: certPEM, Key: keyPEM},
+		},
+	})
+	defer relayDone()
+	assert.True(t, strings.HasPrefix(relay.URL(), "https://"))
+
+	clientTLS := pldconf.TLSConfig{CA: certPEM}
+	node1, callbacks1, done1 := newTestHTTPSTransport(t, "node1", &Config{
+		Server: pldconf.HTTPServerConfig{
+			Address: confutil.P("127.0.0.1"),
+			Port:    confutil.P(0),
+			TLS:     pldconf.TLSConfig{Enabled: true, Cert: certPEM, Key: keyPEM},
+		},
+		Client: pldconf.HTTPClientConfig{TLS: clientTLS},
+	})
+	defer done1()
+	node2, callbacks2, done2 := newTestHTTPSTransport(t, "node2", &Config{
+		Client: pldconf.HTTPClientConfig{TLS: clientTLS},
+		Relay: RelayConfig{
+			HTTPClientConfig: pldconf.HTTPClientConfig{URL: relay.URL(), TLS: clientTLS},
+		},
+	})
+	defer done2()
+
+	transports := map[string]*httpsTransport{"node1": node1, "node2": node2}
+	mockRegistry(callbacks1, transports)
+	mockRegistry(callbacks2, transports)
+	received1 := captureReceived(callbacks1)
+	received2 := captureReceived(callbacks2)
+
+	peerInfo := testActivatePeer(t, node2, node1)
+	assert.True(t, strings.HasPrefix(peerInfo.URL, "https://"))
+	testActivatePeer(t, node1, node2)
+
+	require.NoError(t, testSend(t, node1, "node2", "ping"))
+	testReceive(t, received2, "node1", "ping")
+	require.NoError(t, testSend(t, node2, "node1", "pong"))
+	testReceive(t, received1, "node2", "pong")
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package httpstransport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldresty"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/transports/https/internal/msgs"
+)
+
+type peer struct {
+	nodeName  string
+	publicKey *ecdsa.PublicKey
+	info      PeerInfo
+	client    *resty.Client
+}
+
+func (t *httpsTransport) newPeer(ctx context.Context, nodeName string, transportDetailsJSON string) (*peer, error) {
+	transportDetails, publicKey, err := t.parseTransportDetails(ctx, nodeName, transportDetailsJSON)
+	if err != nil {
+		return nil, err
+	}
+	p := &peer{
+		nodeName:  nodeName,
+		publicKey: publicKey,
+	}
+
+	// We prefer direct delivery where the node accepts it, and otherwise post to its mailbox on a relay
+	switch {
+	case transportDetails.Endpoint != "":
+		p.info.URL = strings.TrimSuffix(transportDetails.Endpoint, "/") + "/messages"
+	case transportDetails.Relay != "":
+		p.info.URL = strings.TrimSuffix(transportDetails.Relay, "/") + "/mailbox/" + mailboxID(transportDetails.PublicKey)
+		p.info.Relayed = true
+	default:
+		return nil, i18n.NewError(ctx, msgs.MsgPeerNotReachable, nodeName)
+	}
+
+	clientConf := t.conf.Client
+	clientConf.URL = p.info.URL
+	if p.client, err = pldresty.New(ctx, &clientConf); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *peer) send(ctx context.Context, localKey *ecdsa.PrivateKey, localNode string, msg *prototk.PaladinMsg) error {
+	env, err := sealEnvelope(localKey, p.publicKey, localNode, p.nodeName, msg)
+	if err != nil {
+		return err
+	}
+	res, err := p.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(env).
+		Post("")
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgDeliveryFailed, msg.MessageId, p.nodeName)
+	}
+	if !res.IsSuccess() {
+		resBody := res.String()
+		if len(resBody) > 256 {
+			resBody = resBody[0:256] + "..."
+		}
+		return i18n.NewError(ctx, msgs.MsgDeliveryRejected, msg.MessageId, p.nodeName, res.StatusCode(), resBody)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package httpstransport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/toolkit/pkg/httpserver"
+)
+
+// RelayServerConfig configures a standalone relay, which serves mailboxes without being part of a Paladin node
+type RelayServerConfig struct {
+	Server         pldconf.HTTPServerConfig `json:"server"`
+	Mailbox        MailboxConfig            `json:"mailbox"`
+	MaxMessageSize *string                  `json:"maxMessageSize,omitempty"`
+}
+
+type Relay interface {
+	Start() error
+	Stop()
+	URL() string
+}
+
+type relay struct {
+	cancelCtx context.CancelFunc
+	tls       bool
+	mailboxes *mailboxes
+	server    httpserver.Server
+}
+
+// NewRelay creates an in-process store-and-forward relay, with the same mailbox implementation
+// that a node runs when mailbox.enabled is set on its transport
+func NewRelay(ctx context.Context, conf *RelayServerConfig) (_ Relay, err error) {
+	r := &relay{tls: conf.Server.TLS.Enabled}
+	ctx, r.cancelCtx = context.WithCancel(ctx)
+	r.mailboxes = newMailboxes(ctx, &conf.Mailbox, confutil.ByteSize(conf.MaxMessageSize, 1024, *ConfigDefaults.MaxMessageSize))
+	mux := http.NewServeMux()
+	r.mailboxes.register(mux)
+	if r.server, err = httpserver.NewServer(ctx, "HTTPS transport relay", &conf.Server, mux); err != nil {
+		r.cancelCtx()
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *relay) Start() error {
+	return r.server.Start()
+}
+
+func (r *relay) Stop() {
+	r.cancelCtx()
+	r.server.Stop()
+}
+
+func (r *relay) URL() string {
+	scheme := "http"
+	if r.tls {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, r.server.Addr())
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package httpstransport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldresty"
+	"github.com/kaleido-io/paladin/transports/https/internal/msgs"
+)
+
+// The relay poller long-polls the mailbox for this node on a relay, for nodes that
+// cannot accept inbound connections from their peers.
+type relayPoller struct {
+	t                  *httpsTransport
+	client             *resty.Client
+	mailbox            string
+	timeout            time.Duration
+	retryDelay         time.Duration
+	lookupRetryTimeout time.Duration
+	instance           string
+	after              uint64
+	lookupRetrySeq     uint64
+	lookupRetryStart   time.Time
+	done               chan struct{}
+}
+
+func (t *httpsTransport) newRelayPoller(ctx context.Context) (_ *relayPoller, err error) {
+	rp := &relayPoller{
+		t:                  t,
+		mailbox:            mailboxID(t.publicKey),
+		timeout:            confutil.DurationMin(t.conf.Relay.PollTimeout, 0, *ConfigDefaults.Relay.PollTimeout),
+		retryDelay:         confutil.DurationMin(t.conf.Relay.RetryDelay, 0, *ConfigDefaults.Relay.RetryDelay),
+		lookupRetryTimeout: confutil.DurationMin(t.conf.Relay.LookupRetryTimeout, 0, *ConfigDefaults.Relay.LookupRetryTimeout),
+		done:               make(chan struct{}),
+	}
+
+	// The HTTP request timeout is in addition to the time the relay holds the poll open
+	clientConf := t.conf.Relay.HTTPClientConfig
+	requestTimeout := confutil.DurationMin(clientConf.RequestTimeout, 0, *pldconf.DefaultHTTPConfig.RequestTimeout)
+	clientConf.RequestTimeout = confutil.P((requestTimeout + rp.timeout).String())
+	if rp.client, err = pldresty.New(ctx, &clientConf); err != nil {
+		return nil, err
+	}
+	return rp, nil
+}
+
+func (rp *relayPoller) run(ctx context.Context) {
+	defer close(rp.done)
+
+	log.L(ctx).Infof("HTTPS transport polling mailbox %s on relay %s", rp.mailbox, rp.t.conf.Relay.URL)
+	for {
+		err := rp.poll(ctx)
+		if ctx.Err() != nil {
+			log.L(ctx).Infof("HTTPS transport relay poller stopped")
+			return
+		}
+		if err != nil {
+			log.L(ctx).Errorf("HTTPS transport relay poll failed (retrying in %s): %s", rp.retryDelay, err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(rp.retryDelay):
+			}
+		}
+	}
+}
+
+func (rp *relayPoller) poll(ctx context.Context) error {
+	timestamp := time.Now().Unix()
+	signature, err := ecdsa.SignASN1(rand.Reader, rp.t.privateKey, pollSigningHash(rp.mailbox, rp.after, timestamp))
+	if err != nil {
+		return err
+	}
+
+	var pollRes mailboxPollResponse
+	res, err := rp.client.R().
+		SetContext(ctx).
+		SetHeader(headerPublicKey, hex.EncodeToString(rp.t.publicKey)).
+		SetHeader(headerTimestamp, strconv.FormatInt(timestamp, 10)).
+		SetHeader(headerSignature, hex.EncodeToString(signature)).
+		SetQueryParam("after", strconv.FormatUint(rp.after, 10)).
+		SetQueryParam("instance", rp.instance).
+		SetQueryParam("timeout", rp.timeout.String()).
+		SetResult(&pollRes).
+		Get("/mailbox/" + rp.mailbox)
+	if err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgRelayPollFailed)
+	}
+	if !res.IsSuccess() {
+		resBody := res.String()
+		if len(resBody) > 256 {
+			resBody = resBody[0:256] + "..."
+		}
+		return i18n.NewError(ctx, msgs.MsgRelayPollRejected, res.StatusCode(), resBody)
+	}
+
+	if pollRes.Instance != rp.instance {
+		// The relay has restarted (or this is our first poll) so the sequences have restarted
+		log.L(ctx).Infof("HTTPS transport connected to relay instance %s", pollRes.Instance)
+		rp.instance = pollRes.Instance
+		rp.after = 0
+	}
+
+	for _, m := range pollRes.Messages {
+		fromNode, msg, err := rp.t.openReceived(ctx, m.Envelope)
+		if lookupErr, ok := err.(*peerLookupError); ok && !rp.lookupRetryExpired(m.Sequence) {
+			// We do not acknowledge the message, so it will be redelivered on the next poll
+			return lookupErr
+		}
+		if err != nil {
+			// The message can never be delivered, so we acknowledge it to remove it from the mailbox
+			log.L(ctx).Errorf("Discarding undeliverable message seq=%d from relay: %s", m.Sequence, err)
+		} else if err := rp.t.receive(ctx, fromNode, msg); err != nil {
+			// We do not acknowledge the message, so it will be redelivered on the next poll
+			return err
+		}
+		rp.after = m.Sequence
+	}
+	return nil
+}
+
+// A message from a node that cannot be looked up blocks the mailbox, as the cursor cannot move past it.
+// So we only retry for a limited time, in case the sender is not a node that will ever be in the registry.
+func (rp *relayPoller) lookupRetryExpired(seq uint64) bool {
+	if rp.lookupRetrySeq != seq {
+		rp.lookupRetrySeq = seq
+		rp.lookupRetryStart = time.Now()
+	}
+	return time.Since(rp.lookupRetryStart) >= rp.lookupRetryTimeout
+}
@@ -0,0 +1,62 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgs
+
+import (
+	"sync"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"golang.org/x/text/language"
+)
+
+var registered sync.Once
+var pde = func(key, translation string, statusHint ...int) i18n.ErrorMessageKey {
+	registered.Do(func() {
+		i18n.RegisterPrefix("PD09", "Paladin HTTPS Transport")
+	})
+	return i18n.PDE(language.AmericanEnglish, key, translation, statusHint...)
+}
+
+var (
+	// Generic PD0900XX
+	MsgInvalidTransportConfig      = pde("PD090000", "Invalid transport configuration")
+	MsgNodeNameRequired            = pde("PD090001", "nodeName is required, and must match the name of the local node in the registry")
+	MsgServerOrRelayRequired       = pde("PD090002", "a server port for direct delivery, or a relay URL for mailbox delivery, is required")
+	MsgPrivateKeyRequired          = pde("PD090003", "privateKey or privateKeyFile must be configured with a PEM encoded P-256 private key")
+	MsgPrivateKeyInvalid           = pde("PD090004", "the configured private key is not a valid PEM encoded P-256 private key")
+	MsgPeerTransportDetailsInvalid = pde("PD090005", "published peer transport details for node '%s' are invalid")
+	MsgPeerNotReachable            = pde("PD090006", "published transport details for node '%s' contain neither an endpoint nor a relay")
+	MsgNodeNotActive               = pde("PD090007", "Send for node that is not active '%s'")
+	MsgDeliveryFailed              = pde("PD090008", "HTTPS delivery of message %s to node '%s' failed")
+	MsgDeliveryRejected            = pde("PD090009", "HTTPS delivery of message %s to node '%s' rejected [%d]: %s")
+	MsgEnvelopeInvalid             = pde("PD090010", "Invalid message envelope", 400)
+	MsgEnvelopeWrongRecipient      = pde("PD090011", "Message envelope from '%s' is addressed to '%s'", 400)
+	MsgEnvelopeDecryptFailed       = pde("PD090012", "Failed to decrypt message envelope from node '%s'", 400)
+	MsgReceiveFailed               = pde("PD090013", "Failed to deliver received message from node '%s' to Paladin", 500)
+	MsgPeerLookupFailed            = pde("PD090014", "Failed to look up the transport details of node '%s'", 503)
+
+	// Relay PD0901XX
+	MsgMailboxInvalidID      = pde("PD090100", "Invalid mailbox identifier '%s'", 400)
+	MsgMailboxFull           = pde("PD090101", "Mailbox '%s' is full", 429)
+	MsgMailboxAuthFailed     = pde("PD090102", "Mailbox request authentication failed", 401)
+	MsgMailboxRequestExpired = pde("PD090103", "Mailbox request timestamp %d is outside the permitted clock skew of %s", 401)
+	MsgMailboxInvalidCursor  = pde("PD090104", "Invalid mailbox cursor '%s'", 400)
+	MsgRelayPollFailed       = pde("PD090105", "Poll of relay mailbox failed")
+	MsgRelayPollRejected     = pde("PD090106", "Poll of relay mailbox rejected [%d]: %s")
+	MsgRelayMailboxLimit     = pde("PD090107", "Relay is holding the maximum number of mailboxes (%d)", 429)
+	MsgRelayStorageLimit     = pde("PD090108", "Relay is holding the maximum total size of messages (%d bytes)", 429)
+)
@@ -0,0 +1,38 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package https
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/transports/https/internal/httpstransport"
+)
+
+// allow this plugin to be loaded by component tests in other packages
+func NewPlugin(ctx context.Context) plugintk.PluginBase {
+	return httpstransport.NewPlugin(ctx)
+}
+
+// allow component tests in other packages to run an in-process relay, for nodes configured with relay.url
+func NewRelay(ctx context.Context, conf *RelayServerConfig) (Relay, error) {
+	return httpstransport.NewRelay(ctx, (*httpstransport.RelayServerConfig)(conf))
+}
+
+type Config httpstransport.Config
+type PublishedTransportDetails httpstransport.PublishedTransportDetails
+type RelayServerConfig httpstransport.RelayServerConfig
+type Relay = httpstransport.Relay